@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/backend-interview-task/config"
+)
+
+// newConfigCommand builds the config command, which inspects the configuration the process loads
+func newConfigCommand(c *cli) *cobra.Command {
+	configCommand := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the effective configuration",
+	}
+	configCommand.AddCommand(&cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration as YAML, with secrets masked",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printConfig(c.cfg, os.Stdout)
+		},
+	})
+	return configCommand
+}
+
+// printConfig writes cfg, once resolved from the files, environment and secret stores, to out
+// with its secrets masked
+func printConfig(cfg *config.Config, out io.Writer) error {
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg.Redacted().Settings()); err != nil {
+		return fmt.Errorf("failed to print the configuration: %w", err)
+	}
+	return encoder.Close()
+}
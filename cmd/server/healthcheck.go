@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/backend-interview-task/config"
+)
+
+// newHealthcheckCommand builds the healthcheck command, which asks the gRPC health service of the
+// server running next to it whether it is serving and fails when it is not. It stands in for
+// grpc_health_probe in container HEALTHCHECKs and exec probes of images that do not ship it.
+func newHealthcheckCommand(c *cli) *cobra.Command {
+	var (
+		address string
+		service string
+		timeout time.Duration
+	)
+	command := &cobra.Command{
+		Use:   "healthcheck",
+		Short: "Exit 0 when the local server is serving, 1 otherwise",
+		Args:  cobra.NoArgs,
+		// Probes run every few seconds: only the config is loaded, without reading the
+		// credentials from AWS or Vault, which the check does not need
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			c.cfg, c.logger = cfg, zap.NewNop()
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if address == "" {
+				address = localAddress(c.cfg.Server)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			return runHealthcheck(ctx, c.cfg.Server.TLS, address, service, os.Stdout)
+		},
+	}
+	command.Flags().StringVar(&address, "address", "", "address of the gRPC server, the configured server port on localhost by default")
+	command.Flags().StringVar(&service, "service", "", "service to check, the whole server by default")
+	command.Flags().DurationVar(&timeout, "timeout", 3*time.Second, "time allowed for the check")
+	return command
+}
+
+// localAddress returns the address the server configured in cfg is reached at from its own host
+func localAddress(cfg config.ServerConfig) string {
+	host := cfg.Host
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, cfg.Port)
+}
+
+// runHealthcheck checks service on the server at address, printing its status to out. The server
+// certificate is not verified, as the check only ever talks to the server next to it; under
+// mutual TLS it presents that same certificate, which the client CAs must therefore trust.
+func runHealthcheck(ctx context.Context, tlsCfg config.ServerTLSConfig, address string, service string, out io.Writer) error {
+	creds := insecure.NewCredentials()
+	if tlsCfg.Enabled {
+		clientTLS := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}
+		if tlsCfg.ClientCAFile != "" {
+			cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+			if err != nil {
+				return fmt.Errorf("failed to load the server certificate: %w", err)
+			}
+			clientTLS.Certificates = []tls.Certificate{cert}
+		}
+		creds = credentials.NewTLS(clientTLS)
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return fmt.Errorf("health check of %s failed: %w", address, err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s is %s", address, resp.GetStatus())
+	}
+	fmt.Fprintln(out, resp.GetStatus())
+	return nil
+}
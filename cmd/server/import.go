@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/backend-interview-task/internal/importer"
+	"github.com/backend-interview-task/internal/providers/database"
+	"github.com/backend-interview-task/internal/repository"
+	"github.com/backend-interview-task/utils"
+)
+
+// defaultImportBatchSize is how many decisions an import writes per transaction by default
+const defaultImportBatchSize = 10000
+
+// newImportCommand builds the import command, which bulk-loads historical decisions from a
+// CSV or NDJSON file, or from stdin when the file is -
+func newImportCommand(c *cli) *cobra.Command {
+	var (
+		format    string
+		batchSize int
+		tenant    string
+	)
+	command := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Bulk-load historical decisions from a CSV or NDJSON file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if batchSize < 1 {
+				return fmt.Errorf("invalid batch size %d", batchSize)
+			}
+			if err := validateTenant(c, tenant); err != nil {
+				return err
+			}
+
+			input, err := openImportInput(args[0], &format)
+			if err != nil {
+				return err
+			}
+			defer input.Close()
+
+			reader, err := importer.NewReader(input, format)
+			if err != nil {
+				return err
+			}
+			return runImport(cmd.Context(), c, tenant, reader, batchSize, cmd.OutOrStdout())
+		},
+	}
+	command.Flags().StringVar(&format, "format", "", "csv or ndjson, taken from the file extension by default")
+	command.Flags().IntVar(&batchSize, "batch-size", defaultImportBatchSize, "decisions written per transaction")
+	command.Flags().StringVar(&tenant, "tenant", "", "tenant the decisions belong to, required when tenants are configured")
+	return command
+}
+
+// validateTenant checks that tenant is configured, and that one is given when tenants are
+func validateTenant(c *cli, tenant string) error {
+	if tenant != "" && !slices.Contains(c.cfg.Tenancy.Tenants, tenant) {
+		return fmt.Errorf("unknown tenant %q", tenant)
+	}
+	if tenant == "" && len(c.cfg.Tenancy.Tenants) > 0 {
+		return errors.New("--tenant is required when tenants are configured")
+	}
+	return nil
+}
+
+// openImportInput opens the file at path, or stdin for -, and fills in its format from the
+// extension when none was given
+func openImportInput(path string, format *string) (io.ReadCloser, error) {
+	if path == "-" {
+		if *format == "" {
+			return nil, errors.New("--format is required when reading from stdin")
+		}
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	if *format == "" {
+		detected, err := importer.FormatOf(path)
+		if err != nil {
+			return nil, err
+		}
+		*format = detected
+	}
+	return os.Open(path)
+}
+
+// runImport imports every decision of reader into the database of tenant, or the shared one
+func runImport(ctx context.Context, c *cli, tenant string, reader importer.Reader, batchSize int, out io.Writer) error {
+	store, closeStore, err := openImportStore(c, tenant)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+	if tenant != "" {
+		ctx = utils.WithTenant(ctx, tenant)
+	}
+
+	repo := repository.NewRetryingExplorerRepository(store, c.cfg.Database.Retry, c.logger)
+
+	summary, err := importer.NewImporter(repo, batchSize, c.logger).Import(ctx, reader)
+	fmt.Fprintf(out, "rows %d, created %d, updated %d, skipped %d, matches %d\n",
+		summary.Rows, summary.Created, summary.Updated, summary.Skipped, summary.Matches)
+	return err
+}
+
+// openImportStore opens the repository of the configured storage, on the schema of tenant
+// when set, and returns it with the function closing it
+func openImportStore(c *cli, tenant string) (repository.ExplorerRepository, func(), error) {
+	if c.cfg.Storage.Driver == database.StorageDynamoDB {
+		client, err := database.NewDynamoDBClient(context.Background(), c.cfg.Storage.DynamoDB, c.logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize DynamoDB: %w", err)
+		}
+		return repository.NewDynamoDBExplorerRepository(client, c.cfg.Storage.DynamoDB.Table, c.cfg.Decisions.LikeTTL, c.logger), func() {}, nil
+	}
+	if c.cfg.Database.Driver == database.DriverMySQL {
+		if tenant != "" {
+			return nil, nil, fmt.Errorf("--tenant needs database.driver %s", database.DriverPostgres)
+		}
+		db, err := database.NewMySQLDB(c.cfg.Database, c.logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize database: %w", err)
+		}
+		return repository.NewMySQLExplorerRepository(db, c.cfg.Decisions.LikeTTL, c.logger), func() { _ = db.Close() }, nil
+	}
+
+	var (
+		db  database.DBProvider
+		err error
+	)
+	if tenant != "" {
+		db, err = database.NewTenantDBProvider(c.cfg.Database, []string{tenant}, c.logger)
+	} else {
+		db, err = database.NewDBProvider(c.cfg.Database, c.logger)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	return repository.NewExplorerRepository(db, c.cfg.Decisions.LikeTTL, c.logger), db.Close, nil
+}
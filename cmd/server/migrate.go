@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/providers/database"
+)
+
+// newMigrateCommand builds the migrate command, whose subcommands run against the schema of
+// every tenant, or of the tenant given with --tenant
+func newMigrateCommand(c *cli) *cobra.Command {
+	var tenant string
+	migrate := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, revert or inspect the database migrations",
+	}
+	migrate.PersistentFlags().StringVar(&tenant, "tenant", "", "only migrate the schema of this tenant")
+
+	run := func(command migrateCommand) error {
+		return runMigrateCommand(c.cfg, tenant, command, os.Stdout)
+	}
+	migrate.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return run(migrateCommand{name: "up"})
+			},
+		},
+		&cobra.Command{
+			Use:   "down [n]",
+			Short: "Revert the last n applied migrations, 1 by default",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				command := migrateCommand{name: "down", steps: 1}
+				if len(args) == 1 {
+					steps, err := strconv.Atoi(args[0])
+					if err != nil || steps < 1 {
+						return fmt.Errorf("invalid number of migrations to revert %q", args[0])
+					}
+					command.steps = steps
+				}
+				return run(command)
+			},
+		},
+		&cobra.Command{
+			Use:   "version",
+			Short: "Print the version of the last applied migration",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return run(migrateCommand{name: "version"})
+			},
+		},
+		&cobra.Command{
+			Use:   "plan",
+			Short: "Print the migrations up would apply and their SQL, without applying them",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return run(migrateCommand{name: "plan"})
+			},
+		},
+		&cobra.Command{
+			Use:   "force <version>",
+			Short: "Record a version as applied and clear the dirty flag, once the schema was repaired by hand",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				version, err := strconv.Atoi(args[0])
+				if err != nil || version < -1 {
+					return fmt.Errorf("invalid version %q", args[0])
+				}
+				return run(migrateCommand{name: "force", version: version})
+			},
+		},
+	)
+	return migrate
+}
+
+// migrateCommand is a parsed migrate subcommand
+type migrateCommand struct {
+	name    string
+	steps   int
+	version int
+}
+
+// runMigrateCommand runs command against the schema of every tenant, or only of tenant when
+// it is set, writing what it did to out
+func runMigrateCommand(cfg *config.Config, tenant string, command migrateCommand, out io.Writer) error {
+	if cfg.Storage.Driver == database.StorageDynamoDB {
+		return errors.New("storage.driver dynamodb has no migrations, its table is created as the README shows")
+	}
+	tenants := cfg.Tenancy.Tenants
+	if tenant != "" {
+		if !slices.Contains(tenants, tenant) {
+			return fmt.Errorf("unknown tenant %q", tenant)
+		}
+		tenants = []string{tenant}
+	}
+
+	for _, schema := range database.MigrationSchemas(tenants) {
+		if err := migrateSchema(cfg.Database, schema, command, out); err != nil {
+			return fmt.Errorf("schema %q: %w", schema, err)
+		}
+	}
+	return nil
+}
+
+// migrateSchema runs command against the migrations of schema
+func migrateSchema(cfg config.DatabaseConfig, schema string, command migrateCommand, out io.Writer) error {
+	migrator, err := database.NewMigrator(cfg, schema)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch command.name {
+	case "plan":
+		return printPlan(migrator, out)
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down(command.steps)
+	case "force":
+		err = migrator.Force(command.version)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "schema %q: version %d, dirty %t\n", schema, version, dirty)
+	return nil
+}
+
+// printPlan writes the migrations pending on the schema of migrator and their SQL to out
+func printPlan(migrator *database.Migrator, out io.Writer) error {
+	planned, err := migrator.Plan()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "-- schema %q: %d pending migrations\n", migrator.Schema(), len(planned))
+	for _, migration := range planned {
+		fmt.Fprintf(out, "\n-- %d %s\n%s\n", migration.Version, migration.Name, strings.TrimSpace(migration.SQL))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/backend-interview-task/internal/importer"
+)
+
+// prodEnv is the server.env of production deployments, which seed refuses to write to
+const prodEnv = "prod"
+
+// newSeedCommand builds the seed command, which fills the database with made up decisions so
+// that local development and demos don't start from an empty decisions table
+func newSeedCommand(c *cli) *cobra.Command {
+	var (
+		opts      importer.SeedOptions
+		days      int
+		batchSize int
+		tenant    string
+		force     bool
+	)
+	command := &cobra.Command{
+		Use:   "seed",
+		Short: "Fill the database with made up likes, passes and matches for development and demos",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if c.cfg.Server.Env == prodEnv && !force {
+				return fmt.Errorf("refusing to seed the %s environment without --force", prodEnv)
+			}
+			if batchSize < 1 {
+				return fmt.Errorf("invalid batch size %d", batchSize)
+			}
+			if err := validateTenant(c, tenant); err != nil {
+				return err
+			}
+
+			opts.Period = time.Duration(days) * 24 * time.Hour
+			reader, err := importer.NewSeedReader(opts, time.Now())
+			if err != nil {
+				return err
+			}
+			return runImport(cmd.Context(), c, tenant, reader, batchSize, cmd.OutOrStdout())
+		},
+	}
+	command.Flags().IntVar(&opts.Users, "users", 1000, "users making and receiving decisions")
+	command.Flags().IntVar(&opts.DecisionsPerUser, "decisions-per-user", 50, "mean number of decisions a user makes")
+	command.Flags().Float64Var(&opts.LikeRate, "like-rate", 0.3, "share of decisions on a user of average popularity that are likes")
+	command.Flags().Float64Var(&opts.ReplyRate, "reply-rate", 0.5, "share of likes the recipient answers, liking back at the like rate")
+	command.Flags().IntVar(&days, "days", 30, "days up to now the decisions are spread over")
+	command.Flags().StringVar(&opts.Prefix, "prefix", "seed-user-", "prefix of the made up user IDs")
+	command.Flags().Uint64Var(&opts.Seed, "seed", 1, "random seed, the same one making up the same users and decisions")
+	command.Flags().IntVar(&batchSize, "batch-size", defaultImportBatchSize, "decisions written per transaction")
+	command.Flags().StringVar(&tenant, "tenant", "", "tenant the decisions belong to, required when tenants are configured")
+	command.Flags().BoolVar(&force, "force", false, "seed even when server.env is prod")
+	return command
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/core"
+	"github.com/backend-interview-task/internal/graphql"
+	"github.com/backend-interview-task/internal/jobs"
+	"github.com/backend-interview-task/internal/middleware"
+	"github.com/backend-interview-task/internal/providers/auth"
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/internal/providers/database"
+	"github.com/backend-interview-task/internal/providers/events"
+	"github.com/backend-interview-task/internal/providers/pubsub"
+	"github.com/backend-interview-task/internal/providers/secrets"
+	"github.com/backend-interview-task/internal/providers/tracing"
+	"github.com/backend-interview-task/internal/providers/webhook"
+	"github.com/backend-interview-task/internal/repository"
+	"github.com/backend-interview-task/internal/service"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// newLikesBufferSize is how many new like events a watcher may lag behind before events are dropped
+const newLikesBufferSize = 16
+
+// Storages the decisions can be kept in
+const (
+	// storageConfigured keeps the decisions in the store selected by storage.driver and, for
+	// the database storage, database.driver
+	storageConfigured = "configured"
+	// storageMemory keeps the decisions in the process, for demos and trying the API out
+	// without Postgres. They are lost when it exits.
+	storageMemory = "memory"
+)
+
+func newServeCommand(c *cli) *cobra.Command {
+	var storage string
+	command := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the gRPC API and run the background jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if storage != storageConfigured && storage != storageMemory {
+				return fmt.Errorf("invalid storage %q, expected %s or %s", storage, storageConfigured, storageMemory)
+			}
+			runServe(c.cfg, c.vault, storage, c.logger)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&storage, "storage", storageConfigured, "where decisions are kept: configured, the store of storage.driver and database.driver, or memory for a demo without a database")
+	return command
+}
+
+// runServe serves the gRPC API, and GraphQL when enabled, and runs the background jobs until
+// the process is told to stop. When the credentials came from Vault, its leases are renewed.
+func runServe(cfg *config.Config, vault *secrets.Vault, storage string, logger *zap.Logger) {
+	logger.Info("Starting Explore Service",
+		zap.String("version", "1.0.0"),
+		zap.String("host", cfg.Server.Host),
+		zap.String("port", cfg.Server.Port))
+	logger.Debug("Effective configuration", zap.Any("config", cfg.Redacted().Settings()))
+
+	if cfg.Faults.Enabled {
+		logger.Warn("Injecting faults into gRPC, cache and DB calls", zap.Any("faults", cfg.Faults))
+	}
+
+	// Set up before the DB and cache clients, which take the global tracer provider
+	shutdownTracing, err := tracing.NewTracerProvider(context.Background(), cfg.Tracing, logger)
+	if err != nil {
+		logger.Fatal("Invalid tracing configuration", zap.Error(err))
+	}
+
+	var (
+		pgxPool  database.DBProvider
+		mysqlDB  *sql.DB
+		dynamoDB *dynamodb.Client
+	)
+	switch {
+	case storage == storageMemory:
+		// Tenant schemas, the admin RPCs and the change feed are built on Postgres itself
+		if len(cfg.Tenancy.Tenants) > 0 || cfg.Admin.Enabled || cfg.ChangeFeed.Enabled {
+			logger.Fatal("Invalid storage configuration",
+				zap.Error(errors.New("tenancy.tenants, admin and change_feed need the postgres storage")))
+		}
+		logger.Warn("Keeping decisions in memory, they are lost when the server stops")
+	case cfg.Storage.Driver == database.StorageDynamoDB:
+		dynamoDB, err = database.NewDynamoDBClient(context.Background(), cfg.Storage.DynamoDB, logger)
+		if err != nil {
+			logger.Fatal("Failed to initialize DynamoDB", zap.Error(err))
+		}
+		if cfg.Faults.Enabled {
+			logger.Warn("DB faults are only injected with the postgres driver")
+		}
+	case cfg.Database.Driver == database.DriverMySQL:
+		mysqlDB, err = database.NewMySQLDB(cfg.Database, logger)
+		if err != nil {
+			logger.Fatal("Failed to initialize database", zap.Error(err))
+		}
+		defer func() { _ = mysqlDB.Close() }()
+		prometheus.MustRegister(collectors.NewDBStatsCollector(mysqlDB, cfg.Database.DBName))
+		if cfg.Faults.Enabled {
+			logger.Warn("DB faults are only injected with the postgres driver")
+		}
+
+		if cfg.Database.AutoMigrate {
+			if err := database.RunMigrations(cfg.Database, nil); err != nil {
+				logger.Fatal("Failed to apply migrations", zap.Error(err))
+			}
+		}
+	default:
+		if len(cfg.Tenancy.Tenants) > 0 {
+			pgxPool, err = database.NewTenantDBProvider(cfg.Database, cfg.Tenancy.Tenants, logger)
+		} else {
+			pgxPool, err = database.NewDBProvider(cfg.Database, logger)
+		}
+		if err != nil {
+			logger.Fatal("Failed to initialize database", zap.Error(err))
+		}
+		defer pgxPool.Close()
+		prometheus.MustRegister(database.NewPoolCollector(pgxPool))
+		if cfg.Faults.Enabled {
+			pgxPool = database.NewFaultyDBProvider(pgxPool, cfg.Faults.Database)
+		}
+
+		if cfg.Database.AutoMigrate {
+			if err := database.RunMigrations(cfg.Database, cfg.Tenancy.Tenants); err != nil {
+				logger.Fatal("Failed to apply migrations", zap.Error(err))
+			}
+		}
+	}
+
+	utils.SetCacheKeyPrefix(cfg.Cache.KeyPrefix)
+	cacheProvider, err := cache.NewCacheProvider(context.Background(), cfg.Cache, cfg.Redis, cfg.Faults, logger)
+	if err != nil {
+		logger.Warn("Failed to initialize cache, running without it", zap.String("provider", cfg.Cache.Provider), zap.Error(err))
+		cacheProvider = cache.NewNoopCacheProvider()
+	}
+	cacheProvider = cache.NewInstrumentedCacheProvider(cacheProvider)
+
+	// Without the change feed, new like events only reach watchers connected to the instance
+	// whose outbox dispatcher delivers them. With it, every instance pushes the new likes it is
+	// notified of to its own watchers, and the outbox only feeds the event bus and webhooks.
+	pubsubProvider := pubsub.NewMemoryPubSubProvider(newLikesBufferSize, logger)
+	outboxPubSub := pubsubProvider
+	if cfg.ChangeFeed.Enabled {
+		outboxPubSub = pubsub.NewNoopPubSubProvider()
+	}
+
+	eventPublisher, err := events.NewEventPublisher(context.Background(), cfg.Events, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize event publisher", zap.String("driver", cfg.Events.Driver), zap.Error(err))
+	}
+	defer eventPublisher.Close()
+
+	webhookProvider := webhook.NewHTTPWebhookProvider(&http.Client{Timeout: cfg.Webhooks.Timeout}, cfg.Webhooks.Secret, logger)
+
+	// Initialize repositories
+	utils.SetPageSizeLimits(utils.PageSizeLimits{
+		Min:     int(cfg.Pagination.MinPageSize),
+		Max:     int(cfg.Pagination.MaxPageSize),
+		Default: int(cfg.Pagination.DefaultPageSize),
+	})
+	var (
+		explorerStore repository.ExplorerRepository
+		reportStore   repository.ReportRepository
+		auditStore    repository.AuditRepository
+		adminStore    repository.AdminRepository
+	)
+	switch {
+	case storage == storageMemory:
+		explorerStore = repository.NewMemoryExplorerRepository(cfg.Decisions.LikeTTL, logger)
+		reportStore = explorerStore
+		auditStore = repository.NewMemoryAuditRepository(explorerStore, logger)
+	case dynamoDB != nil:
+		explorerStore = repository.NewDynamoDBExplorerRepository(dynamoDB, cfg.Storage.DynamoDB.Table, cfg.Decisions.LikeTTL, logger)
+		reportStore = explorerStore
+		auditStore = repository.NewDynamoDBAuditRepository(dynamoDB, cfg.Storage.DynamoDB.Table, logger)
+	case mysqlDB != nil:
+		explorerStore = repository.NewMySQLExplorerRepository(mysqlDB, cfg.Decisions.LikeTTL, logger)
+		reportStore = explorerStore
+		auditStore = repository.NewMySQLAuditRepository(mysqlDB, logger)
+	default:
+		explorerStore = repository.NewExplorerRepository(pgxPool, cfg.Decisions.LikeTTL, logger)
+		reportStore = repository.NewReportRepository(pgxPool, logger)
+		auditStore = repository.NewAuditRepository(pgxPool, logger)
+		adminStore = repository.NewAdminRepository(pgxPool, logger)
+	}
+	repo := repository.NewRetryingExplorerRepository(repository.NewInstrumentedExplorerRepository(explorerStore), cfg.Database.Retry, logger)
+	reportRepo := repository.NewInstrumentedReportRepository(reportStore)
+	auditRepo := repository.NewInstrumentedAuditRepository(auditStore)
+	adminRepo := repository.NewInstrumentedAdminRepository(adminStore)
+
+	// Initialize cores
+	warmCache := cfg.Cache.Warmer.Interval > 0 && cfg.Cache.Warmer.Recipients > 0
+	var exploreCore core.ExplorerCore = core.NewExploreCore(repo, cacheProvider, pubsubProvider, cfg.Webhooks.Endpoints, cfg.Decisions.LikeTTL, warmCache, logger)
+	var reportCore core.ReportCore = core.NewReportCore(reportRepo, logger)
+	var adminCore core.AdminCore = core.NewAdminCore(adminRepo, cacheProvider, logger)
+	if cfg.Audit.Enabled {
+		exploreCore = core.NewAuditedExplorerCore(exploreCore, auditRepo, logger)
+		reportCore = core.NewAuditedReportCore(reportCore, auditRepo, logger)
+		adminCore = core.NewAuditedAdminCore(adminCore, auditRepo, logger)
+	}
+	exploreCore = core.NewTracedExplorerCore(exploreCore)
+	reportCore = core.NewTracedReportCore(reportCore)
+	adminCore = core.NewTracedAdminCore(adminCore)
+	auditCore := core.NewTracedAuditCore(core.NewAuditCore(auditRepo, logger))
+
+	// Initialize gRPC services
+	userIDs, err := service.NewUserIDValidator(cfg.UserIDs)
+	if err != nil {
+		logger.Fatal("Invalid user ID configuration", zap.Error(err))
+	}
+	exploreService := service.NewExploreService(exploreCore, reportCore, auditCore, cfg.Pagination, cfg.Admin, userIDs, logger)
+
+	interceptors := middleware.NewChain()
+	interceptors.Register("request_id", middleware.Interceptor{Unary: service.UnaryRequestIDInterceptor(), Stream: service.StreamRequestIDInterceptor()})
+	interceptors.Register("metrics", middleware.Interceptor{Unary: service.UnaryMetricsInterceptor(), Stream: service.StreamMetricsInterceptor()})
+	interceptors.Register("access_log", middleware.Interceptor{Unary: service.UnaryAccessLogInterceptor(logger), Stream: service.StreamAccessLogInterceptor(logger)})
+	interceptors.Register("recovery", middleware.Interceptor{Unary: service.UnaryRecoveryInterceptor(logger), Stream: service.StreamRecoveryInterceptor(logger)})
+	var authenticators auth.Authenticators
+	if cfg.Auth.Enabled {
+		authenticators = newAuthenticators(cfg.Auth, logger)
+		interceptors.Register("auth", middleware.Interceptor{Unary: service.UnaryAuthInterceptor(authenticators, logger), Stream: service.StreamAuthInterceptor(authenticators, logger)})
+	} else {
+		interceptors.Register("auth", middleware.Interceptor{})
+	}
+	interceptors.Register("tenant", middleware.Interceptor{Unary: service.UnaryTenantInterceptor(cfg.Tenancy), Stream: service.StreamTenantInterceptor(cfg.Tenancy)})
+	if cfg.RateLimit.Enabled {
+		interceptors.Register("rate_limit", middleware.Interceptor{Unary: service.UnaryRateLimitInterceptor(cacheProvider, cfg.RateLimit, logger), Stream: service.StreamRateLimitInterceptor(cacheProvider, cfg.RateLimit, logger)})
+	} else {
+		interceptors.Register("rate_limit", middleware.Interceptor{})
+	}
+	if cfg.Faults.Enabled {
+		interceptors.Register("faults", middleware.Interceptor{Unary: service.UnaryFaultInterceptor(cfg.Faults.GRPC), Stream: service.StreamFaultInterceptor(cfg.Faults.GRPC)})
+	} else {
+		interceptors.Register("faults", middleware.Interceptor{})
+	}
+	serverOptions, err := interceptors.ServerOptions(cfg.Server.Interceptors)
+	if err != nil {
+		logger.Fatal("Invalid server.interceptors configuration", zap.Error(err))
+	}
+
+	serverOptions = append(serverOptions,
+		grpc.MaxRecvMsgSize(cfg.Server.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.Server.MaxSendMsgSize))
+	if cfg.Tracing.Enabled {
+		// Health probes would otherwise start a trace every few seconds
+		serverOptions = append(serverOptions, grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))))
+	}
+	keepaliveOptions, err := service.NewKeepaliveServerOptions(cfg.Server.Keepalive)
+	if err != nil {
+		logger.Fatal("Invalid server keepalive configuration", zap.Error(err))
+	}
+	serverOptions = append(serverOptions, keepaliveOptions...)
+	var tlsConfig *tls.Config
+	if cfg.Server.TLS.Enabled {
+		tlsConfig, err = service.NewServerTLSConfig(cfg.Server.TLS, logger)
+		if err != nil {
+			logger.Fatal("Invalid server TLS configuration", zap.Error(err))
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(serverOptions...)
+	pb.RegisterExploreServiceServer(grpcServer, exploreService)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("explore.ExploreService", healthpb.HealthCheckResponse_SERVING)
+	healthServices := []string{"", "explore.ExploreService"}
+	if cfg.Admin.Enabled {
+		pb.RegisterAdminServiceServer(grpcServer, service.NewAdminService(adminCore, cfg.Pagination, userIDs, logger))
+		healthServer.SetServingStatus("explore.AdminService", healthpb.HealthCheckResponse_SERVING)
+		healthServices = append(healthServices, "explore.AdminService")
+	}
+	var healthChecker *jobs.HealthChecker
+	if cfg.Health.Interval > 0 {
+		checks := []jobs.HealthCheck{
+			{Name: "cache", Service: "explore.cache", Check: cacheProvider.Ping, Critical: cfg.Health.RequireCache},
+		}
+		if pgxPool != nil {
+			checks = append([]jobs.HealthCheck{
+				{Name: "postgres", Service: "explore.db", Check: pgxPool.Ping, Critical: true},
+			}, checks...)
+		}
+		if mysqlDB != nil {
+			checks = append([]jobs.HealthCheck{
+				{Name: "mysql", Service: "explore.db", Check: mysqlDB.PingContext, Critical: true},
+			}, checks...)
+		}
+		if dynamoDB != nil {
+			checks = append([]jobs.HealthCheck{
+				{Name: "dynamodb", Service: "explore.db", Check: func(ctx context.Context) error {
+					return database.PingDynamoDB(ctx, dynamoDB, cfg.Storage.DynamoDB.Table)
+				}, Critical: true},
+			}, checks...)
+		}
+		healthChecker = jobs.NewHealthChecker(checks, healthServer, healthServices, cfg.Health.Interval, cfg.Health.Timeout, logger)
+		healthChecker.Check(context.Background())
+	}
+
+	reflection.Register(grpcServer)
+	address := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.String("address", address), zap.Error(err))
+	}
+
+	go func() {
+		logger.Info("gRPC server starting", zap.String("address", address), zap.Bool("tls", tlsConfig != nil))
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Fatal("Failed to serve", zap.Error(err))
+		}
+	}()
+
+	var graphqlServer *http.Server
+	if cfg.GraphQL.Enabled {
+		schema, err := graphql.NewSchema(exploreCore, utils.NewPageTokenSigner(cfg.Pagination.CursorKeys, cfg.Pagination.CursorTTL), userIDs)
+		if err != nil {
+			logger.Fatal("Failed to build GraphQL schema", zap.Error(err))
+		}
+
+		mux := http.NewServeMux()
+		var rateLimiter *service.RateLimiter
+		if cfg.RateLimit.Enabled {
+			rateLimiter = service.NewRateLimiter(cacheProvider, cfg.RateLimit, logger)
+		}
+		mux.Handle(cfg.GraphQL.Path, graphql.NewHandler(schema, cfg.Tenancy, authenticators, rateLimiter, logger))
+		graphqlServer = &http.Server{
+			Addr:      fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.GraphQL.Port),
+			Handler:   mux,
+			TLSConfig: tlsConfig,
+		}
+
+		go func() {
+			logger.Info("GraphQL server starting", zap.String("address", graphqlServer.Addr), zap.Bool("tls", tlsConfig != nil))
+			serve := graphqlServer.ListenAndServe
+			if tlsConfig != nil {
+				// The certificates come from TLSConfig, which rereads them as they rotate
+				serve = func() error { return graphqlServer.ListenAndServeTLS("", "") }
+			}
+			if err := serve(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve GraphQL", zap.Error(err))
+			}
+		}()
+	}
+
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle(cfg.Metrics.Path, promhttp.Handler())
+		metricsServer = &http.Server{
+			Addr:    fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Metrics.Port),
+			Handler: mux,
+		}
+
+		go func() {
+			logger.Info("Metrics server starting", zap.String("address", metricsServer.Addr), zap.String("path", cfg.Metrics.Path))
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve metrics", zap.Error(err))
+			}
+		}()
+	}
+
+	var debugServer *http.Server
+	if cfg.Debug.Enabled {
+		debugServer = &http.Server{
+			Addr:    net.JoinHostPort(cfg.Debug.Host, cfg.Debug.Port),
+			Handler: newDebugHandler(),
+		}
+
+		go func() {
+			logger.Info("Debug server starting", zap.String("address", debugServer.Addr))
+			if err := debugServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve debug endpoints", zap.Error(err))
+			}
+		}()
+	}
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if vault != nil {
+		go vault.Renew(jobsCtx)
+	}
+	if healthChecker != nil {
+		go healthChecker.Run(jobsCtx)
+	}
+	if cfg.ChangeFeed.Enabled {
+		// A single connection hears the changes of every tenant schema
+		listener := database.NewListener(cfg.Database, cfg.ChangeFeed.ReconnectDelay, logger)
+		go jobs.NewChangeFeed(listener, exploreCore, cfg.Tenancy.Tenants, logger).Run(jobsCtx)
+	}
+	// Every tenant runs its own jobs, each against the tenant's schema and cache namespace.
+	// Replicas take turns through cache locks, so each tick runs on one replica only.
+	for _, tenantCtx := range tenantContexts(jobsCtx, cfg.Tenancy.Tenants) {
+		dispatcher := jobs.NewOutboxDispatcher(repo, cacheProvider, outboxPubSub, eventPublisher, webhookProvider, cfg.Outbox.PollInterval, cfg.Outbox.MaxAttempts, logger)
+		go dispatcher.Run(tenantCtx)
+		if cfg.Decisions.LikeTTL > 0 && cfg.Decisions.PurgeInterval > 0 {
+			purger := jobs.NewLikePurger(repo, cacheProvider, cfg.Decisions.LikeTTL, cfg.Decisions.PurgeInterval, logger)
+			go purger.Run(tenantCtx)
+		}
+		if cfg.Decisions.CountReconcileInterval > 0 {
+			reconciler := jobs.NewLikeCountReconciler(repo, cacheProvider, cfg.Decisions.CountReconcileInterval, logger)
+			go reconciler.Run(tenantCtx)
+		}
+		if warmCache {
+			warmer := jobs.NewCacheWarmer(exploreCore, cacheProvider, cfg.Cache.Warmer.Interval, cfg.Cache.Warmer.Recipients, logger)
+			go warmer.Run(tenantCtx)
+		}
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Server shutting down gracefully...")
+	stopJobs()
+	healthServer.Shutdown()
+
+	// Graceful shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if graphqlServer != nil {
+		if err := graphqlServer.Shutdown(ctx); err != nil {
+			logger.Warn("Failed to shut down GraphQL server", zap.Error(err))
+		}
+	}
+	grpcServer.GracefulStop()
+	// Cache writes left behind by the last calls finish before the process exits
+	if err := exploreCore.Flush(ctx); err != nil {
+		logger.Warn("Failed to flush background cache writes", zap.Error(err))
+	}
+	// Shut down last, so that the final state of the calls above can still be scraped
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.Warn("Failed to shut down metrics server", zap.Error(err))
+		}
+	}
+	if debugServer != nil {
+		// Profiles being taken are cut short rather than holding up the exit
+		_ = debugServer.Close()
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn("Failed to flush traces", zap.Error(err))
+	}
+
+	logger.Info("Server shutdown complete")
+}
+
+// newAuthenticators builds the authenticators of the schemes cfg configures: Bearer JWTs when
+// it names an issuer and JWKS, and API keys when it lists any. Validate makes sure it configures one.
+func newAuthenticators(cfg config.AuthConfig, logger *zap.Logger) auth.Authenticators {
+	authenticators := auth.Authenticators{}
+	if cfg.Issuer != "" {
+		authenticators[auth.SchemeBearer] = auth.NewJWTAuthenticator(cfg, &http.Client{Timeout: 10 * time.Second}, logger)
+	}
+	if len(cfg.APIKeys) > 0 {
+		apiKeys, err := auth.NewAPIKeyAuthenticator(cfg.APIKeys, cfg.ServiceRole)
+		if err != nil {
+			logger.Fatal("Invalid auth configuration", zap.Error(err))
+		}
+		authenticators[auth.SchemeAPIKey] = apiKeys
+	}
+	return authenticators
+}
+
+// tenantContexts returns ctx scoped to each of the tenants, or ctx alone when running single-tenant
+func tenantContexts(ctx context.Context, tenants []string) []context.Context {
+	if len(tenants) == 0 {
+		return []context.Context{ctx}
+	}
+
+	contexts := make([]context.Context, len(tenants))
+	for i, tenant := range tenants {
+		contexts[i] = utils.WithTenant(ctx, tenant)
+	}
+	return contexts
+}
+
+// initLogger initializes the logger based on configuration
+func initLogger(cfg config.LoggerConfig) (*zap.Logger, error) {
+	var level zapcore.Level
+	if err := level.Set(cfg.Level); err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+
+	var config zap.Config
+	switch cfg.Format {
+	case "json", "":
+		config = zap.NewProductionConfig()
+	case "console":
+		config = zap.NewDevelopmentConfig()
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		// Development mode would also panic on DPanic, which is not what asking for readable
+		// output means
+		config.Development = false
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected json or console", cfg.Format)
+	}
+	config.Level = zap.NewAtomicLevelAt(level)
+	config.DisableCaller = !cfg.Caller
+
+	// The stack traces are added below instead, at the configured level
+	config.DisableStacktrace = true
+	var options []zap.Option
+	if cfg.StacktraceLevel != "none" {
+		var stacktraceLevel zapcore.Level
+		if err := stacktraceLevel.Set(cfg.StacktraceLevel); err != nil {
+			return nil, fmt.Errorf("invalid stacktrace level: %w", err)
+		}
+		options = append(options, zap.AddStacktrace(stacktraceLevel))
+	}
+
+	logger, err := config.Build(options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build logger: %w", err)
+	}
+
+	return logger, nil
+}
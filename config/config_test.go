@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoadTestSuite struct {
+	suite.Suite
+}
+
+func TestLoadTestSuite(t *testing.T) {
+	suite.Run(t, new(LoadTestSuite))
+}
+
+func (s *LoadTestSuite) TestLayersEnvConfig() {
+	s.T().Setenv("SERVER_ENV", "staging")
+
+	cfg, err := Load()
+	s.Require().NoError(err)
+	s.Equal("staging", cfg.Server.Env)
+	s.Equal("debug", cfg.Logger.Level)
+	s.True(cfg.Admin.Enabled)
+	s.Equal("require", cfg.Database.SSLMode)
+	s.Equal("localhost", cfg.Database.Host)
+}
+
+func (s *LoadTestSuite) TestEnvVariablesOverrideEnvConfig() {
+	s.T().Setenv("SERVER_ENV", "prod")
+	s.T().Setenv("LOGGER_LEVEL", "warn")
+
+	cfg, err := Load()
+	s.Require().NoError(err)
+	s.Equal("warn", cfg.Logger.Level)
+	s.False(cfg.Database.AutoMigrate)
+}
+
+func (s *LoadTestSuite) TestKeepsBaseConfigWithoutEnvConfig() {
+	s.T().Setenv("SERVER_ENV", "qa")
+
+	cfg, err := Load()
+	s.Require().NoError(err)
+	s.Equal("info", cfg.Logger.Level)
+	s.False(cfg.Admin.Enabled)
+	// Connections are kept open until an environment opts into ageing them out
+	s.Zero(cfg.Server.Keepalive.MaxConnectionIdle)
+	s.Zero(cfg.Server.Keepalive.MaxConnectionAge)
+}
+
+func (s *LoadTestSuite) TestProdAgesOutConnections() {
+	s.T().Setenv("SERVER_ENV", "prod")
+
+	cfg, err := Load()
+	s.Require().NoError(err)
+	s.Equal(15*time.Minute, cfg.Server.Keepalive.MaxConnectionIdle)
+	s.Equal(30*time.Minute, cfg.Server.Keepalive.MaxConnectionAge)
+	s.Equal(time.Minute, cfg.Server.Keepalive.MaxConnectionAgeGrace)
+}
+
+func (s *LoadTestSuite) TestRejectsEnvOutsideConfigDir() {
+	s.T().Setenv("SERVER_ENV", "../secrets")
+
+	_, err := Load()
+	s.Error(err)
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces the secrets of a redacted config
+const redactedValue = "<redacted>"
+
+// Redacted returns a copy of cfg with its secrets masked, fit for logs and printing. Unset
+// secrets stay empty, so that the copy still shows which of them are configured.
+func (cfg *Config) Redacted() *Config {
+	redacted := *cfg
+	for _, secret := range []*string{
+		&redacted.Database.Password,
+		&redacted.Redis.Password,
+		&redacted.Webhooks.Secret,
+		&redacted.Vault.Token,
+		&redacted.Vault.SecretID,
+	} {
+		if *secret != "" {
+			*secret = redactedValue
+		}
+	}
+	if len(redacted.Pagination.CursorKeys) > 0 {
+		redacted.Pagination.CursorKeys = make([]string, len(cfg.Pagination.CursorKeys))
+		for i := range redacted.Pagination.CursorKeys {
+			redacted.Pagination.CursorKeys[i] = redactedValue
+		}
+	}
+	if redacted.Database.URL != "" {
+		redacted.Database.URL = redactURL(redacted.Database.URL)
+	}
+	if redacted.Redis.URL != "" {
+		redacted.Redis.URL = redactURL(redacted.Redis.URL)
+	}
+	return &redacted
+}
+
+// Settings returns cfg as nested maps keyed like the config file, with durations written as
+// in it, such as "1m30s"
+func (cfg *Config) Settings() map[string]interface{} {
+	return settings(reflect.ValueOf(*cfg)).(map[string]interface{})
+}
+
+func settings(value reflect.Value) interface{} {
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return value.Interface().(time.Duration).String()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		fields := make(map[string]interface{}, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			key, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("mapstructure"), ",")
+			if key == "" || key == "-" {
+				continue
+			}
+			fields[key] = settings(value.Field(i))
+		}
+		return fields
+	case reflect.Slice:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = settings(value.Index(i))
+		}
+		return items
+	default:
+		return value.Interface()
+	}
+}
@@ -0,0 +1,313 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// FieldError reports a config field holding an invalid value, named by its config key
+type FieldError struct {
+	Field  string
+	Value  interface{}
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %s, got %q", e.Field, e.Reason, fmt.Sprint(e.Value))
+}
+
+// ValidationError lists every invalid field of a config
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		lines[i] = field.Error()
+	}
+	return "invalid configuration: " + strings.Join(lines, "; ")
+}
+
+// Unwrap returns the FieldErrors, so that errors.As finds each of them
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// prodEnv is the server.env of production deployments
+const prodEnv = "prod"
+
+// minCursorKeyLength is the shortest key accepted for signing pagination tokens, the size of
+// the HMAC-SHA256 digest
+const minCursorKeyLength = 32
+
+// Valid values of the enumerated settings
+var (
+	storageDrivers = []string{"database", "dynamodb"}
+	dbDrivers      = []string{"postgres", "mysql"}
+	sslModes       = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	queryExecModes = []string{"cache_statement", "cache_describe", "describe_exec", "exec", "simple_protocol"}
+	cacheProviders = []string{"redis", "memcached", "none"}
+	logFormats     = []string{"json", "console"}
+	eventsDrivers  = []string{"kafka", "nats"}
+	userIDFormats  = []string{"any", "uuid", "pattern"}
+	vaultMethods   = []string{"token", "approle", "kubernetes"}
+)
+
+// Validate checks every setting of cfg, returning a *ValidationError that lists all the invalid
+// ones, so that a misconfigured deployment fails at startup with all of them at once
+func (cfg *Config) Validate() error {
+	v := &validator{}
+
+	v.port("server.port", cfg.Server.Port)
+	v.check(cfg.Server.MaxRecvMsgSize > 0, "server.max_recv_msg_size", cfg.Server.MaxRecvMsgSize, "must be positive")
+	v.check(cfg.Server.MaxSendMsgSize > 0, "server.max_send_msg_size", cfg.Server.MaxSendMsgSize, "must be positive")
+	if cfg.Server.TLS.Enabled {
+		v.required("server.tls.cert_file", cfg.Server.TLS.CertFile)
+		v.required("server.tls.key_file", cfg.Server.TLS.KeyFile)
+	}
+	keepalive := cfg.Server.Keepalive
+	v.check(keepalive.MaxConnectionIdle >= 0, "server.keepalive.max_connection_idle", keepalive.MaxConnectionIdle, "must not be negative")
+	v.check(keepalive.MaxConnectionAge >= 0, "server.keepalive.max_connection_age", keepalive.MaxConnectionAge, "must not be negative")
+	v.check(keepalive.MaxConnectionAgeGrace >= 0, "server.keepalive.max_connection_age_grace", keepalive.MaxConnectionAgeGrace, "must not be negative")
+	v.check(keepalive.Time >= 0, "server.keepalive.time", keepalive.Time, "must not be negative")
+	v.check(keepalive.Timeout >= 0, "server.keepalive.timeout", keepalive.Timeout, "must not be negative")
+	v.check(keepalive.MinTime >= 0, "server.keepalive.min_time", keepalive.MinTime, "must not be negative")
+
+	v.oneOf("storage.driver", cfg.Storage.Driver, storageDrivers)
+	if cfg.Storage.Driver == "dynamodb" {
+		v.required("storage.dynamodb.table", cfg.Storage.DynamoDB.Table)
+		v.check(len(cfg.Tenancy.Tenants) == 0, "tenancy.tenants", cfg.Tenancy.Tenants, "need storage.driver database")
+		v.check(!cfg.Admin.Enabled, "admin.enabled", cfg.Admin.Enabled, "needs storage.driver database")
+		v.check(!cfg.ChangeFeed.Enabled, "change_feed.enabled", cfg.ChangeFeed.Enabled, "needs storage.driver database")
+	}
+
+	db := cfg.Database
+	v.oneOf("database.driver", db.Driver, dbDrivers)
+	if db.Driver == "mysql" {
+		// Tenant schemas, the admin RPCs and the change feed are built on Postgres itself
+		v.check(len(cfg.Tenancy.Tenants) == 0, "tenancy.tenants", cfg.Tenancy.Tenants, "need database.driver postgres")
+		v.check(!cfg.Admin.Enabled, "admin.enabled", cfg.Admin.Enabled, "needs database.driver postgres")
+		v.check(!cfg.ChangeFeed.Enabled, "change_feed.enabled", cfg.ChangeFeed.Enabled, "needs database.driver postgres")
+	}
+	// The DynamoDB deployment has no database to connect to
+	if cfg.Storage.Driver != "dynamodb" {
+		v.required("database.host", db.Host)
+		v.required("database.user", db.User)
+		v.required("database.dbname", db.DBName)
+	}
+	v.port("database.port", db.Port)
+	v.oneOf("database.sslmode", db.SSLMode, sslModes)
+	v.oneOf("database.query_exec_mode", db.QueryExecMode, queryExecModes)
+	v.check(db.MaxOpenConns > 0, "database.max_open_conns", db.MaxOpenConns, "must be positive")
+	v.check(db.MaxIdleConns >= 0, "database.max_idle_conns", db.MaxIdleConns, "must not be negative")
+	v.check(db.MaxIdleConns <= db.MaxOpenConns, "database.max_idle_conns", db.MaxIdleConns, "must not exceed database.max_open_conns")
+	v.check(db.Retry.MaxAttempts > 0, "database.retry.max_attempts", db.Retry.MaxAttempts, "must be positive")
+	v.check(db.Retry.BaseDelay >= 0, "database.retry.base_delay", db.Retry.BaseDelay, "must not be negative")
+	v.check(db.Retry.MaxDelay >= db.Retry.BaseDelay, "database.retry.max_delay", db.Retry.MaxDelay, "must not be below database.retry.base_delay")
+
+	v.oneOf("cache.provider", cfg.Cache.Provider, cacheProviders)
+	switch cfg.Cache.Provider {
+	case "redis":
+		if len(cfg.Redis.ClusterAddresses) == 0 {
+			v.required("redis.address", cfg.Redis.Address)
+		}
+	case "memcached":
+		v.check(len(cfg.Cache.Memcached.Servers) > 0, "cache.memcached.servers", cfg.Cache.Memcached.Servers, "must list a server")
+	}
+	redis := cfg.Redis
+	v.check(redis.DB >= 0, "redis.db", redis.DB, "must not be negative")
+	v.check(redis.DB == 0 || len(redis.ClusterAddresses) == 0, "redis.db", redis.DB, "must be 0 with redis.cluster_addresses")
+	v.check(redis.PoolSize >= 0, "redis.pool_size", redis.PoolSize, "must not be negative")
+	v.check(redis.MinIdleConns >= 0, "redis.min_idle_conns", redis.MinIdleConns, "must not be negative")
+	v.check(redis.PoolSize == 0 || redis.MinIdleConns <= redis.PoolSize, "redis.min_idle_conns", redis.MinIdleConns, "must not exceed redis.pool_size")
+	v.check(redis.DialTimeout >= 0, "redis.dial_timeout", redis.DialTimeout, "must not be negative")
+	v.check(redis.ReadTimeout >= 0, "redis.read_timeout", redis.ReadTimeout, "must not be negative")
+	v.check(redis.WriteTimeout >= 0, "redis.write_timeout", redis.WriteTimeout, "must not be negative")
+	v.check(redis.MaxRetries >= -1, "redis.max_retries", redis.MaxRetries, "must be -1, to disable retries, or more")
+	v.check(cfg.Cache.LocalSize >= 0, "cache.local_size", cfg.Cache.LocalSize, "must not be negative")
+	v.check(cfg.Cache.LocalTTL >= 0, "cache.local_ttl", cfg.Cache.LocalTTL, "must not be negative")
+	v.check(cfg.Cache.Warmer.Interval >= 0, "cache.warmer.interval", cfg.Cache.Warmer.Interval, "must not be negative")
+	v.check(cfg.Cache.Warmer.Recipients >= 0, "cache.warmer.recipients", cfg.Cache.Warmer.Recipients, "must not be negative")
+
+	v.level("logger.level", cfg.Logger.Level)
+	if cfg.Logger.Format != "" {
+		v.oneOf("logger.format", cfg.Logger.Format, logFormats)
+	}
+	if cfg.Logger.StacktraceLevel != "none" {
+		v.level("logger.stacktrace_level", cfg.Logger.StacktraceLevel)
+	}
+
+	v.check(cfg.Pagination.MinPageSize > 0, "pagination.min_page_size", cfg.Pagination.MinPageSize, "must be positive")
+	v.check(cfg.Pagination.MaxPageSize >= cfg.Pagination.MinPageSize, "pagination.max_page_size", cfg.Pagination.MaxPageSize, "must not be below pagination.min_page_size")
+	v.check(cfg.Pagination.DefaultPageSize >= cfg.Pagination.MinPageSize && cfg.Pagination.DefaultPageSize <= cfg.Pagination.MaxPageSize,
+		"pagination.default_page_size", cfg.Pagination.DefaultPageSize, "must be between pagination.min_page_size and pagination.max_page_size")
+	v.check(cfg.Pagination.CursorTTL >= 0, "pagination.cursor_ttl", cfg.Pagination.CursorTTL, "must not be negative")
+	for i, key := range cfg.Pagination.CursorKeys {
+		v.check(len(key) >= minCursorKeyLength, fmt.Sprintf("pagination.cursor_keys[%d]", i), redactedValue, fmt.Sprintf("must be at least %d bytes long", minCursorKeyLength))
+	}
+	if cfg.Pagination.Offset.Enabled {
+		v.check(cfg.Pagination.Offset.MaxOffset > 0, "pagination.offset.max_offset", cfg.Pagination.Offset.MaxOffset, "must be positive")
+	}
+
+	if cfg.GraphQL.Enabled {
+		v.port("graphql.port", cfg.GraphQL.Port)
+		v.path("graphql.path", cfg.GraphQL.Path)
+	}
+
+	v.check(cfg.Decisions.LikeTTL >= 0, "decisions.like_ttl", cfg.Decisions.LikeTTL, "must not be negative")
+	v.check(cfg.Outbox.PollInterval > 0, "outbox.poll_interval", cfg.Outbox.PollInterval, "must be positive")
+	v.check(cfg.Outbox.MaxAttempts > 0, "outbox.max_attempts", cfg.Outbox.MaxAttempts, "must be positive")
+	v.check(cfg.Webhooks.Timeout > 0, "webhooks.timeout", cfg.Webhooks.Timeout, "must be positive")
+	for i, endpoint := range cfg.Webhooks.Endpoints {
+		v.httpsURL(fmt.Sprintf("webhooks.endpoints[%d]", i), endpoint)
+	}
+	if len(cfg.Webhooks.Endpoints) > 0 {
+		v.required("webhooks.secret", cfg.Webhooks.Secret)
+	}
+
+	switch cfg.Events.Driver {
+	case "":
+	case "kafka":
+		v.check(len(cfg.Events.Kafka.Brokers) > 0, "events.kafka.brokers", cfg.Events.Kafka.Brokers, "must list a broker")
+		v.required("events.kafka.topic", cfg.Events.Kafka.Topic)
+	case "nats":
+		v.required("events.nats.url", cfg.Events.NATS.URL)
+		v.required("events.nats.stream", cfg.Events.NATS.Stream)
+	default:
+		v.oneOf("events.driver", cfg.Events.Driver, eventsDrivers)
+	}
+
+	if len(cfg.Tenancy.Tenants) > 0 {
+		v.required("tenancy.header", cfg.Tenancy.Header)
+	}
+	for i, tenant := range cfg.Tenancy.Tenants {
+		v.check(utils.ValidTenant(tenant), fmt.Sprintf("tenancy.tenants[%d]", i), tenant, "must be 1 to 32 lowercase letters, digits or underscores")
+	}
+
+	v.check(cfg.Health.Interval >= 0, "health.interval", cfg.Health.Interval, "must not be negative")
+	if cfg.Health.Interval > 0 {
+		v.check(cfg.Health.Timeout > 0, "health.timeout", cfg.Health.Timeout, "must be positive")
+	}
+
+	if cfg.Auth.Enabled {
+		// JWTs are verified against the keys of one issuer, so the two are set together
+		if cfg.Auth.Issuer != "" || cfg.Auth.JWKSURL != "" {
+			v.required("auth.issuer", cfg.Auth.Issuer)
+			v.required("auth.jwks_url", cfg.Auth.JWKSURL)
+		} else {
+			v.check(len(cfg.Auth.APIKeys) > 0, "auth.jwks_url", cfg.Auth.JWKSURL, "is required with auth.issuer unless auth.api_keys are set")
+		}
+		v.check(cfg.Auth.Leeway >= 0, "auth.leeway", cfg.Auth.Leeway, "must not be negative")
+	}
+
+	if cfg.RateLimit.Enabled {
+		v.check(cfg.RateLimit.Limit > 0, "rate_limit.limit", cfg.RateLimit.Limit, "must be positive")
+		v.check(cfg.RateLimit.Period > 0, "rate_limit.period", cfg.RateLimit.Period, "must be positive")
+		v.check(cfg.RateLimit.Burst > 0, "rate_limit.burst", cfg.RateLimit.Burst, "must be positive")
+	}
+
+	v.oneOf("user_ids.format", cfg.UserIDs.Format, userIDFormats)
+	if cfg.UserIDs.Format == "pattern" {
+		_, err := regexp.Compile(cfg.UserIDs.Pattern)
+		v.check(cfg.UserIDs.Pattern != "" && err == nil, "user_ids.pattern", cfg.UserIDs.Pattern, "must be a regular expression")
+	}
+	v.check(cfg.UserIDs.MaxLength > 0, "user_ids.max_length", cfg.UserIDs.MaxLength, "must be positive")
+
+	v.check(cfg.Tracing.SampleRatio >= 0 && cfg.Tracing.SampleRatio <= 1, "tracing.sample_ratio", cfg.Tracing.SampleRatio, "must be between 0 and 1")
+	if cfg.Tracing.Enabled {
+		v.required("tracing.endpoint", cfg.Tracing.Endpoint)
+	}
+
+	if cfg.Metrics.Enabled {
+		v.port("metrics.port", cfg.Metrics.Port)
+		v.path("metrics.path", cfg.Metrics.Path)
+	}
+	if cfg.Debug.Enabled {
+		v.port("debug.port", cfg.Debug.Port)
+	}
+
+	if cfg.Vault.Enabled {
+		v.required("vault.address", cfg.Vault.Address)
+		v.oneOf("vault.auth_method", cfg.Vault.AuthMethod, vaultMethods)
+	}
+
+	if cfg.Faults.Enabled {
+		v.check(cfg.Server.Env != prodEnv, "faults.enabled", cfg.Faults.Enabled, "must be false when server.env is "+prodEnv)
+		v.faults("faults.grpc", cfg.Faults.GRPC)
+		v.faults("faults.cache", cfg.Faults.Cache.FaultConfig)
+		v.rate("faults.cache.miss_rate", cfg.Faults.Cache.MissRate)
+		v.faults("faults.database", cfg.Faults.Database)
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.errs}
+}
+
+// validator collects the FieldErrors of a config
+type validator struct {
+	errs []*FieldError
+}
+
+// check records a FieldError for field unless ok
+func (v *validator) check(ok bool, field string, value interface{}, reason string) {
+	if !ok {
+		v.errs = append(v.errs, &FieldError{Field: field, Value: value, Reason: reason})
+	}
+}
+
+func (v *validator) required(field, value string) {
+	v.check(value != "", field, value, "is required")
+}
+
+func (v *validator) port(field, value string) {
+	port, err := strconv.Atoi(value)
+	v.check(err == nil && port > 0 && port < 1<<16, field, value, "must be a port number between 1 and 65535")
+}
+
+func (v *validator) path(field, value string) {
+	v.check(strings.HasPrefix(value, "/"), field, value, "must be a path starting with /")
+}
+
+func (v *validator) httpsURL(field, value string) {
+	u, err := url.Parse(value)
+	v.check(err == nil && u.Scheme == "https" && u.Host != "", field, value, "must be an https URL")
+}
+
+func (v *validator) oneOf(field, value string, valid []string) {
+	for _, option := range valid {
+		if value == option {
+			return
+		}
+	}
+	v.check(false, field, value, "must be one of "+strings.Join(valid, ", "))
+}
+
+func (v *validator) rate(field string, value float64) {
+	v.check(value >= 0 && value <= 1, field, value, "must be between 0 and 1")
+}
+
+// faults checks the fault settings of a dependency, the fields under prefix
+func (v *validator) faults(prefix string, cfg FaultConfig) {
+	v.check(cfg.Latency >= 0, prefix+".latency", cfg.Latency, "must not be negative")
+	v.rate(prefix+".latency_rate", cfg.LatencyRate)
+	v.rate(prefix+".error_rate", cfg.ErrorRate)
+}
+
+func (v *validator) level(field, value string) {
+	var level zapcore.Level
+	v.check(level.Set(value) == nil, field, value, "must be a log level such as debug, info, warn or error")
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+	cfg *Config
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) SetupTest() {
+	cfg, err := Load()
+	s.Require().NoError(err)
+	s.cfg = cfg
+}
+
+func (s *ValidateTestSuite) fieldErrors(err error) []string {
+	var validationErr *ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func (s *ValidateTestSuite) TestAcceptsShippedConfig() {
+	s.NoError(s.cfg.Validate())
+}
+
+func (s *ValidateTestSuite) TestReportsEveryInvalidField() {
+	s.cfg.Database.Host = ""
+	s.cfg.Database.Port = "abc"
+	s.cfg.Database.MaxOpenConns = -1
+	s.cfg.Logger.Level = "verbose"
+
+	err := s.cfg.Validate()
+	s.Require().Error(err)
+	s.Equal([]string{"database.host", "database.port", "database.max_open_conns", "database.max_idle_conns", "logger.level"}, s.fieldErrors(err))
+	s.Contains(err.Error(), `database.port must be a port number between 1 and 65535, got "abc"`)
+}
+
+func (s *ValidateTestSuite) TestRejectsDefaultPageSizeOutOfBounds() {
+	s.cfg.Pagination.DefaultPageSize = s.cfg.Pagination.MaxPageSize + 1
+
+	s.Equal([]string{"pagination.default_page_size"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestFindsFieldErrors() {
+	s.cfg.Pagination.MinPageSize = 10
+	s.cfg.Pagination.MaxPageSize = 5
+
+	var fieldErr *FieldError
+	s.Require().True(errors.As(s.cfg.Validate(), &fieldErr))
+	s.Equal("pagination.max_page_size", fieldErr.Field)
+	s.Equal(uint32(5), fieldErr.Value)
+}
+
+func (s *ValidateTestSuite) TestChecksEnabledFeaturesOnly() {
+	s.cfg.Metrics.Port = "metrics"
+	s.cfg.Events.Kafka.Brokers = nil
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.Metrics.Enabled = true
+	s.cfg.Events.Driver = "kafka"
+	s.Equal([]string{"events.kafka.brokers", "metrics.port"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRejectsUnknownValues() {
+	s.cfg.Cache.Provider = "etcd"
+	s.cfg.Events.Driver = "sqs"
+	s.cfg.Database.SSLMode = "on"
+	s.cfg.Server.Keepalive.Time = -time.Second
+
+	s.Equal([]string{"server.keepalive.time", "database.sslmode", "cache.provider", "events.driver"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRejectsPostgresFeaturesOnMySQL() {
+	s.cfg.Database.Driver = "mysql"
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.Tenancy.Tenants = []string{"acme"}
+	s.cfg.Admin.Enabled = true
+	s.cfg.ChangeFeed.Enabled = true
+	s.Equal([]string{"tenancy.tenants", "admin.enabled", "change_feed.enabled"}, s.fieldErrors(s.cfg.Validate()))
+
+	s.cfg.Database.Driver = "oracle"
+	s.Equal([]string{"database.driver"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRejectsDatabaseFeaturesOnDynamoDB() {
+	s.cfg.Storage.Driver = "dynamodb"
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.Storage.DynamoDB.Table = ""
+	s.cfg.Tenancy.Tenants = []string{"acme"}
+	s.cfg.Admin.Enabled = true
+	s.cfg.ChangeFeed.Enabled = true
+	s.Equal([]string{"storage.dynamodb.table", "tenancy.tenants", "admin.enabled", "change_feed.enabled"}, s.fieldErrors(s.cfg.Validate()))
+
+	s.cfg.Storage.Driver = "cassandra"
+	s.Equal([]string{"storage.driver"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRejectsDatabaseOnRedisCluster() {
+	s.cfg.Redis.ClusterAddresses = []string{"node-1:6379"}
+	s.cfg.Redis.DB = 2
+	s.cfg.Redis.MinIdleConns = s.cfg.Redis.PoolSize + 1
+
+	s.Equal([]string{"redis.db", "redis.min_idle_conns"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRejectsShortCursorKeys() {
+	s.cfg.Pagination.CursorKeys = []string{strings.Repeat("k", 32), "short"}
+
+	err := s.cfg.Validate()
+	s.Equal([]string{"pagination.cursor_keys[1]"}, s.fieldErrors(err))
+	s.NotContains(err.Error(), "short")
+}
+
+func (s *ValidateTestSuite) TestRequiresMaxOffsetForOffsetPagination() {
+	s.cfg.Pagination.Offset.MaxOffset = 0
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.Pagination.Offset.Enabled = true
+	s.Equal([]string{"pagination.offset.max_offset"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRejectsFaultsInProd() {
+	s.cfg.Faults.Enabled = true
+	s.cfg.Faults.Cache.MissRate = 0.5
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.Server.Env = "prod"
+	s.cfg.Faults.GRPC.ErrorRate = 1.5
+	s.cfg.Faults.Database.Latency = -time.Second
+	s.Equal([]string{"faults.enabled", "faults.grpc.error_rate", "faults.database.latency"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestSkipsDatabaseConnectionOnDynamoDB() {
+	s.cfg.Storage.Driver = "dynamodb"
+	s.cfg.Database.Host = ""
+	s.cfg.Database.User = ""
+	s.cfg.Database.DBName = ""
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.Storage.Driver = "database"
+	s.Equal([]string{"database.host", "database.user", "database.dbname"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRequiresBurstOfOneOrMore() {
+	s.cfg.RateLimit.Enabled = true
+	s.cfg.RateLimit.Limit = 10
+	s.cfg.RateLimit.Period = time.Second
+	s.cfg.RateLimit.Burst = 1
+	s.NoError(s.cfg.Validate())
+
+	s.cfg.RateLimit.Burst = 0
+	s.Equal([]string{"rate_limit.burst"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestRequiresIssuerAndJWKSTogether() {
+	s.cfg.Auth.Enabled = true
+	s.cfg.Auth.Issuer = ""
+	s.cfg.Auth.JWKSURL = "https://auth.example.com/.well-known/jwks.json"
+	s.Equal([]string{"auth.issuer"}, s.fieldErrors(s.cfg.Validate()))
+
+	s.cfg.Auth.Issuer = "https://auth.example.com/"
+	s.cfg.Auth.JWKSURL = ""
+	s.Equal([]string{"auth.jwks_url"}, s.fieldErrors(s.cfg.Validate()))
+
+	s.cfg.Auth.Issuer = ""
+	s.cfg.Auth.APIKeys = nil
+	s.Equal([]string{"auth.jwks_url"}, s.fieldErrors(s.cfg.Validate()))
+
+	s.cfg.Auth.APIKeys = []APIKey{{Name: "support", SHA256: strings.Repeat("a", 64)}}
+	s.NoError(s.cfg.Validate())
+}
+
+func (s *ValidateTestSuite) TestChecksWebhooks() {
+	s.cfg.Webhooks.Endpoints = []string{"https://hooks.example.com/likes", "http://hooks.example.com/likes"}
+	s.cfg.Webhooks.Secret = ""
+
+	s.Equal([]string{"webhooks.endpoints[1]", "webhooks.secret"}, s.fieldErrors(s.cfg.Validate()))
+}
+
+func (s *ValidateTestSuite) TestChecksTenantNames() {
+	s.cfg.Tenancy.Tenants = []string{"acme", "Acme Corp"}
+
+	s.Equal([]string{"tenancy.tenants[1]"}, s.fieldErrors(s.cfg.Validate()))
+}
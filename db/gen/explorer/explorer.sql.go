@@ -7,12 +7,14 @@ package explorerdb
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countLikes = `-- name: CountLikes :one
-SELECT COUNT(*)
-FROM decisions
-WHERE recipient_user_id = $1 AND liked_recipient = true
+SELECT COALESCE((
+    SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = $1
+), 0)::bigint AS count
 `
 
 func (q *Queries) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
@@ -22,13 +24,63 @@ func (q *Queries) CountLikes(ctx context.Context, recipientUserID string) (int64
 	return count, err
 }
 
-const createDecision = `-- name: CreateDecision :exec
-INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at)
-VALUES ($1, $2, $3, NOW())
+const countRecentLikes = `-- name: CountRecentLikes :one
+SELECT COUNT(*)
+FROM decisions d
+WHERE d.recipient_user_id = $1 AND d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+)
+  AND d.created_at >= NOW() - make_interval(secs => $2::bigint)
+`
+
+type CountRecentLikesParams struct {
+	RecipientUserID string
+	MaxAgeSeconds   int64
+}
+
+func (q *Queries) CountRecentLikes(ctx context.Context, arg CountRecentLikesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRecentLikes, arg.RecipientUserID, arg.MaxAgeSeconds)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBlock = `-- name: CreateBlock :exec
+INSERT INTO blocks (blocker_user_id, blocked_user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (blocker_user_id, blocked_user_id) DO NOTHING
+`
+
+type CreateBlockParams struct {
+	BlockerUserID string
+	BlockedUserID string
+}
+
+func (q *Queries) CreateBlock(ctx context.Context, arg CreateBlockParams) error {
+	_, err := q.db.Exec(ctx, createBlock, arg.BlockerUserID, arg.BlockedUserID)
+	return err
+}
+
+const createDecision = `-- name: CreateDecision :one
+WITH previous AS (
+    SELECT d.liked_recipient FROM decisions d
+    WHERE d.actor_user_id = $1 AND d.recipient_user_id = $2
+    FOR UPDATE
+)
+INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
 ON CONFLICT (actor_user_id, recipient_user_id)
     DO UPDATE SET
                   liked_recipient = EXCLUDED.liked_recipient,
-                  created_at = NOW()
+                  created_at = NOW(),
+                  updated_at = NOW()
+RETURNING
+    NOT EXISTS (SELECT 1 FROM previous) AS inserted,
+    COALESCE((SELECT p.liked_recipient FROM previous p), false)::boolean AS previous_liked_recipient,
+    created_at
 `
 
 type CreateDecisionParams struct {
@@ -37,11 +89,113 @@ type CreateDecisionParams struct {
 	LikedRecipient  bool
 }
 
-func (q *Queries) CreateDecision(ctx context.Context, arg CreateDecisionParams) error {
-	_, err := q.db.Exec(ctx, createDecision, arg.ActorUserID, arg.RecipientUserID, arg.LikedRecipient)
+type CreateDecisionRow struct {
+	Inserted               bool
+	PreviousLikedRecipient bool
+	CreatedAt              pgtype.Timestamptz
+}
+
+func (q *Queries) CreateDecision(ctx context.Context, arg CreateDecisionParams) (CreateDecisionRow, error) {
+	row := q.db.QueryRow(ctx, createDecision, arg.ActorUserID, arg.RecipientUserID, arg.LikedRecipient)
+	var i CreateDecisionRow
+	err := row.Scan(&i.Inserted, &i.PreviousLikedRecipient, &i.CreatedAt)
+	return i, err
+}
+
+const createMatch = `-- name: CreateMatch :exec
+INSERT INTO matches (user_id, matched_user_id, matched_at)
+VALUES ($1, $2, NOW()), ($2, $1, NOW())
+ON CONFLICT (user_id, matched_user_id) DO NOTHING
+`
+
+type CreateMatchParams struct {
+	UserID        string
+	MatchedUserID string
+}
+
+func (q *Queries) CreateMatch(ctx context.Context, arg CreateMatchParams) error {
+	_, err := q.db.Exec(ctx, createMatch, arg.UserID, arg.MatchedUserID)
 	return err
 }
 
+const deleteBlock = `-- name: DeleteBlock :execrows
+DELETE FROM blocks
+WHERE blocker_user_id = $1 AND blocked_user_id = $2
+`
+
+type DeleteBlockParams struct {
+	BlockerUserID string
+	BlockedUserID string
+}
+
+func (q *Queries) DeleteBlock(ctx context.Context, arg DeleteBlockParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBlock, arg.BlockerUserID, arg.BlockedUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteDecision = `-- name: DeleteDecision :execrows
+DELETE FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2
+`
+
+type DeleteDecisionParams struct {
+	ActorUserID     string
+	RecipientUserID string
+}
+
+func (q *Queries) DeleteDecision(ctx context.Context, arg DeleteDecisionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDecision, arg.ActorUserID, arg.RecipientUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteMatch = `-- name: DeleteMatch :execrows
+DELETE FROM matches
+WHERE (user_id = $1 AND matched_user_id = $2) OR (user_id = $2 AND matched_user_id = $1)
+`
+
+type DeleteMatchParams struct {
+	UserID        string
+	MatchedUserID string
+}
+
+func (q *Queries) DeleteMatch(ctx context.Context, arg DeleteMatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteMatch, arg.UserID, arg.MatchedUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getDecision = `-- name: GetDecision :one
+SELECT id, actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2
+`
+
+type GetDecisionParams struct {
+	ActorUserID     string
+	RecipientUserID string
+}
+
+func (q *Queries) GetDecision(ctx context.Context, arg GetDecisionParams) (Decision, error) {
+	row := q.db.QueryRow(ctx, getDecision, arg.ActorUserID, arg.RecipientUserID)
+	var i Decision
+	err := row.Scan(
+		&i.ID,
+		&i.ActorUserID,
+		&i.RecipientUserID,
+		&i.LikedRecipient,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const hasMutualLike = `-- name: HasMutualLike :one
 SELECT EXISTS(
     SELECT 1 FROM decisions
@@ -63,3 +217,524 @@ func (q *Queries) HasMutualLike(ctx context.Context, arg HasMutualLikeParams) (*
 	err := row.Scan(&column_1)
 	return column_1, err
 }
+
+const isBlocked = `-- name: IsBlocked :one
+SELECT EXISTS(
+    SELECT 1 FROM blocks
+    WHERE (blocker_user_id = $1 AND blocked_user_id = $2) OR (blocker_user_id = $2 AND blocked_user_id = $1)
+)
+`
+
+type IsBlockedParams struct {
+	BlockerUserID string
+	BlockedUserID string
+}
+
+func (q *Queries) IsBlocked(ctx context.Context, arg IsBlockedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isBlocked, arg.BlockerUserID, arg.BlockedUserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listDecidersAsc = `-- name: ListDecidersAsc :many
+SELECT d.actor_user_id,
+       EXTRACT(EPOCH FROM d.created_at)::bigint AS timestamp,
+       EXISTS(
+           SELECT 1 FROM decisions back
+           WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+       )::boolean AS liked_back,
+       d.id,
+       d.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.id, latest.actor_user_id, latest.recipient_user_id, latest.liked_recipient, latest.created_at
+    FROM decisions latest
+    WHERE latest.recipient_user_id = $1
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC
+) d
+WHERE d.liked_recipient = $2
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+  )
+  AND ($3::bigint IS NULL OR d.created_at >= NOW() - make_interval(secs => $3::bigint))
+  AND ($4::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint >= $4::bigint)
+  AND ($5::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint < $5::bigint)
+  AND ($6::timestamptz IS NULL
+      OR (d.created_at, d.id) > ($6::timestamptz, $7::bigint))
+  AND ($8::boolean IS NULL OR EXISTS(
+      SELECT 1 FROM decisions back
+      WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+  ) = $8::boolean)
+ORDER BY d.created_at ASC, d.id ASC
+LIMIT $9::int OFFSET $10::int
+`
+
+type ListDecidersAscParams struct {
+	RecipientUserID string
+	LikedRecipient  bool
+	MaxAgeSeconds   *int64
+	Since           *int64
+	Until           *int64
+	AfterCreatedAt  pgtype.Timestamptz
+	AfterID         int64
+	LikedBack       *bool
+	PageLimit       int32
+	PageOffset      int32
+}
+
+type ListDecidersAscRow struct {
+	ActorUserID string
+	Timestamp   int64
+	LikedBack   bool
+	ID          int64
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) ListDecidersAsc(ctx context.Context, arg ListDecidersAscParams) ([]ListDecidersAscRow, error) {
+	rows, err := q.db.Query(ctx, listDecidersAsc,
+		arg.RecipientUserID,
+		arg.LikedRecipient,
+		arg.MaxAgeSeconds,
+		arg.Since,
+		arg.Until,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.LikedBack,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDecidersAscRow
+	for rows.Next() {
+		var i ListDecidersAscRow
+		if err := rows.Scan(
+			&i.ActorUserID,
+			&i.Timestamp,
+			&i.LikedBack,
+			&i.ID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDecidersDesc = `-- name: ListDecidersDesc :many
+SELECT d.actor_user_id,
+       EXTRACT(EPOCH FROM d.created_at)::bigint AS timestamp,
+       EXISTS(
+           SELECT 1 FROM decisions back
+           WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+       )::boolean AS liked_back,
+       d.id,
+       d.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.id, latest.actor_user_id, latest.recipient_user_id, latest.liked_recipient, latest.created_at
+    FROM decisions latest
+    WHERE latest.recipient_user_id = $1
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC
+) d
+WHERE d.liked_recipient = $2
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+  )
+  AND ($3::bigint IS NULL OR d.created_at >= NOW() - make_interval(secs => $3::bigint))
+  AND ($4::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint >= $4::bigint)
+  AND ($5::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint < $5::bigint)
+  AND ($6::timestamptz IS NULL
+      OR (d.created_at, d.id) < ($6::timestamptz, $7::bigint))
+  AND ($8::boolean IS NULL OR EXISTS(
+      SELECT 1 FROM decisions back
+      WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+  ) = $8::boolean)
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT $9::int OFFSET $10::int
+`
+
+type ListDecidersDescParams struct {
+	RecipientUserID string
+	LikedRecipient  bool
+	MaxAgeSeconds   *int64
+	Since           *int64
+	Until           *int64
+	AfterCreatedAt  pgtype.Timestamptz
+	AfterID         int64
+	LikedBack       *bool
+	PageLimit       int32
+	PageOffset      int32
+}
+
+type ListDecidersDescRow struct {
+	ActorUserID string
+	Timestamp   int64
+	LikedBack   bool
+	ID          int64
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) ListDecidersDesc(ctx context.Context, arg ListDecidersDescParams) ([]ListDecidersDescRow, error) {
+	rows, err := q.db.Query(ctx, listDecidersDesc,
+		arg.RecipientUserID,
+		arg.LikedRecipient,
+		arg.MaxAgeSeconds,
+		arg.Since,
+		arg.Until,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.LikedBack,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDecidersDescRow
+	for rows.Next() {
+		var i ListDecidersDescRow
+		if err := rows.Scan(
+			&i.ActorUserID,
+			&i.Timestamp,
+			&i.LikedBack,
+			&i.ID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLikersByPopularity = `-- name: ListLikersByPopularity :many
+SELECT d.actor_user_id,
+       EXTRACT(EPOCH FROM d.created_at)::bigint AS timestamp,
+       EXISTS(
+           SELECT 1 FROM decisions back
+           WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+       )::boolean AS liked_back,
+       d.id,
+       COALESCE(ps.score, 0)::double precision AS score
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.id, latest.actor_user_id, latest.recipient_user_id, latest.liked_recipient, latest.created_at
+    FROM decisions latest
+    WHERE latest.recipient_user_id = $1
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC
+) d
+LEFT JOIN popularity_scores ps ON ps.actor_user_id = d.actor_user_id
+WHERE d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+  )
+  AND ($2::bigint IS NULL OR d.created_at >= NOW() - make_interval(secs => $2::bigint))
+  AND ($3::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint >= $3::bigint)
+  AND ($4::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint < $4::bigint)
+  AND ($5::double precision IS NULL
+      OR (COALESCE(ps.score, 0)::double precision, d.id) < ($5::double precision, $6::bigint))
+ORDER BY COALESCE(ps.score, 0)::double precision DESC, d.id DESC
+LIMIT $7::int OFFSET $8::int
+`
+
+type ListLikersByPopularityParams struct {
+	RecipientUserID string
+	MaxAgeSeconds   *int64
+	Since           *int64
+	Until           *int64
+	AfterScore      *float64
+	AfterID         int64
+	PageLimit       int32
+	PageOffset      int32
+}
+
+type ListLikersByPopularityRow struct {
+	ActorUserID string
+	Timestamp   int64
+	LikedBack   bool
+	ID          int64
+	Score       float64
+}
+
+func (q *Queries) ListLikersByPopularity(ctx context.Context, arg ListLikersByPopularityParams) ([]ListLikersByPopularityRow, error) {
+	rows, err := q.db.Query(ctx, listLikersByPopularity,
+		arg.RecipientUserID,
+		arg.MaxAgeSeconds,
+		arg.Since,
+		arg.Until,
+		arg.AfterScore,
+		arg.AfterID,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLikersByPopularityRow
+	for rows.Next() {
+		var i ListLikersByPopularityRow
+		if err := rows.Scan(
+			&i.ActorUserID,
+			&i.Timestamp,
+			&i.LikedBack,
+			&i.ID,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNewLikersAsc = `-- name: ListNewLikersAsc :many
+SELECT nl.actor_user_id,
+       EXTRACT(EPOCH FROM nl.created_at)::bigint AS timestamp,
+       nl.decision_id AS id,
+       nl.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.decision_id, latest.actor_user_id, latest.recipient_user_id, latest.created_at
+    FROM new_likes latest
+    WHERE latest.recipient_user_id = $1
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.decision_id DESC
+) nl
+WHERE NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = nl.recipient_user_id AND b.blocked_user_id = nl.actor_user_id)
+       OR (b.blocker_user_id = nl.actor_user_id AND b.blocked_user_id = nl.recipient_user_id)
+  )
+  AND ($2::bigint IS NULL OR nl.created_at >= NOW() - make_interval(secs => $2::bigint))
+  AND ($3::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint >= $3::bigint)
+  AND ($4::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint < $4::bigint)
+  AND ($5::timestamptz IS NULL
+      OR (nl.created_at, nl.decision_id) > ($5::timestamptz, $6::bigint))
+ORDER BY nl.created_at ASC, nl.decision_id ASC
+LIMIT $7::int OFFSET $8::int
+`
+
+type ListNewLikersAscParams struct {
+	RecipientUserID string
+	MaxAgeSeconds   *int64
+	Since           *int64
+	Until           *int64
+	AfterCreatedAt  pgtype.Timestamptz
+	AfterID         int64
+	PageLimit       int32
+	PageOffset      int32
+}
+
+type ListNewLikersAscRow struct {
+	ActorUserID string
+	Timestamp   int64
+	ID          int64
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) ListNewLikersAsc(ctx context.Context, arg ListNewLikersAscParams) ([]ListNewLikersAscRow, error) {
+	rows, err := q.db.Query(ctx, listNewLikersAsc,
+		arg.RecipientUserID,
+		arg.MaxAgeSeconds,
+		arg.Since,
+		arg.Until,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNewLikersAscRow
+	for rows.Next() {
+		var i ListNewLikersAscRow
+		if err := rows.Scan(
+			&i.ActorUserID,
+			&i.Timestamp,
+			&i.ID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNewLikersDesc = `-- name: ListNewLikersDesc :many
+SELECT nl.actor_user_id,
+       EXTRACT(EPOCH FROM nl.created_at)::bigint AS timestamp,
+       nl.decision_id AS id,
+       nl.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.decision_id, latest.actor_user_id, latest.recipient_user_id, latest.created_at
+    FROM new_likes latest
+    WHERE latest.recipient_user_id = $1
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.decision_id DESC
+) nl
+WHERE NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = nl.recipient_user_id AND b.blocked_user_id = nl.actor_user_id)
+       OR (b.blocker_user_id = nl.actor_user_id AND b.blocked_user_id = nl.recipient_user_id)
+  )
+  AND ($2::bigint IS NULL OR nl.created_at >= NOW() - make_interval(secs => $2::bigint))
+  AND ($3::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint >= $3::bigint)
+  AND ($4::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint < $4::bigint)
+  AND ($5::timestamptz IS NULL
+      OR (nl.created_at, nl.decision_id) < ($5::timestamptz, $6::bigint))
+ORDER BY nl.created_at DESC, nl.decision_id DESC
+LIMIT $7::int OFFSET $8::int
+`
+
+type ListNewLikersDescParams struct {
+	RecipientUserID string
+	MaxAgeSeconds   *int64
+	Since           *int64
+	Until           *int64
+	AfterCreatedAt  pgtype.Timestamptz
+	AfterID         int64
+	PageLimit       int32
+	PageOffset      int32
+}
+
+type ListNewLikersDescRow struct {
+	ActorUserID string
+	Timestamp   int64
+	ID          int64
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) ListNewLikersDesc(ctx context.Context, arg ListNewLikersDescParams) ([]ListNewLikersDescRow, error) {
+	rows, err := q.db.Query(ctx, listNewLikersDesc,
+		arg.RecipientUserID,
+		arg.MaxAgeSeconds,
+		arg.Since,
+		arg.Until,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNewLikersDescRow
+	for rows.Next() {
+		var i ListNewLikersDescRow
+		if err := rows.Scan(
+			&i.ActorUserID,
+			&i.Timestamp,
+			&i.ID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockDecisionPair = `-- name: LockDecisionPair :exec
+SELECT pg_advisory_xact_lock(hashtextextended(
+    LEAST($1::text, $2::text) || ':' ||
+    GREATEST($1::text, $2::text), 0))
+`
+
+type LockDecisionPairParams struct {
+	UserID      string
+	OtherUserID string
+}
+
+func (q *Queries) LockDecisionPair(ctx context.Context, arg LockDecisionPairParams) error {
+	_, err := q.db.Exec(ctx, lockDecisionPair, arg.UserID, arg.OtherUserID)
+	return err
+}
+
+const purgeExpiredLikes = `-- name: PurgeExpiredLikes :execrows
+DELETE FROM decisions
+WHERE id IN (
+    SELECT id FROM decisions
+    WHERE liked_recipient = true AND created_at < NOW() - make_interval(secs => $1::bigint)
+    LIMIT $2::int
+)
+`
+
+type PurgeExpiredLikesParams struct {
+	MaxAgeSeconds int64
+	BatchSize     int32
+}
+
+func (q *Queries) PurgeExpiredLikes(ctx context.Context, arg PurgeExpiredLikesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeExpiredLikes, arg.MaxAgeSeconds, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reconcileLikeCounts = `-- name: ReconcileLikeCounts :one
+WITH actual AS (
+    SELECT d.recipient_user_id, COUNT(*) AS count
+    FROM decisions d
+    WHERE d.liked_recipient = true
+      AND NOT EXISTS(
+        SELECT 1 FROM blocks b
+        WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+           OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+    )
+    GROUP BY d.recipient_user_id
+), zeroed AS (
+    UPDATE like_counts lc
+    SET count = 0, updated_at = NOW()
+    WHERE lc.count <> 0
+      AND NOT EXISTS(SELECT 1 FROM actual a WHERE a.recipient_user_id = lc.recipient_user_id)
+    RETURNING lc.recipient_user_id
+), corrected AS (
+    INSERT INTO like_counts (recipient_user_id, count, updated_at)
+    SELECT a.recipient_user_id, a.count, NOW()
+    FROM actual a
+    ON CONFLICT (recipient_user_id)
+        DO UPDATE SET count = EXCLUDED.count, updated_at = NOW()
+        WHERE like_counts.count <> EXCLUDED.count
+    RETURNING like_counts.recipient_user_id
+)
+SELECT ((SELECT COUNT(*) FROM zeroed) + (SELECT COUNT(*) FROM corrected))::bigint AS corrected
+`
+
+func (q *Queries) ReconcileLikeCounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, reconcileLikeCounts)
+	var corrected int64
+	err := row.Scan(&corrected)
+	return corrected, err
+}
@@ -8,10 +8,101 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditEvent struct {
+	ID              int64
+	OccurredAt      pgtype.Timestamptz
+	Action          string
+	CallerID        string
+	CallerIsService bool
+	ClientAddress   string
+	RequestID       string
+	UserIds         []string
+	Outcome         string
+	Details         []byte
+}
+
+type Block struct {
+	ID            int64
+	BlockerUserID string
+	BlockedUserID string
+	CreatedAt     pgtype.Timestamptz
+}
+
 type Decision struct {
 	ID              int64
 	ActorUserID     string
 	RecipientUserID string
 	LikedRecipient  bool
 	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type ErasureReceipt struct {
+	ID          int64
+	SubjectHash string
+	ErasedAt    pgtype.Timestamptz
+	CallerID    string
+	RequestID   string
+	Decisions   int64
+	Matches     int64
+	Blocks      int64
+	Reports     int64
+	AuditEvents int64
+}
+
+type LikeCount struct {
+	RecipientUserID string
+	Count           int64
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type Match struct {
+	ID            int64
+	UserID        string
+	MatchedUserID string
+	MatchedAt     pgtype.Timestamptz
+}
+
+type NewLike struct {
+	DecisionID      int64
+	ActorUserID     string
+	RecipientUserID string
+	CreatedAt       pgtype.Timestamptz
+}
+
+type Outbox struct {
+	ID            int64
+	Topic         string
+	Payload       []byte
+	Attempts      int32
+	LastError     *string
+	NextAttemptAt pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+}
+
+type OutboxDeadLetter struct {
+	ID        int64
+	OutboxID  int64
+	Topic     string
+	Payload   []byte
+	Attempts  int32
+	LastError string
+	CreatedAt pgtype.Timestamptz
+	FailedAt  pgtype.Timestamptz
+}
+
+type PopularityScore struct {
+	ActorUserID string
+	Score       float64
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type Report struct {
+	ID             int64
+	ReporterUserID string
+	ReportedUserID string
+	Reason         string
+	ReporterLiked  *bool
+	ReportedLiked  *bool
+	CreatedAt      pgtype.Timestamptz
 }
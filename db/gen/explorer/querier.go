@@ -9,9 +9,45 @@ import (
 )
 
 type Querier interface {
+	AllowAuditEventErasure(ctx context.Context) error
+	AnonymizeUserAuditEvents(ctx context.Context, arg AnonymizeUserAuditEventsParams) (int64, error)
+	AnonymizeUserReports(ctx context.Context, arg AnonymizeUserReportsParams) (int64, error)
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]Outbox, error)
 	CountLikes(ctx context.Context, recipientUserID string) (int64, error)
-	CreateDecision(ctx context.Context, arg CreateDecisionParams) error
+	CountRecentLikes(ctx context.Context, arg CountRecentLikesParams) (int64, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateBlock(ctx context.Context, arg CreateBlockParams) error
+	CreateDecision(ctx context.Context, arg CreateDecisionParams) (CreateDecisionRow, error)
+	CreateErasureReceipt(ctx context.Context, arg CreateErasureReceiptParams) (CreateErasureReceiptRow, error)
+	CreateMatch(ctx context.Context, arg CreateMatchParams) error
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	CreateReport(ctx context.Context, arg CreateReportParams) (Report, error)
+	DeadLetterOutboxEvent(ctx context.Context, arg DeadLetterOutboxEventParams) error
+	DeleteBlock(ctx context.Context, arg DeleteBlockParams) (int64, error)
+	DeleteDecision(ctx context.Context, arg DeleteDecisionParams) (int64, error)
+	DeleteMatch(ctx context.Context, arg DeleteMatchParams) (int64, error)
+	DeleteOutboxEvent(ctx context.Context, id int64) error
+	DeleteUserBlocks(ctx context.Context, userID string) (int64, error)
+	DeleteUserDecisions(ctx context.Context, userID string) ([]DeleteUserDecisionsRow, error)
+	DeleteUserLikeCount(ctx context.Context, recipientUserID string) error
+	DeleteUserMatches(ctx context.Context, userID string) (int64, error)
+	DeleteUserPopularityScore(ctx context.Context, actorUserID string) error
+	GetDecision(ctx context.Context, arg GetDecisionParams) (Decision, error)
+	GetServiceStats(ctx context.Context) (GetServiceStatsRow, error)
 	HasMutualLike(ctx context.Context, arg HasMutualLikeParams) (*bool, error)
+	IsBlocked(ctx context.Context, arg IsBlockedParams) (bool, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListDecidersAsc(ctx context.Context, arg ListDecidersAscParams) ([]ListDecidersAscRow, error)
+	ListDecidersDesc(ctx context.Context, arg ListDecidersDescParams) ([]ListDecidersDescRow, error)
+	ListLikersByPopularity(ctx context.Context, arg ListLikersByPopularityParams) ([]ListLikersByPopularityRow, error)
+	ListNewLikersAsc(ctx context.Context, arg ListNewLikersAscParams) ([]ListNewLikersAscRow, error)
+	ListNewLikersDesc(ctx context.Context, arg ListNewLikersDescParams) ([]ListNewLikersDescRow, error)
+	ListUserDecisions(ctx context.Context, arg ListUserDecisionsParams) ([]ListUserDecisionsRow, error)
+	LockDecisionPair(ctx context.Context, arg LockDecisionPairParams) error
+	PurgeExpiredLikes(ctx context.Context, arg PurgeExpiredLikesParams) (int64, error)
+	ReconcileLikeCounts(ctx context.Context) (int64, error)
+	RetryOutboxEvent(ctx context.Context, arg RetryOutboxEventParams) error
+	UpsertDecisions(ctx context.Context, arg []UpsertDecisionsParams) *UpsertDecisionsBatchResults
 }
 
 var _ Querier = (*Queries)(nil)
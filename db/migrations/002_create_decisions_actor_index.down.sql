@@ -0,0 +1,2 @@
+-- Migration 002 rollback: Drop outbound likes index
+DROP INDEX IF EXISTS idx_decisions_actor_liked_created;
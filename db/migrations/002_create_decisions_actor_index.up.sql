@@ -0,0 +1,3 @@
+-- Migration 002: Index outbound likes by actor
+CREATE INDEX IF NOT EXISTS idx_decisions_actor_liked_created
+    ON decisions(actor_user_id, liked_recipient, created_at DESC);
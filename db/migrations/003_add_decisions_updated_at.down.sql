@@ -0,0 +1,2 @@
+-- Migration 003 rollback: Drop decisions updated_at column
+ALTER TABLE decisions DROP COLUMN IF EXISTS updated_at;
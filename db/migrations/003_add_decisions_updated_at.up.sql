@@ -0,0 +1,3 @@
+-- Migration 003: Track when a decision was last changed
+ALTER TABLE decisions
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
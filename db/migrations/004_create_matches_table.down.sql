@@ -0,0 +1,3 @@
+-- Migration 004 rollback: Drop matches table
+DROP INDEX IF EXISTS idx_matches_user_matched_at;
+DROP TABLE IF EXISTS matches;
@@ -0,0 +1,12 @@
+-- Migration 004: Create matches table
+-- Every match is stored once per participant so a user's matches can be listed with a single index scan
+CREATE TABLE IF NOT EXISTS matches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    matched_user_id VARCHAR(255) NOT NULL,
+    matched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, matched_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_matches_user_matched_at
+    ON matches(user_id, matched_at DESC);
@@ -0,0 +1,3 @@
+-- Migration 005 rollback: Drop blocks table
+DROP INDEX IF EXISTS idx_blocks_blocked_user;
+DROP TABLE IF EXISTS blocks;
@@ -0,0 +1,12 @@
+-- Migration 005: Create blocks table
+-- A block hides the two users from each other's likers lists and counts, whichever of them blocked
+CREATE TABLE IF NOT EXISTS blocks (
+    id BIGSERIAL PRIMARY KEY,
+    blocker_user_id VARCHAR(255) NOT NULL,
+    blocked_user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(blocker_user_id, blocked_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked_user
+    ON blocks(blocked_user_id, blocker_user_id);
@@ -0,0 +1,3 @@
+-- Migration 006 rollback: Drop reports table
+DROP INDEX IF EXISTS idx_reports_reported_user;
+DROP TABLE IF EXISTS reports;
@@ -0,0 +1,15 @@
+-- Migration 006: Create reports table
+-- reporter_liked and reported_liked snapshot each user's decision on the other when the report was filed;
+-- NULL means that user had not decided yet
+CREATE TABLE IF NOT EXISTS reports (
+    id BIGSERIAL PRIMARY KEY,
+    reporter_user_id VARCHAR(255) NOT NULL,
+    reported_user_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    reporter_liked BOOLEAN,
+    reported_liked BOOLEAN,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_reported_user
+    ON reports(reported_user_id, created_at DESC);
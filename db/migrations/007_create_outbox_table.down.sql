@@ -0,0 +1,3 @@
+-- Migration 007 rollback: Drop outbox table
+DROP INDEX IF EXISTS idx_outbox_next_attempt;
+DROP TABLE IF EXISTS outbox;
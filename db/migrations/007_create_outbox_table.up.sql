@@ -0,0 +1,15 @@
+-- Migration 007: Create outbox table
+-- Events are written here in the same transaction as the change they describe and deleted once
+-- dispatched; rows that ran out of attempts stay behind with their last_error for inspection
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt
+    ON outbox(next_attempt_at);
@@ -0,0 +1,3 @@
+-- Migration 008 rollback: Drop outbox dead letters table
+DROP INDEX IF EXISTS idx_outbox_dead_letters_failed_at;
+DROP TABLE IF EXISTS outbox_dead_letters;
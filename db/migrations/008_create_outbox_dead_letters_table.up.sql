@@ -0,0 +1,15 @@
+-- Migration 008: Create outbox dead letters table
+-- Outbox events whose final delivery attempt failed are moved here, keeping the outbox for deliverable events
+CREATE TABLE IF NOT EXISTS outbox_dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    outbox_id BIGINT NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_letters_failed_at
+    ON outbox_dead_letters(failed_at DESC);
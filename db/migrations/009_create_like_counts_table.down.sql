@@ -0,0 +1,7 @@
+-- Migration 009 rollback: Drop like_counts table and the triggers maintaining it
+DROP TRIGGER IF EXISTS blocks_like_counts ON blocks;
+DROP TRIGGER IF EXISTS decisions_like_counts ON decisions;
+DROP FUNCTION IF EXISTS count_block_likes();
+DROP FUNCTION IF EXISTS count_decision_likes();
+DROP FUNCTION IF EXISTS add_like_count(VARCHAR, VARCHAR, BIGINT);
+DROP TABLE IF EXISTS like_counts;
@@ -0,0 +1,97 @@
+-- Migration 009: Create like_counts table
+-- Holds the number of likes each recipient received, leaving out likes between users where
+-- either blocked the other. Triggers keep it in step with decisions and blocks in the same
+-- transaction as the write; likes past the like TTL are counted until the purge job deletes them.
+CREATE TABLE IF NOT EXISTS like_counts (
+    recipient_user_id VARCHAR(255) PRIMARY KEY,
+    count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- add_like_count moves the recipient's count by delta, unless the pair is blocked
+CREATE OR REPLACE FUNCTION add_like_count(actor VARCHAR, recipient VARCHAR, delta BIGINT) RETURNS VOID AS $$
+BEGIN
+    IF delta = 0 OR EXISTS(
+        SELECT 1 FROM blocks b
+        WHERE (b.blocker_user_id = recipient AND b.blocked_user_id = actor)
+           OR (b.blocker_user_id = actor AND b.blocked_user_id = recipient)
+    ) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO like_counts (recipient_user_id, count, updated_at)
+    VALUES (recipient, delta, NOW())
+    ON CONFLICT (recipient_user_id)
+        DO UPDATE SET count = like_counts.count + EXCLUDED.count, updated_at = NOW();
+END;
+$$ LANGUAGE plpgsql;
+
+-- A new like counts one up, a like turned into a pass or deleted counts one down
+CREATE OR REPLACE FUNCTION count_decision_likes() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM add_like_count(NEW.actor_user_id, NEW.recipient_user_id, CASE WHEN NEW.liked_recipient THEN 1 ELSE 0 END);
+    ELSIF TG_OP = 'UPDATE' THEN
+        PERFORM add_like_count(NEW.actor_user_id, NEW.recipient_user_id,
+            (CASE WHEN NEW.liked_recipient THEN 1 ELSE 0 END) - (CASE WHEN OLD.liked_recipient THEN 1 ELSE 0 END));
+    ELSE
+        PERFORM add_like_count(OLD.actor_user_id, OLD.recipient_user_id, CASE WHEN OLD.liked_recipient THEN -1 ELSE 0 END);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER decisions_like_counts
+    AFTER INSERT OR UPDATE OF liked_recipient OR DELETE ON decisions
+    FOR EACH ROW EXECUTE FUNCTION count_decision_likes();
+
+-- A block hides the likes either user gave the other, an unblock shows them again.
+-- Nothing changes while the block in the other direction still hides them.
+CREATE OR REPLACE FUNCTION count_block_likes() RETURNS TRIGGER AS $$
+DECLARE
+    blocker VARCHAR;
+    blocked VARCHAR;
+    delta BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        blocker := NEW.blocker_user_id;
+        blocked := NEW.blocked_user_id;
+        delta := -1;
+    ELSE
+        blocker := OLD.blocker_user_id;
+        blocked := OLD.blocked_user_id;
+        delta := 1;
+    END IF;
+
+    IF EXISTS(SELECT 1 FROM blocks b WHERE b.blocker_user_id = blocked AND b.blocked_user_id = blocker) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO like_counts (recipient_user_id, count, updated_at)
+    SELECT d.recipient_user_id, delta, NOW()
+    FROM decisions d
+    WHERE d.liked_recipient = true
+      AND ((d.actor_user_id = blocker AND d.recipient_user_id = blocked)
+        OR (d.actor_user_id = blocked AND d.recipient_user_id = blocker))
+    ON CONFLICT (recipient_user_id)
+        DO UPDATE SET count = like_counts.count + EXCLUDED.count, updated_at = NOW();
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER blocks_like_counts
+    AFTER INSERT OR DELETE ON blocks
+    FOR EACH ROW EXECUTE FUNCTION count_block_likes();
+
+-- Backfill from the likes recorded so far
+INSERT INTO like_counts (recipient_user_id, count, updated_at)
+SELECT d.recipient_user_id, COUNT(*), NOW()
+FROM decisions d
+WHERE d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+)
+GROUP BY d.recipient_user_id
+ON CONFLICT (recipient_user_id) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Migration 010 rollback: Drop new_likes table and the trigger maintaining it
+DROP TRIGGER IF EXISTS decisions_new_likes ON decisions;
+DROP FUNCTION IF EXISTS track_new_likes();
+DROP FUNCTION IF EXISTS sync_new_like(VARCHAR, VARCHAR);
+DROP TABLE IF EXISTS new_likes;
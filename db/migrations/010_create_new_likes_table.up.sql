@@ -0,0 +1,63 @@
+-- Migration 010: Create new_likes table
+-- Holds the likes whose recipient has not decided on the liker yet, so new likers are read
+-- from one index instead of joining decisions with itself. Triggers keep it in step with
+-- decisions in the same transaction as the write; blocks are still filtered when reading.
+CREATE TABLE IF NOT EXISTS new_likes (
+    decision_id BIGINT PRIMARY KEY REFERENCES decisions(id) ON DELETE CASCADE,
+    actor_user_id VARCHAR(255) NOT NULL,
+    recipient_user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(actor_user_id, recipient_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_new_likes_recipient_created
+    ON new_likes(recipient_user_id, created_at DESC, decision_id DESC);
+
+-- sync_new_like makes the actor's like of the recipient a new like exactly while it is a like
+-- and the recipient has no decision on the actor
+CREATE OR REPLACE FUNCTION sync_new_like(actor VARCHAR, recipient VARCHAR) RETURNS VOID AS $$
+BEGIN
+    DELETE FROM new_likes nl WHERE nl.actor_user_id = actor AND nl.recipient_user_id = recipient;
+
+    INSERT INTO new_likes (decision_id, actor_user_id, recipient_user_id, created_at)
+    SELECT d.id, d.actor_user_id, d.recipient_user_id, d.created_at
+    FROM decisions d
+    WHERE d.actor_user_id = actor AND d.recipient_user_id = recipient AND d.liked_recipient = true
+      AND NOT EXISTS(
+        SELECT 1 FROM decisions back
+        WHERE back.actor_user_id = recipient AND back.recipient_user_id = actor
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- A decision changes whether its own like is new, and whether the like in the other direction still is
+CREATE OR REPLACE FUNCTION track_new_likes() RETURNS TRIGGER AS $$
+DECLARE
+    changed decisions%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM sync_new_like(changed.actor_user_id, changed.recipient_user_id);
+    PERFORM sync_new_like(changed.recipient_user_id, changed.actor_user_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER decisions_new_likes
+    AFTER INSERT OR UPDATE OF liked_recipient, created_at OR DELETE ON decisions
+    FOR EACH ROW EXECUTE FUNCTION track_new_likes();
+
+-- Backfill from the decisions recorded so far
+INSERT INTO new_likes (decision_id, actor_user_id, recipient_user_id, created_at)
+SELECT d.id, d.actor_user_id, d.recipient_user_id, d.created_at
+FROM decisions d
+WHERE d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM decisions back
+    WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id
+)
+ON CONFLICT (decision_id) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Migration 011 rollback: Stop notifying listeners of decision changes
+DROP TRIGGER IF EXISTS decisions_notify_changes ON decisions;
+DROP FUNCTION IF EXISTS notify_decision_change();
@@ -0,0 +1,42 @@
+-- Migration 011: Notify listeners of decision changes
+-- Every committed change to a decision is announced on the decision_changes channel, so that
+-- each service instance can drop its cached listings and push new likes to its watchers
+-- whichever instance, job or tool wrote it. Notifications carry the schema the change was
+-- made in, telling the tenant apart, and are only delivered once the transaction commits.
+CREATE OR REPLACE FUNCTION notify_decision_change() RETURNS TRIGGER AS $$
+DECLARE
+    decision decisions%ROWTYPE;
+    liked_before BOOLEAN := false;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        decision := OLD;
+        liked_before := OLD.liked_recipient;
+    ELSE
+        decision := NEW;
+        IF TG_OP = 'UPDATE' THEN
+            liked_before := OLD.liked_recipient;
+        END IF;
+    END IF;
+
+    PERFORM pg_notify('decision_changes', json_build_object(
+        'schema', TG_TABLE_SCHEMA,
+        'op', TG_OP,
+        'actor_user_id', decision.actor_user_id,
+        'recipient_user_id', decision.recipient_user_id,
+        'liked_recipient', TG_OP <> 'DELETE' AND decision.liked_recipient,
+        'liked_before', liked_before,
+        'mutual', TG_OP <> 'DELETE' AND decision.liked_recipient AND EXISTS(
+            SELECT 1 FROM decisions back
+            WHERE back.actor_user_id = decision.recipient_user_id
+              AND back.recipient_user_id = decision.actor_user_id
+              AND back.liked_recipient = true
+        ),
+        'created_at', EXTRACT(EPOCH FROM decision.created_at)::bigint
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER decisions_notify_changes
+    AFTER INSERT OR UPDATE OF liked_recipient, created_at OR DELETE ON decisions
+    FOR EACH ROW EXECUTE FUNCTION notify_decision_change();
@@ -0,0 +1,5 @@
+-- Migration 012 rollback: Drop audit_events table
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS reject_audit_event_change();
+DROP INDEX IF EXISTS idx_audit_events_user_ids;
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,29 @@
+-- Migration 012: Create audit_events table
+-- An append-only trail of the calls that change users' data and of admin calls: who made them,
+-- from where, on which users and with what outcome. caller_id is empty for unauthenticated
+-- calls. A trigger rejects updates and deletes, so that recorded events cannot be rewritten.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    action VARCHAR(64) NOT NULL,
+    caller_id VARCHAR(255) NOT NULL,
+    caller_is_service BOOLEAN NOT NULL,
+    client_address VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL,
+    user_ids TEXT[] NOT NULL,
+    outcome VARCHAR(32) NOT NULL,
+    details JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_ids
+    ON audit_events USING GIN (user_ids);
+
+CREATE OR REPLACE FUNCTION reject_audit_event_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_event_change();
@@ -0,0 +1,2 @@
+-- Migration 013 rollback: Drop popularity_scores table
+DROP TABLE IF EXISTS popularity_scores;
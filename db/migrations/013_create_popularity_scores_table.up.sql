@@ -0,0 +1,8 @@
+-- Migration 013: Create popularity_scores table
+-- Holds the popularity score of each user, written by the ranking pipeline, by which likers
+-- can be ranked instead of by recency. Users without a score rank as scoring 0.
+CREATE TABLE IF NOT EXISTS popularity_scores (
+    actor_user_id VARCHAR(255) PRIMARY KEY,
+    score DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
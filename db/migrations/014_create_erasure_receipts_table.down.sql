@@ -0,0 +1,8 @@
+-- Migration 014 rollback: Drop erasure_receipts table and make audit_events strictly append-only again
+CREATE OR REPLACE FUNCTION reject_audit_event_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS erasure_receipts;
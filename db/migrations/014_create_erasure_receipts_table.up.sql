@@ -0,0 +1,30 @@
+-- Migration 014: Create erasure_receipts table
+-- Records every user erased under the right to erasure: when, on whose call and how much was
+-- erased. The user is only kept as the SHA-256 hash of their ID, so that the receipt of a data
+-- subject can be looked up from the ID they give without storing it.
+CREATE TABLE IF NOT EXISTS erasure_receipts (
+    id BIGSERIAL PRIMARY KEY,
+    subject_hash CHAR(64) NOT NULL,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    caller_id VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL,
+    decisions BIGINT NOT NULL,
+    matches BIGINT NOT NULL,
+    blocks BIGINT NOT NULL,
+    reports BIGINT NOT NULL,
+    audit_events BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasure_receipts_subject_hash
+    ON erasure_receipts(subject_hash);
+
+-- Audit events stay append-only, except that an erasure transaction, which sets explore.erasure,
+-- may rewrite them to scrub the erased user's ID
+CREATE OR REPLACE FUNCTION reject_audit_event_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND current_setting('explore.erasure', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
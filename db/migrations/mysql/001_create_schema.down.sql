@@ -0,0 +1,23 @@
+-- Rollback 001: Drop the explore schema on MySQL
+DROP TRIGGER IF EXISTS audit_events_no_delete;
+DROP TRIGGER IF EXISTS audit_events_no_update;
+DROP TRIGGER IF EXISTS blocks_after_delete;
+DROP TRIGGER IF EXISTS blocks_after_insert;
+DROP TRIGGER IF EXISTS decisions_after_delete;
+DROP TRIGGER IF EXISTS decisions_after_update;
+DROP TRIGGER IF EXISTS decisions_after_insert;
+DROP PROCEDURE IF EXISTS count_block_likes;
+DROP PROCEDURE IF EXISTS sync_new_like;
+DROP PROCEDURE IF EXISTS add_like_count;
+DROP TABLE IF EXISTS decision_pair_locks;
+DROP TABLE IF EXISTS erasure_receipts;
+DROP TABLE IF EXISTS popularity_scores;
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS new_likes;
+DROP TABLE IF EXISTS like_counts;
+DROP TABLE IF EXISTS outbox_dead_letters;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS matches;
+DROP TABLE IF EXISTS decisions;
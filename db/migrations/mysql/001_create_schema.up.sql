@@ -0,0 +1,209 @@
+-- Migration 001: Create the explore schema on MySQL
+-- The tables of the Postgres migrations up to 014, written for MySQL: see them for what each
+-- table holds. Timestamps are DATETIME(6) in UTC, the time zone the service's sessions run in.
+-- The triggers keeping like_counts and new_likes in step with decisions and blocks are ported
+-- too; decision changes are not notified, as the change feed is only available on Postgres.
+CREATE TABLE IF NOT EXISTS decisions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    actor_user_id VARCHAR(255) NOT NULL,
+    recipient_user_id VARCHAR(255) NOT NULL,
+    liked_recipient BOOLEAN NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE KEY uq_decisions_actor_recipient (actor_user_id, recipient_user_id),
+    KEY idx_decisions_created_at (created_at),
+    KEY idx_decisions_recipient_liked_created (recipient_user_id, liked_recipient, created_at),
+    KEY idx_decisions_actor_liked_created (actor_user_id, liked_recipient, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS matches (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    matched_user_id VARCHAR(255) NOT NULL,
+    matched_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE KEY uq_matches_user_matched (user_id, matched_user_id),
+    KEY idx_matches_user_matched_at (user_id, matched_at)
+);
+
+CREATE TABLE IF NOT EXISTS blocks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    blocker_user_id VARCHAR(255) NOT NULL,
+    blocked_user_id VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE KEY uq_blocks_blocker_blocked (blocker_user_id, blocked_user_id),
+    KEY idx_blocks_blocked_user (blocked_user_id, blocker_user_id)
+);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reporter_user_id VARCHAR(255) NOT NULL,
+    reported_user_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    reporter_liked BOOLEAN,
+    reported_liked BOOLEAN,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    KEY idx_reports_reported_user (reported_user_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    payload LONGBLOB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    KEY idx_outbox_next_attempt (next_attempt_at)
+);
+
+CREATE TABLE IF NOT EXISTS outbox_dead_letters (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    outbox_id BIGINT NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    payload LONGBLOB NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    failed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    KEY idx_outbox_dead_letters_failed_at (failed_at)
+);
+
+CREATE TABLE IF NOT EXISTS like_counts (
+    recipient_user_id VARCHAR(255) PRIMARY KEY,
+    count BIGINT NOT NULL DEFAULT 0,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+-- Without the foreign key of Postgres: the triggers below delete the new like of a deleted decision
+CREATE TABLE IF NOT EXISTS new_likes (
+    decision_id BIGINT PRIMARY KEY,
+    actor_user_id VARCHAR(255) NOT NULL,
+    recipient_user_id VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    UNIQUE KEY uq_new_likes_actor_recipient (actor_user_id, recipient_user_id),
+    KEY idx_new_likes_recipient_created (recipient_user_id, created_at, decision_id)
+);
+
+-- user_ids is a JSON array of strings, searched with JSON_CONTAINS
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    occurred_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    action VARCHAR(64) NOT NULL,
+    caller_id VARCHAR(255) NOT NULL,
+    caller_is_service BOOLEAN NOT NULL,
+    client_address VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL,
+    user_ids JSON NOT NULL,
+    outcome VARCHAR(32) NOT NULL,
+    details JSON NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS popularity_scores (
+    actor_user_id VARCHAR(255) PRIMARY KEY,
+    score DOUBLE NOT NULL,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+CREATE TABLE IF NOT EXISTS erasure_receipts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    subject_hash CHAR(64) NOT NULL,
+    erased_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    caller_id VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL,
+    decisions BIGINT NOT NULL,
+    matches BIGINT NOT NULL,
+    blocks BIGINT NOT NULL,
+    reports BIGINT NOT NULL,
+    audit_events BIGINT NOT NULL,
+    KEY idx_erasure_receipts_subject_hash (subject_hash)
+);
+
+-- One row per pair of users that made a decision on one another, locked until the end of the
+-- transaction recording a decision on the pair, in place of the advisory locks of Postgres
+CREATE TABLE IF NOT EXISTS decision_pair_locks (
+    pair CHAR(64) PRIMARY KEY
+);
+
+-- add_like_count moves the recipient's count by delta, unless the pair is blocked
+CREATE PROCEDURE add_like_count(IN actor VARCHAR(255), IN recipient VARCHAR(255), IN delta BIGINT)
+BEGIN
+    IF delta <> 0 AND NOT EXISTS(
+        SELECT 1 FROM blocks b
+        WHERE (b.blocker_user_id = recipient AND b.blocked_user_id = actor)
+           OR (b.blocker_user_id = actor AND b.blocked_user_id = recipient)
+    ) THEN
+        INSERT INTO like_counts (recipient_user_id, count, updated_at)
+        VALUES (recipient, delta, NOW(6))
+        ON DUPLICATE KEY UPDATE count = count + VALUES(count), updated_at = NOW(6);
+    END IF;
+END;
+
+-- sync_new_like makes the actor's like of the recipient a new like exactly while it is a like
+-- and the recipient has no decision on the actor
+CREATE PROCEDURE sync_new_like(IN actor VARCHAR(255), IN recipient VARCHAR(255))
+BEGIN
+    DELETE FROM new_likes WHERE actor_user_id = actor AND recipient_user_id = recipient;
+
+    INSERT INTO new_likes (decision_id, actor_user_id, recipient_user_id, created_at)
+    SELECT d.id, d.actor_user_id, d.recipient_user_id, d.created_at
+    FROM decisions d
+    WHERE d.actor_user_id = actor AND d.recipient_user_id = recipient AND d.liked_recipient = true
+      AND NOT EXISTS(
+        SELECT 1 FROM decisions back
+        WHERE back.actor_user_id = recipient AND back.recipient_user_id = actor
+    );
+END;
+
+-- A block hides the likes either user gave the other, an unblock shows them again.
+-- Nothing changes while the block in the other direction still hides them.
+CREATE PROCEDURE count_block_likes(IN blocker VARCHAR(255), IN blocked VARCHAR(255), IN delta BIGINT)
+BEGIN
+    IF NOT EXISTS(SELECT 1 FROM blocks b WHERE b.blocker_user_id = blocked AND b.blocked_user_id = blocker) THEN
+        INSERT INTO like_counts (recipient_user_id, count, updated_at)
+        SELECT d.recipient_user_id, delta, NOW(6)
+        FROM decisions d
+        WHERE d.liked_recipient = true
+          AND ((d.actor_user_id = blocker AND d.recipient_user_id = blocked)
+            OR (d.actor_user_id = blocked AND d.recipient_user_id = blocker))
+        ON DUPLICATE KEY UPDATE count = like_counts.count + VALUES(count), updated_at = NOW(6);
+    END IF;
+END;
+
+-- A new like counts one up, a like turned into a pass or deleted counts one down, and a decision
+-- changes whether its own like is new, and whether the like in the other direction still is
+CREATE TRIGGER decisions_after_insert AFTER INSERT ON decisions FOR EACH ROW
+BEGIN
+    CALL add_like_count(NEW.actor_user_id, NEW.recipient_user_id, IF(NEW.liked_recipient, 1, 0));
+    CALL sync_new_like(NEW.actor_user_id, NEW.recipient_user_id);
+    CALL sync_new_like(NEW.recipient_user_id, NEW.actor_user_id);
+END;
+
+CREATE TRIGGER decisions_after_update AFTER UPDATE ON decisions FOR EACH ROW
+BEGIN
+    CALL add_like_count(NEW.actor_user_id, NEW.recipient_user_id, IF(NEW.liked_recipient, 1, 0) - IF(OLD.liked_recipient, 1, 0));
+    IF NEW.liked_recipient <> OLD.liked_recipient OR NEW.created_at <> OLD.created_at THEN
+        CALL sync_new_like(NEW.actor_user_id, NEW.recipient_user_id);
+        CALL sync_new_like(NEW.recipient_user_id, NEW.actor_user_id);
+    END IF;
+END;
+
+CREATE TRIGGER decisions_after_delete AFTER DELETE ON decisions FOR EACH ROW
+BEGIN
+    CALL add_like_count(OLD.actor_user_id, OLD.recipient_user_id, IF(OLD.liked_recipient, -1, 0));
+    CALL sync_new_like(OLD.actor_user_id, OLD.recipient_user_id);
+    CALL sync_new_like(OLD.recipient_user_id, OLD.actor_user_id);
+END;
+
+CREATE TRIGGER blocks_after_insert AFTER INSERT ON blocks FOR EACH ROW
+    CALL count_block_likes(NEW.blocker_user_id, NEW.blocked_user_id, -1);
+
+CREATE TRIGGER blocks_after_delete AFTER DELETE ON blocks FOR EACH ROW
+    CALL count_block_likes(OLD.blocker_user_id, OLD.blocked_user_id, 1);
+
+-- Recorded audit events cannot be rewritten. MySQL has no transaction-local setting to let an
+-- erasure through as Postgres does, which is one reason the admin RPCs need Postgres.
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
@@ -0,0 +1,59 @@
+-- name: ListUserDecisions :many
+SELECT id, actor_user_id, recipient_user_id, liked_recipient,
+       EXTRACT(EPOCH FROM created_at)::bigint AS timestamp
+FROM decisions
+WHERE (actor_user_id = sqlc.arg(user_id)::text OR recipient_user_id = sqlc.arg(user_id)::text)
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit)::int;
+
+-- name: DeleteUserDecisions :many
+DELETE FROM decisions
+WHERE actor_user_id = sqlc.arg(user_id)::text OR recipient_user_id = sqlc.arg(user_id)::text
+RETURNING actor_user_id, recipient_user_id;
+
+-- name: DeleteUserMatches :execrows
+DELETE FROM matches
+WHERE user_id = sqlc.arg(user_id)::text OR matched_user_id = sqlc.arg(user_id)::text;
+
+-- name: DeleteUserBlocks :execrows
+DELETE FROM blocks
+WHERE blocker_user_id = sqlc.arg(user_id)::text OR blocked_user_id = sqlc.arg(user_id)::text;
+
+-- name: DeleteUserLikeCount :exec
+DELETE FROM like_counts
+WHERE recipient_user_id = $1;
+
+-- name: DeleteUserPopularityScore :exec
+DELETE FROM popularity_scores
+WHERE actor_user_id = $1;
+
+-- name: GetServiceStats :one
+SELECT
+    (SELECT GREATEST(c.reltuples, 0) FROM pg_class c WHERE c.oid = 'decisions'::regclass)::bigint AS decisions,
+    (SELECT COALESCE(SUM(lc.count), 0) FROM like_counts lc)::bigint AS likes,
+    (SELECT GREATEST(c.reltuples, 0) FROM pg_class c WHERE c.oid = 'matches'::regclass)::bigint AS matches,
+    (SELECT GREATEST(c.reltuples, 0) FROM pg_class c WHERE c.oid = 'blocks'::regclass)::bigint AS blocks,
+    (SELECT COUNT(*) FROM outbox)::bigint AS pending_outbox_events,
+    (SELECT COUNT(*) FROM outbox_dead_letters)::bigint AS dead_lettered_outbox_events;
+
+-- name: AllowAuditEventErasure :exec
+SELECT set_config('explore.erasure', 'on', true);
+
+-- name: AnonymizeUserAuditEvents :execrows
+UPDATE audit_events
+SET user_ids = array_replace(user_ids, sqlc.arg(user_id)::text, sqlc.arg(placeholder)::text),
+    caller_id = CASE WHEN caller_id = sqlc.arg(user_id)::text THEN sqlc.arg(placeholder)::text ELSE caller_id END,
+    details = '{}'::jsonb
+WHERE sqlc.arg(user_id)::text = ANY(user_ids) OR caller_id = sqlc.arg(user_id)::text;
+
+-- name: AnonymizeUserReports :execrows
+UPDATE reports
+SET reporter_user_id = CASE WHEN reporter_user_id = sqlc.arg(user_id)::text THEN sqlc.arg(placeholder)::text ELSE reporter_user_id END,
+    reported_user_id = CASE WHEN reported_user_id = sqlc.arg(user_id)::text THEN sqlc.arg(placeholder)::text ELSE reported_user_id END
+WHERE reporter_user_id = sqlc.arg(user_id)::text OR reported_user_id = sqlc.arg(user_id)::text;
+
+-- name: CreateErasureReceipt :one
+INSERT INTO erasure_receipts (subject_hash, caller_id, request_id, decisions, matches, blocks, reports, audit_events)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, EXTRACT(EPOCH FROM erased_at)::bigint AS erased_at;
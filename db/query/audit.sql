@@ -0,0 +1,14 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (action, caller_id, caller_is_service, client_address, request_id, user_ids, outcome, details)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListAuditEvents :many
+SELECT id, occurred_at, action, caller_id, caller_is_service, client_address, request_id, user_ids, outcome, details
+FROM audit_events
+WHERE (sqlc.narg(user_id)::text IS NULL OR sqlc.narg(user_id)::text = ANY(user_ids) OR caller_id = sqlc.narg(user_id)::text)
+  AND (sqlc.narg(action)::text IS NULL OR action = sqlc.narg(action)::text)
+  AND (sqlc.narg(since)::bigint IS NULL OR occurred_at >= to_timestamp(sqlc.narg(since)::bigint))
+  AND (sqlc.narg(until)::bigint IS NULL OR occurred_at < to_timestamp(sqlc.narg(until)::bigint))
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit)::int;
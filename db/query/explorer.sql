@@ -1,10 +1,20 @@
--- name: CreateDecision :exec
-INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at)
-VALUES ($1, $2, $3, NOW())
+-- name: CreateDecision :one
+WITH previous AS (
+    SELECT d.liked_recipient FROM decisions d
+    WHERE d.actor_user_id = $1 AND d.recipient_user_id = $2
+    FOR UPDATE
+)
+INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
 ON CONFLICT (actor_user_id, recipient_user_id)
     DO UPDATE SET
                   liked_recipient = EXCLUDED.liked_recipient,
-                  created_at = NOW();
+                  created_at = NOW(),
+                  updated_at = NOW()
+RETURNING
+    NOT EXISTS (SELECT 1 FROM previous) AS inserted,
+    COALESCE((SELECT p.liked_recipient FROM previous p), false)::boolean AS previous_liked_recipient,
+    created_at;
 
 -- name: HasMutualLike :one
 SELECT EXISTS(
@@ -16,6 +26,253 @@ SELECT EXISTS(
 );
 
 -- name: CountLikes :one
+SELECT COALESCE((
+    SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = $1
+), 0)::bigint AS count;
+
+-- name: CountRecentLikes :one
 SELECT COUNT(*)
-FROM decisions
-WHERE recipient_user_id = $1 AND liked_recipient = true;
+FROM decisions d
+WHERE d.recipient_user_id = $1 AND d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+)
+  AND d.created_at >= NOW() - make_interval(secs => sqlc.arg(max_age_seconds)::bigint);
+
+-- name: ListDecidersAsc :many
+SELECT d.actor_user_id,
+       EXTRACT(EPOCH FROM d.created_at)::bigint AS timestamp,
+       EXISTS(
+           SELECT 1 FROM decisions back
+           WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+       )::boolean AS liked_back,
+       d.id,
+       d.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.id, latest.actor_user_id, latest.recipient_user_id, latest.liked_recipient, latest.created_at
+    FROM decisions latest
+    WHERE latest.recipient_user_id = sqlc.arg(recipient_user_id)
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC
+) d
+WHERE d.liked_recipient = sqlc.arg(liked_recipient)
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+  )
+  AND (sqlc.narg(max_age_seconds)::bigint IS NULL OR d.created_at >= NOW() - make_interval(secs => sqlc.narg(max_age_seconds)::bigint))
+  AND (sqlc.narg(since)::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint >= sqlc.narg(since)::bigint)
+  AND (sqlc.narg(until)::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint < sqlc.narg(until)::bigint)
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+      OR (d.created_at, d.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.arg(after_id)::bigint))
+  AND (sqlc.narg(liked_back)::boolean IS NULL OR EXISTS(
+      SELECT 1 FROM decisions back
+      WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+  ) = sqlc.narg(liked_back)::boolean)
+ORDER BY d.created_at ASC, d.id ASC
+LIMIT sqlc.arg(page_limit)::int OFFSET sqlc.arg(page_offset)::int;
+
+-- name: ListDecidersDesc :many
+SELECT d.actor_user_id,
+       EXTRACT(EPOCH FROM d.created_at)::bigint AS timestamp,
+       EXISTS(
+           SELECT 1 FROM decisions back
+           WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+       )::boolean AS liked_back,
+       d.id,
+       d.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.id, latest.actor_user_id, latest.recipient_user_id, latest.liked_recipient, latest.created_at
+    FROM decisions latest
+    WHERE latest.recipient_user_id = sqlc.arg(recipient_user_id)
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC
+) d
+WHERE d.liked_recipient = sqlc.arg(liked_recipient)
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+  )
+  AND (sqlc.narg(max_age_seconds)::bigint IS NULL OR d.created_at >= NOW() - make_interval(secs => sqlc.narg(max_age_seconds)::bigint))
+  AND (sqlc.narg(since)::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint >= sqlc.narg(since)::bigint)
+  AND (sqlc.narg(until)::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint < sqlc.narg(until)::bigint)
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+      OR (d.created_at, d.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.arg(after_id)::bigint))
+  AND (sqlc.narg(liked_back)::boolean IS NULL OR EXISTS(
+      SELECT 1 FROM decisions back
+      WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+  ) = sqlc.narg(liked_back)::boolean)
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT sqlc.arg(page_limit)::int OFFSET sqlc.arg(page_offset)::int;
+
+-- name: ListLikersByPopularity :many
+SELECT d.actor_user_id,
+       EXTRACT(EPOCH FROM d.created_at)::bigint AS timestamp,
+       EXISTS(
+           SELECT 1 FROM decisions back
+           WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+       )::boolean AS liked_back,
+       d.id,
+       COALESCE(ps.score, 0)::double precision AS score
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.id, latest.actor_user_id, latest.recipient_user_id, latest.liked_recipient, latest.created_at
+    FROM decisions latest
+    WHERE latest.recipient_user_id = sqlc.arg(recipient_user_id)
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC
+) d
+LEFT JOIN popularity_scores ps ON ps.actor_user_id = d.actor_user_id
+WHERE d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+  )
+  AND (sqlc.narg(max_age_seconds)::bigint IS NULL OR d.created_at >= NOW() - make_interval(secs => sqlc.narg(max_age_seconds)::bigint))
+  AND (sqlc.narg(since)::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint >= sqlc.narg(since)::bigint)
+  AND (sqlc.narg(until)::bigint IS NULL OR EXTRACT(EPOCH FROM d.created_at)::bigint < sqlc.narg(until)::bigint)
+  AND (sqlc.narg(after_score)::double precision IS NULL
+      OR (COALESCE(ps.score, 0)::double precision, d.id) < (sqlc.narg(after_score)::double precision, sqlc.arg(after_id)::bigint))
+ORDER BY COALESCE(ps.score, 0)::double precision DESC, d.id DESC
+LIMIT sqlc.arg(page_limit)::int OFFSET sqlc.arg(page_offset)::int;
+
+-- name: ListNewLikersAsc :many
+SELECT nl.actor_user_id,
+       EXTRACT(EPOCH FROM nl.created_at)::bigint AS timestamp,
+       nl.decision_id AS id,
+       nl.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.decision_id, latest.actor_user_id, latest.recipient_user_id, latest.created_at
+    FROM new_likes latest
+    WHERE latest.recipient_user_id = sqlc.arg(recipient_user_id)
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.decision_id DESC
+) nl
+WHERE NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = nl.recipient_user_id AND b.blocked_user_id = nl.actor_user_id)
+       OR (b.blocker_user_id = nl.actor_user_id AND b.blocked_user_id = nl.recipient_user_id)
+  )
+  AND (sqlc.narg(max_age_seconds)::bigint IS NULL OR nl.created_at >= NOW() - make_interval(secs => sqlc.narg(max_age_seconds)::bigint))
+  AND (sqlc.narg(since)::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint >= sqlc.narg(since)::bigint)
+  AND (sqlc.narg(until)::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint < sqlc.narg(until)::bigint)
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+      OR (nl.created_at, nl.decision_id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.arg(after_id)::bigint))
+ORDER BY nl.created_at ASC, nl.decision_id ASC
+LIMIT sqlc.arg(page_limit)::int OFFSET sqlc.arg(page_offset)::int;
+
+-- name: ListNewLikersDesc :many
+SELECT nl.actor_user_id,
+       EXTRACT(EPOCH FROM nl.created_at)::bigint AS timestamp,
+       nl.decision_id AS id,
+       nl.created_at
+FROM (
+    SELECT DISTINCT ON (latest.actor_user_id)
+           latest.decision_id, latest.actor_user_id, latest.recipient_user_id, latest.created_at
+    FROM new_likes latest
+    WHERE latest.recipient_user_id = sqlc.arg(recipient_user_id)
+    ORDER BY latest.actor_user_id, latest.created_at DESC, latest.decision_id DESC
+) nl
+WHERE NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = nl.recipient_user_id AND b.blocked_user_id = nl.actor_user_id)
+       OR (b.blocker_user_id = nl.actor_user_id AND b.blocked_user_id = nl.recipient_user_id)
+  )
+  AND (sqlc.narg(max_age_seconds)::bigint IS NULL OR nl.created_at >= NOW() - make_interval(secs => sqlc.narg(max_age_seconds)::bigint))
+  AND (sqlc.narg(since)::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint >= sqlc.narg(since)::bigint)
+  AND (sqlc.narg(until)::bigint IS NULL OR EXTRACT(EPOCH FROM nl.created_at)::bigint < sqlc.narg(until)::bigint)
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+      OR (nl.created_at, nl.decision_id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.arg(after_id)::bigint))
+ORDER BY nl.created_at DESC, nl.decision_id DESC
+LIMIT sqlc.arg(page_limit)::int OFFSET sqlc.arg(page_offset)::int;
+
+-- name: GetDecision :one
+SELECT * FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2;
+
+-- name: LockDecisionPair :exec
+SELECT pg_advisory_xact_lock(hashtextextended(
+    LEAST(sqlc.arg(user_id)::text, sqlc.arg(other_user_id)::text) || ':' ||
+    GREATEST(sqlc.arg(user_id)::text, sqlc.arg(other_user_id)::text), 0));
+
+-- name: DeleteDecision :execrows
+DELETE FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2;
+
+-- name: CreateMatch :exec
+INSERT INTO matches (user_id, matched_user_id, matched_at)
+VALUES ($1, $2, NOW()), ($2, $1, NOW())
+ON CONFLICT (user_id, matched_user_id) DO NOTHING;
+
+-- name: DeleteMatch :execrows
+DELETE FROM matches
+WHERE (user_id = $1 AND matched_user_id = $2) OR (user_id = $2 AND matched_user_id = $1);
+
+-- name: CreateBlock :exec
+INSERT INTO blocks (blocker_user_id, blocked_user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (blocker_user_id, blocked_user_id) DO NOTHING;
+
+-- name: DeleteBlock :execrows
+DELETE FROM blocks
+WHERE blocker_user_id = $1 AND blocked_user_id = $2;
+
+-- name: IsBlocked :one
+SELECT EXISTS(
+    SELECT 1 FROM blocks
+    WHERE (blocker_user_id = $1 AND blocked_user_id = $2) OR (blocker_user_id = $2 AND blocked_user_id = $1)
+);
+
+-- name: UpsertDecisions :batchone
+INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+ON CONFLICT (actor_user_id, recipient_user_id)
+    DO UPDATE SET
+                  liked_recipient = EXCLUDED.liked_recipient,
+                  created_at = NOW(),
+                  updated_at = NOW()
+RETURNING (xmax = 0)::boolean AS inserted,
+          (liked_recipient AND EXISTS(
+              SELECT 1 FROM decisions d
+              WHERE d.actor_user_id = $2 AND d.recipient_user_id = $1 AND d.liked_recipient = true
+          ))::boolean AS mutual_like;
+
+-- name: PurgeExpiredLikes :execrows
+DELETE FROM decisions
+WHERE id IN (
+    SELECT id FROM decisions
+    WHERE liked_recipient = true AND created_at < NOW() - make_interval(secs => sqlc.arg(max_age_seconds)::bigint)
+    LIMIT sqlc.arg(batch_size)::int
+);
+
+-- name: ReconcileLikeCounts :one
+WITH actual AS (
+    SELECT d.recipient_user_id, COUNT(*) AS count
+    FROM decisions d
+    WHERE d.liked_recipient = true
+      AND NOT EXISTS(
+        SELECT 1 FROM blocks b
+        WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+           OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+    )
+    GROUP BY d.recipient_user_id
+), zeroed AS (
+    UPDATE like_counts lc
+    SET count = 0, updated_at = NOW()
+    WHERE lc.count <> 0
+      AND NOT EXISTS(SELECT 1 FROM actual a WHERE a.recipient_user_id = lc.recipient_user_id)
+    RETURNING lc.recipient_user_id
+), corrected AS (
+    INSERT INTO like_counts (recipient_user_id, count, updated_at)
+    SELECT a.recipient_user_id, a.count, NOW()
+    FROM actual a
+    ON CONFLICT (recipient_user_id)
+        DO UPDATE SET count = EXCLUDED.count, updated_at = NOW()
+        WHERE like_counts.count <> EXCLUDED.count
+    RETURNING like_counts.recipient_user_id
+)
+SELECT ((SELECT COUNT(*) FROM zeroed) + (SELECT COUNT(*) FROM corrected))::bigint AS corrected;
@@ -0,0 +1,31 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox (topic, payload, created_at, next_attempt_at)
+VALUES ($1, $2, NOW(), NOW());
+
+-- name: ClaimOutboxEvents :many
+SELECT * FROM outbox
+WHERE next_attempt_at <= NOW() AND attempts < sqlc.arg(max_attempts)::int
+ORDER BY id
+LIMIT sqlc.arg(batch_size)::int
+FOR UPDATE SKIP LOCKED;
+
+-- name: DeadLetterOutboxEvent :exec
+WITH moved AS (
+    DELETE FROM outbox
+    WHERE outbox.id = sqlc.arg(id)
+    RETURNING id, topic, payload, attempts, created_at
+)
+INSERT INTO outbox_dead_letters (outbox_id, topic, payload, attempts, last_error, created_at, failed_at)
+SELECT moved.id, moved.topic, moved.payload, moved.attempts + 1, sqlc.arg(last_error)::text, moved.created_at, NOW()
+FROM moved;
+
+-- name: DeleteOutboxEvent :exec
+DELETE FROM outbox
+WHERE id = $1;
+
+-- name: RetryOutboxEvent :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = sqlc.arg(last_error)::text,
+    next_attempt_at = NOW() + LEAST(make_interval(secs => POWER(2, attempts)), INTERVAL '5 minutes')
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,9 @@
+-- name: CreateReport :one
+INSERT INTO reports (reporter_user_id, reported_user_id, reason, reporter_liked, reported_liked, created_at)
+VALUES (
+    $1, $2, $3,
+    (SELECT liked_recipient FROM decisions WHERE actor_user_id = $1 AND recipient_user_id = $2),
+    (SELECT liked_recipient FROM decisions WHERE actor_user_id = $2 AND recipient_user_id = $1),
+    NOW()
+)
+RETURNING id, reporter_user_id, reported_user_id, reason, reporter_liked, reported_liked, created_at;
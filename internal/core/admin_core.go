@@ -0,0 +1,201 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/internal/repository"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+// AdminCore serves the support and operations tooling of the admin service
+type AdminCore interface {
+	ListUserDecisions(ctx context.Context, req *pb.ListUserDecisionsRequest) (*pb.ListUserDecisionsResponse, error)
+	ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest, send func(*pb.ExportUserDataChunk) error) error
+	PurgeUserData(ctx context.Context, req *pb.PurgeUserDataRequest) (*pb.PurgeUserDataResponse, error)
+	EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error)
+	InvalidateRecipientCache(ctx context.Context, req *pb.InvalidateRecipientCacheRequest) (*pb.InvalidateRecipientCacheResponse, error)
+	GetServiceStats(ctx context.Context, req *pb.GetServiceStatsRequest) (*pb.GetServiceStatsResponse, error)
+}
+
+// adminCore implements the business logic for the admin service
+type adminCore struct {
+	repo      repository.AdminRepository
+	cache     cache.CacheProvider
+	startedAt time.Time
+	logger    *zap.Logger
+}
+
+// NewAdminCore creates a new AdminCore working on the data in repo and the listings cached in cache
+func NewAdminCore(repo repository.AdminRepository, cache cache.CacheProvider, logger *zap.Logger) AdminCore {
+	return &adminCore{
+		repo:      repo,
+		cache:     cache,
+		startedAt: time.Now(),
+		logger:    logger,
+	}
+}
+
+// ListUserDecisions returns the decisions the user made and the decisions made on them
+func (a *adminCore) ListUserDecisions(ctx context.Context, req *pb.ListUserDecisionsRequest) (*pb.ListUserDecisionsResponse, error) {
+	decisions, nextToken, err := a.repo.ListUserDecisions(ctx, req.UserId, models.PageRequest{
+		Token: req.GetPaginationToken(),
+		Size:  int(req.GetPageSize()),
+	})
+	if err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to list user decisions", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list user decisions")
+	}
+
+	pbDecisions := make([]*pb.ListUserDecisionsResponse_Decision, len(decisions))
+	for i, decision := range decisions {
+		pbDecisions[i] = &pb.ListUserDecisionsResponse_Decision{
+			ActorUserId:     decision.ActorUserID,
+			RecipientUserId: decision.RecipientUserID,
+			LikedRecipient:  decision.LikedRecipient,
+			UnixTimestamp:   uint64(decision.Timestamp),
+		}
+	}
+
+	response := &pb.ListUserDecisionsResponse{
+		Decisions: pbDecisions,
+	}
+
+	if nextToken != "" {
+		response.NextPaginationToken = &nextToken
+	}
+
+	return response, nil
+}
+
+// ExportUserData calls send with the chunks of an export of the decisions the user made and the
+// decisions made on them, in the requested format. Decisions are encoded as they are read, so
+// that no more than a chunk of the export is held in memory.
+func (a *adminCore) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest, send func(*pb.ExportUserDataChunk) error) error {
+	out := &chunkWriter{send: send}
+	encoder, err := newDecisionEncoder(out, req.Format, req.UserId)
+	if err == nil {
+		err = a.repo.StreamUserDecisions(ctx, req.UserId, encoder.Encode)
+	}
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to export user data", zap.Error(err))
+		return status.Error(codes.Internal, "failed to export user data")
+	}
+	return nil
+}
+
+// PurgeUserData deletes the user's data and drops the cached listings of the user and of every
+// user their decisions concerned. The data is gone once the purge commits, so failing to drop
+// the cache is logged, leaving stale listings to expire with their TTL.
+func (a *adminCore) PurgeUserData(ctx context.Context, req *pb.PurgeUserDataRequest) (*pb.PurgeUserDataResponse, error) {
+	summary, err := a.repo.PurgeUserData(ctx, req.UserId)
+	if err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to purge user data", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to purge user data")
+	}
+
+	utils.Logger(ctx, a.logger).Info("User data purged",
+		zap.String("user_id", req.UserId),
+		zap.Int64("decisions", summary.Decisions),
+		zap.Int64("matches", summary.Matches),
+		zap.Int64("blocks", summary.Blocks),
+	)
+
+	if err := a.invalidateUserCache(ctx, append([]string{req.UserId}, summary.Counterparts...)...); err != nil {
+		utils.Logger(ctx, a.logger).Warn("Failed to invalidate cache of purged user", zap.Error(err))
+	}
+
+	return &pb.PurgeUserDataResponse{
+		DecisionsDeleted: uint64(summary.Decisions),
+		MatchesDeleted:   uint64(summary.Matches),
+		BlocksDeleted:    uint64(summary.Blocks),
+	}, nil
+}
+
+// EraseUserData erases the user under the right to erasure and drops the cached listings of the
+// user and of every user their decisions concerned. The erasure is logged by its receipt only, so
+// that the logs do not keep the erased ID either.
+func (a *adminCore) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	caller, _ := utils.CallerFromContext(ctx)
+	receipt, err := a.repo.EraseUserData(ctx, req.UserId, caller.UserID, utils.RequestIDFromContext(ctx))
+	if err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to erase user data", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to erase user data")
+	}
+
+	utils.Logger(ctx, a.logger).Info("User data erased", zap.Int64("receipt_id", receipt.ID))
+
+	if err := a.invalidateUserCache(ctx, append([]string{req.UserId}, receipt.Counterparts...)...); err != nil {
+		utils.Logger(ctx, a.logger).Warn("Failed to invalidate cache of erased user",
+			zap.Int64("receipt_id", receipt.ID), zap.Error(err))
+	}
+
+	return &pb.EraseUserDataResponse{
+		ReceiptId:             receipt.ID,
+		ErasedUnix:            uint64(receipt.ErasedAt),
+		DecisionsDeleted:      uint64(receipt.Decisions),
+		MatchesDeleted:        uint64(receipt.Matches),
+		BlocksDeleted:         uint64(receipt.Blocks),
+		ReportsAnonymized:     uint64(receipt.Reports),
+		AuditEventsAnonymized: uint64(receipt.AuditEvents),
+	}, nil
+}
+
+// InvalidateRecipientCache drops the recipient's cached listings, likers index and likers count,
+// to be rebuilt from the DB on their next read
+func (a *adminCore) InvalidateRecipientCache(ctx context.Context, req *pb.InvalidateRecipientCacheRequest) (*pb.InvalidateRecipientCacheResponse, error) {
+	if err := a.invalidateUserCache(ctx, req.RecipientUserId); err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to invalidate recipient cache", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to invalidate recipient cache")
+	}
+	return &pb.InvalidateRecipientCacheResponse{}, nil
+}
+
+// invalidateUserCache invalidates every cached listing of the users, at any depth and page
+// size, and drops their likers counts and likers indexes. Other instances stop serving their
+// in-process copies of the listings within cache.local_ttl.
+func (a *adminCore) invalidateUserCache(ctx context.Context, userIDs ...string) error {
+	if err := bumpCacheGenerations(ctx, a.cache, userIDs...); err != nil {
+		return err
+	}
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = utils.LikersCountKey(ctx, id)
+	}
+	if err := a.cache.Del(ctx, keys...); err != nil {
+		return err
+	}
+	return a.cache.DelLikersIndex(ctx, userIDs...)
+}
+
+// GetServiceStats reports the size of the service's tables and how long this instance has run
+func (a *adminCore) GetServiceStats(ctx context.Context, req *pb.GetServiceStatsRequest) (*pb.GetServiceStatsResponse, error) {
+	stats, err := a.repo.GetServiceStats(ctx)
+	if err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to get service stats", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get service stats")
+	}
+
+	return &pb.GetServiceStatsResponse{
+		Decisions:                uint64(stats.Decisions),
+		Likes:                    uint64(stats.Likes),
+		Matches:                  uint64(stats.Matches),
+		Blocks:                   uint64(stats.Blocks),
+		PendingOutboxEvents:      uint64(stats.PendingOutboxEvents),
+		DeadLetteredOutboxEvents: uint64(stats.DeadLetteredOutboxEvents),
+		UptimeSeconds:            uint64(time.Since(a.startedAt).Seconds()),
+	}, nil
+}
@@ -0,0 +1,288 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/internal/models"
+	cachemock "github.com/backend-interview-task/mocks/providers/cache"
+	repomock "github.com/backend-interview-task/mocks/repository"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+type AdminCoreTestSuite struct {
+	suite.Suite
+	mockAdminRepo *repomock.AdminRepository
+	mockCache     *cachemock.CacheProvider
+	adminCore     AdminCore
+	ctx           context.Context
+}
+
+func TestAdminCoreTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminCoreTestSuite))
+}
+
+func (s *AdminCoreTestSuite) SetupTest() {
+	s.mockAdminRepo = new(repomock.AdminRepository)
+	s.mockCache = new(cachemock.CacheProvider)
+	s.adminCore = NewAdminCore(s.mockAdminRepo, s.mockCache, zap.NewNop())
+	s.ctx = context.Background()
+}
+
+func (s *AdminCoreTestSuite) TearDownTest() {
+	s.mockAdminRepo.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+}
+
+// userCacheKeys returns the cache keys PurgeUserData and InvalidateRecipientCache drop for userIDs
+func userCacheKeys(userIDs ...string) []interface{} {
+	var keys []interface{}
+	for _, id := range userIDs {
+		keys = append(keys, utils.LikersCountKey(context.Background(), id))
+	}
+	return keys
+}
+
+func (s *AdminCoreTestSuite) TestListUserDecisions_Success() {
+	pageSize := uint32(2)
+	req := &pb.ListUserDecisionsRequest{UserId: "user123", PageSize: &pageSize}
+	s.mockAdminRepo.EXPECT().ListUserDecisions(s.ctx, "user123", models.PageRequest{Size: 2}).
+		Return([]models.UserDecision{
+			{ID: 9, ActorUserID: "user123", RecipientUserID: "user456", LikedRecipient: true, Timestamp: 1640995300},
+			{ID: 7, ActorUserID: "user789", RecipientUserID: "user123", Timestamp: 1640995200},
+		}, "next-token", nil).Once()
+
+	resp, err := s.adminCore.ListUserDecisions(s.ctx, req)
+
+	s.NoError(err)
+	s.Require().Len(resp.Decisions, 2)
+	s.Equal("user456", resp.Decisions[0].RecipientUserId)
+	s.True(resp.Decisions[0].LikedRecipient)
+	s.Equal(uint64(1640995200), resp.Decisions[1].UnixTimestamp)
+	s.Equal("next-token", resp.GetNextPaginationToken())
+}
+
+func (s *AdminCoreTestSuite) TestListUserDecisions_LastPage() {
+	s.mockAdminRepo.EXPECT().ListUserDecisions(s.ctx, "user123", models.PageRequest{}).
+		Return(nil, "", nil).Once()
+
+	resp, err := s.adminCore.ListUserDecisions(s.ctx, &pb.ListUserDecisionsRequest{UserId: "user123"})
+
+	s.NoError(err)
+	s.Empty(resp.Decisions)
+	s.Nil(resp.NextPaginationToken)
+}
+
+// expectStream expects the decisions of user123 to be streamed as decisions
+func (s *AdminCoreTestSuite) expectStream(decisions ...models.UserDecision) {
+	s.mockAdminRepo.EXPECT().StreamUserDecisions(s.ctx, "user123", mock.Anything).
+		RunAndReturn(func(ctx context.Context, userID string, fn func(models.UserDecision) error) error {
+			for _, decision := range decisions {
+				if err := fn(decision); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+}
+
+// export runs ExportUserData for req and returns the chunks it sent
+func (s *AdminCoreTestSuite) export(req *pb.ExportUserDataRequest) ([]string, error) {
+	var chunks []string
+	err := s.adminCore.ExportUserData(s.ctx, req, func(chunk *pb.ExportUserDataChunk) error {
+		chunks = append(chunks, string(chunk.Data))
+		return nil
+	})
+	return chunks, err
+}
+
+func (s *AdminCoreTestSuite) TestExportUserData_Formats() {
+	decisions := []models.UserDecision{
+		{ID: 4, ActorUserID: "user123", RecipientUserID: "user000", LikedRecipient: true, Timestamp: 1640995100},
+		{ID: 7, ActorUserID: "user789", RecipientUserID: "user123", Timestamp: 1640995200},
+	}
+	testCases := []struct {
+		name     string
+		format   pb.ExportFormat
+		expected string
+	}{
+		{
+			name:   "json",
+			format: pb.ExportFormat_JSON,
+			expected: `{"user_id":"user123","decisions":[` +
+				`{"actor_user_id":"user123","recipient_user_id":"user000","liked_recipient":true,"unix_timestamp":1640995100},` +
+				`{"actor_user_id":"user789","recipient_user_id":"user123","liked_recipient":false,"unix_timestamp":1640995200}]}` + "\n",
+		},
+		{
+			name:   "csv",
+			format: pb.ExportFormat_CSV,
+			expected: "actor_user_id,recipient_user_id,liked_recipient,unix_timestamp\n" +
+				"user123,user000,true,1640995100\n" +
+				"user789,user123,false,1640995200\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.expectStream(decisions...)
+
+			chunks, err := s.export(&pb.ExportUserDataRequest{UserId: "user123", Format: tc.format})
+
+			s.NoError(err)
+			s.Equal([]string{tc.expected}, chunks)
+		})
+	}
+}
+
+func (s *AdminCoreTestSuite) TestExportUserData_NoDecisions() {
+	s.expectStream()
+
+	chunks, err := s.export(&pb.ExportUserDataRequest{UserId: "user123"})
+
+	s.NoError(err)
+	s.Equal([]string{`{"user_id":"user123","decisions":[]}` + "\n"}, chunks)
+}
+
+func (s *AdminCoreTestSuite) TestExportUserData_SendsChunks() {
+	decisions := make([]models.UserDecision, 2000)
+	for i := range decisions {
+		decisions[i] = models.UserDecision{ID: int64(i), ActorUserID: "user123", RecipientUserID: fmt.Sprintf("user%04d", i)}
+	}
+	s.expectStream(decisions...)
+
+	chunks, err := s.export(&pb.ExportUserDataRequest{UserId: "user123", Format: pb.ExportFormat_CSV})
+
+	s.NoError(err)
+	s.Greater(len(chunks), 1)
+	s.GreaterOrEqual(len(chunks[0]), exportChunkSize)
+	s.Equal(len(decisions)+1, strings.Count(strings.Join(chunks, ""), "\n"))
+}
+
+func (s *AdminCoreTestSuite) TestExportUserData_RepositoryError() {
+	s.mockAdminRepo.EXPECT().StreamUserDecisions(s.ctx, "user123", mock.Anything).
+		Return(errors.New("database error")).Once()
+
+	_, err := s.export(&pb.ExportUserDataRequest{UserId: "user123"})
+
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *AdminCoreTestSuite) TestPurgeUserData_InvalidatesCounterparts() {
+	s.mockAdminRepo.EXPECT().PurgeUserData(s.ctx, "user123").
+		Return(models.PurgeSummary{Decisions: 3, Matches: 1, Blocks: 2, Counterparts: []string{"user456", "user789"}}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf("user123", "user456", "user789"), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, userCacheKeys("user123", "user456", "user789")...).Return(nil).Once()
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, "user123", "user456", "user789").Return(nil).Once()
+
+	resp, err := s.adminCore.PurgeUserData(s.ctx, &pb.PurgeUserDataRequest{UserId: "user123"})
+
+	s.NoError(err)
+	s.Equal(uint64(3), resp.DecisionsDeleted)
+	s.Equal(uint64(1), resp.MatchesDeleted)
+	s.Equal(uint64(2), resp.BlocksDeleted)
+}
+
+func (s *AdminCoreTestSuite) TestPurgeUserData_CacheErrorIgnored() {
+	s.mockAdminRepo.EXPECT().PurgeUserData(s.ctx, "user123").
+		Return(models.PurgeSummary{Decisions: 1}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf("user123"), utils.CacheGenerationTTL).Return(errors.New("cache down")).Once()
+
+	resp, err := s.adminCore.PurgeUserData(s.ctx, &pb.PurgeUserDataRequest{UserId: "user123"})
+
+	s.NoError(err)
+	s.Equal(uint64(1), resp.DecisionsDeleted)
+}
+
+func (s *AdminCoreTestSuite) TestPurgeUserData_RepositoryError() {
+	s.mockAdminRepo.EXPECT().PurgeUserData(s.ctx, "user123").
+		Return(models.PurgeSummary{}, errors.New("database error")).Once()
+
+	resp, err := s.adminCore.PurgeUserData(s.ctx, &pb.PurgeUserDataRequest{UserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *AdminCoreTestSuite) TestEraseUserData_Success() {
+	ctx := utils.WithRequestID(utils.WithCaller(s.ctx, utils.Caller{UserID: "support", Service: true}), "req-1")
+	s.mockAdminRepo.EXPECT().EraseUserData(ctx, "user123", "support", "req-1").
+		Return(models.ErasureReceipt{
+			ID:           42,
+			ErasedAt:     1640995200,
+			PurgeSummary: models.PurgeSummary{Decisions: 3, Matches: 1, Counterparts: []string{"user456"}},
+			Reports:      2,
+			AuditEvents:  5,
+		}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf("user123", "user456"), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, userCacheKeys("user123", "user456")...).Return(nil).Once()
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, "user123", "user456").Return(nil).Once()
+
+	resp, err := s.adminCore.EraseUserData(ctx, &pb.EraseUserDataRequest{UserId: "user123"})
+
+	s.NoError(err)
+	s.Equal(&pb.EraseUserDataResponse{
+		ReceiptId:             42,
+		ErasedUnix:            1640995200,
+		DecisionsDeleted:      3,
+		MatchesDeleted:        1,
+		ReportsAnonymized:     2,
+		AuditEventsAnonymized: 5,
+	}, resp)
+}
+
+func (s *AdminCoreTestSuite) TestEraseUserData_RepositoryError() {
+	s.mockAdminRepo.EXPECT().EraseUserData(s.ctx, "user123", "", "").
+		Return(models.ErasureReceipt{}, errors.New("database error")).Once()
+
+	resp, err := s.adminCore.EraseUserData(s.ctx, &pb.EraseUserDataRequest{UserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *AdminCoreTestSuite) TestInvalidateRecipientCache_Success() {
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf("user123"), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, userCacheKeys("user123")...).Return(nil).Once()
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, "user123").Return(nil).Once()
+
+	resp, err := s.adminCore.InvalidateRecipientCache(s.ctx, &pb.InvalidateRecipientCacheRequest{RecipientUserId: "user123"})
+
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *AdminCoreTestSuite) TestInvalidateRecipientCache_CacheError() {
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf("user123"), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, userCacheKeys("user123")...).Return(nil).Once()
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, "user123").Return(errors.New("cache down")).Once()
+
+	resp, err := s.adminCore.InvalidateRecipientCache(s.ctx, &pb.InvalidateRecipientCacheRequest{RecipientUserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *AdminCoreTestSuite) TestGetServiceStats_Success() {
+	s.mockAdminRepo.EXPECT().GetServiceStats(s.ctx).
+		Return(models.ServiceStats{Decisions: 1200, Likes: 800, Matches: 30, Blocks: 15, PendingOutboxEvents: 3, DeadLetteredOutboxEvents: 1}, nil).Once()
+
+	resp, err := s.adminCore.GetServiceStats(s.ctx, &pb.GetServiceStatsRequest{})
+
+	s.NoError(err)
+	s.Equal(uint64(1200), resp.Decisions)
+	s.Equal(uint64(800), resp.Likes)
+	s.Equal(uint64(30), resp.Matches)
+	s.Equal(uint64(15), resp.Blocks)
+	s.Equal(uint64(3), resp.PendingOutboxEvents)
+	s.Equal(uint64(1), resp.DeadLetteredOutboxEvents)
+}
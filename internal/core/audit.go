@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/repository"
+	pb "github.com/backend-interview-task/proto"
+)
+
+// auditedExplorerCore records the calls that change users' data, and the admin calls, in the
+// audit trail once they return, whether they succeed or not. Recording them in the core covers
+// every API serving them, gRPC and GraphQL alike. Actions are named after the RPCs.
+type auditedExplorerCore struct {
+	ExplorerCore
+	auditor *auditor
+}
+
+// NewAuditedExplorerCore records core's calls that change users' data in the audit trail kept in repo
+func NewAuditedExplorerCore(core ExplorerCore, repo repository.AuditRepository, logger *zap.Logger) ExplorerCore {
+	return &auditedExplorerCore{ExplorerCore: core, auditor: newAuditor(repo, logger)}
+}
+
+func (c *auditedExplorerCore) CreateDecision(ctx context.Context, req *pb.PutDecisionRequest) (resp *pb.PutDecisionResponse, err error) {
+	defer func() { c.auditor.record(ctx, "PutDecision", decisionUserIDs(req), req, err) }()
+	return c.ExplorerCore.CreateDecision(ctx, req)
+}
+
+func (c *auditedExplorerCore) DeleteDecision(ctx context.Context, req *pb.DeleteDecisionRequest) (resp *pb.DeleteDecisionResponse, err error) {
+	defer func() {
+		c.auditor.record(ctx, "DeleteDecision", []string{req.ActorUserId, req.RecipientUserId}, req, err)
+	}()
+	return c.ExplorerCore.DeleteDecision(ctx, req)
+}
+
+func (c *auditedExplorerCore) BatchCreateDecisions(ctx context.Context, req *pb.BatchPutDecisionsRequest) (resp *pb.BatchPutDecisionsResponse, err error) {
+	defer func() { c.auditor.record(ctx, "BatchPutDecisions", decisionUserIDs(req.Decisions...), req, err) }()
+	return c.ExplorerCore.BatchCreateDecisions(ctx, req)
+}
+
+// IngestDecisions records every batch of a PutDecisions stream as an event of its own, so that
+// an event stays as small as a batch however long the stream runs
+func (c *auditedExplorerCore) IngestDecisions(ctx context.Context, decisions []*pb.PutDecisionRequest) (resp *pb.PutDecisionsSummary, err error) {
+	defer func() {
+		c.auditor.record(ctx, "PutDecisions", decisionUserIDs(decisions...), &pb.BatchPutDecisionsRequest{Decisions: decisions}, err)
+	}()
+	return c.ExplorerCore.IngestDecisions(ctx, decisions)
+}
+
+func (c *auditedExplorerCore) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (resp *pb.BlockUserResponse, err error) {
+	defer func() { c.auditor.record(ctx, "BlockUser", []string{req.BlockerUserId, req.BlockedUserId}, req, err) }()
+	return c.ExplorerCore.BlockUser(ctx, req)
+}
+
+func (c *auditedExplorerCore) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (resp *pb.UnblockUserResponse, err error) {
+	defer func() { c.auditor.record(ctx, "UnblockUser", []string{req.BlockerUserId, req.BlockedUserId}, req, err) }()
+	return c.ExplorerCore.UnblockUser(ctx, req)
+}
+
+func (c *auditedExplorerCore) ListPassers(ctx context.Context, req *pb.ListPassedYouRequest) (resp *pb.ListPassedYouResponse, err error) {
+	defer func() { c.auditor.record(ctx, "ListPassedYou", []string{req.RecipientUserId}, req, err) }()
+	return c.ExplorerCore.ListPassers(ctx, req)
+}
+
+// auditedReportCore records the reports filed in the audit trail
+type auditedReportCore struct {
+	ReportCore
+	auditor *auditor
+}
+
+// NewAuditedReportCore records core's calls in the audit trail kept in repo
+func NewAuditedReportCore(core ReportCore, repo repository.AuditRepository, logger *zap.Logger) ReportCore {
+	return &auditedReportCore{ReportCore: core, auditor: newAuditor(repo, logger)}
+}
+
+func (c *auditedReportCore) ReportUser(ctx context.Context, req *pb.ReportUserRequest) (resp *pb.ReportUserResponse, err error) {
+	defer func() {
+		c.auditor.record(ctx, "ReportUser", []string{req.ReporterUserId, req.ReportedUserId}, req, err)
+	}()
+	return c.ReportCore.ReportUser(ctx, req)
+}
+
+// auditedAdminCore records the calls of the admin service in the audit trail
+type auditedAdminCore struct {
+	AdminCore
+	auditor *auditor
+}
+
+// NewAuditedAdminCore records core's calls in the audit trail kept in repo
+func NewAuditedAdminCore(core AdminCore, repo repository.AuditRepository, logger *zap.Logger) AdminCore {
+	return &auditedAdminCore{AdminCore: core, auditor: newAuditor(repo, logger)}
+}
+
+func (c *auditedAdminCore) ListUserDecisions(ctx context.Context, req *pb.ListUserDecisionsRequest) (resp *pb.ListUserDecisionsResponse, err error) {
+	defer func() { c.auditor.record(ctx, "ListUserDecisions", []string{req.UserId}, req, err) }()
+	return c.AdminCore.ListUserDecisions(ctx, req)
+}
+
+func (c *auditedAdminCore) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest, send func(*pb.ExportUserDataChunk) error) (err error) {
+	defer func() { c.auditor.record(ctx, "ExportUserData", []string{req.UserId}, req, err) }()
+	return c.AdminCore.ExportUserData(ctx, req, send)
+}
+
+func (c *auditedAdminCore) PurgeUserData(ctx context.Context, req *pb.PurgeUserDataRequest) (resp *pb.PurgeUserDataResponse, err error) {
+	defer func() { c.auditor.record(ctx, "PurgeUserData", []string{req.UserId}, req, err) }()
+	return c.AdminCore.PurgeUserData(ctx, req)
+}
+
+// EraseUserData records the erasure by its receipt, leaving out the erased user, whose ID the
+// erasure has just scrubbed from the trail
+func (c *auditedAdminCore) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (resp *pb.EraseUserDataResponse, err error) {
+	defer func() {
+		c.auditor.record(ctx, "EraseUserData", nil, &pb.EraseUserDataResponse{ReceiptId: resp.GetReceiptId()}, err)
+	}()
+	return c.AdminCore.EraseUserData(ctx, req)
+}
+
+func (c *auditedAdminCore) InvalidateRecipientCache(ctx context.Context, req *pb.InvalidateRecipientCacheRequest) (resp *pb.InvalidateRecipientCacheResponse, err error) {
+	defer func() {
+		c.auditor.record(ctx, "InvalidateRecipientCache", []string{req.RecipientUserId}, req, err)
+	}()
+	return c.AdminCore.InvalidateRecipientCache(ctx, req)
+}
+
+func (c *auditedAdminCore) GetServiceStats(ctx context.Context, req *pb.GetServiceStatsRequest) (resp *pb.GetServiceStatsResponse, err error) {
+	defer func() { c.auditor.record(ctx, "GetServiceStats", nil, req, err) }()
+	return c.AdminCore.GetServiceStats(ctx, req)
+}
+
+// decisionUserIDs returns the actors and recipients of decisions
+func decisionUserIDs(decisions ...*pb.PutDecisionRequest) []string {
+	userIDs := make([]string, 0, 2*len(decisions))
+	for _, decision := range decisions {
+		userIDs = append(userIDs, decision.ActorUserId, decision.RecipientUserId)
+	}
+	return userIDs
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+// AuditCore serves the audit trail of the calls that changed users' data and of admin calls
+type AuditCore interface {
+	ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error)
+}
+
+// auditCore implements the business logic for the audit trail
+type auditCore struct {
+	auditor *auditor
+	repo    repository.AuditRepository
+	logger  *zap.Logger
+}
+
+// NewAuditCore creates a new AuditCore serving the audit trail kept in repo. Listing the trail
+// is itself recorded in it.
+func NewAuditCore(repo repository.AuditRepository, logger *zap.Logger) AuditCore {
+	return &auditCore{
+		auditor: newAuditor(repo, logger),
+		repo:    repo,
+		logger:  logger,
+	}
+}
+
+// ListAuditEvents returns the audit events matching the request, newest first
+func (a *auditCore) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (resp *pb.ListAuditEventsResponse, err error) {
+	var userIDs []string
+	if req.UserId != nil {
+		userIDs = []string{req.GetUserId()}
+	}
+	defer func() { a.auditor.record(ctx, "ListAuditEvents", userIDs, req, err) }()
+
+	events, nextToken, err := a.repo.ListAuditEvents(ctx, models.AuditFilter{
+		UserID: req.GetUserId(),
+		Action: req.GetAction(),
+		Since:  int64(req.GetSinceUnix()),
+		Until:  int64(req.GetUntilUnix()),
+	}, models.PageRequest{
+		Token: req.GetPaginationToken(),
+		Size:  int(req.GetPageSize()),
+	})
+	if err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to list audit events", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list audit events")
+	}
+
+	pbEvents := make([]*pb.ListAuditEventsResponse_AuditEvent, len(events))
+	for i, event := range events {
+		pbEvents[i] = &pb.ListAuditEventsResponse_AuditEvent{
+			Id:              event.ID,
+			UnixTimestamp:   uint64(event.OccurredAt),
+			Action:          event.Action,
+			CallerId:        event.CallerID,
+			CallerIsService: event.CallerIsService,
+			ClientAddress:   event.ClientAddress,
+			RequestId:       event.RequestID,
+			UserIds:         event.UserIDs,
+			Outcome:         event.Outcome,
+			DetailsJson:     string(event.Details),
+		}
+	}
+
+	response := &pb.ListAuditEventsResponse{
+		Events: pbEvents,
+	}
+
+	if nextToken != "" {
+		response.NextPaginationToken = &nextToken
+	}
+
+	return response, nil
+}
+
+// auditor appends the calls made through the cores to the audit trail
+type auditor struct {
+	repo   repository.AuditRepository
+	logger *zap.Logger
+}
+
+func newAuditor(repo repository.AuditRepository, logger *zap.Logger) *auditor {
+	return &auditor{repo: repo, logger: logger}
+}
+
+// record appends the call to action about userIDs, made with req and ending with err, to the
+// audit trail. By then the call has taken effect, so failing to record it is logged rather
+// than failing the call; the write outlives the call being cancelled.
+func (a *auditor) record(ctx context.Context, action string, userIDs []string, req proto.Message, err error) {
+	details, marshalErr := protojson.Marshal(req)
+	if marshalErr != nil {
+		details = []byte("{}")
+	}
+
+	caller, _ := utils.CallerFromContext(ctx)
+	event := explorerdb.CreateAuditEventParams{
+		Action:          action,
+		CallerID:        caller.UserID,
+		CallerIsService: caller.Service,
+		ClientAddress:   clientAddress(ctx),
+		RequestID:       utils.RequestIDFromContext(ctx),
+		UserIds:         uniqueUserIDs(userIDs),
+		Outcome:         status.Code(err).String(),
+		Details:         details,
+	}
+	if err := a.repo.CreateAuditEvent(context.WithoutCancel(ctx), event); err != nil {
+		utils.Logger(ctx, a.logger).Error("Failed to record audit event",
+			zap.String("action", action),
+			zap.Strings("user_ids", event.UserIds),
+			zap.String("outcome", event.Outcome),
+			zap.Error(err))
+	}
+}
+
+// clientAddress returns the address the call ctx handles came from: the peer of a gRPC call, or
+// the address recorded for other requests
+func clientAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	addr, _ := utils.ClientAddressFromContext(ctx)
+	return addr
+}
+
+// uniqueUserIDs returns userIDs without repeats, in their first order
+func uniqueUserIDs(userIDs []string) []string {
+	seen := make(map[string]bool, len(userIDs))
+	unique := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
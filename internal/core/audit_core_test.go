@@ -0,0 +1,208 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	coremock "github.com/backend-interview-task/mocks/core"
+	repomock "github.com/backend-interview-task/mocks/repository"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+type AuditCoreTestSuite struct {
+	suite.Suite
+	mockAuditRepo *repomock.AuditRepository
+	mockCore      *coremock.ExplorerCore
+	mockReports   *coremock.ReportCore
+	auditCore     AuditCore
+	auditedCore   ExplorerCore
+	auditedReport ReportCore
+	ctx           context.Context
+}
+
+func TestAuditCoreTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditCoreTestSuite))
+}
+
+func (s *AuditCoreTestSuite) SetupTest() {
+	s.mockAuditRepo = new(repomock.AuditRepository)
+	s.mockCore = new(coremock.ExplorerCore)
+	s.mockReports = new(coremock.ReportCore)
+	s.auditCore = NewAuditCore(s.mockAuditRepo, zap.NewNop())
+	s.auditedCore = NewAuditedExplorerCore(s.mockCore, s.mockAuditRepo, zap.NewNop())
+	s.auditedReport = NewAuditedReportCore(s.mockReports, s.mockAuditRepo, zap.NewNop())
+
+	s.ctx = utils.WithCaller(context.Background(), utils.Caller{UserID: "user123"})
+	s.ctx = utils.WithRequestID(s.ctx, "req-1")
+	s.ctx = peer.NewContext(s.ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.9"), Port: 51234}})
+}
+
+func (s *AuditCoreTestSuite) TearDownTest() {
+	s.mockAuditRepo.AssertExpectations(s.T())
+	s.mockCore.AssertExpectations(s.T())
+	s.mockReports.AssertExpectations(s.T())
+}
+
+func (s *AuditCoreTestSuite) TestCreateDecision_RecordsEvent() {
+	req := &pb.PutDecisionRequest{ActorUserId: "user123", RecipientUserId: "user456", LikedRecipient: true}
+	s.mockCore.EXPECT().CreateDecision(s.ctx, req).Return(&pb.PutDecisionResponse{}, nil).Once()
+	var event explorerdb.CreateAuditEventParams
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, arg explorerdb.CreateAuditEventParams) { event = arg }).
+		Return(nil).Once()
+
+	resp, err := s.auditedCore.CreateDecision(s.ctx, req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.Equal("PutDecision", event.Action)
+	s.Equal("user123", event.CallerID)
+	s.False(event.CallerIsService)
+	s.Equal("203.0.113.9:51234", event.ClientAddress)
+	s.Equal("req-1", event.RequestID)
+	s.Equal([]string{"user123", "user456"}, event.UserIds)
+	s.Equal("OK", event.Outcome)
+	s.JSONEq(`{"actorUserId":"user123","recipientUserId":"user456","likedRecipient":true}`, string(event.Details))
+}
+
+func (s *AuditCoreTestSuite) TestDeleteDecision_RecordsFailure() {
+	req := &pb.DeleteDecisionRequest{ActorUserId: "user123", RecipientUserId: "user456"}
+	s.mockCore.EXPECT().DeleteDecision(s.ctx, req).Return(nil, status.Error(codes.NotFound, "decision not found")).Once()
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event explorerdb.CreateAuditEventParams) bool {
+		return event.Action == "DeleteDecision" && event.Outcome == "NotFound"
+	})).Return(nil).Once()
+
+	resp, err := s.auditedCore.DeleteDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *AuditCoreTestSuite) TestIngestDecisions_RecordsUsersOnce() {
+	decisions := []*pb.PutDecisionRequest{
+		{ActorUserId: "user123", RecipientUserId: "user456", LikedRecipient: true},
+		{ActorUserId: "user123", RecipientUserId: "user789"},
+	}
+	s.mockCore.EXPECT().IngestDecisions(s.ctx, decisions).Return(&pb.PutDecisionsSummary{Created: 2}, nil).Once()
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event explorerdb.CreateAuditEventParams) bool {
+		return event.Action == "PutDecisions" && s.Equal([]string{"user123", "user456", "user789"}, event.UserIds)
+	})).Return(nil).Once()
+
+	_, err := s.auditedCore.IngestDecisions(s.ctx, decisions)
+
+	s.NoError(err)
+}
+
+func (s *AuditCoreTestSuite) TestReportUser_RecordFailureDoesNotFailCall() {
+	req := &pb.ReportUserRequest{ReporterUserId: "user123", ReportedUserId: "user456", Reason: "spam"}
+	expectedResp := &pb.ReportUserResponse{ReportId: 42}
+	s.mockReports.EXPECT().ReportUser(s.ctx, req).Return(expectedResp, nil).Once()
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+
+	resp, err := s.auditedReport.ReportUser(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *AuditCoreTestSuite) TestPurgeUserData_RecordsEvent() {
+	adminCore := new(coremock.AdminCore)
+	defer adminCore.AssertExpectations(s.T())
+	req := &pb.PurgeUserDataRequest{UserId: "user456"}
+	adminCore.EXPECT().PurgeUserData(s.ctx, req).Return(&pb.PurgeUserDataResponse{DecisionsDeleted: 2}, nil).Once()
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event explorerdb.CreateAuditEventParams) bool {
+		return event.Action == "PurgeUserData" && event.Outcome == "OK" && s.Equal([]string{"user456"}, event.UserIds)
+	})).Return(nil).Once()
+
+	_, err := NewAuditedAdminCore(adminCore, s.mockAuditRepo, zap.NewNop()).PurgeUserData(s.ctx, req)
+
+	s.NoError(err)
+}
+
+func (s *AuditCoreTestSuite) TestEraseUserData_RecordsReceiptOnly() {
+	adminCore := new(coremock.AdminCore)
+	defer adminCore.AssertExpectations(s.T())
+	req := &pb.EraseUserDataRequest{UserId: "user456"}
+	adminCore.EXPECT().EraseUserData(s.ctx, req).Return(&pb.EraseUserDataResponse{ReceiptId: 42}, nil).Once()
+	var event explorerdb.CreateAuditEventParams
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, arg explorerdb.CreateAuditEventParams) { event = arg }).
+		Return(nil).Once()
+
+	_, err := NewAuditedAdminCore(adminCore, s.mockAuditRepo, zap.NewNop()).EraseUserData(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal("EraseUserData", event.Action)
+	s.Empty(event.UserIds)
+	s.NotContains(string(event.Details), "user456")
+	s.Contains(string(event.Details), "42")
+}
+
+func (s *AuditCoreTestSuite) TestReadsAreNotRecorded() {
+	req := &pb.CountLikedYouRequest{RecipientUserId: "user123"}
+	s.mockCore.EXPECT().CountLikers(s.ctx, req).Return(&pb.CountLikedYouResponse{Count: 3}, nil).Once()
+
+	_, err := s.auditedCore.CountLikers(s.ctx, req)
+
+	s.NoError(err)
+	s.mockAuditRepo.AssertNotCalled(s.T(), "CreateAuditEvent", mock.Anything, mock.Anything)
+}
+
+func (s *AuditCoreTestSuite) TestClientAddress_FromContext() {
+	ctx := utils.WithClientAddress(context.Background(), "198.51.100.4:40000")
+
+	s.Equal("198.51.100.4:40000", clientAddress(ctx))
+	s.Empty(clientAddress(context.Background()))
+}
+
+func (s *AuditCoreTestSuite) TestListAuditEvents_Success() {
+	req := &pb.ListAuditEventsRequest{
+		UserId:          utils.ToPointer("user456"),
+		SinceUnix:       utils.ToPointer(uint64(1640000000)),
+		PaginationToken: utils.ToPointer("token"),
+		PageSize:        utils.ToPointer(uint32(10)),
+	}
+	s.mockAuditRepo.EXPECT().ListAuditEvents(s.ctx,
+		models.AuditFilter{UserID: "user456", Since: 1640000000},
+		models.PageRequest{Token: "token", Size: 10},
+	).Return([]models.AuditEvent{
+		{ID: 9, OccurredAt: 1640995200, Action: "PutDecision", CallerID: "user123", UserIDs: []string{"user123", "user456"}, Outcome: "OK", Details: []byte(`{}`)},
+	}, "next", nil).Once()
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event explorerdb.CreateAuditEventParams) bool {
+		return event.Action == "ListAuditEvents" && s.Equal([]string{"user456"}, event.UserIds)
+	})).Return(nil).Once()
+
+	resp, err := s.auditCore.ListAuditEvents(s.ctx, req)
+
+	s.NoError(err)
+	s.Require().Len(resp.Events, 1)
+	s.Equal(uint64(1640995200), resp.Events[0].UnixTimestamp)
+	s.Equal("{}", resp.Events[0].DetailsJson)
+	s.Equal("next", resp.GetNextPaginationToken())
+}
+
+func (s *AuditCoreTestSuite) TestListAuditEvents_RepositoryError() {
+	req := &pb.ListAuditEventsRequest{}
+	s.mockAuditRepo.EXPECT().ListAuditEvents(s.ctx, models.AuditFilter{}, models.PageRequest{}).
+		Return(nil, "", errors.New("database error")).Once()
+	s.mockAuditRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(event explorerdb.CreateAuditEventParams) bool {
+		return event.Action == "ListAuditEvents" && event.Outcome == "Internal"
+	})).Return(nil).Once()
+
+	resp, err := s.auditCore.ListAuditEvents(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
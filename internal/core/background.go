@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// backgroundWriteTimeout bounds a cache write made in the background of a call
+const backgroundWriteTimeout = 5 * time.Second
+
+// backgroundWrites runs the cache writes that calls leave behind them, so that the calls return
+// without waiting on the cache, and lets shutdown wait for those still running
+type backgroundWrites struct {
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// Go runs write in the background with a context carrying the values of ctx, detached from its
+// cancellation and bounded by backgroundWriteTimeout. Writes started once the core is flushed
+// are dropped, as the cache is about to be closed.
+func (b *backgroundWrites) Go(ctx context.Context, write func(ctx context.Context)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), backgroundWriteTimeout)
+		defer cancel()
+		write(ctx)
+	}()
+}
+
+// Flush stops taking new writes and waits for the running ones to finish, or until ctx is done
+func (b *backgroundWrites) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
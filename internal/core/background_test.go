@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BackgroundWritesTestSuite struct {
+	suite.Suite
+	writes *backgroundWrites
+}
+
+func TestBackgroundWritesTestSuite(t *testing.T) {
+	suite.Run(t, new(BackgroundWritesTestSuite))
+}
+
+func (s *BackgroundWritesTestSuite) SetupTest() {
+	s.writes = &backgroundWrites{}
+}
+
+func (s *BackgroundWritesTestSuite) TestGo_DetachesFromCallCancellation() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := make(chan error, 1)
+	var deadline time.Time
+
+	s.writes.Go(ctx, func(ctx context.Context) {
+		deadline, _ = ctx.Deadline()
+		result <- ctx.Err()
+	})
+
+	s.NoError(<-result)
+	s.WithinDuration(time.Now().Add(backgroundWriteTimeout), deadline, time.Second)
+}
+
+func (s *BackgroundWritesTestSuite) TestFlush_WaitsForRunningWrites() {
+	release := make(chan struct{})
+	finished := false
+	s.writes.Go(context.Background(), func(ctx context.Context) {
+		<-release
+		finished = true
+	})
+
+	go close(release)
+	s.NoError(s.writes.Flush(context.Background()))
+	s.True(finished)
+}
+
+func (s *BackgroundWritesTestSuite) TestFlush_GivesUpWhenContextDone() {
+	release := make(chan struct{})
+	defer close(release)
+	s.writes.Go(context.Background(), func(ctx context.Context) { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	s.ErrorIs(s.writes.Flush(ctx), context.DeadlineExceeded)
+}
+
+func (s *BackgroundWritesTestSuite) TestGo_DropsWritesAfterFlush() {
+	s.Require().NoError(s.writes.Flush(context.Background()))
+
+	ran := make(chan struct{}, 1)
+	s.writes.Go(context.Background(), func(ctx context.Context) { ran <- struct{}{} })
+
+	s.NoError(s.writes.Flush(context.Background()))
+	s.Empty(ran)
+}
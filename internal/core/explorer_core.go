@@ -2,14 +2,25 @@ package core
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
 	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/internal/providers/pubsub"
 	"github.com/backend-interview-task/internal/repository"
 	pb "github.com/backend-interview-task/proto"
 	"github.com/backend-interview-task/utils"
@@ -20,160 +31,1106 @@ type ExplorerCore interface {
 	ListLikers(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error)
 	ListNewLikers(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error)
 	CountLikers(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error)
+	ListLikedRecipients(ctx context.Context, req *pb.ListLikedByYouRequest) (*pb.ListLikedByYouResponse, error)
+	GetDecision(ctx context.Context, req *pb.GetDecisionRequest) (*pb.GetDecisionResponse, error)
+	DeleteDecision(ctx context.Context, req *pb.DeleteDecisionRequest) (*pb.DeleteDecisionResponse, error)
+	BatchCreateDecisions(ctx context.Context, req *pb.BatchPutDecisionsRequest) (*pb.BatchPutDecisionsResponse, error)
+	ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error)
+	IngestDecisions(ctx context.Context, decisions []*pb.PutDecisionRequest) (*pb.PutDecisionsSummary, error)
+	WatchNewLikers(ctx context.Context, req *pb.WatchNewLikesRequest, send func(*pb.WatchNewLikesEvent) error) error
+	ListPassers(ctx context.Context, req *pb.ListPassedYouRequest) (*pb.ListPassedYouResponse, error)
+	CheckMutualLike(ctx context.Context, req *pb.CheckMutualLikeRequest) (*pb.CheckMutualLikeResponse, error)
+	BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error)
+	UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error)
+	WarmRecipients(ctx context.Context, recipientUserIDs []string) (int, error)
+	HandleDecisionChange(ctx context.Context, change models.DecisionChange) error
+	Flush(ctx context.Context) error
 }
 
+// matchCreatedEvent is the type of the webhook sent when two users like each other
+const matchCreatedEvent = "match.created"
+
+// likeWithdrawnEvent is the type of the webhook sent when a user passes on someone they liked
+const likeWithdrawnEvent = "like.withdrawn"
+
+// likersIndexMaxSize caps the likers loaded into a likers index; recipients with more are always listed from the DB
+const likersIndexMaxSize = 10000
+
 // exploreCore implements the business logic for the ExploreService
 type exploreCore struct {
-	repo   repository.ExplorerRepository
-	cache  cache.CacheProvider
-	logger *zap.Logger
+	repo             repository.ExplorerRepository
+	cache            cache.CacheProvider
+	pubsub           pubsub.PubSubProvider
+	webhookEndpoints []string
+	// likeTTL hides likes older than it, as the repository does; zero keeps likes forever
+	likeTTL time.Duration
+	// trackHotRecipients tallies requests for recipients, for the cache warmer to pick the hot ones
+	trackHotRecipients bool
+	// background runs the cache writes left behind by calls
+	background *backgroundWrites
+	logger     *zap.Logger
 }
 
-// NewExploreCore creates a new ExploreCore to handle the app business logic
-func NewExploreCore(repo repository.ExplorerRepository, cache cache.CacheProvider, logger *zap.Logger) ExplorerCore {
+// NewExploreCore creates a new ExploreCore to handle the app business logic.
+// Every mutual like is announced to each of the webhook endpoints.
+func NewExploreCore(repo repository.ExplorerRepository, cache cache.CacheProvider, pubsub pubsub.PubSubProvider, webhookEndpoints []string, likeTTL time.Duration, trackHotRecipients bool, logger *zap.Logger) ExplorerCore {
 	return &exploreCore{
-		repo:   repo,
-		logger: logger,
-		cache:  cache,
+		repo:               repo,
+		logger:             logger,
+		cache:              cache,
+		pubsub:             pubsub,
+		webhookEndpoints:   webhookEndpoints,
+		likeTTL:            likeTTL,
+		trackHotRecipients: trackHotRecipients,
+		background:         &backgroundWrites{},
 	}
 }
 
-// ListLikers returns all users who liked the recipient
-// First it try from cache, if not found then query from DB
+// Flush waits for the cache writes left running in the background by earlier calls to finish,
+// or until ctx is done, and drops those started afterwards. It is called on shutdown, once no
+// more calls are served, before the cache is closed.
+func (s *exploreCore) Flush(ctx context.Context) error {
+	return s.background.Flush(ctx)
+}
+
+// matchWebhook is the JSON body of the match.created webhook
+type matchWebhook struct {
+	EventID       string `json:"event_id"`
+	Type          string `json:"type"`
+	UserID        string `json:"user_id"`
+	MatchedUserID string `json:"matched_user_id"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// likeWithdrawnWebhook is the JSON body of the like.withdrawn webhook
+type likeWithdrawnWebhook struct {
+	EventID         string `json:"event_id"`
+	Type            string `json:"type"`
+	ActorUserID     string `json:"actor_user_id"`
+	RecipientUserID string `json:"recipient_user_id"`
+	WithdrawnAt     int64  `json:"withdrawn_at"`
+}
+
+// ListLikers returns all users who liked the recipient.
+// Pages are read from the recipient's likers index, falling back to the DB while the index is not built.
 func (s *exploreCore) ListLikers(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	key := utils.LikersKey(req.GetRecipientUserId(), req.GetPaginationToken())
+	page := pageRequest(req)
+	if page.Token == "" {
+		s.trackHotRecipient(ctx, req.RecipientUserId)
+	}
 
-	var cached pb.ListLikedYouResponse
-	if ok, err := s.cache.GetJSON(ctx, key, &cached); err == nil && ok {
-		return &cached, nil
+	// An invalid token is left for the repository to reject. The index holds no decision ids,
+	// so tokens that seek within a second by decision id, and tokens of previous pages, which
+	// need to know whether a page precedes theirs, are served from the DB, as are offset pages
+	// and pages ranked by popularity.
+	if cursor, err := utils.ResolveCursor(page.Token, page.Size, page.Ascending); err == nil && cursor.LastID == 0 && !cursor.Backward && page.Page == 0 &&
+		!page.ByPopularity && !cursor.ByPopularity {
+		likers, built, err := s.cache.GetLikersPage(ctx, req.RecipientUserId, s.likersRange(page, cursor))
+		switch {
+		case err != nil:
+			utils.Logger(ctx, s.logger).Warn("Failed to read likers index", zap.Error(err))
+		case built:
+			response, ok, err := indexedLikersResponse(likers, cursor, page.Token != "")
+			if err != nil {
+				utils.Logger(ctx, s.logger).Error("Failed to encode pagination tokens", zap.Error(err))
+				return nil, status.Error(codes.Internal, "failed to get likers")
+			}
+			if ok {
+				return applyLikersReadMask(response, req.GetReadMask()), nil
+			}
+		default:
+			s.background.Go(ctx, func(ctx context.Context) { s.buildLikersIndex(ctx, req.RecipientUserId) })
+		}
 	}
 
 	// Get likers with pagination
-	likers, nextToken, err := s.repo.GetLikers(ctx, req.RecipientUserId, req.GetPaginationToken())
+	likers, tokens, err := s.repo.GetLikers(ctx, req.RecipientUserId, page)
 	if err != nil {
-		s.logger.Error("Failed to get likers", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to get likers", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to get likers")
 	}
 
-	// Convert to protobuf format
+	return applyLikersReadMask(likersResponse(likers, tokens), req.GetReadMask()), nil
+}
+
+// likersRange maps the page onto the likers index, applying the same bounds the repository applies
+func (s *exploreCore) likersRange(page models.PageRequest, cursor *utils.Cursor) models.LikersRange {
+	likersRange := models.LikersRange{
+		Ascending: cursor.Ascending,
+		Limit:     cursor.Limit + 1, // One extra to tell whether there is a next page
+	}
+
+	after := func(bound int64) {
+		likersRange.After = max(likersRange.After, bound)
+	}
+	before := func(bound int64) {
+		if likersRange.Before == 0 || bound < likersRange.Before {
+			likersRange.Before = bound
+		}
+	}
+
+	if s.likeTTL > 0 {
+		after(time.Now().Add(-s.likeTTL).Unix() - 1)
+	}
+	if page.Since > 0 {
+		after(page.Since - 1)
+	}
+	if page.Until > 0 {
+		before(page.Until)
+	}
+	if page.Token != "" {
+		if cursor.Ascending {
+			after(cursor.LastCreatedAt)
+		} else {
+			before(cursor.LastCreatedAt)
+		}
+	}
+
+	return likersRange
+}
+
+// indexedLikersResponse trims a page read from the likers index, holding one liker more than
+// the cursor limit when there is a next page, and issues the tokens the repository would have.
+// It reports false when the page would end within a second, whose token needs the decision
+// id of the last liker, leaving that page to the DB. A page continued from a token that seeks
+// past a whole second starts on a new second, so its previous page token needs no decision id.
+func indexedLikersResponse(likers []models.Liker, cursor *utils.Cursor, continued bool) (*pb.ListLikedYouResponse, bool, error) {
+	var tokens models.PageTokens
+	if len(likers) > cursor.Limit {
+		if likers[cursor.Limit].Timestamp == likers[cursor.Limit-1].Timestamp {
+			return nil, false, nil
+		}
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: likers[cursor.Limit-1].Timestamp,
+			Limit:         cursor.Limit,
+			Ascending:     cursor.Ascending,
+		}
+		var err error
+		if tokens.Next, err = nextCursor.Encode(); err != nil {
+			return nil, false, err
+		}
+		likers = likers[:cursor.Limit]
+	}
+	if continued && len(likers) > 0 {
+		prevCursor := &utils.Cursor{
+			LastCreatedAt: likers[0].Timestamp,
+			Limit:         cursor.Limit,
+			Ascending:     cursor.Ascending,
+			Backward:      true,
+		}
+		var err error
+		if tokens.Prev, err = prevCursor.Encode(); err != nil {
+			return nil, false, err
+		}
+	}
+	return likersResponse(likers, tokens), true, nil
+}
+
+// likersResponse converts a page of likers to protobuf format
+func likersResponse(likers []models.Liker, tokens models.PageTokens) *pb.ListLikedYouResponse {
 	pbLikers := make([]*pb.ListLikedYouResponse_Liker, len(likers))
 	for i, liker := range likers {
 		pbLikers[i] = &pb.ListLikedYouResponse_Liker{
-			ActorId:       liker.ActorID,
-			UnixTimestamp: uint64(liker.Timestamp),
+			ActorId:          liker.ActorID,
+			UnixTimestamp:    uint64(liker.Timestamp),
+			AlreadyLikedBack: liker.LikedBack,
 		}
 	}
 
 	response := &pb.ListLikedYouResponse{
 		Likers: pbLikers,
 	}
-
-	if nextToken != "" {
-		response.NextPaginationToken = &nextToken
+	if tokens.Next != "" {
+		response.NextPaginationToken = &tokens.Next
+	}
+	if tokens.Prev != "" {
+		response.PrevPaginationToken = &tokens.Prev
 	}
+	return response
+}
 
-	go func() {
-		err = s.cache.SetJSON(ctx, key, response, utils.LikersTTL)
-		if err != nil {
-			s.logger.Warn("Failed to cache likers", zap.Error(err))
-		}
-	}()
+// buildLikersIndex loads every liker of the recipient into their likers index.
+// Recipients with more than likersIndexMaxSize likers are left without an index.
+// An index another build got in first with is kept, along with the likes written through to it since.
+func (s *exploreCore) buildLikersIndex(ctx context.Context, recipientUserID string) {
+	likers, tokens, err := s.repo.GetLikers(ctx, recipientUserID, models.PageRequest{Size: likersIndexMaxSize})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to load likers index", zap.String("recipient_user_id", recipientUserID), zap.Error(err))
+		return
+	}
+	if tokens.Next != "" {
+		return
+	}
 
-	return response, nil
+	if err := s.cache.SetLikersIndex(ctx, recipientUserID, likers, utils.LikersIndexTTL); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to build likers index", zap.String("recipient_user_id", recipientUserID), zap.Error(err))
+	}
 }
 
-// ListNewLikers returns users who liked the recipient but haven't been liked back
+// ListNewLikers returns users who liked the recipient but haven't been decided on back
 // method try from cache, if not found then query from DB
 func (s *exploreCore) ListNewLikers(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	key := utils.NewLikersKey(req.GetRecipientUserId(), req.GetPaginationToken())
+	page := pageRequest(req)
+
+	// Time ranges are usually relative to now, and offset pages are read by admin tools, so their
+	// results are not worth caching. Listings including passed likers are not cached either, as a
+	// pass does not invalidate the cached new likers.
+	useCache := !page.HasTimeRange() && page.Page == 0 && !page.IncludePassed
+
+	var key string
+	if useCache {
+		var generation string
+		generation, useCache = s.cacheGeneration(ctx, req.GetRecipientUserId())
+		key = utils.NewLikersKey(ctx, req.GetRecipientUserId(), generation, req.GetPaginationToken(), req.GetPageSize(), int32(req.GetSortOrder()))
+	}
+
+	if page.Token == "" {
+		s.trackHotRecipient(ctx, req.RecipientUserId)
+	}
 
 	var cached pb.ListLikedYouResponse
-	if ok, err := s.cache.GetJSON(ctx, key, &cached); err == nil && ok {
-		return &cached, nil
+	if useCache {
+		if ok, err := s.cache.GetJSON(ctx, key, &cached); err == nil && ok {
+			return applyLikersReadMask(&cached, req.GetReadMask()), nil
+		}
 	}
 
-	likers, nextToken, err := s.repo.GetNewLikers(ctx, req.RecipientUserId, req.GetPaginationToken())
+	response, err := s.loadNewLikers(ctx, req.RecipientUserId, page)
 	if err != nil {
-		s.logger.Error("Failed to get new likers", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to get new likers", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to get new likers")
 	}
 
-	pbLikers := make([]*pb.ListLikedYouResponse_Liker, len(likers))
-	for i, liker := range likers {
-		pbLikers[i] = &pb.ListLikedYouResponse_Liker{
-			ActorId:       liker.ActorID,
-			UnixTimestamp: uint64(liker.Timestamp),
+	if useCache {
+		s.cacheResponseInBackground(ctx, key, response, len(response.Likers) == 0, utils.NewLikersTTL)
+	}
+	return applyLikersReadMask(response, req.GetReadMask()), nil
+}
+
+// loadNewLikers reads a page of the recipient's new likers from the DB
+func (s *exploreCore) loadNewLikers(ctx context.Context, recipientUserID string, page models.PageRequest) (*pb.ListLikedYouResponse, error) {
+	likers, tokens, err := s.repo.GetNewLikers(ctx, recipientUserID, page)
+	if err != nil {
+		return nil, err
+	}
+	return likersResponse(likers, tokens), nil
+}
+
+// ListPassers returns all users who passed on the recipient.
+// It is used for investigations, so results always come straight from the DB.
+func (s *exploreCore) ListPassers(ctx context.Context, req *pb.ListPassedYouRequest) (*pb.ListPassedYouResponse, error) {
+	passers, tokens, err := s.repo.GetPassers(ctx, req.RecipientUserId, models.PageRequest{
+		Token: req.GetPaginationToken(),
+		Size:  int(req.GetPageSize()),
+		Page:  int(req.GetPage()),
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get passers", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get passers")
+	}
+
+	pbPassers := make([]*pb.ListPassedYouResponse_Passer, len(passers))
+	for i, passer := range passers {
+		pbPassers[i] = &pb.ListPassedYouResponse_Passer{
+			ActorId:       passer.ActorID,
+			UnixTimestamp: uint64(passer.Timestamp),
 		}
 	}
 
-	response := &pb.ListLikedYouResponse{
-		Likers: pbLikers,
+	response := &pb.ListPassedYouResponse{
+		Passers: pbPassers,
+	}
+
+	if tokens.Next != "" {
+		response.NextPaginationToken = &tokens.Next
+	}
+
+	return response, nil
+}
+
+// ListLikedRecipients returns all users the actor has liked
+// First it try from cache, if not found then query from DB
+func (s *exploreCore) ListLikedRecipients(ctx context.Context, req *pb.ListLikedByYouRequest) (*pb.ListLikedByYouResponse, error) {
+	generation, useCache := s.cacheGeneration(ctx, req.GetActorUserId())
+	key := utils.LikedByKey(ctx, req.GetActorUserId(), generation, req.GetPaginationToken())
+
+	var cached pb.ListLikedByYouResponse
+	if useCache {
+		if ok, err := s.cache.GetJSON(ctx, key, &cached); err == nil && ok {
+			return &cached, nil
+		}
+	}
+
+	recipients, nextToken, err := s.repo.GetLikedRecipients(ctx, req.ActorUserId, models.PageRequest{
+		Token: req.GetPaginationToken(),
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get liked recipients", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get liked recipients")
+	}
+
+	pbRecipients := make([]*pb.ListLikedByYouResponse_Recipient, len(recipients))
+	for i, recipient := range recipients {
+		pbRecipients[i] = &pb.ListLikedByYouResponse_Recipient{
+			RecipientId:   recipient.RecipientID,
+			UnixTimestamp: uint64(recipient.Timestamp),
+		}
+	}
+
+	response := &pb.ListLikedByYouResponse{
+		Recipients: pbRecipients,
 	}
 
 	if nextToken != "" {
 		response.NextPaginationToken = &nextToken
 	}
 
-	go func() {
-		_ = s.cache.SetJSON(ctx, key, response, utils.NewLikersTTL)
-	}()
+	if useCache {
+		s.cacheResponseInBackground(ctx, key, response, len(recipients) == 0, utils.LikedByTTL)
+	}
 	return response, nil
 }
 
-// CountLikers returns the count of users who liked the recipient
-// First it try from cache, if not found then query from DB
+// CountLikers returns the count of users who liked the recipient.
+// The count is kept live in the cache by decision writes; the DB only seeds it when it is missing.
 func (s *exploreCore) CountLikers(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
-	key := utils.LikersCountKey(req.GetRecipientUserId())
+	s.trackHotRecipient(ctx, req.GetRecipientUserId())
+
+	key := utils.LikersCountKey(ctx, req.GetRecipientUserId())
 	if raw, err := s.cache.Get(ctx, key); err == nil && raw != "" {
 		if n, err := strconv.ParseUint(raw, 10, 64); err == nil {
 			return &pb.CountLikedYouResponse{Count: n}, nil
 		}
 	}
 
-	count, err := s.repo.CountLikes(ctx, req.RecipientUserId)
+	count, err := s.repo.CountLikers(ctx, req.RecipientUserId)
 	if err != nil {
-		s.logger.Error("Failed to count likers", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to count likers", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to count likers")
 	}
 
-	go func() {
-		_ = s.cache.Set(ctx, key, strconv.FormatInt(count, 10), utils.LikersCountTTL)
-	}()
+	s.background.Go(ctx, func(ctx context.Context) {
+		_ = s.seedLikersCount(ctx, req.RecipientUserId, count)
+	})
 
 	return &pb.CountLikedYouResponse{
 		Count: uint64(count),
 	}, nil
 }
 
+// seedLikersCount seeds the recipient's live likers count with a count read from the DB
+func (s *exploreCore) seedLikersCount(ctx context.Context, recipientUserID string, count int64) error {
+	// Zero counts are seeded for a short while only, like other empty results
+	ttl := utils.LikersCountTTL
+	if count == 0 {
+		ttl = utils.EmptyResultTTL
+	}
+	return s.cache.SeedLikersCount(ctx, recipientUserID, count, ttl)
+}
+
+// WarmRecipients refreshes the cached first page of each recipient's new likers, as requested
+// without a page size or sort order, and seeds the likers counts that have expired. It is run by
+// the cache warmer for hot recipients ahead of their cache entries expiring, reading the cache
+// generations of the recipients in one batch, writing the pages in another and looking up which
+// counts are still cached in a third. A recipient that fails to load does not stop the others;
+// it returns how many recipients were warmed.
+func (s *exploreCore) WarmRecipients(ctx context.Context, recipientUserIDs []string) (int, error) {
+	generationKeys := make([]string, len(recipientUserIDs))
+	for i, recipientUserID := range recipientUserIDs {
+		generationKeys[i] = utils.CacheGenerationKey(ctx, recipientUserID)
+	}
+	generations, err := s.cache.GetMany(ctx, generationKeys...)
+	if err != nil {
+		return 0, err
+	}
+
+	pages := make(map[string]interface{}, len(recipientUserIDs))
+	empty := make(map[string]interface{})
+	warmed := make([]string, 0, len(recipientUserIDs))
+	for i, recipientUserID := range recipientUserIDs {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		response, err := s.loadNewLikers(ctx, recipientUserID, models.PageRequest{})
+		if err != nil {
+			utils.Logger(ctx, s.logger).Warn("Failed to warm recipient cache", zap.String("recipient_user_id", recipientUserID), zap.Error(err))
+			continue
+		}
+		key := utils.NewLikersKey(ctx, recipientUserID, generations[i], "", 0, int32(pb.SortOrder_NEWEST_FIRST))
+		if len(response.Likers) == 0 {
+			empty[key] = utils.EmptyCacheValue
+		} else {
+			raw, err := json.Marshal(response)
+			if err != nil {
+				return 0, err
+			}
+			pages[key] = string(raw)
+		}
+		warmed = append(warmed, recipientUserID)
+	}
+	if len(pages) > 0 {
+		if err := s.cache.SetMany(ctx, pages, utils.NewLikersTTL); err != nil {
+			return 0, err
+		}
+	}
+	if len(empty) > 0 {
+		if err := s.cache.SetMany(ctx, empty, utils.EmptyResultTTL); err != nil {
+			return 0, err
+		}
+	}
+	if len(warmed) == 0 {
+		return 0, nil
+	}
+
+	// Counts still cached are kept up to date by every like, so only the expired ones are counted
+	countKeys := make([]string, len(warmed))
+	for i, recipientUserID := range warmed {
+		countKeys[i] = utils.LikersCountKey(ctx, recipientUserID)
+	}
+	counts, err := s.cache.GetMany(ctx, countKeys...)
+	if err != nil {
+		return len(warmed), err
+	}
+	for i, recipientUserID := range warmed {
+		if i < len(counts) && counts[i] != "" {
+			continue
+		}
+		count, err := s.repo.CountLikers(ctx, recipientUserID)
+		if err == nil {
+			err = s.seedLikersCount(ctx, recipientUserID, count)
+		}
+		if err != nil {
+			utils.Logger(ctx, s.logger).Warn("Failed to seed likers count", zap.String("recipient_user_id", recipientUserID), zap.Error(err))
+		}
+	}
+	return len(warmed), nil
+}
+
+// trackHotRecipient tallies a request for the recipient in the background, if the cache warmer runs
+func (s *exploreCore) trackHotRecipient(ctx context.Context, recipientUserID string) {
+	if !s.trackHotRecipients {
+		return
+	}
+	s.background.Go(ctx, func(ctx context.Context) {
+		if err := s.cache.TrackHotRecipient(ctx, recipientUserID, time.Now()); err != nil {
+			utils.Logger(ctx, s.logger).Warn("Failed to track hot recipient", zap.String("recipient_user_id", recipientUserID), zap.Error(err))
+		}
+	})
+}
+
+// CreateDecision records the decision, along with the match it creates or ends, and drops the cached listings it changes
 func (s *exploreCore) CreateDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
-	err := s.repo.CreateDecision(ctx, explorerdb.CreateDecisionParams{
+	events, err := s.decisionEvents(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to encode decision events", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create decision")
+	}
+
+	recorded, err := s.repo.RecordDecision(ctx, explorerdb.CreateDecisionParams{
 		ActorUserID:     req.ActorUserId,
 		RecipientUserID: req.RecipientUserId,
 		LikedRecipient:  req.LikedRecipient,
-	})
+	}, events)
 	if err != nil {
-		s.logger.Error("Failed to create decision", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to create decision", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to create decision")
 	}
 
-	// Check for mutual like only if this is a like decision
-	var mutualLikes bool
-	if req.LikedRecipient {
-		hasMutualLike, err := s.repo.HasMutualLike(ctx, explorerdb.HasMutualLikeParams{
-			ActorUserID:     req.ActorUserId,
-			RecipientUserID: req.RecipientUserId,
-		})
+	s.invalidateDecisionCache(ctx, req.ActorUserId, req.RecipientUserId)
+	s.indexDecision(ctx, req, recorded.Timestamp)
+	s.countDecision(ctx, req.RecipientUserId, recorded.LikesDelta)
+
+	return &pb.PutDecisionResponse{
+		MutualLikes: recorded.MutualLikes,
+	}, nil
+}
+
+// BatchCreateDecisions records all decisions atomically and reports mutual likes per decision
+func (s *exploreCore) BatchCreateDecisions(ctx context.Context, req *pb.BatchPutDecisionsRequest) (*pb.BatchPutDecisionsResponse, error) {
+	params := make([]explorerdb.CreateDecisionParams, len(req.Decisions))
+	events := make([]models.DecisionEvents, len(req.Decisions))
+	for i, decision := range req.Decisions {
+		params[i] = explorerdb.CreateDecisionParams{
+			ActorUserID:     decision.ActorUserId,
+			RecipientUserID: decision.RecipientUserId,
+			LikedRecipient:  decision.LikedRecipient,
+		}
+
+		var err error
+		if events[i], err = s.decisionEvents(ctx, decision); err != nil {
+			utils.Logger(ctx, s.logger).Error("Failed to encode decision events", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to create decisions")
+		}
+	}
+
+	results, err := s.repo.CreateDecisions(ctx, params, events)
+	if index, ok := rejectedDecision(err); ok {
+		utils.Logger(ctx, s.logger).Warn("Decision rejected by the database", zap.Int("index", index), zap.Error(err))
+		return nil, status.Errorf(codes.InvalidArgument, "decisions[%d]: rejected by the database", index)
+	}
+	if errors.Is(err, models.ErrBatchTooLarge) {
+		utils.Logger(ctx, s.logger).Warn("Batch too large for the store", zap.Int("decisions", len(params)), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "too many decisions to record atomically, send a smaller batch")
+	}
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to create decisions", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create decisions")
+	}
+
+	// Rebuilding the likers indexes and counts on their next read is cheaper than writing each decision through
+	users := make([]string, 0, 2*len(params))
+	recipients := make([]string, 0, len(params))
+	for _, decision := range params {
+		users = append(users, decision.ActorUserID, decision.RecipientUserID)
+		recipients = append(recipients, decision.RecipientUserID)
+	}
+	if err := s.cache.DelLikersIndex(ctx, users...); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to invalidate likers index", zap.Error(err))
+	}
+	s.dropLikersCounts(ctx, recipients...)
+
+	pbResults := make([]*pb.BatchPutDecisionsResponse_Result, len(results))
+	for i, result := range results {
+		pbResults[i] = &pb.BatchPutDecisionsResponse_Result{
+			ActorUserId:     result.ActorUserID,
+			RecipientUserId: result.RecipientUserID,
+			MutualLikes:     result.MutualLikes,
+		}
+	}
+
+	return &pb.BatchPutDecisionsResponse{
+		Results: pbResults,
+	}, nil
+}
+
+// IngestDecisions bulk-records decisions and summarizes how many were created, updated or mutual.
+// Cached listings and likers indexes are left to expire on their own, as invalidating per decision would defeat the batching.
+// The live likers counts of the recipients are dropped in one go, as they would otherwise stay off for long.
+func (s *exploreCore) IngestDecisions(ctx context.Context, decisions []*pb.PutDecisionRequest) (*pb.PutDecisionsSummary, error) {
+	params := make([]explorerdb.UpsertDecisionsParams, len(decisions))
+	for i, decision := range decisions {
+		params[i] = explorerdb.UpsertDecisionsParams{
+			ActorUserID:     decision.ActorUserId,
+			RecipientUserID: decision.RecipientUserId,
+			LikedRecipient:  decision.LikedRecipient,
+		}
+	}
+
+	summary, err := s.repo.IngestDecisions(ctx, params)
+	if index, ok := rejectedDecision(err); ok {
+		utils.Logger(ctx, s.logger).Warn("Decision rejected by the database", zap.Int("index", index), zap.Error(err))
+		return nil, status.Errorf(codes.InvalidArgument, "decisions[%d]: rejected by the database", index)
+	}
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to ingest decisions", zap.Int("count", len(decisions)), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to ingest decisions")
+	}
+
+	recipients := make([]string, len(decisions))
+	for i, decision := range decisions {
+		recipients[i] = decision.RecipientUserId
+	}
+	s.dropLikersCounts(ctx, recipients...)
+
+	return &pb.PutDecisionsSummary{
+		Created:     uint64(summary.Created),
+		Updated:     uint64(summary.Updated),
+		MutualLikes: uint64(summary.MutualLikes),
+	}, nil
+}
+
+// rejectedDecision returns the index of the decision that failed a batch because of its own
+// data, such as an ID too long for its column, as opposed to the database failing
+func rejectedDecision(err error) (int, bool) {
+	var decisionErr *models.DecisionError
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &decisionErr) || !errors.As(err, &pgErr) {
+		return 0, false
+	}
+	// Class 22 is data exceptions and class 23 integrity constraint violations
+	if strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23") {
+		return decisionErr.Index, true
+	}
+	return 0, false
+}
+
+// WatchNewLikers calls send with an event for every new like the recipient receives until ctx is done
+func (s *exploreCore) WatchNewLikers(ctx context.Context, req *pb.WatchNewLikesRequest, send func(*pb.WatchNewLikesEvent) error) error {
+	messages, err := s.pubsub.Subscribe(ctx, utils.NewLikesTopic(ctx, req.RecipientUserId))
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to subscribe to new likes", zap.Error(err))
+		return status.Error(codes.Internal, "failed to watch new likes")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case payload, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			var event pb.WatchNewLikesEvent
+			if err := proto.Unmarshal(payload, &event); err != nil {
+				utils.Logger(ctx, s.logger).Warn("Failed to decode new like event", zap.Error(err))
+				continue
+			}
+			if err := send(&event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// HandleDecisionChange reacts to a decision change announced by the database, whichever
+// instance, job or tool made it: the cached listings of both users are moved to a new
+// generation, so that instances keeping a local copy catch up at once, and a new like is pushed
+// to the recipient's watchers connected to this instance
+func (s *exploreCore) HandleDecisionChange(ctx context.Context, change models.DecisionChange) error {
+	s.invalidateDecisionCache(ctx, change.ActorUserID, change.RecipientUserID)
+
+	if !change.NewLike() {
+		return nil
+	}
+	event, err := newLikeEvent(ctx, change.ActorUserID, change.RecipientUserID, change.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return s.pubsub.Publish(ctx, event.Topic, event.Payload)
+}
+
+// decisionEvents builds the outbox events a like may emit: a new like event for watchers of the
+// recipient, or a match webhook per endpoint if it completes a mutual like. Passes emit none.
+func (s *exploreCore) decisionEvents(ctx context.Context, decision *pb.PutDecisionRequest) (models.DecisionEvents, error) {
+	if !decision.LikedRecipient {
+		withdrawn, err := s.likeWithdrawnWebhookEvents(decision.ActorUserId, decision.RecipientUserId)
 		if err != nil {
-			s.logger.Error("Failed to check mutual like", zap.Error(err))
-			return nil, status.Error(codes.Internal, "failed to check mutual like")
+			return models.DecisionEvents{}, err
+		}
+		return models.DecisionEvents{Withdrawn: withdrawn}, nil
+	}
+
+	newLike, err := newLikeEvent(ctx, decision.ActorUserId, decision.RecipientUserId, time.Now().Unix())
+	if err != nil {
+		return models.DecisionEvents{}, err
+	}
+	matched, err := s.matchWebhookEvents(decision.ActorUserId, decision.RecipientUserId)
+	if err != nil {
+		return models.DecisionEvents{}, err
+	}
+
+	return models.DecisionEvents{
+		NewLike: &newLike,
+		Matched: matched,
+	}, nil
+}
+
+// newLikeEvent builds the event telling watchers of the recipient that the actor liked them at likedAt
+func newLikeEvent(ctx context.Context, actorUserID, recipientUserID string, likedAt int64) (models.OutboxEvent, error) {
+	payload, err := proto.Marshal(&pb.WatchNewLikesEvent{
+		ActorId:       actorUserID,
+		UnixTimestamp: uint64(likedAt),
+		EventId:       utils.NewEventID(),
+	})
+	if err != nil {
+		return models.OutboxEvent{}, err
+	}
+
+	return models.OutboxEvent{
+		Topic:   utils.NewLikesTopic(ctx, recipientUserID),
+		Payload: payload,
+	}, nil
+}
+
+// matchWebhookEvents builds one outbox event per webhook endpoint announcing the mutual like.
+// All of them share an event_id so receivers can drop redeliveries.
+func (s *exploreCore) matchWebhookEvents(userID, matchedUserID string) ([]models.OutboxEvent, error) {
+	if len(s.webhookEndpoints) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(matchWebhook{
+		EventID:       utils.NewEventID(),
+		Type:          matchCreatedEvent,
+		UserID:        userID,
+		MatchedUserID: matchedUserID,
+		CreatedAt:     time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.webhookEvents(payload), nil
+}
+
+// likeWithdrawnWebhookEvents builds one outbox event per webhook endpoint announcing that the
+// actor passed on the recipient. The repository writes them only if the actor liked the recipient before.
+func (s *exploreCore) likeWithdrawnWebhookEvents(actorUserID, recipientUserID string) ([]models.OutboxEvent, error) {
+	if len(s.webhookEndpoints) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(likeWithdrawnWebhook{
+		EventID:         utils.NewEventID(),
+		Type:            likeWithdrawnEvent,
+		ActorUserID:     actorUserID,
+		RecipientUserID: recipientUserID,
+		WithdrawnAt:     time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.webhookEvents(payload), nil
+}
+
+// webhookEvents addresses the webhook payload to every endpoint
+func (s *exploreCore) webhookEvents(payload []byte) []models.OutboxEvent {
+	events := make([]models.OutboxEvent, len(s.webhookEndpoints))
+	for i, endpoint := range s.webhookEndpoints {
+		events[i] = models.OutboxEvent{
+			Topic:   utils.WebhookTopic(endpoint),
+			Payload: payload,
 		}
+	}
+	return events
+}
+
+// CheckMutualLike reports whether the two users like each other.
+// Callers use it to gate messaging, so it always reads from the DB.
+func (s *exploreCore) CheckMutualLike(ctx context.Context, req *pb.CheckMutualLikeRequest) (*pb.CheckMutualLikeResponse, error) {
+	hasMutualLike, err := s.repo.HasMutualLike(ctx, explorerdb.HasMutualLikeParams{
+		ActorUserID:     req.UserId,
+		RecipientUserID: req.OtherUserId,
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to check mutual like", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to check mutual like")
+	}
+
+	return &pb.CheckMutualLikeResponse{
+		MutualLike: hasMutualLike != nil && *hasMutualLike,
+	}, nil
+}
+
+// BlockUser blocks the blocked user for the blocker and ends any match between them
+func (s *exploreCore) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	err := s.repo.CreateBlock(ctx, explorerdb.CreateBlockParams{
+		BlockerUserID: req.BlockerUserId,
+		BlockedUserID: req.BlockedUserId,
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to create block", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to block user")
+	}
 
-		if hasMutualLike != nil && *hasMutualLike {
-			mutualLikes = true
+	if err := s.removeMatch(ctx, req.BlockerUserId, req.BlockedUserId); err != nil {
+		return nil, err
+	}
+	s.invalidateBlockCache(ctx, req.BlockerUserId, req.BlockedUserId)
+
+	return &pb.BlockUserResponse{}, nil
+}
+
+// UnblockUser lifts the block the blocker placed on the blocked user, or returns NotFound if there is none
+func (s *exploreCore) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	deleted, err := s.repo.DeleteBlock(ctx, explorerdb.DeleteBlockParams{
+		BlockerUserID: req.BlockerUserId,
+		BlockedUserID: req.BlockedUserId,
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to delete block", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to unblock user")
+	}
+	if deleted == 0 {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+
+	s.invalidateBlockCache(ctx, req.BlockerUserId, req.BlockedUserId)
+
+	return &pb.UnblockUserResponse{}, nil
+}
+
+// GetDecision returns the decision the actor made on the recipient, or NotFound if there is none
+func (s *exploreCore) GetDecision(ctx context.Context, req *pb.GetDecisionRequest) (*pb.GetDecisionResponse, error) {
+	decision, err := s.repo.GetDecision(ctx, explorerdb.GetDecisionParams{
+		ActorUserID:     req.ActorUserId,
+		RecipientUserID: req.RecipientUserId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "decision not found")
 		}
+		utils.Logger(ctx, s.logger).Error("Failed to get decision", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get decision")
 	}
 
-	return &pb.PutDecisionResponse{
-		MutualLikes: mutualLikes,
+	return &pb.GetDecisionResponse{
+		LikedRecipient: decision.LikedRecipient,
+		CreatedAtUnix:  uint64(decision.CreatedAt.Time.Unix()),
+		UpdatedAtUnix:  uint64(decision.UpdatedAt.Time.Unix()),
+	}, nil
+}
+
+// DeleteDecision withdraws the decision the actor made on the recipient
+// and drops the cached listings the decision was part of
+func (s *exploreCore) DeleteDecision(ctx context.Context, req *pb.DeleteDecisionRequest) (*pb.DeleteDecisionResponse, error) {
+	removed, err := s.repo.RemoveDecision(ctx, explorerdb.DeleteDecisionParams{
+		ActorUserID:     req.ActorUserId,
+		RecipientUserID: req.RecipientUserId,
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to delete decision", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete decision")
+	}
+	if !removed.Deleted {
+		return nil, status.Error(codes.NotFound, "decision not found")
+	}
+
+	s.invalidateDecisionCache(ctx, req.ActorUserId, req.RecipientUserId)
+	if err := s.cache.RemoveLike(ctx, req.ActorUserId, req.RecipientUserId); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to update likers index", zap.Error(err))
+	}
+	// Whether the deleted decision was a counted like is not known here
+	s.dropLikersCounts(ctx, req.RecipientUserId)
+
+	return &pb.DeleteDecisionResponse{
+		WasMutualLike: removed.WasMutualLike,
 	}, nil
 }
+
+// ListMatches returns all users the user has a mutual like with
+// First it try from cache, if not found then query from DB
+func (s *exploreCore) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	generation, useCache := s.cacheGeneration(ctx, req.GetUserId())
+	key := utils.MatchesKey(ctx, req.GetUserId(), generation, req.GetPaginationToken())
+
+	var cached pb.ListMatchesResponse
+	if useCache {
+		if ok, err := s.cache.GetJSON(ctx, key, &cached); err == nil && ok {
+			return &cached, nil
+		}
+	}
+
+	matches, nextToken, err := s.repo.GetMatches(ctx, req.UserId, models.PageRequest{
+		Token: req.GetPaginationToken(),
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get matches", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get matches")
+	}
+
+	pbMatches := make([]*pb.ListMatchesResponse_Match, len(matches))
+	for i, match := range matches {
+		pbMatches[i] = &pb.ListMatchesResponse_Match{
+			UserId:        match.UserID,
+			MatchedAtUnix: uint64(match.MatchedAt),
+		}
+	}
+
+	response := &pb.ListMatchesResponse{
+		Matches: pbMatches,
+	}
+
+	if nextToken != "" {
+		response.NextPaginationToken = &nextToken
+	}
+
+	if useCache {
+		s.cacheResponseInBackground(ctx, key, response, len(matches) == 0, utils.MatchesTTL)
+	}
+	return response, nil
+}
+
+// cacheResponse caches a listing response for ttl. An empty response is cached as the
+// empty sentinel for EmptyResultTTL instead.
+func (s *exploreCore) cacheResponse(ctx context.Context, key string, response any, empty bool, ttl time.Duration) {
+	if empty {
+		_ = s.cache.Set(ctx, key, utils.EmptyCacheValue, utils.EmptyResultTTL)
+		return
+	}
+	_ = s.cache.SetJSON(ctx, key, response, ttl)
+}
+
+// cacheResponseInBackground caches a copy of response without holding up the call, as the
+// caller goes on to change the response it returns, such as by signing its pagination tokens
+func (s *exploreCore) cacheResponseInBackground(ctx context.Context, key string, response proto.Message, empty bool, ttl time.Duration) {
+	response = proto.Clone(response)
+	s.background.Go(ctx, func(ctx context.Context) {
+		s.cacheResponse(ctx, key, response, empty, ttl)
+	})
+}
+
+// removeMatch deletes the stored match between two users, if there is one
+func (s *exploreCore) removeMatch(ctx context.Context, userID, matchedUserID string) error {
+	_, err := s.repo.DeleteMatch(ctx, explorerdb.DeleteMatchParams{
+		UserID:        userID,
+		MatchedUserID: matchedUserID,
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to delete match", zap.Error(err))
+		return status.Error(codes.Internal, "failed to delete match")
+	}
+	return nil
+}
+
+// cacheGeneration returns the generation of the user's cached listings, which their listing
+// keys carry. It reports false when the generation cannot be read, and the listings are then
+// neither read from nor written to the cache.
+func (s *exploreCore) cacheGeneration(ctx context.Context, userID string) (string, bool) {
+	generation, err := s.cache.Get(ctx, utils.CacheGenerationKey(ctx, userID))
+	if err != nil {
+		return "", false
+	}
+	return generation, true
+}
+
+// bumpCacheGenerations moves the users to a new generation of cached listings. Every page cached
+// for them before, whatever its depth or page size, is missed from then on and left to expire.
+// Instances keeping a local copy of the old generation see the new one once their copy expires.
+func bumpCacheGenerations(ctx context.Context, c cache.CacheProvider, userIDs ...string) error {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 10)
+	values := make(map[string]interface{}, len(userIDs))
+	for _, id := range userIDs {
+		values[utils.CacheGenerationKey(ctx, id)] = generation
+	}
+	return c.SetMany(ctx, values, utils.CacheGenerationTTL)
+}
+
+// invalidateDecisionCache invalidates every cached listing of the actor and the recipient,
+// the new likers, liked recipients and matches a decision between them may change
+func (s *exploreCore) invalidateDecisionCache(ctx context.Context, actorUserID, recipientUserID string) {
+	if err := bumpCacheGenerations(ctx, s.cache, actorUserID, recipientUserID); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to invalidate decision cache", zap.Error(err))
+	}
+}
+
+// invalidateBlockCache drops the likers indexes, cached listings and counts of likers of both
+// users, since a block hides each of them from the other's lists and ends their match
+func (s *exploreCore) invalidateBlockCache(ctx context.Context, userID, otherUserID string) {
+	if err := bumpCacheGenerations(ctx, s.cache, userID, otherUserID); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to invalidate block cache", zap.Error(err))
+	}
+	if err := s.cache.Del(ctx, utils.LikersCountKey(ctx, userID), utils.LikersCountKey(ctx, otherUserID)); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to invalidate block cache", zap.Error(err))
+	}
+	if err := s.cache.DelLikersIndex(ctx, userID, otherUserID); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to invalidate likers index", zap.Error(err))
+	}
+}
+
+// indexDecision writes the decision, made at likedAt as the DB lists it, through to the likers
+// indexes. A like between users who blocked one another stays hidden, so it is left out.
+func (s *exploreCore) indexDecision(ctx context.Context, req *pb.PutDecisionRequest, likedAt int64) {
+	if !req.LikedRecipient {
+		if err := s.cache.RemoveLike(ctx, req.ActorUserId, req.RecipientUserId); err != nil {
+			utils.Logger(ctx, s.logger).Warn("Failed to update likers index", zap.Error(err))
+		}
+		return
+	}
+
+	blocked, err := s.repo.IsBlocked(ctx, explorerdb.IsBlockedParams{
+		BlockerUserID: req.RecipientUserId,
+		BlockedUserID: req.ActorUserId,
+	})
+	if err != nil {
+		// Without knowing, the recipient's index is dropped rather than risk showing a blocked user
+		utils.Logger(ctx, s.logger).Warn("Failed to check block", zap.Error(err))
+		if err := s.cache.DelLikersIndex(ctx, req.RecipientUserId); err != nil {
+			utils.Logger(ctx, s.logger).Warn("Failed to invalidate likers index", zap.Error(err))
+		}
+		return
+	}
+	if blocked {
+		return
+	}
+
+	if err := s.cache.AddLike(ctx, req.ActorUserId, req.RecipientUserId, likedAt); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to update likers index", zap.Error(err))
+	}
+}
+
+// countDecision moves the recipient's live likers count by the change the decision made to it
+func (s *exploreCore) countDecision(ctx context.Context, recipientUserID string, delta int64) {
+	if delta == 0 {
+		return
+	}
+	if err := s.cache.IncrLikersCount(ctx, recipientUserID, delta); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to update likers count", zap.Error(err))
+	}
+}
+
+// dropLikersCounts drops the live likers counts of the recipients, to be seeded from the DB on their next read
+func (s *exploreCore) dropLikersCounts(ctx context.Context, recipientUserIDs ...string) {
+	keys := make([]string, len(recipientUserIDs))
+	for i, id := range recipientUserIDs {
+		keys[i] = utils.LikersCountKey(ctx, id)
+	}
+	if err := s.cache.Del(ctx, keys...); err != nil {
+		utils.Logger(ctx, s.logger).Warn("Failed to invalidate likers count", zap.Error(err))
+	}
+}
+
+// applyLikersReadMask returns a copy of the likers response holding only the fields selected by the mask.
+// It only trims the response sent: pages are still read, cached and keyed in full, whatever the mask,
+// so that requests with different masks share them. The mask is applied last and never mutates resp.
+func applyLikersReadMask(resp *pb.ListLikedYouResponse, mask *fieldmaskpb.FieldMask) *pb.ListLikedYouResponse {
+	if len(mask.GetPaths()) == 0 {
+		return resp
+	}
+
+	var withLikers, withActorID, withTimestamp, withLikedBack, withToken, withPrevToken bool
+	for _, path := range mask.GetPaths() {
+		switch path {
+		case "likers":
+			withLikers, withActorID, withTimestamp, withLikedBack = true, true, true, true
+		case "likers.actor_id":
+			withLikers, withActorID = true, true
+		case "likers.unix_timestamp":
+			withLikers, withTimestamp = true, true
+		case "likers.already_liked_back":
+			withLikers, withLikedBack = true, true
+		case "next_pagination_token":
+			withToken = true
+		case "prev_pagination_token":
+			withPrevToken = true
+		}
+	}
+
+	masked := &pb.ListLikedYouResponse{}
+	if withToken {
+		masked.NextPaginationToken = resp.NextPaginationToken
+	}
+	if withPrevToken {
+		masked.PrevPaginationToken = resp.PrevPaginationToken
+	}
+	if withLikers {
+		masked.Likers = make([]*pb.ListLikedYouResponse_Liker, len(resp.GetLikers()))
+		for i, liker := range resp.GetLikers() {
+			masked.Likers[i] = &pb.ListLikedYouResponse_Liker{}
+			if withActorID {
+				masked.Likers[i].ActorId = liker.GetActorId()
+			}
+			if withTimestamp {
+				masked.Likers[i].UnixTimestamp = liker.GetUnixTimestamp()
+			}
+			if withLikedBack {
+				masked.Likers[i].AlreadyLikedBack = liker.GetAlreadyLikedBack()
+			}
+		}
+	}
+
+	return masked
+}
+
+// pageRequest extracts the requested page from a likers listing request
+func pageRequest(req *pb.ListLikedYouRequest) models.PageRequest {
+	return models.PageRequest{
+		Token:         req.GetPaginationToken(),
+		Size:          int(req.GetPageSize()),
+		Since:         int64(req.GetSinceUnix()),
+		Until:         int64(req.GetUntilUnix()),
+		Ascending:     req.GetSortOrder() == pb.SortOrder_OLDEST_FIRST,
+		ByPopularity:  req.GetSortOrder() == pb.SortOrder_MOST_POPULAR,
+		IncludePassed: req.GetIncludePassed(),
+		Page:          int(req.GetPage()),
+	}
+}
@@ -2,18 +2,27 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	explorerdb "github.com/backend-interview-task/db/gen/explorer"
 	"github.com/backend-interview-task/internal/models"
 	cachemock "github.com/backend-interview-task/mocks/providers/cache"
+	pubsubmock "github.com/backend-interview-task/mocks/providers/pubsub"
 	repomock "github.com/backend-interview-task/mocks/repository"
 	pb "github.com/backend-interview-task/proto"
 	"github.com/backend-interview-task/utils"
@@ -23,6 +32,7 @@ type ExplorerCoreTestSuite struct {
 	suite.Suite
 	mockExplorerRepo *repomock.ExplorerRepository
 	mockCache        *cachemock.CacheProvider
+	mockPubSub       *pubsubmock.PubSubProvider
 	explorerCore     ExplorerCore
 	logger           *zap.Logger
 }
@@ -35,154 +45,379 @@ func (s *ExplorerCoreTestSuite) SetupTest() {
 	s.logger = zap.NewNop()
 	s.mockExplorerRepo = new(repomock.ExplorerRepository)
 	s.mockCache = new(cachemock.CacheProvider)
-	s.explorerCore = NewExploreCore(s.mockExplorerRepo, s.mockCache, s.logger)
+	s.mockPubSub = new(pubsubmock.PubSubProvider)
+	s.explorerCore = NewExploreCore(s.mockExplorerRepo, s.mockCache, s.mockPubSub, nil, 0, false, s.logger)
 }
 
 func (s *ExplorerCoreTestSuite) TearDownTest() {
 	s.mockExplorerRepo.AssertExpectations(s.T())
 	s.mockCache.AssertExpectations(s.T())
+	s.mockPubSub.AssertExpectations(s.T())
 }
 
-func (s *ExplorerCoreTestSuite) TestListLikers_CacheHit() {
+// expectCacheGeneration expects the generation of the user's cached listings to be read
+func (s *ExplorerCoreTestSuite) expectCacheGeneration(userID, generation string) {
+	s.mockCache.EXPECT().Get(mock.Anything, utils.CacheGenerationKey(context.Background(), userID)).Return(generation, nil).Once()
+}
+
+// generationsOf matches the cache generations of userIDs, all moved to the same new generation
+func generationsOf(userIDs ...string) interface{} {
+	return mock.MatchedBy(func(values map[string]interface{}) bool {
+		if len(values) != len(userIDs) {
+			return false
+		}
+		var generation interface{}
+		for _, id := range userIDs {
+			value, ok := values[utils.CacheGenerationKey(context.Background(), id)]
+			if !ok || value == "" || (generation != nil && value != generation) {
+				return false
+			}
+			generation = value
+		}
+		return true
+	})
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexHit() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "testuser",
-		PaginationToken: utils.ToPointer("eyJsYXN0X2NyZWF0ZWRfYXQiOiAxNzU2Mzc3NjU0LCAibGltaXQiOiAxMH0="),
+		PageSize:        utils.ToPointer(uint32(2)),
 	}
-	cacheKey := utils.LikersKey(req.RecipientUserId, req.GetPaginationToken())
 
-	cachedEmptyResp := &pb.ListLikedYouResponse{}
-	cachedFinalResp := pb.ListLikedYouResponse{
-		Likers: []*pb.ListLikedYouResponse_Liker{
-			{ActorId: "testActor1", UnixTimestamp: 100},
-			{ActorId: "testActor2", UnixTimestamp: 200},
-		},
+	// One liker more than the page size means there is a next page
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, models.LikersRange{Limit: 3}).
+		Return([]models.Liker{
+			{ActorID: "actor1", Timestamp: 300, LikedBack: true},
+			{ActorID: "actor2", Timestamp: 200},
+			{ActorID: "actor3", Timestamp: 100},
+		}, true, nil).Once()
+
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Require().Len(resp.Likers, 2)
+	s.Equal("actor1", resp.Likers[0].ActorId)
+	s.Equal(uint64(300), resp.Likers[0].UnixTimestamp)
+	s.True(resp.Likers[0].AlreadyLikedBack)
+	s.Equal("actor2", resp.Likers[1].ActorId)
+	s.False(resp.Likers[1].AlreadyLikedBack)
+
+	cursor, err := utils.DecodeCursor(resp.GetNextPaginationToken())
+	s.Require().NoError(err)
+	s.Equal(&utils.Cursor{LastCreatedAt: 200, Limit: 2}, cursor)
+	s.Nil(resp.PrevPaginationToken)
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "GetLikers")
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexHit_LastPage() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
 	}
 
-	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, cachedEmptyResp).
-		Run(func(ctx context.Context, key string, out interface{}) {
-			obj := out.(*pb.ListLikedYouResponse)
-			*obj = cachedFinalResp
-		}).Return(true, nil).Once()
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, models.LikersRange{Limit: utils.DefaultPageSize + 1}).
+		Return([]models.Liker{}, true, nil).Once()
 
 	resp, err := s.explorerCore.ListLikers(context.Background(), req)
 
 	s.NoError(err)
-	s.Equal(&cachedFinalResp, resp)
-	s.mockExplorerRepo.AssertNotCalled(s.T(), "GetLikers")
+	s.Empty(resp.Likers)
+	s.Nil(resp.NextPaginationToken)
 }
 
-func (s *ExplorerCoreTestSuite) TestListLikers_CacheMiss_DatabaseSuccess() {
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexPageEndsWithinSecond_DatabaseSuccess() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "testuser",
-		PaginationToken: utils.ToPointer("token123"),
+		PageSize:        utils.ToPointer(uint32(2)),
 	}
-	cacheKey := utils.LikersKey(req.RecipientUserId, req.GetPaginationToken())
 
-	// Mock cache miss
-	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
-		Return(false, nil).Once()
+	// The next page starts in the same second as the last liker, which only the DB can seek to
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, models.LikersRange{Limit: 3}).
+		Return([]models.Liker{
+			{ActorID: "actor1", Timestamp: 300},
+			{ActorID: "actor2", Timestamp: 200},
+			{ActorID: "actor3", Timestamp: 200},
+		}, true, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 300}, {ActorID: "actor2", Timestamp: 200}}, models.PageTokens{Next: "next"}, nil).Once()
 
-	// Mock repository response
-	likers := []models.Liker{
-		{ActorID: "actor1", Timestamp: 100},
-		{ActorID: "actor2", Timestamp: 200},
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 2)
+	s.Equal("next", resp.GetNextPaginationToken())
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_TokenWithinSecond_SkipsIndex() {
+	token, err := (&utils.Cursor{LastCreatedAt: 200, LastID: 7, Limit: 2}).Encode()
+	s.Require().NoError(err)
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		PaginationToken: &token,
 	}
-	nextToken := "nextPageToken"
 
-	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return(likers, nextToken, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return([]models.Liker{{ActorID: "actor3", Timestamp: 200}}, models.PageTokens{}, nil).Once()
 
-	// Mock cache set (async goroutine)
-	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.LikersTTL).
-		Return(nil).Maybe()
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 1)
+	s.mockCache.AssertNotCalled(s.T(), "GetLikersPage")
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexRange_FollowsToken() {
+	token, err := (&utils.Cursor{LastCreatedAt: 500, Limit: 10}).Encode()
+	s.Require().NoError(err)
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		PaginationToken: &token,
+		SinceUnix:       utils.ToPointer(uint64(100)),
+		UntilUnix:       utils.ToPointer(uint64(1000)),
+	}
+
+	// Newest first continues below the token, within [since, until)
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, models.LikersRange{After: 99, Before: 500, Limit: 11}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 400}}, true, nil).Once()
 
 	resp, err := s.explorerCore.ListLikers(context.Background(), req)
 
 	s.NoError(err)
-	s.NotNil(resp)
-	s.Len(resp.Likers, 2)
-	s.Equal("actor1", resp.Likers[0].ActorId)
-	s.Equal(uint64(100), resp.Likers[0].UnixTimestamp)
-	s.Equal("actor2", resp.Likers[1].ActorId)
-	s.Equal(uint64(200), resp.Likers[1].UnixTimestamp)
-	s.Equal(nextToken, *resp.NextPaginationToken)
+	s.Len(resp.Likers, 1)
+	s.Nil(resp.NextPaginationToken)
+
+	// The page starts on a new second, so the previous page ends before all of it
+	cursor, err := utils.DecodeCursor(resp.GetPrevPaginationToken())
+	s.Require().NoError(err)
+	s.Equal(&utils.Cursor{LastCreatedAt: 400, Limit: 10, Backward: true}, cursor)
 }
 
-func (s *ExplorerCoreTestSuite) TestListLikers_CacheMiss_DatabaseSuccess_NoNextToken() {
+func (s *ExplorerCoreTestSuite) TestListLikers_OffsetPage_SkipsIndex() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "testuser",
-		PaginationToken: nil, // No pagination token
+		Page:            utils.ToPointer(uint32(2)),
 	}
-	cacheKey := utils.LikersKey(req.RecipientUserId, req.GetPaginationToken())
 
-	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
-		Return(false, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, models.PageRequest{Page: 2}).
+		Return([]models.Liker{{ActorID: "actor21", Timestamp: 100}}, models.PageTokens{}, nil).Once()
+
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 1)
+	s.Nil(resp.NextPaginationToken)
+	s.mockCache.AssertNotCalled(s.T(), "GetLikersPage")
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_MostPopular_SkipsIndex() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		SortOrder:       pb.SortOrder_MOST_POPULAR,
+	}
+
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, models.PageRequest{ByPopularity: true}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 100}}, models.PageTokens{Next: "next"}, nil).Once()
+
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 1)
+	s.Equal("next", resp.GetNextPaginationToken())
+	s.mockCache.AssertNotCalled(s.T(), "GetLikersPage")
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_PrevToken_SkipsIndex() {
+	token, err := (&utils.Cursor{LastCreatedAt: 400, Limit: 10, Backward: true}).Encode()
+	s.Require().NoError(err)
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		PaginationToken: &token,
+	}
+
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 500}}, models.PageTokens{Next: "next", Prev: "prev"}, nil).Once()
+
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 1)
+	s.Equal("next", resp.GetNextPaginationToken())
+	s.Equal("prev", resp.GetPrevPaginationToken())
+	s.mockCache.AssertNotCalled(s.T(), "GetLikersPage")
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexRange_OldestFirst() {
+	token, err := (&utils.Cursor{LastCreatedAt: 500, Limit: 10, Ascending: true}).Encode()
+	s.Require().NoError(err)
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		PaginationToken: &token,
+	}
+
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, models.LikersRange{After: 500, Ascending: true, Limit: 11}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 600}}, true, nil).Once()
+
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 1)
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexRange_LikeTTL() {
+	explorerCore := NewExploreCore(s.mockExplorerRepo, s.mockCache, s.mockPubSub, nil, time.Hour, false, s.logger)
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+	}
+
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, mock.Anything).
+		Run(func(ctx context.Context, recipient string, page models.LikersRange) {
+			// Likes from more than an hour ago are left out
+			s.InDelta(time.Now().Add(-time.Hour).Unix()-1, page.After, 1)
+			s.Zero(page.Before)
+		}).Return([]models.Liker{}, true, nil).Once()
+
+	_, err := explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_ColdIndex_DatabaseSuccess() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		SortOrder:       pb.SortOrder_OLDEST_FIRST,
+	}
+	page := models.PageRequest{Ascending: true}
+
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, mock.Anything).Return(nil, false, nil).Once()
 
 	likers := []models.Liker{
-		{ActorID: "actor1", Timestamp: 100},
+		{ActorID: "actor1", Timestamp: 100, LikedBack: true},
+		{ActorID: "actor2", Timestamp: 200},
 	}
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, page).
+		Return(likers, models.PageTokens{Next: "nextPageToken"}, nil).Once()
+
+	// The index is built in the background from every liker
+	built := make(chan []models.Liker, 1)
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, models.PageRequest{Size: likersIndexMaxSize}).
+		Return(likers, models.PageTokens{}, nil).Once()
+	s.mockCache.EXPECT().SetLikersIndex(mock.Anything, req.RecipientUserId, likers, utils.LikersIndexTTL).
+		Run(func(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) {
+			built <- likers
+		}).Return(nil).Once()
 
-	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return(likers, "", nil).Once() // Empty next token
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
 
-	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.LikersTTL).
-		Return(nil).Maybe()
+	s.NoError(err)
+	s.Require().Len(resp.Likers, 2)
+	s.Equal("actor1", resp.Likers[0].ActorId)
+	s.Equal(uint64(100), resp.Likers[0].UnixTimestamp)
+	s.True(resp.Likers[0].AlreadyLikedBack)
+	s.Equal("nextPageToken", resp.GetNextPaginationToken())
+	s.Len(<-built, 2)
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_ColdIndex_TooManyLikers() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+	}
+
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, mock.Anything).Return(nil, false, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, models.PageRequest{}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 100}}, models.PageTokens{}, nil).Once()
+
+	// A next page past likersIndexMaxSize leaves the recipient without an index
+	loaded := make(chan struct{})
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, models.PageRequest{Size: likersIndexMaxSize}).
+		Run(func(ctx context.Context, recipientUserID string, page models.PageRequest) {
+			close(loaded)
+		}).Return([]models.Liker{{ActorID: "actor1", Timestamp: 100}}, models.PageTokens{Next: "next"}, nil).Once()
 
 	resp, err := s.explorerCore.ListLikers(context.Background(), req)
 
 	s.NoError(err)
-	s.NotNil(resp)
 	s.Len(resp.Likers, 1)
-	s.Nil(resp.NextPaginationToken) // Should be nil when no next token
+	<-loaded
+	s.mockCache.AssertNotCalled(s.T(), "SetLikersIndex")
 }
 
-func (s *ExplorerCoreTestSuite) TestListLikers_CacheMiss_DatabaseError() {
+func (s *ExplorerCoreTestSuite) TestListLikers_IndexError_DatabaseSuccess() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "testuser",
-		PaginationToken: utils.ToPointer("token123"),
 	}
-	cacheKey := utils.LikersKey(req.RecipientUserId, req.GetPaginationToken())
 
-	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
-		Return(false, nil).Once()
+	// An unavailable index is not rebuilt, the page comes from the DB
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, mock.Anything).
+		Return(nil, false, errors.New("cache unavailable")).Once()
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, models.PageRequest{}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 100}}, models.PageTokens{}, nil).Once()
+
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Likers, 1)
+	s.Nil(resp.NextPaginationToken)
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikers_InvalidToken_DatabaseError() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		PaginationToken: utils.ToPointer("token123"),
+	}
 
-	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return(nil, "", errors.New("database connection failed")).Once()
+	// The index is skipped and the repository rejects the token
+	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return(nil, models.PageTokens{}, errors.New("invalid paginationToken")).Once()
 
 	resp, err := s.explorerCore.ListLikers(context.Background(), req)
 
 	s.Nil(resp)
-	s.Error(err)
 	s.Equal(codes.Internal, status.Code(err))
 	s.Contains(err.Error(), "failed to get likers")
+	s.mockCache.AssertNotCalled(s.T(), "GetLikersPage")
 }
 
-func (s *ExplorerCoreTestSuite) TestListLikers_CacheError_DatabaseSuccess() {
+func (s *ExplorerCoreTestSuite) TestListLikers_ReadMask_ActorIDsOnly() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "testuser",
-		PaginationToken: utils.ToPointer("token123"),
+		PageSize:        utils.ToPointer(uint32(1)),
+		ReadMask:        &fieldmaskpb.FieldMask{Paths: []string{"likers.actor_id"}},
 	}
-	cacheKey := utils.LikersKey(req.RecipientUserId, req.GetPaginationToken())
 
-	// Mock cache error
-	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
-		Return(false, errors.New("cache unavailable")).Once()
+	s.mockCache.EXPECT().GetLikersPage(mock.Anything, req.RecipientUserId, models.LikersRange{Limit: 2}).
+		Return([]models.Liker{
+			{ActorID: "actor1", Timestamp: 200},
+			{ActorID: "actor2", Timestamp: 100},
+		}, true, nil).Once()
 
-	likers := []models.Liker{
-		{ActorID: "actor1", Timestamp: 100},
-	}
+	resp, err := s.explorerCore.ListLikers(context.Background(), req)
 
-	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return(likers, "", nil).Once()
+	s.NoError(err)
+	s.Require().Len(resp.Likers, 1)
+	s.Equal("actor1", resp.Likers[0].ActorId)
+	s.Zero(resp.Likers[0].UnixTimestamp)
+	s.Nil(resp.NextPaginationToken)
+}
 
-	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.LikersTTL).
-		Return(nil).Maybe()
+func (s *ExplorerCoreTestSuite) TestListNewLikers_ReadMask_TokenOnly_CacheHit() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		ReadMask:        &fieldmaskpb.FieldMask{Paths: []string{"next_pagination_token"}},
+	}
+	s.expectCacheGeneration(req.RecipientUserId, "")
+	cacheKey := utils.NewLikersKey(context.Background(), req.RecipientUserId, "", "", 0, 0)
 
-	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
+		Run(func(ctx context.Context, key string, out interface{}) {
+			cached := out.(*pb.ListLikedYouResponse)
+			cached.Likers = []*pb.ListLikedYouResponse_Liker{{ActorId: "actor1", UnixTimestamp: 100}}
+			cached.NextPaginationToken = utils.ToPointer("next")
+		}).Return(true, nil).Once()
 
-	s.NoError(err) // Should still succeed despite cache error
-	s.NotNil(resp)
-	s.Len(resp.Likers, 1)
+	resp, err := s.explorerCore.ListNewLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Empty(resp.Likers)
+	s.Equal("next", resp.GetNextPaginationToken())
 }
 
 func (s *ExplorerCoreTestSuite) TestListNewLikers_CacheHit() {
@@ -190,7 +425,9 @@ func (s *ExplorerCoreTestSuite) TestListNewLikers_CacheHit() {
 		RecipientUserId: "testuser",
 		PaginationToken: utils.ToPointer("newtoken123"),
 	}
-	cacheKey := utils.NewLikersKey(req.RecipientUserId, req.GetPaginationToken())
+	// Pages are read from the current generation of the recipient's listings
+	s.expectCacheGeneration(req.RecipientUserId, "1700000000000000000")
+	cacheKey := utils.NewLikersKey(context.Background(), req.RecipientUserId, "1700000000000000000", req.GetPaginationToken(), req.GetPageSize(), int32(req.GetSortOrder()))
 
 	cachedEmptyResp := &pb.ListLikedYouResponse{}
 	cachedFinalResp := pb.ListLikedYouResponse{
@@ -202,13 +439,13 @@ func (s *ExplorerCoreTestSuite) TestListNewLikers_CacheHit() {
 	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, cachedEmptyResp).
 		Run(func(ctx context.Context, key string, out interface{}) {
 			obj := out.(*pb.ListLikedYouResponse)
-			*obj = cachedFinalResp
+			obj.Likers = cachedFinalResp.Likers
 		}).Return(true, nil).Once()
 
 	resp, err := s.explorerCore.ListNewLikers(context.Background(), req)
 
 	s.NoError(err)
-	s.Equal(&cachedFinalResp, resp)
+	s.Equal(cachedFinalResp.Likers, resp.Likers)
 	s.mockExplorerRepo.AssertNotCalled(s.T(), "GetNewLikers")
 }
 
@@ -217,7 +454,8 @@ func (s *ExplorerCoreTestSuite) TestListNewLikers_CacheMiss_DatabaseSuccess() {
 		RecipientUserId: "testuser",
 		PaginationToken: utils.ToPointer("newtoken123"),
 	}
-	cacheKey := utils.NewLikersKey(req.RecipientUserId, req.GetPaginationToken())
+	s.expectCacheGeneration(req.RecipientUserId, "")
+	cacheKey := utils.NewLikersKey(context.Background(), req.RecipientUserId, "", req.GetPaginationToken(), req.GetPageSize(), int32(req.GetSortOrder()))
 
 	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
 		Return(false, nil).Once()
@@ -228,8 +466,8 @@ func (s *ExplorerCoreTestSuite) TestListNewLikers_CacheMiss_DatabaseSuccess() {
 	}
 	nextToken := "newNextToken"
 
-	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return(likers, nextToken, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return(likers, models.PageTokens{Next: nextToken}, nil).Once()
 
 	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.NewLikersTTL).
 		Return(nil).Maybe()
@@ -244,18 +482,61 @@ func (s *ExplorerCoreTestSuite) TestListNewLikers_CacheMiss_DatabaseSuccess() {
 	s.Equal(nextToken, *resp.NextPaginationToken)
 }
 
+func (s *ExplorerCoreTestSuite) TestListNewLikers_EmptyResultCachedAsSentinel() {
+	req := &pb.ListLikedYouRequest{RecipientUserId: "testuser"}
+	s.expectCacheGeneration(req.RecipientUserId, "")
+	cacheKey := utils.NewLikersKey(context.Background(), req.RecipientUserId, "", "", 0, 0)
+
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
+		Return(false, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return(nil, models.PageTokens{}, nil).Once()
+
+	cached := make(chan struct{})
+	s.mockCache.EXPECT().Set(mock.Anything, cacheKey, utils.EmptyCacheValue, utils.EmptyResultTTL).
+		Run(func(context.Context, string, interface{}, time.Duration) { close(cached) }).
+		Return(nil).Once()
+
+	resp, err := s.explorerCore.ListNewLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Empty(resp.Likers)
+	s.Nil(resp.NextPaginationToken)
+	<-cached
+	s.mockCache.AssertNotCalled(s.T(), "SetJSON")
+}
+
+func (s *ExplorerCoreTestSuite) TestListNewLikers_IncludePassed_SkipsCache() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "testuser",
+		IncludePassed:   true,
+	}
+
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, req.RecipientUserId, models.PageRequest{IncludePassed: true}).
+		Return([]models.Liker{{ActorID: "passedactor", Timestamp: 300}}, models.PageTokens{}, nil).Once()
+
+	resp, err := s.explorerCore.ListNewLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Require().Len(resp.Likers, 1)
+	s.Equal("passedactor", resp.Likers[0].ActorId)
+	s.mockCache.AssertNotCalled(s.T(), "GetJSON")
+	s.mockCache.AssertNotCalled(s.T(), "SetJSON")
+}
+
 func (s *ExplorerCoreTestSuite) TestListNewLikers_DatabaseError() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "testuser",
 		PaginationToken: utils.ToPointer("newtoken123"),
 	}
-	cacheKey := utils.NewLikersKey(req.RecipientUserId, req.GetPaginationToken())
+	s.expectCacheGeneration(req.RecipientUserId, "")
+	cacheKey := utils.NewLikersKey(context.Background(), req.RecipientUserId, "", req.GetPaginationToken(), req.GetPageSize(), int32(req.GetSortOrder()))
 
 	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
 		Return(false, nil).Once()
 
-	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return(nil, "", errors.New("database timeout")).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, req.RecipientUserId, pageRequest(req)).
+		Return(nil, models.PageTokens{}, errors.New("database timeout")).Once()
 
 	resp, err := s.explorerCore.ListNewLikers(context.Background(), req)
 
@@ -265,9 +546,84 @@ func (s *ExplorerCoreTestSuite) TestListNewLikers_DatabaseError() {
 	s.Contains(err.Error(), "failed to get new likers")
 }
 
+func (s *ExplorerCoreTestSuite) TestCountLikers_TracksHotRecipient() {
+	explorerCore := NewExploreCore(s.mockExplorerRepo, s.mockCache, s.mockPubSub, nil, 0, true, s.logger)
+	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
+
+	tracked := make(chan struct{})
+	s.mockCache.EXPECT().TrackHotRecipient(mock.Anything, req.RecipientUserId, mock.Anything).
+		Run(func(context.Context, string, time.Time) { close(tracked) }).
+		Return(nil).Once()
+	s.mockCache.EXPECT().Get(mock.Anything, utils.LikersCountKey(context.Background(), req.RecipientUserId)).Return("42", nil).Once()
+
+	resp, err := explorerCore.CountLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(uint64(42), resp.Count)
+	<-tracked
+}
+
+func (s *ExplorerCoreTestSuite) TestWarmRecipients_BatchesPagesAndSeedsExpiredCounts() {
+	ctx := context.Background()
+	s.mockCache.EXPECT().GetMany(mock.Anything, utils.CacheGenerationKey(ctx, "user1"), utils.CacheGenerationKey(ctx, "user2")).
+		Return([]string{"1700000000000000000", ""}, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, "user1", models.PageRequest{}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 100}}, models.PageTokens{}, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, "user2", models.PageRequest{}).
+		Return(nil, models.PageTokens{}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, mock.Anything, utils.NewLikersTTL).
+		Run(func(ctx context.Context, values map[string]interface{}, expiration time.Duration) {
+			s.Len(values, 1)
+			s.Contains(values, utils.NewLikersKey(ctx, "user1", "1700000000000000000", "", 0, 0))
+		}).
+		Return(nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, map[string]interface{}{utils.NewLikersKey(ctx, "user2", "", "", 0, 0): utils.EmptyCacheValue}, utils.EmptyResultTTL).
+		Return(nil).Once()
+	s.mockCache.EXPECT().GetMany(mock.Anything, utils.LikersCountKey(ctx, "user1"), utils.LikersCountKey(ctx, "user2")).
+		Return([]string{"1", ""}, nil).Once()
+	s.mockExplorerRepo.EXPECT().CountLikers(mock.Anything, "user2").Return(int64(0), nil).Once()
+	s.mockCache.EXPECT().SeedLikersCount(mock.Anything, "user2", int64(0), utils.EmptyResultTTL).Return(nil).Once()
+
+	warmed, err := s.explorerCore.WarmRecipients(ctx, []string{"user1", "user2"})
+
+	s.NoError(err)
+	s.Equal(2, warmed)
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "CountLikers", mock.Anything, "user1")
+}
+
+func (s *ExplorerCoreTestSuite) TestWarmRecipients_SkipsFailedRecipient() {
+	ctx := context.Background()
+	s.mockCache.EXPECT().GetMany(mock.Anything, utils.CacheGenerationKey(ctx, "user1"), utils.CacheGenerationKey(ctx, "user2")).
+		Return([]string{"", ""}, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, "user1", models.PageRequest{}).
+		Return(nil, models.PageTokens{}, errors.New("database timeout")).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, "user2", models.PageRequest{}).
+		Return(nil, models.PageTokens{}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, map[string]interface{}{utils.NewLikersKey(ctx, "user2", "", "", 0, 0): utils.EmptyCacheValue}, utils.EmptyResultTTL).
+		Return(nil).Once()
+	s.mockCache.EXPECT().GetMany(mock.Anything, utils.LikersCountKey(ctx, "user2")).Return([]string{"0"}, nil).Once()
+
+	warmed, err := s.explorerCore.WarmRecipients(ctx, []string{"user1", "user2"})
+
+	s.NoError(err)
+	s.Equal(1, warmed)
+}
+
+func (s *ExplorerCoreTestSuite) TestWarmRecipients_CacheError() {
+	s.mockCache.EXPECT().GetMany(mock.Anything, utils.CacheGenerationKey(context.Background(), "user1")).Return([]string{""}, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetNewLikers(mock.Anything, "user1", models.PageRequest{}).
+		Return(nil, models.PageTokens{}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, mock.Anything, utils.EmptyResultTTL).Return(errors.New("connection refused")).Once()
+
+	warmed, err := s.explorerCore.WarmRecipients(context.Background(), []string{"user1"})
+
+	s.Error(err)
+	s.Zero(warmed)
+}
+
 func (s *ExplorerCoreTestSuite) TestCountLikers_CacheHit() {
 	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
 	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("42", nil).Once()
 
@@ -276,12 +632,12 @@ func (s *ExplorerCoreTestSuite) TestCountLikers_CacheHit() {
 	s.NoError(err)
 	s.NotNil(resp)
 	s.Equal(uint64(42), resp.Count)
-	s.mockExplorerRepo.AssertNotCalled(s.T(), "CountLikes")
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "CountLikers")
 }
 
 func (s *ExplorerCoreTestSuite) TestCountLikers_CacheHit_ZeroCount() {
 	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
 	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("0", nil).Once()
 
@@ -290,20 +646,20 @@ func (s *ExplorerCoreTestSuite) TestCountLikers_CacheHit_ZeroCount() {
 	s.NoError(err)
 	s.NotNil(resp)
 	s.Equal(uint64(0), resp.Count)
-	s.mockExplorerRepo.AssertNotCalled(s.T(), "CountLikes")
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "CountLikers")
 }
 
 func (s *ExplorerCoreTestSuite) TestCountLikers_CacheInvalidValue_DatabaseSuccess() {
 	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
 	// Cache returns invalid value
 	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("invalid_number", nil).Once()
 
-	s.mockExplorerRepo.EXPECT().CountLikes(mock.Anything, req.RecipientUserId).
+	s.mockExplorerRepo.EXPECT().CountLikers(mock.Anything, req.RecipientUserId).
 		Return(int64(15), nil).Once()
 
-	s.mockCache.EXPECT().Set(mock.Anything, cacheKey, "15", utils.LikersCountTTL).
+	s.mockCache.EXPECT().SeedLikersCount(mock.Anything, req.RecipientUserId, int64(15), utils.LikersCountTTL).
 		Return(nil).Maybe()
 
 	resp, err := s.explorerCore.CountLikers(context.Background(), req)
@@ -315,15 +671,15 @@ func (s *ExplorerCoreTestSuite) TestCountLikers_CacheInvalidValue_DatabaseSucces
 
 func (s *ExplorerCoreTestSuite) TestCountLikers_CacheMiss_DatabaseSuccess() {
 	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
 	// Cache miss (empty string)
 	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("", errors.New("cache miss")).Once()
 
-	s.mockExplorerRepo.EXPECT().CountLikes(mock.Anything, req.RecipientUserId).
+	s.mockExplorerRepo.EXPECT().CountLikers(mock.Anything, req.RecipientUserId).
 		Return(int64(25), nil).Once()
 
-	s.mockCache.EXPECT().Set(mock.Anything, cacheKey, "25", utils.LikersCountTTL).
+	s.mockCache.EXPECT().SeedLikersCount(mock.Anything, req.RecipientUserId, int64(25), utils.LikersCountTTL).
 		Return(nil).Maybe()
 
 	resp, err := s.explorerCore.CountLikers(context.Background(), req)
@@ -335,14 +691,14 @@ func (s *ExplorerCoreTestSuite) TestCountLikers_CacheMiss_DatabaseSuccess() {
 
 func (s *ExplorerCoreTestSuite) TestCountLikers_CacheError_DatabaseSuccess() {
 	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
 	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("", errors.New("cache unavailable")).Once()
 
-	s.mockExplorerRepo.EXPECT().CountLikes(mock.Anything, req.RecipientUserId).
+	s.mockExplorerRepo.EXPECT().CountLikers(mock.Anything, req.RecipientUserId).
 		Return(int64(35), nil).Once()
 
-	s.mockCache.EXPECT().Set(mock.Anything, cacheKey, "35", utils.LikersCountTTL).
+	s.mockCache.EXPECT().SeedLikersCount(mock.Anything, req.RecipientUserId, int64(35), utils.LikersCountTTL).
 		Return(nil).Maybe()
 
 	resp, err := s.explorerCore.CountLikers(context.Background(), req)
@@ -354,11 +710,11 @@ func (s *ExplorerCoreTestSuite) TestCountLikers_CacheError_DatabaseSuccess() {
 
 func (s *ExplorerCoreTestSuite) TestCountLikers_DatabaseError() {
 	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
 	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("", errors.New("cache miss")).Once()
 
-	s.mockExplorerRepo.EXPECT().CountLikes(mock.Anything, req.RecipientUserId).
+	s.mockExplorerRepo.EXPECT().CountLikers(mock.Anything, req.RecipientUserId).
 		Return(int64(0), errors.New("database connection failed")).Once()
 
 	resp, err := s.explorerCore.CountLikers(context.Background(), req)
@@ -382,17 +738,11 @@ func (s *ExplorerCoreTestSuite) TestCreateDecision_LikedRecipient_MutualLike() {
 		LikedRecipient:  req.LikedRecipient,
 	}
 
-	mutualParams := explorerdb.HasMutualLikeParams{
-		ActorUserID:     req.ActorUserId,
-		RecipientUserID: req.RecipientUserId,
-	}
-
-	s.mockExplorerRepo.EXPECT().CreateDecision(mock.Anything, createParams).Return(nil).Once()
-
-	// Return pointer to true for mutual like
-	mutualLike := true
-	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, mutualParams).
-		Return(&mutualLike, nil).Once()
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, createParams, mock.Anything).
+		Return(models.RecordedDecision{MutualLikes: true, LikesDelta: 1, Timestamp: 1700000000}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.expectIndexedLike(req.ActorUserId, req.RecipientUserId, 1700000000)
+	s.mockCache.EXPECT().IncrLikersCount(mock.Anything, req.RecipientUserId, int64(1)).Return(nil).Once()
 
 	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
 
@@ -414,30 +764,37 @@ func (s *ExplorerCoreTestSuite) TestCreateDecision_LikedRecipient_NoMutualLike()
 		LikedRecipient:  req.LikedRecipient,
 	}
 
-	mutualParams := explorerdb.HasMutualLikeParams{
-		ActorUserID:     req.ActorUserId,
-		RecipientUserID: req.RecipientUserId,
-	}
-
-	s.mockExplorerRepo.EXPECT().CreateDecision(mock.Anything, createParams).Return(nil).Once()
-
-	// Return pointer to false for no mutual like
-	mutualLike := false
-	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, mutualParams).
-		Return(&mutualLike, nil).Once()
+	// Watchers of the recipient are told about the new like through the outbox
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, createParams, mock.Anything).
+		Run(func(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) {
+			s.Require().NotNil(events.NewLike)
+			s.Equal(utils.NewLikesTopic(context.Background(), req.RecipientUserId), events.NewLike.Topic)
+			var event pb.WatchNewLikesEvent
+			s.Require().NoError(proto.Unmarshal(events.NewLike.Payload, &event))
+			s.Equal(req.ActorUserId, event.ActorId)
+			s.NotEmpty(event.EventId)
+			s.Empty(events.Matched) // No webhook endpoints are configured
+		}).Return(models.RecordedDecision{LikesDelta: 1, Timestamp: 1700000000}, nil).Once()
+
+	// The recipient's new likers are dropped and their likers and count are written through,
+	// so the new like shows up immediately
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.expectIndexedLike(req.ActorUserId, req.RecipientUserId, 1700000000)
+	s.mockCache.EXPECT().IncrLikersCount(mock.Anything, req.RecipientUserId, int64(1)).Return(nil).Once()
 
 	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
 
 	s.NoError(err)
 	s.NotNil(resp)
 	s.False(resp.MutualLikes)
+	s.mockPubSub.AssertNotCalled(s.T(), "Publish")
 }
 
-func (s *ExplorerCoreTestSuite) TestCreateDecision_LikedRecipient_MutualLikeNil() {
+func (s *ExplorerCoreTestSuite) TestCreateDecision_NotLikedRecipient() {
 	req := &pb.PutDecisionRequest{
 		ActorUserId:     "actor123",
 		RecipientUserId: "recipient456",
-		LikedRecipient:  true,
+		LikedRecipient:  false, // Not liked
 	}
 
 	createParams := explorerdb.CreateDecisionParams{
@@ -446,49 +803,40 @@ func (s *ExplorerCoreTestSuite) TestCreateDecision_LikedRecipient_MutualLikeNil(
 		LikedRecipient:  req.LikedRecipient,
 	}
 
-	mutualParams := explorerdb.HasMutualLikeParams{
-		ActorUserID:     req.ActorUserId,
-		RecipientUserID: req.RecipientUserId,
-	}
-
-	s.mockExplorerRepo.EXPECT().CreateDecision(mock.Anything, createParams).Return(nil).Once()
-
-	// Return nil for mutual like (no result)
-	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, mutualParams).
-		Return(nil, nil).Once()
+	// Without webhook endpoints a pass emits no events
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, createParams, models.DecisionEvents{}).
+		Return(models.RecordedDecision{}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().RemoveLike(mock.Anything, req.ActorUserId, req.RecipientUserId).Return(nil).Once()
 
 	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
 
 	s.NoError(err)
 	s.NotNil(resp)
-	s.False(resp.MutualLikes) // Should be false when nil
+	s.False(resp.MutualLikes)
 }
 
-func (s *ExplorerCoreTestSuite) TestCreateDecision_NotLikedRecipient() {
+func (s *ExplorerCoreTestSuite) TestCreateDecision_NotLikedRecipient_RemovesMatch() {
 	req := &pb.PutDecisionRequest{
 		ActorUserId:     "actor123",
 		RecipientUserId: "recipient456",
-		LikedRecipient:  false, // Not liked
-	}
-
-	createParams := explorerdb.CreateDecisionParams{
-		ActorUserID:     req.ActorUserId,
-		RecipientUserID: req.RecipientUserId,
-		LikedRecipient:  req.LikedRecipient,
+		LikedRecipient:  false,
 	}
 
-	s.mockExplorerRepo.EXPECT().CreateDecision(mock.Anything, createParams).Return(nil).Once()
+	// The pass withdraws an earlier like, so the recipient's count goes down, and ends the match
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, mock.Anything, mock.Anything).
+		Return(models.RecordedDecision{LikesDelta: -1, MatchEnded: true}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().RemoveLike(mock.Anything, req.ActorUserId, req.RecipientUserId).Return(nil).Once()
+	s.mockCache.EXPECT().IncrLikersCount(mock.Anything, req.RecipientUserId, int64(-1)).Return(nil).Once()
 
-	// Should NOT call HasMutualLike when not liked
 	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
 
 	s.NoError(err)
-	s.NotNil(resp)
 	s.False(resp.MutualLikes)
-	s.mockExplorerRepo.AssertNotCalled(s.T(), "HasMutualLike")
 }
 
-func (s *ExplorerCoreTestSuite) TestCreateDecision_CreateDecisionError() {
+func (s *ExplorerCoreTestSuite) TestCreateDecision_RecordDecisionError() {
 	req := &pb.PutDecisionRequest{
 		ActorUserId:     "actor123",
 		RecipientUserId: "recipient456",
@@ -501,8 +849,8 @@ func (s *ExplorerCoreTestSuite) TestCreateDecision_CreateDecisionError() {
 		LikedRecipient:  req.LikedRecipient,
 	}
 
-	s.mockExplorerRepo.EXPECT().CreateDecision(mock.Anything, createParams).
-		Return(errors.New("database constraint violation")).Once()
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, createParams, mock.Anything).
+		Return(models.RecordedDecision{}, errors.New("database constraint violation")).Once()
 
 	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
 
@@ -510,80 +858,884 @@ func (s *ExplorerCoreTestSuite) TestCreateDecision_CreateDecisionError() {
 	s.Error(err)
 	s.Equal(codes.Internal, status.Code(err))
 	s.Contains(err.Error(), "failed to create decision")
-	s.mockExplorerRepo.AssertNotCalled(s.T(), "HasMutualLike")
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "CreateMatch")
 }
 
-func (s *ExplorerCoreTestSuite) TestCreateDecision_HasMutualLikeError() {
-	req := &pb.PutDecisionRequest{
-		ActorUserId:     "actor123",
-		RecipientUserId: "recipient456",
-		LikedRecipient:  true,
-	}
+func (s *ExplorerCoreTestSuite) TestCountLikers_ZeroCountFromDatabase() {
+	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
+	cacheKey := utils.LikersCountKey(context.Background(), req.RecipientUserId)
 
-	createParams := explorerdb.CreateDecisionParams{
-		ActorUserID:     req.ActorUserId,
-		RecipientUserID: req.RecipientUserId,
-		LikedRecipient:  req.LikedRecipient,
-	}
+	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("", errors.New("cache miss")).Once()
+
+	s.mockExplorerRepo.EXPECT().CountLikers(mock.Anything, req.RecipientUserId).
+		Return(int64(0), nil).Once()
+
+	// A zero count is cached only briefly
+	s.mockCache.EXPECT().SeedLikersCount(mock.Anything, req.RecipientUserId, int64(0), utils.EmptyResultTTL).
+		Return(nil).Maybe()
+
+	resp, err := s.explorerCore.CountLikers(context.Background(), req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.Equal(uint64(0), resp.Count)
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikedRecipients_CacheHit() {
+	req := &pb.ListLikedByYouRequest{
+		ActorUserId:     "testactor",
+		PaginationToken: utils.ToPointer("likedbytoken123"),
+	}
+	s.expectCacheGeneration(req.ActorUserId, "")
+	cacheKey := utils.LikedByKey(context.Background(), req.ActorUserId, "", req.GetPaginationToken())
+
+	cachedRecipients := []*pb.ListLikedByYouResponse_Recipient{
+		{RecipientId: "recipient1", UnixTimestamp: 500},
+	}
+
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedByYouResponse{}).
+		Run(func(ctx context.Context, key string, out interface{}) {
+			obj := out.(*pb.ListLikedByYouResponse)
+			obj.Recipients = cachedRecipients
+		}).Return(true, nil).Once()
+
+	resp, err := s.explorerCore.ListLikedRecipients(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(cachedRecipients, resp.Recipients)
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "GetLikedRecipients")
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikedRecipients_CacheMiss_DatabaseSuccess() {
+	req := &pb.ListLikedByYouRequest{
+		ActorUserId:     "testactor",
+		PaginationToken: nil,
+	}
+	s.expectCacheGeneration(req.ActorUserId, "")
+	cacheKey := utils.LikedByKey(context.Background(), req.ActorUserId, "", req.GetPaginationToken())
+
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedByYouResponse{}).
+		Return(false, nil).Once()
+
+	recipients := []models.Recipient{
+		{RecipientID: "recipient1", Timestamp: 500},
+		{RecipientID: "recipient2", Timestamp: 400},
+	}
+	nextToken := "likedByNextToken"
+
+	s.mockExplorerRepo.EXPECT().GetLikedRecipients(mock.Anything, req.ActorUserId, models.PageRequest{Token: req.GetPaginationToken()}).
+		Return(recipients, nextToken, nil).Once()
+
+	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.LikedByTTL).
+		Return(nil).Maybe()
+
+	resp, err := s.explorerCore.ListLikedRecipients(context.Background(), req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.Len(resp.Recipients, 2)
+	s.Equal("recipient1", resp.Recipients[0].RecipientId)
+	s.Equal(uint64(500), resp.Recipients[0].UnixTimestamp)
+	s.Equal("recipient2", resp.Recipients[1].RecipientId)
+	s.Equal(nextToken, *resp.NextPaginationToken)
+}
+
+func (s *ExplorerCoreTestSuite) TestListLikedRecipients_DatabaseError() {
+	req := &pb.ListLikedByYouRequest{
+		ActorUserId: "testactor",
+	}
+	s.expectCacheGeneration(req.ActorUserId, "")
+	cacheKey := utils.LikedByKey(context.Background(), req.ActorUserId, "", req.GetPaginationToken())
+
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedByYouResponse{}).
+		Return(false, nil).Once()
+
+	s.mockExplorerRepo.EXPECT().GetLikedRecipients(mock.Anything, req.ActorUserId, models.PageRequest{Token: req.GetPaginationToken()}).
+		Return(nil, "", errors.New("database timeout")).Once()
 
-	mutualParams := explorerdb.HasMutualLikeParams{
+	resp, err := s.explorerCore.ListLikedRecipients(context.Background(), req)
+
+	s.Nil(resp)
+	s.Error(err)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get liked recipients")
+}
+
+func (s *ExplorerCoreTestSuite) TestGetDecision_Found() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	createdAt := time.Unix(1640995200, 0)
+	updatedAt := time.Unix(1640995300, 0)
+
+	s.mockExplorerRepo.EXPECT().GetDecision(mock.Anything, explorerdb.GetDecisionParams{
+		ActorUserID:     req.ActorUserId,
+		RecipientUserID: req.RecipientUserId,
+	}).Return(explorerdb.Decision{
 		ActorUserID:     req.ActorUserId,
 		RecipientUserID: req.RecipientUserId,
+		LikedRecipient:  true,
+		CreatedAt:       pgtype.Timestamptz{Time: createdAt, Valid: true},
+		UpdatedAt:       pgtype.Timestamptz{Time: updatedAt, Valid: true},
+	}, nil).Once()
+
+	resp, err := s.explorerCore.GetDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.True(resp.LikedRecipient)
+	s.Equal(uint64(1640995200), resp.CreatedAtUnix)
+	s.Equal(uint64(1640995300), resp.UpdatedAtUnix)
+}
+
+func (s *ExplorerCoreTestSuite) TestGetDecision_NotFound() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
 	}
 
-	s.mockExplorerRepo.EXPECT().CreateDecision(mock.Anything, createParams).Return(nil).Once()
+	s.mockExplorerRepo.EXPECT().GetDecision(mock.Anything, mock.Anything).
+		Return(explorerdb.Decision{}, pgx.ErrNoRows).Once()
 
-	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, mutualParams).
-		Return(nil, errors.New("database timeout")).Once()
+	resp, err := s.explorerCore.GetDecision(context.Background(), req)
 
-	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
+	s.Nil(resp)
+	s.Error(err)
+	s.Equal(codes.NotFound, status.Code(err))
+	s.Contains(err.Error(), "decision not found")
+}
+
+func (s *ExplorerCoreTestSuite) TestGetDecision_DatabaseError() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockExplorerRepo.EXPECT().GetDecision(mock.Anything, mock.Anything).
+		Return(explorerdb.Decision{}, errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.GetDecision(context.Background(), req)
 
 	s.Nil(resp)
 	s.Error(err)
 	s.Equal(codes.Internal, status.Code(err))
-	s.Contains(err.Error(), "failed to check mutual like")
+	s.Contains(err.Error(), "failed to get decision")
 }
 
-func (s *ExplorerCoreTestSuite) TestListLikers_EmptyResult() {
-	req := &pb.ListLikedYouRequest{
+func (s *ExplorerCoreTestSuite) TestDeleteDecision_PreviouslyMutualLike() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockExplorerRepo.EXPECT().RemoveDecision(mock.Anything, explorerdb.DeleteDecisionParams{
+		ActorUserID:     req.ActorUserId,
+		RecipientUserID: req.RecipientUserId,
+	}).Return(models.RemovedDecision{Deleted: true, WasMutualLike: true, MatchEnded: true}, nil).Once()
+
+	// The actor's own listings must be invalidated too, since the recipient reappears among
+	// their new likers and leaves their matches once the actor's decision is gone
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().RemoveLike(mock.Anything, req.ActorUserId, req.RecipientUserId).Return(nil).Once()
+	// Whether the deleted like was counted is unknown here, so the count is seeded again on its next read
+	s.mockCache.EXPECT().Del(mock.Anything, utils.LikersCountKey(context.Background(), req.RecipientUserId)).Return(nil).Once()
+
+	resp, err := s.explorerCore.DeleteDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.True(resp.WasMutualLike)
+}
+
+func (s *ExplorerCoreTestSuite) TestDeleteDecision_NoMutualLike() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockExplorerRepo.EXPECT().RemoveDecision(mock.Anything, mock.Anything).Return(models.RemovedDecision{Deleted: true}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, mock.Anything, utils.CacheGenerationTTL).
+		Return(nil).Once()
+	s.mockCache.EXPECT().RemoveLike(mock.Anything, req.ActorUserId, req.RecipientUserId).Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, utils.LikersCountKey(context.Background(), req.RecipientUserId)).Return(nil).Once()
+
+	resp, err := s.explorerCore.DeleteDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.False(resp.WasMutualLike)
+}
+
+func (s *ExplorerCoreTestSuite) TestDeleteDecision_CacheErrorIgnored() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockExplorerRepo.EXPECT().RemoveDecision(mock.Anything, mock.Anything).Return(models.RemovedDecision{Deleted: true}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, mock.Anything, utils.CacheGenerationTTL).
+		Return(errors.New("cache unavailable")).Once()
+	s.mockCache.EXPECT().RemoveLike(mock.Anything, req.ActorUserId, req.RecipientUserId).
+		Return(errors.New("cache unavailable")).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, utils.LikersCountKey(context.Background(), req.RecipientUserId)).
+		Return(errors.New("cache unavailable")).Once()
+
+	resp, err := s.explorerCore.DeleteDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+	s.False(resp.WasMutualLike)
+}
+
+func (s *ExplorerCoreTestSuite) TestDeleteDecision_NotFound() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockExplorerRepo.EXPECT().RemoveDecision(mock.Anything, mock.Anything).Return(models.RemovedDecision{}, nil).Once()
+
+	resp, err := s.explorerCore.DeleteDecision(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+	s.mockCache.AssertNotCalled(s.T(), "Del")
+}
+
+func (s *ExplorerCoreTestSuite) TestDeleteDecision_DatabaseError() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockExplorerRepo.EXPECT().RemoveDecision(mock.Anything, mock.Anything).
+		Return(models.RemovedDecision{}, errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.DeleteDecision(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to delete decision")
+	s.mockCache.AssertNotCalled(s.T(), "Del")
+}
+
+func (s *ExplorerCoreTestSuite) TestBatchCreateDecisions_Success() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+			{ActorUserId: "actor1", RecipientUserId: "recipient2", LikedRecipient: false},
+		},
+	}
+
+	params := []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", LikedRecipient: false},
+	}
+
+	// Only the like emits events
+	events := mock.MatchedBy(func(events []models.DecisionEvents) bool {
+		return len(events) == 2 &&
+			events[0].NewLike != nil && events[0].NewLike.Topic == utils.NewLikesTopic(context.Background(), "recipient1") &&
+			events[1].NewLike == nil
+	})
+
+	s.mockExplorerRepo.EXPECT().CreateDecisions(mock.Anything, params, events).Return([]models.DecisionResult{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", MutualLikes: true},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", MutualLikes: false},
+	}, nil).Once()
+
+	// Every user involved gets their likers index rebuilt on the next read
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, "actor1", "recipient1", "actor1", "recipient2").Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, utils.LikersCountKey(context.Background(), "recipient1"), utils.LikersCountKey(context.Background(), "recipient2")).Return(nil).Once()
+
+	resp, err := s.explorerCore.BatchCreateDecisions(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Results, 2)
+	s.Equal("recipient1", resp.Results[0].RecipientUserId)
+	s.True(resp.Results[0].MutualLikes)
+	s.Equal("recipient2", resp.Results[1].RecipientUserId)
+	s.False(resp.Results[1].MutualLikes)
+}
+
+func (s *ExplorerCoreTestSuite) TestBatchCreateDecisions_DatabaseError() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+		},
+	}
+
+	s.mockExplorerRepo.EXPECT().CreateDecisions(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.BatchCreateDecisions(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to create decisions")
+}
+
+func (s *ExplorerCoreTestSuite) TestBatchCreateDecisions_RejectedDecision() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+			{ActorUserId: "actor1", RecipientUserId: "recipient2", LikedRecipient: true},
+		},
+	}
+
+	s.mockExplorerRepo.EXPECT().CreateDecisions(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("failed to create decision 1: %w", &models.DecisionError{
+			Index: 1,
+			Err:   &pgconn.PgError{Code: "22001", Message: "value too long for type character varying(255)"},
+		})).Once()
+
+	resp, err := s.explorerCore.BatchCreateDecisions(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "decisions[1]")
+}
+
+func (s *ExplorerCoreTestSuite) TestBatchCreateDecisions_BatchTooLarge() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+		},
+	}
+
+	s.mockExplorerRepo.EXPECT().CreateDecisions(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("failed to commit transaction: %w", models.ErrBatchTooLarge)).Once()
+
+	resp, err := s.explorerCore.BatchCreateDecisions(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "send a smaller batch")
+}
+
+func (s *ExplorerCoreTestSuite) TestIngestDecisions_Success() {
+	decisions := []*pb.PutDecisionRequest{
+		{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+		{ActorUserId: "actor1", RecipientUserId: "recipient2", LikedRecipient: false},
+	}
+
+	params := []explorerdb.UpsertDecisionsParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", LikedRecipient: false},
+	}
+
+	s.mockExplorerRepo.EXPECT().IngestDecisions(mock.Anything, params).
+		Return(models.IngestSummary{Created: 1, Updated: 1, MutualLikes: 1}, nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything, utils.LikersCountKey(context.Background(), "recipient1"), utils.LikersCountKey(context.Background(), "recipient2")).Return(nil).Once()
+
+	resp, err := s.explorerCore.IngestDecisions(context.Background(), decisions)
+
+	s.NoError(err)
+	s.Equal(uint64(1), resp.Created)
+	s.Equal(uint64(1), resp.Updated)
+	s.Equal(uint64(1), resp.MutualLikes)
+}
+
+func (s *ExplorerCoreTestSuite) TestIngestDecisions_DatabaseError() {
+	decisions := []*pb.PutDecisionRequest{
+		{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+	}
+
+	s.mockExplorerRepo.EXPECT().IngestDecisions(mock.Anything, mock.Anything).
+		Return(models.IngestSummary{}, errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.IngestDecisions(context.Background(), decisions)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to ingest decisions")
+}
+
+func (s *ExplorerCoreTestSuite) TestIngestDecisions_RejectedDecision() {
+	decisions := []*pb.PutDecisionRequest{
+		{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+	}
+
+	s.mockExplorerRepo.EXPECT().IngestDecisions(mock.Anything, mock.Anything).
+		Return(models.IngestSummary{}, fmt.Errorf("failed to upsert decision 0: %w", &models.DecisionError{
+			Err: &pgconn.PgError{Code: "23514"},
+		})).Once()
+
+	resp, err := s.explorerCore.IngestDecisions(context.Background(), decisions)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "decisions[0]")
+}
+
+func (s *ExplorerCoreTestSuite) TestIngestDecisions_FailedDecisionIsInternal() {
+	decisions := []*pb.PutDecisionRequest{
+		{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+	}
+
+	// A row failing because the database did is not the caller's fault
+	s.mockExplorerRepo.EXPECT().IngestDecisions(mock.Anything, mock.Anything).
+		Return(models.IngestSummary{}, fmt.Errorf("failed to upsert decision 0: %w", &models.DecisionError{
+			Err: &pgconn.PgError{Code: "57014"},
+		})).Once()
+
+	resp, err := s.explorerCore.IngestDecisions(context.Background(), decisions)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *ExplorerCoreTestSuite) TestWatchNewLikers_ForwardsEvents() {
+	req := &pb.WatchNewLikesRequest{RecipientUserId: "recipient456"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payload, err := proto.Marshal(&pb.WatchNewLikesEvent{ActorId: "actor123", UnixTimestamp: 1640995200})
+	s.Require().NoError(err)
+
+	messages := make(chan []byte, 2)
+	messages <- []byte("not a proto event")
+	messages <- payload
+	close(messages)
+	s.mockPubSub.EXPECT().Subscribe(mock.Anything, utils.NewLikesTopic(context.Background(), req.RecipientUserId)).
+		Return((<-chan []byte)(messages), nil).Once()
+
+	var received []*pb.WatchNewLikesEvent
+	err = s.explorerCore.WatchNewLikers(ctx, req, func(event *pb.WatchNewLikesEvent) error {
+		received = append(received, event)
+		return nil
+	})
+
+	s.NoError(err)
+	s.Require().Len(received, 1)
+	s.Equal("actor123", received[0].ActorId)
+	s.Equal(uint64(1640995200), received[0].UnixTimestamp)
+}
+
+func (s *ExplorerCoreTestSuite) TestHandleDecisionChange_NewLike() {
+	ctx := context.Background()
+	change := models.DecisionChange{ActorUserID: "actor123", RecipientUserID: "recipient456", LikedRecipient: true, CreatedAt: 1640995200}
+
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf("actor123", "recipient456"), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockPubSub.EXPECT().Publish(mock.Anything, utils.NewLikesTopic(ctx, "recipient456"), mock.Anything).
+		Run(func(ctx context.Context, topic string, payload []byte) {
+			var event pb.WatchNewLikesEvent
+			s.Require().NoError(proto.Unmarshal(payload, &event))
+			s.Equal("actor123", event.ActorId)
+			s.Equal(uint64(1640995200), event.UnixTimestamp)
+			s.NotEmpty(event.EventId)
+		}).
+		Return(nil).Once()
+
+	s.NoError(s.explorerCore.HandleDecisionChange(ctx, change))
+}
+
+func (s *ExplorerCoreTestSuite) TestHandleDecisionChange_PassPublishesNothing() {
+	change := models.DecisionChange{ActorUserID: "actor123", RecipientUserID: "recipient456"}
+
+	s.mockCache.EXPECT().SetMany(mock.Anything, mock.Anything, utils.CacheGenerationTTL).Return(errors.New("cache down")).Once()
+
+	s.NoError(s.explorerCore.HandleDecisionChange(context.Background(), change))
+}
+
+func (s *ExplorerCoreTestSuite) TestWatchNewLikers_SendError() {
+	req := &pb.WatchNewLikesRequest{RecipientUserId: "recipient456"}
+
+	payload, err := proto.Marshal(&pb.WatchNewLikesEvent{ActorId: "actor123"})
+	s.Require().NoError(err)
+
+	messages := make(chan []byte, 1)
+	messages <- payload
+	s.mockPubSub.EXPECT().Subscribe(mock.Anything, mock.Anything).
+		Return((<-chan []byte)(messages), nil).Once()
+
+	sendErr := errors.New("client gone")
+	err = s.explorerCore.WatchNewLikers(context.Background(), req, func(*pb.WatchNewLikesEvent) error {
+		return sendErr
+	})
+
+	s.ErrorIs(err, sendErr)
+}
+
+func (s *ExplorerCoreTestSuite) TestWatchNewLikers_SubscribeError() {
+	req := &pb.WatchNewLikesRequest{RecipientUserId: "recipient456"}
+
+	s.mockPubSub.EXPECT().Subscribe(mock.Anything, mock.Anything).
+		Return(nil, errors.New("broker unavailable")).Once()
+
+	err := s.explorerCore.WatchNewLikers(context.Background(), req, func(*pb.WatchNewLikesEvent) error {
+		return nil
+	})
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to watch new likes")
+}
+
+func (s *ExplorerCoreTestSuite) TestListPassers_Success() {
+	req := &pb.ListPassedYouRequest{
 		RecipientUserId: "testuser",
-		PaginationToken: nil,
+		PaginationToken: utils.ToPointer("token"),
+		PageSize:        utils.ToPointer(uint32(10)),
 	}
-	cacheKey := utils.LikersKey(req.RecipientUserId, req.GetPaginationToken())
 
-	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListLikedYouResponse{}).
+	s.mockExplorerRepo.EXPECT().GetPassers(mock.Anything, req.RecipientUserId, models.PageRequest{Token: "token", Size: 10}).
+		Return([]models.Liker{{ActorID: "actor1", Timestamp: 100}}, models.PageTokens{Next: "next"}, nil).Once()
+
+	resp, err := s.explorerCore.ListPassers(context.Background(), req)
+
+	s.NoError(err)
+	s.Require().Len(resp.Passers, 1)
+	s.Equal("actor1", resp.Passers[0].ActorId)
+	s.Equal(uint64(100), resp.Passers[0].UnixTimestamp)
+	s.Equal("next", resp.GetNextPaginationToken())
+	s.mockCache.AssertNotCalled(s.T(), "GetJSON")
+}
+
+func (s *ExplorerCoreTestSuite) TestListPassers_DatabaseError() {
+	req := &pb.ListPassedYouRequest{RecipientUserId: "testuser"}
+
+	s.mockExplorerRepo.EXPECT().GetPassers(mock.Anything, req.RecipientUserId, models.PageRequest{}).
+		Return(nil, models.PageTokens{}, errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.ListPassers(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get passers")
+}
+
+func (s *ExplorerCoreTestSuite) TestCheckMutualLike() {
+	req := &pb.CheckMutualLikeRequest{
+		UserId:      "user123",
+		OtherUserId: "user456",
+	}
+	params := explorerdb.HasMutualLikeParams{
+		ActorUserID:     req.UserId,
+		RecipientUserID: req.OtherUserId,
+	}
+
+	mutualLike := true
+	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, params).Return(&mutualLike, nil).Once()
+
+	resp, err := s.explorerCore.CheckMutualLike(context.Background(), req)
+
+	s.NoError(err)
+	s.True(resp.MutualLike)
+}
+
+func (s *ExplorerCoreTestSuite) TestCheckMutualLike_NilResult() {
+	req := &pb.CheckMutualLikeRequest{
+		UserId:      "user123",
+		OtherUserId: "user456",
+	}
+
+	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	resp, err := s.explorerCore.CheckMutualLike(context.Background(), req)
+
+	s.NoError(err)
+	s.False(resp.MutualLike)
+}
+
+func (s *ExplorerCoreTestSuite) TestCheckMutualLike_DatabaseError() {
+	req := &pb.CheckMutualLikeRequest{
+		UserId:      "user123",
+		OtherUserId: "user456",
+	}
+
+	s.mockExplorerRepo.EXPECT().HasMutualLike(mock.Anything, mock.Anything).
+		Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.CheckMutualLike(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to check mutual like")
+}
+
+func (s *ExplorerCoreTestSuite) TestListMatches_CacheMiss_DatabaseSuccess() {
+	req := &pb.ListMatchesRequest{
+		UserId: "testuser",
+	}
+	s.expectCacheGeneration(req.UserId, "")
+	cacheKey := utils.MatchesKey(context.Background(), req.UserId, "", req.GetPaginationToken())
+
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListMatchesResponse{}).
 		Return(false, nil).Once()
 
-	// Empty likers result
-	s.mockExplorerRepo.EXPECT().GetLikers(mock.Anything, req.RecipientUserId, req.GetPaginationToken()).
-		Return([]models.Liker{}, "", nil).Once()
+	nextToken := "matchesNextToken"
+	s.mockExplorerRepo.EXPECT().GetMatches(mock.Anything, req.UserId, models.PageRequest{}).
+		Return([]models.Match{{UserID: "match1", MatchedAt: 700}}, nextToken, nil).Once()
 
-	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.LikersTTL).
+	s.mockCache.EXPECT().SetJSON(mock.Anything, cacheKey, mock.Anything, utils.MatchesTTL).
 		Return(nil).Maybe()
 
-	resp, err := s.explorerCore.ListLikers(context.Background(), req)
+	resp, err := s.explorerCore.ListMatches(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Matches, 1)
+	s.Equal("match1", resp.Matches[0].UserId)
+	s.Equal(uint64(700), resp.Matches[0].MatchedAtUnix)
+	s.Equal(nextToken, *resp.NextPaginationToken)
+}
+
+func (s *ExplorerCoreTestSuite) TestListMatches_CacheHit() {
+	req := &pb.ListMatchesRequest{
+		UserId: "testuser",
+	}
+	s.expectCacheGeneration(req.UserId, "")
+	cacheKey := utils.MatchesKey(context.Background(), req.UserId, "", req.GetPaginationToken())
+
+	cachedMatches := []*pb.ListMatchesResponse_Match{{UserId: "match1", MatchedAtUnix: 700}}
+	s.mockCache.EXPECT().GetJSON(mock.Anything, cacheKey, &pb.ListMatchesResponse{}).
+		Run(func(ctx context.Context, key string, out interface{}) {
+			out.(*pb.ListMatchesResponse).Matches = cachedMatches
+		}).Return(true, nil).Once()
+
+	resp, err := s.explorerCore.ListMatches(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(cachedMatches, resp.Matches)
+	s.mockExplorerRepo.AssertNotCalled(s.T(), "GetMatches")
+}
+
+func (s *ExplorerCoreTestSuite) TestListMatches_GenerationUnavailable_SkipsCache() {
+	req := &pb.ListMatchesRequest{
+		UserId: "testuser",
+	}
+
+	// Without the current generation, a cached page may be one invalidated since
+	s.mockCache.EXPECT().Get(mock.Anything, utils.CacheGenerationKey(context.Background(), req.UserId)).
+		Return("", errors.New("cache down")).Once()
+	s.mockExplorerRepo.EXPECT().GetMatches(mock.Anything, req.UserId, models.PageRequest{}).
+		Return([]models.Match{{UserID: "match1", MatchedAt: 1640995200}}, "", nil).Once()
+
+	resp, err := s.explorerCore.ListMatches(context.Background(), req)
+
+	s.NoError(err)
+	s.Require().Len(resp.Matches, 1)
+	s.mockCache.AssertNotCalled(s.T(), "GetJSON")
+	s.mockCache.AssertNotCalled(s.T(), "SetJSON")
+}
+
+func (s *ExplorerCoreTestSuite) TestListMatches_DatabaseError() {
+	req := &pb.ListMatchesRequest{
+		UserId: "testuser",
+	}
+
+	s.expectCacheGeneration(req.UserId, "")
+	s.mockCache.EXPECT().GetJSON(mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	s.mockExplorerRepo.EXPECT().GetMatches(mock.Anything, req.UserId, models.PageRequest{}).
+		Return(nil, "", errors.New("database timeout")).Once()
+
+	resp, err := s.explorerCore.ListMatches(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get matches")
+}
+
+func (s *ExplorerCoreTestSuite) expectBlockCacheInvalidation(userID, otherUserID string) {
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(userID, otherUserID), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().Del(mock.Anything,
+		utils.LikersCountKey(context.Background(), userID),
+		utils.LikersCountKey(context.Background(), otherUserID),
+	).Return(nil).Once()
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, userID, otherUserID).Return(nil).Once()
+}
+
+func (s *ExplorerCoreTestSuite) TestBlockUser_RemovesMatch() {
+	req := &pb.BlockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
+
+	s.mockExplorerRepo.EXPECT().CreateBlock(mock.Anything, explorerdb.CreateBlockParams{
+		BlockerUserID: req.BlockerUserId,
+		BlockedUserID: req.BlockedUserId,
+	}).Return(nil).Once()
+	s.mockExplorerRepo.EXPECT().DeleteMatch(mock.Anything, explorerdb.DeleteMatchParams{
+		UserID:        req.BlockerUserId,
+		MatchedUserID: req.BlockedUserId,
+	}).Return(int64(2), nil).Once()
+	s.expectBlockCacheInvalidation(req.BlockerUserId, req.BlockedUserId)
+
+	resp, err := s.explorerCore.BlockUser(context.Background(), req)
 
 	s.NoError(err)
 	s.NotNil(resp)
-	s.Empty(resp.Likers)
-	s.Nil(resp.NextPaginationToken)
 }
 
-func (s *ExplorerCoreTestSuite) TestCountLikers_ZeroCountFromDatabase() {
-	req := &pb.CountLikedYouRequest{RecipientUserId: "testuser"}
-	cacheKey := utils.LikersCountKey(req.RecipientUserId)
+func (s *ExplorerCoreTestSuite) TestBlockUser_RepositoryError() {
+	req := &pb.BlockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
 
-	s.mockCache.EXPECT().Get(mock.Anything, cacheKey).Return("", errors.New("cache miss")).Once()
+	s.mockExplorerRepo.EXPECT().CreateBlock(mock.Anything, mock.Anything).
+		Return(errors.New("database timeout")).Once()
 
-	s.mockExplorerRepo.EXPECT().CountLikes(mock.Anything, req.RecipientUserId).
-		Return(int64(0), nil).Once()
+	resp, err := s.explorerCore.BlockUser(context.Background(), req)
 
-	s.mockCache.EXPECT().Set(mock.Anything, cacheKey, "0", utils.LikersCountTTL).
-		Return(nil).Maybe()
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to block user")
+}
 
-	resp, err := s.explorerCore.CountLikers(context.Background(), req)
+func (s *ExplorerCoreTestSuite) TestUnblockUser_Success() {
+	req := &pb.UnblockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
+
+	s.mockExplorerRepo.EXPECT().DeleteBlock(mock.Anything, explorerdb.DeleteBlockParams{
+		BlockerUserID: req.BlockerUserId,
+		BlockedUserID: req.BlockedUserId,
+	}).Return(int64(1), nil).Once()
+	s.expectBlockCacheInvalidation(req.BlockerUserId, req.BlockedUserId)
+
+	resp, err := s.explorerCore.UnblockUser(context.Background(), req)
 
 	s.NoError(err)
 	s.NotNil(resp)
-	s.Equal(uint64(0), resp.Count)
+}
+
+func (s *ExplorerCoreTestSuite) TestUnblockUser_NotFound() {
+	req := &pb.UnblockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
+
+	s.mockExplorerRepo.EXPECT().DeleteBlock(mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+
+	resp, err := s.explorerCore.UnblockUser(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+	s.mockCache.AssertNotCalled(s.T(), "Del")
+}
+
+func (s *ExplorerCoreTestSuite) TestCreateDecision_MatchWebhookEvents() {
+	endpoints := []string{"https://a.example.com/hooks", "https://b.example.com/hooks"}
+	explorerCore := NewExploreCore(s.mockExplorerRepo, s.mockCache, s.mockPubSub, endpoints, 0, false, s.logger)
+
+	req := &pb.PutDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+		LikedRecipient:  true,
+	}
+
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) {
+			s.Require().Len(events.Matched, 2)
+			s.Equal(utils.WebhookTopic(endpoints[0]), events.Matched[0].Topic)
+			s.Equal(utils.WebhookTopic(endpoints[1]), events.Matched[1].Topic)
+
+			// Every endpoint receives the same event
+			s.Equal(events.Matched[0].Payload, events.Matched[1].Payload)
+			var payload matchWebhook
+			s.Require().NoError(json.Unmarshal(events.Matched[0].Payload, &payload))
+			s.Equal(matchCreatedEvent, payload.Type)
+			s.Equal(req.ActorUserId, payload.UserID)
+			s.Equal(req.RecipientUserId, payload.MatchedUserID)
+			s.NotEmpty(payload.EventID)
+		}).Return(models.RecordedDecision{MutualLikes: true, LikesDelta: 1, Timestamp: 1700000000}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.expectIndexedLike(req.ActorUserId, req.RecipientUserId, 1700000000)
+	s.mockCache.EXPECT().IncrLikersCount(mock.Anything, req.RecipientUserId, int64(1)).Return(nil).Once()
+
+	resp, err := explorerCore.CreateDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.True(resp.MutualLikes)
+}
+
+func (s *ExplorerCoreTestSuite) TestCreateDecision_LikeWithdrawnWebhookEvents() {
+	endpoints := []string{"https://a.example.com/hooks"}
+	explorerCore := NewExploreCore(s.mockExplorerRepo, s.mockCache, s.mockPubSub, endpoints, 0, false, s.logger)
+
+	req := &pb.PutDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+		LikedRecipient:  false,
+	}
+
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) {
+			s.Nil(events.NewLike)
+			s.Empty(events.Matched)
+			s.Require().Len(events.Withdrawn, 1)
+			s.Equal(utils.WebhookTopic(endpoints[0]), events.Withdrawn[0].Topic)
+
+			var payload likeWithdrawnWebhook
+			s.Require().NoError(json.Unmarshal(events.Withdrawn[0].Payload, &payload))
+			s.Equal(likeWithdrawnEvent, payload.Type)
+			s.Equal(req.ActorUserId, payload.ActorUserID)
+			s.Equal(req.RecipientUserId, payload.RecipientUserID)
+			s.NotEmpty(payload.EventID)
+		}).Return(models.RecordedDecision{LikesDelta: -1, LikedBefore: true}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockCache.EXPECT().RemoveLike(mock.Anything, req.ActorUserId, req.RecipientUserId).Return(nil).Once()
+	s.mockCache.EXPECT().IncrLikersCount(mock.Anything, req.RecipientUserId, int64(-1)).Return(nil).Once()
+
+	resp, err := explorerCore.CreateDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.False(resp.MutualLikes)
+}
+
+// expectIndexedLike expects the like of actor on recipient, made at likedAt, to be written
+// through to the likers indexes
+func (s *ExplorerCoreTestSuite) expectIndexedLike(actorUserID, recipientUserID string, likedAt int64) {
+	s.mockExplorerRepo.EXPECT().IsBlocked(mock.Anything, explorerdb.IsBlockedParams{
+		BlockerUserID: recipientUserID,
+		BlockedUserID: actorUserID,
+	}).Return(false, nil).Once()
+	s.mockCache.EXPECT().AddLike(mock.Anything, actorUserID, recipientUserID, likedAt).Return(nil).Once()
+}
+
+func (s *ExplorerCoreTestSuite) TestCreateDecision_BlockedLikeNotIndexed() {
+	req := &pb.PutDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+		LikedRecipient:  true,
+	}
+
+	// A like between blocked users leaves the count alone
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, mock.Anything, mock.Anything).Return(models.RecordedDecision{}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockExplorerRepo.EXPECT().IsBlocked(mock.Anything, explorerdb.IsBlockedParams{
+		BlockerUserID: req.RecipientUserId,
+		BlockedUserID: req.ActorUserId,
+	}).Return(true, nil).Once()
+
+	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.False(resp.MutualLikes)
+	s.mockCache.AssertNotCalled(s.T(), "AddLike")
+	s.mockCache.AssertNotCalled(s.T(), "IncrLikersCount")
+}
+
+func (s *ExplorerCoreTestSuite) TestCreateDecision_BlockCheckErrorDropsIndex() {
+	req := &pb.PutDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+		LikedRecipient:  true,
+	}
+
+	s.mockExplorerRepo.EXPECT().RecordDecision(mock.Anything, mock.Anything, mock.Anything).
+		Return(models.RecordedDecision{LikesDelta: 1}, nil).Once()
+	s.mockCache.EXPECT().SetMany(mock.Anything, generationsOf(req.ActorUserId, req.RecipientUserId), utils.CacheGenerationTTL).Return(nil).Once()
+	s.mockExplorerRepo.EXPECT().IsBlocked(mock.Anything, mock.Anything).Return(false, errors.New("database timeout")).Once()
+	s.mockCache.EXPECT().DelLikersIndex(mock.Anything, req.RecipientUserId).Return(nil).Once()
+	s.mockCache.EXPECT().IncrLikersCount(mock.Anything, req.RecipientUserId, int64(1)).Return(nil).Once()
+
+	resp, err := s.explorerCore.CreateDecision(context.Background(), req)
+
+	s.NoError(err)
+	s.False(resp.MutualLikes)
+	s.mockCache.AssertNotCalled(s.T(), "AddLike")
 }
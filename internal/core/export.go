@@ -0,0 +1,136 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/backend-interview-task/internal/models"
+	pb "github.com/backend-interview-task/proto"
+)
+
+// exportChunkSize is the number of bytes of an export buffered before they are sent as a chunk
+const exportChunkSize = 32 * 1024
+
+// chunkWriter sends what is written to it in chunks of at least exportChunkSize bytes, the last
+// one excepted, which Flush sends
+type chunkWriter struct {
+	buf  []byte
+	send func(*pb.ExportUserDataChunk) error
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= exportChunkSize {
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends what is buffered, if anything
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	chunk := w.buf
+	w.buf = nil
+	return w.send(&pb.ExportUserDataChunk{Data: chunk})
+}
+
+// decisionEncoder writes the decisions of an export document one at a time
+type decisionEncoder interface {
+	Encode(decision models.UserDecision) error
+	// Close completes the document
+	Close() error
+}
+
+// newDecisionEncoder starts the export document of userID in format on w
+func newDecisionEncoder(w io.Writer, format pb.ExportFormat, userID string) (decisionEncoder, error) {
+	switch format {
+	case pb.ExportFormat_JSON:
+		return newJSONDecisionEncoder(w, userID)
+	case pb.ExportFormat_CSV:
+		return newCSVDecisionEncoder(w)
+	default:
+		return nil, fmt.Errorf("unknown export format %v", format)
+	}
+}
+
+// exportedDecision is a decision as a JSON export names its fields
+type exportedDecision struct {
+	ActorUserID     string `json:"actor_user_id"`
+	RecipientUserID string `json:"recipient_user_id"`
+	LikedRecipient  bool   `json:"liked_recipient"`
+	UnixTimestamp   int64  `json:"unix_timestamp"`
+}
+
+// jsonDecisionEncoder writes a single JSON object holding the user's ID and their decisions
+type jsonDecisionEncoder struct {
+	w       io.Writer
+	encoded bool
+}
+
+func newJSONDecisionEncoder(w io.Writer, userID string) (*jsonDecisionEncoder, error) {
+	id, err := json.Marshal(userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, `{"user_id":%s,"decisions":[`, id); err != nil {
+		return nil, err
+	}
+	return &jsonDecisionEncoder{w: w}, nil
+}
+
+func (e *jsonDecisionEncoder) Encode(decision models.UserDecision) error {
+	data, err := json.Marshal(exportedDecision{
+		ActorUserID:     decision.ActorUserID,
+		RecipientUserID: decision.RecipientUserID,
+		LikedRecipient:  decision.LikedRecipient,
+		UnixTimestamp:   decision.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+	if e.encoded {
+		data = append([]byte{','}, data...)
+	}
+	e.encoded = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonDecisionEncoder) Close() error {
+	_, err := io.WriteString(e.w, "]}\n")
+	return err
+}
+
+// csvDecisionEncoder writes a header line, then a line per decision
+type csvDecisionEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVDecisionEncoder(w io.Writer) (*csvDecisionEncoder, error) {
+	e := &csvDecisionEncoder{w: csv.NewWriter(w)}
+	if err := e.w.Write([]string{"actor_user_id", "recipient_user_id", "liked_recipient", "unix_timestamp"}); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *csvDecisionEncoder) Encode(decision models.UserDecision) error {
+	return e.w.Write([]string{
+		decision.ActorUserID,
+		decision.RecipientUserID,
+		strconv.FormatBool(decision.LikedRecipient),
+		strconv.FormatInt(decision.Timestamp, 10),
+	})
+}
+
+func (e *csvDecisionEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
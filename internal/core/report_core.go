@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/repository"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+type ReportCore interface {
+	ReportUser(ctx context.Context, req *pb.ReportUserRequest) (*pb.ReportUserResponse, error)
+}
+
+// reportCore implements the business logic for user reports
+type reportCore struct {
+	repo   repository.ReportRepository
+	logger *zap.Logger
+}
+
+// NewReportCore creates a new ReportCore to handle user reports
+func NewReportCore(repo repository.ReportRepository, logger *zap.Logger) ReportCore {
+	return &reportCore{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ReportUser stores a report along with the decisions the two users had made on each other at the time
+func (s *reportCore) ReportUser(ctx context.Context, req *pb.ReportUserRequest) (*pb.ReportUserResponse, error) {
+	report, err := s.repo.CreateReport(ctx, explorerdb.CreateReportParams{
+		ReporterUserID: req.ReporterUserId,
+		ReportedUserID: req.ReportedUserId,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to create report", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to report user")
+	}
+
+	utils.Logger(ctx, s.logger).Info("User reported",
+		zap.Int64("report_id", report.ID),
+		zap.String("reporter_user_id", report.ReporterUserID),
+		zap.String("reported_user_id", report.ReportedUserID),
+	)
+
+	return &pb.ReportUserResponse{ReportId: report.ID}, nil
+}
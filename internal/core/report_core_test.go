@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	repomock "github.com/backend-interview-task/mocks/repository"
+	pb "github.com/backend-interview-task/proto"
+)
+
+type ReportCoreTestSuite struct {
+	suite.Suite
+	mockReportRepo *repomock.ReportRepository
+	reportCore     ReportCore
+}
+
+func TestReportCoreTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportCoreTestSuite))
+}
+
+func (s *ReportCoreTestSuite) SetupTest() {
+	s.mockReportRepo = new(repomock.ReportRepository)
+	s.reportCore = NewReportCore(s.mockReportRepo, zap.NewNop())
+}
+
+func (s *ReportCoreTestSuite) TearDownTest() {
+	s.mockReportRepo.AssertExpectations(s.T())
+}
+
+func (s *ReportCoreTestSuite) TestReportUser_Success() {
+	req := &pb.ReportUserRequest{
+		ReporterUserId: "user123",
+		ReportedUserId: "user456",
+		Reason:         "spam",
+	}
+
+	liked := true
+	s.mockReportRepo.EXPECT().CreateReport(mock.Anything, explorerdb.CreateReportParams{
+		ReporterUserID: req.ReporterUserId,
+		ReportedUserID: req.ReportedUserId,
+		Reason:         req.Reason,
+	}).Return(explorerdb.Report{
+		ID:             42,
+		ReporterUserID: req.ReporterUserId,
+		ReportedUserID: req.ReportedUserId,
+		Reason:         req.Reason,
+		ReporterLiked:  &liked,
+	}, nil).Once()
+
+	resp, err := s.reportCore.ReportUser(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(int64(42), resp.ReportId)
+}
+
+func (s *ReportCoreTestSuite) TestReportUser_RepositoryError() {
+	req := &pb.ReportUserRequest{
+		ReporterUserId: "user123",
+		ReportedUserId: "user456",
+		Reason:         "spam",
+	}
+
+	s.mockReportRepo.EXPECT().CreateReport(mock.Anything, mock.Anything).
+		Return(explorerdb.Report{}, errors.New("database timeout")).Once()
+
+	resp, err := s.reportCore.ReportUser(context.Background(), req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to report user")
+}
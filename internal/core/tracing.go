@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/backend-interview-task/internal/models"
+	pb "github.com/backend-interview-task/proto"
+)
+
+// coreTracerName is the instrumentation scope of the spans of core operations
+const coreTracerName = "github.com/backend-interview-task/internal/core"
+
+// endSpan marks span failed when *err is set and ends it. It is deferred with a pointer to the
+// operation's error result.
+func endSpan(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// tracedExplorerCore records a span for every core operation, parenting the spans of the cache
+// commands and SQL queries it runs, so that a slow call can be traced down to its queries
+type tracedExplorerCore struct {
+	ExplorerCore
+	tracer trace.Tracer
+}
+
+// NewTracedExplorerCore records the spans of core's operations with the global tracer provider,
+// a no-op unless tracing is enabled
+func NewTracedExplorerCore(core ExplorerCore) ExplorerCore {
+	return &tracedExplorerCore{ExplorerCore: core, tracer: otel.Tracer(coreTracerName)}
+}
+
+func (c *tracedExplorerCore) CreateDecision(ctx context.Context, req *pb.PutDecisionRequest) (_ *pb.PutDecisionResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.CreateDecision")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.CreateDecision(ctx, req)
+}
+
+func (c *tracedExplorerCore) ListLikers(ctx context.Context, req *pb.ListLikedYouRequest) (_ *pb.ListLikedYouResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.ListLikers")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.ListLikers(ctx, req)
+}
+
+func (c *tracedExplorerCore) ListNewLikers(ctx context.Context, req *pb.ListLikedYouRequest) (_ *pb.ListLikedYouResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.ListNewLikers")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.ListNewLikers(ctx, req)
+}
+
+func (c *tracedExplorerCore) CountLikers(ctx context.Context, req *pb.CountLikedYouRequest) (_ *pb.CountLikedYouResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.CountLikers")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.CountLikers(ctx, req)
+}
+
+func (c *tracedExplorerCore) ListLikedRecipients(ctx context.Context, req *pb.ListLikedByYouRequest) (_ *pb.ListLikedByYouResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.ListLikedRecipients")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.ListLikedRecipients(ctx, req)
+}
+
+func (c *tracedExplorerCore) GetDecision(ctx context.Context, req *pb.GetDecisionRequest) (_ *pb.GetDecisionResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.GetDecision")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.GetDecision(ctx, req)
+}
+
+func (c *tracedExplorerCore) DeleteDecision(ctx context.Context, req *pb.DeleteDecisionRequest) (_ *pb.DeleteDecisionResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.DeleteDecision")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.DeleteDecision(ctx, req)
+}
+
+func (c *tracedExplorerCore) BatchCreateDecisions(ctx context.Context, req *pb.BatchPutDecisionsRequest) (_ *pb.BatchPutDecisionsResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.BatchCreateDecisions")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.BatchCreateDecisions(ctx, req)
+}
+
+func (c *tracedExplorerCore) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (_ *pb.ListMatchesResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.ListMatches")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.ListMatches(ctx, req)
+}
+
+func (c *tracedExplorerCore) IngestDecisions(ctx context.Context, decisions []*pb.PutDecisionRequest) (_ *pb.PutDecisionsSummary, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.IngestDecisions")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.IngestDecisions(ctx, decisions)
+}
+
+func (c *tracedExplorerCore) WatchNewLikers(ctx context.Context, req *pb.WatchNewLikesRequest, send func(*pb.WatchNewLikesEvent) error) (err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.WatchNewLikers")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.WatchNewLikers(ctx, req, send)
+}
+
+func (c *tracedExplorerCore) ListPassers(ctx context.Context, req *pb.ListPassedYouRequest) (_ *pb.ListPassedYouResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.ListPassers")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.ListPassers(ctx, req)
+}
+
+func (c *tracedExplorerCore) CheckMutualLike(ctx context.Context, req *pb.CheckMutualLikeRequest) (_ *pb.CheckMutualLikeResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.CheckMutualLike")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.CheckMutualLike(ctx, req)
+}
+
+func (c *tracedExplorerCore) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (_ *pb.BlockUserResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.BlockUser")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.BlockUser(ctx, req)
+}
+
+func (c *tracedExplorerCore) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (_ *pb.UnblockUserResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.UnblockUser")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.UnblockUser(ctx, req)
+}
+
+func (c *tracedExplorerCore) WarmRecipients(ctx context.Context, recipientUserIDs []string) (_ int, err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.WarmRecipients")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.WarmRecipients(ctx, recipientUserIDs)
+}
+
+func (c *tracedExplorerCore) HandleDecisionChange(ctx context.Context, change models.DecisionChange) (err error) {
+	ctx, span := c.tracer.Start(ctx, "ExplorerCore.HandleDecisionChange")
+	defer endSpan(span, &err)
+	return c.ExplorerCore.HandleDecisionChange(ctx, change)
+}
+
+// tracedReportCore records a span for every report operation
+type tracedReportCore struct {
+	ReportCore
+	tracer trace.Tracer
+}
+
+// NewTracedReportCore records the spans of core's operations with the global tracer provider
+func NewTracedReportCore(core ReportCore) ReportCore {
+	return &tracedReportCore{ReportCore: core, tracer: otel.Tracer(coreTracerName)}
+}
+
+func (c *tracedReportCore) ReportUser(ctx context.Context, req *pb.ReportUserRequest) (_ *pb.ReportUserResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "ReportCore.ReportUser")
+	defer endSpan(span, &err)
+	return c.ReportCore.ReportUser(ctx, req)
+}
+
+// tracedAuditCore records a span for every audit trail operation
+type tracedAuditCore struct {
+	AuditCore
+	tracer trace.Tracer
+}
+
+// NewTracedAuditCore records the spans of core's operations with the global tracer provider
+func NewTracedAuditCore(core AuditCore) AuditCore {
+	return &tracedAuditCore{AuditCore: core, tracer: otel.Tracer(coreTracerName)}
+}
+
+func (c *tracedAuditCore) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (_ *pb.ListAuditEventsResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "AuditCore.ListAuditEvents")
+	defer endSpan(span, &err)
+	return c.AuditCore.ListAuditEvents(ctx, req)
+}
+
+// tracedAdminCore records a span for every admin operation
+type tracedAdminCore struct {
+	AdminCore
+	tracer trace.Tracer
+}
+
+// NewTracedAdminCore records the spans of core's operations with the global tracer provider
+func NewTracedAdminCore(core AdminCore) AdminCore {
+	return &tracedAdminCore{AdminCore: core, tracer: otel.Tracer(coreTracerName)}
+}
+
+func (c *tracedAdminCore) ListUserDecisions(ctx context.Context, req *pb.ListUserDecisionsRequest) (_ *pb.ListUserDecisionsResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "AdminCore.ListUserDecisions")
+	defer endSpan(span, &err)
+	return c.AdminCore.ListUserDecisions(ctx, req)
+}
+
+func (c *tracedAdminCore) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest, send func(*pb.ExportUserDataChunk) error) (err error) {
+	ctx, span := c.tracer.Start(ctx, "AdminCore.ExportUserData")
+	defer endSpan(span, &err)
+	return c.AdminCore.ExportUserData(ctx, req, send)
+}
+
+func (c *tracedAdminCore) PurgeUserData(ctx context.Context, req *pb.PurgeUserDataRequest) (_ *pb.PurgeUserDataResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "AdminCore.PurgeUserData")
+	defer endSpan(span, &err)
+	return c.AdminCore.PurgeUserData(ctx, req)
+}
+
+func (c *tracedAdminCore) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (_ *pb.EraseUserDataResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "AdminCore.EraseUserData")
+	defer endSpan(span, &err)
+	return c.AdminCore.EraseUserData(ctx, req)
+}
+
+func (c *tracedAdminCore) InvalidateRecipientCache(ctx context.Context, req *pb.InvalidateRecipientCacheRequest) (_ *pb.InvalidateRecipientCacheResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "AdminCore.InvalidateRecipientCache")
+	defer endSpan(span, &err)
+	return c.AdminCore.InvalidateRecipientCache(ctx, req)
+}
+
+func (c *tracedAdminCore) GetServiceStats(ctx context.Context, req *pb.GetServiceStatsRequest) (_ *pb.GetServiceStatsResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "AdminCore.GetServiceStats")
+	defer endSpan(span, &err)
+	return c.AdminCore.GetServiceStats(ctx, req)
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	coremock "github.com/backend-interview-task/mocks/core"
+	pb "github.com/backend-interview-task/proto"
+)
+
+type TracedExplorerCoreTestSuite struct {
+	suite.Suite
+	mockCore *coremock.ExplorerCore
+	recorder *tracetest.SpanRecorder
+	core     ExplorerCore
+}
+
+func TestTracedExplorerCoreTestSuite(t *testing.T) {
+	suite.Run(t, new(TracedExplorerCoreTestSuite))
+}
+
+func (s *TracedExplorerCoreTestSuite) SetupTest() {
+	s.mockCore = new(coremock.ExplorerCore)
+	s.recorder = tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)).Tracer("test")
+	s.core = &tracedExplorerCore{ExplorerCore: s.mockCore, tracer: tracer}
+}
+
+func (s *TracedExplorerCoreTestSuite) TearDownTest() {
+	s.mockCore.AssertExpectations(s.T())
+}
+
+func (s *TracedExplorerCoreTestSuite) TestParentsInnerSpans() {
+	req := &pb.ListLikedYouRequest{RecipientUserId: "user1"}
+	s.mockCore.EXPECT().ListLikers(mock.Anything, req).
+		RunAndReturn(func(ctx context.Context, _ *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+			s.True(trace.SpanContextFromContext(ctx).IsValid())
+			return &pb.ListLikedYouResponse{}, nil
+		}).Once()
+
+	_, err := s.core.ListLikers(context.Background(), req)
+
+	s.NoError(err)
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("ExplorerCore.ListLikers", spans[0].Name())
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *TracedExplorerCoreTestSuite) TestFailureMarksSpan() {
+	s.mockCore.EXPECT().CountLikers(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	_, err := s.core.CountLikers(context.Background(), &pb.CountLikedYouRequest{RecipientUserId: "user1"})
+
+	s.Error(err)
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Equal("db down", spans[0].Status().Description)
+}
@@ -0,0 +1,73 @@
+package faults
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/backend-interview-task/config"
+)
+
+// Targets the faults are injected into
+const (
+	TargetGRPC     = "grpc"
+	TargetCache    = "cache"
+	TargetDatabase = "database"
+)
+
+// Kinds of faults
+const (
+	FaultLatency = "latency"
+	FaultError   = "error"
+	FaultMiss    = "miss"
+)
+
+// ErrInjected is the error of the calls failed on purpose
+var ErrInjected = errors.New("injected fault")
+
+var injectedFaults = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "explore_injected_faults_total",
+	Help: "Faults injected for chaos testing, by target (grpc, cache or database) and fault (latency, error or miss).",
+}, []string{"target", "fault"})
+
+// Injector draws the faults injected into the calls to a target, at the rates of its config
+type Injector struct {
+	target string
+	cfg    config.FaultConfig
+}
+
+// NewInjector returns an Injector for the calls to target
+func NewInjector(target string, cfg config.FaultConfig) *Injector {
+	return &Injector{target: target, cfg: cfg}
+}
+
+// Inject reports whether fault is injected into the call, at rate, and counts it when it is
+func (i *Injector) Inject(fault string, rate float64) bool {
+	if rate <= 0 || rand.Float64() >= rate {
+		return false
+	}
+	injectedFaults.WithLabelValues(i.target, fault).Inc()
+	return true
+}
+
+// Call holds the call up by the configured latency, at its rate, then reports whether it fails,
+// at the error rate. It returns ctx's error when ctx is done before the latency has passed.
+func (i *Injector) Call(ctx context.Context) error {
+	if i.Inject(FaultLatency, i.cfg.LatencyRate) {
+		timer := time.NewTimer(i.cfg.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if i.Inject(FaultError, i.cfg.ErrorRate) {
+		return ErrInjected
+	}
+	return nil
+}
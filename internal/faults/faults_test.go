@@ -0,0 +1,70 @@
+package faults
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/config"
+)
+
+type InjectorTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestInjectorTestSuite(t *testing.T) {
+	suite.Run(t, new(InjectorTestSuite))
+}
+
+func (s *InjectorTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func (s *InjectorTestSuite) TestNoFaultsAtZeroRates() {
+	injector := NewInjector("test_none", config.FaultConfig{Latency: time.Hour})
+
+	for range 100 {
+		s.NoError(injector.Call(s.ctx))
+	}
+	s.Equal(float64(0), testutil.ToFloat64(injectedFaults.WithLabelValues("test_none", FaultLatency)))
+}
+
+func (s *InjectorTestSuite) TestFailsAtFullErrorRate() {
+	injector := NewInjector("test_error", config.FaultConfig{ErrorRate: 1})
+
+	s.ErrorIs(injector.Call(s.ctx), ErrInjected)
+	s.Equal(float64(1), testutil.ToFloat64(injectedFaults.WithLabelValues("test_error", FaultError)))
+}
+
+func (s *InjectorTestSuite) TestDelaysAtFullLatencyRate() {
+	injector := NewInjector("test_latency", config.FaultConfig{Latency: 20 * time.Millisecond, LatencyRate: 1})
+
+	started := time.Now()
+	s.NoError(injector.Call(s.ctx))
+	s.GreaterOrEqual(time.Since(started), 20*time.Millisecond)
+	s.Equal(float64(1), testutil.ToFloat64(injectedFaults.WithLabelValues("test_latency", FaultLatency)))
+}
+
+func (s *InjectorTestSuite) TestStopsDelayWhenContextIsDone() {
+	injector := NewInjector("test_cancel", config.FaultConfig{Latency: time.Hour, LatencyRate: 1, ErrorRate: 1})
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Millisecond)
+	defer cancel()
+
+	s.ErrorIs(injector.Call(ctx), context.DeadlineExceeded)
+}
+
+func (s *InjectorTestSuite) TestInjectsAtRate() {
+	injector := NewInjector("test_rate", config.FaultConfig{})
+
+	injected := 0
+	for range 10000 {
+		if injector.Inject(FaultMiss, 0.3) {
+			injected++
+		}
+	}
+	s.InDelta(3000, injected, 300)
+}
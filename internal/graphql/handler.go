@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	graphqlgo "github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/providers/auth"
+	"github.com/backend-interview-task/internal/service"
+	"github.com/backend-interview-task/utils"
+)
+
+// request is the standard GraphQL-over-HTTP request body
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL queries and mutations over HTTP POST
+type Handler struct {
+	schema         graphqlgo.Schema
+	tenancy        config.TenancyConfig
+	authenticators auth.Authenticators
+	rateLimiter    *service.RateLimiter
+	logger         *zap.Logger
+}
+
+// NewHandler creates a new Handler executing requests against the schema, each scoped to the
+// tenant named in its tenancy header when the service is multi-tenant. Requests must carry
+// credentials accepted by one of authenticators, unless there are none. Callers over their
+// rate limit are rejected by rateLimiter, unless it is nil.
+func NewHandler(schema graphqlgo.Schema, tenancy config.TenancyConfig, authenticators auth.Authenticators, rateLimiter *service.RateLimiter, logger *zap.Logger) *Handler {
+	return &Handler{
+		schema:         schema,
+		tenancy:        tenancy,
+		authenticators: authenticators,
+		rateLimiter:    rateLimiter,
+		logger:         logger,
+	}
+}
+
+// ServeHTTP executes the GraphQL request and writes the result as JSON
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	requestID := utils.RequestID(r.Header.Get(utils.RequestIDHeader))
+	w.Header().Set(utils.RequestIDHeader, requestID)
+	ctx := utils.WithRequestID(r.Context(), requestID)
+	ctx = utils.WithClientAddress(ctx, r.RemoteAddr)
+	if len(h.authenticators) > 0 {
+		authenticator, credentials, ok := h.authenticators.Lookup(r.Header.Get("Authorization"))
+		if !ok {
+			h.unauthorized(w, "credentials are required")
+			return
+		}
+		caller, err := authenticator.Authenticate(ctx, credentials)
+		if err != nil {
+			utils.Logger(ctx, h.logger).Debug("Rejected credentials", zap.Error(err))
+			h.unauthorized(w, "invalid credentials")
+			return
+		}
+		ctx = utils.WithCaller(ctx, caller)
+	}
+	if len(h.tenancy.Tenants) > 0 {
+		tenant := r.Header.Get(h.tenancy.Header)
+		if tenant == "" {
+			http.Error(w, h.tenancy.Header+" header is required", http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(h.tenancy.Tenants, tenant) {
+			http.Error(w, "unknown tenant", http.StatusForbidden)
+			return
+		}
+		ctx = utils.WithTenant(ctx, tenant)
+	}
+	if h.rateLimiter != nil {
+		if allowed, retryAfter := h.rateLimiter.Allow(ctx); !allowed {
+			w.Header().Set("Retry-After", strconv.FormatInt(service.RetryAfterSeconds(retryAfter), 10))
+			http.Error(w, "rate limit exceeded, retry in "+retryAfter.Round(time.Millisecond).String(), http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result := graphqlgo.Do(graphqlgo.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        ctx,
+	})
+	if result.HasErrors() {
+		utils.Logger(ctx, h.logger).Warn("GraphQL request returned errors", zap.Any("errors", result.Errors))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		utils.Logger(ctx, h.logger).Error("Failed to write GraphQL response", zap.Error(err))
+	}
+}
+
+// unauthorized rejects a request, challenging the client with every accepted scheme
+func (h *Handler) unauthorized(w http.ResponseWriter, message string) {
+	for _, scheme := range h.authenticators.Schemes() {
+		w.Header().Add("WWW-Authenticate", scheme)
+	}
+	http.Error(w, message, http.StatusUnauthorized)
+}
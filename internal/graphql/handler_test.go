@@ -0,0 +1,337 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	graphqlgo "github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/providers/auth"
+	"github.com/backend-interview-task/internal/service"
+	coremock "github.com/backend-interview-task/mocks/core"
+	authmock "github.com/backend-interview-task/mocks/providers/auth"
+	cachemock "github.com/backend-interview-task/mocks/providers/cache"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type HandlerTestSuite struct {
+	suite.Suite
+	mockCore *coremock.ExplorerCore
+	userIDs  *service.UserIDValidator
+	handler  *Handler
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (s *HandlerTestSuite) SetupTest() {
+	s.mockCore = new(coremock.ExplorerCore)
+	var err error
+	s.userIDs, err = service.NewUserIDValidator(config.UserIDsConfig{Format: service.UserIDFormatAny, MaxLength: 128})
+	s.Require().NoError(err)
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	s.handler = NewHandler(schema, config.TenancyConfig{}, nil, nil, zaptest.NewLogger(s.T()))
+}
+
+func (s *HandlerTestSuite) TearDownTest() {
+	s.mockCore.AssertExpectations(s.T())
+}
+
+func (s *HandlerTestSuite) do(body string) (int, graphQLResponse) {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	var resp graphQLResponse
+	if rec.Code == http.StatusOK {
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec.Code, resp
+}
+
+func (s *HandlerTestSuite) TestLikers() {
+	expectedReq := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		PaginationToken: utils.ToPointer("token456"),
+	}
+	s.mockCore.EXPECT().ListLikers(mock.Anything, expectedReq).Return(&pb.ListLikedYouResponse{
+		Likers: []*pb.ListLikedYouResponse_Liker{
+			{ActorId: "actor1", UnixTimestamp: 1640995200},
+		},
+		NextPaginationToken: utils.ToPointer("next"),
+	}, nil).Once()
+
+	code, resp := s.do(`{"query":"{ likers(recipientUserId: \"user123\", paginationToken: \"token456\") { likers { actorId unixTimestamp } nextPaginationToken } }"}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Empty(resp.Errors)
+	likers := resp.Data["likers"].(map[string]interface{})
+	s.Equal("next", likers["nextPaginationToken"])
+	first := likers["likers"].([]interface{})[0].(map[string]interface{})
+	s.Equal("actor1", first["actorId"])
+	s.Equal(float64(1640995200), first["unixTimestamp"])
+}
+
+func (s *HandlerTestSuite) TestLikers_SignedPaginationTokens() {
+	signer := utils.NewPageTokenSigner([]string{strings.Repeat("k", 32)}, 0)
+	sign := func(token string) string {
+		signed, err := signer.Sign(token)
+		s.Require().NoError(err)
+		return signed
+	}
+	schema, err := NewSchema(s.mockCore, signer, s.userIDs)
+	s.Require().NoError(err)
+	s.handler = NewHandler(schema, config.TenancyConfig{}, nil, nil, zaptest.NewLogger(s.T()))
+	s.mockCore.EXPECT().ListLikers(mock.Anything, &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		PaginationToken: utils.ToPointer("token456"),
+	}).Return(&pb.ListLikedYouResponse{NextPaginationToken: utils.ToPointer("next"), PrevPaginationToken: utils.ToPointer("prev")}, nil).Once()
+
+	code, resp := s.do(`{"query":"{ likers(recipientUserId: \"user123\", paginationToken: \"` + sign("token456") + `\") { nextPaginationToken prevPaginationToken } }"}`)
+	s.Equal(http.StatusOK, code)
+	s.Empty(resp.Errors)
+	s.Equal(sign("next"), resp.Data["likers"].(map[string]interface{})["nextPaginationToken"])
+	s.Equal(sign("prev"), resp.Data["likers"].(map[string]interface{})["prevPaginationToken"])
+
+	_, resp = s.do(`{"query":"{ likers(recipientUserId: \"user123\", paginationToken: \"token456\") { nextPaginationToken } }"}`)
+	s.Require().Len(resp.Errors, 1)
+	s.Contains(resp.Errors[0].Message, "invalid pagination token")
+}
+
+func (s *HandlerTestSuite) TestNewLikers_CoreError() {
+	s.mockCore.EXPECT().ListNewLikers(mock.Anything, &pb.ListLikedYouRequest{RecipientUserId: "user123"}).
+		Return(nil, errors.New("failed to get new likers")).Once()
+
+	code, resp := s.do(`{"query":"{ newLikers(recipientUserId: \"user123\") { likers { actorId } } }"}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Require().Len(resp.Errors, 1)
+	s.Contains(resp.Errors[0].Message, "failed to get new likers")
+}
+
+func (s *HandlerTestSuite) TestLikerCount() {
+	s.mockCore.EXPECT().CountLikers(mock.Anything, &pb.CountLikedYouRequest{RecipientUserId: "user123"}).
+		Return(&pb.CountLikedYouResponse{Count: 7}, nil).Once()
+
+	code, resp := s.do(`{"query":"query($id: String!) { likerCount(recipientUserId: $id) }","variables":{"id":"user123"}}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Empty(resp.Errors)
+	s.Equal(float64(7), resp.Data["likerCount"])
+}
+
+func (s *HandlerTestSuite) TestLikers_InvalidRecipient() {
+	code, resp := s.do(`{"query":"{ likers(recipientUserId: \"` + strings.Repeat("u", 129) + `\") { likers { actorId } } }"}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Require().Len(resp.Errors, 1)
+	s.Equal("recipientUserId must be at most 128 bytes", resp.Errors[0].Message)
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *HandlerTestSuite) TestLikers_OtherRecipient() {
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	ctx := utils.WithCaller(context.Background(), utils.Caller{UserID: "user456"})
+
+	result := graphqlgo.Do(graphqlgo.Params{
+		Schema:        schema,
+		RequestString: `{ likers(recipientUserId: "user123") { likers { actorId } } }`,
+		Context:       ctx,
+	})
+
+	s.Require().Len(result.Errors, 1)
+	s.Contains(result.Errors[0].Message, "must be the authenticated user")
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *HandlerTestSuite) TestPutDecision() {
+	expectedReq := &pb.PutDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+		LikedRecipient:  true,
+	}
+	s.mockCore.EXPECT().CreateDecision(mock.Anything, expectedReq).
+		Return(&pb.PutDecisionResponse{MutualLikes: true}, nil).Once()
+
+	code, resp := s.do(`{"query":"mutation { putDecision(actorUserId: \"actor123\", recipientUserId: \"recipient456\", likedRecipient: true) { mutualLikes } }"}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Empty(resp.Errors)
+	s.Equal(true, resp.Data["putDecision"].(map[string]interface{})["mutualLikes"])
+}
+
+func (s *HandlerTestSuite) TestPutDecision_SameUser() {
+	code, resp := s.do(`{"query":"mutation { putDecision(actorUserId: \"user123\", recipientUserId: \"user123\", likedRecipient: true) { mutualLikes } }"}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Require().Len(resp.Errors, 1)
+	s.Contains(resp.Errors[0].Message, "cannot be the same")
+	s.mockCore.AssertNotCalled(s.T(), "CreateDecision")
+}
+
+func (s *HandlerTestSuite) TestPutDecision_InvalidUserID() {
+	code, resp := s.do(`{"query":"mutation { putDecision(actorUserId: \"actor 123\", recipientUserId: \"recipient456\", likedRecipient: true) { mutualLikes } }"}`)
+
+	s.Equal(http.StatusOK, code)
+	s.Require().Len(resp.Errors, 1)
+	s.Equal("actorUserId must not contain whitespace or control characters", resp.Errors[0].Message)
+	s.mockCore.AssertNotCalled(s.T(), "CreateDecision")
+}
+
+func (s *HandlerTestSuite) TestPutDecision_OtherActor() {
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	ctx := utils.WithCaller(context.Background(), utils.Caller{UserID: "user456"})
+
+	result := graphqlgo.Do(graphqlgo.Params{
+		Schema:        schema,
+		RequestString: `mutation { putDecision(actorUserId: "user123", recipientUserId: "user789", likedRecipient: true) { mutualLikes } }`,
+		Context:       ctx,
+	})
+
+	s.Require().Len(result.Errors, 1)
+	s.Contains(result.Errors[0].Message, "actorUserId must be the authenticated user")
+	s.mockCore.AssertNotCalled(s.T(), "CreateDecision")
+}
+
+func (s *HandlerTestSuite) TestEchoesRequestID() {
+	s.mockCore.EXPECT().CountLikers(mock.MatchedBy(func(ctx context.Context) bool {
+		return utils.RequestIDFromContext(ctx) == "gateway-7f3a"
+	}), &pb.CountLikedYouRequest{RecipientUserId: "user123"}).
+		Return(&pb.CountLikedYouResponse{Count: 7}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	req.Header.Set("X-Request-Id", "gateway-7f3a")
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("gateway-7f3a", rec.Header().Get("X-Request-Id"))
+}
+
+func (s *HandlerTestSuite) TestRecordsClientAddress() {
+	s.mockCore.EXPECT().CountLikers(mock.MatchedBy(func(ctx context.Context) bool {
+		addr, ok := utils.ClientAddressFromContext(ctx)
+		return ok && addr == "203.0.113.9:51234"
+	}), &pb.CountLikedYouRequest{RecipientUserId: "user123"}).
+		Return(&pb.CountLikedYouResponse{Count: 7}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	req.RemoteAddr = "203.0.113.9:51234"
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *HandlerTestSuite) TestRateLimitsClientAddress() {
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	mockCache := cachemock.NewCacheProvider(s.T())
+	cfg := config.RateLimitConfig{Enabled: true, Limit: 600, Period: time.Minute, Burst: 50}
+	handler := NewHandler(schema, config.TenancyConfig{}, nil, service.NewRateLimiter(mockCache, cfg, zaptest.NewLogger(s.T())), zaptest.NewLogger(s.T()))
+
+	// Requests without a caller are counted against the address they came from, as calls over gRPC are
+	mockCache.EXPECT().Allow(mock.Anything, utils.RateLimitKey(context.Background(), "addr:203.0.113.9"), 600, time.Minute, 50).
+		Return(false, 1500*time.Millisecond, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	req.RemoteAddr = "203.0.113.9:51234"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusTooManyRequests, rec.Code)
+	s.Equal("2", rec.Header().Get("Retry-After"))
+	s.mockCore.AssertNotCalled(s.T(), "CountLikers")
+}
+
+func (s *HandlerTestSuite) TestMethodNotAllowed() {
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusMethodNotAllowed, rec.Code)
+}
+
+func (s *HandlerTestSuite) TestInvalidBody() {
+	code, _ := s.do(`not json`)
+
+	s.Equal(http.StatusBadRequest, code)
+}
+
+func (s *HandlerTestSuite) TestScopesRequestToTenant() {
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	handler := NewHandler(schema, config.TenancyConfig{Tenants: []string{"acme"}, Header: "X-Tenant-Id"}, nil, nil, zaptest.NewLogger(s.T()))
+	s.mockCore.EXPECT().CountLikers(mock.MatchedBy(func(ctx context.Context) bool {
+		return utils.TenantFromContext(ctx) == "acme"
+	}), &pb.CountLikedYouRequest{RecipientUserId: "user123"}).
+		Return(&pb.CountLikedYouResponse{Count: 7}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	req.Header.Set("X-Tenant-Id", "acme")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *HandlerTestSuite) TestRejectsUnknownTenant() {
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	handler := NewHandler(schema, config.TenancyConfig{Tenants: []string{"acme"}, Header: "X-Tenant-Id"}, nil, nil, zaptest.NewLogger(s.T()))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	req.Header.Set("X-Tenant-Id", "initech")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *HandlerTestSuite) TestRequiresBearerToken() {
+	schema, err := NewSchema(s.mockCore, utils.NewPageTokenSigner(nil, 0), s.userIDs)
+	s.Require().NoError(err)
+	mockAuth := new(authmock.Authenticator)
+	defer mockAuth.AssertExpectations(s.T())
+	handler := NewHandler(schema, config.TenancyConfig{}, auth.Authenticators{auth.SchemeBearer: mockAuth}, nil, zaptest.NewLogger(s.T()))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusUnauthorized, rec.Code)
+
+	mockAuth.EXPECT().Authenticate(mock.Anything, "token123").Return(utils.Caller{UserID: "user123"}, nil).Once()
+	s.mockCore.EXPECT().CountLikers(mock.MatchedBy(func(ctx context.Context) bool {
+		caller, ok := utils.CallerFromContext(ctx)
+		return ok && caller.UserID == "user123"
+	}), &pb.CountLikedYouRequest{RecipientUserId: "user123"}).
+		Return(&pb.CountLikedYouResponse{Count: 7}, nil).Once()
+
+	req = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ likerCount(recipientUserId: \"user123\") }"}`))
+	req.Header.Set("Authorization", "Bearer token123")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusOK, rec.Code)
+}
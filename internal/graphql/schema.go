@@ -0,0 +1,218 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	graphqlgo "github.com/graphql-go/graphql"
+
+	"github.com/backend-interview-task/internal/core"
+	"github.com/backend-interview-task/internal/service"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+var likerType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name: "Liker",
+	Fields: graphqlgo.Fields{
+		"actorId": &graphqlgo.Field{
+			Type: graphqlgo.NewNonNull(graphqlgo.String),
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return p.Source.(*pb.ListLikedYouResponse_Liker).GetActorId(), nil
+			},
+		},
+		"unixTimestamp": &graphqlgo.Field{
+			// GraphQL Int is 32-bit, so timestamps are exposed as floats
+			Type: graphqlgo.NewNonNull(graphqlgo.Float),
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return float64(p.Source.(*pb.ListLikedYouResponse_Liker).GetUnixTimestamp()), nil
+			},
+		},
+		"alreadyLikedBack": &graphqlgo.Field{
+			Type: graphqlgo.NewNonNull(graphqlgo.Boolean),
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return p.Source.(*pb.ListLikedYouResponse_Liker).GetAlreadyLikedBack(), nil
+			},
+		},
+	},
+})
+
+var likersPageType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name: "LikersPage",
+	Fields: graphqlgo.Fields{
+		"likers": &graphqlgo.Field{
+			Type: graphqlgo.NewNonNull(graphqlgo.NewList(graphqlgo.NewNonNull(likerType))),
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return p.Source.(*pb.ListLikedYouResponse).GetLikers(), nil
+			},
+		},
+		"nextPaginationToken": &graphqlgo.Field{
+			Type: graphqlgo.String,
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return p.Source.(*pb.ListLikedYouResponse).NextPaginationToken, nil
+			},
+		},
+		"prevPaginationToken": &graphqlgo.Field{
+			Type: graphqlgo.String,
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return p.Source.(*pb.ListLikedYouResponse).PrevPaginationToken, nil
+			},
+		},
+	},
+})
+
+var decisionType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name: "Decision",
+	Fields: graphqlgo.Fields{
+		"mutualLikes": &graphqlgo.Field{
+			Type: graphqlgo.NewNonNull(graphqlgo.Boolean),
+			Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+				return p.Source.(*pb.PutDecisionResponse).GetMutualLikes(), nil
+			},
+		},
+	},
+})
+
+var likersArgs = graphqlgo.FieldConfigArgument{
+	"recipientUserId": &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	"paginationToken": &graphqlgo.ArgumentConfig{Type: graphqlgo.String},
+}
+
+// NewSchema builds the GraphQL schema resolving likers, newLikers, likerCount and putDecision against
+// the core. Pagination tokens are signed, and checked for tampering and expiry, with pageTokens,
+// and user IDs are checked with userIDs, as the gRPC API does.
+func NewSchema(explorerCore core.ExplorerCore, pageTokens *utils.PageTokenSigner, userIDs *service.UserIDValidator) (graphqlgo.Schema, error) {
+	listLikers := func(p graphqlgo.ResolveParams, list func(context.Context, *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error)) (interface{}, error) {
+		req, err := likersRequest(p.Context, p.Args, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		if req.PaginationToken != nil {
+			token, err := pageTokens.Verify(req.GetPaginationToken())
+			if err != nil {
+				return nil, err
+			}
+			req.PaginationToken = &token
+		}
+		resp, err := list(p.Context, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range []*string{resp.NextPaginationToken, resp.PrevPaginationToken} {
+			if token == nil {
+				continue
+			}
+			signed, err := pageTokens.Sign(*token)
+			if err != nil {
+				return nil, err
+			}
+			*token = signed
+		}
+		return resp, nil
+	}
+
+	query := graphqlgo.NewObject(graphqlgo.ObjectConfig{
+		Name: "Query",
+		Fields: graphqlgo.Fields{
+			"likers": &graphqlgo.Field{
+				Type: graphqlgo.NewNonNull(likersPageType),
+				Args: likersArgs,
+				Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+					return listLikers(p, explorerCore.ListLikers)
+				},
+			},
+			"newLikers": &graphqlgo.Field{
+				Type: graphqlgo.NewNonNull(likersPageType),
+				Args: likersArgs,
+				Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+					return listLikers(p, explorerCore.ListNewLikers)
+				},
+			},
+			"likerCount": &graphqlgo.Field{
+				Type: graphqlgo.NewNonNull(graphqlgo.Int),
+				Args: graphqlgo.FieldConfigArgument{
+					"recipientUserId": &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+				},
+				Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+					recipientUserID, _ := p.Args["recipientUserId"].(string)
+					if err := userIDs.Validate(recipientUserID); err != nil {
+						return nil, fmt.Errorf("recipientUserId %w", err)
+					}
+					if !utils.MayActFor(p.Context, recipientUserID) {
+						return nil, errNotRecipient
+					}
+					resp, err := explorerCore.CountLikers(p.Context, &pb.CountLikedYouRequest{RecipientUserId: recipientUserID})
+					if err != nil {
+						return nil, err
+					}
+					return int(resp.GetCount()), nil
+				},
+			},
+		},
+	})
+
+	mutation := graphqlgo.NewObject(graphqlgo.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphqlgo.Fields{
+			"putDecision": &graphqlgo.Field{
+				Type: graphqlgo.NewNonNull(decisionType),
+				Args: graphqlgo.FieldConfigArgument{
+					"actorUserId":     &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+					"recipientUserId": &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+					"likedRecipient":  &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(graphqlgo.Boolean)},
+				},
+				Resolve: func(p graphqlgo.ResolveParams) (interface{}, error) {
+					req := &pb.PutDecisionRequest{}
+					req.ActorUserId, _ = p.Args["actorUserId"].(string)
+					req.RecipientUserId, _ = p.Args["recipientUserId"].(string)
+					req.LikedRecipient, _ = p.Args["likedRecipient"].(bool)
+
+					if err := userIDs.Validate(req.ActorUserId); err != nil {
+						return nil, fmt.Errorf("actorUserId %w", err)
+					}
+					if err := userIDs.Validate(req.RecipientUserId); err != nil {
+						return nil, fmt.Errorf("recipientUserId %w", err)
+					}
+					switch {
+					case req.ActorUserId == req.RecipientUserId:
+						return nil, errors.New("actorUserId and recipientUserId cannot be the same")
+					case !utils.MayActFor(p.Context, req.ActorUserId):
+						return nil, errNotActor
+					}
+
+					return explorerCore.CreateDecision(p.Context, req)
+				},
+			},
+		},
+	})
+
+	return graphqlgo.NewSchema(graphqlgo.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// errNotRecipient rejects reading the likers of a user other than the caller
+var errNotRecipient = errors.New("recipientUserId must be the authenticated user")
+
+// errNotActor rejects recording a decision on behalf of a user other than the caller
+var errNotActor = errors.New("actorUserId must be the authenticated user")
+
+// likersRequest builds a likers listing request from the query arguments
+func likersRequest(ctx context.Context, args map[string]interface{}, userIDs *service.UserIDValidator) (*pb.ListLikedYouRequest, error) {
+	recipientUserID, _ := args["recipientUserId"].(string)
+	if err := userIDs.Validate(recipientUserID); err != nil {
+		return nil, fmt.Errorf("recipientUserId %w", err)
+	}
+	if !utils.MayActFor(ctx, recipientUserID) {
+		return nil, errNotRecipient
+	}
+
+	req := &pb.ListLikedYouRequest{RecipientUserId: recipientUserID}
+	if token, ok := args["paginationToken"].(string); ok && token != "" {
+		req.PaginationToken = &token
+	}
+
+	return req, nil
+}
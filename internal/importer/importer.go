@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+)
+
+// Importer bulk-loads decisions exported from another system into the repository
+type Importer struct {
+	repo      repository.ExplorerRepository
+	batchSize int
+	logger    *zap.Logger
+}
+
+// NewImporter creates a new Importer writing batchSize decisions per transaction
+func NewImporter(repo repository.ExplorerRepository, batchSize int, logger *zap.Logger) *Importer {
+	return &Importer{
+		repo:      repo,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// Import reads every decision of reader and imports them in batches, each committed on its own.
+// It stops at the first invalid row or failed batch, keeping the batches committed before it;
+// importing the same input again is harmless, so a failed import is resumed by rerunning it.
+func (i *Importer) Import(ctx context.Context, reader Reader) (models.ImportSummary, error) {
+	var total models.ImportSummary
+	batch := make([]models.ImportedDecision, 0, i.batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		summary, err := i.repo.ImportDecisions(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("failed to import batch after %d rows: %w", total.Rows, err)
+		}
+		total = total.Add(summary)
+		batch = batch[:0]
+
+		i.logger.Info("Imported decisions batch",
+			zap.Int("rows", summary.Rows),
+			zap.Int("total_rows", total.Rows))
+		return nil
+	}
+
+	for {
+		decision, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return total, err
+		}
+
+		batch = append(batch, decision)
+		if len(batch) == i.batchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/models"
+	repomock "github.com/backend-interview-task/mocks/repository"
+)
+
+type ImporterTestSuite struct {
+	suite.Suite
+	mockRepo *repomock.ExplorerRepository
+	importer *Importer
+}
+
+func TestImporterTestSuite(t *testing.T) {
+	suite.Run(t, new(ImporterTestSuite))
+}
+
+func (s *ImporterTestSuite) SetupTest() {
+	s.mockRepo = new(repomock.ExplorerRepository)
+	s.importer = NewImporter(s.mockRepo, 2, zap.NewNop())
+}
+
+func (s *ImporterTestSuite) TearDownTest() {
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ImporterTestSuite) reader(format, input string) Reader {
+	reader, err := NewReader(strings.NewReader(input), format)
+	s.Require().NoError(err)
+	return reader
+}
+
+func (s *ImporterTestSuite) TestImport_WritesInBatches() {
+	input := `actor_user_id,recipient_user_id,liked_recipient,created_at
+user1,user2,true,2023-05-01T12:00:00Z
+user2,user1,true,2023-05-02T12:00:00Z
+user3,user1,false,2023-05-03T12:00:00Z
+`
+	s.mockRepo.EXPECT().ImportDecisions(mock.Anything, mock.MatchedBy(func(batch []models.ImportedDecision) bool {
+		return len(batch) == 2 && batch[0].ActorUserID == "user1" && batch[1].ActorUserID == "user2"
+	})).Return(models.ImportSummary{Rows: 2, Created: 2, Matches: 1}, nil).Once()
+	s.mockRepo.EXPECT().ImportDecisions(mock.Anything, []models.ImportedDecision{
+		{ActorUserID: "user3", RecipientUserID: "user1", CreatedAt: time.Date(2023, 5, 3, 12, 0, 0, 0, time.UTC)},
+	}).Return(models.ImportSummary{Rows: 1, Updated: 1}, nil).Once()
+
+	summary, err := s.importer.Import(context.Background(), s.reader(FormatCSV, input))
+
+	s.NoError(err)
+	s.Equal(models.ImportSummary{Rows: 3, Created: 2, Updated: 1, Matches: 1}, summary)
+}
+
+func (s *ImporterTestSuite) TestImport_StopsAtInvalidRow() {
+	input := `{"actor_user_id":"user1","recipient_user_id":"user2","liked_recipient":true,"created_at":"2023-05-01T12:00:00Z"}
+{"actor_user_id":"user2","recipient_user_id":"user1","liked_recipient":true,"created_at":"2023-05-02T12:00:00Z"}
+
+{"actor_user_id":"user3","recipient_user_id":"user3","liked_recipient":true,"created_at":"2023-05-03T12:00:00Z"}
+`
+	s.mockRepo.EXPECT().ImportDecisions(mock.Anything, mock.Anything).
+		Return(models.ImportSummary{Rows: 2, Created: 2}, nil).Once()
+
+	summary, err := s.importer.Import(context.Background(), s.reader(FormatNDJSON, input))
+
+	var rowErr *RowError
+	s.Require().ErrorAs(err, &rowErr)
+	s.Equal(4, rowErr.Line)
+	s.Equal(models.ImportSummary{Rows: 2, Created: 2}, summary)
+}
+
+func (s *ImporterTestSuite) TestImport_BatchError() {
+	input := `actor_user_id,recipient_user_id,liked_recipient,created_at
+user1,user2,true,2023-05-01T12:00:00Z
+`
+	s.mockRepo.EXPECT().ImportDecisions(mock.Anything, mock.Anything).
+		Return(models.ImportSummary{}, errors.New("connection reset")).Once()
+
+	_, err := s.importer.Import(context.Background(), s.reader(FormatCSV, input))
+
+	s.Error(err)
+	s.Contains(err.Error(), "connection reset")
+}
+
+func (s *ImporterTestSuite) TestNewReader_CSVColumnsInAnyOrder() {
+	input := `created_at,liked_recipient,recipient_user_id,actor_user_id
+2023-05-01T12:00:00+02:00,false,user2,user1
+`
+	reader := s.reader(FormatCSV, input)
+
+	decision, err := reader.Read()
+
+	s.NoError(err)
+	s.Equal("user1", decision.ActorUserID)
+	s.Equal("user2", decision.RecipientUserID)
+	s.False(decision.LikedRecipient)
+	s.True(decision.CreatedAt.Equal(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)))
+}
+
+func (s *ImporterTestSuite) TestNewReader_CSVMissingColumn() {
+	_, err := NewReader(strings.NewReader("actor_user_id,recipient_user_id,created_at\n"), FormatCSV)
+
+	s.Error(err)
+	s.Contains(err.Error(), "liked_recipient")
+}
+
+func (s *ImporterTestSuite) TestNewReader_CSVInvalidTimestamp() {
+	reader := s.reader(FormatCSV, "actor_user_id,recipient_user_id,liked_recipient,created_at\nuser1,user2,true,yesterday\n")
+
+	_, err := reader.Read()
+
+	var rowErr *RowError
+	s.Require().ErrorAs(err, &rowErr)
+	s.Equal(2, rowErr.Line)
+	s.Contains(err.Error(), "invalid created_at")
+}
+
+func (s *ImporterTestSuite) TestNewReader_NDJSONMissingLikedRecipient() {
+	reader := s.reader(FormatNDJSON, `{"actor_user_id":"user1","recipient_user_id":"user2","created_at":"2023-05-01T12:00:00Z"}`)
+
+	_, err := reader.Read()
+
+	s.Error(err)
+	s.Contains(err.Error(), "liked_recipient is required")
+}
+
+func (s *ImporterTestSuite) TestFormatOf() {
+	format, err := FormatOf("export/decisions.JSONL")
+	s.NoError(err)
+	s.Equal(FormatNDJSON, format)
+
+	_, err = FormatOf("decisions.txt")
+	s.Error(err)
+}
@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/backend-interview-task/internal/models"
+)
+
+// Formats an import file can be written in
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// maxLineSize caps the length of an NDJSON line
+const maxLineSize = 64 * 1024
+
+// Reader reads decisions one at a time, returning io.EOF once the input is exhausted
+type Reader interface {
+	Read() (models.ImportedDecision, error)
+}
+
+// RowError is the error of the row starting on Line of the input, which stopped the import
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// FormatOf returns the format of the file at path from its extension
+func FormatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %q from its extension", path)
+}
+
+// NewReader reads decisions from r in the given format.
+//
+// CSV input starts with a header naming the actor_user_id, recipient_user_id, liked_recipient
+// and created_at columns, in any order. NDJSON input holds one object with those fields per line.
+// created_at is an RFC 3339 timestamp.
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+// validateDecision checks the fields every imported decision needs
+func validateDecision(d models.ImportedDecision) error {
+	if d.ActorUserID == "" {
+		return errors.New("actor_user_id is required")
+	}
+	if d.RecipientUserID == "" {
+		return errors.New("recipient_user_id is required")
+	}
+	if d.ActorUserID == d.RecipientUserID {
+		return errors.New("actor and recipient cannot be the same user")
+	}
+	if d.CreatedAt.IsZero() {
+		return errors.New("created_at is required")
+	}
+	return nil
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing CSV header")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"actor_user_id", "recipient_user_id", "liked_recipient", "created_at"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the %s column", name)
+		}
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) Read() (models.ImportedDecision, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return models.ImportedDecision{}, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return models.ImportedDecision{}, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	liked, err := strconv.ParseBool(record[r.columns["liked_recipient"]])
+	if err != nil {
+		return models.ImportedDecision{}, &RowError{Line: line, Err: fmt.Errorf("invalid liked_recipient %q", record[r.columns["liked_recipient"]])}
+	}
+	createdAt, err := time.Parse(time.RFC3339, record[r.columns["created_at"]])
+	if err != nil {
+		return models.ImportedDecision{}, &RowError{Line: line, Err: fmt.Errorf("invalid created_at %q", record[r.columns["created_at"]])}
+	}
+
+	decision := models.ImportedDecision{
+		ActorUserID:     record[r.columns["actor_user_id"]],
+		RecipientUserID: record[r.columns["recipient_user_id"]],
+		LikedRecipient:  liked,
+		CreatedAt:       createdAt,
+	}
+	if err := validateDecision(decision); err != nil {
+		return models.ImportedDecision{}, &RowError{Line: line, Err: err}
+	}
+	return decision, nil
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// ndjsonDecision is a line of NDJSON input. LikedRecipient is a pointer so that a missing field
+// is told apart from a pass.
+type ndjsonDecision struct {
+	ActorUserID     string    `json:"actor_user_id"`
+	RecipientUserID string    `json:"recipient_user_id"`
+	LikedRecipient  *bool     `json:"liked_recipient"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (r *ndjsonReader) Read() (models.ImportedDecision, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var row ndjsonDecision
+		if err := json.Unmarshal(line, &row); err != nil {
+			return models.ImportedDecision{}, &RowError{Line: r.line, Err: err}
+		}
+		if row.LikedRecipient == nil {
+			return models.ImportedDecision{}, &RowError{Line: r.line, Err: errors.New("liked_recipient is required")}
+		}
+
+		decision := models.ImportedDecision{
+			ActorUserID:     row.ActorUserID,
+			RecipientUserID: row.RecipientUserID,
+			LikedRecipient:  *row.LikedRecipient,
+			CreatedAt:       row.CreatedAt,
+		}
+		if err := validateDecision(decision); err != nil {
+			return models.ImportedDecision{}, &RowError{Line: r.line, Err: err}
+		}
+		return decision, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return models.ImportedDecision{}, &RowError{Line: r.line + 1, Err: err}
+	}
+	return models.ImportedDecision{}, io.EOF
+}
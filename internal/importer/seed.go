@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/backend-interview-task/internal/models"
+)
+
+// popularityShape is the shape of the Pareto distribution users' popularity is drawn from: a
+// few users are seen and liked far more often than the rest
+const popularityShape = 1.5
+
+// maxReplyDelay bounds how long after being liked a user answers the like
+const maxReplyDelay = 72 * time.Hour
+
+// SeedOptions shape the decisions a seed reader makes up
+type SeedOptions struct {
+	Users            int           // Number of users making and receiving decisions
+	DecisionsPerUser int           // Mean number of decisions a user makes, heavy users making many more
+	LikeRate         float64       // Share of decisions on a user of average popularity that are likes
+	ReplyRate        float64       // Share of likes the recipient answers with a decision on the actor
+	Period           time.Duration // Decisions are spread over the period up to now
+	Prefix           string        // Prefix of the made up user IDs
+	Seed             uint64        // The same options and seed make up the same decisions
+}
+
+// Validate returns why opts cannot seed decisions
+func (opts SeedOptions) Validate() error {
+	switch {
+	case opts.Users < 2:
+		return errors.New("at least 2 users are required")
+	case opts.DecisionsPerUser < 1:
+		return errors.New("decisions per user must be positive")
+	case opts.LikeRate < 0 || opts.LikeRate > 1:
+		return errors.New("like rate must be between 0 and 1")
+	case opts.ReplyRate < 0 || opts.ReplyRate > 1:
+		return errors.New("reply rate must be between 0 and 1")
+	case opts.Period <= 0:
+		return errors.New("period must be positive")
+	}
+	return nil
+}
+
+// seedReader makes up decisions one actor at a time. Users are drawn a popularity, by which they
+// are both shown to actors and liked; a like is answered by the recipient at ReplyRate, which is
+// what makes matches. Each user decides on another at most once.
+type seedReader struct {
+	opts       SeedOptions
+	now        time.Time
+	rng        *rand.Rand
+	popularity []float64
+	cumulative []float64       // Running sums of popularity, to draw recipients by it
+	decided    map[uint64]bool // Pairs decided on, by pairKey
+	actor      int
+	pending    []models.ImportedDecision
+}
+
+// NewSeedReader returns a Reader making up decisions among opts.Users users, made in the
+// opts.Period up to now
+func NewSeedReader(opts SeedOptions, now time.Time) (Reader, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := &seedReader{
+		opts:       opts,
+		now:        now,
+		rng:        rand.New(rand.NewPCG(opts.Seed, opts.Seed)),
+		popularity: make([]float64, opts.Users),
+		cumulative: make([]float64, opts.Users),
+		decided:    make(map[uint64]bool),
+	}
+	var total float64
+	for i := range r.popularity {
+		r.popularity[i] = math.Pow(1-r.rng.Float64(), -1/popularityShape)
+		total += r.popularity[i]
+		r.cumulative[i] = total
+	}
+	// Scale popularity to average 1, so that a user of average popularity is liked at LikeRate
+	mean := total / float64(opts.Users)
+	for i := range r.popularity {
+		r.popularity[i] /= mean
+	}
+	return r, nil
+}
+
+func (r *seedReader) Read() (models.ImportedDecision, error) {
+	for len(r.pending) == 0 {
+		if r.actor == r.opts.Users {
+			return models.ImportedDecision{}, io.EOF
+		}
+		r.decide(r.actor)
+		r.actor++
+	}
+
+	decision := r.pending[0]
+	r.pending = r.pending[1:]
+	return decision, nil
+}
+
+// decide queues the decisions of actor, and the answers to their likes
+func (r *seedReader) decide(actor int) {
+	count := min(int(r.rng.ExpFloat64()*float64(r.opts.DecisionsPerUser)), r.opts.Users-1)
+
+	// Popular users are drawn again and again, so give up on a few draws rather than loop
+	for made, attempts := 0, 0; made < count && attempts < 4*count; attempts++ {
+		recipient := r.drawRecipient()
+		if recipient == actor || r.decided[pairKey(actor, recipient)] {
+			continue
+		}
+		made++
+
+		createdAt := r.now.Add(-time.Duration(r.rng.Int64N(int64(r.opts.Period))))
+		liked := r.likes(recipient)
+		r.pending = append(r.pending, r.decision(actor, recipient, liked, createdAt))
+
+		if liked && r.rng.Float64() < r.opts.ReplyRate {
+			repliedAt := createdAt.Add(time.Duration(r.rng.Int64N(int64(maxReplyDelay))))
+			if repliedAt.After(r.now) {
+				repliedAt = r.now
+			}
+			if !r.decided[pairKey(recipient, actor)] {
+				r.pending = append(r.pending, r.decision(recipient, actor, r.likes(actor), repliedAt))
+			}
+		}
+	}
+}
+
+// drawRecipient draws a user with a chance proportional to their popularity
+func (r *seedReader) drawRecipient() int {
+	target := r.rng.Float64() * r.cumulative[len(r.cumulative)-1]
+	return min(sort.SearchFloat64s(r.cumulative, target), len(r.cumulative)-1)
+}
+
+// likes draws whether a decision on recipient is a like, more likely the more popular they are
+func (r *seedReader) likes(recipient int) bool {
+	chance := min(max(r.opts.LikeRate*r.popularity[recipient], 0.01), 0.95)
+	return r.rng.Float64() < chance
+}
+
+// decision records that actor decided on recipient, who is not drawn for them again
+func (r *seedReader) decision(actor, recipient int, liked bool, createdAt time.Time) models.ImportedDecision {
+	r.decided[pairKey(actor, recipient)] = true
+	return models.ImportedDecision{
+		ActorUserID:     r.userID(actor),
+		RecipientUserID: r.userID(recipient),
+		LikedRecipient:  liked,
+		CreatedAt:       createdAt.Truncate(time.Second),
+	}
+}
+
+// pairKey packs a pair of users in one key, which keeps the decided pairs of tens of millions
+// of decisions in memory
+func pairKey(actor, recipient int) uint64 {
+	return uint64(actor)<<32 | uint64(recipient)
+}
+
+func (r *seedReader) userID(user int) string {
+	return fmt.Sprintf("%s%06d", r.opts.Prefix, user+1)
+}
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/internal/models"
+)
+
+type SeedReaderTestSuite struct {
+	suite.Suite
+	now  time.Time
+	opts SeedOptions
+}
+
+func TestSeedReaderTestSuite(t *testing.T) {
+	suite.Run(t, new(SeedReaderTestSuite))
+}
+
+func (s *SeedReaderTestSuite) SetupTest() {
+	s.now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.opts = SeedOptions{
+		Users:            200,
+		DecisionsPerUser: 20,
+		LikeRate:         0.3,
+		ReplyRate:        0.5,
+		Period:           7 * 24 * time.Hour,
+		Prefix:           "seed-",
+		Seed:             42,
+	}
+}
+
+// readAll returns every decision a seed reader makes up with opts
+func (s *SeedReaderTestSuite) readAll(opts SeedOptions) []models.ImportedDecision {
+	reader, err := NewSeedReader(opts, s.now)
+	s.Require().NoError(err)
+
+	var decisions []models.ImportedDecision
+	for {
+		decision, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return decisions
+		}
+		s.Require().NoError(err)
+		decisions = append(decisions, decision)
+	}
+}
+
+func (s *SeedReaderTestSuite) TestSameSeedSameDecisions() {
+	s.Equal(s.readAll(s.opts), s.readAll(s.opts))
+
+	other := s.opts
+	other.Seed = 43
+	s.NotEqual(s.readAll(s.opts), s.readAll(other))
+}
+
+func (s *SeedReaderTestSuite) TestDecisions() {
+	decisions := s.readAll(s.opts)
+
+	s.Greater(len(decisions), s.opts.Users*s.opts.DecisionsPerUser/2)
+	pairs := make(map[[2]string]bool, len(decisions))
+	liked := make(map[[2]string]bool)
+	var likes, matches int
+	for _, d := range decisions {
+		s.NotEqual(d.ActorUserID, d.RecipientUserID)
+		s.Regexp(`^seed-\d{6}$`, d.ActorUserID)
+		s.False(d.CreatedAt.After(s.now))
+		s.False(d.CreatedAt.Before(s.now.Add(-s.opts.Period)))
+
+		pair := [2]string{d.ActorUserID, d.RecipientUserID}
+		s.False(pairs[pair], "pair %v decided twice", pair)
+		pairs[pair] = true
+		if d.LikedRecipient {
+			likes++
+			liked[pair] = true
+			if liked[[2]string{d.RecipientUserID, d.ActorUserID}] {
+				matches++
+			}
+		}
+	}
+
+	rate := float64(likes) / float64(len(decisions))
+	s.Greater(rate, 0.1)
+	s.Less(rate, 0.7)
+	s.Positive(matches)
+}
+
+func (s *SeedReaderTestSuite) TestInvalidOptions() {
+	testCases := []struct {
+		name   string
+		modify func(*SeedOptions)
+	}{
+		{name: "single user", modify: func(o *SeedOptions) { o.Users = 1 }},
+		{name: "no decisions", modify: func(o *SeedOptions) { o.DecisionsPerUser = 0 }},
+		{name: "like rate above 1", modify: func(o *SeedOptions) { o.LikeRate = 1.5 }},
+		{name: "negative reply rate", modify: func(o *SeedOptions) { o.ReplyRate = -0.1 }},
+		{name: "empty period", modify: func(o *SeedOptions) { o.Period = 0 }},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			opts := s.opts
+			tc.modify(&opts)
+
+			reader, err := NewSeedReader(opts, s.now)
+
+			s.Nil(reader)
+			s.Error(err)
+		})
+	}
+}
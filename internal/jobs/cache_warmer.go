@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/core"
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/utils"
+)
+
+// CacheWarmer periodically refreshes the cached first page and count of the most requested
+// recipients, so that their entries are rebuilt ahead of expiring rather than by a burst of
+// requests all missing the cache at once. One replica at a time warms the cache.
+type CacheWarmer struct {
+	core       core.ExplorerCore
+	cache      cache.CacheProvider
+	interval   time.Duration
+	recipients int
+	logger     *zap.Logger
+}
+
+// NewCacheWarmer creates a new CacheWarmer warming the cache of up to recipients hot recipients every interval
+func NewCacheWarmer(core core.ExplorerCore, cache cache.CacheProvider, interval time.Duration, recipients int, logger *zap.Logger) *CacheWarmer {
+	return &CacheWarmer{
+		core:       core,
+		cache:      cache,
+		interval:   interval,
+		recipients: recipients,
+		logger:     logger,
+	}
+}
+
+// Run warms the cache of hot recipients every interval until ctx is done
+func (w *CacheWarmer) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := runLocked(ctx, w.cache, "cache_warmer", w.interval, func(ctx context.Context) error {
+				_, err := w.Warm(ctx)
+				return err
+			})
+			if err != nil && ctx.Err() == nil {
+				w.logger.Error("Failed to warm cache", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Warm refreshes the cache of the recipients most requested in the last complete window and
+// returns how many were warmed. A recipient that fails to warm does not stop the others.
+func (w *CacheWarmer) Warm(ctx context.Context) (int, error) {
+	window := time.Now().Add(-utils.HotRecipientsWindow)
+	recipients, err := w.cache.HotRecipients(ctx, window, w.recipients)
+	if err != nil {
+		return 0, err
+	}
+	if len(recipients) == 0 {
+		return 0, nil
+	}
+	return w.core.WarmRecipients(ctx, recipients)
+}
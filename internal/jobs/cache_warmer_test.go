@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	coremock "github.com/backend-interview-task/mocks/core"
+	cachemock "github.com/backend-interview-task/mocks/providers/cache"
+	"github.com/backend-interview-task/utils"
+)
+
+type CacheWarmerTestSuite struct {
+	suite.Suite
+	mockCore  *coremock.ExplorerCore
+	mockCache *cachemock.CacheProvider
+	warmer    *CacheWarmer
+}
+
+func TestCacheWarmerTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheWarmerTestSuite))
+}
+
+func (s *CacheWarmerTestSuite) SetupTest() {
+	s.mockCore = new(coremock.ExplorerCore)
+	s.mockCache = new(cachemock.CacheProvider)
+	s.warmer = NewCacheWarmer(s.mockCore, s.mockCache, time.Hour, 2, zap.NewNop())
+}
+
+func (s *CacheWarmerTestSuite) TearDownTest() {
+	s.mockCore.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+}
+
+func (s *CacheWarmerTestSuite) TestWarm_ReadsLastCompleteWindow() {
+	s.mockCache.EXPECT().HotRecipients(mock.Anything, mock.Anything, 2).
+		Run(func(ctx context.Context, window time.Time, limit int) {
+			s.WithinDuration(time.Now().Add(-utils.HotRecipientsWindow), window, time.Second)
+		}).
+		Return([]string{"user1", "user2"}, nil).Once()
+	s.mockCore.EXPECT().WarmRecipients(mock.Anything, []string{"user1", "user2"}).Return(2, nil).Once()
+
+	warmed, err := s.warmer.Warm(context.Background())
+
+	s.NoError(err)
+	s.Equal(2, warmed)
+}
+
+func (s *CacheWarmerTestSuite) TestWarm_NoHotRecipients() {
+	s.mockCache.EXPECT().HotRecipients(mock.Anything, mock.Anything, 2).Return(nil, nil).Once()
+
+	warmed, err := s.warmer.Warm(context.Background())
+
+	s.NoError(err)
+	s.Zero(warmed)
+	s.mockCore.AssertNotCalled(s.T(), "WarmRecipients", mock.Anything, mock.Anything)
+}
+
+func (s *CacheWarmerTestSuite) TestWarm_CacheError() {
+	s.mockCache.EXPECT().HotRecipients(mock.Anything, mock.Anything, 2).Return(nil, errors.New("connection refused")).Once()
+
+	warmed, err := s.warmer.Warm(context.Background())
+
+	s.Error(err)
+	s.Zero(warmed)
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/core"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/providers/database"
+	"github.com/backend-interview-task/utils"
+)
+
+// ChangeFeed listens for the decision changes the database announces and hands each to the core,
+// keeping caches and new like watchers of this instance up to date without polling
+type ChangeFeed struct {
+	listener database.Listener
+	core     core.ExplorerCore
+	// tenants are the configured tenants; changes made in the schema of any other are ignored
+	tenants map[string]bool
+	logger  *zap.Logger
+}
+
+// NewChangeFeed creates a new ChangeFeed. With tenants configured, every change is handled in
+// the scope of the tenant whose schema it was made in.
+func NewChangeFeed(listener database.Listener, core core.ExplorerCore, tenants []string, logger *zap.Logger) *ChangeFeed {
+	known := make(map[string]bool, len(tenants))
+	for _, tenant := range tenants {
+		known[tenant] = true
+	}
+	return &ChangeFeed{
+		listener: listener,
+		core:     core,
+		tenants:  known,
+		logger:   logger,
+	}
+}
+
+// decisionNotification is the payload of a notification on utils.DecisionChangesChannel
+type decisionNotification struct {
+	Schema          string `json:"schema"`
+	ActorUserID     string `json:"actor_user_id"`
+	RecipientUserID string `json:"recipient_user_id"`
+	LikedRecipient  bool   `json:"liked_recipient"`
+	LikedBefore     bool   `json:"liked_before"`
+	Mutual          bool   `json:"mutual"`
+	CreatedAt       int64  `json:"created_at"`
+}
+
+// Run handles decision changes until ctx is done
+func (f *ChangeFeed) Run(ctx context.Context) {
+	if err := f.listener.Listen(ctx, utils.DecisionChangesChannel, f.Handle); err != nil {
+		f.logger.Error("Decision change feed stopped", zap.Error(err))
+	}
+}
+
+// Handle decodes a decision change notification and hands it to the core
+func (f *ChangeFeed) Handle(ctx context.Context, payload string) {
+	var notification decisionNotification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		f.logger.Warn("Failed to decode decision change", zap.String("payload", payload), zap.Error(err))
+		return
+	}
+
+	if len(f.tenants) > 0 {
+		tenant, ok := utils.TenantOfSchema(notification.Schema)
+		if !ok || !f.tenants[tenant] {
+			return
+		}
+		ctx = utils.WithTenant(ctx, tenant)
+	}
+
+	change := models.DecisionChange{
+		ActorUserID:     notification.ActorUserID,
+		RecipientUserID: notification.RecipientUserID,
+		LikedRecipient:  notification.LikedRecipient,
+		LikedBefore:     notification.LikedBefore,
+		Mutual:          notification.Mutual,
+		CreatedAt:       notification.CreatedAt,
+	}
+	if err := f.core.HandleDecisionChange(ctx, change); err != nil {
+		f.logger.Warn("Failed to handle decision change",
+			zap.String("actor_user_id", change.ActorUserID),
+			zap.String("recipient_user_id", change.RecipientUserID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/models"
+	coremock "github.com/backend-interview-task/mocks/core"
+	databasemock "github.com/backend-interview-task/mocks/providers/database"
+	"github.com/backend-interview-task/utils"
+)
+
+type ChangeFeedTestSuite struct {
+	suite.Suite
+	mockListener *databasemock.Listener
+	mockCore     *coremock.ExplorerCore
+}
+
+func TestChangeFeedTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeFeedTestSuite))
+}
+
+func (s *ChangeFeedTestSuite) SetupTest() {
+	s.mockListener = new(databasemock.Listener)
+	s.mockCore = new(coremock.ExplorerCore)
+}
+
+func (s *ChangeFeedTestSuite) TearDownTest() {
+	s.mockListener.AssertExpectations(s.T())
+	s.mockCore.AssertExpectations(s.T())
+}
+
+func (s *ChangeFeedTestSuite) TestRun_HandsChangesToCore() {
+	feed := NewChangeFeed(s.mockListener, s.mockCore, nil, zap.NewNop())
+
+	s.mockListener.EXPECT().Listen(mock.Anything, utils.DecisionChangesChannel, mock.Anything).
+		Run(func(ctx context.Context, channel string, handle func(context.Context, string)) {
+			handle(ctx, `{"schema":"public","op":"INSERT","actor_user_id":"actor1","recipient_user_id":"recipient1","liked_recipient":true,"liked_before":false,"mutual":false,"created_at":1640995200}`)
+			handle(ctx, `not json`)
+		}).
+		Return(nil).Once()
+	s.mockCore.EXPECT().HandleDecisionChange(mock.Anything, models.DecisionChange{
+		ActorUserID:     "actor1",
+		RecipientUserID: "recipient1",
+		LikedRecipient:  true,
+		CreatedAt:       1640995200,
+	}).Return(nil).Once()
+
+	feed.Run(context.Background())
+}
+
+func (s *ChangeFeedTestSuite) TestHandle_ScopesChangeToTenant() {
+	feed := NewChangeFeed(s.mockListener, s.mockCore, []string{"acme"}, zap.NewNop())
+
+	s.mockCore.EXPECT().HandleDecisionChange(mock.MatchedBy(func(ctx context.Context) bool {
+		return utils.TenantFromContext(ctx) == "acme"
+	}), mock.Anything).Return(nil).Once()
+
+	feed.Handle(context.Background(), `{"schema":"tenant_acme","actor_user_id":"actor1","recipient_user_id":"recipient1","liked_recipient":false,"liked_before":true}`)
+	// Changes made in schemas of tenants this instance does not serve are ignored
+	feed.Handle(context.Background(), `{"schema":"tenant_other","actor_user_id":"actor1","recipient_user_id":"recipient1"}`)
+	feed.Handle(context.Background(), `{"schema":"public","actor_user_id":"actor1","recipient_user_id":"recipient1"}`)
+}
@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthCheck probes one dependency of the service. A failing critical check takes the service
+// out of rotation; other failures are only logged, as the service keeps working without them.
+// When Service is set, the dependency's own status is also reported under that service name,
+// whether it is critical or not, so that one can watch which dependency is degraded.
+type HealthCheck struct {
+	Name     string
+	Service  string
+	Check    func(ctx context.Context) error
+	Critical bool
+}
+
+// HealthReporter publishes the serving status of services, such as the gRPC health server
+type HealthReporter interface {
+	SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// HealthChecker periodically probes the dependencies of the service and reports the services
+// as not serving while a critical one is down, so that load balancers route around the instance
+type HealthChecker struct {
+	checks   []HealthCheck
+	reporter HealthReporter
+	services []string
+	interval time.Duration
+	timeout  time.Duration
+	logger   *zap.Logger
+	// down holds the checks that failed their last probe, to log only when a dependency changes state
+	down map[string]bool
+}
+
+// NewHealthChecker creates a new HealthChecker probing checks every interval, each bounded by
+// timeout, and reporting the status of services to reporter
+func NewHealthChecker(checks []HealthCheck, reporter HealthReporter, services []string, interval, timeout time.Duration, logger *zap.Logger) *HealthChecker {
+	return &HealthChecker{
+		checks:   checks,
+		reporter: reporter,
+		services: services,
+		interval: interval,
+		timeout:  timeout,
+		logger:   logger,
+		down:     make(map[string]bool),
+	}
+}
+
+// Run probes the dependencies every interval until ctx is done
+func (c *HealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
+
+// Check probes every dependency once, reports the status of each dependency and the resulting
+// status of the services, and returns the latter
+func (c *HealthChecker) Check(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	for _, check := range c.checks {
+		err := c.probe(ctx, check)
+		if ctx.Err() != nil {
+			return servingStatus
+		}
+
+		switch {
+		case err != nil && !c.down[check.Name]:
+			c.logger.Error("Dependency is down", zap.String("dependency", check.Name), zap.Bool("critical", check.Critical), zap.Error(err))
+		case err == nil && c.down[check.Name]:
+			c.logger.Info("Dependency recovered", zap.String("dependency", check.Name))
+		}
+		c.down[check.Name] = err != nil
+
+		dependencyStatus := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			dependencyStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if check.Service != "" {
+			c.reporter.SetServingStatus(check.Service, dependencyStatus)
+		}
+		if err != nil && check.Critical {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	for _, service := range c.services {
+		c.reporter.SetServingStatus(service, servingStatus)
+	}
+	return servingStatus
+}
+
+// probe runs check bounded by the timeout
+func (c *HealthChecker) probe(ctx context.Context, check HealthCheck) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	return check.Check(ctx)
+}
@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const healthService = "explore.ExploreService"
+
+type HealthCheckerTestSuite struct {
+	suite.Suite
+	server   *health.Server
+	dbErr    error
+	cacheErr error
+	checker  *HealthChecker
+}
+
+func TestHealthCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckerTestSuite))
+}
+
+func (s *HealthCheckerTestSuite) SetupTest() {
+	s.server = health.NewServer()
+	s.dbErr, s.cacheErr = nil, nil
+	s.checker = NewHealthChecker([]HealthCheck{
+		{Name: "postgres", Service: "explore.db", Critical: true, Check: func(ctx context.Context) error { return s.dbErr }},
+		{Name: "cache", Service: "explore.cache", Check: func(ctx context.Context) error { return s.cacheErr }},
+	}, s.server, []string{"", healthService}, time.Minute, time.Second, zap.NewNop())
+}
+
+// status returns the status the health server reports for service
+func (s *HealthCheckerTestSuite) status(service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := s.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	s.Require().NoError(err)
+	return resp.Status
+}
+
+func (s *HealthCheckerTestSuite) TestCheck_AllUp() {
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.checker.Check(context.Background()))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.status(healthService))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.status("explore.db"))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.status("explore.cache"))
+}
+
+func (s *HealthCheckerTestSuite) TestCheck_CriticalDownStopsServing() {
+	s.dbErr = errors.New("connection refused")
+
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.checker.Check(context.Background()))
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status(""))
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status(healthService))
+}
+
+func (s *HealthCheckerTestSuite) TestCheck_OptionalDownKeepsServing() {
+	s.cacheErr = errors.New("connection refused")
+
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.checker.Check(context.Background()))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.status(healthService))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.status("explore.db"))
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status("explore.cache"))
+}
+
+func (s *HealthCheckerTestSuite) TestCheck_Recovers() {
+	s.dbErr = errors.New("connection refused")
+	s.checker.Check(context.Background())
+
+	s.dbErr = nil
+
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.checker.Check(context.Background()))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.status(healthService))
+}
+
+// watchStream is a Health/Watch stream queueing the statuses sent to the watcher
+type watchStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	statuses chan healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(resp *healthpb.HealthCheckResponse) error {
+	w.statuses <- resp.Status
+	return nil
+}
+
+func (s *HealthCheckerTestSuite) TestWatch_ReportsDependencyTransitions() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.checker.Check(ctx)
+	stream := &watchStream{ctx: ctx, statuses: make(chan healthpb.HealthCheckResponse_ServingStatus, 10)}
+	go func() { _ = s.server.Watch(&healthpb.HealthCheckRequest{Service: "explore.db"}, stream) }()
+
+	next := func() healthpb.HealthCheckResponse_ServingStatus {
+		select {
+		case servingStatus := <-stream.statuses:
+			return servingStatus
+		case <-time.After(time.Second):
+			s.FailNow("no status sent to the watcher")
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+	}
+	s.Equal(healthpb.HealthCheckResponse_SERVING, next())
+
+	s.dbErr = errors.New("connection refused")
+	s.checker.Check(ctx)
+	s.checker.Check(ctx)
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, next())
+
+	s.dbErr = nil
+	s.checker.Check(ctx)
+	s.Equal(healthpb.HealthCheckResponse_SERVING, next())
+	s.Empty(stream.statuses, "unchanged statuses are not resent")
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/providers/cache"
+	repomock "github.com/backend-interview-task/mocks/repository"
+)
+
+type LikeCountReconcilerTestSuite struct {
+	suite.Suite
+	mockExplorerRepo *repomock.ExplorerRepository
+	reconciler       *LikeCountReconciler
+}
+
+func TestLikeCountReconcilerTestSuite(t *testing.T) {
+	suite.Run(t, new(LikeCountReconcilerTestSuite))
+}
+
+func (s *LikeCountReconcilerTestSuite) SetupTest() {
+	s.mockExplorerRepo = new(repomock.ExplorerRepository)
+	s.reconciler = NewLikeCountReconciler(s.mockExplorerRepo, cache.NewNoopCacheProvider(), time.Hour, zap.NewNop())
+}
+
+func (s *LikeCountReconcilerTestSuite) TearDownTest() {
+	s.mockExplorerRepo.AssertExpectations(s.T())
+}
+
+func (s *LikeCountReconcilerTestSuite) TestReconcile_ReturnsCorrected() {
+	s.mockExplorerRepo.EXPECT().ReconcileLikeCounts(mock.Anything).Return(int64(2), nil).Once()
+
+	corrected, err := s.reconciler.Reconcile(context.Background())
+
+	s.NoError(err)
+	s.Equal(int64(2), corrected)
+}
+
+func (s *LikeCountReconcilerTestSuite) TestReconcile_RepositoryError() {
+	s.mockExplorerRepo.EXPECT().ReconcileLikeCounts(mock.Anything).Return(int64(0), errors.New("database timeout")).Once()
+
+	corrected, err := s.reconciler.Reconcile(context.Background())
+
+	s.Error(err)
+	s.Zero(corrected)
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/internal/repository"
+)
+
+// purgeBatchSize caps the number of likes deleted per statement, keeping each delete's locks short
+const purgeBatchSize = 1000
+
+// LikePurger periodically deletes likes older than the configured TTL, on one replica at a time
+type LikePurger struct {
+	repo     repository.ExplorerRepository
+	locks    cache.CacheProvider
+	likeTTL  time.Duration
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewLikePurger creates a new LikePurger deleting likes older than likeTTL every interval
+func NewLikePurger(repo repository.ExplorerRepository, locks cache.CacheProvider, likeTTL, interval time.Duration, logger *zap.Logger) *LikePurger {
+	return &LikePurger{
+		repo:     repo,
+		locks:    locks,
+		likeTTL:  likeTTL,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run purges expired likes every interval until ctx is done
+func (p *LikePurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := runLocked(ctx, p.locks, "like_purger", p.interval, func(ctx context.Context) error {
+				_, err := p.Purge(ctx)
+				return err
+			})
+			if err != nil && ctx.Err() == nil {
+				p.logger.Error("Failed to purge expired likes", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Purge deletes all likes older than the TTL in batches and returns how many were deleted
+func (p *LikePurger) Purge(ctx context.Context) (int64, error) {
+	var total int64
+	for {
+		deleted, err := p.repo.PurgeExpiredLikes(ctx, explorerdb.PurgeExpiredLikesParams{
+			MaxAgeSeconds: int64(p.likeTTL.Seconds()),
+			BatchSize:     purgeBatchSize,
+		})
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+
+		if deleted < purgeBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		p.logger.Info("Purged expired likes", zap.Int64("deleted", total))
+	}
+
+	return total, nil
+}
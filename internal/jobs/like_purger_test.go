@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/providers/cache"
+	repomock "github.com/backend-interview-task/mocks/repository"
+)
+
+type LikePurgerTestSuite struct {
+	suite.Suite
+	mockExplorerRepo *repomock.ExplorerRepository
+	purger           *LikePurger
+}
+
+func TestLikePurgerTestSuite(t *testing.T) {
+	suite.Run(t, new(LikePurgerTestSuite))
+}
+
+func (s *LikePurgerTestSuite) SetupTest() {
+	s.mockExplorerRepo = new(repomock.ExplorerRepository)
+	s.purger = NewLikePurger(s.mockExplorerRepo, cache.NewNoopCacheProvider(), 90*24*time.Hour, time.Hour, zap.NewNop())
+}
+
+func (s *LikePurgerTestSuite) TearDownTest() {
+	s.mockExplorerRepo.AssertExpectations(s.T())
+}
+
+func (s *LikePurgerTestSuite) TestPurge_DeletesInBatches() {
+	params := explorerdb.PurgeExpiredLikesParams{
+		MaxAgeSeconds: 7776000,
+		BatchSize:     purgeBatchSize,
+	}
+
+	s.mockExplorerRepo.EXPECT().PurgeExpiredLikes(mock.Anything, params).Return(int64(purgeBatchSize), nil).Twice()
+	s.mockExplorerRepo.EXPECT().PurgeExpiredLikes(mock.Anything, params).Return(int64(7), nil).Once()
+
+	deleted, err := s.purger.Purge(context.Background())
+
+	s.NoError(err)
+	s.Equal(int64(2*purgeBatchSize+7), deleted)
+}
+
+func (s *LikePurgerTestSuite) TestPurge_RepositoryError() {
+	s.mockExplorerRepo.EXPECT().PurgeExpiredLikes(mock.Anything, mock.Anything).Return(int64(purgeBatchSize), nil).Once()
+	s.mockExplorerRepo.EXPECT().PurgeExpiredLikes(mock.Anything, mock.Anything).Return(int64(0), errors.New("database timeout")).Once()
+
+	deleted, err := s.purger.Purge(context.Background())
+
+	s.Error(err)
+	s.Equal(int64(purgeBatchSize), deleted)
+}
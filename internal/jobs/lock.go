@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/utils"
+)
+
+// runLocked runs a job's tick on one replica per interval: the replica that takes the job's
+// lock runs the tick and keeps the lock until the interval is over, while the others skip
+// their tick. A failed tick releases the lock early, so that another replica can retry it.
+// A tick outlasting the interval may overlap with the next replica to take the lock.
+func runLocked(ctx context.Context, locks cache.CacheProvider, name string, interval time.Duration, tick func(ctx context.Context) error) error {
+	key := utils.LockKey(ctx, name)
+	token, acquired, err := locks.Lock(ctx, key, interval)
+	if err != nil {
+		return fmt.Errorf("take %s lock: %w", name, err)
+	}
+	if !acquired {
+		return nil
+	}
+	if err := tick(ctx); err != nil {
+		_ = locks.Unlock(context.WithoutCancel(ctx), key, token)
+		return err
+	}
+	return nil
+}
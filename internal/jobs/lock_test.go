@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/providers/cache"
+)
+
+type RunLockedTestSuite struct {
+	suite.Suite
+	server *miniredis.Miniredis
+	locks  cache.CacheProvider
+	ctx    context.Context
+}
+
+func TestRunLockedTestSuite(t *testing.T) {
+	suite.Run(t, new(RunLockedTestSuite))
+}
+
+func (s *RunLockedTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	locks, err := cache.NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.locks = locks
+}
+
+// tick returns a tick counting its runs and failing with err
+func tick(runs *int, err error) func(context.Context) error {
+	return func(context.Context) error {
+		*runs++
+		return err
+	}
+}
+
+func (s *RunLockedTestSuite) TestSecondHolderRefusedUntilIntervalIsOver() {
+	var first, second int
+
+	s.NoError(runLocked(s.ctx, s.locks, "job", time.Minute, tick(&first, nil)))
+	s.NoError(runLocked(s.ctx, s.locks, "job", time.Minute, tick(&second, nil)))
+	s.Equal(1, first)
+	s.Zero(second)
+
+	s.server.FastForward(time.Minute)
+	s.NoError(runLocked(s.ctx, s.locks, "job", time.Minute, tick(&second, nil)))
+	s.Equal(1, second)
+}
+
+func (s *RunLockedTestSuite) TestFailedTickReleasesLock() {
+	var first, second int
+
+	s.Error(runLocked(s.ctx, s.locks, "job", time.Minute, tick(&first, errors.New("database timeout"))))
+	s.NoError(runLocked(s.ctx, s.locks, "job", time.Minute, tick(&second, nil)))
+	s.Equal(1, first)
+	s.Equal(1, second)
+}
+
+func (s *RunLockedTestSuite) TestJobsLockSeparately() {
+	var purges, reconciles int
+
+	s.NoError(runLocked(s.ctx, s.locks, "like_purger", time.Minute, tick(&purges, nil)))
+	s.NoError(runLocked(s.ctx, s.locks, "like_count_reconciler", time.Minute, tick(&reconciles, nil)))
+	s.Equal(1, purges)
+	s.Equal(1, reconciles)
+}
+
+func (s *RunLockedTestSuite) TestSkipsTickWhenLockFails() {
+	var runs int
+	s.server.Close()
+
+	s.Error(runLocked(s.ctx, s.locks, "job", time.Minute, tick(&runs, nil)))
+	s.Zero(runs)
+}
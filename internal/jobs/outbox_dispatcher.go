@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/internal/providers/events"
+	"github.com/backend-interview-task/internal/providers/pubsub"
+	"github.com/backend-interview-task/internal/providers/webhook"
+	"github.com/backend-interview-task/internal/repository"
+	"github.com/backend-interview-task/utils"
+)
+
+// outboxBatchSize caps the number of events claimed per drain
+const outboxBatchSize = 100
+
+// OutboxDispatcher periodically drains the outbox to local watchers, the external event bus and webhook endpoints.
+// Events are delivered at least once: one whose delivery succeeded but whose removal
+// from the outbox did not commit is delivered again, carrying the same payload.
+// One replica at a time drains the outbox.
+type OutboxDispatcher struct {
+	repo        repository.ExplorerRepository
+	locks       cache.CacheProvider
+	pubsub      pubsub.PubSubProvider
+	events      events.EventPublisher
+	webhook     webhook.WebhookProvider
+	interval    time.Duration
+	maxAttempts int32
+	logger      *zap.Logger
+}
+
+// NewOutboxDispatcher creates a new OutboxDispatcher draining the outbox every interval and
+// giving up on an event after maxAttempts failed deliveries
+func NewOutboxDispatcher(repo repository.ExplorerRepository, locks cache.CacheProvider, pubsub pubsub.PubSubProvider, events events.EventPublisher, webhook webhook.WebhookProvider, interval time.Duration, maxAttempts int32, logger *zap.Logger) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		repo:        repo,
+		locks:       locks,
+		pubsub:      pubsub,
+		events:      events,
+		webhook:     webhook,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+// Run drains the outbox every interval until ctx is done
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := runLocked(ctx, d.locks, "outbox_dispatcher", d.interval, func(ctx context.Context) error {
+				_, err := d.Dispatch(ctx)
+				return err
+			})
+			if err != nil && ctx.Err() == nil {
+				d.logger.Error("Failed to dispatch outbox events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Dispatch publishes due outbox events until none are left or a drain fails, and returns how many were delivered
+func (d *OutboxDispatcher) Dispatch(ctx context.Context) (int, error) {
+	claim := explorerdb.ClaimOutboxEventsParams{
+		MaxAttempts: d.maxAttempts,
+		BatchSize:   outboxBatchSize,
+	}
+
+	var delivered int
+	for {
+		summary, err := d.repo.DrainOutbox(ctx, claim, d.deliver)
+		if err != nil {
+			return delivered, err
+		}
+		delivered += summary.Delivered
+
+		// A short batch means the outbox is drained; retried events wait for their backoff
+		if summary.Delivered+summary.Retried+summary.DeadLettered < outboxBatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// deliver sends a single outbox event to its webhook endpoint, or publishes it to its topic.
+// The event bus goes first so a failed publish is retried without pushing the event to watchers twice.
+func (d *OutboxDispatcher) deliver(ctx context.Context, event explorerdb.Outbox) error {
+	if endpoint, ok := utils.WebhookEndpoint(event.Topic); ok {
+		return d.webhook.Send(ctx, endpoint, event.Payload)
+	}
+	if err := d.events.Publish(ctx, event.Topic, event.Payload); err != nil {
+		return err
+	}
+	return d.pubsub.Publish(ctx, event.Topic, event.Payload)
+}
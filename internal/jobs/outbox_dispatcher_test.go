@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/providers/cache"
+	eventsmock "github.com/backend-interview-task/mocks/providers/events"
+	pubsubmock "github.com/backend-interview-task/mocks/providers/pubsub"
+	webhookmock "github.com/backend-interview-task/mocks/providers/webhook"
+	repomock "github.com/backend-interview-task/mocks/repository"
+)
+
+type OutboxDispatcherTestSuite struct {
+	suite.Suite
+	mockExplorerRepo *repomock.ExplorerRepository
+	mockPubSub       *pubsubmock.PubSubProvider
+	mockEvents       *eventsmock.EventPublisher
+	mockWebhook      *webhookmock.WebhookProvider
+	dispatcher       *OutboxDispatcher
+}
+
+func TestOutboxDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxDispatcherTestSuite))
+}
+
+func (s *OutboxDispatcherTestSuite) SetupTest() {
+	s.mockExplorerRepo = new(repomock.ExplorerRepository)
+	s.mockPubSub = new(pubsubmock.PubSubProvider)
+	s.mockEvents = new(eventsmock.EventPublisher)
+	s.mockWebhook = new(webhookmock.WebhookProvider)
+	s.dispatcher = NewOutboxDispatcher(s.mockExplorerRepo, cache.NewNoopCacheProvider(), s.mockPubSub, s.mockEvents, s.mockWebhook, time.Second, 10, zap.NewNop())
+}
+
+func (s *OutboxDispatcherTestSuite) TearDownTest() {
+	s.mockExplorerRepo.AssertExpectations(s.T())
+	s.mockPubSub.AssertExpectations(s.T())
+	s.mockEvents.AssertExpectations(s.T())
+	s.mockWebhook.AssertExpectations(s.T())
+}
+
+func (s *OutboxDispatcherTestSuite) TestDispatch_PublishesClaimedEvents() {
+	claim := explorerdb.ClaimOutboxEventsParams{MaxAttempts: 10, BatchSize: outboxBatchSize}
+	event := explorerdb.Outbox{ID: 1, Topic: "newlikes:user1", Payload: []byte("event")}
+
+	s.mockEvents.EXPECT().Publish(mock.Anything, event.Topic, event.Payload).Return(nil).Once()
+	s.mockPubSub.EXPECT().Publish(mock.Anything, event.Topic, event.Payload).Return(nil).Once()
+	s.mockExplorerRepo.EXPECT().DrainOutbox(mock.Anything, claim, mock.Anything).
+		RunAndReturn(func(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+			s.Require().NoError(deliver(ctx, event))
+			return models.DrainSummary{Delivered: 1}, nil
+		}).Once()
+
+	delivered, err := s.dispatcher.Dispatch(context.Background())
+
+	s.NoError(err)
+	s.Equal(1, delivered)
+}
+
+func (s *OutboxDispatcherTestSuite) TestDispatch_DrainsFullBatchesAgain() {
+	s.mockExplorerRepo.EXPECT().DrainOutbox(mock.Anything, mock.Anything, mock.Anything).
+		Return(models.DrainSummary{Delivered: outboxBatchSize - 1, Retried: 1}, nil).Once()
+	s.mockExplorerRepo.EXPECT().DrainOutbox(mock.Anything, mock.Anything, mock.Anything).
+		Return(models.DrainSummary{Delivered: 3}, nil).Once()
+
+	delivered, err := s.dispatcher.Dispatch(context.Background())
+
+	s.NoError(err)
+	s.Equal(outboxBatchSize+2, delivered)
+}
+
+func (s *OutboxDispatcherTestSuite) TestDispatch_RepositoryError() {
+	s.mockExplorerRepo.EXPECT().DrainOutbox(mock.Anything, mock.Anything, mock.Anything).
+		Return(models.DrainSummary{}, errors.New("database timeout")).Once()
+
+	delivered, err := s.dispatcher.Dispatch(context.Background())
+
+	s.Error(err)
+	s.Zero(delivered)
+}
+
+func (s *OutboxDispatcherTestSuite) TestDispatch_SendsWebhookEvents() {
+	event := explorerdb.Outbox{ID: 1, Topic: "webhook:https://partner.example.com/match", Payload: []byte(`{}`)}
+
+	s.mockWebhook.EXPECT().Send(mock.Anything, "https://partner.example.com/match", event.Payload).Return(nil).Once()
+	s.mockExplorerRepo.EXPECT().DrainOutbox(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+			s.Require().NoError(deliver(ctx, event))
+			return models.DrainSummary{Delivered: 1}, nil
+		}).Once()
+
+	delivered, err := s.dispatcher.Dispatch(context.Background())
+
+	s.NoError(err)
+	s.Equal(1, delivered)
+	s.mockPubSub.AssertNotCalled(s.T(), "Publish")
+	s.mockEvents.AssertNotCalled(s.T(), "Publish")
+}
+
+func (s *OutboxDispatcherTestSuite) TestDispatch_EventBusErrorSkipsWatchers() {
+	event := explorerdb.Outbox{ID: 1, Topic: "newlikes:user1", Payload: []byte("event")}
+
+	s.mockEvents.EXPECT().Publish(mock.Anything, event.Topic, event.Payload).Return(errors.New("broker unavailable")).Once()
+	s.mockExplorerRepo.EXPECT().DrainOutbox(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+			s.Require().Error(deliver(ctx, event))
+			return models.DrainSummary{Retried: 1}, nil
+		}).Once()
+
+	delivered, err := s.dispatcher.Dispatch(context.Background())
+
+	s.NoError(err)
+	s.Zero(delivered)
+	s.mockPubSub.AssertNotCalled(s.T(), "Publish")
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+// Interceptor is a pair of unary and stream interceptors applying the same concern, such as
+// authentication, to both kinds of RPC
+type Interceptor struct {
+	Unary  grpc.UnaryServerInterceptor
+	Stream grpc.StreamServerInterceptor
+}
+
+// Chain assembles named interceptors into the gRPC server's interceptor chains, in an order
+// given by configuration. Interceptors are registered under their name, with a zero Interceptor
+// for those turned off, so that the order can name every interceptor whether or not it runs.
+type Chain struct {
+	interceptors map[string]Interceptor
+	names        []string
+}
+
+// NewChain creates an empty Chain
+func NewChain() *Chain {
+	return &Chain{interceptors: make(map[string]Interceptor)}
+}
+
+// Register makes interceptor available under name. A zero interceptor registers the name of
+// one that is turned off.
+func (c *Chain) Register(name string, interceptor Interceptor) {
+	if _, ok := c.interceptors[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.interceptors[name] = interceptor
+}
+
+// ServerOptions returns the server options chaining the registered interceptors in order, the
+// first being the outermost
+func (c *Chain) ServerOptions(order []string) ([]grpc.ServerOption, error) {
+	unary, stream, err := c.Build(order)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}, nil
+}
+
+// Build returns the unary and stream interceptors that are turned on, in order. The order must
+// name every registered interceptor exactly once and nothing else, so that a typo cannot
+// silently leave out authentication or rate limiting.
+func (c *Chain) Build(order []string) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	seen := make(map[string]bool, len(order))
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	for _, name := range order {
+		interceptor, ok := c.interceptors[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown interceptor %q", name)
+		}
+		if seen[name] {
+			return nil, nil, fmt.Errorf("interceptor %q is listed more than once", name)
+		}
+		seen[name] = true
+
+		if interceptor.Unary != nil {
+			unary = append(unary, interceptor.Unary)
+		}
+		if interceptor.Stream != nil {
+			stream = append(stream, interceptor.Stream)
+		}
+	}
+	for _, name := range c.names {
+		if !seen[name] {
+			return nil, nil, fmt.Errorf("interceptor %q is missing from the order", name)
+		}
+	}
+	return unary, stream, nil
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+)
+
+type ChainTestSuite struct {
+	suite.Suite
+	chain *Chain
+	calls []string
+}
+
+func TestChainTestSuite(t *testing.T) {
+	suite.Run(t, new(ChainTestSuite))
+}
+
+func (s *ChainTestSuite) SetupTest() {
+	s.calls = nil
+	s.chain = NewChain()
+	s.chain.Register("first", s.recording("first"))
+	s.chain.Register("second", s.recording("second"))
+	s.chain.Register("off", Interceptor{})
+}
+
+// recording returns an interceptor noting its name when a call goes through it
+func (s *ChainTestSuite) recording(name string) Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			s.calls = append(s.calls, name)
+			return handler(ctx, req)
+		},
+		Stream: func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			s.calls = append(s.calls, name)
+			return handler(srv, stream)
+		},
+	}
+}
+
+func (s *ChainTestSuite) TestBuild_KeepsOrderAndSkipsTurnedOff() {
+	unary, stream, err := s.chain.Build([]string{"second", "off", "first"})
+	s.Require().NoError(err)
+	s.Require().Len(unary, 2)
+	s.Require().Len(stream, 2)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	for _, interceptor := range unary {
+		_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	}
+	streamHandler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+	for _, interceptor := range stream {
+		_ = interceptor(nil, nil, &grpc.StreamServerInfo{}, streamHandler)
+	}
+
+	s.Equal([]string{"second", "first", "second", "first"}, s.calls)
+}
+
+func (s *ChainTestSuite) TestServerOptions_ChainsBoth() {
+	options, err := s.chain.ServerOptions([]string{"first", "second", "off"})
+
+	s.NoError(err)
+	s.Len(options, 2)
+}
+
+func (s *ChainTestSuite) TestServerOptions_UnknownInterceptor() {
+	_, err := s.chain.ServerOptions([]string{"first", "second", "off", "auht"})
+
+	s.EqualError(err, `unknown interceptor "auht"`)
+}
+
+func (s *ChainTestSuite) TestServerOptions_DuplicateInterceptor() {
+	_, err := s.chain.ServerOptions([]string{"first", "second", "first", "off"})
+
+	s.EqualError(err, `interceptor "first" is listed more than once`)
+}
+
+func (s *ChainTestSuite) TestServerOptions_MissingInterceptor() {
+	_, err := s.chain.ServerOptions([]string{"first", "off"})
+
+	s.EqualError(err, `interceptor "second" is missing from the order`)
+}
@@ -1,6 +1,254 @@
 package models
 
+import (
+	"errors"
+	"time"
+)
+
+// PageRequest identifies the page a list query should return.
+// A non-zero Size takes precedence over the size carried by Token.
+// A non-zero Since or Until restricts results to [Since, Until) in unix seconds.
+// Ascending only applies to the first page, later pages follow the order carried by Token.
+// A non-zero Page selects the page by its 1-based number instead of Token, for offset
+// pagination, and the page is returned without tokens.
+// ByPopularity ranks listings that support it by popularity score instead of time, and like
+// Ascending only applies to the first page.
+// IncludePassed lists new likers the recipient passed on too, who are otherwise left out.
+type PageRequest struct {
+	Token         string
+	Size          int
+	Since         int64
+	Until         int64
+	Ascending     bool
+	Page          int
+	ByPopularity  bool
+	IncludePassed bool
+}
+
+// HasTimeRange reports whether the request restricts results to a time range
+func (p PageRequest) HasTimeRange() bool {
+	return p.Since > 0 || p.Until > 0
+}
+
+// Offset returns the number of rows before the requested page of limit rows in offset pagination
+func (p PageRequest) Offset(limit int) int {
+	if p.Page <= 1 {
+		return 0
+	}
+	return (p.Page - 1) * limit
+}
+
+// PageTokens are the pagination tokens of the pages around a page of a listing, empty when the
+// listing has no page on that side
+type PageTokens struct {
+	Next string
+	Prev string
+}
+
+// LikersRange selects a page of likers from the likers index by like time in unix seconds.
+// After and Before are exclusive bounds, zero leaves that side unbounded.
+type LikersRange struct {
+	After     int64
+	Before    int64
+	Ascending bool
+	Limit     int
+}
+
 type Liker struct {
 	ActorID   string
 	Timestamp int64
+	LikedBack bool // Whether the recipient has liked the actor in return
+}
+
+type Recipient struct {
+	RecipientID string
+	Timestamp   int64
+}
+
+type Match struct {
+	UserID    string
+	MatchedAt int64
+}
+
+type DecisionResult struct {
+	ActorUserID     string
+	RecipientUserID string
+	MutualLikes     bool
+}
+
+// RecordedDecision is how recording a single decision turned out
+type RecordedDecision struct {
+	MutualLikes bool
+	LikesDelta  int64 // How far the decision moved the recipient's like count: 1, -1 or 0
+	Created     bool  // False when the decision replaced an earlier one on the same recipient
+	LikedBefore bool  // Whether the decision it replaced was a like
+	MatchEnded  bool  // Whether the decision, a pass, ended a match between the two users
+	Timestamp   int64 // Unix time the decision was made at, as the likers listings show it
+}
+
+// RemovedDecision is what withdrawing a decision changed
+type RemovedDecision struct {
+	Deleted       bool // False when there was no decision to withdraw
+	WasMutualLike bool // Whether the withdrawn decision was half of a mutual like
+	MatchEnded    bool // Whether withdrawing it ended a match between the two users
+}
+
+// DecisionError is the error of the decision at Index of a batch, which stopped the whole batch
+type DecisionError struct {
+	Index int
+	Err   error
+}
+
+func (e *DecisionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecisionError) Unwrap() error {
+	return e.Err
+}
+
+// ErrBatchTooLarge is the error of a batch of decisions the store cannot write in one transaction
+var ErrBatchTooLarge = errors.New("batch too large to write in one transaction")
+
+// IngestSummary counts the outcome of a bulk decision ingestion
+type IngestSummary struct {
+	Created     int
+	Updated     int
+	MutualLikes int
+}
+
+// ImportedDecision is a decision carried over from another system, with the time it was made there
+type ImportedDecision struct {
+	ActorUserID     string
+	RecipientUserID string
+	LikedRecipient  bool
+	CreatedAt       time.Time
+}
+
+// ImportSummary counts the outcome of a bulk decision import. Skipped rows were older than the
+// decision already stored on the same pair, or repeated a pair later in the same batch.
+type ImportSummary struct {
+	Rows    int
+	Created int
+	Updated int
+	Skipped int
+	Matches int
+}
+
+// Add returns the sum of both summaries
+func (s ImportSummary) Add(other ImportSummary) ImportSummary {
+	return ImportSummary{
+		Rows:    s.Rows + other.Rows,
+		Created: s.Created + other.Created,
+		Updated: s.Updated + other.Updated,
+		Skipped: s.Skipped + other.Skipped,
+		Matches: s.Matches + other.Matches,
+	}
+}
+
+// OutboxEvent is published to Topic once the change it describes has been committed
+type OutboxEvent struct {
+	Topic   string
+	Payload []byte
+}
+
+// DecisionEvents are the outbox events a decision emits, depending on how it turns out
+type DecisionEvents struct {
+	NewLike   *OutboxEvent  // Emitted when the decision is a like the recipient has not returned
+	Matched   []OutboxEvent // Emitted when the decision completes a mutual like
+	Withdrawn []OutboxEvent // Emitted when the decision turns a like into a pass
+}
+
+// DecisionChange is a committed change to the decision of Actor on Recipient, as announced by
+// the database. Deleted decisions have LikedRecipient false.
+type DecisionChange struct {
+	ActorUserID     string
+	RecipientUserID string
+	LikedRecipient  bool
+	LikedBefore     bool  // Whether the decision was a like before the change
+	Mutual          bool  // Whether the change left the two users liking each other
+	CreatedAt       int64 // When the decision was made, in unix seconds
+}
+
+// NewLike reports whether the change is a like the recipient has not returned, replacing no earlier like
+func (c DecisionChange) NewLike() bool {
+	return c.LikedRecipient && !c.LikedBefore && !c.Mutual
+}
+
+// DrainSummary counts the outcome of one outbox drain
+type DrainSummary struct {
+	Delivered    int
+	Retried      int
+	DeadLettered int
+}
+
+// AuditEvent records a call that changed users' data, or an admin call. CallerID is the
+// authenticated user or service, empty when the call was not authenticated, and UserIDs are the
+// users the call was about. Outcome is the gRPC code the call ended with.
+type AuditEvent struct {
+	ID              int64
+	OccurredAt      int64 // When the call was made, in unix seconds
+	Action          string
+	CallerID        string
+	CallerIsService bool
+	ClientAddress   string
+	RequestID       string
+	UserIDs         []string
+	Outcome         string
+	Details         []byte // The call's request as JSON
+}
+
+// AuditFilter narrows the audit events listed. UserID matches events made by or about the user.
+// Since and Until restrict events to [Since, Until) in unix seconds; zero leaves a side unbounded.
+type AuditFilter struct {
+	UserID string
+	Action string
+	Since  int64
+	Until  int64
+}
+
+// UserDecision is a decision listed for an admin inspecting a user, made by or on the user
+type UserDecision struct {
+	ID              int64
+	ActorUserID     string
+	RecipientUserID string
+	LikedRecipient  bool
+	Timestamp       int64 // When the decision was last changed, in unix seconds
+}
+
+// PurgeSummary counts what purging a user's data deleted. Matches are counted once per match.
+// Counterparts are the other users of the deleted decisions, whose listings and counts the
+// purge changed.
+type PurgeSummary struct {
+	Decisions    int64
+	Matches      int64
+	Blocks       int64
+	Counterparts []string
+}
+
+// ErasedUserID replaces the ID of an erased user in the audit trail and in reports. It holds a
+// space, so that no valid user ID can be mistaken for it.
+const ErasedUserID = "erased user"
+
+// ErasureReceipt records the erasure of a user's data. SubjectHash is the hex SHA-256 hash of the
+// erased user's ID, the only trace of it kept. Reports and AuditEvents count the reports and audit
+// events the user's ID was scrubbed from.
+type ErasureReceipt struct {
+	ID          int64
+	SubjectHash string
+	ErasedAt    int64 // In unix seconds
+	PurgeSummary
+	Reports     int64
+	AuditEvents int64
+}
+
+// ServiceStats sizes the service's tables. Decisions, Matches and Blocks are estimated from the
+// table statistics, and Matches counts every match once.
+type ServiceStats struct {
+	Decisions                int64
+	Likes                    int64
+	Matches                  int64
+	Blocks                   int64
+	PendingOutboxEvents      int64
+	DeadLetteredOutboxEvents int64
 }
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+// apiKey is a configured API key, kept as the digest of the key
+type apiKey struct {
+	digest []byte
+	caller utils.Caller
+}
+
+// apiKeyAuthenticator implements the Authenticator interface by matching API keys against the
+// digests of the configured ones
+type apiKeyAuthenticator struct {
+	keys []apiKey
+}
+
+// NewAPIKeyAuthenticator creates an Authenticator accepting the given API keys. Each key's name
+// is its caller's user ID, and keys granted serviceRole are service accounts.
+func NewAPIKeyAuthenticator(keys []config.APIKey, serviceRole string) (Authenticator, error) {
+	authenticator := &apiKeyAuthenticator{keys: make([]apiKey, 0, len(keys))}
+	names := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.Name == "" {
+			return nil, errors.New("API key name is required")
+		}
+		if names[key.Name] {
+			return nil, fmt.Errorf("duplicate API key name %q", key.Name)
+		}
+		names[key.Name] = true
+
+		digest, err := hex.DecodeString(strings.TrimSpace(key.SHA256))
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("API key %q: sha256 must be a hex encoded SHA-256 digest", key.Name)
+		}
+		authenticator.keys = append(authenticator.keys, apiKey{
+			digest: digest,
+			caller: utils.Caller{
+				UserID:  key.Name,
+				Service: serviceRole != "" && slices.Contains(key.Roles, serviceRole),
+			},
+		})
+	}
+	return authenticator, nil
+}
+
+// Authenticate returns the caller of the API key matching token
+func (a *apiKeyAuthenticator) Authenticate(_ context.Context, token string) (utils.Caller, error) {
+	digest := sha256.Sum256([]byte(token))
+	for _, key := range a.keys {
+		if subtle.ConstantTimeCompare(digest[:], key.digest) == 1 {
+			return key.caller, nil
+		}
+	}
+	return utils.Caller{}, errors.New("unknown API key")
+}
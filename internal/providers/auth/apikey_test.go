@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type APIKeyAuthenticatorTestSuite struct {
+	suite.Suite
+	auth Authenticator
+}
+
+func TestAPIKeyAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyAuthenticatorTestSuite))
+}
+
+func digest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *APIKeyAuthenticatorTestSuite) SetupTest() {
+	var err error
+	s.auth, err = NewAPIKeyAuthenticator([]config.APIKey{
+		{Name: "nightly-digest", SHA256: digest("digest-key"), Roles: []string{"service"}},
+		{Name: "reporting", SHA256: digest("reporting-key")},
+	}, "service")
+	s.Require().NoError(err)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate() {
+	caller, err := s.auth.Authenticate(context.Background(), "digest-key")
+	s.NoError(err)
+	s.Equal(utils.Caller{UserID: "nightly-digest", Service: true}, caller)
+
+	caller, err = s.auth.Authenticate(context.Background(), "reporting-key")
+	s.NoError(err)
+	s.Equal(utils.Caller{UserID: "reporting"}, caller)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate_UnknownKey() {
+	_, err := s.auth.Authenticate(context.Background(), "guessed-key")
+
+	s.Error(err)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestNewAPIKeyAuthenticator_InvalidKeys() {
+	invalid := map[string][]config.APIKey{
+		"no name":        {{SHA256: digest("key")}},
+		"duplicate name": {{Name: "jobs", SHA256: digest("a")}, {Name: "jobs", SHA256: digest("b")}},
+		"plaintext key":  {{Name: "jobs", SHA256: "digest-key"}},
+	}
+	for name, keys := range invalid {
+		_, err := NewAPIKeyAuthenticator(keys, "service")
+		s.Error(err, name)
+	}
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestLookup() {
+	authenticators := Authenticators{SchemeBearer: s.auth}
+
+	_, credentials, ok := authenticators.Lookup("bearer token123")
+	s.True(ok)
+	s.Equal("token123", credentials)
+
+	_, _, ok = authenticators.Lookup("ApiKey key123")
+	s.False(ok)
+	_, _, ok = authenticators.Lookup("Bearer ")
+	s.False(ok)
+}
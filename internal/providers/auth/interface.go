@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// The authorization schemes credentials are presented with
+const (
+	SchemeBearer = "Bearer"
+	SchemeAPIKey = "ApiKey"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (utils.Caller, error)
+}
+
+// Authenticators maps each accepted authorization scheme to the Authenticator of its credentials
+type Authenticators map[string]Authenticator
+
+// Schemes returns the accepted schemes, Bearer first, for WWW-Authenticate challenges
+func (a Authenticators) Schemes() []string {
+	var schemes []string
+	for _, scheme := range []string{SchemeBearer, SchemeAPIKey} {
+		if a[scheme] != nil {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes
+}
+
+// Lookup splits an authorization header value into its scheme, matched case-insensitively, and
+// credentials, and returns the Authenticator of that scheme
+func (a Authenticators) Lookup(authorization string) (Authenticator, string, bool) {
+	scheme, credentials, ok := strings.Cut(authorization, " ")
+	if !ok || credentials == "" {
+		return nil, "", false
+	}
+	for name, authenticator := range a {
+		if strings.EqualFold(name, scheme) {
+			return authenticator, credentials, true
+		}
+	}
+	return nil, "", false
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+// jwtAuthenticator implements the Authenticator interface by verifying JWTs signed with one of
+// the keys published at the configured JWKS URL
+type jwtAuthenticator struct {
+	keys        *jwks
+	parser      *jwt.Parser
+	rolesClaim  string
+	serviceRole string
+}
+
+// NewJWTAuthenticator creates an Authenticator accepting JWTs issued by cfg.Issuer, for
+// cfg.Audience when set. Their subject is the caller's user ID, and tokens granted
+// cfg.ServiceRole in their cfg.RolesClaim claim are service accounts.
+func NewJWTAuthenticator(cfg config.AuthConfig, client *http.Client, logger *zap.Logger) Authenticator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &jwtAuthenticator{
+		keys:        newJWKS(cfg.JWKSURL, client, cfg.JWKSRefreshInterval, logger),
+		parser:      jwt.NewParser(options...),
+		rolesClaim:  cfg.RolesClaim,
+		serviceRole: cfg.ServiceRole,
+	}
+}
+
+// Authenticate verifies the token and returns the caller it was issued to
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, token string) (utils.Caller, error) {
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return a.keys.key(ctx, kid)
+	}); err != nil {
+		return utils.Caller{}, fmt.Errorf("invalid token: %w", err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return utils.Caller{}, errors.New("invalid token: missing subject")
+	}
+	return utils.Caller{
+		UserID:  subject,
+		Service: a.serviceRole != "" && slices.Contains(roles(claims[a.rolesClaim]), a.serviceRole),
+	}, nil
+}
+
+// roles reads a roles claim, either a list of strings or a space separated string as scopes are
+func roles(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		roles := make([]string, 0, len(v))
+		for _, role := range v {
+			if s, ok := role.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type JWTAuthenticatorTestSuite struct {
+	suite.Suite
+	rsaKey  *rsa.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	server  *httptest.Server
+	fetches atomic.Int32
+	auth    Authenticator
+}
+
+func TestJWTAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, new(JWTAuthenticatorTestSuite))
+}
+
+func (s *JWTAuthenticatorTestSuite) SetupTest() {
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	encode := func(n *big.Int) string { return base64.RawURLEncoding.EncodeToString(n.Bytes()) }
+	set := map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa1", "use": "sig", "n": encode(s.rsaKey.N), "e": encode(big.NewInt(int64(s.rsaKey.E)))},
+		{"kty": "EC", "kid": "ec1", "crv": "P-256", "x": encode(s.ecKey.X), "y": encode(s.ecKey.Y)},
+		{"kty": "RSA", "kid": "enc1", "use": "enc", "n": encode(s.rsaKey.N), "e": "AQAB"},
+	}}
+	s.fetches.Store(0)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+
+	s.auth = NewJWTAuthenticator(config.AuthConfig{
+		Issuer:              "https://auth.example.com",
+		Audience:            "explore",
+		JWKSURL:             s.server.URL,
+		JWKSRefreshInterval: time.Hour,
+		RolesClaim:          "roles",
+		ServiceRole:         "service",
+	}, s.server.Client(), zap.NewNop())
+}
+
+func (s *JWTAuthenticatorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// claims returns valid claims for subject
+func claims(subject string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": "https://auth.example.com",
+		"aud": "explore",
+		"sub": subject,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (s *JWTAuthenticatorTestSuite) sign(method jwt.SigningMethod, kid string, key any, c jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, c)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *JWTAuthenticatorTestSuite) TestAuthenticate_RSA() {
+	caller, err := s.auth.Authenticate(context.Background(), s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, claims("user123")))
+
+	s.NoError(err)
+	s.Equal(utils.Caller{UserID: "user123"}, caller)
+}
+
+func (s *JWTAuthenticatorTestSuite) TestAuthenticate_EC() {
+	caller, err := s.auth.Authenticate(context.Background(), s.sign(jwt.SigningMethodES256, "ec1", s.ecKey, claims("user123")))
+
+	s.NoError(err)
+	s.Equal(utils.Caller{UserID: "user123"}, caller)
+}
+
+func (s *JWTAuthenticatorTestSuite) TestAuthenticate_ServiceRole() {
+	listed := claims("indexer")
+	listed["roles"] = []string{"reader", "service"}
+	scoped := claims("indexer")
+	scoped["roles"] = "reader service"
+	other := claims("user123")
+	other["roles"] = []string{"reader"}
+
+	for token, want := range map[string]bool{
+		s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, listed): true,
+		s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, scoped): true,
+		s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, other):  false,
+	} {
+		caller, err := s.auth.Authenticate(context.Background(), token)
+		s.Require().NoError(err)
+		s.Equal(want, caller.Service)
+	}
+}
+
+func (s *JWTAuthenticatorTestSuite) TestAuthenticate_KeysAreCached() {
+	for range 3 {
+		_, err := s.auth.Authenticate(context.Background(), s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, claims("user123")))
+		s.Require().NoError(err)
+	}
+
+	s.Equal(int32(1), s.fetches.Load())
+}
+
+func (s *JWTAuthenticatorTestSuite) TestAuthenticate_Rejects() {
+	expired := claims("user123")
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	otherIssuer := claims("user123")
+	otherIssuer["iss"] = "https://evil.example.com"
+	otherAudience := claims("user123")
+	otherAudience["aud"] = "billing"
+	noExpiry := claims("user123")
+	delete(noExpiry, "exp")
+
+	tokens := map[string]string{
+		"expired":        s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, expired),
+		"other issuer":   s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, otherIssuer),
+		"other audience": s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, otherAudience),
+		"no expiry":      s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, noExpiry),
+		"no subject":     s.sign(jwt.SigningMethodRS256, "rsa1", s.rsaKey, claims("")),
+		"unknown key":    s.sign(jwt.SigningMethodRS256, "rsa2", s.rsaKey, claims("user123")),
+		"encryption key": s.sign(jwt.SigningMethodRS256, "enc1", s.rsaKey, claims("user123")),
+		"symmetric":      s.sign(jwt.SigningMethodHS256, "rsa1", []byte("secret"), claims("user123")),
+		"malformed":      "not-a-jwt",
+	}
+	for name, token := range tokens {
+		_, err := s.auth.Authenticate(context.Background(), token)
+		s.Error(err, name)
+	}
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/models"
+)
+
+// breakerCacheProvider guards the remote cache with a circuit breaker, so that while the cache
+// is slow or down lookups and fills fail straight away and requests go to the DB instead of
+// waiting on the cache. Each guarded call is bounded by a timeout, which is what turns a slow
+// cache into failures.
+// Invalidations, index updates and unlocks always reach the remote cache, since skipping
+// them would leave stale values or held locks behind once the cache recovers.
+type breakerCacheProvider struct {
+	CacheProvider
+	breaker *gobreaker.CircuitBreaker
+	timeout time.Duration
+}
+
+// NewBreakerCacheProvider puts a circuit breaker configured by cfg in front of remote
+func NewBreakerCacheProvider(remote CacheProvider, cfg config.BreakerConfig, logger *zap.Logger) CacheProvider {
+	return &breakerCacheProvider{
+		CacheProvider: remote,
+		breaker: gobreaker.NewCircuitBreaker(gobreaker.Settings{
+			Name:        "cache",
+			MaxRequests: cfg.HalfOpenRequests,
+			Timeout:     cfg.OpenTimeout,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= cfg.MaxFailures
+			},
+			// A caller that went away says nothing about the health of the cache
+			IsSuccessful: func(err error) bool {
+				return err == nil || errors.Is(err, context.Canceled)
+			},
+			OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+				cacheBreakerState.Set(float64(to))
+				logger.Warn("Cache circuit breaker changed state", zap.String("from", from.String()), zap.String("to", to.String()))
+			},
+		}),
+		timeout: cfg.Timeout,
+	}
+}
+
+// call runs fn against the remote cache through the breaker, bounded by the call timeout
+func (c *breakerCacheProvider) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := c.breaker.Execute(func() (interface{}, error) {
+		if c.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+		}
+		return nil, fn(ctx)
+	})
+	return err
+}
+
+// Get reads a value from the remote cache unless the breaker is open
+func (c *breakerCacheProvider) Get(ctx context.Context, key string) (string, error) {
+	var val string
+	err := c.call(ctx, func(ctx context.Context) error {
+		var err error
+		val, err = c.CacheProvider.Get(ctx, key)
+		return err
+	})
+	return val, err
+}
+
+// Set stores a value in the remote cache unless the breaker is open
+func (c *breakerCacheProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.call(ctx, func(ctx context.Context) error {
+		return c.CacheProvider.Set(ctx, key, value, expiration)
+	})
+}
+
+// GetMany reads several values from the remote cache unless the breaker is open
+func (c *breakerCacheProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	var values []string
+	err := c.call(ctx, func(ctx context.Context) error {
+		var err error
+		values, err = c.CacheProvider.GetMany(ctx, keys...)
+		return err
+	})
+	return values, err
+}
+
+// SetMany stores several values in the remote cache unless the breaker is open
+func (c *breakerCacheProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	return c.call(ctx, func(ctx context.Context) error {
+		return c.CacheProvider.SetMany(ctx, values, expiration)
+	})
+}
+
+// GetJSON reads a JSON value through Get, so that values that fail to decode do not trip the breaker
+func (c *breakerCacheProvider) GetJSON(ctx context.Context, key string, out any) (bool, error) {
+	raw, err := c.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return decodeJSON(raw, out)
+}
+
+// SetJSON marshals a value to JSON and stores it through Set
+func (c *breakerCacheProvider) SetJSON(ctx context.Context, key string, val any, ttl time.Duration) error {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, key, string(b), ttl)
+}
+
+// GetLikersPage reads a page of the recipient's likers unless the breaker is open
+func (c *breakerCacheProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error) {
+	var (
+		likers []models.Liker
+		built  bool
+	)
+	err := c.call(ctx, func(ctx context.Context) error {
+		var err error
+		likers, built, err = c.CacheProvider.GetLikersPage(ctx, recipient, page)
+		return err
+	})
+	return likers, built, err
+}
+
+// SetLikersIndex builds the recipient's likers index unless the breaker is open
+func (c *breakerCacheProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error {
+	return c.call(ctx, func(ctx context.Context) error {
+		return c.CacheProvider.SetLikersIndex(ctx, recipient, likers, ttl)
+	})
+}
+
+// TrackHotRecipient counts a request for the recipient unless the breaker is open
+func (c *breakerCacheProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error {
+	return c.call(ctx, func(ctx context.Context) error {
+		return c.CacheProvider.TrackHotRecipient(ctx, recipient, at)
+	})
+}
+
+// HotRecipients reads the most requested recipients of the window unless the breaker is open
+func (c *breakerCacheProvider) HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error) {
+	var recipients []string
+	err := c.call(ctx, func(ctx context.Context) error {
+		var err error
+		recipients, err = c.CacheProvider.HotRecipients(ctx, window, limit)
+		return err
+	})
+	return recipients, err
+}
+
+// Lock takes the lock unless the breaker is open, in which case the lock is not taken
+func (c *breakerCacheProvider) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	var (
+		token    string
+		acquired bool
+	)
+	err := c.call(ctx, func(ctx context.Context) error {
+		var err error
+		token, acquired, err = c.CacheProvider.Lock(ctx, key, ttl)
+		return err
+	})
+	return token, acquired, err
+}
+
+// Allow admits a request against the rate limit under key unless the breaker is open
+func (c *breakerCacheProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error) {
+	var (
+		allowed    bool
+		retryAfter time.Duration
+	)
+	err := c.call(ctx, func(ctx context.Context) error {
+		var err error
+		allowed, retryAfter, err = c.CacheProvider.Allow(ctx, key, limit, period, burst)
+		return err
+	})
+	return allowed, retryAfter, err
+}
+
+// SeedLikersCount seeds the recipient's likers count unless the breaker is open
+func (c *breakerCacheProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	return c.call(ctx, func(ctx context.Context) error {
+		return c.CacheProvider.SeedLikersCount(ctx, recipient, count, ttl)
+	})
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// blockingProvider stands in for a cache that hangs until the caller gives up
+type blockingProvider struct {
+	CacheProvider
+}
+
+func (blockingProvider) Get(ctx context.Context, key string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+type BreakerTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	remote   CacheProvider
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestBreakerTestSuite(t *testing.T) {
+	suite.Run(t, new(BreakerTestSuite))
+}
+
+func (s *BreakerTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	remote, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.remote = remote
+	s.provider = NewBreakerCacheProvider(remote, config.BreakerConfig{
+		MaxFailures:      2,
+		Timeout:          time.Second,
+		OpenTimeout:      50 * time.Millisecond,
+		HalfOpenRequests: 1,
+	}, zap.NewNop())
+}
+
+func (s *BreakerTestSuite) trip() {
+	s.server.SetError("LOADING Redis is loading the dataset in memory")
+	for i := 0; i < 2; i++ {
+		_, err := s.provider.Get(s.ctx, "key")
+		s.Require().Error(err)
+	}
+	s.server.SetError("")
+}
+
+func (s *BreakerTestSuite) TestOpensAfterConsecutiveFailures() {
+	s.trip()
+	s.Require().NoError(s.server.Set("key", "value"))
+
+	// The cache works again, but the open breaker keeps requests off it
+	_, err := s.provider.Get(s.ctx, "key")
+	s.ErrorIs(err, gobreaker.ErrOpenState)
+	s.ErrorIs(s.provider.Set(s.ctx, "other", "value", time.Minute), gobreaker.ErrOpenState)
+	s.False(s.server.Exists("other"))
+}
+
+func (s *BreakerTestSuite) TestRecoversOnceOpenTimeoutPasses() {
+	s.trip()
+	s.Require().NoError(s.server.Set("key", "value"))
+
+	s.Eventually(func() bool {
+		val, err := s.provider.Get(s.ctx, "key")
+		return err == nil && val == "value"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (s *BreakerTestSuite) TestInvalidationsBypassOpenBreaker() {
+	s.Require().NoError(s.server.Set("key", "value"))
+	s.trip()
+
+	s.NoError(s.provider.Del(s.ctx, "key"))
+	s.False(s.server.Exists("key"))
+}
+
+func (s *BreakerTestSuite) TestUndecodableValueDoesNotTrip() {
+	s.Require().NoError(s.server.Set("page", "{"))
+
+	for i := 0; i < 3; i++ {
+		var page cachedPage
+		_, err := s.provider.GetJSON(s.ctx, "page", &page)
+		s.Error(err)
+	}
+
+	s.NoError(s.provider.Set(s.ctx, "key", "value", time.Minute))
+}
+
+func (s *BreakerTestSuite) TestSlowCallsTimeOutAndTrip() {
+	provider := NewBreakerCacheProvider(blockingProvider{s.remote}, config.BreakerConfig{
+		MaxFailures: 1,
+		Timeout:     10 * time.Millisecond,
+		OpenTimeout: time.Minute,
+	}, zap.NewNop())
+
+	_, err := provider.Get(s.ctx, "key")
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	started := time.Now()
+	_, err = provider.Get(s.ctx, "key")
+	s.ErrorIs(err, gobreaker.ErrOpenState)
+	s.Less(time.Since(started), 10*time.Millisecond)
+}
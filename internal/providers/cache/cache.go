@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+const (
+	// ProviderRedis caches in Redis
+	ProviderRedis = "redis"
+	// ProviderMemcached caches in Memcached
+	ProviderMemcached = "memcached"
+	// ProviderNone runs without a cache, serving every request from the DB
+	ProviderNone = "none"
+)
+
+// NewCacheProvider returns the CacheProvider selected by cfg.Provider, guarded by the circuit breaker
+// and fronted by the in-process cache when they are enabled. When faultsCfg is enabled, its cache
+// faults are injected into the calls to the selected cache.
+func NewCacheProvider(ctx context.Context, cfg config.CacheConfig, redisCfg config.RedisConfig, faultsCfg config.FaultsConfig, logger *zap.Logger) (CacheProvider, error) {
+	var (
+		provider CacheProvider
+		err      error
+	)
+	switch cfg.Provider {
+	case ProviderRedis:
+		provider, err = NewRedisCacheProvider(ctx, redisCfg, logger)
+	case ProviderMemcached:
+		provider, err = NewMemcachedCacheProvider(cfg.Memcached.Servers, logger)
+	case ProviderNone:
+		return NewNoopCacheProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown cache provider %q", cfg.Provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if faultsCfg.Enabled {
+		provider = NewFaultyCacheProvider(provider, faultsCfg.Cache)
+	}
+	// The breaker sits behind the in-process cache, which keeps serving hot keys while it is open
+	if cfg.Breaker.MaxFailures > 0 {
+		provider = NewBreakerCacheProvider(provider, cfg.Breaker, logger)
+	}
+	if cfg.LocalSize > 0 {
+		provider = NewLocalCacheProvider(provider, cfg.LocalSize, cfg.LocalTTL)
+	}
+	return provider, nil
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/models"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func (s *CacheTestSuite) SetupTest() {
+	s.logger = zap.NewNop()
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_Redis() {
+	server := miniredis.RunT(s.T())
+
+	provider, err := NewCacheProvider(context.Background(), config.CacheConfig{Provider: ProviderRedis},
+		config.RedisConfig{Address: server.Addr()}, config.FaultsConfig{}, s.logger)
+
+	s.Require().NoError(err)
+	s.IsType(&redisProvider{}, provider)
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_LocalCacheInFront() {
+	server := miniredis.RunT(s.T())
+	cfg := config.CacheConfig{Provider: ProviderRedis, LocalSize: 10, LocalTTL: time.Second}
+
+	provider, err := NewCacheProvider(context.Background(), cfg, config.RedisConfig{Address: server.Addr()}, config.FaultsConfig{}, s.logger)
+
+	s.Require().NoError(err)
+	s.IsType(&localCacheProvider{}, provider)
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_MemcachedUnreachable() {
+	cfg := config.CacheConfig{
+		Provider:  ProviderMemcached,
+		Memcached: config.MemcachedConfig{Servers: []string{"127.0.0.1:1"}},
+	}
+
+	provider, err := NewCacheProvider(context.Background(), cfg, config.RedisConfig{}, config.FaultsConfig{}, s.logger)
+
+	s.Error(err)
+	s.Nil(provider)
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_UnknownProvider() {
+	provider, err := NewCacheProvider(context.Background(), config.CacheConfig{Provider: "hazelcast"}, config.RedisConfig{}, config.FaultsConfig{}, s.logger)
+
+	s.Error(err)
+	s.Contains(err.Error(), "unknown cache provider")
+	s.Nil(provider)
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_None() {
+	cfg := config.CacheConfig{Provider: ProviderNone, LocalSize: 10, LocalTTL: time.Second}
+
+	provider, err := NewCacheProvider(context.Background(), cfg, config.RedisConfig{}, config.FaultsConfig{}, s.logger)
+
+	s.Require().NoError(err)
+	s.IsType(noopProvider{}, provider)
+}
+
+func (s *CacheTestSuite) TestNoopCacheProvider_AlwaysMisses() {
+	ctx := context.Background()
+	provider := NewNoopCacheProvider()
+
+	s.Require().NoError(provider.Set(ctx, "key", "value", time.Minute))
+	val, err := provider.Get(ctx, "key")
+	s.NoError(err)
+	s.Empty(val)
+
+	values, err := provider.GetMany(ctx, "key", "other")
+	s.NoError(err)
+	s.Equal([]string{"", ""}, values)
+
+	var page cachedPage
+	found, err := provider.GetJSON(ctx, "key", &page)
+	s.NoError(err)
+	s.False(found)
+
+	s.Require().NoError(provider.SetLikersIndex(ctx, "recipient", nil, time.Minute))
+	_, built, err := provider.GetLikersPage(ctx, "recipient", models.LikersRange{Limit: 10})
+	s.NoError(err)
+	s.False(built)
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_BreakerBehindLocalCache() {
+	server := miniredis.RunT(s.T())
+	cfg := config.CacheConfig{
+		Provider:  ProviderRedis,
+		LocalSize: 10,
+		LocalTTL:  time.Second,
+		Breaker:   config.BreakerConfig{MaxFailures: 5, OpenTimeout: time.Second},
+	}
+
+	provider, err := NewCacheProvider(context.Background(), cfg, config.RedisConfig{Address: server.Addr()}, config.FaultsConfig{}, s.logger)
+
+	s.Require().NoError(err)
+	s.Require().IsType(&localCacheProvider{}, provider)
+	s.IsType(&breakerCacheProvider{}, provider.(*localCacheProvider).CacheProvider)
+}
+
+func (s *CacheTestSuite) TestNewCacheProvider_FaultsBehindBreaker() {
+	server := miniredis.RunT(s.T())
+	cfg := config.CacheConfig{
+		Provider: ProviderRedis,
+		Breaker:  config.BreakerConfig{MaxFailures: 5, OpenTimeout: time.Second},
+	}
+
+	provider, err := NewCacheProvider(context.Background(), cfg, config.RedisConfig{Address: server.Addr()}, config.FaultsConfig{Enabled: true}, s.logger)
+
+	s.Require().NoError(err)
+	s.Require().IsType(&breakerCacheProvider{}, provider)
+	s.IsType(&faultyCacheProvider{}, provider.(*breakerCacheProvider).CacheProvider)
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// ClusterTestSuite runs the provider through a cluster client. Miniredis serves every slot
+// from one node, so this covers the client wiring rather than slot placement.
+type ClusterTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestClusterTestSuite(t *testing.T) {
+	suite.Run(t, new(ClusterTestSuite))
+}
+
+func (s *ClusterTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{ClusterAddresses: []string{s.server.Addr()}}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *ClusterTestSuite) TestLikersIndex() {
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", []models.Liker{{ActorID: "actor1", Timestamp: 100}}, time.Minute))
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "actor2", nil, time.Minute))
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor2", "recipient", 200))
+	s.Require().NoError(s.provider.AddLike(s.ctx, "recipient", "actor2", 300))
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor1", Timestamp: 100},
+	}, likers)
+
+	s.Require().NoError(s.provider.DelLikersIndex(s.ctx, "recipient", "actor2"))
+	s.False(s.server.Exists(utils.LikersIndexKey(s.ctx, "recipient")))
+	s.False(s.server.Exists(utils.LikedSetKey(s.ctx, "actor2")))
+}
+
+func (s *ClusterTestSuite) TestDelAcrossUsers() {
+	s.Require().NoError(s.provider.Set(s.ctx, utils.LikersCountKey(s.ctx, "user1"), "1", time.Minute))
+	s.Require().NoError(s.provider.Set(s.ctx, utils.LikersCountKey(s.ctx, "user2"), "2", time.Minute))
+
+	s.Require().NoError(s.provider.Del(s.ctx, utils.LikersCountKey(s.ctx, "user1"), utils.LikersCountKey(s.ctx, "user2")))
+
+	s.False(s.server.Exists(utils.LikersCountKey(s.ctx, "user1")))
+	s.False(s.server.Exists(utils.LikersCountKey(s.ctx, "user2")))
+}
+
+func (s *ClusterTestSuite) TestManyAcrossUsers() {
+	s.Require().NoError(s.provider.SetMany(s.ctx, map[string]interface{}{
+		utils.LikersCountKey(s.ctx, "user1"): 1,
+		utils.LikersCountKey(s.ctx, "user2"): 2,
+	}, time.Minute))
+
+	values, err := s.provider.GetMany(s.ctx, utils.LikersCountKey(s.ctx, "user1"), utils.LikersCountKey(s.ctx, "user2"), utils.LikersCountKey(s.ctx, "user3"))
+
+	s.NoError(err)
+	s.Equal([]string{"1", "2", ""}, values)
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/faults"
+	"github.com/backend-interview-task/internal/models"
+)
+
+// faultyCacheProvider injects faults into the calls to the remote cache, for chaos testing. It
+// sits behind the breaker, which sees the injected failures and latency as those of the cache.
+// Dropped reads miss without reaching the cache, as an evicted or expired value would.
+type faultyCacheProvider struct {
+	CacheProvider
+	injector *faults.Injector
+	missRate float64
+}
+
+// NewFaultyCacheProvider injects the faults of cfg into the calls to remote
+func NewFaultyCacheProvider(remote CacheProvider, cfg config.CacheFaultConfig) CacheProvider {
+	return &faultyCacheProvider{
+		CacheProvider: remote,
+		injector:      faults.NewInjector(faults.TargetCache, cfg.FaultConfig),
+		missRate:      cfg.MissRate,
+	}
+}
+
+// call runs the injected latency and failure of a call
+func (c *faultyCacheProvider) call(ctx context.Context) error {
+	if err := c.injector.Call(ctx); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	return nil
+}
+
+// miss reports whether a read is dropped
+func (c *faultyCacheProvider) miss() bool {
+	return c.injector.Inject(faults.FaultMiss, c.missRate)
+}
+
+func (c *faultyCacheProvider) Get(ctx context.Context, key string) (string, error) {
+	if err := c.call(ctx); err != nil {
+		return "", err
+	}
+	if c.miss() {
+		return "", nil
+	}
+	return c.CacheProvider.Get(ctx, key)
+}
+
+func (c *faultyCacheProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.Set(ctx, key, value, expiration)
+}
+
+func (c *faultyCacheProvider) Del(ctx context.Context, keys ...string) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.Del(ctx, keys...)
+}
+
+func (c *faultyCacheProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	if err := c.call(ctx); err != nil {
+		return nil, err
+	}
+	if c.miss() {
+		return make([]string, len(keys)), nil
+	}
+	return c.CacheProvider.GetMany(ctx, keys...)
+}
+
+func (c *faultyCacheProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.SetMany(ctx, values, expiration)
+}
+
+func (c *faultyCacheProvider) GetJSON(ctx context.Context, key string, out any) (bool, error) {
+	if err := c.call(ctx); err != nil {
+		return false, err
+	}
+	if c.miss() {
+		return false, nil
+	}
+	return c.CacheProvider.GetJSON(ctx, key, out)
+}
+
+func (c *faultyCacheProvider) SetJSON(ctx context.Context, key string, val any, ttl time.Duration) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.SetJSON(ctx, key, val, ttl)
+}
+
+func (c *faultyCacheProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error) {
+	if err := c.call(ctx); err != nil {
+		return nil, false, err
+	}
+	if c.miss() {
+		return nil, false, nil
+	}
+	return c.CacheProvider.GetLikersPage(ctx, recipient, page)
+}
+
+func (c *faultyCacheProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.SetLikersIndex(ctx, recipient, likers, ttl)
+}
+
+func (c *faultyCacheProvider) AddLike(ctx context.Context, actor string, recipient string, likedAt int64) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.AddLike(ctx, actor, recipient, likedAt)
+}
+
+func (c *faultyCacheProvider) RemoveLike(ctx context.Context, actor string, recipient string) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.RemoveLike(ctx, actor, recipient)
+}
+
+func (c *faultyCacheProvider) DelLikersIndex(ctx context.Context, users ...string) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.DelLikersIndex(ctx, users...)
+}
+
+func (c *faultyCacheProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.SeedLikersCount(ctx, recipient, count, ttl)
+}
+
+func (c *faultyCacheProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.IncrLikersCount(ctx, recipient, delta)
+}
+
+func (c *faultyCacheProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.TrackHotRecipient(ctx, recipient, at)
+}
+
+func (c *faultyCacheProvider) HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error) {
+	if err := c.call(ctx); err != nil {
+		return nil, err
+	}
+	return c.CacheProvider.HotRecipients(ctx, window, limit)
+}
+
+func (c *faultyCacheProvider) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	if err := c.call(ctx); err != nil {
+		return "", false, err
+	}
+	return c.CacheProvider.Lock(ctx, key, ttl)
+}
+
+func (c *faultyCacheProvider) Unlock(ctx context.Context, key string, token string) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.Unlock(ctx, key, token)
+}
+
+func (c *faultyCacheProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error) {
+	if err := c.call(ctx); err != nil {
+		return false, 0, err
+	}
+	return c.CacheProvider.Allow(ctx, key, limit, period, burst)
+}
+
+func (c *faultyCacheProvider) Ping(ctx context.Context) error {
+	if err := c.call(ctx); err != nil {
+		return err
+	}
+	return c.CacheProvider.Ping(ctx)
+}
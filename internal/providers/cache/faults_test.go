@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/faults"
+	"github.com/backend-interview-task/internal/models"
+)
+
+type FaultyCacheTestSuite struct {
+	suite.Suite
+	server *miniredis.Miniredis
+	remote CacheProvider
+	ctx    context.Context
+}
+
+func TestFaultyCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(FaultyCacheTestSuite))
+}
+
+func (s *FaultyCacheTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	remote, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.remote = remote
+	s.Require().NoError(remote.Set(s.ctx, "key", "value", time.Minute))
+}
+
+func (s *FaultyCacheTestSuite) TestPassesCallsThroughWithoutFaults() {
+	provider := NewFaultyCacheProvider(s.remote, config.CacheFaultConfig{})
+
+	val, err := provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Equal("value", val)
+}
+
+func (s *FaultyCacheTestSuite) TestDropsReads() {
+	provider := NewFaultyCacheProvider(s.remote, config.CacheFaultConfig{MissRate: 1})
+
+	val, err := provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Empty(val)
+
+	vals, err := provider.GetMany(s.ctx, "key", "other")
+	s.NoError(err)
+	s.Equal([]string{"", ""}, vals)
+
+	likers, found, err := provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+	s.NoError(err)
+	s.False(found)
+	s.Empty(likers)
+
+	// Writes still reach the cache
+	s.Require().NoError(provider.Set(s.ctx, "written", "value", time.Minute))
+	s.True(s.server.Exists("written"))
+}
+
+func (s *FaultyCacheTestSuite) TestFailsCalls() {
+	provider := NewFaultyCacheProvider(s.remote, config.CacheFaultConfig{FaultConfig: config.FaultConfig{ErrorRate: 1}})
+
+	_, err := provider.Get(s.ctx, "key")
+	s.ErrorIs(err, faults.ErrInjected)
+	s.ErrorIs(provider.Set(s.ctx, "written", "value", time.Minute), faults.ErrInjected)
+	s.False(s.server.Exists("written"))
+}
+
+func (s *FaultyCacheTestSuite) TestOpensBreaker() {
+	provider := NewBreakerCacheProvider(
+		NewFaultyCacheProvider(s.remote, config.CacheFaultConfig{FaultConfig: config.FaultConfig{ErrorRate: 1}}),
+		config.BreakerConfig{MaxFailures: 2, OpenTimeout: time.Minute, HalfOpenRequests: 1},
+		zap.NewNop())
+
+	for range 2 {
+		_, err := provider.Get(s.ctx, "key")
+		s.ErrorIs(err, faults.ErrInjected)
+	}
+	_, err := provider.Get(s.ctx, "key")
+	s.ErrorIs(err, gobreaker.ErrOpenState)
+}
+
+func (s *FaultyCacheTestSuite) TestSlowCallsTimeOutInBreaker() {
+	provider := NewBreakerCacheProvider(
+		NewFaultyCacheProvider(s.remote, config.CacheFaultConfig{FaultConfig: config.FaultConfig{Latency: time.Minute, LatencyRate: 1}}),
+		config.BreakerConfig{MaxFailures: 2, Timeout: 10 * time.Millisecond, OpenTimeout: time.Minute, HalfOpenRequests: 1},
+		zap.NewNop())
+
+	_, err := provider.Get(s.ctx, "key")
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
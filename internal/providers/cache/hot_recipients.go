@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// hotRecipientsTTL keeps a window's tally until the window after it has been read
+const hotRecipientsTTL = 3 * utils.HotRecipientsWindow
+
+// TrackHotRecipient counts a request for the recipient in the tally of the window at falls in
+func (r *redisProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error {
+	key := utils.HotRecipientsKey(ctx, at)
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZIncrBy(ctx, key, 1, recipient)
+		pipe.Expire(ctx, key, hotRecipientsTTL)
+		return nil
+	})
+	return err
+}
+
+// HotRecipients returns up to limit of the most requested recipients of the window, most requested first
+func (r *redisProvider) HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	return r.client.ZRevRange(ctx, utils.HotRecipientsKey(ctx, window), 0, int64(limit-1)).Result()
+}
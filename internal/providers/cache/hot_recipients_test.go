@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type HotRecipientsTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestHotRecipientsTestSuite(t *testing.T) {
+	suite.Run(t, new(HotRecipientsTestSuite))
+}
+
+func (s *HotRecipientsTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *HotRecipientsTestSuite) TestMostRequestedFirst() {
+	window := time.Now().Truncate(utils.HotRecipientsWindow)
+	for _, recipient := range []string{"user1", "user2", "user2", "user3", "user3", "user3"} {
+		s.Require().NoError(s.provider.TrackHotRecipient(s.ctx, recipient, window.Add(time.Second)))
+	}
+
+	recipients, err := s.provider.HotRecipients(s.ctx, window, 2)
+
+	s.NoError(err)
+	s.Equal([]string{"user3", "user2"}, recipients)
+	s.Equal(hotRecipientsTTL, s.server.TTL(utils.HotRecipientsKey(s.ctx, window)))
+}
+
+func (s *HotRecipientsTestSuite) TestWindowsAreTalliedApart() {
+	window := time.Now().Truncate(utils.HotRecipientsWindow)
+	s.Require().NoError(s.provider.TrackHotRecipient(s.ctx, "user1", window))
+
+	recipients, err := s.provider.HotRecipients(s.ctx, window.Add(-utils.HotRecipientsWindow), 10)
+
+	s.NoError(err)
+	s.Empty(recipients)
+}
@@ -3,12 +3,29 @@ package cache
 import (
 	"context"
 	"time"
+
+	"github.com/backend-interview-task/internal/models"
 )
 
 type CacheProvider interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Del(ctx context.Context, keys ...string) error
+	GetMany(ctx context.Context, keys ...string) ([]string, error)
+	SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error
 	GetJSON(ctx context.Context, key string, out any) (bool, error)
 	SetJSON(ctx context.Context, key string, val any, ttl time.Duration) error
+	GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error)
+	SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error
+	AddLike(ctx context.Context, actor string, recipient string, likedAt int64) error
+	RemoveLike(ctx context.Context, actor string, recipient string) error
+	DelLikersIndex(ctx context.Context, users ...string) error
+	SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error
+	IncrLikersCount(ctx context.Context, recipient string, delta int64) error
+	TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error
+	HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error)
+	Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error)
+	Unlock(ctx context.Context, key string, token string) error
+	Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error)
+	Ping(ctx context.Context) error
 }
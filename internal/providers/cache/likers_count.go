@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// incrIfExistsScript moves a counter only if it has been seeded: incrementing a missing
+// counter would start it from zero instead of the stored count.
+// KEYS: counter. ARGV: delta.
+var incrIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('INCRBY', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// SeedLikersCount sets the recipient's live likers count unless it is already set,
+// so that a count kept up to date meanwhile is not replaced by an older read
+func (r *redisProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	return r.client.SetNX(ctx, utils.LikersCountKey(ctx, recipient), count, ttl).Err()
+}
+
+// IncrLikersCount moves the recipient's live likers count by delta, if it has been seeded
+func (r *redisProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) error {
+	return incrIfExistsScript.Run(ctx, r.client, []string{utils.LikersCountKey(ctx, recipient)}, delta).Err()
+}
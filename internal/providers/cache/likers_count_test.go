@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type LikersCountTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestLikersCountTestSuite(t *testing.T) {
+	suite.Run(t, new(LikersCountTestSuite))
+}
+
+func (s *LikersCountTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *LikersCountTestSuite) TestIncrLikersCount_Seeded() {
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", -1))
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+
+	count, err := s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.NoError(err)
+	s.Equal("6", count)
+	s.Equal(time.Minute, s.server.TTL(utils.LikersCountKey(s.ctx, "recipient")))
+}
+
+func (s *LikersCountTestSuite) TestIncrLikersCount_NotSeeded() {
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+
+	s.False(s.server.Exists(utils.LikersCountKey(s.ctx, "recipient")))
+}
+
+func (s *LikersCountTestSuite) TestSeedLikersCount_KeepsLiveCount() {
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+
+	// A seed read from the DB before the like must not replace the live count
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+
+	count, err := s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.NoError(err)
+	s.Equal("6", count)
+}
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// The likers index of a recipient is a sorted set of the actors who liked them, scored by
+// the unix time of the like, next to the set of actors the recipient liked in return.
+// Both hold the sentinel member so that a built index is told apart from one that expired
+// or was never built, since Redis drops empty sets.
+const likersIndexSentinel = ""
+
+// The scripts below index a like, but only in indexes that are already built: an index
+// that is missing a like would otherwise pass for complete. Each script only touches the
+// keys of one user, which share a slot on Redis Cluster.
+
+// addLikerScript adds the actor to the recipient's likers index.
+// KEYS: recipient's likers index. ARGV: like time, actor.
+var addLikerScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[1], '') then
+	redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+end
+return 0
+`)
+
+// addLikedScript adds the recipient to the actor's liked set.
+// KEYS: actor's likers index, actor's liked set. ARGV: recipient.
+var addLikedScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[1], '') then
+	redis.call('SADD', KEYS[2], ARGV[1])
+end
+return 0
+`)
+
+// buildLikersScript builds the recipient's likers index, unless it is already built: a build
+// racing another would otherwise replace it with an older read, dropping the likes indexed
+// since. The members are added one by one, as an index can outgrow Lua's limit on unpacked
+// arguments.
+// KEYS: recipient's likers index, recipient's liked set.
+// ARGV: TTL in seconds, number of likers, then each liker's like time and actor, then the
+// actors the recipient liked back.
+var buildLikersScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[1], '') then
+	return 0
+end
+redis.call('DEL', KEYS[1], KEYS[2])
+redis.call('ZADD', KEYS[1], 0, '')
+redis.call('SADD', KEYS[2], '')
+local likers = tonumber(ARGV[2])
+for i = 3, 2 * likers + 1, 2 do
+	redis.call('ZADD', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+for i = 2 * likers + 3, #ARGV do
+	redis.call('SADD', KEYS[2], ARGV[i])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[1])
+redis.call('EXPIRE', KEYS[2], ARGV[1])
+return 1
+`)
+
+// GetLikersPage reads a page of the recipient's likers from their likers index.
+// It reports false when the index has not been built.
+func (r *redisProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error) {
+	key := utils.LikersIndexKey(ctx, recipient)
+
+	// Scores are unix seconds, so excluding 0 also leaves the sentinel out
+	opt := &redis.ZRangeBy{
+		Min:   "(" + strconv.FormatInt(page.After, 10),
+		Max:   "+inf",
+		Count: int64(page.Limit),
+	}
+	if page.Before > 0 {
+		opt.Max = "(" + strconv.FormatInt(page.Before, 10)
+	}
+
+	pipe := r.client.Pipeline()
+	built := pipe.ZScore(ctx, key, likersIndexSentinel)
+	var members *redis.ZSliceCmd
+	if page.Ascending {
+		members = pipe.ZRangeByScoreWithScores(ctx, key, opt)
+	} else {
+		members = pipe.ZRevRangeByScoreWithScores(ctx, key, opt)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, false, err
+	}
+	if errors.Is(built.Err(), redis.Nil) {
+		return nil, false, nil
+	}
+
+	likers := make([]models.Liker, len(members.Val()))
+	actorIDs := make([]interface{}, len(likers))
+	for i, member := range members.Val() {
+		likers[i] = models.Liker{
+			ActorID:   member.Member.(string),
+			Timestamp: int64(member.Score),
+		}
+		actorIDs[i] = likers[i].ActorID
+	}
+	if len(likers) == 0 {
+		return likers, true, nil
+	}
+
+	likedBack, err := r.client.SMIsMember(ctx, utils.LikedSetKey(ctx, recipient), actorIDs...).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range likers {
+		likers[i].LikedBack = likedBack[i]
+	}
+
+	return likers, true, nil
+}
+
+// SetLikersIndex builds the recipient's likers index with the given likers, unless another
+// build got there first and it is already built
+func (r *redisProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error {
+	keys := []string{utils.LikersIndexKey(ctx, recipient), utils.LikedSetKey(ctx, recipient)}
+
+	args := make([]interface{}, 0, 2+2*len(likers))
+	args = append(args, int64(ttl/time.Second), len(likers))
+	var liked []interface{}
+	for _, liker := range likers {
+		args = append(args, liker.Timestamp, liker.ActorID)
+		if liker.LikedBack {
+			liked = append(liked, liker.ActorID)
+		}
+	}
+	args = append(args, liked...)
+
+	return buildLikersScript.Run(ctx, r.client, keys, args...).Err()
+}
+
+// AddLike records in the built likers indexes that actor liked recipient at likedAt
+func (r *redisProvider) AddLike(ctx context.Context, actor string, recipient string, likedAt int64) error {
+	if err := addLikerScript.Run(ctx, r.client, []string{utils.LikersIndexKey(ctx, recipient)}, likedAt, actor).Err(); err != nil {
+		return err
+	}
+	keys := []string{utils.LikersIndexKey(ctx, actor), utils.LikedSetKey(ctx, actor)}
+	return addLikedScript.Run(ctx, r.client, keys, recipient).Err()
+}
+
+// RemoveLike drops the like of actor on recipient from the likers indexes
+func (r *redisProvider) RemoveLike(ctx context.Context, actor string, recipient string) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, utils.LikersIndexKey(ctx, recipient), actor)
+		pipe.SRem(ctx, utils.LikedSetKey(ctx, actor), recipient)
+		return nil
+	})
+	return err
+}
+
+// DelLikersIndex drops the likers indexes of the users, to be rebuilt on their next read
+func (r *redisProvider) DelLikersIndex(ctx context.Context, users ...string) error {
+	keys := make([]string, 0, 2*len(users))
+	for _, user := range users {
+		keys = append(keys, utils.LikersIndexKey(ctx, user), utils.LikedSetKey(ctx, user))
+	}
+	return r.Del(ctx, keys...)
+}
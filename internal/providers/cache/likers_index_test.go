@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+type LikersIndexTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestLikersIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(LikersIndexTestSuite))
+}
+
+func (s *LikersIndexTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *LikersIndexTestSuite) buildIndex() {
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", []models.Liker{
+		{ActorID: "actor1", Timestamp: 100},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor3", Timestamp: 300},
+	}, time.Minute))
+}
+
+func (s *LikersIndexTestSuite) TestGetLikersPage_NotBuilt() {
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.False(built)
+	s.Empty(likers)
+}
+
+func (s *LikersIndexTestSuite) TestGetLikersPage_NewestFirst() {
+	s.buildIndex()
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 2})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{
+		{ActorID: "actor3", Timestamp: 300},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+	}, likers)
+}
+
+func (s *LikersIndexTestSuite) TestGetLikersPage_OldestFirstWithinBounds() {
+	s.buildIndex()
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{After: 100, Before: 300, Ascending: true, Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{{ActorID: "actor2", Timestamp: 200, LikedBack: true}}, likers)
+}
+
+func (s *LikersIndexTestSuite) TestGetLikersPage_BuiltEmpty() {
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", nil, time.Minute))
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Empty(likers)
+	s.Equal(time.Minute, s.server.TTL(utils.LikersIndexKey(s.ctx, "recipient")))
+	s.Equal(time.Minute, s.server.TTL(utils.LikedSetKey(s.ctx, "recipient")))
+}
+
+func (s *LikersIndexTestSuite) TestSetLikersIndex_KeepsBuiltIndex() {
+	s.buildIndex()
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor4", "recipient", 400))
+
+	// A build that read the likers before the index was built and actor4 liked is dropped
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", []models.Liker{{ActorID: "actor1", Timestamp: 100}}, time.Minute))
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{
+		{ActorID: "actor4", Timestamp: 400},
+		{ActorID: "actor3", Timestamp: 300},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor1", Timestamp: 100},
+	}, likers)
+}
+
+func (s *LikersIndexTestSuite) TestAddLike_UpdatesBuiltIndexes() {
+	s.buildIndex()
+
+	// recipient likes actor1 back, then actor4 likes recipient
+	s.Require().NoError(s.provider.AddLike(s.ctx, "recipient", "actor1", 400))
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor4", "recipient", 500))
+
+	likers, _, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "actor4", Timestamp: 500},
+		{ActorID: "actor3", Timestamp: 300},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor1", Timestamp: 100, LikedBack: true},
+	}, likers)
+}
+
+func (s *LikersIndexTestSuite) TestAddLike_LeavesMissingIndexesAlone() {
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor1", "recipient", 100))
+
+	_, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.False(built)
+	s.False(s.server.Exists(utils.LikersIndexKey(s.ctx, "recipient")))
+	s.False(s.server.Exists(utils.LikedSetKey(s.ctx, "actor1")))
+}
+
+func (s *LikersIndexTestSuite) TestRemoveLike() {
+	s.buildIndex()
+
+	// actor3 withdraws their like and recipient withdraws theirs on actor2
+	s.Require().NoError(s.provider.RemoveLike(s.ctx, "actor3", "recipient"))
+	s.Require().NoError(s.provider.RemoveLike(s.ctx, "recipient", "actor2"))
+
+	likers, _, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "actor2", Timestamp: 200},
+		{ActorID: "actor1", Timestamp: 100},
+	}, likers)
+}
+
+func (s *LikersIndexTestSuite) TestDelLikersIndex() {
+	s.buildIndex()
+
+	s.Require().NoError(s.provider.DelLikersIndex(s.ctx, "recipient", "actor1"))
+
+	_, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.False(built)
+	s.False(s.server.Exists(utils.LikedSetKey(s.ctx, "recipient")))
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// localCacheProvider keeps recently read and written values of the remote cache in process
+// for a short while, so that lookups of hot keys such as counts and first pages survive a
+// blip of the remote cache without falling through to the DB. Values dropped or moved
+// through this instance are evicted locally; changes made through other instances show
+// up once the local copy expires.
+// The likers indexes are always read from the remote cache.
+type localCacheProvider struct {
+	CacheProvider
+	local *expirable.LRU[string, localEntry]
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// localEntry is a local copy of a value. A value written with an expiration shorter than the
+// local TTL, such as a cached empty result, carries the time it expires at.
+type localEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// NewLocalCacheProvider puts an in-process LRU of size entries, each kept for at most ttl, in front of remote
+func NewLocalCacheProvider(remote CacheProvider, size int, ttl time.Duration) CacheProvider {
+	return &localCacheProvider{
+		CacheProvider: remote,
+		local:         expirable.NewLRU[string, localEntry](size, nil, ttl),
+		ttl:           ttl,
+		now:           time.Now,
+	}
+}
+
+// add keeps a local copy of value for the shorter of expiration and the local TTL. Values
+// read from the remote cache, whose expiration is unknown, are added with no expiration.
+func (c *localCacheProvider) add(key, value string, expiration time.Duration) {
+	entry := localEntry{value: value}
+	if expiration > 0 && expiration < c.ttl {
+		entry.expiresAt = c.now().Add(expiration)
+	}
+	c.local.Add(key, entry)
+}
+
+// lookup returns the local copy of the value under key, unless there is none or it expired
+func (c *localCacheProvider) lookup(key string) (string, bool) {
+	entry, ok := c.local.Get(key)
+	if !ok {
+		return "", false
+	}
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.local.Remove(key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+// Get returns the local copy of the value if there is one, otherwise reads it from the remote cache
+func (c *localCacheProvider) Get(ctx context.Context, key string) (string, error) {
+	if val, ok := c.lookup(key); ok {
+		return val, nil
+	}
+
+	val, err := c.CacheProvider.Get(ctx, key)
+	if err == nil && val != "" {
+		c.add(key, val, 0)
+	}
+	return val, err
+}
+
+// Set stores the value in the remote cache and keeps a local copy, even if the remote cache is unavailable
+func (c *localCacheProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.add(key, formatValue(value), expiration)
+	return c.CacheProvider.Set(ctx, key, value, expiration)
+}
+
+// Del deletes the keys from both caches
+func (c *localCacheProvider) Del(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		c.local.Remove(key)
+	}
+	return c.CacheProvider.Del(ctx, keys...)
+}
+
+// GetMany returns the local copies of the values it has and reads the others from the remote cache in one batch
+func (c *localCacheProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	values := make([]string, len(keys))
+	var missing []int
+	for i, key := range keys {
+		if val, ok := c.lookup(key); ok {
+			values[i] = val
+		} else {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	remoteKeys := make([]string, len(missing))
+	for j, i := range missing {
+		remoteKeys[j] = keys[i]
+	}
+	remoteValues, err := c.CacheProvider.GetMany(ctx, remoteKeys...)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range missing {
+		values[i] = remoteValues[j]
+		if values[i] != "" {
+			c.add(keys[i], values[i], 0)
+		}
+	}
+	return values, nil
+}
+
+// SetMany stores the values in the remote cache and keeps local copies, even if the remote cache is unavailable
+func (c *localCacheProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	for key, value := range values {
+		c.add(key, formatValue(value), expiration)
+	}
+	return c.CacheProvider.SetMany(ctx, values, expiration)
+}
+
+// GetJSON reads a JSON value through Get and unmarshals it into the provided output
+func (c *localCacheProvider) GetJSON(ctx context.Context, key string, out any) (bool, error) {
+	raw, err := c.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return decodeJSON(raw, out)
+}
+
+// SetJSON marshals a value to JSON and stores it through Set
+func (c *localCacheProvider) SetJSON(ctx context.Context, key string, val any, ttl time.Duration) error {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, key, string(b), ttl)
+}
+
+// SeedLikersCount seeds the remote count and drops the local copy, which is read back from the remote cache.
+// The seed is kept locally only while the remote cache is unavailable.
+func (c *localCacheProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	key := utils.LikersCountKey(ctx, recipient)
+	if err := c.CacheProvider.SeedLikersCount(ctx, recipient, count, ttl); err != nil {
+		c.add(key, formatValue(count), ttl)
+		return err
+	}
+	c.local.Remove(key)
+	return nil
+}
+
+// IncrLikersCount moves the remote count and drops the local copy
+func (c *localCacheProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) error {
+	c.local.Remove(utils.LikersCountKey(ctx, recipient))
+	return c.CacheProvider.IncrLikersCount(ctx, recipient, delta)
+}
+
+// formatValue formats a value the way Redis stores it, which is how every cache stores values
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
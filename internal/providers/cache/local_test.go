@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type LocalCacheTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestLocalCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(LocalCacheTestSuite))
+}
+
+func (s *LocalCacheTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	remote, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = NewLocalCacheProvider(remote, 100, time.Minute)
+}
+
+func (s *LocalCacheTestSuite) TestGet_ServedLocallyWhileRemoteIsDown() {
+	s.Require().NoError(s.server.Set("key", "value"))
+	val, err := s.provider.Get(s.ctx, "key")
+	s.Require().NoError(err)
+	s.Require().Equal("value", val)
+
+	s.server.Close()
+
+	val, err = s.provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Equal("value", val)
+}
+
+func (s *LocalCacheTestSuite) TestSet_KeptLocallyWhenRemoteFails() {
+	s.server.Close()
+
+	s.Error(s.provider.SetJSON(s.ctx, "page", cachedPage{Items: []string{"a"}}, time.Minute))
+
+	var page cachedPage
+	found, err := s.provider.GetJSON(s.ctx, "page", &page)
+	s.NoError(err)
+	s.True(found)
+	s.Equal([]string{"a"}, page.Items)
+}
+
+func (s *LocalCacheTestSuite) TestGetJSON_EmptySentinel() {
+	s.Require().NoError(s.provider.Set(s.ctx, "page", utils.EmptyCacheValue, utils.EmptyResultTTL))
+	s.server.Close()
+
+	var page cachedPage
+	found, err := s.provider.GetJSON(s.ctx, "page", &page)
+	s.NoError(err)
+	s.True(found)
+	s.Empty(page.Items)
+}
+
+func (s *LocalCacheTestSuite) TestDel_EvictsLocalCopy() {
+	s.Require().NoError(s.provider.Set(s.ctx, "key", "value", time.Minute))
+	s.Require().NoError(s.provider.Del(s.ctx, "key"))
+
+	val, err := s.provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Empty(val)
+}
+
+func (s *LocalCacheTestSuite) TestIncrLikersCount_EvictsLocalCopy() {
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+	val, err := s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.Require().NoError(err)
+	s.Require().Equal("5", val)
+
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+
+	val, err = s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.NoError(err)
+	s.Equal("6", val)
+}
+
+func (s *LocalCacheTestSuite) TestSeedLikersCount_ReadsBackLiveCount() {
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+	s.Require().NoError(s.server.Set(utils.LikersCountKey(s.ctx, "recipient"), "7"))
+
+	// A later seed loses to the live count and leaves no local copy of itself
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+
+	val, err := s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.NoError(err)
+	s.Equal("7", val)
+}
+
+func (s *LocalCacheTestSuite) TestSeedLikersCount_KeptLocallyWhenRemoteFails() {
+	s.server.Close()
+
+	s.Error(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+
+	val, err := s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.NoError(err)
+	s.Equal("5", val)
+}
+
+func (s *LocalCacheTestSuite) TestGetMany_ReadsOnlyMissingKeysRemotely() {
+	s.Require().NoError(s.provider.SetMany(s.ctx, map[string]interface{}{"a": "1"}, time.Minute))
+	s.Require().NoError(s.server.Set("b", "2"))
+	values, err := s.provider.GetMany(s.ctx, "a", "b")
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"1", "2"}, values)
+
+	s.server.Close()
+
+	// Both values are now kept locally, so the remote cache is not needed
+	values, err = s.provider.GetMany(s.ctx, "b", "a")
+	s.NoError(err)
+	s.Equal([]string{"2", "1"}, values)
+
+	_, err = s.provider.GetMany(s.ctx, "a", "c")
+	s.Error(err)
+}
+
+func (s *LocalCacheTestSuite) TestSet_LocalCopyExpiresWithShorterExpiration() {
+	now := time.Now()
+	s.provider.(*localCacheProvider).now = func() time.Time { return now }
+
+	s.Require().NoError(s.provider.Set(s.ctx, "page", utils.EmptyCacheValue, 5*time.Second))
+	s.Require().NoError(s.provider.SetMany(s.ctx, map[string]interface{}{"count": "3"}, 5*time.Second))
+	s.Require().NoError(s.provider.Set(s.ctx, "kept", "1", time.Hour))
+
+	now = now.Add(5 * time.Second)
+	s.server.FastForward(5 * time.Second)
+
+	// The local copies expire with the remote values rather than after the local TTL
+	values, err := s.provider.GetMany(s.ctx, "page", "count", "kept")
+	s.NoError(err)
+	s.Equal([]string{"", "", "1"}, values)
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// unlockScript deletes a lock only while it still holds the token of the caller, so that a
+// lock that expired and was taken by another replica is not released by the previous holder.
+// KEYS: lock. ARGV: token.
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// newLockToken returns a random token identifying one holder of a lock
+func newLockToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Lock takes the lock under key for ttl unless another holder has it, returning the token that releases it.
+// The lock expires after ttl even if it is never released, so ttl should outlast the work it guards.
+func (r *redisProvider) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	token := newLockToken()
+	acquired, err := r.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil || !acquired {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// Unlock releases the lock under key if token still holds it
+func (r *redisProvider) Unlock(ctx context.Context, key string, token string) error {
+	return unlockScript.Run(ctx, r.client, []string{key}, token).Err()
+}
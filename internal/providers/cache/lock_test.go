@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type LockTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestLockTestSuite(t *testing.T) {
+	suite.Run(t, new(LockTestSuite))
+}
+
+func (s *LockTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *LockTestSuite) TestLock_HeldUntilUnlocked() {
+	key := utils.LockKey(s.ctx, "job")
+	token, acquired, err := s.provider.Lock(s.ctx, key, time.Minute)
+	s.Require().NoError(err)
+	s.Require().True(acquired)
+	s.NotEmpty(token)
+	s.Equal(time.Minute, s.server.TTL(key))
+
+	_, acquired, err = s.provider.Lock(s.ctx, key, time.Minute)
+	s.NoError(err)
+	s.False(acquired)
+
+	s.Require().NoError(s.provider.Unlock(s.ctx, key, token))
+	_, acquired, err = s.provider.Lock(s.ctx, key, time.Minute)
+	s.NoError(err)
+	s.True(acquired)
+}
+
+func (s *LockTestSuite) TestUnlock_LeavesLockTakenOverAlone() {
+	key := utils.LockKey(s.ctx, "job")
+	staleToken, _, err := s.provider.Lock(s.ctx, key, time.Minute)
+	s.Require().NoError(err)
+
+	// The lock expires and another replica takes it
+	s.server.FastForward(time.Minute)
+	_, acquired, err := s.provider.Lock(s.ctx, key, time.Minute)
+	s.Require().NoError(err)
+	s.Require().True(acquired)
+
+	s.Require().NoError(s.provider.Unlock(s.ctx, key, staleToken))
+	s.True(s.server.Exists(key))
+}
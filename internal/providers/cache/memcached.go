@@ -0,0 +1,518 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+const (
+	// memcachedMaxRelativeExpiration is the longest expiration memcached takes as relative;
+	// longer ones are read as a unix time
+	memcachedMaxRelativeExpiration = 30 * 24 * time.Hour
+	// memcachedMaxKeyLength is the longest key memcached accepts
+	memcachedMaxKeyLength = 250
+	// memcachedMaxCASAttempts bounds how often an index update is retried when it races another
+	memcachedMaxCASAttempts = 10
+)
+
+var errCASContention = errors.New("memcache: too many compare-and-swap conflicts")
+
+// memcacheClient is the part of the memcache client the provider uses
+type memcacheClient interface {
+	Get(key string) (*memcache.Item, error)
+	GetMulti(keys []string) (map[string]*memcache.Item, error)
+	Set(item *memcache.Item) error
+	Add(item *memcache.Item) error
+	CompareAndSwap(item *memcache.Item) error
+	Delete(key string) error
+	Increment(key string, delta uint64) (uint64, error)
+	Decrement(key string, delta uint64) (uint64, error)
+	Ping() error
+}
+
+// memcachedProvider implements the CacheProvider interface on memcached. Memcached has no
+// sorted sets, so each likers index and liked set is stored as a single JSON item that is
+// updated with compare-and-swap.
+type memcachedProvider struct {
+	client memcacheClient
+	logger *zap.Logger
+}
+
+// memcachedIndex is a likers index or liked set as stored in memcached. Members maps each
+// member to its score; ExpiresAt is kept so that updates can carry the remaining TTL over.
+type memcachedIndex struct {
+	ExpiresAt int64            `json:"expires_at"`
+	Members   map[string]int64 `json:"members"`
+}
+
+// NewMemcachedCacheProvider creates and returns a memcachedProvider struct that satisfies the CacheProvider interface.
+func NewMemcachedCacheProvider(servers []string, logger *zap.Logger) (CacheProvider, error) {
+	client := memcache.New(servers...)
+	if err := client.Ping(); err != nil {
+		return nil, err
+	}
+
+	return &memcachedProvider{
+		client: client,
+		logger: logger,
+	}, nil
+}
+
+// memcachedKey maps a cache key onto a valid memcached key, hashing keys that are too long
+// or contain spaces or control characters
+func memcachedKey(key string) string {
+	valid := len(key) <= memcachedMaxKeyLength
+	for i := 0; valid && i < len(key); i++ {
+		valid = key[i] > ' ' && key[i] != 0x7f
+	}
+	if valid {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// memcachedExpiration converts a TTL to a memcached expiration, where zero never expires
+func memcachedExpiration(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	if ttl > memcachedMaxRelativeExpiration {
+		return int32(time.Now().Add(ttl).Unix())
+	}
+	// Memcached counts whole seconds, so a TTL under a second is rounded up rather than made permanent
+	return int32((ttl + time.Second - 1) / time.Second)
+}
+
+// Get retrieves a value from memcached.
+func (m *memcachedProvider) Get(ctx context.Context, key string) (string, error) {
+	item, err := m.client.Get(memcachedKey(key))
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return "", nil // Return empty string if key does not exist
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(item.Value), nil
+}
+
+// Set stores a value in memcached with an expiration.
+func (m *memcachedProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return m.client.Set(&memcache.Item{
+		Key:        memcachedKey(key),
+		Value:      []byte(formatValue(value)),
+		Expiration: memcachedExpiration(expiration),
+	})
+}
+
+// Del deletes one or more keys from memcached.
+func (m *memcachedProvider) Del(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := m.client.Delete(memcachedKey(key)); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMany retrieves the values of several keys from memcached in one round trip, with an empty string for each missing key.
+func (m *memcachedProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	memcachedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		memcachedKeys[i] = memcachedKey(key)
+	}
+
+	items, err := m.client.GetMulti(memcachedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(keys))
+	for i, key := range memcachedKeys {
+		if item := items[key]; item != nil {
+			values[i] = string(item.Value)
+		}
+	}
+	return values, nil
+}
+
+// SetMany stores several values in memcached with the same expiration.
+// The memcached protocol has no multi-key set, so the values are stored one by one.
+func (m *memcachedProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	for key, value := range values {
+		if err := m.Set(ctx, key, value, expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetJSON retrieves a JSON value from memcached and unmarshals it into the provided output.
+// A cached empty result is reported as found, leaving the output as its zero value.
+func (m *memcachedProvider) GetJSON(ctx context.Context, key string, out any) (bool, error) {
+	raw, err := m.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return decodeJSON(raw, out)
+}
+
+// SetJSON marshals a value to JSON and stores it in memcached with an expiration.
+func (m *memcachedProvider) SetJSON(ctx context.Context, key string, val any, ttl time.Duration) error {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return m.Set(ctx, key, string(b), ttl)
+}
+
+// GetLikersPage reads a page of the recipient's likers from their likers index.
+// It reports false when the index has not been built.
+func (m *memcachedProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error) {
+	indexKey := memcachedKey(utils.LikersIndexKey(ctx, recipient))
+	likedKey := memcachedKey(utils.LikedSetKey(ctx, recipient))
+
+	items, err := m.client.GetMulti([]string{indexKey, likedKey})
+	if err != nil {
+		return nil, false, err
+	}
+	if items[indexKey] == nil {
+		return nil, false, nil
+	}
+
+	var index, liked memcachedIndex
+	if err := json.Unmarshal(items[indexKey].Value, &index); err != nil {
+		return nil, false, err
+	}
+	if items[likedKey] != nil {
+		if err := json.Unmarshal(items[likedKey].Value, &liked); err != nil {
+			return nil, false, err
+		}
+	}
+
+	likers := make([]models.Liker, 0, len(index.Members))
+	for actor, likedAt := range index.Members {
+		if likedAt <= page.After || (page.Before > 0 && likedAt >= page.Before) {
+			continue
+		}
+		_, likedBack := liked.Members[actor]
+		likers = append(likers, models.Liker{ActorID: actor, Timestamp: likedAt, LikedBack: likedBack})
+	}
+
+	// Ordered like a sorted set: by time, then by actor
+	sort.Slice(likers, func(i, j int) bool {
+		a, b := likers[i], likers[j]
+		if !page.Ascending {
+			a, b = b, a
+		}
+		if a.Timestamp != b.Timestamp {
+			return a.Timestamp < b.Timestamp
+		}
+		return a.ActorID < b.ActorID
+	})
+	if len(likers) > page.Limit {
+		likers = likers[:page.Limit]
+	}
+
+	return likers, true, nil
+}
+
+// SetLikersIndex builds the recipient's likers index with the given likers, unless another
+// build got there first and it is already built
+func (m *memcachedProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl).Unix()
+	index := memcachedIndex{ExpiresAt: expiresAt, Members: make(map[string]int64, len(likers))}
+	liked := memcachedIndex{ExpiresAt: expiresAt, Members: make(map[string]int64)}
+	for _, liker := range likers {
+		index.Members[liker.ActorID] = liker.Timestamp
+		if liker.LikedBack {
+			liked.Members[liker.ActorID] = 0
+		}
+	}
+
+	// A build racing another would otherwise replace it with an older read, dropping the likes
+	// indexed since
+	if _, err := m.client.Get(memcachedKey(utils.LikersIndexKey(ctx, recipient))); !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
+
+	// The liked set goes first, so that a built index is never read next to a stale liked set
+	if err := m.setIndex(utils.LikedSetKey(ctx, recipient), liked, ttl); err != nil {
+		return err
+	}
+	b, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	err = m.client.Add(&memcache.Item{
+		Key:        memcachedKey(utils.LikersIndexKey(ctx, recipient)),
+		Value:      b,
+		Expiration: memcachedExpiration(ttl),
+	})
+	if errors.Is(err, memcache.ErrNotStored) {
+		return nil
+	}
+	return err
+}
+
+// AddLike records in the built likers indexes that actor liked recipient at likedAt
+func (m *memcachedProvider) AddLike(ctx context.Context, actor string, recipient string, likedAt int64) error {
+	if err := m.updateIndex(utils.LikersIndexKey(ctx, recipient), func(index *memcachedIndex) {
+		index.Members[actor] = likedAt
+	}); err != nil {
+		return err
+	}
+
+	// The liked set only matters next to a built likers index of the actor
+	if _, err := m.client.Get(memcachedKey(utils.LikersIndexKey(ctx, actor))); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil
+		}
+		return err
+	}
+	return m.updateIndex(utils.LikedSetKey(ctx, actor), func(liked *memcachedIndex) {
+		liked.Members[recipient] = 0
+	})
+}
+
+// RemoveLike drops the like of actor on recipient from the likers indexes
+func (m *memcachedProvider) RemoveLike(ctx context.Context, actor string, recipient string) error {
+	if err := m.updateIndex(utils.LikersIndexKey(ctx, recipient), func(index *memcachedIndex) {
+		delete(index.Members, actor)
+	}); err != nil {
+		return err
+	}
+	return m.updateIndex(utils.LikedSetKey(ctx, actor), func(liked *memcachedIndex) {
+		delete(liked.Members, recipient)
+	})
+}
+
+// DelLikersIndex drops the likers indexes of the users, to be rebuilt on their next read
+func (m *memcachedProvider) DelLikersIndex(ctx context.Context, users ...string) error {
+	keys := make([]string, 0, 2*len(users))
+	for _, user := range users {
+		keys = append(keys, utils.LikersIndexKey(ctx, user), utils.LikedSetKey(ctx, user))
+	}
+	return m.Del(ctx, keys...)
+}
+
+// SeedLikersCount sets the recipient's live likers count unless it is already set,
+// so that a count kept up to date meanwhile is not replaced by an older read
+func (m *memcachedProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	err := m.client.Add(&memcache.Item{
+		Key:        memcachedKey(utils.LikersCountKey(ctx, recipient)),
+		Value:      []byte(strconv.FormatInt(count, 10)),
+		Expiration: memcachedExpiration(ttl),
+	})
+	if errors.Is(err, memcache.ErrNotStored) {
+		return nil
+	}
+	return err
+}
+
+// IncrLikersCount moves the recipient's live likers count by delta, if it has been seeded.
+// Memcached stops decrements at zero.
+func (m *memcachedProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) error {
+	key := memcachedKey(utils.LikersCountKey(ctx, recipient))
+
+	var err error
+	if delta >= 0 {
+		_, err = m.client.Increment(key, uint64(delta))
+	} else {
+		_, err = m.client.Decrement(key, uint64(-delta))
+	}
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	return err
+}
+
+// TrackHotRecipient counts a request for the recipient in the tally of the window at falls in.
+// The tally is a single item, so this costs a compare-and-swap on every request.
+func (m *memcachedProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error {
+	key := utils.HotRecipientsKey(ctx, at)
+	b, err := json.Marshal(memcachedIndex{
+		ExpiresAt: time.Now().Add(hotRecipientsTTL).Unix(),
+		Members:   map[string]int64{recipient: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The first request of a window starts its tally
+	err = m.client.Add(&memcache.Item{Key: memcachedKey(key), Value: b, Expiration: memcachedExpiration(hotRecipientsTTL)})
+	if !errors.Is(err, memcache.ErrNotStored) {
+		return err
+	}
+	return m.updateIndex(key, func(tally *memcachedIndex) {
+		tally.Members[recipient]++
+	})
+}
+
+// HotRecipients returns up to limit of the most requested recipients of the window, most requested first
+func (m *memcachedProvider) HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error) {
+	item, err := m.client.Get(memcachedKey(utils.HotRecipientsKey(ctx, window)))
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tally memcachedIndex
+	if err := json.Unmarshal(item.Value, &tally); err != nil {
+		return nil, err
+	}
+
+	recipients := make([]string, 0, len(tally.Members))
+	for recipient := range tally.Members {
+		recipients = append(recipients, recipient)
+	}
+	// Ordered like ZREVRANGE: by count, then by recipient, both descending
+	sort.Slice(recipients, func(i, j int) bool {
+		a, b := recipients[i], recipients[j]
+		if tally.Members[a] != tally.Members[b] {
+			return tally.Members[a] > tally.Members[b]
+		}
+		return a > b
+	})
+	if len(recipients) > limit {
+		recipients = recipients[:max(limit, 0)]
+	}
+	return recipients, nil
+}
+
+// Lock takes the lock under key for ttl unless another holder has it, returning the token that releases it
+func (m *memcachedProvider) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	token := newLockToken()
+	err := m.client.Add(&memcache.Item{Key: memcachedKey(key), Value: []byte(token), Expiration: memcachedExpiration(ttl)})
+	if errors.Is(err, memcache.ErrNotStored) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// Unlock releases the lock under key if token still holds it. The lock is expired with a
+// compare-and-swap rather than deleted, so that a lock taken over meanwhile is left alone.
+func (m *memcachedProvider) Unlock(ctx context.Context, key string, token string) error {
+	item, err := m.client.Get(memcachedKey(key))
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if string(item.Value) != token {
+		return nil
+	}
+
+	item.Expiration = -1 // Memcached drops items stored with an expiration in the past
+	err = m.client.CompareAndSwap(item)
+	if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) || errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	return err
+}
+
+// Allow admits a request against the rate limit under key. Memcached cannot run the cell rate
+// algorithm atomically, so it counts requests in fixed windows of period instead, admitting up
+// to limit per window: burst is not enforced, and up to twice limit may pass around a window
+// boundary.
+func (m *memcachedProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error) {
+	now := time.Now()
+	window := now.Truncate(period)
+	windowKey := memcachedKey(fmt.Sprintf("%s:%d", key, window.UnixMilli()))
+
+	err := m.client.Add(&memcache.Item{Key: windowKey, Value: []byte("0"), Expiration: memcachedExpiration(period)})
+	if err != nil && !errors.Is(err, memcache.ErrNotStored) {
+		return false, 0, err
+	}
+	count, err := m.client.Increment(windowKey, 1)
+	if err != nil {
+		return false, 0, err
+	}
+	if count > uint64(limit) {
+		return false, window.Add(period).Sub(now), nil
+	}
+	return true, 0, nil
+}
+
+// setIndex stores an index item for ttl
+func (m *memcachedProvider) setIndex(key string, index memcachedIndex, ttl time.Duration) error {
+	b, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return m.client.Set(&memcache.Item{
+		Key:        memcachedKey(key),
+		Value:      b,
+		Expiration: memcachedExpiration(ttl),
+	})
+}
+
+// updateIndex applies change to the index item under key, retrying when another update
+// gets in between. Missing and expired items are left alone.
+func (m *memcachedProvider) updateIndex(key string, change func(*memcachedIndex)) error {
+	key = memcachedKey(key)
+
+	for attempt := 0; attempt < memcachedMaxCASAttempts; attempt++ {
+		item, err := m.client.Get(key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var index memcachedIndex
+		if err := json.Unmarshal(item.Value, &index); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		if index.Members == nil {
+			index.Members = make(map[string]int64)
+		}
+		ttl := time.Until(time.Unix(index.ExpiresAt, 0))
+		if ttl <= 0 {
+			return nil
+		}
+		change(&index)
+
+		// Memcached does not hand out the expiration, so the item carries its own
+		if item.Value, err = json.Marshal(index); err != nil {
+			return err
+		}
+		item.Expiration = memcachedExpiration(ttl)
+
+		err = m.client.CompareAndSwap(item)
+		switch {
+		case errors.Is(err, memcache.ErrCASConflict):
+			continue
+		case errors.Is(err, memcache.ErrNotStored), errors.Is(err, memcache.ErrCacheMiss):
+			return nil // Dropped meanwhile
+		default:
+			return err
+		}
+	}
+	return errCASContention
+}
+
+// Ping checks that every memcached server answers
+func (m *memcachedProvider) Ping(ctx context.Context) error {
+	return m.client.Ping()
+}
@@ -0,0 +1,442 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// fakeMemcache keeps items in memory with memcached's semantics for the commands the provider uses.
+// Expirations are recorded but not enforced.
+type fakeMemcache struct {
+	mu    sync.Mutex
+	items map[string]memcache.Item
+	casID uint64
+	// beforeCAS runs before each compare-and-swap, letting tests race it
+	beforeCAS func()
+}
+
+func newFakeMemcache() *fakeMemcache {
+	return &fakeMemcache{items: make(map[string]memcache.Item)}
+}
+
+func (f *fakeMemcache) store(item *memcache.Item) {
+	f.casID++
+	stored := *item
+	stored.Value = append([]byte(nil), item.Value...)
+	stored.CasID = f.casID
+	f.items[item.Key] = stored
+}
+
+func (f *fakeMemcache) Get(key string) (*memcache.Item, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	item, ok := f.items[key]
+	if !ok {
+		return nil, memcache.ErrCacheMiss
+	}
+	item.Value = append([]byte(nil), item.Value...)
+	item.Expiration = 0 // Memcached does not return it
+	return &item, nil
+}
+
+func (f *fakeMemcache) GetMulti(keys []string) (map[string]*memcache.Item, error) {
+	items := make(map[string]*memcache.Item)
+	for _, key := range keys {
+		if item, err := f.Get(key); err == nil {
+			items[key] = item
+		}
+	}
+	return items, nil
+}
+
+func (f *fakeMemcache) Set(item *memcache.Item) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.store(item)
+	return nil
+}
+
+func (f *fakeMemcache) Add(item *memcache.Item) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.items[item.Key]; ok {
+		return memcache.ErrNotStored
+	}
+	f.store(item)
+	return nil
+}
+
+func (f *fakeMemcache) CompareAndSwap(item *memcache.Item) error {
+	if f.beforeCAS != nil {
+		f.beforeCAS()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current, ok := f.items[item.Key]
+	if !ok {
+		return memcache.ErrNotStored
+	}
+	if current.CasID != item.CasID {
+		return memcache.ErrCASConflict
+	}
+	if item.Expiration < 0 {
+		delete(f.items, item.Key)
+		return nil
+	}
+	f.store(item)
+	return nil
+}
+
+func (f *fakeMemcache) Delete(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.items[key]; !ok {
+		return memcache.ErrCacheMiss
+	}
+	delete(f.items, key)
+	return nil
+}
+
+func (f *fakeMemcache) Increment(key string, delta uint64) (uint64, error) {
+	return f.add(key, int64(delta))
+}
+
+func (f *fakeMemcache) Ping() error {
+	return nil
+}
+
+func (f *fakeMemcache) Decrement(key string, delta uint64) (uint64, error) {
+	return f.add(key, -int64(delta))
+}
+
+func (f *fakeMemcache) add(key string, delta int64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	item, ok := f.items[key]
+	if !ok {
+		return 0, memcache.ErrCacheMiss
+	}
+	n, err := strconv.ParseInt(string(item.Value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n = max(n+delta, 0)
+	item.Value = []byte(strconv.FormatInt(n, 10))
+	f.store(&item)
+	return uint64(n), nil
+}
+
+type MemcachedProviderTestSuite struct {
+	suite.Suite
+	client   *fakeMemcache
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestMemcachedProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(MemcachedProviderTestSuite))
+}
+
+func (s *MemcachedProviderTestSuite) SetupTest() {
+	s.client = newFakeMemcache()
+	s.provider = &memcachedProvider{client: s.client, logger: zap.NewNop()}
+	s.ctx = context.Background()
+}
+
+func (s *MemcachedProviderTestSuite) buildIndex() {
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", []models.Liker{
+		{ActorID: "actor1", Timestamp: 100},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor3", Timestamp: 300},
+	}, time.Minute))
+}
+
+func (s *MemcachedProviderTestSuite) TestGetSetDel() {
+	val, err := s.provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Empty(val)
+
+	s.Require().NoError(s.provider.Set(s.ctx, "key", "value", 90*time.Second))
+	val, err = s.provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Equal("value", val)
+	s.Equal(int32(90), s.client.items["key"].Expiration)
+
+	// Deleting a missing key alongside is not an error
+	s.NoError(s.provider.Del(s.ctx, "key", "missing"))
+	val, err = s.provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Empty(val)
+}
+
+func (s *MemcachedProviderTestSuite) TestJSON() {
+	s.Require().NoError(s.provider.SetJSON(s.ctx, "page", cachedPage{Items: []string{"a", "b"}}, time.Minute))
+
+	var page cachedPage
+	found, err := s.provider.GetJSON(s.ctx, "page", &page)
+	s.NoError(err)
+	s.True(found)
+	s.Equal([]string{"a", "b"}, page.Items)
+
+	s.Require().NoError(s.provider.Set(s.ctx, "empty", utils.EmptyCacheValue, utils.EmptyResultTTL))
+	var empty cachedPage
+	found, err = s.provider.GetJSON(s.ctx, "empty", &empty)
+	s.NoError(err)
+	s.True(found)
+	s.Empty(empty.Items)
+
+	found, err = s.provider.GetJSON(s.ctx, "missing", &empty)
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *MemcachedProviderTestSuite) TestMany() {
+	long := strings.Repeat("k", memcachedMaxKeyLength+1)
+	s.Require().NoError(s.provider.SetMany(s.ctx, map[string]interface{}{
+		"page": `{"items":["a"]}`,
+		long:   utils.EmptyCacheValue,
+	}, time.Minute))
+	s.Equal(int32(60), s.client.items["page"].Expiration)
+
+	values, err := s.provider.GetMany(s.ctx, "page", long, "missing")
+	s.NoError(err)
+	s.Equal([]string{`{"items":["a"]}`, utils.EmptyCacheValue, ""}, values)
+}
+
+func (s *MemcachedProviderTestSuite) TestExpiration() {
+	s.Equal(int32(0), memcachedExpiration(0))
+	s.Equal(int32(1), memcachedExpiration(200*time.Millisecond))
+	s.Equal(int32(20), memcachedExpiration(20*time.Second))
+
+	// Past 30 days memcached reads expirations as unix times
+	ttl := 60 * 24 * time.Hour
+	s.InDelta(time.Now().Add(ttl).Unix(), int64(memcachedExpiration(ttl)), 1)
+}
+
+func (s *MemcachedProviderTestSuite) TestKeys() {
+	s.Equal("likerscount:user1", memcachedKey("likerscount:user1"))
+
+	long := memcachedKey(strings.Repeat("k", memcachedMaxKeyLength+1))
+	s.LessOrEqual(len(long), memcachedMaxKeyLength)
+	s.NotEqual(long, memcachedKey("has space"))
+	s.NotContains(memcachedKey("has space"), " ")
+}
+
+func (s *MemcachedProviderTestSuite) TestGetLikersPage_NotBuilt() {
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.False(built)
+	s.Empty(likers)
+}
+
+func (s *MemcachedProviderTestSuite) TestGetLikersPage_NewestFirst() {
+	s.buildIndex()
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 2})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{
+		{ActorID: "actor3", Timestamp: 300},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+	}, likers)
+}
+
+func (s *MemcachedProviderTestSuite) TestGetLikersPage_OldestFirstWithinBounds() {
+	s.buildIndex()
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{After: 100, Before: 300, Ascending: true, Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{{ActorID: "actor2", Timestamp: 200, LikedBack: true}}, likers)
+}
+
+func (s *MemcachedProviderTestSuite) TestGetLikersPage_BuiltEmpty() {
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", nil, time.Minute))
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Empty(likers)
+	s.Equal(int32(60), s.client.items[utils.LikersIndexKey(s.ctx, "recipient")].Expiration)
+}
+
+func (s *MemcachedProviderTestSuite) TestSetLikersIndex_KeepsBuiltIndex() {
+	s.buildIndex()
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor4", "recipient", 400))
+
+	// A build that read the likers before the index was built and actor4 liked is dropped
+	s.Require().NoError(s.provider.SetLikersIndex(s.ctx, "recipient", []models.Liker{{ActorID: "actor1", Timestamp: 100}}, time.Minute))
+
+	likers, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.True(built)
+	s.Equal([]models.Liker{
+		{ActorID: "actor4", Timestamp: 400},
+		{ActorID: "actor3", Timestamp: 300},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor1", Timestamp: 100},
+	}, likers)
+}
+
+func (s *MemcachedProviderTestSuite) TestAddLike_UpdatesBuiltIndexes() {
+	s.buildIndex()
+
+	// recipient likes actor1 back, then actor4 likes recipient
+	s.Require().NoError(s.provider.AddLike(s.ctx, "recipient", "actor1", 400))
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor4", "recipient", 500))
+
+	likers, _, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "actor4", Timestamp: 500},
+		{ActorID: "actor3", Timestamp: 300},
+		{ActorID: "actor2", Timestamp: 200, LikedBack: true},
+		{ActorID: "actor1", Timestamp: 100, LikedBack: true},
+	}, likers)
+
+	// The update keeps the index expiring when it was built to
+	s.InDelta(60, s.client.items[utils.LikersIndexKey(s.ctx, "recipient")].Expiration, 1)
+}
+
+func (s *MemcachedProviderTestSuite) TestAddLike_LeavesMissingIndexesAlone() {
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor1", "recipient", 100))
+
+	s.Empty(s.client.items)
+}
+
+func (s *MemcachedProviderTestSuite) TestAddLike_RetriesOnConflict() {
+	s.buildIndex()
+
+	// Another instance indexes actor5 between our read and our write
+	raced := false
+	s.client.beforeCAS = func() {
+		if !raced {
+			raced = true
+			s.client.beforeCAS = nil
+			s.Require().NoError(s.provider.AddLike(s.ctx, "actor5", "recipient", 600))
+		}
+	}
+	s.Require().NoError(s.provider.AddLike(s.ctx, "actor4", "recipient", 500))
+
+	likers, _, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 2})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "actor5", Timestamp: 600},
+		{ActorID: "actor4", Timestamp: 500},
+	}, likers)
+}
+
+func (s *MemcachedProviderTestSuite) TestRemoveLike() {
+	s.buildIndex()
+
+	// actor3 withdraws their like and recipient withdraws theirs on actor2
+	s.Require().NoError(s.provider.RemoveLike(s.ctx, "actor3", "recipient"))
+	s.Require().NoError(s.provider.RemoveLike(s.ctx, "recipient", "actor2"))
+
+	likers, _, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "actor2", Timestamp: 200},
+		{ActorID: "actor1", Timestamp: 100},
+	}, likers)
+}
+
+func (s *MemcachedProviderTestSuite) TestDelLikersIndex() {
+	s.buildIndex()
+
+	s.Require().NoError(s.provider.DelLikersIndex(s.ctx, "recipient", "actor1"))
+
+	_, built, err := s.provider.GetLikersPage(s.ctx, "recipient", models.LikersRange{Limit: 10})
+	s.NoError(err)
+	s.False(built)
+	s.Empty(s.client.items)
+}
+
+func (s *MemcachedProviderTestSuite) TestLikersCount() {
+	// A count that has not been seeded is left alone
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+	s.Empty(s.client.items)
+
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", -1))
+	s.Require().NoError(s.provider.IncrLikersCount(s.ctx, "recipient", 1))
+
+	// A later seed does not replace the live count
+	s.Require().NoError(s.provider.SeedLikersCount(s.ctx, "recipient", 5, time.Minute))
+
+	count, err := s.provider.Get(s.ctx, utils.LikersCountKey(s.ctx, "recipient"))
+	s.NoError(err)
+	s.Equal("6", count)
+}
+
+func (s *MemcachedProviderTestSuite) TestHotRecipients() {
+	window := time.Now().Truncate(utils.HotRecipientsWindow)
+	for _, recipient := range []string{"user1", "user2", "user2", "user3", "user3", "user3"} {
+		s.Require().NoError(s.provider.TrackHotRecipient(s.ctx, recipient, window))
+	}
+
+	recipients, err := s.provider.HotRecipients(s.ctx, window, 2)
+	s.NoError(err)
+	s.Equal([]string{"user3", "user2"}, recipients)
+
+	recipients, err = s.provider.HotRecipients(s.ctx, window.Add(-utils.HotRecipientsWindow), 2)
+	s.NoError(err)
+	s.Empty(recipients)
+}
+
+func (s *MemcachedProviderTestSuite) TestLock() {
+	key := utils.LockKey(s.ctx, "job")
+	token, acquired, err := s.provider.Lock(s.ctx, key, time.Minute)
+	s.Require().NoError(err)
+	s.Require().True(acquired)
+
+	_, acquired, err = s.provider.Lock(s.ctx, key, time.Minute)
+	s.NoError(err)
+	s.False(acquired)
+
+	// Only the holder's token releases the lock
+	s.Require().NoError(s.provider.Unlock(s.ctx, key, "other"))
+	_, acquired, err = s.provider.Lock(s.ctx, key, time.Minute)
+	s.NoError(err)
+	s.False(acquired)
+
+	s.Require().NoError(s.provider.Unlock(s.ctx, key, token))
+	_, acquired, err = s.provider.Lock(s.ctx, key, time.Minute)
+	s.NoError(err)
+	s.True(acquired)
+}
+
+func (s *MemcachedProviderTestSuite) TestAllow_CountsPerWindow() {
+	key := utils.RateLimitKey(s.ctx, "user:user123")
+	for range 3 {
+		allowed, _, err := s.provider.Allow(s.ctx, key, 3, 24*time.Hour, 1)
+		s.Require().NoError(err)
+		s.True(allowed)
+	}
+
+	allowed, retryAfter, err := s.provider.Allow(s.ctx, key, 3, 24*time.Hour, 1)
+	s.NoError(err)
+	s.False(allowed)
+	s.Positive(retryAfter)
+	s.LessOrEqual(retryAfter, 24*time.Hour)
+}
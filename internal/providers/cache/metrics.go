@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/backend-interview-task/internal/models"
+)
+
+var (
+	cacheCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "explore_cache_call_duration_seconds",
+		Help:    "Duration of cache calls, by operation.",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 2, 14),
+	}, []string{"op"})
+	cacheCallErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "explore_cache_call_errors_total",
+		Help: "Cache calls that failed, by operation, including those refused by the open circuit breaker.",
+	}, []string{"op"})
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "explore_cache_lookups_total",
+		Help: "Keys looked up in the cache, by operation and result: hit or miss. A failed lookup counts as a miss.",
+	}, []string{"op", "result"})
+	cacheBreakerState = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "explore_cache_breaker_state",
+		Help: "State of the circuit breaker guarding the cache: 0 closed, 1 half-open, 2 open.",
+	})
+)
+
+// observeCacheCall records the duration of the call to op started at started, and counts it as
+// failed when *err is set. It is deferred with a pointer to the call's error result.
+func observeCacheCall(op string, started time.Time, err *error) {
+	cacheCallDuration.WithLabelValues(op).Observe(time.Since(started).Seconds())
+	if *err != nil {
+		cacheCallErrors.WithLabelValues(op).Inc()
+	}
+}
+
+// observeLookups counts the hits and misses of a lookup of several keys
+func observeLookups(op string, found []bool, err error) {
+	hits := 0
+	if err == nil {
+		for _, ok := range found {
+			if ok {
+				hits++
+			}
+		}
+	}
+	cacheLookups.WithLabelValues(op, "hit").Add(float64(hits))
+	cacheLookups.WithLabelValues(op, "miss").Add(float64(len(found) - hits))
+}
+
+// instrumentedCacheProvider records the duration, failures and hit ratio of every cache call.
+// It fronts the whole cache, so hits of the in-process cache count as hits and calls refused by
+// the open breaker as failures, as the callers see them.
+type instrumentedCacheProvider struct {
+	CacheProvider
+}
+
+// NewInstrumentedCacheProvider exports the latency, errors and hit ratio of cache's calls to Prometheus
+func NewInstrumentedCacheProvider(cache CacheProvider) CacheProvider {
+	return &instrumentedCacheProvider{CacheProvider: cache}
+}
+
+func (c *instrumentedCacheProvider) Get(ctx context.Context, key string) (_ string, err error) {
+	defer observeCacheCall("Get", time.Now(), &err)
+	val, err := c.CacheProvider.Get(ctx, key)
+	observeLookups("Get", []bool{val != ""}, err)
+	return val, err
+}
+
+func (c *instrumentedCacheProvider) GetMany(ctx context.Context, keys ...string) (_ []string, err error) {
+	defer observeCacheCall("GetMany", time.Now(), &err)
+	values, err := c.CacheProvider.GetMany(ctx, keys...)
+	found := make([]bool, len(keys))
+	for i := range found {
+		found[i] = i < len(values) && values[i] != ""
+	}
+	observeLookups("GetMany", found, err)
+	return values, err
+}
+
+func (c *instrumentedCacheProvider) GetJSON(ctx context.Context, key string, out any) (_ bool, err error) {
+	defer observeCacheCall("GetJSON", time.Now(), &err)
+	found, err := c.CacheProvider.GetJSON(ctx, key, out)
+	observeLookups("GetJSON", []bool{found}, err)
+	return found, err
+}
+
+func (c *instrumentedCacheProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) (_ []models.Liker, _ bool, err error) {
+	defer observeCacheCall("GetLikersPage", time.Now(), &err)
+	likers, built, err := c.CacheProvider.GetLikersPage(ctx, recipient, page)
+	observeLookups("GetLikersPage", []bool{built}, err)
+	return likers, built, err
+}
+
+func (c *instrumentedCacheProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) (err error) {
+	defer observeCacheCall("Set", time.Now(), &err)
+	return c.CacheProvider.Set(ctx, key, value, expiration)
+}
+
+func (c *instrumentedCacheProvider) Del(ctx context.Context, keys ...string) (err error) {
+	defer observeCacheCall("Del", time.Now(), &err)
+	return c.CacheProvider.Del(ctx, keys...)
+}
+
+func (c *instrumentedCacheProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) (err error) {
+	defer observeCacheCall("SetMany", time.Now(), &err)
+	return c.CacheProvider.SetMany(ctx, values, expiration)
+}
+
+func (c *instrumentedCacheProvider) SetJSON(ctx context.Context, key string, val any, ttl time.Duration) (err error) {
+	defer observeCacheCall("SetJSON", time.Now(), &err)
+	return c.CacheProvider.SetJSON(ctx, key, val, ttl)
+}
+
+func (c *instrumentedCacheProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) (err error) {
+	defer observeCacheCall("SetLikersIndex", time.Now(), &err)
+	return c.CacheProvider.SetLikersIndex(ctx, recipient, likers, ttl)
+}
+
+func (c *instrumentedCacheProvider) AddLike(ctx context.Context, actor string, recipient string, likedAt int64) (err error) {
+	defer observeCacheCall("AddLike", time.Now(), &err)
+	return c.CacheProvider.AddLike(ctx, actor, recipient, likedAt)
+}
+
+func (c *instrumentedCacheProvider) RemoveLike(ctx context.Context, actor string, recipient string) (err error) {
+	defer observeCacheCall("RemoveLike", time.Now(), &err)
+	return c.CacheProvider.RemoveLike(ctx, actor, recipient)
+}
+
+func (c *instrumentedCacheProvider) DelLikersIndex(ctx context.Context, users ...string) (err error) {
+	defer observeCacheCall("DelLikersIndex", time.Now(), &err)
+	return c.CacheProvider.DelLikersIndex(ctx, users...)
+}
+
+func (c *instrumentedCacheProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) (err error) {
+	defer observeCacheCall("SeedLikersCount", time.Now(), &err)
+	return c.CacheProvider.SeedLikersCount(ctx, recipient, count, ttl)
+}
+
+func (c *instrumentedCacheProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) (err error) {
+	defer observeCacheCall("IncrLikersCount", time.Now(), &err)
+	return c.CacheProvider.IncrLikersCount(ctx, recipient, delta)
+}
+
+func (c *instrumentedCacheProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) (err error) {
+	defer observeCacheCall("TrackHotRecipient", time.Now(), &err)
+	return c.CacheProvider.TrackHotRecipient(ctx, recipient, at)
+}
+
+func (c *instrumentedCacheProvider) HotRecipients(ctx context.Context, window time.Time, limit int) (_ []string, err error) {
+	defer observeCacheCall("HotRecipients", time.Now(), &err)
+	return c.CacheProvider.HotRecipients(ctx, window, limit)
+}
+
+func (c *instrumentedCacheProvider) Lock(ctx context.Context, key string, ttl time.Duration) (_ string, _ bool, err error) {
+	defer observeCacheCall("Lock", time.Now(), &err)
+	return c.CacheProvider.Lock(ctx, key, ttl)
+}
+
+func (c *instrumentedCacheProvider) Unlock(ctx context.Context, key string, token string) (err error) {
+	defer observeCacheCall("Unlock", time.Now(), &err)
+	return c.CacheProvider.Unlock(ctx, key, token)
+}
+
+func (c *instrumentedCacheProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (_ bool, _ time.Duration, err error) {
+	defer observeCacheCall("Allow", time.Now(), &err)
+	return c.CacheProvider.Allow(ctx, key, limit, period, burst)
+}
+
+func (c *instrumentedCacheProvider) Ping(ctx context.Context) (err error) {
+	defer observeCacheCall("Ping", time.Now(), &err)
+	return c.CacheProvider.Ping(ctx)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+type InstrumentedCacheTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+func TestInstrumentedCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(InstrumentedCacheTestSuite))
+}
+
+func (s *InstrumentedCacheTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	remote, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = NewInstrumentedCacheProvider(remote)
+}
+
+// lookups returns the hits and misses counted for op
+func (s *InstrumentedCacheTestSuite) lookups(op string) (float64, float64) {
+	return testutil.ToFloat64(cacheLookups.WithLabelValues(op, "hit")), testutil.ToFloat64(cacheLookups.WithLabelValues(op, "miss"))
+}
+
+func (s *InstrumentedCacheTestSuite) TestCountsHitsAndMisses() {
+	s.Require().NoError(s.provider.SetJSON(s.ctx, "page:1", cachedPage{Items: []string{"a"}}, time.Minute))
+	hits, misses := s.lookups("GetMany")
+
+	_, err := s.provider.GetMany(s.ctx, "page:1", "page:2")
+
+	s.Require().NoError(err)
+	afterHits, afterMisses := s.lookups("GetMany")
+	s.Equal(1.0, afterHits-hits)
+	s.Equal(1.0, afterMisses-misses)
+}
+
+func (s *InstrumentedCacheTestSuite) TestCountsFailures() {
+	failures := testutil.ToFloat64(cacheCallErrors.WithLabelValues("Set"))
+	_, misses := s.lookups("Get")
+	s.server.Close()
+
+	s.Error(s.provider.Set(s.ctx, "key", "value", time.Minute))
+	_, err := s.provider.Get(s.ctx, "key")
+
+	s.Error(err)
+	s.Equal(1.0, testutil.ToFloat64(cacheCallErrors.WithLabelValues("Set"))-failures)
+	_, afterMisses := s.lookups("Get")
+	s.Equal(1.0, afterMisses-misses)
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/backend-interview-task/internal/models"
+)
+
+// noopProvider implements the CacheProvider interface without a cache: every read misses and
+// every write is discarded, so that all requests are served from the DB.
+type noopProvider struct{}
+
+// NewNoopCacheProvider returns a CacheProvider that caches nothing, for running without a cache
+func NewNoopCacheProvider() CacheProvider {
+	return noopProvider{}
+}
+
+func (noopProvider) Get(ctx context.Context, key string) (string, error) {
+	return "", nil
+}
+
+func (noopProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return nil
+}
+
+func (noopProvider) Del(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+func (noopProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	return make([]string, len(keys)), nil
+}
+
+func (noopProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	return nil
+}
+
+func (noopProvider) GetJSON(ctx context.Context, key string, out any) (bool, error) {
+	return false, nil
+}
+
+func (noopProvider) SetJSON(ctx context.Context, key string, val any, ttl time.Duration) error {
+	return nil
+}
+
+func (noopProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error) {
+	return nil, false, nil
+}
+
+func (noopProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error {
+	return nil
+}
+
+func (noopProvider) AddLike(ctx context.Context, actor string, recipient string, likedAt int64) error {
+	return nil
+}
+
+func (noopProvider) RemoveLike(ctx context.Context, actor string, recipient string) error {
+	return nil
+}
+
+func (noopProvider) DelLikersIndex(ctx context.Context, users ...string) error {
+	return nil
+}
+
+func (noopProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	return nil
+}
+
+func (noopProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) error {
+	return nil
+}
+
+func (noopProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error {
+	return nil
+}
+
+func (noopProvider) HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error) {
+	return nil, nil
+}
+
+// Lock always succeeds: without a cache there is nothing to coordinate through, so every
+// replica runs its jobs as it would without locking
+func (noopProvider) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	return newLockToken(), true, nil
+}
+
+func (noopProvider) Unlock(ctx context.Context, key string, token string) error {
+	return nil
+}
+
+func (noopProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error) {
+	return true, 0, nil
+}
+
+func (noopProvider) Ping(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// gcraScript admits a request under the generic cell rate algorithm: the key holds the
+// theoretical arrival time of the next request, in microseconds of the Redis clock so that
+// every replica judges against the same time, and each admitted request pushes it one
+// emission interval further. A request is refused when that would put it more than burst
+// intervals ahead of now.
+// KEYS: bucket. ARGV: emission interval (µs), burst.
+// Returns: 1 if admitted, else 0, and the µs to wait before a request would be admitted.
+var gcraScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if tat == nil or tat < now then
+	tat = now
+end
+local next_tat = tat + interval
+local ahead = next_tat - now
+if ahead > burst * interval then
+	return {0, ahead - burst * interval}
+end
+
+redis.call('SET', KEYS[1], string.format('%.0f', next_tat), 'PX', math.ceil(ahead / 1000))
+return {1, 0}
+`)
+
+// Allow admits a request against the rate limit under key, which lets limit requests through
+// per period and up to burst of them at once. When the request is refused, it returns how long
+// to wait before one would be admitted.
+func (r *redisProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error) {
+	interval := period.Microseconds() / int64(limit)
+	if interval < 1 {
+		interval = 1
+	}
+	res, err := gcraScript.Run(ctx, r.client, []string{key}, interval, burst).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Microsecond, nil
+}
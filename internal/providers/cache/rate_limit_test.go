@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+	now      time.Time
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+	s.now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.server.SetTime(s.now)
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+// advance moves the Redis clock, and the key expirations with it, forward by d
+func (s *RateLimitTestSuite) advance(d time.Duration) {
+	s.now = s.now.Add(d)
+	s.server.SetTime(s.now)
+	s.server.FastForward(d)
+}
+
+func (s *RateLimitTestSuite) TestAllow_BurstThenRate() {
+	key := utils.RateLimitKey(s.ctx, "user:user123")
+
+	// 60 per minute is one per second, with up to 3 at once
+	for range 3 {
+		allowed, _, err := s.provider.Allow(s.ctx, key, 60, time.Minute, 3)
+		s.Require().NoError(err)
+		s.True(allowed)
+	}
+	allowed, retryAfter, err := s.provider.Allow(s.ctx, key, 60, time.Minute, 3)
+	s.NoError(err)
+	s.False(allowed)
+	s.Equal(time.Second, retryAfter)
+
+	s.advance(time.Second)
+	allowed, _, err = s.provider.Allow(s.ctx, key, 60, time.Minute, 3)
+	s.NoError(err)
+	s.True(allowed)
+	allowed, _, err = s.provider.Allow(s.ctx, key, 60, time.Minute, 3)
+	s.NoError(err)
+	s.False(allowed)
+}
+
+func (s *RateLimitTestSuite) TestAllow_RefusedRequestsDoNotCount() {
+	key := utils.RateLimitKey(s.ctx, "user:user123")
+	_, _, err := s.provider.Allow(s.ctx, key, 60, time.Minute, 1)
+	s.Require().NoError(err)
+	for range 5 {
+		allowed, _, err := s.provider.Allow(s.ctx, key, 60, time.Minute, 1)
+		s.Require().NoError(err)
+		s.False(allowed)
+	}
+
+	s.advance(time.Second)
+	allowed, _, err := s.provider.Allow(s.ctx, key, 60, time.Minute, 1)
+	s.NoError(err)
+	s.True(allowed)
+}
+
+func (s *RateLimitTestSuite) TestAllow_KeyExpiresOnceIdle() {
+	key := utils.RateLimitKey(s.ctx, "user:user123")
+	for range 3 {
+		_, _, err := s.provider.Allow(s.ctx, key, 60, time.Minute, 3)
+		s.Require().NoError(err)
+	}
+	s.Equal(3*time.Second, s.server.TTL(key))
+
+	s.advance(3 * time.Second)
+	s.False(s.server.Exists(key))
+}
+
+func (s *RateLimitTestSuite) TestAllow_CallersAreLimitedApart() {
+	first := utils.RateLimitKey(s.ctx, "user:user1")
+	second := utils.RateLimitKey(s.ctx, "user:user2")
+	allowed, _, err := s.provider.Allow(s.ctx, first, 60, time.Minute, 1)
+	s.Require().NoError(err)
+	s.Require().True(allowed)
+
+	allowed, _, err = s.provider.Allow(s.ctx, second, 60, time.Minute, 1)
+	s.NoError(err)
+	s.True(allowed)
+}
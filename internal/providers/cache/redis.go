@@ -2,26 +2,67 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
 )
 
 // redisProvider implements the CacheProvider interface using the go-redis library.
+// Commands never span the keys of different users, so that they also run on Redis Cluster.
 type redisProvider struct {
-	client *redis.Client
+	client redis.UniversalClient
 	logger *zap.Logger
 }
 
-// NewRedisCacheProvider creates and returns a redisProvider strucy that satisfies the CacheProvider interface.
-func NewRedisCacheProvider(ctx context.Context, address string, password string, logger *zap.Logger) (CacheProvider, error) {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     address,
-		Password: password,
-	})
+// NewRedisCacheProvider creates and returns a redisProvider struct that satisfies the CacheProvider interface.
+// It connects to the Redis Cluster at cfg.ClusterAddresses if any are set, otherwise to the single Redis at cfg.Address.
+func NewRedisCacheProvider(ctx context.Context, cfg config.RedisConfig, logger *zap.Logger) (CacheProvider, error) {
+	tlsConfig, err := newRedisTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	var rdb redis.UniversalClient
+	if len(cfg.ClusterAddresses) > 0 {
+		rdb = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.ClusterAddresses,
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			TLSConfig:    tlsConfig,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  cfg.DialTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			MaxRetries:   cfg.MaxRetries,
+		})
+	} else {
+		rdb = redis.NewClient(&redis.Options{
+			Addr:         cfg.Address,
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			TLSConfig:    tlsConfig,
+			DB:           cfg.DB,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  cfg.DialTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			MaxRetries:   cfg.MaxRetries,
+		})
+	}
+
+	rdb.AddHook(newTracingHook())
 
 	if _, err := rdb.Ping(ctx).Result(); err != nil {
 		return nil, err
@@ -33,6 +74,40 @@ func NewRedisCacheProvider(ctx context.Context, address string, password string,
 	}, nil
 }
 
+// newRedisTLSConfig builds the TLS configuration of redis connections, or returns nil when TLS is disabled.
+// The server name is left for the client to take from each node's address.
+func newRedisTLSConfig(cfg config.RedisTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redis CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in redis CA file %s", cfg.CAFile)
+		}
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 // Get retrieves a value from Redis.
 func (r *redisProvider) Get(ctx context.Context, key string) (string, error) {
 	val, err := r.client.Get(ctx, key).Result()
@@ -48,19 +123,70 @@ func (r *redisProvider) Set(ctx context.Context, key string, value interface{},
 }
 
 // Del deletes one or more keys from Redis.
+// The keys are deleted one by one in a pipeline, since on a cluster they may live in different slots.
 func (r *redisProvider) Del(ctx context.Context, keys ...string) error {
-	return r.client.Del(ctx, keys...).Err()
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+		return nil
+	})
+	return err
+}
+
+// GetMany retrieves the values of several keys from Redis in one round trip, with an empty string for each missing key.
+// The keys are read one by one in a pipeline rather than with MGET, since on a cluster they may live in different slots.
+func (r *redisProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	values := make([]string, len(keys))
+	for i, cmd := range cmds {
+		values[i], err = cmd.Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// SetMany stores several values in Redis with the same expiration in one round trip.
+func (r *redisProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			pipe.Set(ctx, key, value, expiration)
+		}
+		return nil
+	})
+	return err
 }
 
 // GetJSON retrieves a JSON value from Redis and unmarshals it into the provided output.
+// A cached empty result is reported as found, leaving the output as its zero value.
 func (r *redisProvider) GetJSON(ctx context.Context, key string, out any) (bool, error) {
 	raw, err := r.Get(ctx, key)
 	if err != nil {
 		return false, err
 	}
+	return decodeJSON(raw, out)
+}
+
+// decodeJSON unmarshals a raw cached value into out and reports whether there was one
+func decodeJSON(raw string, out any) (bool, error) {
 	if raw == "" {
 		return false, nil
 	}
+	if raw == utils.EmptyCacheValue {
+		return true, nil
+	}
 	if err := json.Unmarshal([]byte(raw), out); err != nil {
 		return false, err
 	}
@@ -75,3 +201,8 @@ func (r *redisProvider) SetJSON(ctx context.Context, key string, val any, ttl ti
 	}
 	return r.Set(ctx, key, string(b), ttl)
 }
+
+// Ping checks that Redis answers
+func (r *redisProvider) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type RedisProviderTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	provider CacheProvider
+	ctx      context.Context
+}
+
+type cachedPage struct {
+	Items []string `json:"items"`
+}
+
+func TestRedisProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisProviderTestSuite))
+}
+
+func (s *RedisProviderTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.ctx = context.Background()
+
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{Address: s.server.Addr()}, zap.NewNop())
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *RedisProviderTestSuite) TestGetJSON_Missing() {
+	var page cachedPage
+	found, err := s.provider.GetJSON(s.ctx, "page", &page)
+
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *RedisProviderTestSuite) TestGetJSON_RoundTrip() {
+	s.Require().NoError(s.provider.SetJSON(s.ctx, "page", cachedPage{Items: []string{"a", "b"}}, time.Minute))
+
+	var page cachedPage
+	found, err := s.provider.GetJSON(s.ctx, "page", &page)
+
+	s.NoError(err)
+	s.True(found)
+	s.Equal([]string{"a", "b"}, page.Items)
+}
+
+func (s *RedisProviderTestSuite) TestGetJSON_EmptySentinel() {
+	s.Require().NoError(s.provider.Set(s.ctx, "page", utils.EmptyCacheValue, utils.EmptyResultTTL))
+
+	var page cachedPage
+	found, err := s.provider.GetJSON(s.ctx, "page", &page)
+
+	s.NoError(err)
+	s.True(found)
+	s.Empty(page.Items)
+	s.Equal(utils.EmptyResultTTL, s.server.TTL("page"))
+}
+
+func (s *RedisProviderTestSuite) TestSetMany() {
+	s.Require().NoError(s.provider.SetMany(s.ctx, map[string]interface{}{"a": "1", "b": 2}, time.Minute))
+
+	values, err := s.provider.GetMany(s.ctx, "a", "missing", "b")
+
+	s.NoError(err)
+	s.Equal([]string{"1", "", "2"}, values)
+	s.Equal(time.Minute, s.server.TTL("b"))
+}
+
+func (s *RedisProviderTestSuite) TestGetMany_RemoteDown() {
+	s.server.Close()
+
+	_, err := s.provider.GetMany(s.ctx, "a", "b")
+	s.Error(err)
+}
+
+func (s *RedisProviderTestSuite) TestAppliesConnectionOptions() {
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{
+		Address:      s.server.Addr(),
+		DB:           3,
+		PoolSize:     20,
+		MinIdleConns: 5,
+		DialTimeout:  time.Second,
+		ReadTimeout:  200 * time.Millisecond,
+		WriteTimeout: 300 * time.Millisecond,
+		MaxRetries:   -1,
+	}, zap.NewNop())
+	s.Require().NoError(err)
+	s.Require().NoError(provider.Set(s.ctx, "key", "value", time.Minute))
+
+	s.server.Select(3)
+	s.True(s.server.Exists("key"))
+	options := provider.(*redisProvider).client.(*redis.Client).Options()
+	s.Equal(20, options.PoolSize)
+	s.Equal(5, options.MinIdleConns)
+	s.Equal(time.Second, options.DialTimeout)
+	s.Equal(200*time.Millisecond, options.ReadTimeout)
+	s.Equal(300*time.Millisecond, options.WriteTimeout)
+	s.Equal(0, options.MaxRetries)
+}
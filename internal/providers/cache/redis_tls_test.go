@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+type RedisTLSTestSuite struct {
+	suite.Suite
+	server *miniredis.Miniredis
+	caFile string
+	ctx    context.Context
+}
+
+func TestRedisTLSTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisTLSTestSuite))
+}
+
+func (s *RedisTLSTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	dir := s.T().TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	s.Require().NoError(err)
+	s.caFile = filepath.Join(dir, "ca.pem")
+	s.Require().NoError(os.WriteFile(s.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "redis"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caTemplate, &serverKey.PublicKey, caKey)
+	s.Require().NoError(err)
+
+	s.server, err = miniredis.RunTLS(&tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverDER}, PrivateKey: serverKey}},
+	})
+	s.Require().NoError(err)
+	s.T().Cleanup(s.server.Close)
+	s.server.RequireUserAuth("app", "secret")
+}
+
+func (s *RedisTLSTestSuite) TestConnect_VerifiesServerAgainstCA() {
+	provider, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{
+		Address:  s.server.Addr(),
+		Username: "app",
+		Password: "secret",
+		TLS:      config.RedisTLSConfig{Enabled: true, CAFile: s.caFile},
+	}, zap.NewNop())
+	s.Require().NoError(err)
+
+	s.Require().NoError(provider.Set(s.ctx, "key", "value", time.Minute))
+	val, err := provider.Get(s.ctx, "key")
+	s.NoError(err)
+	s.Equal("value", val)
+}
+
+func (s *RedisTLSTestSuite) TestConnect_WrongUserRejected() {
+	_, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{
+		Address:  s.server.Addr(),
+		Username: "other",
+		Password: "secret",
+		TLS:      config.RedisTLSConfig{Enabled: true, CAFile: s.caFile},
+	}, zap.NewNop())
+	s.Error(err)
+}
+
+func (s *RedisTLSTestSuite) TestConnect_UnknownCARejected() {
+	_, err := NewRedisCacheProvider(s.ctx, config.RedisConfig{
+		Address:  s.server.Addr(),
+		Username: "app",
+		Password: "secret",
+		TLS:      config.RedisTLSConfig{Enabled: true},
+	}, zap.NewNop())
+	s.Error(err)
+}
+
+func (s *RedisTLSTestSuite) TestNewRedisTLSConfig_Disabled() {
+	tlsConfig, err := newRedisTLSConfig(config.RedisTLSConfig{CAFile: s.caFile})
+	s.NoError(err)
+	s.Nil(tlsConfig)
+}
+
+func (s *RedisTLSTestSuite) TestNewRedisTLSConfig_MissingCAFile() {
+	_, err := newRedisTLSConfig(config.RedisTLSConfig{Enabled: true, CAFile: filepath.Join(s.T().TempDir(), "missing.pem")})
+	s.Error(err)
+}
+
+func (s *RedisTLSTestSuite) TestNewRedisTLSConfig_CAFileWithoutCertificates() {
+	caFile := filepath.Join(s.T().TempDir(), "ca.pem")
+	s.Require().NoError(os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+
+	_, err := newRedisTLSConfig(config.RedisTLSConfig{Enabled: true, CAFile: caFile})
+	s.Error(err)
+}
+
+func (s *RedisTLSTestSuite) TestNewRedisTLSConfig_KeyWithoutCertificate() {
+	_, err := newRedisTLSConfig(config.RedisTLSConfig{Enabled: true, KeyFile: s.caFile})
+	s.Error(err)
+}
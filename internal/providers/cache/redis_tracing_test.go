@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type RedisTracingTestSuite struct {
+	suite.Suite
+	server   *miniredis.Miniredis
+	recorder *tracetest.SpanRecorder
+	provider *redisProvider
+	ctx      context.Context
+}
+
+func TestRedisTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisTracingTestSuite))
+}
+
+func (s *RedisTracingTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.recorder = tracetest.NewSpanRecorder()
+	s.ctx = context.Background()
+
+	client := redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)).Tracer("test")
+	client.AddHook(tracingHook{tracer: tracer})
+	s.provider = &redisProvider{client: client}
+}
+
+func (s *RedisTracingTestSuite) TestCommandSpan() {
+	s.Require().NoError(s.provider.Set(s.ctx, "likers:u1", "1", 0))
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("redis set", spans[0].Name())
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *RedisTracingTestSuite) TestMissIsNotAnError() {
+	_, err := s.provider.Get(s.ctx, "likers:u1")
+	s.Require().NoError(err)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *RedisTracingTestSuite) TestPipelineSpan() {
+	_, err := s.provider.GetMany(s.ctx, "a", "b")
+	s.Require().NoError(err)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("redis pipeline", spans[0].Name())
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *RedisTracingTestSuite) TestFailureMarksSpan() {
+	s.server.Close()
+
+	s.Error(s.provider.Set(s.ctx, "likers:u1", "1", 0))
+
+	spans := s.recorder.Ended()
+	s.Require().NotEmpty(spans)
+	s.Equal(codes.Error, spans[len(spans)-1].Status().Code)
+}
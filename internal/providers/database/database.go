@@ -2,19 +2,17 @@ package database
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
+	"net"
+	"net/url"
+	"strings"
 
-	"github.com/golang-migrate/migrate/v4"
+	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-
 	"github.com/backend-interview-task/config"
 )
 
@@ -24,10 +22,13 @@ type pgxPool struct {
 
 // NewDBProvider return pgx connection pool instance
 func NewDBProvider(cfg config.DatabaseConfig, logger *zap.Logger) (DBProvider, error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName, cfg.SSLMode)
+	return newPgxPool(cfg, "", logger)
+}
 
-	poolConfig, err := pgxpool.ParseConfig(dsn)
+// newPgxPool connects a pool whose connections look tables up in schema, or in the default
+// search path when schema is empty
+func newPgxPool(cfg config.DatabaseConfig, schema string, logger *zap.Logger) (*pgxPool, error) {
+	poolConfig, err := pgxpool.ParseConfig(dataSourceName(cfg, schema))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse pgx config: %w", err)
 	}
@@ -35,6 +36,13 @@ func NewDBProvider(cfg config.DatabaseConfig, logger *zap.Logger) (DBProvider, e
 	poolConfig.MaxConns = int32(cfg.MaxOpenConns)
 	poolConfig.MinConns = int32(cfg.MaxIdleConns)
 
+	if err := setQueryExecMode(poolConfig.ConnConfig, cfg.QueryExecMode); err != nil {
+		return nil, err
+	}
+	// Spans go to the global tracer provider, a no-op unless tracing is enabled. Query
+	// parameters are left out of them, as they hold user IDs.
+	poolConfig.ConnConfig.Tracer = otelpgx.NewTracer(otelpgx.WithSpanNameFunc(querySpanName))
+
 	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
@@ -45,13 +53,83 @@ func NewDBProvider(cfg config.DatabaseConfig, logger *zap.Logger) (DBProvider, e
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	logger.Info("Database connection pool established")
+	logger.Info("Database connection pool established", zap.String("schema", schema))
 
 	return &pgxPool{
 		Pool: pool,
 	}, nil
 }
 
+// querySpanName names the span of a query after the sqlc query it runs, taken from its leading
+// "-- name: GetLikers :many" comment, or else after its first keyword
+func querySpanName(sql string) string {
+	if rest, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		if name, _, ok := strings.Cut(rest, " "); ok && name != "" {
+			return name
+		}
+	}
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
+
+// dataSourceName builds the connection URL of the database. The certificates are passed as the
+// libpq sslrootcert, sslcert and sslkey parameters, understood by both pgx and the migrations driver.
+// A schema is set as the search_path of the connections.
+func dataSourceName(cfg config.DatabaseConfig, schema string) string {
+	params := url.Values{}
+	params.Set("sslmode", cfg.SSLMode)
+	if cfg.TLS.CAFile != "" {
+		params.Set("sslrootcert", cfg.TLS.CAFile)
+	}
+	if cfg.TLS.CertFile != "" {
+		params.Set("sslcert", cfg.TLS.CertFile)
+	}
+	if cfg.TLS.KeyFile != "" {
+		params.Set("sslkey", cfg.TLS.KeyFile)
+	}
+	if schema != "" {
+		params.Set("search_path", schema)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, cfg.Port),
+		Path:     "/" + cfg.DBName,
+		RawQuery: params.Encode(),
+	}
+	return dsn.String()
+}
+
+// setQueryExecMode applies the configured query exec mode. Modes that prepare nothing on the
+// server also get no statement or description caches, as a pooler hands each transaction to
+// whichever server connection is free and cached statements would not exist there.
+func setQueryExecMode(connConfig *pgx.ConnConfig, mode string) error {
+	switch mode {
+	case "", "cache_statement":
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	case "cache_describe":
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheDescribe
+	case "describe_exec":
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+	case "exec":
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	case "simple_protocol":
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	default:
+		return fmt.Errorf("unknown query exec mode %q", mode)
+	}
+
+	switch connConfig.DefaultQueryExecMode {
+	case pgx.QueryExecModeExec, pgx.QueryExecModeSimpleProtocol:
+		connConfig.StatementCacheCapacity = 0
+		connConfig.DescriptionCacheCapacity = 0
+	}
+	return nil
+}
+
 func (p *pgxPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
 	return p.Pool.QueryRow(ctx, sql, args...)
 }
@@ -64,34 +142,18 @@ func (p *pgxPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows,
 	return p.Pool.Query(ctx, sql, args...)
 }
 
-func (p *pgxPool) Close() {
-	p.Pool.Close()
+func (p *pgxPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.Pool.Begin(ctx)
 }
 
-// RunMigrations applies all up migrations from the migrations folder.
-func RunMigrations(cfg config.DatabaseConfig) {
-	log.Println("Starting database migrations...")
-
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName, cfg.SSLMode)
-
-	m, err := migrate.New(
-		"file://db/migrations",
-		dsn,
-	)
-	if err != nil {
-		log.Fatalf("Failed to create migrate instance: %v", err)
-	}
+func (p *pgxPool) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return p.Pool.SendBatch(ctx, b)
+}
 
-	// Apply all available up migrations
-	if err := m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			log.Println("No new migrations to apply.")
-			return
-		} else {
-			log.Fatalf("Failed to apply migrations: %v", err)
-		}
-	}
+func (p *pgxPool) Ping(ctx context.Context) error {
+	return p.Pool.Ping(ctx)
+}
 
-	log.Println("Database migrations applied successfully.")
+func (p *pgxPool) Close() {
+	p.Pool.Close()
 }
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// Storages the decisions can be kept in, selected by storage.driver
+const (
+	// StorageDatabase keeps the decisions in the relational database of database.driver
+	StorageDatabase = "database"
+	// StorageDynamoDB keeps the decisions in a DynamoDB table, for the serverless deployment,
+	// without the features built on Postgres itself: tenant schemas, the admin RPCs and the
+	// change feed
+	StorageDynamoDB = "dynamodb"
+)
+
+// NewDynamoDBClient returns a DynamoDB client with the credentials of the default AWS chain and
+// checks that the table of cfg is reachable
+func NewDynamoDBClient(ctx context.Context, cfg config.DynamoDBConfig, logger *zap.Logger) (*dynamodb.Client, error) {
+	var options []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		options = append(options, awsconfig.WithRegion(cfg.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the AWS config: %w", err)
+	}
+
+	client := dynamodb.NewFromConfig(awsConfig, func(o *dynamodb.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	})
+	if err := PingDynamoDB(ctx, client, cfg.Table); err != nil {
+		return nil, err
+	}
+
+	logger.Info("DynamoDB table reachable", zap.String("table", cfg.Table))
+
+	return client, nil
+}
+
+// PingDynamoDB checks that the table exists and is active
+func PingDynamoDB(ctx context.Context, client *dynamodb.Client, table string) error {
+	output, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+	if status := output.Table.TableStatus; status != types.TableStatusActive && status != types.TableStatusUpdating {
+		return fmt.Errorf("table %s is %s", table, status)
+	}
+	return nil
+}
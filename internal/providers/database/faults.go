@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/faults"
+)
+
+// faultyDBProvider injects faults into the calls to the database, for chaos testing. Failed
+// calls fail with a serialization failure, which guarantees nothing was written and is retried
+// by the repository. Statements run in a transaction are only faulted through Begin.
+type faultyDBProvider struct {
+	DBProvider
+	injector *faults.Injector
+}
+
+// NewFaultyDBProvider injects the faults of cfg into the calls to db
+func NewFaultyDBProvider(db DBProvider, cfg config.FaultConfig) DBProvider {
+	return &faultyDBProvider{
+		DBProvider: db,
+		injector:   faults.NewInjector(faults.TargetDatabase, cfg),
+	}
+}
+
+// call runs the injected latency and failure of a call
+func (p *faultyDBProvider) call(ctx context.Context) error {
+	err := p.injector.Call(ctx)
+	if errors.Is(err, faults.ErrInjected) {
+		return fmt.Errorf("%w: %w", err, &pgconn.PgError{Severity: "ERROR", Code: "40001", Message: "injected serialization failure"})
+	}
+	return err
+}
+
+func (p *faultyDBProvider) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if err := p.call(ctx); err != nil {
+		return errRow{err: err}
+	}
+	return p.DBProvider.QueryRow(ctx, sql, args...)
+}
+
+func (p *faultyDBProvider) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if err := p.call(ctx); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return p.DBProvider.Exec(ctx, sql, args...)
+}
+
+func (p *faultyDBProvider) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if err := p.call(ctx); err != nil {
+		return nil, err
+	}
+	return p.DBProvider.Query(ctx, sql, args...)
+}
+
+func (p *faultyDBProvider) Begin(ctx context.Context) (pgx.Tx, error) {
+	if err := p.call(ctx); err != nil {
+		return nil, err
+	}
+	return p.DBProvider.Begin(ctx)
+}
+
+func (p *faultyDBProvider) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	if err := p.call(ctx); err != nil {
+		return errBatchResults{err: err}
+	}
+	return p.DBProvider.SendBatch(ctx, b)
+}
+
+func (p *faultyDBProvider) Ping(ctx context.Context) error {
+	if err := p.call(ctx); err != nil {
+		return err
+	}
+	return p.DBProvider.Ping(ctx)
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/faults"
+)
+
+type FaultyDBProviderTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	ctx  context.Context
+}
+
+func TestFaultyDBProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(FaultyDBProviderTestSuite))
+}
+
+func (s *FaultyDBProviderTestSuite) SetupTest() {
+	var err error
+	s.mock, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+	s.ctx = context.Background()
+}
+
+func (s *FaultyDBProviderTestSuite) TearDownTest() {
+	s.NoError(s.mock.ExpectationsWereMet())
+	s.mock.Close()
+}
+
+func (s *FaultyDBProviderTestSuite) TestPassesCallsThroughWithoutFaults() {
+	provider := NewFaultyDBProvider(s.mock, config.FaultConfig{})
+	s.mock.ExpectExec("DELETE FROM decisions").WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	tag, err := provider.Exec(s.ctx, "DELETE FROM decisions")
+	s.NoError(err)
+	s.Equal(int64(1), tag.RowsAffected())
+}
+
+func (s *FaultyDBProviderTestSuite) TestFailsWithSerializationFailure() {
+	provider := NewFaultyDBProvider(s.mock, config.FaultConfig{ErrorRate: 1})
+
+	_, err := provider.Exec(s.ctx, "DELETE FROM decisions")
+	s.ErrorIs(err, faults.ErrInjected)
+	var pgErr *pgconn.PgError
+	s.Require().True(errors.As(err, &pgErr))
+	s.Equal("40001", pgErr.Code)
+
+	var exists bool
+	s.ErrorIs(provider.QueryRow(s.ctx, "SELECT true").Scan(&exists), faults.ErrInjected)
+	_, err = provider.Begin(s.ctx)
+	s.ErrorIs(err, faults.ErrInjected)
+	_, err = provider.Query(s.ctx, "SELECT 1")
+	s.ErrorIs(err, faults.ErrInjected)
+}
@@ -7,9 +7,17 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// DBProvider runs queries on the pool, or on the schema of the request's tenant. Statements
+// that must commit together run in a transaction from Begin, or from pgx.BeginFunc, which takes
+// a DBProvider and commits unless the function fails; SendBatch sends several statements in one
+// round trip. BeginFunc is left to pgx rather than added here, so that pgxmock pools remain
+// DBProviders in tests.
 type DBProvider interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	Ping(ctx context.Context) error
 	Close()
 }
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// Listener receives the notifications Postgres delivers on a channel
+type Listener interface {
+	Listen(ctx context.Context, channel string, handle func(ctx context.Context, payload string)) error
+}
+
+// pgListener holds a dedicated connection LISTENing on the channel, outside of any pool, as a
+// listening connection must stay the same one for as long as notifications are wanted.
+// Transaction-mode poolers such as PgBouncer do not pass notifications on, so it needs a
+// direct connection to the server.
+type pgListener struct {
+	cfg            config.DatabaseConfig
+	reconnectDelay time.Duration
+	logger         *zap.Logger
+}
+
+// NewListener creates a Listener connecting to the database of cfg, waiting reconnectDelay
+// before connecting again after losing its connection
+func NewListener(cfg config.DatabaseConfig, reconnectDelay time.Duration, logger *zap.Logger) Listener {
+	return &pgListener{
+		cfg:            cfg,
+		reconnectDelay: reconnectDelay,
+		logger:         logger,
+	}
+}
+
+// Listen calls handle with the payload of every notification on channel until ctx is done,
+// reconnecting whenever the connection is lost. Notifications sent while it is disconnected
+// are missed.
+func (l *pgListener) Listen(ctx context.Context, channel string, handle func(ctx context.Context, payload string)) error {
+	for {
+		err := l.listen(ctx, channel, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+		l.logger.Warn("Lost notification listener connection, reconnecting",
+			zap.String("channel", channel),
+			zap.Duration("delay", l.reconnectDelay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(l.reconnectDelay):
+		}
+	}
+}
+
+// listen connects and handles notifications until the connection fails or ctx is done
+func (l *pgListener) listen(ctx context.Context, channel string, handle func(ctx context.Context, payload string)) error {
+	conn, err := pgx.Connect(ctx, dataSourceName(l.cfg, ""))
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+	l.logger.Info("Listening for notifications", zap.String("channel", channel))
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(ctx, notification.Payload)
+	}
+}
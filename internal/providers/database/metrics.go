@@ -0,0 +1,64 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolAcquiredConns = prometheus.NewDesc("explore_db_pool_acquired_connections",
+		"Connections of the pool in use.", []string{"tenant"}, nil)
+	poolIdleConns = prometheus.NewDesc("explore_db_pool_idle_connections",
+		"Connections of the pool left idle.", []string{"tenant"}, nil)
+	poolMaxConns = prometheus.NewDesc("explore_db_pool_max_connections",
+		"Most connections the pool may open.", []string{"tenant"}, nil)
+	poolAcquires = prometheus.NewDesc("explore_db_pool_acquires_total",
+		"Connections acquired from the pool.", []string{"tenant"}, nil)
+	poolEmptyAcquires = prometheus.NewDesc("explore_db_pool_empty_acquires_total",
+		"Acquires that had to wait for a connection, as none was idle.", []string{"tenant"}, nil)
+	poolAcquireWait = prometheus.NewDesc("explore_db_pool_acquire_wait_seconds_total",
+		"Time spent waiting to acquire connections from the pool.", []string{"tenant"}, nil)
+)
+
+// poolCollector exports the connection stats of the pools of a DBProvider, by tenant
+type poolCollector struct {
+	pools map[string]*pgxpool.Pool
+}
+
+// NewPoolCollector returns a collector of the connection stats of db's pools, labelled with the
+// tenant of each, or no tenant when running single-tenant
+func NewPoolCollector(db DBProvider) prometheus.Collector {
+	pools := map[string]*pgxpool.Pool{}
+	switch db := db.(type) {
+	case *pgxPool:
+		pools[""] = db.Pool
+	case *tenantDBProvider:
+		for tenant, tenantDB := range db.tenants {
+			if pool, ok := tenantDB.(*pgxPool); ok {
+				pools[tenant] = pool.Pool
+			}
+		}
+	}
+	return &poolCollector{pools: pools}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConns
+	ch <- poolIdleConns
+	ch <- poolMaxConns
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolAcquireWait
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	for tenant, pool := range c.pools {
+		stat := pool.Stat()
+		ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()), tenant)
+		ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()), tenant)
+		ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()), tenant)
+		ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()), tenant)
+		ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()), tenant)
+		ch <- prometheus.MustNewConstMetric(poolAcquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds(), tenant)
+	}
+}
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jackc/pgx/v5"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+// Where the migration files are read from: MySQL has its own, as the schema is written in its dialect
+const (
+	migrationsSource      = "file://db/migrations"
+	mysqlMigrationsSource = "file://db/migrations/mysql"
+)
+
+// Migrator applies and reverts the migrations of one schema, tracking them in its schema_migrations table
+type Migrator struct {
+	schema  string
+	source  string
+	migrate *migrate.Migrate
+}
+
+// NewMigrator opens the migrations of schema, the default schema when empty. A tenant schema
+// is created first if it does not exist yet. With the mysql driver, schema must be empty.
+func NewMigrator(cfg config.DatabaseConfig, schema string) (*Migrator, error) {
+	if cfg.Driver == DriverMySQL {
+		if schema != "" {
+			return nil, fmt.Errorf("tenant schemas need the %s driver", DriverPostgres)
+		}
+		m, err := newMySQLMigrate(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+		}
+		return &Migrator{source: mysqlMigrationsSource, migrate: m}, nil
+	}
+
+	if schema != "" {
+		if err := createSchema(cfg, schema); err != nil {
+			return nil, fmt.Errorf("failed to create schema %s: %w", schema, err)
+		}
+	}
+
+	m, err := migrate.New(migrationsSource, dataSourceName(cfg, schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	return &Migrator{schema: schema, source: migrationsSource, migrate: m}, nil
+}
+
+// Schema returns the schema the migrator works on, empty for the default one
+func (m *Migrator) Schema() string {
+	return m.schema
+}
+
+// Up applies all pending migrations
+func (m *Migrator) Up() error {
+	if err := m.migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down reverts the last steps applied migrations
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	return m.migrate.Steps(-steps)
+}
+
+// Version returns the version of the last applied migration, zero when none was applied, and
+// whether that migration failed halfway and left the schema dirty
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Force records version as applied and clears the dirty flag without running any migration,
+// once an operator has repaired the schema by hand. -1 records that no migration is applied.
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+// PlannedMigration is a migration Up would apply
+type PlannedMigration struct {
+	Version uint
+	Name    string
+	SQL     string
+}
+
+// Plan returns the migrations Up would apply and their SQL, in order, without running them
+func (m *Migrator) Plan() ([]PlannedMigration, error) {
+	version, dirty, err := m.migrate.Version()
+	applied := true
+	if errors.Is(err, migrate.ErrNilVersion) {
+		applied, err = false, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("schema is dirty at version %d, force a version before planning", version)
+	}
+
+	src, err := source.Open(m.source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+	defer src.Close()
+
+	return pendingMigrations(src, version, applied)
+}
+
+// pendingMigrations reads the up migrations of src following version, or all of them when
+// none is applied yet
+func pendingMigrations(src source.Driver, version uint, applied bool) ([]PlannedMigration, error) {
+	var (
+		next uint
+		err  error
+	)
+	if applied {
+		next, err = src.Next(version)
+	} else {
+		next, err = src.First()
+	}
+
+	var planned []PlannedMigration
+	for ; err == nil; next, err = src.Next(next) {
+		r, name, readErr := src.ReadUp(next)
+		if errors.Is(readErr, os.ErrNotExist) {
+			continue
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+		sql, readErr := io.ReadAll(r)
+		r.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		planned = append(planned, PlannedMigration{Version: next, Name: name, SQL: string(sql)})
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return planned, nil
+}
+
+// Close releases the connections of the migrator
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.migrate.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// MigrationSchemas returns the schemas holding the service's tables: one per tenant, or the
+// default schema when running single-tenant
+func MigrationSchemas(tenants []string) []string {
+	if len(tenants) == 0 {
+		return []string{""}
+	}
+
+	schemas := make([]string, len(tenants))
+	for i, tenant := range tenants {
+		schemas[i] = utils.TenantSchema(tenant)
+	}
+	return schemas
+}
+
+// RunMigrations applies all up migrations from the migrations folder, to the schema of every
+// tenant when tenants are given and to the default schema otherwise.
+func RunMigrations(cfg config.DatabaseConfig, tenants []string) error {
+	for _, schema := range MigrationSchemas(tenants) {
+		log.Printf("Starting database migrations of schema %q...", schema)
+
+		migrator, err := NewMigrator(cfg, schema)
+		if err != nil {
+			return err
+		}
+		err = migrator.Up()
+		_ = migrator.Close()
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations to schema %q: %w", schema, err)
+		}
+	}
+
+	log.Println("Database migrations applied successfully.")
+	return nil
+}
+
+// createSchema creates schema unless it exists already
+func createSchema(cfg config.DatabaseConfig, schema string) error {
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dataSourceName(cfg, ""))
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+pgx.Identifier{schema}.Sanitize())
+	return err
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+	src source.Driver
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
+
+func (s *MigrationsTestSuite) SetupTest() {
+	var err error
+	s.src, err = source.Open("file://../../../db/migrations")
+	s.Require().NoError(err)
+}
+
+func (s *MigrationsTestSuite) TearDownTest() {
+	s.NoError(s.src.Close())
+}
+
+func (s *MigrationsTestSuite) TestPendingMigrations_AfterVersion() {
+	planned, err := pendingMigrations(s.src, 8, true)
+
+	s.Require().NoError(err)
+	s.Require().GreaterOrEqual(len(planned), 2)
+	s.Equal(uint(9), planned[0].Version)
+	s.Equal("create_like_counts_table", planned[0].Name)
+	s.Contains(planned[0].SQL, "CREATE TABLE IF NOT EXISTS like_counts")
+	s.Equal(uint(10), planned[1].Version)
+}
+
+func (s *MigrationsTestSuite) TestPendingMigrations_NoneApplied() {
+	planned, err := pendingMigrations(s.src, 0, false)
+
+	s.Require().NoError(err)
+	s.Equal(uint(1), planned[0].Version)
+	s.Contains(planned[0].SQL, "CREATE TABLE IF NOT EXISTS decisions")
+}
+
+func (s *MigrationsTestSuite) TestPendingMigrations_UpToDate() {
+	planned, err := pendingMigrations(s.src, 8, true)
+	s.Require().NoError(err)
+	latest := planned[len(planned)-1].Version
+
+	planned, err = pendingMigrations(s.src, latest, true)
+
+	s.NoError(err)
+	s.Empty(planned)
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	mysqlmigrate "github.com/golang-migrate/migrate/v4/database/mysql"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// Databases the decisions can be kept in, selected by database.driver
+const (
+	DriverPostgres = "postgres"
+	// DriverMySQL keeps the decisions in MySQL, without the features built on Postgres itself:
+	// tenant schemas, the admin RPCs and the change feed
+	DriverMySQL = "mysql"
+)
+
+// NewMySQLDB opens a pool of connections to the MySQL database of cfg and checks it is reachable
+func NewMySQLDB(cfg config.DatabaseConfig, logger *zap.Logger) (*sql.DB, error) {
+	db, err := openMySQL(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+
+	if err := db.PingContext(context.Background()); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info("Database connection pool established", zap.String("driver", DriverMySQL))
+
+	return db, nil
+}
+
+// openMySQL opens the database of cfg without connecting yet. Sessions run in UTC, in which
+// the DATETIME columns are stored and read back as times, so that timestamps convert to and from
+// Unix seconds as they do in Postgres. multiStatements lets a migration file run in one call.
+func openMySQL(cfg config.DatabaseConfig, multiStatements bool) (*sql.DB, error) {
+	dsn := mysql.NewConfig()
+	dsn.User = cfg.User
+	dsn.Passwd = cfg.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
+	dsn.DBName = cfg.DBName
+	dsn.ParseTime = true
+	dsn.Loc = time.UTC
+	dsn.Params = map[string]string{"time_zone": "'+00:00'"}
+	dsn.MultiStatements = multiStatements
+
+	connector, err := mysql.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mysql config: %w", err)
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// newMySQLMigrate opens the MySQL migrations of the database of cfg
+func newMySQLMigrate(cfg config.DatabaseConfig) (*migrate.Migrate, error) {
+	db, err := openMySQL(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := mysqlmigrate.WithInstance(db, &mysqlmigrate.Config{})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	m, err := migrate.NewWithDatabaseInstance(mysqlMigrationsSource, DriverMySQL, driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+// ErrUnknownTenant is returned for calls whose context is not scoped to a configured tenant
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// tenantDBProvider sends each call to the database of the tenant its context is scoped to.
+// Every tenant has its own schema and pool, so their data stays apart without any query
+// having to filter by tenant.
+type tenantDBProvider struct {
+	tenants map[string]DBProvider
+}
+
+// NewTenantDBProvider connects a pool to the schema of every tenant
+func NewTenantDBProvider(cfg config.DatabaseConfig, tenants []string, logger *zap.Logger) (DBProvider, error) {
+	provider := &tenantDBProvider{tenants: make(map[string]DBProvider, len(tenants))}
+	for _, tenant := range tenants {
+		pool, err := newPgxPool(cfg, utils.TenantSchema(tenant), logger)
+		if err != nil {
+			provider.Close()
+			return nil, fmt.Errorf("tenant %s: %w", tenant, err)
+		}
+		provider.tenants[tenant] = pool
+	}
+	return provider, nil
+}
+
+// tenant returns the provider of the tenant ctx is scoped to
+func (p *tenantDBProvider) tenant(ctx context.Context) (DBProvider, error) {
+	tenant := utils.TenantFromContext(ctx)
+	db, ok := p.tenants[tenant]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTenant, tenant)
+	}
+	return db, nil
+}
+
+func (p *tenantDBProvider) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	db, err := p.tenant(ctx)
+	if err != nil {
+		return errRow{err: err}
+	}
+	return db.QueryRow(ctx, sql, args...)
+}
+
+func (p *tenantDBProvider) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db, err := p.tenant(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return db.Exec(ctx, sql, args...)
+}
+
+func (p *tenantDBProvider) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	db, err := p.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.Query(ctx, sql, args...)
+}
+
+func (p *tenantDBProvider) Begin(ctx context.Context) (pgx.Tx, error) {
+	db, err := p.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.Begin(ctx)
+}
+
+func (p *tenantDBProvider) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	db, err := p.tenant(ctx)
+	if err != nil {
+		return errBatchResults{err: err}
+	}
+	return db.SendBatch(ctx, b)
+}
+
+// Ping checks the database of every tenant
+func (p *tenantDBProvider) Ping(ctx context.Context) error {
+	for tenant, db := range p.tenants {
+		if err := db.Ping(ctx); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenant, err)
+		}
+	}
+	return nil
+}
+
+func (p *tenantDBProvider) Close() {
+	for _, db := range p.tenants {
+		db.Close()
+	}
+}
+
+// errRow is a row failing to scan with err
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
+
+// errBatchResults are the results of a batch that could not be sent, failing with err
+type errBatchResults struct {
+	err error
+}
+
+func (b errBatchResults) Exec() (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, b.err
+}
+
+func (b errBatchResults) Query() (pgx.Rows, error) {
+	return nil, b.err
+}
+
+func (b errBatchResults) QueryRow() pgx.Row {
+	return errRow{err: b.err}
+}
+
+func (b errBatchResults) Close() error {
+	return b.err
+}
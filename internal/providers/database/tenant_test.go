@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/utils"
+)
+
+type TenantDBProviderTestSuite struct {
+	suite.Suite
+	acme     pgxmock.PgxPoolIface
+	globex   pgxmock.PgxPoolIface
+	provider DBProvider
+}
+
+func TestTenantDBProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantDBProviderTestSuite))
+}
+
+func (s *TenantDBProviderTestSuite) SetupTest() {
+	var err error
+	s.acme, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+	s.globex, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+
+	s.provider = &tenantDBProvider{tenants: map[string]DBProvider{"acme": s.acme, "globex": s.globex}}
+}
+
+func (s *TenantDBProviderTestSuite) TearDownTest() {
+	s.provider.Close()
+}
+
+func (s *TenantDBProviderTestSuite) TestRoutesToTenantOfContext() {
+	s.globex.ExpectExec("DELETE FROM decisions").WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	_, err := s.provider.Exec(utils.WithTenant(context.Background(), "globex"), "DELETE FROM decisions")
+
+	s.NoError(err)
+	s.NoError(s.acme.ExpectationsWereMet())
+	s.NoError(s.globex.ExpectationsWereMet())
+}
+
+func (s *TenantDBProviderTestSuite) TestRejectsUnknownTenant() {
+	ctx := utils.WithTenant(context.Background(), "initech")
+
+	_, err := s.provider.Exec(ctx, "DELETE FROM decisions")
+	s.ErrorIs(err, ErrUnknownTenant)
+
+	var exists bool
+	s.ErrorIs(s.provider.QueryRow(ctx, "SELECT true").Scan(&exists), ErrUnknownTenant)
+}
+
+func (s *TenantDBProviderTestSuite) TestRejectsContextWithoutTenant() {
+	_, err := s.provider.Query(context.Background(), "SELECT 1")
+
+	s.ErrorIs(err, ErrUnknownTenant)
+}
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+const (
+	// DriverNone disables publishing to an external event bus
+	DriverNone = ""
+	// DriverKafka publishes events to a Kafka topic
+	DriverKafka = "kafka"
+	// DriverNATS publishes events to a NATS JetStream stream
+	DriverNATS = "nats"
+)
+
+// NewEventPublisher returns the EventPublisher selected by cfg.Driver
+func NewEventPublisher(ctx context.Context, cfg config.EventsConfig, logger *zap.Logger) (EventPublisher, error) {
+	switch cfg.Driver {
+	case DriverNone:
+		return noopPublisher{}, nil
+	case DriverKafka:
+		return NewKafkaEventPublisher(cfg.Kafka, logger)
+	case DriverNATS:
+		return NewNATSEventPublisher(ctx, cfg.NATS, logger)
+	default:
+		return nil, fmt.Errorf("unknown events driver %q", cfg.Driver)
+	}
+}
+
+// noopPublisher implements the EventPublisher interface by discarding every event
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	return nil
+}
+
+func (noopPublisher) Close() error {
+	return nil
+}
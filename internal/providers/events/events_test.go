@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+type EventsTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
+
+func (s *EventsTestSuite) SetupTest() {
+	s.logger = zap.NewNop()
+}
+
+func (s *EventsTestSuite) TestNewEventPublisher_NoDriverDiscardsEvents() {
+	publisher, err := NewEventPublisher(context.Background(), config.EventsConfig{}, s.logger)
+
+	s.Require().NoError(err)
+	s.NoError(publisher.Publish(context.Background(), "newlikes:user1", []byte("event")))
+	s.NoError(publisher.Close())
+}
+
+func (s *EventsTestSuite) TestNewEventPublisher_Kafka() {
+	cfg := config.EventsConfig{
+		Driver: DriverKafka,
+		Kafka:  config.KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "explore.events"},
+	}
+
+	publisher, err := NewEventPublisher(context.Background(), cfg, s.logger)
+
+	s.Require().NoError(err)
+	s.IsType(&kafkaProvider{}, publisher)
+	s.NoError(publisher.Close())
+}
+
+func (s *EventsTestSuite) TestNewEventPublisher_KafkaRequiresBrokers() {
+	cfg := config.EventsConfig{
+		Driver: DriverKafka,
+		Kafka:  config.KafkaConfig{Topic: "explore.events"},
+	}
+
+	_, err := NewEventPublisher(context.Background(), cfg, s.logger)
+
+	s.EqualError(err, "events.kafka.brokers is required")
+}
+
+func (s *EventsTestSuite) TestNewEventPublisher_NATSRequiresStream() {
+	cfg := config.EventsConfig{
+		Driver: DriverNATS,
+		NATS:   config.NATSConfig{URL: "nats://localhost:4222", SubjectPrefix: "explore"},
+	}
+
+	_, err := NewEventPublisher(context.Background(), cfg, s.logger)
+
+	s.EqualError(err, "events.nats.stream and events.nats.subject_prefix are required")
+}
+
+func (s *EventsTestSuite) TestNewEventPublisher_UnknownDriver() {
+	_, err := NewEventPublisher(context.Background(), config.EventsConfig{Driver: "rabbitmq"}, s.logger)
+
+	s.EqualError(err, `unknown events driver "rabbitmq"`)
+}
+
+func (s *EventsTestSuite) TestNATSSubject() {
+	s.Equal("explore.newlikes.user1", natsSubject("explore", "newlikes:user1"))
+	// Only the kind separator becomes a subject token boundary
+	s.Equal("explore.newlikes.team:user1", natsSubject("explore", "newlikes:team:user1"))
+}
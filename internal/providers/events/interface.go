@@ -0,0 +1,8 @@
+package events
+
+import "context"
+
+type EventPublisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Close() error
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// kafkaProvider implements the EventPublisher interface by writing every event to a single Kafka topic.
+// The event topic is the message key, so events for the same user land on the same partition in order.
+type kafkaProvider struct {
+	writer *kafka.Writer
+	logger *zap.Logger
+}
+
+// NewKafkaEventPublisher creates an EventPublisher writing to cfg.Topic on cfg.Brokers
+func NewKafkaEventPublisher(cfg config.KafkaConfig, logger *zap.Logger) (EventPublisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("events.kafka.brokers is required")
+	}
+	if cfg.Topic == "" {
+		return nil, errors.New("events.kafka.topic is required")
+	}
+
+	return &kafkaProvider{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Publish writes the payload keyed by topic and waits for every in-sync replica to acknowledge it
+func (p *kafkaProvider) Publish(ctx context.Context, topic string, payload []byte) error {
+	err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(topic),
+		Value: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write kafka message: %w", err)
+	}
+	return nil
+}
+
+// Close flushes pending writes and closes the broker connections
+func (p *kafkaProvider) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// natsProvider implements the EventPublisher interface by publishing to a NATS JetStream stream.
+// Every event topic is published on its own subject under the configured prefix.
+type natsProvider struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	subjectPrefix string
+	logger        *zap.Logger
+}
+
+// NewNATSEventPublisher connects to cfg.URL and makes sure cfg.Stream captures every subject under cfg.SubjectPrefix
+func NewNATSEventPublisher(ctx context.Context, cfg config.NATSConfig, logger *zap.Logger) (EventPublisher, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("events.nats.url is required")
+	}
+	if cfg.Stream == "" || cfg.SubjectPrefix == "" {
+		return nil, errors.New("events.nats.stream and events.nats.subject_prefix are required")
+	}
+
+	conn, err := nats.Connect(cfg.URL, nats.Name("explore-service"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     cfg.Stream,
+		Subjects: []string{cfg.SubjectPrefix + ".>"},
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream stream %q: %w", cfg.Stream, err)
+	}
+
+	return &natsProvider{
+		conn:          conn,
+		js:            js,
+		subjectPrefix: cfg.SubjectPrefix,
+		logger:        logger,
+	}, nil
+}
+
+// Publish stores the payload in the stream and waits for the server to acknowledge it
+func (p *natsProvider) Publish(ctx context.Context, topic string, payload []byte) error {
+	if _, err := p.js.Publish(ctx, natsSubject(p.subjectPrefix, topic), payload); err != nil {
+		return fmt.Errorf("failed to publish to jetstream: %w", err)
+	}
+	return nil
+}
+
+// Close drains pending publishes and closes the connection
+func (p *natsProvider) Close() error {
+	return p.conn.Drain()
+}
+
+// natsSubject maps an event topic such as "newlikes:user123" to "<prefix>.newlikes.user123",
+// so consumers can subscribe to a whole kind of event with "<prefix>.newlikes.>"
+func natsSubject(prefix, topic string) string {
+	return prefix + "." + strings.Replace(topic, ":", ".", 1)
+}
@@ -0,0 +1,8 @@
+package pubsub
+
+import "context"
+
+type PubSubProvider interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
@@ -0,0 +1,70 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// memoryProvider implements the PubSubProvider interface with in-process channels.
+// Messages only reach subscribers connected to the same instance.
+type memoryProvider struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+	bufferSize  int
+	logger      *zap.Logger
+}
+
+// NewMemoryPubSubProvider creates and returns a memoryProvider that satisfies the PubSubProvider interface.
+// bufferSize is the number of messages a slow subscriber may lag behind before messages are dropped.
+func NewMemoryPubSubProvider(bufferSize int, logger *zap.Logger) PubSubProvider {
+	return &memoryProvider{
+		subscribers: make(map[string]map[chan []byte]struct{}),
+		bufferSize:  bufferSize,
+		logger:      logger,
+	}
+}
+
+// Publish delivers the payload to every current subscriber of the topic without blocking.
+func (p *memoryProvider) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for ch := range p.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+			p.logger.Warn("Dropping message for slow subscriber", zap.String("topic", topic))
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel receiving messages published to the topic.
+// The subscription ends and the channel is closed once ctx is done.
+func (p *memoryProvider) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, p.bufferSize)
+
+	p.mu.Lock()
+	if p.subscribers[topic] == nil {
+		p.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+	p.subscribers[topic][ch] = struct{}{}
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		p.mu.Lock()
+		delete(p.subscribers[topic], ch)
+		if len(p.subscribers[topic]) == 0 {
+			delete(p.subscribers, topic)
+		}
+		p.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
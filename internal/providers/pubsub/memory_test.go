@@ -0,0 +1,29 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemoryProvider_PublishSubscribe(t *testing.T) {
+	provider := NewMemoryPubSubProvider(1, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	messages, err := provider.Subscribe(ctx, "topic")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Publish(ctx, "other", []byte("ignored")))
+	require.NoError(t, provider.Publish(ctx, "topic", []byte("first")))
+	// The buffer is full, so this message is dropped instead of blocking
+	require.NoError(t, provider.Publish(ctx, "topic", []byte("second")))
+
+	assert.Equal(t, []byte("first"), <-messages)
+
+	cancel()
+	_, ok := <-messages
+	assert.False(t, ok, "channel should be closed once the subscription ends")
+}
@@ -0,0 +1,27 @@
+package pubsub
+
+import "context"
+
+// noopProvider implements the PubSubProvider interface without delivering anything: published
+// messages are discarded and subscriptions never receive one.
+type noopProvider struct{}
+
+// NewNoopPubSubProvider returns a PubSubProvider that delivers nothing, for publishers whose
+// messages reach subscribers by another route
+func NewNoopPubSubProvider() PubSubProvider {
+	return noopProvider{}
+}
+
+func (noopProvider) Publish(ctx context.Context, topic string, payload []byte) error {
+	return nil
+}
+
+// Subscribe returns a channel that is closed once ctx is done
+func (noopProvider) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
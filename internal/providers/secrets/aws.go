@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	"github.com/backend-interview-task/config"
+)
+
+// Schemes of the config values resolved from AWS at startup
+const (
+	SchemeSecretsManager = "secretsmanager://"
+	SchemeSSM            = "ssm://"
+)
+
+// SecretsManagerClient is the part of the AWS Secrets Manager API the resolver uses
+type SecretsManagerClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// ParameterStoreClient is the part of the AWS SSM Parameter Store API the resolver uses
+type ParameterStoreClient interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// AWSResolver replaces the secretsmanager:// and ssm:// references held by sensitive config
+// fields with the values they point to
+type AWSResolver struct {
+	secrets    SecretsManagerClient
+	parameters ParameterStoreClient
+}
+
+// NewAWSResolver creates a resolver using the given clients
+func NewAWSResolver(secrets SecretsManagerClient, parameters ParameterStoreClient) *AWSResolver {
+	return &AWSResolver{secrets: secrets, parameters: parameters}
+}
+
+// ResolveAWSReferences resolves the AWS references in the sensitive fields of cfg. The AWS
+// clients are only created when a field holds a reference, from the default credential chain
+// and the standard AWS_* environment variables, such as AWS_REGION.
+func ResolveAWSReferences(ctx context.Context, cfg *config.Config) error {
+	if !HasAWSReferences(cfg) {
+		return nil
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load the AWS config: %w", err)
+	}
+	resolver := NewAWSResolver(secretsmanager.NewFromConfig(awsConfig), ssm.NewFromConfig(awsConfig))
+	return resolver.Resolve(ctx, cfg)
+}
+
+// HasAWSReferences reports whether a sensitive field of cfg holds an AWS reference
+func HasAWSReferences(cfg *config.Config) bool {
+	for _, field := range sensitiveFields(cfg) {
+		if isAWSReference(*field.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve replaces every AWS reference in the sensitive fields of cfg with its value
+func (r *AWSResolver) Resolve(ctx context.Context, cfg *config.Config) error {
+	for _, field := range sensitiveFields(cfg) {
+		if !isAWSReference(*field.value) {
+			continue
+		}
+		value, err := r.lookUp(ctx, *field.value)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", field.key, err)
+		}
+		*field.value = value
+	}
+	return nil
+}
+
+// lookUp reads the value a reference points to: secretsmanager://<secret-id>[#<json-key>]
+// or ssm://<parameter-name>
+func (r *AWSResolver) lookUp(ctx context.Context, reference string) (string, error) {
+	switch {
+	case strings.HasPrefix(reference, SchemeSecretsManager):
+		id, key, _ := strings.Cut(strings.TrimPrefix(reference, SchemeSecretsManager), "#")
+		if id == "" {
+			return "", fmt.Errorf("%q names no secret", reference)
+		}
+		return r.secretValue(ctx, id, key)
+	default:
+		name := strings.TrimPrefix(reference, SchemeSSM)
+		if name == "" {
+			return "", fmt.Errorf("%q names no parameter", reference)
+		}
+		return r.parameterValue(ctx, name)
+	}
+}
+
+// secretValue reads a Secrets Manager secret, or the key of a secret holding a JSON object
+func (r *AWSResolver) secretValue(ctx context.Context, id, key string) (string, error) {
+	output, err := r.secrets.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", id, err)
+	}
+	if output.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", id)
+	}
+	if key == "" {
+		return *output.SecretString, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(*output.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", id, err)
+	}
+	value, ok := fields[key].(string)
+	if !ok {
+		return "", fmt.Errorf("secret %s has no string field %q", id, key)
+	}
+	return value, nil
+}
+
+// parameterValue reads an SSM parameter, decrypting SecureString parameters
+func (r *AWSResolver) parameterValue(ctx context.Context, name string) (string, error) {
+	output, err := r.parameters.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read parameter %s: %w", name, err)
+	}
+	if output.Parameter == nil || output.Parameter.Value == nil {
+		return "", fmt.Errorf("parameter %s has no value", name)
+	}
+	return *output.Parameter.Value, nil
+}
+
+// sensitiveField is a config value that may hold an AWS reference, named by its config key
+type sensitiveField struct {
+	key   string
+	value *string
+}
+
+// sensitiveFields lists the fields of cfg that may be given as AWS references
+func sensitiveFields(cfg *config.Config) []sensitiveField {
+	return []sensitiveField{
+		{key: "database.password", value: &cfg.Database.Password},
+		{key: "redis.password", value: &cfg.Redis.Password},
+		{key: "webhooks.secret", value: &cfg.Webhooks.Secret},
+		{key: "vault.token", value: &cfg.Vault.Token},
+		{key: "vault.secret_id", value: &cfg.Vault.SecretID},
+	}
+}
+
+func isAWSReference(value string) bool {
+	return strings.HasPrefix(value, SchemeSecretsManager) || strings.HasPrefix(value, SchemeSSM)
+}
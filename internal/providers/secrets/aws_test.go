@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/config"
+	secretsmock "github.com/backend-interview-task/mocks/providers/secrets"
+)
+
+type AWSResolverTestSuite struct {
+	suite.Suite
+	secrets    *secretsmock.SecretsManagerClient
+	parameters *secretsmock.ParameterStoreClient
+	resolver   *AWSResolver
+	ctx        context.Context
+}
+
+func TestAWSResolverTestSuite(t *testing.T) {
+	suite.Run(t, new(AWSResolverTestSuite))
+}
+
+func (s *AWSResolverTestSuite) SetupTest() {
+	s.secrets = secretsmock.NewSecretsManagerClient(s.T())
+	s.parameters = secretsmock.NewParameterStoreClient(s.T())
+	s.resolver = NewAWSResolver(s.secrets, s.parameters)
+	s.ctx = context.Background()
+}
+
+func (s *AWSResolverTestSuite) secretReturns(id, value string) {
+	s.secrets.EXPECT().
+		GetSecretValue(s.ctx, mock.MatchedBy(func(input *secretsmanager.GetSecretValueInput) bool {
+			return aws.ToString(input.SecretId) == id
+		})).
+		Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(value)}, nil)
+}
+
+func (s *AWSResolverTestSuite) TestResolvesReferences() {
+	cfg := &config.Config{}
+	cfg.Database.Password = "secretsmanager://explore/db#password"
+	cfg.Redis.Password = "ssm:///explore/redis-password"
+	cfg.Webhooks.Secret = "secretsmanager://explore/webhooks"
+	cfg.Vault.Token = "plain-token"
+
+	s.secretReturns("explore/db", `{"username":"explore","password":"db-secret"}`)
+	s.secretReturns("explore/webhooks", "webhook-secret")
+	s.parameters.EXPECT().
+		GetParameter(s.ctx, mock.MatchedBy(func(input *ssm.GetParameterInput) bool {
+			return aws.ToString(input.Name) == "/explore/redis-password" && aws.ToBool(input.WithDecryption)
+		})).
+		Return(&ssm.GetParameterOutput{Parameter: &types.Parameter{Value: aws.String("redis-secret")}}, nil)
+
+	s.Require().True(HasAWSReferences(cfg))
+	s.Require().NoError(s.resolver.Resolve(s.ctx, cfg))
+	s.Equal("db-secret", cfg.Database.Password)
+	s.Equal("redis-secret", cfg.Redis.Password)
+	s.Equal("webhook-secret", cfg.Webhooks.Secret)
+	s.Equal("plain-token", cfg.Vault.Token)
+	s.False(HasAWSReferences(cfg))
+}
+
+func (s *AWSResolverTestSuite) TestRejectsMissingJSONKey() {
+	cfg := &config.Config{}
+	cfg.Database.Password = "secretsmanager://explore/db#pass"
+	s.secretReturns("explore/db", `{"password":"db-secret"}`)
+
+	err := s.resolver.Resolve(s.ctx, cfg)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "database.password")
+	s.Equal("secretsmanager://explore/db#pass", cfg.Database.Password)
+}
+
+func (s *AWSResolverTestSuite) TestReportsLookupErrors() {
+	cfg := &config.Config{}
+	cfg.Vault.SecretID = "ssm://explore-secret-id"
+	s.parameters.EXPECT().GetParameter(s.ctx, mock.Anything).Return(nil, errors.New("access denied"))
+
+	err := s.resolver.Resolve(s.ctx, cfg)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "vault.secret_id")
+	s.Contains(err.Error(), "access denied")
+}
+
+func (s *AWSResolverTestSuite) TestRejectsEmptyReference() {
+	cfg := &config.Config{}
+	cfg.Redis.Password = "ssm://"
+
+	s.Error(s.resolver.Resolve(s.ctx, cfg))
+}
+
+func (s *AWSResolverTestSuite) TestSkipsConfigWithoutReferences() {
+	cfg := &config.Config{}
+	cfg.Database.Password = "postgres"
+
+	s.False(HasAWSReferences(cfg))
+	s.NoError(ResolveAWSReferences(s.ctx, cfg))
+}
@@ -0,0 +1,210 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// Auth methods the service can log in to Vault with
+const (
+	AuthToken      = "token"
+	AuthAppRole    = "approle"
+	AuthKubernetes = "kubernetes"
+)
+
+// reloginDelay is how long to wait before logging in again after a failed attempt
+const reloginDelay = 10 * time.Second
+
+// Vault reads credentials from HashiCorp Vault and keeps its token, and the leases of the
+// dynamic secrets it read, renewed for as long as the service runs
+type Vault struct {
+	client *vault.Client
+	cfg    config.VaultConfig
+	logger *zap.Logger
+	// login is the secret of the last login, nil when a static token is used
+	login *vault.Secret
+	// leases holds the leased secrets read, renewed alongside the token
+	leases []*vault.Secret
+}
+
+// NewVault logs in to the Vault at cfg.Address with the configured auth method. The client also
+// honours the standard VAULT_* environment variables, such as VAULT_CACERT and VAULT_NAMESPACE.
+func NewVault(ctx context.Context, cfg config.VaultConfig, logger *zap.Logger) (*Vault, error) {
+	vaultConfig := vault.DefaultConfig()
+	if vaultConfig.Error != nil {
+		return nil, fmt.Errorf("failed to configure the Vault client: %w", vaultConfig.Error)
+	}
+	if cfg.Address != "" {
+		vaultConfig.Address = cfg.Address
+	}
+	client, err := vault.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Vault client: %w", err)
+	}
+
+	v := &Vault{client: client, cfg: cfg, logger: logger}
+	if err := v.logIn(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// logIn authenticates the client with the configured auth method
+func (v *Vault) logIn(ctx context.Context) error {
+	var data map[string]interface{}
+	switch v.cfg.AuthMethod {
+	case AuthToken:
+		if v.cfg.Token == "" {
+			return errors.New("vault.token is required for the token auth method")
+		}
+		v.client.SetToken(v.cfg.Token)
+		return nil
+	case AuthAppRole:
+		if v.cfg.RoleID == "" || v.cfg.SecretID == "" {
+			return errors.New("vault.role_id and vault.secret_id are required for the approle auth method")
+		}
+		data = map[string]interface{}{"role_id": v.cfg.RoleID, "secret_id": v.cfg.SecretID}
+	case AuthKubernetes:
+		if v.cfg.Role == "" {
+			return errors.New("vault.role is required for the kubernetes auth method")
+		}
+		jwt, err := os.ReadFile(v.cfg.KubernetesTokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the service account token: %w", err)
+		}
+		data = map[string]interface{}{"role": v.cfg.Role, "jwt": strings.TrimSpace(string(jwt))}
+	default:
+		return fmt.Errorf("unknown vault.auth_method %q, expected %s, %s or %s", v.cfg.AuthMethod, AuthToken, AuthAppRole, AuthKubernetes)
+	}
+
+	mount := v.cfg.AuthMount
+	if mount == "" {
+		mount = v.cfg.AuthMethod
+	}
+	secret, err := v.client.Logical().WriteWithContext(ctx, "auth/"+mount+"/login", data)
+	if err != nil {
+		return fmt.Errorf("failed to log in to Vault: %w", err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return errors.New("failed to log in to Vault: no token returned")
+	}
+	v.client.SetToken(secret.Auth.ClientToken)
+	v.login = secret
+	return nil
+}
+
+// Read returns the value of the key of the secret at path. Secrets of KV version 2 engines,
+// read at their data/ path, are unwrapped. Leased secrets, such as dynamic database
+// credentials, are renewed by Renew.
+func (v *Vault) Read(ctx context.Context, ref config.VaultSecretConfig) (string, error) {
+	secret, err := v.client.Logical().ReadWithContext(ctx, ref.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault secret %s: %w", ref.Path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("secret %s not found in Vault", ref.Path)
+	}
+
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nested
+		}
+	}
+	value, ok := data[ref.Key].(string)
+	if !ok {
+		return "", fmt.Errorf("secret %s in Vault has no string %q key", ref.Path, ref.Key)
+	}
+
+	if secret.LeaseID != "" && secret.Renewable {
+		v.leases = append(v.leases, secret)
+	}
+	return value, nil
+}
+
+// ResolveCredentials replaces the database and Redis passwords of cfg with those read from
+// the Vault secrets cfg.Vault points to, leaving those without a path as configured
+func (v *Vault) ResolveCredentials(ctx context.Context, cfg *config.Config) error {
+	if cfg.Vault.Database.Path != "" {
+		password, err := v.Read(ctx, cfg.Vault.Database)
+		if err != nil {
+			return err
+		}
+		cfg.Database.Password = password
+	}
+	if cfg.Vault.Redis.Path != "" {
+		password, err := v.Read(ctx, cfg.Vault.Redis)
+		if err != nil {
+			return err
+		}
+		cfg.Redis.Password = password
+	}
+	return nil
+}
+
+// Renew keeps the Vault token and the leases of the secrets read renewed until ctx is done.
+// A token that reaches its max TTL is replaced by logging in again. A lease that can no longer
+// be renewed is logged, as the credentials it backs will expire and need a restart to replace.
+func (v *Vault) Renew(ctx context.Context) {
+	for _, lease := range v.leases {
+		go v.watch(ctx, lease, func() {
+			v.logger.Error("Vault lease can no longer be renewed; restart to read new credentials before it expires",
+				zap.String("lease_id", lease.LeaseID))
+		})
+	}
+
+	for v.login != nil && ctx.Err() == nil {
+		v.watch(ctx, v.login, func() {})
+		if ctx.Err() != nil {
+			return
+		}
+		for {
+			err := v.logIn(ctx)
+			if err == nil {
+				v.logger.Info("Logged in to Vault again")
+				break
+			}
+			v.logger.Error("Failed to log in to Vault again", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reloginDelay):
+			}
+		}
+	}
+}
+
+// watch renews secret until it can no longer be, then calls done, or until ctx is done
+func (v *Vault) watch(ctx context.Context, secret *vault.Secret, done func()) {
+	watcher, err := v.client.NewLifetimeWatcher(&vault.LifetimeWatcherInput{Secret: secret})
+	if err != nil {
+		v.logger.Error("Failed to watch Vault lease", zap.Error(err))
+		return
+	}
+	go watcher.Start()
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-watcher.DoneCh():
+			if err != nil {
+				v.logger.Warn("Failed to renew Vault lease", zap.Error(err))
+			}
+			done()
+			return
+		case renewal := <-watcher.RenewCh():
+			v.logger.Debug("Renewed Vault lease", zap.Time("renewed_at", renewal.RenewedAt))
+		}
+	}
+}
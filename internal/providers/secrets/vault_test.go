@@ -0,0 +1,167 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+type VaultTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	logins []map[string]interface{}
+	tokens []string
+	cfg    config.VaultConfig
+}
+
+func TestVaultTestSuite(t *testing.T) {
+	suite.Run(t, new(VaultTestSuite))
+}
+
+func (s *VaultTestSuite) SetupTest() {
+	s.logins, s.tokens = nil, nil
+	mux := http.NewServeMux()
+	login := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		s.Require().NoError(json.NewDecoder(r.Body).Decode(&body))
+		s.logins = append(s.logins, body)
+		s.reply(w, map[string]interface{}{
+			"auth": map[string]interface{}{"client_token": "s.login", "lease_duration": 3600, "renewable": true},
+		})
+	}
+	mux.HandleFunc("/v1/auth/approle/login", login)
+	mux.HandleFunc("/v1/auth/k8s/login", login)
+	mux.HandleFunc("/v1/secret/data/explore/database", func(w http.ResponseWriter, r *http.Request) {
+		s.tokens = append(s.tokens, r.Header.Get("X-Vault-Token"))
+		s.reply(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     map[string]interface{}{"password": "db-secret"},
+				"metadata": map[string]interface{}{"version": 3},
+			},
+		})
+	})
+	mux.HandleFunc("/v1/database/creds/explore", func(w http.ResponseWriter, r *http.Request) {
+		s.reply(w, map[string]interface{}{
+			"lease_id":       "database/creds/explore/abc",
+			"lease_duration": 3600,
+			"renewable":      true,
+			"data":           map[string]interface{}{"username": "v-explore", "password": "dynamic-secret"},
+		})
+	})
+	mux.HandleFunc("/v1/secret/redis", func(w http.ResponseWriter, r *http.Request) {
+		s.reply(w, map[string]interface{}{"data": map[string]interface{}{"pass": "redis-secret"}})
+	})
+	s.server = httptest.NewServer(mux)
+	s.T().Cleanup(s.server.Close)
+
+	s.cfg = config.VaultConfig{
+		Enabled:    true,
+		Address:    s.server.URL,
+		AuthMethod: AuthAppRole,
+		RoleID:     "role",
+		SecretID:   "secret",
+		Database:   config.VaultSecretConfig{Path: "secret/data/explore/database", Key: "password"},
+		Redis:      config.VaultSecretConfig{Path: "secret/redis", Key: "pass"},
+	}
+}
+
+func (s *VaultTestSuite) reply(w http.ResponseWriter, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	s.Require().NoError(json.NewEncoder(w).Encode(body))
+}
+
+func (s *VaultTestSuite) TestResolveCredentials_AppRole() {
+	v, err := NewVault(context.Background(), s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+	cfg := &config.Config{Vault: s.cfg}
+	cfg.Database.Password = "from-config"
+
+	s.Require().NoError(v.ResolveCredentials(context.Background(), cfg))
+
+	s.Equal("db-secret", cfg.Database.Password)
+	s.Equal("redis-secret", cfg.Redis.Password)
+	s.Equal([]map[string]interface{}{{"role_id": "role", "secret_id": "secret"}}, s.logins)
+	s.Equal([]string{"s.login"}, s.tokens)
+	s.Empty(v.leases)
+}
+
+func (s *VaultTestSuite) TestResolveCredentials_KeepsPasswordsWithoutPath() {
+	s.cfg.Database.Path, s.cfg.Redis.Path = "", ""
+	v, err := NewVault(context.Background(), s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+	cfg := &config.Config{Vault: s.cfg}
+	cfg.Database.Password = "from-config"
+
+	s.Require().NoError(v.ResolveCredentials(context.Background(), cfg))
+
+	s.Equal("from-config", cfg.Database.Password)
+}
+
+func (s *VaultTestSuite) TestRead_KeepsLeaseOfDynamicSecret() {
+	v, err := NewVault(context.Background(), s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+
+	password, err := v.Read(context.Background(), config.VaultSecretConfig{Path: "database/creds/explore", Key: "password"})
+
+	s.NoError(err)
+	s.Equal("dynamic-secret", password)
+	s.Require().Len(v.leases, 1)
+	s.Equal("database/creds/explore/abc", v.leases[0].LeaseID)
+}
+
+func (s *VaultTestSuite) TestRead_MissingKey() {
+	v, err := NewVault(context.Background(), s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+
+	_, err = v.Read(context.Background(), config.VaultSecretConfig{Path: "secret/redis", Key: "password"})
+
+	s.EqualError(err, `secret secret/redis in Vault has no string "password" key`)
+}
+
+func (s *VaultTestSuite) TestKubernetesLogin() {
+	tokenFile := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(tokenFile, []byte("service-account-jwt\n"), 0o600))
+	s.cfg.AuthMethod = AuthKubernetes
+	s.cfg.AuthMount = "k8s"
+	s.cfg.Role = "explore"
+	s.cfg.KubernetesTokenFile = tokenFile
+
+	_, err := NewVault(context.Background(), s.cfg, zap.NewNop())
+
+	s.NoError(err)
+	s.Equal([]map[string]interface{}{{"role": "explore", "jwt": "service-account-jwt"}}, s.logins)
+}
+
+func (s *VaultTestSuite) TestTokenLogin() {
+	s.cfg.AuthMethod = AuthToken
+	s.cfg.Token = "s.static"
+	v, err := NewVault(context.Background(), s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+
+	_, err = v.Read(context.Background(), s.cfg.Database)
+
+	s.NoError(err)
+	s.Empty(s.logins)
+	s.Equal([]string{"s.static"}, s.tokens)
+}
+
+func (s *VaultTestSuite) TestInvalidConfig() {
+	for _, cfg := range []config.VaultConfig{
+		{Address: s.server.URL, AuthMethod: "ldap"},
+		{Address: s.server.URL, AuthMethod: AuthToken},
+		{Address: s.server.URL, AuthMethod: AuthAppRole, RoleID: "role"},
+		{Address: s.server.URL, AuthMethod: AuthKubernetes},
+	} {
+		_, err := NewVault(context.Background(), cfg, zap.NewNop())
+		s.Error(err, cfg.AuthMethod)
+	}
+}
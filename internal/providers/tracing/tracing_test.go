@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func (s *TracingTestSuite) TestDisabled() {
+	shutdown, err := NewTracerProvider(s.ctx, config.TracingConfig{}, zap.NewNop())
+
+	s.Require().NoError(err)
+	s.NoError(shutdown(s.ctx))
+}
+
+func (s *TracingTestSuite) TestInvalidConfig() {
+	_, err := NewTracerProvider(s.ctx, config.TracingConfig{Enabled: true, SampleRatio: 0.1}, zap.NewNop())
+	s.Error(err)
+
+	_, err = NewTracerProvider(s.ctx, config.TracingConfig{Enabled: true, Endpoint: "localhost:4317", SampleRatio: 1.5}, zap.NewNop())
+	s.Error(err)
+}
+
+func (s *TracingTestSuite) TestSamplerFollowsParent() {
+	sampler := newSampler(0)
+	traceID := trace.TraceID{1}
+
+	sampled := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled, Remote: true})
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: trace.ContextWithSpanContext(s.ctx, sampled),
+		TraceID:       traceID,
+	})
+	s.Equal(sdktrace.RecordAndSample, result.Decision)
+
+	result = sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: s.ctx, TraceID: traceID})
+	s.Equal(sdktrace.Drop, result.Decision)
+}
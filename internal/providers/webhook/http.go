@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>"
+	SignatureHeader = "X-Explore-Signature"
+	// TimestampHeader carries the unix time the request was signed at, so receivers can reject replays
+	TimestampHeader = "X-Explore-Timestamp"
+)
+
+// httpProvider implements the WebhookProvider interface by POSTing signed JSON over HTTPS
+type httpProvider struct {
+	client *http.Client
+	secret []byte
+	logger *zap.Logger
+}
+
+// NewHTTPWebhookProvider creates a WebhookProvider signing every payload with the shared secret
+func NewHTTPWebhookProvider(client *http.Client, secret string, logger *zap.Logger) WebhookProvider {
+	return &httpProvider{
+		client: client,
+		secret: []byte(secret),
+		logger: logger,
+	}
+}
+
+// ValidateEndpoint checks that the endpoint is an absolute HTTPS URL
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid webhook endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("webhook endpoint %q must be an https URL", endpoint)
+	}
+	return nil
+}
+
+// Send POSTs the payload to the endpoint and fails unless it answers with a 2xx status
+func (p *httpProvider) Send(ctx context.Context, endpoint string, payload []byte) error {
+	if err := ValidateEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+p.sign(timestamp, payload))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint answered %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// sign returns the hex HMAC-SHA256 of the timestamp and payload
+func (p *httpProvider) sign(timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
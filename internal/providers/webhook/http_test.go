@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type HTTPWebhookProviderTestSuite struct {
+	suite.Suite
+}
+
+func TestHTTPWebhookProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPWebhookProviderTestSuite))
+}
+
+func (s *HTTPWebhookProviderTestSuite) TestSend_SignsPayload() {
+	payload := []byte(`{"type":"match.created"}`)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+		s.Equal(payload, body)
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(r.Header.Get(TimestampHeader) + "."))
+		mac.Write(body)
+		s.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(SignatureHeader))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	provider := NewHTTPWebhookProvider(server.Client(), "secret", zap.NewNop())
+
+	s.NoError(provider.Send(context.Background(), server.URL, payload))
+}
+
+func (s *HTTPWebhookProviderTestSuite) TestSend_NonSuccessStatus() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := NewHTTPWebhookProvider(server.Client(), "secret", zap.NewNop())
+
+	err := provider.Send(context.Background(), server.URL, []byte(`{}`))
+
+	s.Error(err)
+	s.Contains(err.Error(), "503")
+}
+
+func (s *HTTPWebhookProviderTestSuite) TestValidateEndpoint() {
+	s.NoError(ValidateEndpoint("https://partner.example.com/hooks/match"))
+	s.Error(ValidateEndpoint("http://partner.example.com/hooks/match"))
+	s.Error(ValidateEndpoint("partner.example.com"))
+}
@@ -0,0 +1,7 @@
+package webhook
+
+import "context"
+
+type WebhookProvider interface {
+	Send(ctx context.Context, endpoint string, payload []byte) error
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/providers/database"
+)
+
+type AdminRepository interface {
+	ListUserDecisions(ctx context.Context, userID string, page models.PageRequest) ([]models.UserDecision, string, error)
+	StreamUserDecisions(ctx context.Context, userID string, fn func(models.UserDecision) error) error
+	PurgeUserData(ctx context.Context, userID string) (models.PurgeSummary, error)
+	EraseUserData(ctx context.Context, userID, callerID, requestID string) (models.ErasureReceipt, error)
+	GetServiceStats(ctx context.Context) (models.ServiceStats, error)
+}
+
+type adminStore struct {
+	db database.DBProvider
+	*explorerdb.Queries
+	logger *zap.Logger
+}
+
+func NewAdminRepository(db database.DBProvider, logger *zap.Logger) AdminRepository {
+	return &adminStore{
+		db:      db,
+		logger:  logger,
+		Queries: explorerdb.New(db),
+	}
+}
+
+// ListUserDecisions returns the decisions the user made and the decisions made on them, with
+// pagination. Decisions are paged by id alone, most recently recorded pairs first.
+func (r *adminStore) ListUserDecisions(ctx context.Context, userID string, page models.PageRequest) ([]models.UserDecision, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	params := explorerdb.ListUserDecisionsParams{
+		UserID:    userID,
+		PageLimit: int32(cursor.Limit + 1),
+	}
+	if page.Token != "" {
+		params.BeforeID = &cursor.LastID
+	}
+
+	rows, err := r.Queries.ListUserDecisions(ctx, params)
+	if err != nil {
+		r.logger.Error("Failed to list user decisions", zap.String("user_id", userID), zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list user decisions: %w", err)
+	}
+
+	decisions := make([]models.UserDecision, 0, len(rows))
+	for _, row := range rows {
+		decisions = append(decisions, models.UserDecision(row))
+	}
+
+	var nextPaginationToken string
+	if len(decisions) > cursor.Limit {
+		last := decisions[cursor.Limit-1]
+		nextPaginationToken, err = nextCursor(cursor, last.Timestamp, last.ID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		decisions = decisions[:cursor.Limit] // Remove the extra item
+	}
+
+	return decisions, nextPaginationToken, nil
+}
+
+// StreamUserDecisions calls fn with every decision the user made or that was made on them, oldest
+// recorded first, as rows arrive from the database, so that exporting a user's decisions never
+// holds them all in memory. It stops at the first error fn returns.
+func (r *adminStore) StreamUserDecisions(ctx context.Context, userID string, fn func(models.UserDecision) error) error {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Select("id, actor_user_id, recipient_user_id, liked_recipient, EXTRACT(EPOCH FROM created_at)::bigint as timestamp").
+		From("decisions").
+		Where(squirrel.Or{squirrel.Eq{"actor_user_id": userID}, squirrel.Eq{"recipient_user_id": userID}}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to stream user decisions", zap.String("user_id", userID), zap.Error(err))
+		return fmt.Errorf("failed to stream user decisions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var decision models.UserDecision
+		if err := rows.Scan(&decision.ID, &decision.ActorUserID, &decision.RecipientUserID, &decision.LikedRecipient, &decision.Timestamp); err != nil {
+			return fmt.Errorf("failed to scan decision: %w", err)
+		}
+		if err := fn(decision); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over results: %w", err)
+	}
+	return nil
+}
+
+// PurgeUserData deletes the user's decisions, in either direction, their matches, their blocks,
+// in either direction, their like count and their popularity score, in a single transaction.
+// Reports and the audit trail are kept.
+func (r *adminStore) PurgeUserData(ctx context.Context, userID string) (models.PurgeSummary, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	summary, err := purgeUserData(ctx, r.Queries.WithTx(tx), userID)
+	if err != nil {
+		return models.PurgeSummary{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return summary, nil
+}
+
+// EraseUserData purges the user's data as PurgeUserData does, replaces their ID with
+// models.ErasedUserID in the reports filed by or against them and in the audit events they made
+// or concern, dropping the requests recorded in those events, and records a receipt of the
+// erasure made by callerID, all in a single transaction
+func (r *adminStore) EraseUserData(ctx context.Context, userID, callerID, requestID string) (models.ErasureReceipt, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ErasureReceipt{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.Queries.WithTx(tx)
+
+	receipt := models.ErasureReceipt{SubjectHash: erasureSubjectHash(userID)}
+	if receipt.PurgeSummary, err = purgeUserData(ctx, q, userID); err != nil {
+		return models.ErasureReceipt{}, err
+	}
+
+	placeholder := models.ErasedUserID
+	if receipt.Reports, err = q.AnonymizeUserReports(ctx, explorerdb.AnonymizeUserReportsParams{UserID: userID, Placeholder: placeholder}); err != nil {
+		return models.ErasureReceipt{}, fmt.Errorf("failed to anonymize reports: %w", err)
+	}
+	// The audit trail only lets an erasure transaction rewrite its events
+	if err := q.AllowAuditEventErasure(ctx); err != nil {
+		return models.ErasureReceipt{}, fmt.Errorf("failed to allow audit event erasure: %w", err)
+	}
+	if receipt.AuditEvents, err = q.AnonymizeUserAuditEvents(ctx, explorerdb.AnonymizeUserAuditEventsParams{UserID: userID, Placeholder: placeholder}); err != nil {
+		return models.ErasureReceipt{}, fmt.Errorf("failed to anonymize audit events: %w", err)
+	}
+
+	row, err := q.CreateErasureReceipt(ctx, explorerdb.CreateErasureReceiptParams{
+		SubjectHash: receipt.SubjectHash,
+		CallerID:    callerID,
+		RequestID:   requestID,
+		Decisions:   receipt.Decisions,
+		Matches:     receipt.Matches,
+		Blocks:      receipt.Blocks,
+		Reports:     receipt.Reports,
+		AuditEvents: receipt.AuditEvents,
+	})
+	if err != nil {
+		return models.ErasureReceipt{}, fmt.Errorf("failed to create erasure receipt: %w", err)
+	}
+	receipt.ID, receipt.ErasedAt = row.ID, row.ErasedAt
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ErasureReceipt{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return receipt, nil
+}
+
+// purgeUserData deletes the user's decisions, matches, blocks, like count and popularity score
+// with q. Decisions go before blocks, so that the like_counts triggers never count the likes a
+// block was hiding back in.
+func purgeUserData(ctx context.Context, q *explorerdb.Queries, userID string) (models.PurgeSummary, error) {
+	var summary models.PurgeSummary
+
+	counterparts := make(map[string]bool)
+	addCounterpart := func(a, b string) {
+		if a == userID {
+			a = b
+		}
+		if !counterparts[a] {
+			counterparts[a] = true
+			summary.Counterparts = append(summary.Counterparts, a)
+		}
+	}
+
+	deleted, err := q.DeleteUserDecisions(ctx, userID)
+	if err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to delete decisions: %w", err)
+	}
+	summary.Decisions = int64(len(deleted))
+	for _, decision := range deleted {
+		addCounterpart(decision.ActorUserID, decision.RecipientUserID)
+	}
+
+	// Every match is stored once per participant
+	matches, err := q.DeleteUserMatches(ctx, userID)
+	if err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to delete matches: %w", err)
+	}
+	summary.Matches = matches / 2
+
+	if summary.Blocks, err = q.DeleteUserBlocks(ctx, userID); err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to delete blocks: %w", err)
+	}
+	if err := q.DeleteUserLikeCount(ctx, userID); err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to delete like count: %w", err)
+	}
+	if err := q.DeleteUserPopularityScore(ctx, userID); err != nil {
+		return models.PurgeSummary{}, fmt.Errorf("failed to delete popularity score: %w", err)
+	}
+
+	return summary, nil
+}
+
+// erasureSubjectHash returns the hex SHA-256 hash of userID, by which an erasure receipt names
+// the erased user
+func erasureSubjectHash(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetServiceStats sizes the service's tables
+func (r *adminStore) GetServiceStats(ctx context.Context) (models.ServiceStats, error) {
+	row, err := r.Queries.GetServiceStats(ctx)
+	if err != nil {
+		return models.ServiceStats{}, fmt.Errorf("failed to get service stats: %w", err)
+	}
+
+	// Every match is stored once per participant
+	row.Matches /= 2
+	return models.ServiceStats(row), nil
+}
@@ -0,0 +1,280 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+	"github.com/backend-interview-task/utils"
+)
+
+var userDecisionColumns = []string{"id", "actor_user_id", "recipient_user_id", "liked_recipient", "timestamp"}
+
+type AdminRepositoryTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo repository.AdminRepository
+	ctx  context.Context
+}
+
+func TestAdminRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminRepositoryTestSuite))
+}
+
+func (s *AdminRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	var err error
+	s.mock, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+
+	s.repo = repository.NewAdminRepository(s.mock, zaptest.NewLogger(s.T()))
+}
+
+func (s *AdminRepositoryTestSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *AdminRepositoryTestSuite) TestListUserDecisions_FirstPage() {
+	rows := pgxmock.NewRows(userDecisionColumns).
+		AddRow(int64(9), "user123", "user456", true, int64(1640995300)).
+		AddRow(int64(7), "user789", "user123", false, int64(1640995200)).
+		AddRow(int64(4), "user123", "user000", true, int64(1640995100))
+
+	s.mock.ExpectQuery(`SELECT .* FROM decisions .* ORDER BY id DESC`).
+		WithArgs("user123", (*int64)(nil), int32(3)).
+		WillReturnRows(rows)
+
+	decisions, nextToken, err := s.repo.ListUserDecisions(s.ctx, "user123", models.PageRequest{Size: 2})
+
+	s.NoError(err)
+	s.Require().Len(decisions, 2)
+	s.Equal(models.UserDecision{ID: 9, ActorUserID: "user123", RecipientUserID: "user456", LikedRecipient: true, Timestamp: 1640995300}, decisions[0])
+	s.Equal("user789", decisions[1].ActorUserID)
+
+	cursor, err := utils.DecodeCursor(nextToken)
+	s.Require().NoError(err)
+	s.Equal(int64(7), cursor.LastID)
+	s.Equal(2, cursor.Limit)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestListUserDecisions_NextPage() {
+	token, err := (&utils.Cursor{LastCreatedAt: 1640995200, LastID: 7, Limit: 2}).Encode()
+	s.Require().NoError(err)
+	beforeID := int64(7)
+
+	s.mock.ExpectQuery(`SELECT .* FROM decisions .*`).
+		WithArgs("user123", &beforeID, int32(3)).
+		WillReturnRows(pgxmock.NewRows(userDecisionColumns).
+			AddRow(int64(4), "user123", "user000", true, int64(1640995100)))
+
+	decisions, nextToken, err := s.repo.ListUserDecisions(s.ctx, "user123", models.PageRequest{Token: token})
+
+	s.NoError(err)
+	s.Len(decisions, 1)
+	s.Empty(nextToken)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestListUserDecisions_DatabaseError() {
+	s.mock.ExpectQuery(`SELECT .* FROM decisions .*`).
+		WithArgs("user123", (*int64)(nil), int32(11)).
+		WillReturnError(errors.New("database error"))
+
+	decisions, nextToken, err := s.repo.ListUserDecisions(s.ctx, "user123", models.PageRequest{Size: 10})
+
+	s.Error(err)
+	s.Nil(decisions)
+	s.Empty(nextToken)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestStreamUserDecisions_Success() {
+	s.mock.ExpectQuery(`SELECT .* FROM decisions WHERE \(actor_user_id = \$1 OR recipient_user_id = \$2\) ORDER BY id`).
+		WithArgs("user123", "user123").
+		WillReturnRows(pgxmock.NewRows(userDecisionColumns).
+			AddRow(int64(4), "user123", "user000", true, int64(1640995100)).
+			AddRow(int64(7), "user789", "user123", false, int64(1640995200)))
+
+	var decisions []models.UserDecision
+	err := s.repo.StreamUserDecisions(s.ctx, "user123", func(decision models.UserDecision) error {
+		decisions = append(decisions, decision)
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]models.UserDecision{
+		{ID: 4, ActorUserID: "user123", RecipientUserID: "user000", LikedRecipient: true, Timestamp: 1640995100},
+		{ID: 7, ActorUserID: "user789", RecipientUserID: "user123", Timestamp: 1640995200},
+	}, decisions)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestStreamUserDecisions_StopsOnCallbackError() {
+	s.mock.ExpectQuery(`SELECT .* FROM decisions`).
+		WithArgs("user123", "user123").
+		WillReturnRows(pgxmock.NewRows(userDecisionColumns).
+			AddRow(int64(4), "user123", "user000", true, int64(1640995100)).
+			AddRow(int64(7), "user789", "user123", false, int64(1640995200)))
+	sendErr := errors.New("stream closed")
+
+	calls := 0
+	err := s.repo.StreamUserDecisions(s.ctx, "user123", func(models.UserDecision) error {
+		calls++
+		return sendErr
+	})
+
+	s.ErrorIs(err, sendErr)
+	s.Equal(1, calls)
+}
+
+func (s *AdminRepositoryTestSuite) TestPurgeUserData_Success() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`DELETE FROM decisions .* RETURNING actor_user_id, recipient_user_id`).
+		WithArgs("user123").
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "recipient_user_id"}).
+			AddRow("user123", "user456").
+			AddRow("user456", "user123").
+			AddRow("user789", "user123"))
+	s.mock.ExpectExec(`DELETE FROM matches`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	s.mock.ExpectExec(`DELETE FROM blocks`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	s.mock.ExpectExec(`DELETE FROM like_counts`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	s.mock.ExpectExec(`DELETE FROM popularity_scores`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	s.mock.ExpectCommit()
+
+	summary, err := s.repo.PurgeUserData(s.ctx, "user123")
+
+	s.NoError(err)
+	s.Equal(models.PurgeSummary{
+		Decisions:    3,
+		Matches:      1,
+		Blocks:       1,
+		Counterparts: []string{"user456", "user789"},
+	}, summary)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestPurgeUserData_RollsBackOnError() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`DELETE FROM decisions`).
+		WithArgs("user123").
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "recipient_user_id"}).
+			AddRow("user123", "user456"))
+	s.mock.ExpectExec(`DELETE FROM matches`).
+		WithArgs("user123").
+		WillReturnError(errors.New("database error"))
+	s.mock.ExpectRollback()
+
+	summary, err := s.repo.PurgeUserData(s.ctx, "user123")
+
+	s.Error(err)
+	s.Equal(models.PurgeSummary{}, summary)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+// expectPurge expects the statements purging user123's data, whose decisions concerned user456
+func (s *AdminRepositoryTestSuite) expectPurge() {
+	s.mock.ExpectQuery(`DELETE FROM decisions`).
+		WithArgs("user123").
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "recipient_user_id"}).
+			AddRow("user123", "user456"))
+	s.mock.ExpectExec(`DELETE FROM matches`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectExec(`DELETE FROM blocks`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectExec(`DELETE FROM like_counts`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectExec(`DELETE FROM popularity_scores`).
+		WithArgs("user123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+}
+
+func (s *AdminRepositoryTestSuite) TestEraseUserData_Success() {
+	// SHA-256 of "user123"
+	subjectHash := "e606e38b0d8c19b24cf0ee3808183162ea7cd63ff7912dbb22b5e803286b4446"
+
+	s.mock.ExpectBegin()
+	s.expectPurge()
+	s.mock.ExpectExec(`UPDATE reports`).
+		WithArgs("user123", models.ErasedUserID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	s.mock.ExpectExec(`SELECT set_config\('explore.erasure', 'on', true\)`).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectExec(`UPDATE audit_events`).
+		WithArgs("user123", models.ErasedUserID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 5))
+	s.mock.ExpectQuery(`INSERT INTO erasure_receipts`).
+		WithArgs(subjectHash, "support", "req-1", int64(1), int64(0), int64(0), int64(2), int64(5)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "erased_at"}).AddRow(int64(42), int64(1640995200)))
+	s.mock.ExpectCommit()
+
+	receipt, err := s.repo.EraseUserData(s.ctx, "user123", "support", "req-1")
+
+	s.NoError(err)
+	s.Equal(models.ErasureReceipt{
+		ID:           42,
+		SubjectHash:  subjectHash,
+		ErasedAt:     1640995200,
+		PurgeSummary: models.PurgeSummary{Decisions: 1, Counterparts: []string{"user456"}},
+		Reports:      2,
+		AuditEvents:  5,
+	}, receipt)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestEraseUserData_RollsBackOnError() {
+	s.mock.ExpectBegin()
+	s.expectPurge()
+	s.mock.ExpectExec(`UPDATE reports`).
+		WithArgs("user123", models.ErasedUserID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	s.mock.ExpectExec(`SELECT set_config`).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectExec(`UPDATE audit_events`).
+		WithArgs("user123", models.ErasedUserID).
+		WillReturnError(errors.New("audit_events is append-only"))
+	s.mock.ExpectRollback()
+
+	receipt, err := s.repo.EraseUserData(s.ctx, "user123", "support", "req-1")
+
+	s.Error(err)
+	s.Equal(models.ErasureReceipt{}, receipt)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AdminRepositoryTestSuite) TestGetServiceStats_Success() {
+	s.mock.ExpectQuery(`SELECT .* FROM pg_class .*`).
+		WillReturnRows(pgxmock.NewRows([]string{"decisions", "likes", "matches", "blocks", "pending_outbox_events", "dead_lettered_outbox_events"}).
+			AddRow(int64(1200), int64(800), int64(60), int64(15), int64(3), int64(1)))
+
+	stats, err := s.repo.GetServiceStats(s.ctx)
+
+	s.NoError(err)
+	s.Equal(models.ServiceStats{
+		Decisions:                1200,
+		Likes:                    800,
+		Matches:                  30,
+		Blocks:                   15,
+		PendingOutboxEvents:      3,
+		DeadLetteredOutboxEvents: 1,
+	}, stats)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/providers/database"
+)
+
+type AuditRepository interface {
+	CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) error
+	ListAuditEvents(ctx context.Context, filter models.AuditFilter, page models.PageRequest) ([]models.AuditEvent, string, error)
+}
+
+type auditStore struct {
+	explorerdb.Querier
+	logger *zap.Logger
+}
+
+func NewAuditRepository(db database.DBProvider, logger *zap.Logger) AuditRepository {
+	return &auditStore{
+		logger:  logger,
+		Querier: explorerdb.New(db),
+	}
+}
+
+// ListAuditEvents returns the audit events matching filter, newest first, with pagination.
+// Events are paged by id alone, as ids grow with the time events are recorded.
+func (r *auditStore) ListAuditEvents(ctx context.Context, filter models.AuditFilter, page models.PageRequest) ([]models.AuditEvent, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	params := explorerdb.ListAuditEventsParams{
+		Since:     optionalBound(filter.Since),
+		Until:     optionalBound(filter.Until),
+		PageLimit: int32(cursor.Limit + 1),
+	}
+	if filter.UserID != "" {
+		params.UserID = &filter.UserID
+	}
+	if filter.Action != "" {
+		params.Action = &filter.Action
+	}
+	if page.Token != "" {
+		params.BeforeID = &cursor.LastID
+	}
+
+	rows, err := r.Querier.ListAuditEvents(ctx, params)
+	if err != nil {
+		r.logger.Error("Failed to list audit events", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	events := make([]models.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, models.AuditEvent{
+			ID:              row.ID,
+			OccurredAt:      row.OccurredAt.Time.Unix(),
+			Action:          row.Action,
+			CallerID:        row.CallerID,
+			CallerIsService: row.CallerIsService,
+			ClientAddress:   row.ClientAddress,
+			RequestID:       row.RequestID,
+			UserIDs:         row.UserIds,
+			Outcome:         row.Outcome,
+			Details:         row.Details,
+		})
+	}
+
+	var nextPaginationToken string
+	if len(events) > cursor.Limit {
+		last := events[cursor.Limit-1]
+		nextPaginationToken, err = nextCursor(cursor, last.OccurredAt, last.ID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		events = events[:cursor.Limit] // Remove the extra item
+	}
+
+	return events, nextPaginationToken, nil
+}
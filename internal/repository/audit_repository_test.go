@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+	"github.com/backend-interview-task/utils"
+)
+
+var auditEventColumns = []string{"id", "occurred_at", "action", "caller_id", "caller_is_service", "client_address", "request_id", "user_ids", "outcome", "details"}
+
+type AuditRepositoryTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo repository.AuditRepository
+	ctx  context.Context
+}
+
+func TestAuditRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepositoryTestSuite))
+}
+
+func (s *AuditRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	var err error
+	s.mock, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+
+	s.repo = repository.NewAuditRepository(s.mock, zaptest.NewLogger(s.T()))
+}
+
+func (s *AuditRepositoryTestSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *AuditRepositoryTestSuite) TestCreateAuditEvent_Success() {
+	params := explorerdb.CreateAuditEventParams{
+		Action:        "PutDecision",
+		CallerID:      "user123",
+		ClientAddress: "203.0.113.9:51234",
+		RequestID:     "req-1",
+		UserIds:       []string{"user123", "user456"},
+		Outcome:       "OK",
+		Details:       []byte(`{"actorUserId":"user123"}`),
+	}
+
+	s.mock.ExpectExec(`INSERT INTO audit_events .*`).
+		WithArgs(params.Action, params.CallerID, params.CallerIsService, params.ClientAddress, params.RequestID, params.UserIds, params.Outcome, params.Details).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	s.NoError(s.repo.CreateAuditEvent(s.ctx, params))
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AuditRepositoryTestSuite) TestListAuditEvents_FirstPage() {
+	occurredAt := pgtype.Timestamptz{Time: time.Unix(1640995200, 0), Valid: true}
+	rows := pgxmock.NewRows(auditEventColumns).
+		AddRow(int64(9), occurredAt, "PutDecision", "user123", false, "203.0.113.9:51234", "req-2", []string{"user123", "user456"}, "OK", []byte(`{}`)).
+		AddRow(int64(8), occurredAt, "BlockUser", "user123", false, "203.0.113.9:51234", "req-1", []string{"user123", "user789"}, "OK", []byte(`{}`)).
+		AddRow(int64(5), occurredAt, "DeleteDecision", "user123", false, "203.0.113.9:51234", "req-0", []string{"user123", "user456"}, "NotFound", []byte(`{}`))
+	userID := "user123"
+
+	s.mock.ExpectQuery(`SELECT .* FROM audit_events .* ORDER BY id DESC`).
+		WithArgs(&userID, (*string)(nil), (*int64)(nil), (*int64)(nil), (*int64)(nil), int32(3)).
+		WillReturnRows(rows)
+
+	events, nextToken, err := s.repo.ListAuditEvents(s.ctx, models.AuditFilter{UserID: userID}, models.PageRequest{Size: 2})
+
+	s.NoError(err)
+	s.Require().Len(events, 2)
+	s.Equal(int64(9), events[0].ID)
+	s.Equal(int64(1640995200), events[0].OccurredAt)
+	s.Equal([]string{"user123", "user456"}, events[0].UserIDs)
+	s.Equal("BlockUser", events[1].Action)
+
+	cursor, err := utils.DecodeCursor(nextToken)
+	s.Require().NoError(err)
+	s.Equal(int64(8), cursor.LastID)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AuditRepositoryTestSuite) TestListAuditEvents_NextPage() {
+	token, err := (&utils.Cursor{LastCreatedAt: 1640995200, LastID: 8, Limit: 2}).Encode()
+	s.Require().NoError(err)
+	action := "PutDecision"
+	since := int64(1640000000)
+	beforeID := int64(8)
+
+	s.mock.ExpectQuery(`SELECT .* FROM audit_events .*`).
+		WithArgs((*string)(nil), &action, &since, (*int64)(nil), &beforeID, int32(3)).
+		WillReturnRows(pgxmock.NewRows(auditEventColumns))
+
+	events, nextToken, err := s.repo.ListAuditEvents(s.ctx, models.AuditFilter{Action: action, Since: since}, models.PageRequest{Token: token})
+
+	s.NoError(err)
+	s.Empty(events)
+	s.Empty(nextToken)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *AuditRepositoryTestSuite) TestListAuditEvents_DatabaseError() {
+	s.mock.ExpectQuery(`SELECT .* FROM audit_events .*`).
+		WithArgs((*string)(nil), (*string)(nil), (*int64)(nil), (*int64)(nil), (*int64)(nil), int32(11)).
+		WillReturnError(errors.New("database error"))
+
+	events, nextToken, err := s.repo.ListAuditEvents(s.ctx, models.AuditFilter{}, models.PageRequest{Size: 10})
+
+	s.Error(err)
+	s.Nil(events)
+	s.Empty(nextToken)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
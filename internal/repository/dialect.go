@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// sqlDialect holds what differs between the SQL of the databases the repository runs on, for
+// the queries built at run time. The queries of explorerdb.Querier are written per database:
+// sqlc generates those of Postgres, and mysqlQueries holds those of MySQL.
+type sqlDialect struct {
+	// placeholders numbers the query parameters, $1 in Postgres, or leaves them as ? in MySQL
+	placeholders squirrel.PlaceholderFormat
+	// unixSeconds converts a timestamp column to the Unix second it holds, rounded
+	unixSeconds func(column string) string
+}
+
+var (
+	postgresDialect = sqlDialect{
+		placeholders: squirrel.Dollar,
+		unixSeconds: func(column string) string {
+			return "EXTRACT(EPOCH FROM " + column + ")::bigint"
+		},
+	}
+	mysqlDialect = sqlDialect{
+		placeholders: squirrel.Question,
+		unixSeconds: func(column string) string {
+			return "CAST(UNIX_TIMESTAMP(" + column + ") AS SIGNED)"
+		},
+	}
+)
+
+// builder returns a statement builder writing the dialect's placeholders
+func (d sqlDialect) builder() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(d.placeholders)
+}
+
+// likedRecipientsQuery builds the query of GetLikedRecipients, reading one recipient more than
+// the page holds to tell whether there is a next page
+func (d sqlDialect) likedRecipientsQuery(actorUserID string, page models.PageRequest, cursor *utils.Cursor) (string, []any, error) {
+	queryBuilder := d.builder().Select("recipient_user_id, " + d.unixSeconds("created_at") + " as timestamp").
+		From("decisions").
+		Where(squirrel.Eq{"actor_user_id": actorUserID}).
+		Where(squirrel.Eq{"liked_recipient": true})
+
+	if page.Token != "" {
+		queryBuilder = queryBuilder.Where(squirrel.Lt{d.unixSeconds("created_at"): cursor.LastCreatedAt})
+	}
+
+	return queryBuilder.
+		OrderBy("created_at DESC").
+		Limit(uint64(cursor.Limit + 1)).
+		ToSql()
+}
+
+// matchesQuery builds the query of GetMatches, reading one match more than the page holds to
+// tell whether there is a next page
+func (d sqlDialect) matchesQuery(userID string, page models.PageRequest, cursor *utils.Cursor) (string, []any, error) {
+	queryBuilder := d.builder().Select("matched_user_id, " + d.unixSeconds("matched_at") + " as timestamp").
+		From("matches").
+		Where(squirrel.Eq{"user_id": userID})
+
+	if page.Token != "" {
+		queryBuilder = queryBuilder.Where(squirrel.Lt{d.unixSeconds("matched_at"): cursor.LastCreatedAt})
+	}
+
+	return queryBuilder.
+		OrderBy("matched_at DESC").
+		Limit(uint64(cursor.Limit + 1)).
+		ToSql()
+}
+
+// rowScanner reads the rows of a query, as both pgx.Rows and *sql.Rows do
+type rowScanner interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+// scanRecipients reads the rows of the liked recipients query into a page
+func scanRecipients(rows rowScanner, cursor *utils.Cursor) ([]models.Recipient, string, error) {
+	var recipients []models.Recipient
+	for rows.Next() {
+		var recipient models.Recipient
+		if err := rows.Scan(&recipient.RecipientID, &recipient.Timestamp); err != nil {
+			return nil, "", fmt.Errorf("failed to scan recipient: %w", err)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating over results: %w", err)
+	}
+
+	var nextPaginationToken string
+	if len(recipients) > cursor.Limit {
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: recipients[cursor.Limit-1].Timestamp,
+			Limit:         cursor.Limit,
+		}
+		var err error
+		nextPaginationToken, err = nextCursor.Encode()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		recipients = recipients[:cursor.Limit]
+	}
+
+	return recipients, nextPaginationToken, nil
+}
+
+// scanMatches reads the rows of the matches query into a page
+func scanMatches(rows rowScanner, cursor *utils.Cursor) ([]models.Match, string, error) {
+	var matches []models.Match
+	for rows.Next() {
+		var match models.Match
+		if err := rows.Scan(&match.UserID, &match.MatchedAt); err != nil {
+			return nil, "", fmt.Errorf("failed to scan match: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating over results: %w", err)
+	}
+
+	var nextPaginationToken string
+	if len(matches) > cursor.Limit {
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: matches[cursor.Limit-1].MatchedAt,
+			Limit:         cursor.Limit,
+		}
+		var err error
+		nextPaginationToken, err = nextCursor.Encode()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		matches = matches[:cursor.Limit]
+	}
+
+	return matches, nextPaginationToken, nil
+}
@@ -0,0 +1,1335 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+)
+
+// DynamoDBClient is the part of the DynamoDB API the DynamoDB repository uses, which
+// *dynamodb.Client implements
+type DynamoDBClient interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+const (
+	// dynamoActorIndex is the global secondary index keyed by actor_pk and actor_sk, listing the
+	// decisions an actor made by time
+	dynamoActorIndex = "actor"
+
+	// Partitions outside the users' ones
+	dynamoOutboxPK      = "outbox"
+	dynamoDeadLetterPK  = "outbox_dead_letters"
+	dynamoAuditEventsPK = "audit_events"
+	dynamoCounterPK     = "counter"
+
+	// dynamoMaxTransactItems is the most items one TransactWriteItems call writes or checks
+	dynamoMaxTransactItems = 100
+	// dynamoMaxBatchGetKeys is the most keys one BatchGetItem call reads
+	dynamoMaxBatchGetKeys = 100
+	// dynamoQueryPage is the most items a listing reads per Query call
+	dynamoQueryPage = 100
+
+	// dynamoLastSecond is the last second the 10 digits of a listing sort key hold
+	dynamoLastSecond = 9_999_999_999
+
+	// dynamoOutboxLease is how long a claimed outbox event is left to its drain. DynamoDB has no
+	// FOR UPDATE SKIP LOCKED, so events are leased instead: the events of a drain that died
+	// mid-delivery are claimed again once their lease ends.
+	dynamoOutboxLease = 5 * time.Minute
+)
+
+// dynamoKey is the primary key of an item of the table
+type dynamoKey struct {
+	pk, sk string
+}
+
+func (k dynamoKey) attributes() map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{"pk": stringValue(k.pk), "sk": stringValue(k.sk)}
+}
+
+// newItem returns an item keyed by k holding attributes
+func (k dynamoKey) newItem(attributes map[string]types.AttributeValue) map[string]types.AttributeValue {
+	item := k.attributes()
+	maps.Copy(item, attributes)
+	return item
+}
+
+func dynamoUserPK(userID string) string {
+	return "user#" + userID
+}
+
+// dynamoDecisionSK sorts the decisions a user received or made by time, then by the other user
+func dynamoDecisionSK(timestamp int64, otherUserID string) string {
+	return fmt.Sprintf("%010d#%s", timestamp, otherUserID)
+}
+
+// dynamoListingRange bounds the sort keys of the listing items made from the second from
+// through the second to
+func dynamoListingRange(from, to int64) (types.AttributeValue, types.AttributeValue) {
+	return stringValue(fmt.Sprintf("%010d#", from)), stringValue(fmt.Sprintf("%010d$", to))
+}
+
+// dynamoListingKey keys the listing item of a decision, in its recipient's partition
+func dynamoListingKey(d explorerdb.Decision) dynamoKey {
+	return dynamoKey{dynamoUserPK(d.RecipientUserID), dynamoDecisionSK(d.CreatedAt.Time.Unix(), d.ActorUserID)}
+}
+
+// dynamoDecisionKey keys the current decision of actor on recipient, in the recipient's partition
+func dynamoDecisionKey(actorUserID, recipientUserID string) dynamoKey {
+	return dynamoKey{dynamoUserPK(recipientUserID), "decided_by#" + actorUserID}
+}
+
+func dynamoBlockKey(blockerUserID, blockedUserID string) dynamoKey {
+	return dynamoKey{dynamoUserPK(blockerUserID), "blocks#" + blockedUserID}
+}
+
+// dynamoBlockedByKey keys the mirror of a block in the blocked user's partition, so the users
+// either side of a block are read from one partition
+func dynamoBlockedByKey(blockerUserID, blockedUserID string) dynamoKey {
+	return dynamoKey{dynamoUserPK(blockedUserID), "blocked_by#" + blockerUserID}
+}
+
+func dynamoMatchKey(userID, matchedUserID string) dynamoKey {
+	return dynamoKey{dynamoUserPK(userID), "match#" + matchedUserID}
+}
+
+// dynamoIDSK sorts items by their id
+func dynamoIDSK(id int64) string {
+	return fmt.Sprintf("%019d", id)
+}
+
+func stringValue(s string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: s}
+}
+
+func numberValue(n int64) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(n, 10)}
+}
+
+func boolValue(b bool) types.AttributeValue {
+	return &types.AttributeValueMemberBOOL{Value: b}
+}
+
+func bytesValue(b []byte) types.AttributeValue {
+	return &types.AttributeValueMemberB{Value: b}
+}
+
+// The attributes of items are read leniently: one that is missing or of another type reads as
+// its zero value, as the table is only written by this repository
+
+func itemString(item map[string]types.AttributeValue, name string) string {
+	if v, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+	return ""
+}
+
+func itemNumber(item map[string]types.AttributeValue, name string) int64 {
+	if v, ok := item[name].(*types.AttributeValueMemberN); ok {
+		n, _ := strconv.ParseInt(v.Value, 10, 64)
+		return n
+	}
+	return 0
+}
+
+func itemBool(item map[string]types.AttributeValue, name string) bool {
+	if v, ok := item[name].(*types.AttributeValueMemberBOOL); ok {
+		return v.Value
+	}
+	return false
+}
+
+func itemBytes(item map[string]types.AttributeValue, name string) []byte {
+	if v, ok := item[name].(*types.AttributeValueMemberB); ok {
+		return v.Value
+	}
+	return nil
+}
+
+// itemTime reads a time stored in nanoseconds since the epoch
+func itemTime(item map[string]types.AttributeValue, name string) time.Time {
+	return time.Unix(0, itemNumber(item, name))
+}
+
+// dynamoRead is an item read by a transaction, nil when it did not exist
+type dynamoRead struct {
+	item    map[string]types.AttributeValue
+	version int64
+}
+
+// dynamoTx buffers the writes of a transaction until it commits them with one TransactWriteItems
+// call. Every item written carries a version, and the commit is conditioned on the items the
+// transaction read being at the version it read, so a transaction that raced another one fails
+// with a TransactionCanceledException and writes nothing, as a serialization failure does.
+type dynamoTx struct {
+	read      map[dynamoKey]dynamoRead
+	readOrder []dynamoKey
+	// written holds the items to put, nil for an item to delete
+	written map[dynamoKey]map[string]types.AttributeValue
+	order   []dynamoKey
+}
+
+func newDynamoTx() *dynamoTx {
+	return &dynamoTx{
+		read:    make(map[dynamoKey]dynamoRead),
+		written: make(map[dynamoKey]map[string]types.AttributeValue),
+	}
+}
+
+// dynamoQueries runs the queries of explorerdb.Querier on the single DynamoDB table of the
+// serverless deployment, with the semantics of their Postgres SQL. A user's partition holds the
+// decisions they received, keyed by time and actor for the listings, their current decision by
+// each actor, their blocks and their matches. The actor index lists the decisions a user made.
+// Rows the Postgres triggers maintain, like counts and new likes, are derived from decisions as
+// they are read, and queries of the admin RPCs and user erasure are not supported.
+type dynamoQueries struct {
+	client DynamoDBClient
+	table  string
+	// likeTTL sets the expires_at of likes, from which the table's time to live deletes them
+	likeTTL time.Duration
+	now     func() time.Time
+	// tx is the transaction the queries write in, nil outside one
+	tx *dynamoTx
+}
+
+var _ explorerdb.Querier = (*dynamoQueries)(nil)
+
+// inTx calls fn with queries writing in a transaction, committed if fn succeeds. Queries
+// already writing in a transaction keep writing in it.
+func (q *dynamoQueries) inTx(ctx context.Context, fn func(*dynamoQueries) error) error {
+	if q.tx != nil {
+		return fn(q)
+	}
+
+	txQueries := *q
+	txQueries.tx = newDynamoTx()
+	if err := fn(&txQueries); err != nil {
+		return err
+	}
+
+	if err := txQueries.commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// versionCondition conditions a write on the item being as the transaction read it
+func versionCondition(read dynamoRead) (*string, map[string]string, map[string]types.AttributeValue) {
+	if read.item == nil {
+		return aws.String("attribute_not_exists(pk)"), nil, nil
+	}
+	return aws.String("#version = :version"),
+		map[string]string{"#version": "version"},
+		map[string]types.AttributeValue{":version": numberValue(read.version)}
+}
+
+// commit writes the items of the transaction, conditioned on the items it read
+func (q *dynamoQueries) commit(ctx context.Context) error {
+	tx := q.tx
+	var items []types.TransactWriteItem
+	for _, key := range tx.order {
+		item := tx.written[key]
+		read, wasRead := tx.read[key]
+		if item == nil {
+			if wasRead && read.item == nil {
+				continue // Nothing to delete
+			}
+			write := &types.Delete{TableName: aws.String(q.table), Key: key.attributes()}
+			if wasRead {
+				write.ConditionExpression, write.ExpressionAttributeNames, write.ExpressionAttributeValues = versionCondition(read)
+			}
+			items = append(items, types.TransactWriteItem{Delete: write})
+			continue
+		}
+
+		item = maps.Clone(item)
+		item["version"] = numberValue(read.version + 1)
+		write := &types.Put{TableName: aws.String(q.table), Item: item}
+		if wasRead {
+			write.ConditionExpression, write.ExpressionAttributeNames, write.ExpressionAttributeValues = versionCondition(read)
+		}
+		items = append(items, types.TransactWriteItem{Put: write})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	for _, key := range tx.readOrder {
+		if _, written := tx.written[key]; written {
+			continue
+		}
+		check := &types.ConditionCheck{TableName: aws.String(q.table), Key: key.attributes()}
+		check.ConditionExpression, check.ExpressionAttributeNames, check.ExpressionAttributeValues = versionCondition(tx.read[key])
+		items = append(items, types.TransactWriteItem{ConditionCheck: check})
+	}
+	if len(items) > dynamoMaxTransactItems {
+		return fmt.Errorf("%w: it writes or checks %d items, more than the %d DynamoDB allows", models.ErrBatchTooLarge, len(items), dynamoMaxTransactItems)
+	}
+
+	_, err := q.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	return err
+}
+
+// recordRead records the item read under key in the transaction, if any
+func (q *dynamoQueries) recordRead(key dynamoKey, item map[string]types.AttributeValue) {
+	if q.tx == nil {
+		return
+	}
+	if _, ok := q.tx.read[key]; ok {
+		return
+	}
+	q.tx.read[key] = dynamoRead{item: item, version: itemNumber(item, "version")}
+	q.tx.readOrder = append(q.tx.readOrder, key)
+}
+
+// buffered returns the item under key as the transaction left it, if it read or wrote it
+func (q *dynamoQueries) buffered(key dynamoKey) (map[string]types.AttributeValue, bool) {
+	if q.tx == nil {
+		return nil, false
+	}
+	if item, ok := q.tx.written[key]; ok {
+		return item, true
+	}
+	if read, ok := q.tx.read[key]; ok {
+		return read.item, true
+	}
+	return nil, false
+}
+
+// getItem returns the item under key, nil when there is none. Items are shared with the
+// transaction and must be cloned before they are changed.
+func (q *dynamoQueries) getItem(ctx context.Context, key dynamoKey) (map[string]types.AttributeValue, error) {
+	if item, ok := q.buffered(key); ok {
+		return item, nil
+	}
+
+	output, err := q.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(q.table),
+		Key:            key.attributes(),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	item := output.Item
+	if len(item) == 0 {
+		item = nil
+	}
+	q.recordRead(key, item)
+	return item, nil
+}
+
+// getItems returns the items under keys that exist, as getItem does
+func (q *dynamoQueries) getItems(ctx context.Context, keys []dynamoKey) (map[dynamoKey]map[string]types.AttributeValue, error) {
+	items := make(map[dynamoKey]map[string]types.AttributeValue, len(keys))
+	var missing []dynamoKey
+	for _, key := range keys {
+		if item, ok := q.buffered(key); ok {
+			if item != nil {
+				items[key] = item
+			}
+			continue
+		}
+		if !slices.Contains(missing, key) {
+			missing = append(missing, key)
+		}
+	}
+
+	for chunk := range slices.Chunk(missing, dynamoMaxBatchGetKeys) {
+		request := make([]map[string]types.AttributeValue, len(chunk))
+		for i, key := range chunk {
+			request[i] = key.attributes()
+		}
+		for len(request) > 0 {
+			output, err := q.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{
+					q.table: {Keys: request, ConsistentRead: aws.Bool(true)},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range output.Responses[q.table] {
+				items[dynamoKey{itemString(item, "pk"), itemString(item, "sk")}] = item
+			}
+			// Keys left unprocessed by throttling are requested again
+			request = output.UnprocessedKeys[q.table].Keys
+		}
+		for _, key := range chunk {
+			q.recordRead(key, items[key])
+		}
+	}
+	return items, nil
+}
+
+// put writes item under key when the transaction commits
+func (q *dynamoQueries) put(key dynamoKey, item map[string]types.AttributeValue) {
+	if _, ok := q.tx.written[key]; !ok {
+		q.tx.order = append(q.tx.order, key)
+	}
+	q.tx.written[key] = item
+}
+
+// remove deletes the item under key when the transaction commits
+func (q *dynamoQueries) remove(key dynamoKey) {
+	q.put(key, nil)
+}
+
+// queryItems hands the items the query reads to fn page by page, until fn returns false
+func (q *dynamoQueries) queryItems(ctx context.Context, input *dynamodb.QueryInput, fn func([]map[string]types.AttributeValue) bool) error {
+	input.TableName = aws.String(q.table)
+	for {
+		output, err := q.client.Query(ctx, input)
+		if err != nil {
+			return err
+		}
+		if !fn(output.Items) || len(output.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
+
+// nextID returns the next id of the counter, as a sequence does. The counter is incremented
+// outside the transaction, so ids of transactions that failed are skipped.
+func (q *dynamoQueries) nextID(ctx context.Context, counter string) (int64, error) {
+	output, err := q.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(q.table),
+		Key:                       dynamoKey{dynamoCounterPK, counter}.attributes(),
+		UpdateExpression:          aws.String("ADD #value :one"),
+		ExpressionAttributeNames:  map[string]string{"#value": "value"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":one": numberValue(1)},
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate %s id: %w", counter, err)
+	}
+	return itemNumber(output.Attributes, "value"), nil
+}
+
+func decodeDecision(item map[string]types.AttributeValue) explorerdb.Decision {
+	return explorerdb.Decision{
+		ID:              itemNumber(item, "id"),
+		ActorUserID:     itemString(item, "actor_user_id"),
+		RecipientUserID: itemString(item, "recipient_user_id"),
+		LikedRecipient:  itemBool(item, "liked_recipient"),
+		CreatedAt:       timestamptz(itemTime(item, "created_at")),
+		UpdatedAt:       timestamptz(itemTime(item, "updated_at")),
+	}
+}
+
+// getDecision returns the decision of actor on recipient, nil when there is none
+func (q *dynamoQueries) getDecision(ctx context.Context, actorUserID, recipientUserID string) (*explorerdb.Decision, error) {
+	item, err := q.getItem(ctx, dynamoDecisionKey(actorUserID, recipientUserID))
+	if err != nil || item == nil {
+		return nil, err
+	}
+	d := decodeDecision(item)
+	return &d, nil
+}
+
+// putDecision writes d and its listing item, replacing previous, the decision it was read as
+func (q *dynamoQueries) putDecision(d explorerdb.Decision, previous *explorerdb.Decision) {
+	if previous != nil && dynamoListingKey(*previous) != dynamoListingKey(d) {
+		q.remove(dynamoListingKey(*previous))
+	}
+
+	attributes := map[string]types.AttributeValue{
+		"id":                numberValue(d.ID),
+		"actor_user_id":     stringValue(d.ActorUserID),
+		"recipient_user_id": stringValue(d.RecipientUserID),
+		"liked_recipient":   boolValue(d.LikedRecipient),
+		"created_at":        numberValue(d.CreatedAt.Time.UnixNano()),
+		"updated_at":        numberValue(d.UpdatedAt.Time.UnixNano()),
+	}
+	if d.LikedRecipient && q.likeTTL > 0 {
+		attributes["expires_at"] = numberValue(d.CreatedAt.Time.Add(q.likeTTL).Unix())
+	}
+	q.put(dynamoDecisionKey(d.ActorUserID, d.RecipientUserID), dynamoDecisionKey(d.ActorUserID, d.RecipientUserID).newItem(attributes))
+
+	listing := dynamoListingKey(d)
+	attributes["actor_pk"] = stringValue(dynamoUserPK(d.ActorUserID))
+	attributes["actor_sk"] = stringValue(dynamoDecisionSK(d.CreatedAt.Time.Unix(), d.RecipientUserID))
+	q.put(listing, listing.newItem(attributes))
+}
+
+// insertDecision stores a new decision of actor on recipient made at createdAt
+func (q *dynamoQueries) insertDecision(ctx context.Context, actorUserID, recipientUserID string, liked bool, createdAt time.Time) error {
+	id, err := q.nextID(ctx, "decision")
+	if err != nil {
+		return err
+	}
+	q.putDecision(explorerdb.Decision{
+		ID:              id,
+		ActorUserID:     actorUserID,
+		RecipientUserID: recipientUserID,
+		LikedRecipient:  liked,
+		CreatedAt:       timestamptz(createdAt),
+		UpdatedAt:       timestamptz(q.now()),
+	}, nil)
+	return nil
+}
+
+// likes reports whether actor likes recipient
+func (q *dynamoQueries) likes(ctx context.Context, actorUserID, recipientUserID string) (bool, error) {
+	d, err := q.getDecision(ctx, actorUserID, recipientUserID)
+	return d != nil && d.LikedRecipient, err
+}
+
+// blockedUsers returns the users who blocked the user or whom the user blocked
+func (q *dynamoQueries) blockedUsers(ctx context.Context, userID string) (map[string]bool, error) {
+	blocked := make(map[string]bool)
+	err := q.queryItems(ctx, &dynamodb.QueryInput{
+		KeyConditionExpression: aws.String("pk = :pk AND begins_with(sk, :block)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":    stringValue(dynamoUserPK(userID)),
+			":block": stringValue("block"),
+		},
+		ConsistentRead: aws.Bool(true),
+	}, func(items []map[string]types.AttributeValue) bool {
+		for _, item := range items {
+			blocked[itemString(item, "other_user_id")] = true
+		}
+		return true
+	})
+	return blocked, err
+}
+
+// insertMatch stores the match of user with matchedUser made at matchedAt, reporting whether
+// it is new
+func (q *dynamoQueries) insertMatch(ctx context.Context, userID, matchedUserID string, matchedAt time.Time) (bool, error) {
+	key := dynamoMatchKey(userID, matchedUserID)
+	item, err := q.getItem(ctx, key)
+	if err != nil || item != nil {
+		return false, err
+	}
+	id, err := q.nextID(ctx, "match")
+	if err != nil {
+		return false, err
+	}
+	q.put(key, key.newItem(map[string]types.AttributeValue{
+		"id":              numberValue(id),
+		"user_id":         stringValue(userID),
+		"matched_user_id": stringValue(matchedUserID),
+		"matched_at":      numberValue(matchedAt.UnixNano()),
+	}))
+	return true, nil
+}
+
+func decodeMatch(item map[string]types.AttributeValue) explorerdb.Match {
+	return explorerdb.Match{
+		ID:            itemNumber(item, "id"),
+		UserID:        itemString(item, "user_id"),
+		MatchedUserID: itemString(item, "matched_user_id"),
+		MatchedAt:     timestamptz(itemTime(item, "matched_at")),
+	}
+}
+
+// listedDecision is a decision a recipient received, read for a listing
+type listedDecision struct {
+	actorUserID string
+	createdAt   time.Time
+	id          int64
+	liked       bool
+	// decidedBack and likedBack tell whether the recipient decided on the actor and liked them
+	decidedBack, likedBack bool
+}
+
+// decisionListing is the part of the listing queries that selects the decisions read
+type decisionListing struct {
+	recipientUserID string
+	liked           bool
+	maxAgeSeconds   *int64
+	since, until    *int64
+	afterCreatedAt  pgtype.Timestamptz
+	afterID         int64
+	ascending       bool
+}
+
+// listDecisions reads the decisions of the listing in the listing order, left out those between
+// blocked users and those keep rejects. The listing items are read by the range of seconds the
+// listing spans, until want decisions are kept and the second of the last of them is read
+// through, as the items of a second are not keyed in time order; want <= 0 reads them all. The
+// decisions are returned sorted by their time and id.
+func (q *dynamoQueries) listDecisions(ctx context.Context, listing decisionListing, want int, keep func(listedDecision) bool) ([]listedDecision, error) {
+	now := q.now()
+	from, to := int64(0), int64(dynamoLastSecond)
+	if listing.maxAgeSeconds != nil {
+		from = now.Add(-time.Duration(*listing.maxAgeSeconds) * time.Second).Unix()
+	}
+	// Items are keyed by the second they were made in, and listed at it rounded
+	if listing.since != nil {
+		from = max(from, *listing.since-1)
+	}
+	if listing.until != nil {
+		to = min(to, *listing.until-1)
+	}
+	if listing.afterCreatedAt.Valid {
+		if listing.ascending {
+			from = max(from, listing.afterCreatedAt.Time.Unix())
+		} else {
+			to = min(to, listing.afterCreatedAt.Time.Unix())
+		}
+	}
+	if from > to {
+		return nil, nil
+	}
+
+	blocked, err := q.blockedUsers(ctx, listing.recipientUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []listedDecision
+	var readErr error
+	fromSK, toSK := dynamoListingRange(from, to)
+	err = q.queryItems(ctx, &dynamodb.QueryInput{
+		KeyConditionExpression: aws.String("pk = :pk AND sk BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":   stringValue(dynamoUserPK(listing.recipientUserID)),
+			":from": fromSK,
+			":to":   toSK,
+		},
+		ScanIndexForward: aws.Bool(listing.ascending),
+		ConsistentRead:   aws.Bool(true),
+		Limit:            aws.Int32(int32(max(want+1, dynamoQueryPage))),
+	}, func(items []map[string]types.AttributeValue) bool {
+		var page []listedDecision
+		var backKeys []dynamoKey
+		for _, item := range items {
+			d := listedDecision{
+				actorUserID: itemString(item, "actor_user_id"),
+				createdAt:   itemTime(item, "created_at"),
+				id:          itemNumber(item, "id"),
+				liked:       itemBool(item, "liked_recipient"),
+			}
+			if d.liked != listing.liked || blocked[d.actorUserID] ||
+				!inListingWindow(now, d.createdAt, listing.maxAgeSeconds, listing.since, listing.until) ||
+				!afterKeyset(d.createdAt, d.id, listing.afterCreatedAt, listing.afterID, listing.ascending) {
+				continue
+			}
+			page = append(page, d)
+			backKeys = append(backKeys, dynamoDecisionKey(listing.recipientUserID, d.actorUserID))
+		}
+
+		back, err := q.getItems(ctx, backKeys)
+		if err != nil {
+			readErr = err
+			return false
+		}
+		for _, d := range page {
+			if item, ok := back[dynamoDecisionKey(listing.recipientUserID, d.actorUserID)]; ok {
+				d.decidedBack, d.likedBack = true, itemBool(item, "liked_recipient")
+			}
+			if keep(d) {
+				kept = append(kept, d)
+			}
+		}
+
+		if want <= 0 || len(kept) < want || len(items) == 0 {
+			return true
+		}
+		// The items are in time order, so the second of the last kept decision is read through
+		// once a later one has been read
+		return itemTime(items[len(items)-1], "created_at").Unix() == kept[want-1].createdAt.Unix()
+	})
+	if err == nil {
+		err = readErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sortByKeyset(kept, listing.ascending, func(d listedDecision) (int64, int64) {
+		return d.createdAt.UnixMicro(), d.id
+	})
+	return kept, nil
+}
+
+func (q *dynamoQueries) AllowAuditEventErasure(ctx context.Context) error {
+	return fmt.Errorf("audit event erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+func (q *dynamoQueries) AnonymizeUserAuditEvents(ctx context.Context, arg explorerdb.AnonymizeUserAuditEventsParams) (int64, error) {
+	return 0, fmt.Errorf("audit event erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+func (q *dynamoQueries) AnonymizeUserReports(ctx context.Context, arg explorerdb.AnonymizeUserReportsParams) (int64, error) {
+	return 0, fmt.Errorf("user erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+func decodeOutboxEvent(item map[string]types.AttributeValue) explorerdb.Outbox {
+	event := explorerdb.Outbox{
+		ID:            itemNumber(item, "id"),
+		Topic:         itemString(item, "topic"),
+		Payload:       itemBytes(item, "payload"),
+		Attempts:      int32(itemNumber(item, "attempts")),
+		NextAttemptAt: timestamptz(itemTime(item, "next_attempt_at")),
+		CreatedAt:     timestamptz(itemTime(item, "created_at")),
+	}
+	if _, ok := item["last_error"]; ok {
+		lastError := itemString(item, "last_error")
+		event.LastError = &lastError
+	}
+	return event
+}
+
+// ClaimOutboxEvents claims the events due for delivery by leasing them, in the order they were
+// written. An event leased by another drain, or changed since it was read, is skipped.
+func (q *dynamoQueries) ClaimOutboxEvents(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error) {
+	now := q.now()
+	var events []explorerdb.Outbox
+	var claimErr error
+	err := q.queryItems(ctx, &dynamodb.QueryInput{
+		KeyConditionExpression:    aws.String("pk = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pk": stringValue(dynamoOutboxPK)},
+		ConsistentRead:            aws.Bool(true),
+	}, func(items []map[string]types.AttributeValue) bool {
+		for _, item := range items {
+			if int32(len(events)) == arg.BatchSize {
+				return false
+			}
+			event := decodeOutboxEvent(item)
+			if event.NextAttemptAt.Time.After(now) || event.Attempts >= arg.MaxAttempts || itemTime(item, "claimed_until").After(now) {
+				continue
+			}
+
+			_, err := q.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                aws.String(q.table),
+				Key:                      dynamoKey{dynamoOutboxPK, dynamoIDSK(event.ID)}.attributes(),
+				UpdateExpression:         aws.String("SET claimed_until = :until ADD #version :one"),
+				ConditionExpression:      aws.String("#version = :version"),
+				ExpressionAttributeNames: map[string]string{"#version": "version"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":until":   numberValue(now.Add(dynamoOutboxLease).UnixNano()),
+					":one":     numberValue(1),
+					":version": numberValue(itemNumber(item, "version")),
+				},
+			})
+			var conditionErr *types.ConditionalCheckFailedException
+			if errors.As(err, &conditionErr) {
+				continue
+			}
+			if err != nil {
+				claimErr = err
+				return false
+			}
+			events = append(events, event)
+		}
+		return int32(len(events)) < arg.BatchSize
+	})
+	if err == nil {
+		err = claimErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// CountLikes counts the likes the recipient received, left out likes between blocked users.
+// As in Postgres, likes past the like TTL are counted until the table's time to live deletes them.
+func (q *dynamoQueries) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	return q.countLikes(ctx, recipientUserID, 0)
+}
+
+// CountRecentLikes counts the likes the recipient received at most MaxAgeSeconds ago, left out
+// likes between blocked users
+func (q *dynamoQueries) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (int64, error) {
+	return q.countLikes(ctx, arg.RecipientUserID, q.now().Unix()-arg.MaxAgeSeconds)
+}
+
+// countLikes counts the likes the recipient received from the second from on
+func (q *dynamoQueries) countLikes(ctx context.Context, recipientUserID string, from int64) (int64, error) {
+	blocked, err := q.blockedUsers(ctx, recipientUserID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	fromSK, toSK := dynamoListingRange(max(from, 0), dynamoLastSecond)
+	err = q.queryItems(ctx, &dynamodb.QueryInput{
+		KeyConditionExpression: aws.String("pk = :pk AND sk BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":   stringValue(dynamoUserPK(recipientUserID)),
+			":from": fromSK,
+			":to":   toSK,
+		},
+		ConsistentRead: aws.Bool(true),
+	}, func(items []map[string]types.AttributeValue) bool {
+		for _, item := range items {
+			if itemBool(item, "liked_recipient") && !blocked[itemString(item, "actor_user_id")] {
+				count++
+			}
+		}
+		return true
+	})
+	return count, err
+}
+
+func (q *dynamoQueries) CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		id, err := q.nextID(ctx, "audit_event")
+		if err != nil {
+			return err
+		}
+		userIDs := make([]types.AttributeValue, len(arg.UserIds))
+		for i, userID := range arg.UserIds {
+			userIDs[i] = stringValue(userID)
+		}
+		key := dynamoKey{dynamoAuditEventsPK, dynamoIDSK(id)}
+		q.put(key, key.newItem(map[string]types.AttributeValue{
+			"id":                numberValue(id),
+			"occurred_at":       numberValue(q.now().UnixNano()),
+			"action":            stringValue(arg.Action),
+			"caller_id":         stringValue(arg.CallerID),
+			"caller_is_service": boolValue(arg.CallerIsService),
+			"client_address":    stringValue(arg.ClientAddress),
+			"request_id":        stringValue(arg.RequestID),
+			"user_ids":          &types.AttributeValueMemberL{Value: userIDs},
+			"outcome":           stringValue(arg.Outcome),
+			"details":           bytesValue(arg.Details),
+		}))
+		return nil
+	})
+}
+
+func (q *dynamoQueries) CreateBlock(ctx context.Context, arg explorerdb.CreateBlockParams) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		key := dynamoBlockKey(arg.BlockerUserID, arg.BlockedUserID)
+		item, err := q.getItem(ctx, key)
+		if err != nil || item != nil {
+			return err
+		}
+		id, err := q.nextID(ctx, "block")
+		if err != nil {
+			return err
+		}
+		attributes := map[string]types.AttributeValue{
+			"id":              numberValue(id),
+			"blocker_user_id": stringValue(arg.BlockerUserID),
+			"blocked_user_id": stringValue(arg.BlockedUserID),
+			"created_at":      numberValue(q.now().UnixNano()),
+			"other_user_id":   stringValue(arg.BlockedUserID),
+		}
+		q.put(key, key.newItem(attributes))
+		mirror := dynamoBlockedByKey(arg.BlockerUserID, arg.BlockedUserID)
+		attributes["other_user_id"] = stringValue(arg.BlockerUserID)
+		q.put(mirror, mirror.newItem(attributes))
+		return nil
+	})
+}
+
+func (q *dynamoQueries) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error) {
+	var row explorerdb.CreateDecisionRow
+	err := q.inTx(ctx, func(q *dynamoQueries) error {
+		previous, err := q.getDecision(ctx, arg.ActorUserID, arg.RecipientUserID)
+		if err != nil {
+			return err
+		}
+		now := q.now()
+		row.CreatedAt = timestamptz(now)
+		if previous == nil {
+			row.Inserted = true
+			return q.insertDecision(ctx, arg.ActorUserID, arg.RecipientUserID, arg.LikedRecipient, now)
+		}
+
+		row.PreviousLikedRecipient = previous.LikedRecipient
+		d := *previous
+		d.LikedRecipient = arg.LikedRecipient
+		d.CreatedAt = row.CreatedAt
+		d.UpdatedAt = d.CreatedAt
+		q.putDecision(d, previous)
+		return nil
+	})
+	if err != nil {
+		return explorerdb.CreateDecisionRow{}, err
+	}
+	return row, nil
+}
+
+func (q *dynamoQueries) CreateErasureReceipt(ctx context.Context, arg explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error) {
+	return explorerdb.CreateErasureReceiptRow{}, fmt.Errorf("user erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+func (q *dynamoQueries) CreateMatch(ctx context.Context, arg explorerdb.CreateMatchParams) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		now := q.now()
+		if _, err := q.insertMatch(ctx, arg.UserID, arg.MatchedUserID, now); err != nil {
+			return err
+		}
+		_, err := q.insertMatch(ctx, arg.MatchedUserID, arg.UserID, now)
+		return err
+	})
+}
+
+func (q *dynamoQueries) CreateOutboxEvent(ctx context.Context, arg explorerdb.CreateOutboxEventParams) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		id, err := q.nextID(ctx, "outbox")
+		if err != nil {
+			return err
+		}
+		now := q.now().UnixNano()
+		key := dynamoKey{dynamoOutboxPK, dynamoIDSK(id)}
+		q.put(key, key.newItem(map[string]types.AttributeValue{
+			"id":              numberValue(id),
+			"topic":           stringValue(arg.Topic),
+			"payload":         bytesValue(arg.Payload),
+			"attempts":        numberValue(0),
+			"next_attempt_at": numberValue(now),
+			"created_at":      numberValue(now),
+		}))
+		return nil
+	})
+}
+
+func (q *dynamoQueries) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (explorerdb.Report, error) {
+	report := explorerdb.Report{
+		ReporterUserID: arg.ReporterUserID,
+		ReportedUserID: arg.ReportedUserID,
+		Reason:         arg.Reason,
+		CreatedAt:      timestamptz(q.now()),
+	}
+	decisions, err := q.getItems(ctx, []dynamoKey{
+		dynamoDecisionKey(arg.ReporterUserID, arg.ReportedUserID),
+		dynamoDecisionKey(arg.ReportedUserID, arg.ReporterUserID),
+	})
+	if err != nil {
+		return explorerdb.Report{}, err
+	}
+	if item, ok := decisions[dynamoDecisionKey(arg.ReporterUserID, arg.ReportedUserID)]; ok {
+		liked := itemBool(item, "liked_recipient")
+		report.ReporterLiked = &liked
+	}
+	if item, ok := decisions[dynamoDecisionKey(arg.ReportedUserID, arg.ReporterUserID)]; ok {
+		liked := itemBool(item, "liked_recipient")
+		report.ReportedLiked = &liked
+	}
+
+	err = q.inTx(ctx, func(q *dynamoQueries) error {
+		var err error
+		if report.ID, err = q.nextID(ctx, "report"); err != nil {
+			return err
+		}
+		attributes := map[string]types.AttributeValue{
+			"id":               numberValue(report.ID),
+			"reporter_user_id": stringValue(report.ReporterUserID),
+			"reported_user_id": stringValue(report.ReportedUserID),
+			"reason":           stringValue(report.Reason),
+			"created_at":       numberValue(report.CreatedAt.Time.UnixNano()),
+		}
+		if report.ReporterLiked != nil {
+			attributes["reporter_liked"] = boolValue(*report.ReporterLiked)
+		}
+		if report.ReportedLiked != nil {
+			attributes["reported_liked"] = boolValue(*report.ReportedLiked)
+		}
+		key := dynamoKey{dynamoUserPK(report.ReportedUserID), "report#" + dynamoIDSK(report.ID)}
+		q.put(key, key.newItem(attributes))
+		return nil
+	})
+	if err != nil {
+		return explorerdb.Report{}, err
+	}
+	return report, nil
+}
+
+func (q *dynamoQueries) DeadLetterOutboxEvent(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		key := dynamoKey{dynamoOutboxPK, dynamoIDSK(arg.ID)}
+		item, err := q.getItem(ctx, key)
+		if err != nil || item == nil {
+			return err
+		}
+		id, err := q.nextID(ctx, "outbox_dead_letter")
+		if err != nil {
+			return err
+		}
+		event := decodeOutboxEvent(item)
+		q.remove(key)
+		deadLetter := dynamoKey{dynamoDeadLetterPK, dynamoIDSK(id)}
+		q.put(deadLetter, deadLetter.newItem(map[string]types.AttributeValue{
+			"id":         numberValue(id),
+			"outbox_id":  numberValue(event.ID),
+			"topic":      stringValue(event.Topic),
+			"payload":    bytesValue(event.Payload),
+			"attempts":   numberValue(int64(event.Attempts) + 1),
+			"last_error": stringValue(arg.LastError),
+			"created_at": numberValue(event.CreatedAt.Time.UnixNano()),
+			"failed_at":  numberValue(q.now().UnixNano()),
+		}))
+		return nil
+	})
+}
+
+func (q *dynamoQueries) DeleteBlock(ctx context.Context, arg explorerdb.DeleteBlockParams) (int64, error) {
+	var deleted int64
+	err := q.inTx(ctx, func(q *dynamoQueries) error {
+		key := dynamoBlockKey(arg.BlockerUserID, arg.BlockedUserID)
+		item, err := q.getItem(ctx, key)
+		if err != nil || item == nil {
+			return err
+		}
+		q.remove(key)
+		q.remove(dynamoBlockedByKey(arg.BlockerUserID, arg.BlockedUserID))
+		deleted = 1
+		return nil
+	})
+	return deleted, err
+}
+
+func (q *dynamoQueries) DeleteDecision(ctx context.Context, arg explorerdb.DeleteDecisionParams) (int64, error) {
+	var deleted int64
+	err := q.inTx(ctx, func(q *dynamoQueries) error {
+		d, err := q.getDecision(ctx, arg.ActorUserID, arg.RecipientUserID)
+		if err != nil || d == nil {
+			return err
+		}
+		q.remove(dynamoListingKey(*d))
+		q.remove(dynamoDecisionKey(arg.ActorUserID, arg.RecipientUserID))
+		deleted = 1
+		return nil
+	})
+	return deleted, err
+}
+
+func (q *dynamoQueries) DeleteMatch(ctx context.Context, arg explorerdb.DeleteMatchParams) (int64, error) {
+	var deleted int64
+	err := q.inTx(ctx, func(q *dynamoQueries) error {
+		keys := []dynamoKey{dynamoMatchKey(arg.UserID, arg.MatchedUserID), dynamoMatchKey(arg.MatchedUserID, arg.UserID)}
+		matches, err := q.getItems(ctx, keys)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if _, ok := matches[key]; ok {
+				q.remove(key)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (q *dynamoQueries) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		q.remove(dynamoKey{dynamoOutboxPK, dynamoIDSK(id)})
+		return nil
+	})
+}
+
+func (q *dynamoQueries) DeleteUserBlocks(ctx context.Context, userID string) (int64, error) {
+	return 0, fmt.Errorf("user erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+func (q *dynamoQueries) DeleteUserDecisions(ctx context.Context, userID string) ([]explorerdb.DeleteUserDecisionsRow, error) {
+	return nil, fmt.Errorf("user erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+// DeleteUserLikeCount leaves nothing to delete, as like counts are derived from decisions
+func (q *dynamoQueries) DeleteUserLikeCount(ctx context.Context, recipientUserID string) error {
+	return nil
+}
+
+func (q *dynamoQueries) DeleteUserMatches(ctx context.Context, userID string) (int64, error) {
+	return 0, fmt.Errorf("user erasure needs the database storage: %w", errors.ErrUnsupported)
+}
+
+// DeleteUserPopularityScore leaves nothing to delete, as popularity scores are written by the
+// ranking pipeline, which only feeds Postgres: every user scores 0
+func (q *dynamoQueries) DeleteUserPopularityScore(ctx context.Context, actorUserID string) error {
+	return nil
+}
+
+func (q *dynamoQueries) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (explorerdb.Decision, error) {
+	d, err := q.getDecision(ctx, arg.ActorUserID, arg.RecipientUserID)
+	if err != nil {
+		return explorerdb.Decision{}, err
+	}
+	if d == nil {
+		return explorerdb.Decision{}, pgx.ErrNoRows
+	}
+	return *d, nil
+}
+
+func (q *dynamoQueries) GetServiceStats(ctx context.Context) (explorerdb.GetServiceStatsRow, error) {
+	return explorerdb.GetServiceStatsRow{}, fmt.Errorf("service stats need the database storage: %w", errors.ErrUnsupported)
+}
+
+func (q *dynamoQueries) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+	keys := []dynamoKey{
+		dynamoDecisionKey(arg.ActorUserID, arg.RecipientUserID),
+		dynamoDecisionKey(arg.RecipientUserID, arg.ActorUserID),
+	}
+	decisions, err := q.getItems(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	mutual := itemBool(decisions[keys[0]], "liked_recipient") && itemBool(decisions[keys[1]], "liked_recipient")
+	return &mutual, nil
+}
+
+func (q *dynamoQueries) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (bool, error) {
+	blocks, err := q.getItems(ctx, []dynamoKey{
+		dynamoBlockKey(arg.BlockerUserID, arg.BlockedUserID),
+		dynamoBlockKey(arg.BlockedUserID, arg.BlockerUserID),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(blocks) > 0, nil
+}
+
+func (q *dynamoQueries) ListAuditEvents(ctx context.Context, arg explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error) {
+	condition := "pk = :pk"
+	values := map[string]types.AttributeValue{":pk": stringValue(dynamoAuditEventsPK)}
+	if arg.BeforeID != nil {
+		condition += " AND sk < :before"
+		values[":before"] = stringValue(dynamoIDSK(*arg.BeforeID))
+	}
+
+	var events []explorerdb.AuditEvent
+	err := q.queryItems(ctx, &dynamodb.QueryInput{
+		KeyConditionExpression:    aws.String(condition),
+		ExpressionAttributeValues: values,
+		ScanIndexForward:          aws.Bool(false),
+		ConsistentRead:            aws.Bool(true),
+	}, func(items []map[string]types.AttributeValue) bool {
+		for _, item := range items {
+			if int32(len(events)) == arg.PageLimit {
+				return false
+			}
+			event := explorerdb.AuditEvent{
+				ID:              itemNumber(item, "id"),
+				OccurredAt:      timestamptz(itemTime(item, "occurred_at")),
+				Action:          itemString(item, "action"),
+				CallerID:        itemString(item, "caller_id"),
+				CallerIsService: itemBool(item, "caller_is_service"),
+				ClientAddress:   itemString(item, "client_address"),
+				RequestID:       itemString(item, "request_id"),
+				Outcome:         itemString(item, "outcome"),
+				Details:         itemBytes(item, "details"),
+			}
+			if userIDs, ok := item["user_ids"].(*types.AttributeValueMemberL); ok {
+				for _, userID := range userIDs.Value {
+					if s, ok := userID.(*types.AttributeValueMemberS); ok {
+						event.UserIds = append(event.UserIds, s.Value)
+					}
+				}
+			}
+
+			occurredAt := event.OccurredAt.Time.Unix()
+			switch {
+			case arg.UserID != nil && !slices.Contains(event.UserIds, *arg.UserID) && event.CallerID != *arg.UserID,
+				arg.Action != nil && event.Action != *arg.Action,
+				arg.Since != nil && occurredAt < *arg.Since,
+				arg.Until != nil && occurredAt >= *arg.Until:
+				continue
+			}
+			events = append(events, event)
+		}
+		return int32(len(events)) < arg.PageLimit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (q *dynamoQueries) ListDecidersAsc(ctx context.Context, arg explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error) {
+	rows, err := q.listDeciders(ctx, explorerdb.ListDecidersDescParams(arg), true)
+	return convertDeciders[explorerdb.ListDecidersAscRow](rows), err
+}
+
+func (q *dynamoQueries) ListDecidersDesc(ctx context.Context, arg explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error) {
+	return q.listDeciders(ctx, arg, false)
+}
+
+// listDeciders reads the deciders listing in either order
+func (q *dynamoQueries) listDeciders(ctx context.Context, arg explorerdb.ListDecidersDescParams, ascending bool) ([]explorerdb.ListDecidersDescRow, error) {
+	decisions, err := q.listDecisions(ctx, decisionListing{
+		recipientUserID: arg.RecipientUserID,
+		liked:           arg.LikedRecipient,
+		maxAgeSeconds:   arg.MaxAgeSeconds,
+		since:           arg.Since,
+		until:           arg.Until,
+		afterCreatedAt:  arg.AfterCreatedAt,
+		afterID:         arg.AfterID,
+		ascending:       ascending,
+	}, int(arg.PageOffset+arg.PageLimit), func(d listedDecision) bool {
+		return arg.LikedBack == nil || d.likedBack == *arg.LikedBack
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []explorerdb.ListDecidersDescRow
+	for _, d := range pageOf(decisions, arg.PageLimit, arg.PageOffset) {
+		rows = append(rows, explorerdb.ListDecidersDescRow{
+			ActorUserID: d.actorUserID,
+			Timestamp:   listingSecond(d.createdAt),
+			LikedBack:   d.likedBack,
+			ID:          d.id,
+			CreatedAt:   timestamptz(d.createdAt),
+		})
+	}
+	return rows, nil
+}
+
+// ListLikersByPopularity reads every like of the recipient to rank them, which the ranking by
+// id amounts to as every user scores 0, see DeleteUserPopularityScore
+func (q *dynamoQueries) ListLikersByPopularity(ctx context.Context, arg explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error) {
+	decisions, err := q.listDecisions(ctx, decisionListing{
+		recipientUserID: arg.RecipientUserID,
+		liked:           true,
+		maxAgeSeconds:   arg.MaxAgeSeconds,
+		since:           arg.Since,
+		until:           arg.Until,
+	}, 0, func(d listedDecision) bool {
+		return arg.AfterScore == nil || *arg.AfterScore > 0 || (*arg.AfterScore == 0 && d.id < arg.AfterID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(decisions, func(a, b listedDecision) int { return cmp.Compare(b.id, a.id) })
+
+	var rows []explorerdb.ListLikersByPopularityRow
+	for _, d := range pageOf(decisions, arg.PageLimit, arg.PageOffset) {
+		rows = append(rows, explorerdb.ListLikersByPopularityRow{
+			ActorUserID: d.actorUserID,
+			Timestamp:   listingSecond(d.createdAt),
+			LikedBack:   d.likedBack,
+			ID:          d.id,
+		})
+	}
+	return rows, nil
+}
+
+func (q *dynamoQueries) ListNewLikersAsc(ctx context.Context, arg explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error) {
+	rows, err := q.listNewLikers(ctx, explorerdb.ListNewLikersDescParams(arg), true)
+	return convertNewLikers[explorerdb.ListNewLikersAscRow](rows), err
+}
+
+func (q *dynamoQueries) ListNewLikersDesc(ctx context.Context, arg explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error) {
+	return q.listNewLikers(ctx, arg, false)
+}
+
+// listNewLikers lists the likes whose recipient has not decided on the liker, which the
+// new_likes table holds in Postgres, in either order
+func (q *dynamoQueries) listNewLikers(ctx context.Context, arg explorerdb.ListNewLikersDescParams, ascending bool) ([]explorerdb.ListNewLikersDescRow, error) {
+	decisions, err := q.listDecisions(ctx, decisionListing{
+		recipientUserID: arg.RecipientUserID,
+		liked:           true,
+		maxAgeSeconds:   arg.MaxAgeSeconds,
+		since:           arg.Since,
+		until:           arg.Until,
+		afterCreatedAt:  arg.AfterCreatedAt,
+		afterID:         arg.AfterID,
+		ascending:       ascending,
+	}, int(arg.PageOffset+arg.PageLimit), func(d listedDecision) bool {
+		return !d.decidedBack
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []explorerdb.ListNewLikersDescRow
+	for _, d := range pageOf(decisions, arg.PageLimit, arg.PageOffset) {
+		rows = append(rows, explorerdb.ListNewLikersDescRow{
+			ActorUserID: d.actorUserID,
+			Timestamp:   listingSecond(d.createdAt),
+			ID:          d.id,
+			CreatedAt:   timestamptz(d.createdAt),
+		})
+	}
+	return rows, nil
+}
+
+func (q *dynamoQueries) ListUserDecisions(ctx context.Context, arg explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error) {
+	return nil, fmt.Errorf("user decisions listing needs the database storage: %w", errors.ErrUnsupported)
+}
+
+// LockDecisionPair has nothing to lock: a transaction reads the decisions of the pair it
+// writes, and the commit fails if either changed since
+func (q *dynamoQueries) LockDecisionPair(ctx context.Context, arg explorerdb.LockDecisionPairParams) error {
+	return nil
+}
+
+// PurgeExpiredLikes leaves the likes to the table's time to live, which deletes them once
+// their expires_at passes
+func (q *dynamoQueries) PurgeExpiredLikes(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams) (int64, error) {
+	return 0, nil
+}
+
+// ReconcileLikeCounts has no counts to correct, as like counts are derived from decisions
+func (q *dynamoQueries) ReconcileLikeCounts(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (q *dynamoQueries) RetryOutboxEvent(ctx context.Context, arg explorerdb.RetryOutboxEventParams) error {
+	return q.inTx(ctx, func(q *dynamoQueries) error {
+		key := dynamoKey{dynamoOutboxPK, dynamoIDSK(arg.ID)}
+		item, err := q.getItem(ctx, key)
+		if err != nil || item == nil {
+			return err
+		}
+		attempts := itemNumber(item, "attempts")
+		delay := min(time.Duration(math.Pow(2, float64(attempts)))*time.Second, maxOutboxRetryDelay)
+
+		item = maps.Clone(item)
+		delete(item, "claimed_until")
+		item["attempts"] = numberValue(attempts + 1)
+		item["last_error"] = stringValue(arg.LastError)
+		item["next_attempt_at"] = numberValue(q.now().Add(delay).UnixNano())
+		q.put(key, item)
+		return nil
+	})
+}
+
+// UpsertDecisions upserts the decisions in one transaction, and returns their rows through
+// the batch results sqlc reads them from
+func (q *dynamoQueries) UpsertDecisions(ctx context.Context, arg []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults {
+	rows := make([]explorerdb.UpsertDecisionsRow, 0, len(arg))
+	err := q.inTx(ctx, func(q *dynamoQueries) error {
+		for _, decision := range arg {
+			created, err := q.CreateDecision(ctx, explorerdb.CreateDecisionParams(decision))
+			if err != nil {
+				return err
+			}
+			row := explorerdb.UpsertDecisionsRow{Inserted: created.Inserted}
+			if decision.LikedRecipient {
+				if row.MutualLike, err = q.likes(ctx, decision.RecipientUserID, decision.ActorUserID); err != nil {
+					return err
+				}
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+	if err != nil && len(rows) == len(arg) {
+		// The commit failed, which fails every decision
+		rows = nil
+	}
+	return explorerdb.New(upsertedDecisions{rows: rows, err: err}).UpsertDecisions(ctx, arg)
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// dynamoStore is an ExplorerRepository keeping the decisions in a DynamoDB table, for the
+// serverless deployment that has no relational database. It runs the transactions of
+// explorerStore with the queries of dynamoQueries, each committed with one TransactWriteItems
+// call, so decisions are recorded, listed and paged as on Postgres. A transaction that races
+// another one on the same users fails with a TransactionCanceledException, which the retrying
+// repository retries as it does a serialization failure.
+type dynamoStore struct {
+	*dynamoQueries
+	likerListings
+	logger *zap.Logger
+}
+
+// NewDynamoDBExplorerRepository returns an ExplorerRepository on the DynamoDB table of the
+// README. Like NewExplorerRepository, likeTTL hides likes older than it from likers listings,
+// zero keeping likes forever; likes are also given an expires_at from it, for the table's time
+// to live to delete them.
+func NewDynamoDBExplorerRepository(client DynamoDBClient, table string, likeTTL time.Duration, logger *zap.Logger) ExplorerRepository {
+	queries := &dynamoQueries{client: client, table: table, likeTTL: likeTTL, now: time.Now}
+	return &dynamoStore{
+		dynamoQueries: queries,
+		likerListings: likerListings{q: queries, likeTTL: likeTTL, logger: logger},
+		logger:        logger,
+	}
+}
+
+// NewDynamoDBAuditRepository returns an AuditRepository keeping the audit trail in the DynamoDB table
+func NewDynamoDBAuditRepository(client DynamoDBClient, table string, logger *zap.Logger) AuditRepository {
+	return &auditStore{Querier: &dynamoQueries{client: client, table: table, now: time.Now}, logger: logger}
+}
+
+// CountLikers returns the number of likes the recipient received, left out likes between
+// blocked users and, as in Postgres, likes past the like TTL
+func (s *dynamoStore) CountLikers(ctx context.Context, recipientUserID string) (int64, error) {
+	return countLikers(ctx, s, s.maxLikeAge(), recipientUserID)
+}
+
+// GetLikedRecipients returns users the actor has liked with pagination, read from the actor
+// index. Like any global secondary index it is eventually consistent, so a like just recorded
+// may take a moment to be listed.
+func (s *dynamoStore) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	condition := "actor_pk = :pk"
+	values := map[string]types.AttributeValue{":pk": stringValue(dynamoUserPK(actorUserID))}
+	if page.Token != "" {
+		condition += " AND actor_sk < :before"
+		values[":before"] = stringValue(fmt.Sprintf("%010d#", cursor.LastCreatedAt))
+	}
+
+	var recipients []models.Recipient
+	err = s.queryItems(ctx, &dynamodb.QueryInput{
+		IndexName:                 aws.String(dynamoActorIndex),
+		KeyConditionExpression:    aws.String(condition),
+		ExpressionAttributeValues: values,
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(int32(max(cursor.Limit+1, dynamoQueryPage))),
+	}, func(items []map[string]types.AttributeValue) bool {
+		for _, item := range items {
+			if len(recipients) > cursor.Limit {
+				return false
+			}
+			if itemBool(item, "liked_recipient") {
+				recipients = append(recipients, models.Recipient{
+					RecipientID: itemString(item, "recipient_user_id"),
+					Timestamp:   itemTime(item, "created_at").Unix(),
+				})
+			}
+		}
+		return len(recipients) <= cursor.Limit
+	})
+	if err != nil {
+		s.logger.Error("Failed to get liked recipients",
+			zap.String("actor_user_id", actorUserID),
+			zap.Error(err))
+		return nil, "", fmt.Errorf("failed to get liked recipients: %w", err)
+	}
+
+	var nextPaginationToken string
+	if len(recipients) > cursor.Limit {
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: recipients[cursor.Limit-1].Timestamp,
+			Limit:         cursor.Limit,
+		}
+		nextPaginationToken, err = nextCursor.Encode()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		recipients = recipients[:cursor.Limit]
+	}
+
+	return recipients, nextPaginationToken, nil
+}
+
+// GetMatches returns users the user has a mutual like with, most recent match first. The
+// matches of a user are few, so they are all read and sorted for each page.
+func (s *dynamoStore) GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var matched []explorerdb.Match
+	err = s.queryItems(ctx, &dynamodb.QueryInput{
+		KeyConditionExpression: aws.String("pk = :pk AND begins_with(sk, :match)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":    stringValue(dynamoUserPK(userID)),
+			":match": stringValue("match#"),
+		},
+		ConsistentRead: aws.Bool(true),
+	}, func(items []map[string]types.AttributeValue) bool {
+		for _, item := range items {
+			match := decodeMatch(item)
+			if page.Token == "" || match.MatchedAt.Time.Unix() < cursor.LastCreatedAt {
+				matched = append(matched, match)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		s.logger.Error("Failed to get matches",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, "", fmt.Errorf("failed to get matches: %w", err)
+	}
+	sortByKeyset(matched, false, func(match explorerdb.Match) (int64, int64) {
+		return match.MatchedAt.Time.Unix(), match.ID
+	})
+
+	var matches []models.Match
+	for _, match := range pageOf(matched, int32(cursor.Limit+1), 0) {
+		matches = append(matches, models.Match{
+			UserID:    match.MatchedUserID,
+			MatchedAt: match.MatchedAt.Time.Unix(),
+		})
+	}
+
+	var nextPaginationToken string
+	if len(matches) > cursor.Limit {
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: matches[cursor.Limit-1].MatchedAt,
+			Limit:         cursor.Limit,
+		}
+		nextPaginationToken, err = nextCursor.Encode()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		matches = matches[:cursor.Limit]
+	}
+
+	return matches, nextPaginationToken, nil
+}
+
+// RecordDecision stores the decision as explorerStore.RecordDecision does. The decisions on the
+// pair of users are serialized by the transaction reading both of them: it fails to commit if
+// either changed since, so of two concurrent opposite likes one is retried and sees the other.
+func (s *dynamoStore) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	var recorded models.RecordedDecision
+	err := s.inTx(ctx, func(q *dynamoQueries) error {
+		var err error
+		recorded, err = recordDecision(ctx, q, decision, events)
+		return err
+	})
+	if err != nil {
+		return models.RecordedDecision{}, err
+	}
+	return recorded, nil
+}
+
+// RemoveDecision withdraws the decision as explorerStore.RemoveDecision does. As in
+// RecordDecision, the transaction reads both decisions on the pair of users and fails to commit
+// if either changed since.
+func (s *dynamoStore) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	var removed models.RemovedDecision
+	err := s.inTx(ctx, func(q *dynamoQueries) error {
+		var err error
+		removed, err = removeDecision(ctx, q, decision)
+		return err
+	})
+	if err != nil {
+		return models.RemovedDecision{}, err
+	}
+	return removed, nil
+}
+
+// atBatchIndex reports the error of a decision stored on its own as the error of decision i of
+// its batch
+func atBatchIndex(i int, action string, err error) error {
+	var decisionErr *models.DecisionError
+	if errors.As(err, &decisionErr) {
+		err = decisionErr.Err
+	}
+	return fmt.Errorf("failed to %s decision %d: %w", action, i, &models.DecisionError{Index: i, Err: err})
+}
+
+// CreateDecisions stores all decisions in a single transaction as explorerStore.CreateDecisions
+// does. One TransactWriteItems call writes at most 100 items, a handful per decision, so a batch
+// needing more fails with models.ErrBatchTooLarge and stores none of its decisions.
+func (s *dynamoStore) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error) {
+	var results []models.DecisionResult
+	err := s.inTx(ctx, func(q *dynamoQueries) error {
+		var err error
+		results, err = createDecisions(ctx, q, decisions, events, s.logger)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// IngestDecisions upserts the decisions as explorerStore.IngestDecisions does, but in one
+// transaction per decision, as the batches the PutDecisions stream flushes write more items than
+// one TransactWriteItems call takes. The stream only keeps the batches flushed before a failure,
+// and here the decisions of the failing batch before the one that failed stay stored too;
+// backfills upsert, so re-running them completes them either way.
+func (s *dynamoStore) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error) {
+	var summary models.IngestSummary
+	for i := range decisions {
+		var ingested models.IngestSummary
+		err := s.inTx(ctx, func(q *dynamoQueries) error {
+			var err error
+			ingested, err = ingestDecisions(ctx, q, decisions[i:i+1], zap.NewNop())
+			return err
+		})
+		if err != nil {
+			err = atBatchIndex(i, "upsert", err)
+			s.logger.Error("Failed to ingest decisions", zap.Error(err))
+			return models.IngestSummary{}, err
+		}
+		summary.Created += ingested.Created
+		summary.Updated += ingested.Updated
+		summary.MutualLikes += ingested.MutualLikes
+	}
+	return summary, nil
+}
+
+// ImportDecisions loads decisions carried over from another system as explorerStore.ImportDecisions
+// does: the latest decision of each pair wins and only replaces a stored decision made before it,
+// matches are created for mutual likes and dated by the like completing them, and no outbox
+// events are written. Each pair is imported in its own transaction, so an import that fails
+// leaves the pairs before it imported; importing the file again completes it.
+func (s *dynamoStore) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error) {
+	summary := models.ImportSummary{Rows: len(decisions)}
+
+	latest := make(map[userPair]models.ImportedDecision, len(decisions))
+	passed := make(map[userPair]bool)
+	var pairs []userPair
+	for _, d := range decisions {
+		pair := userPair{d.ActorUserID, d.RecipientUserID}
+		if !d.LikedRecipient {
+			passed[pair] = true
+		}
+		previous, seen := latest[pair]
+		if !seen {
+			pairs = append(pairs, pair)
+		}
+		if !seen || !d.CreatedAt.Before(previous.CreatedAt) {
+			latest[pair] = d
+		}
+	}
+
+	var created int
+	for _, pair := range pairs {
+		d := latest[pair]
+		var inserted, updated bool
+		var matches int
+		err := s.inTx(ctx, func(q *dynamoQueries) error {
+			inserted, updated, matches = false, false, 0
+
+			stored, err := q.getDecision(ctx, pair.user, pair.other)
+			if err != nil {
+				return err
+			}
+			switch {
+			case stored == nil:
+				if err := q.insertDecision(ctx, pair.user, pair.other, d.LikedRecipient, d.CreatedAt); err != nil {
+					return err
+				}
+				inserted = true
+			case stored.CreatedAt.Time.Before(d.CreatedAt):
+				decision := *stored
+				decision.LikedRecipient = d.LikedRecipient
+				decision.CreatedAt = timestamptz(d.CreatedAt)
+				decision.UpdatedAt = timestamptz(q.now())
+				q.putDecision(decision, stored)
+				updated = true
+			}
+
+			current, err := q.getDecision(ctx, pair.user, pair.other)
+			if err != nil {
+				return err
+			}
+			if !current.LikedRecipient {
+				// A pass that won over a stored like ends the match it was part of
+				if passed[pair] {
+					_, err := q.DeleteMatch(ctx, explorerdb.DeleteMatchParams{UserID: pair.user, MatchedUserID: pair.other})
+					return err
+				}
+				return nil
+			}
+
+			back, err := q.getDecision(ctx, pair.other, pair.user)
+			if err != nil || back == nil || !back.LikedRecipient {
+				return err
+			}
+			matchedAt := current.CreatedAt.Time
+			if back.CreatedAt.Time.After(matchedAt) {
+				matchedAt = back.CreatedAt.Time
+			}
+			for _, match := range []userPair{pair, {pair.other, pair.user}} {
+				inserted, err := q.insertMatch(ctx, match.user, match.other, matchedAt)
+				if err != nil {
+					return err
+				}
+				if inserted {
+					matches++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			s.logger.Error("Failed to import decision",
+				zap.String("actor_user_id", pair.user),
+				zap.String("recipient_user_id", pair.other),
+				zap.Error(err))
+			return models.ImportSummary{}, fmt.Errorf("failed to import decisions: %w", err)
+		}
+
+		switch {
+		case inserted:
+			summary.Created++
+		case updated:
+			summary.Updated++
+		}
+		created += matches
+	}
+	summary.Skipped = summary.Rows - summary.Created - summary.Updated
+	summary.Matches = created / 2
+
+	return summary, nil
+}
+
+// DrainOutbox claims the outbox events due for delivery and hands each to deliver, settling
+// them as explorerStore.DrainOutbox does. Each event is settled in its own transaction, and the
+// events are leased rather than locked while they are delivered, see dynamoOutboxLease.
+func (s *dynamoStore) DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+	return drainOutbox(ctx, s.dynamoQueries, claim, deliver, s.logger)
+}
@@ -0,0 +1,304 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/backend-interview-task/config"
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+	mocks "github.com/backend-interview-task/mocks/repository"
+	"github.com/backend-interview-task/utils"
+)
+
+type DynamoDBRepositoryTestSuite struct {
+	suite.Suite
+	client *mocks.DynamoDBClient
+	repo   repository.ExplorerRepository
+	ctx    context.Context
+}
+
+func TestDynamoDBRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(DynamoDBRepositoryTestSuite))
+}
+
+func (s *DynamoDBRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.client = mocks.NewDynamoDBClient(s.T())
+	s.repo = repository.NewDynamoDBExplorerRepository(s.client, "explore", 0, zaptest.NewLogger(s.T()))
+}
+
+func dynamoS(s string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: s}
+}
+
+func dynamoN(n int64) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(n, 10)}
+}
+
+func dynamoBool(b bool) types.AttributeValue {
+	return &types.AttributeValueMemberBOOL{Value: b}
+}
+
+// dynamoDecision returns an item of the decision of actor on recipient made at timestamp
+func dynamoDecision(id int64, actor, recipient string, liked bool, timestamp int64) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id":                dynamoN(id),
+		"actor_user_id":     dynamoS(actor),
+		"recipient_user_id": dynamoS(recipient),
+		"liked_recipient":   dynamoBool(liked),
+		"created_at":        dynamoN(time.Unix(timestamp, 0).UnixNano()),
+		"version":           dynamoN(1),
+	}
+}
+
+// expectNewDecision expects a first decision of actor1 on recipient1 to be read and given id 7
+func (s *DynamoDBRepositoryTestSuite) expectNewDecision() {
+	s.client.EXPECT().GetItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+		return in.Key["pk"].(*types.AttributeValueMemberS).Value == "user#recipient1" &&
+			in.Key["sk"].(*types.AttributeValueMemberS).Value == "decided_by#actor1"
+	})).Return(&dynamodb.GetItemOutput{}, nil).Once()
+	s.client.EXPECT().UpdateItem(mock.Anything, mock.Anything).
+		Return(&dynamodb.UpdateItemOutput{Attributes: map[string]types.AttributeValue{"value": dynamoN(7)}}, nil).Once()
+}
+
+// isQuery matches the queries whose key condition contains condition
+func isQuery(condition string) interface{} {
+	return mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return strings.Contains(aws.ToString(in.KeyConditionExpression), condition)
+	})
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestRecordDecision_CommitsOnReadVersions() {
+	s.expectNewDecision()
+	// Neither user blocked the other
+	s.client.EXPECT().BatchGetItem(mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{}, nil).Once()
+	// The recipient liked the actor first
+	back := dynamoDecision(3, "recipient1", "actor1", true, 1700000000)
+	back["pk"], back["sk"], back["version"] = dynamoS("user#actor1"), dynamoS("decided_by#recipient1"), dynamoN(2)
+	s.client.EXPECT().BatchGetItem(mock.Anything, mock.Anything).
+		Return(&dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{"explore": {back}}}, nil).Once()
+
+	// The match of both users is stored with the decision
+	s.client.EXPECT().GetItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+		return strings.HasPrefix(in.Key["sk"].(*types.AttributeValueMemberS).Value, "match#")
+	})).Return(&dynamodb.GetItemOutput{}, nil).Twice()
+	s.client.EXPECT().UpdateItem(mock.Anything, mock.Anything).
+		Return(&dynamodb.UpdateItemOutput{Attributes: map[string]types.AttributeValue{"value": dynamoN(8)}}, nil).Twice()
+
+	var committed []types.TransactWriteItem
+	s.client.EXPECT().TransactWriteItems(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			committed = in.TransactItems
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		}).Once()
+
+	recorded, err := s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor1",
+		RecipientUserID: "recipient1",
+		LikedRecipient:  true,
+	}, models.DecisionEvents{})
+
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now(), time.Unix(recorded.Timestamp, 0), time.Second)
+	s.Equal(models.RecordedDecision{Created: true, LikesDelta: 1, MutualLikes: true, Timestamp: recorded.Timestamp}, recorded)
+
+	s.Require().Len(committed, 7)
+	// The decision is only written if it is still missing
+	s.Equal("decided_by#actor1", committed[0].Put.Item["sk"].(*types.AttributeValueMemberS).Value)
+	s.Equal("attribute_not_exists(pk)", aws.ToString(committed[0].Put.ConditionExpression))
+	s.Equal(dynamoN(1), committed[0].Put.Item["version"])
+	s.Equal(dynamoS("user#actor1"), committed[1].Put.Item["actor_pk"])
+	s.Nil(committed[1].Put.ConditionExpression)
+	// The match of both users is written with it
+	s.Equal(dynamoS("match#recipient1"), committed[2].Put.Item["sk"])
+	s.Equal(dynamoS("match#actor1"), committed[3].Put.Item["sk"])
+	// Nor blocked since, and the like back is still at the version read
+	s.Equal("attribute_not_exists(pk)", aws.ToString(committed[4].ConditionCheck.ConditionExpression))
+	s.Equal("attribute_not_exists(pk)", aws.ToString(committed[5].ConditionCheck.ConditionExpression))
+	s.Equal("#version = :version", aws.ToString(committed[6].ConditionCheck.ConditionExpression))
+	s.Equal(dynamoN(2), committed[6].ConditionCheck.ExpressionAttributeValues[":version"])
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestRecordDecision_RetriesCanceledTransaction() {
+	repo := repository.NewRetryingExplorerRepository(s.repo, config.RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}, zaptest.NewLogger(s.T()))
+
+	// The first commit races a decision of the other user, and writes nothing
+	s.expectNewDecision()
+	s.client.EXPECT().TransactWriteItems(mock.Anything, mock.Anything).Return(nil, &types.TransactionCanceledException{
+		Message:             aws.String("Transaction cancelled"),
+		CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed")}, {Code: aws.String("None")}},
+	}).Once()
+	s.expectNewDecision()
+	s.client.EXPECT().TransactWriteItems(mock.Anything, mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+	// Each attempt looks for a match the pass ends
+	s.client.EXPECT().BatchGetItem(mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{}, nil).Twice()
+
+	recorded, err := repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor1",
+		RecipientUserID: "recipient1",
+	}, models.DecisionEvents{})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{Created: true, Timestamp: recorded.Timestamp}, recorded)
+}
+
+// expectPasses expects the decisions of a batch of passes to be read, given ids and found to end
+// no match
+func (s *DynamoDBRepositoryTestSuite) expectPasses() {
+	s.client.EXPECT().GetItem(mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	s.client.EXPECT().UpdateItem(mock.Anything, mock.Anything).
+		Return(&dynamodb.UpdateItemOutput{Attributes: map[string]types.AttributeValue{"value": dynamoN(7)}}, nil)
+	s.client.EXPECT().BatchGetItem(mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{}, nil)
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestCreateDecisions_CommitsBatchInOneTransaction() {
+	s.expectPasses()
+	failure := errors.New("throttled")
+	var committed []types.TransactWriteItem
+	s.client.EXPECT().TransactWriteItems(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			committed = in.TransactItems
+			return nil, failure
+		}).Once()
+
+	_, err := s.repo.CreateDecisions(s.ctx, []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1"},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2"},
+	}, make([]models.DecisionEvents, 2))
+
+	// Both decisions are written by the failed commit, so neither is stored
+	s.ErrorIs(err, failure)
+	s.Require().Len(committed, 8)
+	s.Equal("decided_by#actor1", committed[0].Put.Item["sk"].(*types.AttributeValueMemberS).Value)
+	s.Equal(dynamoS("user#recipient2"), committed[2].Put.Item["pk"])
+	// Nor have the users matched since
+	s.Equal("attribute_not_exists(pk)", aws.ToString(committed[4].ConditionCheck.ConditionExpression))
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestCreateDecisions_RejectsBatchOverItemLimit() {
+	s.expectPasses()
+	decisions := make([]explorerdb.CreateDecisionParams, 51)
+	for i := range decisions {
+		decisions[i] = explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient" + strconv.Itoa(i)}
+	}
+
+	_, err := s.repo.CreateDecisions(s.ctx, decisions, make([]models.DecisionEvents, len(decisions)))
+
+	s.ErrorIs(err, models.ErrBatchTooLarge)
+	s.client.AssertNotCalled(s.T(), "TransactWriteItems", mock.Anything, mock.Anything)
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestGetLikers_FiltersBlockedAndLooksUpLikesBack() {
+	s.client.EXPECT().Query(mock.Anything, isQuery("begins_with(sk, :block)")).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{{"other_user_id": dynamoS("blocked1")}},
+	}, nil).Once()
+	s.client.EXPECT().Query(mock.Anything, isQuery("sk BETWEEN :from AND :to")).
+		RunAndReturn(func(ctx context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			// The time range bounds the sort keys read, from the second before it that rounds into it
+			s.Equal(dynamoS("1599999999#"), in.ExpressionAttributeValues[":from"])
+			s.False(aws.ToBool(in.ScanIndexForward))
+			return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+				dynamoDecision(3, "actor1", "user123", true, 1690000000),
+				dynamoDecision(2, "actor2", "user123", false, 1680000000),
+				dynamoDecision(1, "blocked1", "user123", true, 1670000000),
+			}}, nil
+		}).Once()
+	back := dynamoDecision(4, "user123", "actor1", true, 1695000000)
+	back["pk"], back["sk"] = dynamoS("user#actor1"), dynamoS("decided_by#user123")
+	s.client.EXPECT().BatchGetItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.BatchGetItemInput) bool {
+		return len(in.RequestItems["explore"].Keys) == 1
+	})).Return(&dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{"explore": {back}}}, nil).Once()
+
+	likers, tokens, err := s.repo.GetLikers(s.ctx, "user123", models.PageRequest{Since: 1600000000})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{{ActorID: "actor1", Timestamp: 1690000000, LikedBack: true}}, likers)
+	s.Empty(tokens.Next)
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestCountLikers_LikeTTLBoundsSortKeys() {
+	repo := repository.NewDynamoDBExplorerRepository(s.client, "explore", time.Hour, zaptest.NewLogger(s.T()))
+	s.client.EXPECT().Query(mock.Anything, isQuery("begins_with(sk, :block)")).Return(&dynamodb.QueryOutput{}, nil).Once()
+	s.client.EXPECT().Query(mock.Anything, isQuery("sk BETWEEN :from AND :to")).
+		RunAndReturn(func(ctx context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			// Likes older than the like TTL are not read
+			from, err := strconv.ParseInt(strings.TrimSuffix(in.ExpressionAttributeValues[":from"].(*types.AttributeValueMemberS).Value, "#"), 10, 64)
+			s.Require().NoError(err)
+			s.InDelta(time.Now().Add(-time.Hour).Unix(), from, 5)
+			return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+				dynamoDecision(2, "actor1", "user123", true, time.Now().Unix()),
+				dynamoDecision(1, "actor2", "user123", false, time.Now().Unix()),
+			}}, nil
+		}).Once()
+
+	count, err := repo.CountLikers(s.ctx, "user123")
+
+	s.NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestGetLikedRecipients_ReadsActorIndex() {
+	cursor := &utils.Cursor{LastCreatedAt: 1700000000, Limit: 1}
+	token, err := cursor.Encode()
+	s.Require().NoError(err)
+
+	s.client.EXPECT().Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return aws.ToString(in.IndexName) == "actor" &&
+			aws.ToString(in.KeyConditionExpression) == "actor_pk = :pk AND actor_sk < :before" &&
+			in.ExpressionAttributeValues[":before"].(*types.AttributeValueMemberS).Value == "1700000000#"
+	})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+		dynamoDecision(3, "actor1", "recipient1", true, 1690000000),
+		dynamoDecision(2, "actor1", "recipient2", false, 1685000000),
+		dynamoDecision(1, "actor1", "recipient3", true, 1680000000),
+	}}, nil).Once()
+
+	recipients, nextToken, err := s.repo.GetLikedRecipients(s.ctx, "actor1", models.PageRequest{Token: token})
+
+	s.NoError(err)
+	s.Equal([]models.Recipient{{RecipientID: "recipient1", Timestamp: 1690000000}}, recipients)
+	next, err := utils.DecodeCursor(nextToken)
+	s.Require().NoError(err)
+	s.Equal(int64(1690000000), next.LastCreatedAt)
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestClaimOutboxEvents_SkipsLeasedEvents() {
+	s.client.EXPECT().Query(mock.Anything, isQuery("pk = :pk")).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+		{"id": dynamoN(1), "topic": dynamoS("likes"), "version": dynamoN(2), "claimed_until": dynamoN(time.Now().Add(time.Hour).UnixNano())},
+		{"id": dynamoN(2), "topic": dynamoS("likes"), "version": dynamoN(1)},
+	}}, nil).Once()
+	s.client.EXPECT().UpdateItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.UpdateItemInput) bool {
+		return in.Key["sk"].(*types.AttributeValueMemberS).Value == "0000000000000000002" &&
+			in.ExpressionAttributeValues[":version"].(*types.AttributeValueMemberN).Value == "1"
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	events, err := s.repo.ClaimOutboxEvents(s.ctx, explorerdb.ClaimOutboxEventsParams{MaxAttempts: 5, BatchSize: 10})
+
+	s.NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(int64(2), events[0].ID)
+}
+
+func (s *DynamoDBRepositoryTestSuite) TestAdminQueries_NeedDatabaseStorage() {
+	_, err := s.repo.GetServiceStats(s.ctx)
+	s.ErrorIs(err, errors.ErrUnsupported)
+
+	_, err = s.repo.DeleteUserDecisions(s.ctx, "user123")
+	s.ErrorIs(err, errors.ErrUnsupported)
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
-	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 
 	explorerdb "github.com/backend-interview-task/db/gen/explorer"
@@ -14,169 +16,862 @@ import (
 )
 
 type ExplorerRepository interface {
-	GetLikers(ctx context.Context, recipientUserID string, cursor string) ([]models.Liker, string, error)
-	GetNewLikers(ctx context.Context, recipientUserID string, cursor string) ([]models.Liker, string, error)
+	GetLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error)
+	CountLikers(ctx context.Context, recipientUserID string) (int64, error)
+	GetNewLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error)
+	GetPassers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error)
+	GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error)
+	RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error)
+	RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error)
+	CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error)
+	GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error)
+	IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error)
+	ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error)
+	DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error)
 	explorerdb.Querier
 }
 
 type explorerStore struct {
 	db database.DBProvider
 	*explorerdb.Queries
+	likerListings
 	logger *zap.Logger
 }
 
-func NewExplorerRepository(db database.DBProvider, logger *zap.Logger) ExplorerRepository {
+func NewExplorerRepository(db database.DBProvider, likeTTL time.Duration, logger *zap.Logger) ExplorerRepository {
+	queries := explorerdb.New(db)
 	return &explorerStore{
-		db:      db,
-		logger:  logger,
-		Queries: explorerdb.New(db),
+		db:            db,
+		logger:        logger,
+		Queries:       queries,
+		likerListings: likerListings{q: queries, likeTTL: likeTTL, logger: logger},
 	}
 }
 
+// likerListings pages through the likers, passers and new likers of a recipient with the
+// listing queries of q, whichever store runs them
+type likerListings struct {
+	q explorerdb.Querier
+	// likeTTL hides likes older than it from likers listings and counts; zero keeps likes forever
+	likeTTL time.Duration
+	logger  *zap.Logger
+}
+
+// optionalBound returns the time range bound, or nil when it is unset
+func optionalBound(bound int64) *int64 {
+	if bound <= 0 {
+		return nil
+	}
+	return &bound
+}
+
+// maxLikeAge returns the like TTL in seconds, or nil when likes are kept forever
+func (r *likerListings) maxLikeAge() *int64 {
+	if r.likeTTL <= 0 {
+		return nil
+	}
+	seconds := int64(r.likeTTL.Seconds())
+	return &seconds
+}
+
 // GetLikers returns users who liked the recipient with pagination
-func (r *explorerStore) GetLikers(ctx context.Context, recipientUserID string, paginationToken string) ([]models.Liker, string, error) {
-	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+func (r *likerListings) GetLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	likers, tokens, err := r.getDeciders(ctx, recipientUserID, true, nil, page)
+	if err != nil {
+		r.logger.Error("Failed to get likers",
+			zap.String("recipient_user_id", recipientUserID),
+			zap.Error(err))
+		return nil, models.PageTokens{}, fmt.Errorf("failed to get likers: %w", err)
+	}
+	return likers, tokens, nil
+}
 
-	queryBuilder := psql.Select("actor_user_id, EXTRACT(EPOCH FROM created_at)::bigint as timestamp").
-		From("decisions").
-		Where(squirrel.Eq{"recipient_user_id": recipientUserID}).
-		Where(squirrel.Eq{"liked_recipient": true})
+// GetPassers returns users who passed on the recipient with pagination
+func (r *likerListings) GetPassers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	passers, tokens, err := r.getDeciders(ctx, recipientUserID, false, nil, page)
+	if err != nil {
+		r.logger.Error("Failed to get passers",
+			zap.String("recipient_user_id", recipientUserID),
+			zap.Error(err))
+		return nil, models.PageTokens{}, fmt.Errorf("failed to get passers: %w", err)
+	}
+	return passers, tokens, nil
+}
+
+// getDeciders returns users whose decision on the recipient matches liked, with pagination.
+// A non-nil likedBack also restricts them to those the recipient did or did not like in return.
+func (r *likerListings) getDeciders(ctx context.Context, recipientUserID string, liked bool, likedBack *bool, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	keyset, err := newKeyset(page)
+	if err != nil {
+		return nil, models.PageTokens{}, err
+	}
+	if liked && likedBack == nil && keyset.cursor.ByPopularity {
+		return r.getLikersByPopularity(ctx, recipientUserID, page, keyset)
+	}
 
-	cursor, err := utils.DecodeCursor(paginationToken)
+	params := explorerdb.ListDecidersDescParams{
+		RecipientUserID: recipientUserID,
+		LikedRecipient:  liked,
+		Since:           optionalBound(page.Since),
+		Until:           optionalBound(page.Until),
+		LikedBack:       likedBack,
+		PageLimit:       keyset.limit(),
+		PageOffset:      keyset.offset(),
+	}
+	if liked {
+		params.MaxAgeSeconds = r.maxLikeAge()
+	}
+	params.AfterCreatedAt, params.AfterID = keyset.after()
+
+	var rows []explorerdb.ListDecidersDescRow
+	if keyset.ascending() {
+		var ascending []explorerdb.ListDecidersAscRow
+		ascending, err = r.q.ListDecidersAsc(ctx, explorerdb.ListDecidersAscParams(params))
+		rows = convertDeciders[explorerdb.ListDecidersDescRow](ascending)
+	} else {
+		rows, err = r.q.ListDecidersDesc(ctx, params)
+	}
+	if err != nil {
+		return nil, models.PageTokens{}, err
+	}
+	rows, tokens, err := paginate(keyset, rows, func(row explorerdb.ListDecidersDescRow) (time.Time, int64) {
+		return row.CreatedAt.Time, row.ID
+	})
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid paginationToken: %w", err)
+		return nil, models.PageTokens{}, err
 	}
 
-	if cursor == nil || cursor.Limit <= 0 {
-		cursor = &utils.Cursor{
-			// default limit
-			Limit: 20,
+	var likers []models.Liker
+	for _, row := range rows {
+		likers = append(likers, models.Liker{
+			ActorID:   row.ActorUserID,
+			Timestamp: row.Timestamp,
+			LikedBack: row.LikedBack,
+		})
+	}
+	return likers, tokens, nil
+}
+
+// getLikersByPopularity returns users who liked the recipient ranked by their popularity score,
+// highest first, and then by the most recent like. The ranking is only paged forward, so pages
+// carry no previous page token.
+func (r *likerListings) getLikersByPopularity(ctx context.Context, recipientUserID string, page models.PageRequest, keyset *keyset) ([]models.Liker, models.PageTokens, error) {
+	params := explorerdb.ListLikersByPopularityParams{
+		RecipientUserID: recipientUserID,
+		MaxAgeSeconds:   r.maxLikeAge(),
+		Since:           optionalBound(page.Since),
+		Until:           optionalBound(page.Until),
+		PageLimit:       keyset.limit(),
+		PageOffset:      keyset.offset(),
+	}
+	if keyset.continued {
+		lastScore := keyset.cursor.LastScore
+		params.AfterScore, params.AfterID = &lastScore, keyset.cursor.LastID
+	}
+
+	rows, err := r.q.ListLikersByPopularity(ctx, params)
+	if err != nil {
+		return nil, models.PageTokens{}, err
+	}
+
+	var tokens models.PageTokens
+	if len(rows) > keyset.cursor.Limit {
+		rows = rows[:keyset.cursor.Limit] // Remove the extra item
+		if keyset.page == 0 {
+			last := rows[len(rows)-1]
+			next := &utils.Cursor{LastScore: last.Score, LastID: last.ID, Limit: keyset.cursor.Limit, ByPopularity: true}
+			if tokens.Next, err = next.Encode(); err != nil {
+				return nil, models.PageTokens{}, fmt.Errorf("failed to encode next paginationToken: %w", err)
+			}
 		}
 	}
 
-	if paginationToken != "" {
-		queryBuilder = queryBuilder.Where(squirrel.Lt{"EXTRACT(EPOCH FROM created_at)::bigint": cursor.LastCreatedAt})
+	var likers []models.Liker
+	for _, row := range rows {
+		likers = append(likers, models.Liker{
+			ActorID:   row.ActorUserID,
+			Timestamp: row.Timestamp,
+			LikedBack: row.LikedBack,
+		})
+	}
+	return likers, tokens, nil
+}
+
+// CountLikers returns the number of likes the recipient received, read from the like_counts table.
+// The counter holds expired likes until the purge job deletes them, so with a like TTL the
+// unexpired likes are counted from decisions instead, as the likers listings read them.
+func (r *explorerStore) CountLikers(ctx context.Context, recipientUserID string) (int64, error) {
+	return countLikers(ctx, r.Queries, r.maxLikeAge(), recipientUserID)
+}
+
+// countLikers counts the recipient's likes with the queries of q, from the counter table unless
+// maxAgeSeconds hides the older likes
+func countLikers(ctx context.Context, q explorerdb.Querier, maxAgeSeconds *int64, recipientUserID string) (int64, error) {
+	if maxAgeSeconds == nil {
+		return q.CountLikes(ctx, recipientUserID)
+	}
+	return q.CountRecentLikes(ctx, explorerdb.CountRecentLikesParams{
+		RecipientUserID: recipientUserID,
+		MaxAgeSeconds:   *maxAgeSeconds,
+	})
+}
+
+// GetNewLikers returns users who liked the recipient but haven't been decided on back, read from
+// the new_likes table. With page.IncludePassed, the likers the recipient passed on are listed too,
+// read from decisions as the likers the recipient did not like back.
+func (r *likerListings) GetNewLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	if page.IncludePassed {
+		likedBack := false
+		likers, tokens, err := r.getDeciders(ctx, recipientUserID, true, &likedBack, page)
+		if err != nil {
+			r.logger.Error("Failed to get new likers",
+				zap.String("recipient_user_id", recipientUserID),
+				zap.Error(err))
+			return nil, models.PageTokens{}, fmt.Errorf("failed to get new likers: %w", err)
+		}
+		return likers, tokens, nil
+	}
+
+	keyset, err := newKeyset(page)
+	if err != nil {
+		return nil, models.PageTokens{}, err
+	}
+
+	params := explorerdb.ListNewLikersDescParams{
+		RecipientUserID: recipientUserID,
+		MaxAgeSeconds:   r.maxLikeAge(),
+		Since:           optionalBound(page.Since),
+		Until:           optionalBound(page.Until),
+		PageLimit:       keyset.limit(),
+		PageOffset:      keyset.offset(),
+	}
+	params.AfterCreatedAt, params.AfterID = keyset.after()
+
+	var rows []explorerdb.ListNewLikersDescRow
+	if keyset.ascending() {
+		var ascending []explorerdb.ListNewLikersAscRow
+		ascending, err = r.q.ListNewLikersAsc(ctx, explorerdb.ListNewLikersAscParams(params))
+		rows = convertNewLikers[explorerdb.ListNewLikersDescRow](ascending)
+	} else {
+		rows, err = r.q.ListNewLikersDesc(ctx, params)
+	}
+	if err != nil {
+		r.logger.Error("Failed to get new likers",
+			zap.String("recipient_user_id", recipientUserID),
+			zap.Error(err))
+		return nil, models.PageTokens{}, fmt.Errorf("failed to get new likers: %w", err)
+	}
+	rows, tokens, err := paginate(keyset, rows, func(row explorerdb.ListNewLikersDescRow) (time.Time, int64) {
+		return row.CreatedAt.Time, row.ID
+	})
+	if err != nil {
+		return nil, models.PageTokens{}, err
+	}
+
+	var likers []models.Liker
+	for _, row := range rows {
+		likers = append(likers, models.Liker{
+			ActorID:   row.ActorUserID,
+			Timestamp: row.Timestamp,
+		})
 	}
+	return likers, tokens, nil
+}
 
-	queryBuilder = queryBuilder.
-		OrderBy("created_at DESC").
-		Limit(uint64(cursor.Limit + 1))
+// GetLikedRecipients returns users the actor has liked with pagination
+func (r *explorerStore) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
 
-	query, args, err := queryBuilder.ToSql()
+	query, args, err := postgresDialect.likedRecipientsQuery(actorUserID, page, cursor)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to build query: %w", err)
 	}
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		r.logger.Error("Failed to get likers",
-			zap.String("recipient_user_id", recipientUserID),
+		r.logger.Error("Failed to get liked recipients",
+			zap.String("actor_user_id", actorUserID),
 			zap.Error(err))
-		return nil, "", fmt.Errorf("failed to get likers: %w", err)
+		return nil, "", fmt.Errorf("failed to get liked recipients: %w", err)
 	}
 	defer rows.Close()
 
-	var likers []models.Liker
-	for rows.Next() {
-		var liker models.Liker
-		if err := rows.Scan(&liker.ActorID, &liker.Timestamp); err != nil {
-			return nil, "", fmt.Errorf("failed to scan liker: %w", err)
+	return scanRecipients(rows, cursor)
+}
+
+// RecordDecision stores the decision and reports whether it resulted in a mutual like and
+// how it moved the recipient's like count. The match of a mutual like is created, and the match
+// a pass ends is deleted, in the same transaction. So are the events the like emits written to
+// the outbox, so they are published if and only if the decision is stored.
+// Only a change of mind emits events: repeating a like or a pass stores it again silently.
+// Decisions on the same pair of users, in either direction, are serialized, so of two
+// concurrent opposite likes the later one always sees the earlier and reports the mutual like.
+func (r *explorerStore) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.RecordedDecision{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.Queries.WithTx(tx)
+
+	if err := lockDecisionPairs(ctx, q, []explorerdb.CreateDecisionParams{decision}); err != nil {
+		return models.RecordedDecision{}, err
+	}
+
+	recorded, err := recordDecision(ctx, q, decision, events)
+	if err != nil {
+		return models.RecordedDecision{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.RecordedDecision{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return recorded, nil
+}
+
+// recordDecision stores the decision with q as RecordDecision does, once the decisions on the
+// pair of users are serialized
+func recordDecision(ctx context.Context, q explorerdb.Querier, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	var recorded models.RecordedDecision
+
+	created, err := q.CreateDecision(ctx, decision)
+	if err != nil {
+		return models.RecordedDecision{}, fmt.Errorf("failed to create decision: %w", err)
+	}
+	recorded.Created = created.Inserted
+	recorded.LikedBefore = created.PreviousLikedRecipient
+	recorded.Timestamp = listingSecond(created.CreatedAt.Time)
+
+	if recorded.LikedBefore != decision.LikedRecipient {
+		// Likes between blocked users are left out of the count, as in the like_counts triggers
+		blocked, err := q.IsBlocked(ctx, explorerdb.IsBlockedParams{
+			BlockerUserID: decision.RecipientUserID,
+			BlockedUserID: decision.ActorUserID,
+		})
+		if err != nil {
+			return models.RecordedDecision{}, fmt.Errorf("failed to check block: %w", err)
+		}
+		if !blocked {
+			recorded.LikesDelta = 1
+			if recorded.LikedBefore {
+				recorded.LikesDelta = -1
+			}
 		}
-		likers = append(likers, liker)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("error iterating over results: %w", err)
+	if decision.LikedRecipient {
+		hasMutualLike, err := q.HasMutualLike(ctx, explorerdb.HasMutualLikeParams{
+			ActorUserID:     decision.ActorUserID,
+			RecipientUserID: decision.RecipientUserID,
+		})
+		if err != nil {
+			return models.RecordedDecision{}, fmt.Errorf("failed to check mutual like: %w", err)
+		}
+		recorded.MutualLikes = hasMutualLike != nil && *hasMutualLike
 	}
 
-	var nextPaginationToken string
-	if len(likers) > cursor.Limit {
-		nextCursor := &utils.Cursor{
-			LastCreatedAt: likers[cursor.Limit-1].Timestamp,
-			Limit:         cursor.Limit,
+	matchParams := explorerdb.CreateMatchParams{
+		UserID:        decision.ActorUserID,
+		MatchedUserID: decision.RecipientUserID,
+	}
+	if recorded.MutualLikes {
+		if err := q.CreateMatch(ctx, matchParams); err != nil {
+			return models.RecordedDecision{}, fmt.Errorf("failed to create match: %w", err)
 		}
-		nextPaginationToken, err = nextCursor.Encode()
+	} else if !decision.LikedRecipient {
+		// A pass ends any match the two users had
+		deleted, err := q.DeleteMatch(ctx, explorerdb.DeleteMatchParams(matchParams))
+		if err != nil {
+			return models.RecordedDecision{}, fmt.Errorf("failed to delete match: %w", err)
+		}
+		recorded.MatchEnded = deleted > 0
+	}
+
+	if err := writeDecisionEvents(ctx, q, events, decision.LikedRecipient, recorded.LikedBefore, recorded.MutualLikes); err != nil {
+		return models.RecordedDecision{}, fmt.Errorf("failed to create outbox event: %w", err)
+	}
+
+	return recorded, nil
+}
+
+// RemoveDecision withdraws the decision and, when it was half of a mutual like, deletes the
+// match of the two users in the same transaction. Like RecordDecision, it is serialized with
+// the other decisions on the pair of users, so a concurrent like cannot leave a stale match
+// behind or see its match deleted.
+func (r *explorerStore) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.RemovedDecision{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.Queries.WithTx(tx)
+
+	if err := lockDecisionPairs(ctx, q, []explorerdb.CreateDecisionParams{pairOf(decision)}); err != nil {
+		return models.RemovedDecision{}, err
+	}
+
+	removed, err := removeDecision(ctx, q, decision)
+	if err != nil {
+		return models.RemovedDecision{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.RemovedDecision{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return removed, nil
+}
+
+// removeDecision withdraws the decision with q as RemoveDecision does, once the decisions on the
+// pair of users are serialized
+func removeDecision(ctx context.Context, q explorerdb.Querier, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	hasMutualLike, err := q.HasMutualLike(ctx, explorerdb.HasMutualLikeParams{
+		ActorUserID:     decision.ActorUserID,
+		RecipientUserID: decision.RecipientUserID,
+	})
+	if err != nil {
+		return models.RemovedDecision{}, fmt.Errorf("failed to check mutual like: %w", err)
+	}
+
+	deleted, err := q.DeleteDecision(ctx, decision)
+	if err != nil {
+		return models.RemovedDecision{}, fmt.Errorf("failed to delete decision: %w", err)
+	}
+	if deleted == 0 {
+		return models.RemovedDecision{}, nil
+	}
+
+	removed := models.RemovedDecision{
+		Deleted:       true,
+		WasMutualLike: hasMutualLike != nil && *hasMutualLike,
+	}
+	if removed.WasMutualLike {
+		ended, err := q.DeleteMatch(ctx, explorerdb.DeleteMatchParams{
+			UserID:        decision.ActorUserID,
+			MatchedUserID: decision.RecipientUserID,
+		})
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+			return models.RemovedDecision{}, fmt.Errorf("failed to delete match: %w", err)
 		}
-		likers = likers[:cursor.Limit] // Remove the extra item
+		removed.MatchEnded = ended > 0
 	}
 
-	return likers, nextPaginationToken, nil
+	return removed, nil
+}
+
+// pairOf returns the decision whose pair of users lockDecisionPairs locks to serialize withdrawing it
+func pairOf(decision explorerdb.DeleteDecisionParams) explorerdb.CreateDecisionParams {
+	return explorerdb.CreateDecisionParams{ActorUserID: decision.ActorUserID, RecipientUserID: decision.RecipientUserID}
 }
 
-// GetNewLikers returns users who liked the recipient but haven't been liked back
-func (r *explorerStore) GetNewLikers(ctx context.Context, recipientUserID string, paginationToken string) ([]models.Liker, string, error) {
-	args := []interface{}{recipientUserID}
+// lockDecisionPairs takes the transaction's locks on the pairs of users the decisions are
+// between. Without them, two opposite likes committing concurrently each miss the other's
+// uncommitted row and neither reports the mutual like. Locks are taken in a fixed order so
+// that concurrent batches cannot deadlock.
+func lockDecisionPairs(ctx context.Context, q explorerdb.Querier, decisions []explorerdb.CreateDecisionParams) error {
+	pairs := make([]explorerdb.LockDecisionPairParams, 0, len(decisions))
+	seen := make(map[explorerdb.LockDecisionPairParams]bool, len(decisions))
+	for _, decision := range decisions {
+		pair := explorerdb.LockDecisionPairParams{UserID: decision.ActorUserID, OtherUserID: decision.RecipientUserID}
+		if pair.OtherUserID < pair.UserID {
+			pair.UserID, pair.OtherUserID = pair.OtherUserID, pair.UserID
+		}
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].UserID != pairs[j].UserID {
+			return pairs[i].UserID < pairs[j].UserID
+		}
+		return pairs[i].OtherUserID < pairs[j].OtherUserID
+	})
+
+	for _, pair := range pairs {
+		if err := q.LockDecisionPair(ctx, pair); err != nil {
+			return fmt.Errorf("failed to lock decision pair: %w", err)
+		}
+	}
+	return nil
+}
 
-	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+// writeDecisionEvents writes the events a decision emits to the outbox. A like that is new emits
+// either its mutual like or its new like events, and a pass emits only when it withdraws a like.
+func writeDecisionEvents(ctx context.Context, q explorerdb.Querier, events models.DecisionEvents, liked, likedBefore, mutualLike bool) error {
+	var emitted []models.OutboxEvent
+	switch {
+	case liked == likedBefore:
+	case !liked:
+		emitted = events.Withdrawn
+	case mutualLike:
+		emitted = events.Matched
+	case events.NewLike != nil:
+		emitted = []models.OutboxEvent{*events.NewLike}
+	}
 
-	queryBuilder := psql.Select("d1.actor_user_id, EXTRACT(EPOCH FROM d1.created_at)::bigint as timestamp").
-		From("decisions d1").
-		LeftJoin("decisions d2 ON d1.actor_user_id = d2.recipient_user_id").
-		Where(squirrel.Eq{"d1.recipient_user_id": recipientUserID}).
-		Where(squirrel.Eq{"d1.liked_recipient": true}).
-		Where(squirrel.Eq{"d2.id": nil})
+	for _, event := range emitted {
+		if err := q.CreateOutboxEvent(ctx, explorerdb.CreateOutboxEventParams(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	cursor, err := utils.DecodeCursor(paginationToken)
+// CreateDecisions stores all decisions in a single transaction and reports, per decision,
+// whether it resulted in a mutual like. Either every decision is stored or none is, and the
+// decision that failed the batch is identified by the *models.DecisionError in the returned error.
+// The events emitted by likes, events[i] for decision i, are written to the outbox in the same transaction.
+// As in RecordDecision, decisions on the same pair of users are serialized.
+func (r *explorerStore) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error) {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid paginationToken: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.Queries.WithTx(tx)
 
-	if cursor == nil || cursor.Limit <= 0 {
-		cursor = &utils.Cursor{
-			Limit: 20,
+	if err := lockDecisionPairs(ctx, q, decisions); err != nil {
+		return nil, err
+	}
+
+	results, err := createDecisions(ctx, q, decisions, events, r.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// createDecisions stores the decisions with q as CreateDecisions does, once the decisions on
+// their pairs of users are serialized
+func createDecisions(ctx context.Context, q explorerdb.Querier, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents, logger *zap.Logger) ([]models.DecisionResult, error) {
+	results := make([]models.DecisionResult, len(decisions))
+	for i, decision := range decisions {
+		created, err := q.CreateDecision(ctx, decision)
+		if err != nil {
+			logger.Error("Failed to create decision in batch",
+				zap.Int("index", i),
+				zap.String("actor_user_id", decision.ActorUserID),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to create decision %d: %w", i, &models.DecisionError{Index: i, Err: err})
+		}
+
+		results[i] = models.DecisionResult{
+			ActorUserID:     decision.ActorUserID,
+			RecipientUserID: decision.RecipientUserID,
+		}
+
+		matchParams := explorerdb.CreateMatchParams{
+			UserID:        decision.ActorUserID,
+			MatchedUserID: decision.RecipientUserID,
+		}
+
+		if !decision.LikedRecipient {
+			if _, err := q.DeleteMatch(ctx, explorerdb.DeleteMatchParams(matchParams)); err != nil {
+				return nil, fmt.Errorf("failed to delete match %d: %w", i, &models.DecisionError{Index: i, Err: err})
+			}
+		} else {
+			hasMutualLike, err := q.HasMutualLike(ctx, explorerdb.HasMutualLikeParams{
+				ActorUserID:     decision.ActorUserID,
+				RecipientUserID: decision.RecipientUserID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to check mutual like %d: %w", i, &models.DecisionError{Index: i, Err: err})
+			}
+			results[i].MutualLikes = hasMutualLike != nil && *hasMutualLike
+
+			if results[i].MutualLikes {
+				if err := q.CreateMatch(ctx, matchParams); err != nil {
+					return nil, fmt.Errorf("failed to create match %d: %w", i, &models.DecisionError{Index: i, Err: err})
+				}
+			}
+		}
+		if err := writeDecisionEvents(ctx, q, events[i], decision.LikedRecipient, created.PreviousLikedRecipient, results[i].MutualLikes); err != nil {
+			return nil, fmt.Errorf("failed to create outbox event %d: %w", i, &models.DecisionError{Index: i, Err: err})
 		}
 	}
 
-	if paginationToken != "" {
-		queryBuilder = queryBuilder.Where(squirrel.Lt{"EXTRACT(EPOCH FROM d1.created_at)::bigint": cursor.LastCreatedAt})
+	return results, nil
+}
+
+// GetMatches returns users the user has a mutual like with, most recent match first
+func (r *explorerStore) GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
 	}
 
-	queryBuilder = queryBuilder.
-		OrderBy("d1.created_at DESC").
-		Limit(uint64(cursor.Limit))
-	query, args, err := queryBuilder.ToSql()
+	query, args, err := postgresDialect.matchesQuery(userID, page, cursor)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to build query: %w", err)
 	}
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		r.logger.Error("Failed to get new likers",
-			zap.String("recipient_user_id", recipientUserID),
+		r.logger.Error("Failed to get matches",
+			zap.String("user_id", userID),
 			zap.Error(err))
-		return nil, "", fmt.Errorf("failed to get new likers: %w", err)
+		return nil, "", fmt.Errorf("failed to get matches: %w", err)
 	}
 	defer rows.Close()
 
-	var likers []models.Liker
-	for rows.Next() {
-		var liker models.Liker
-		if err := rows.Scan(&liker.ActorID, &liker.Timestamp); err != nil {
-			return nil, "", fmt.Errorf("failed to scan liker: %w", err)
-		}
-		likers = append(likers, liker)
+	return scanMatches(rows, cursor)
+}
+
+// IngestDecisions upserts the decisions in a single pgx batch inside one transaction and keeps
+// matches in sync with the outcome. It is meant for bulk backfills rather than interactive writes.
+// As in CreateDecisions, a failing decision fails the batch and is identified by a *models.DecisionError.
+func (r *explorerStore) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.IngestSummary{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("error iterating over results: %w", err)
+	q := r.Queries.WithTx(tx)
+
+	summary, err := ingestDecisions(ctx, q, decisions, r.logger)
+	if err != nil {
+		return models.IngestSummary{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.IngestSummary{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return summary, nil
+}
+
+// ingestDecisions upserts the decisions with q as IngestDecisions does
+func ingestDecisions(ctx context.Context, q explorerdb.Querier, decisions []explorerdb.UpsertDecisionsParams, logger *zap.Logger) (models.IngestSummary, error) {
+	var summary models.IngestSummary
+
+	rows := make([]explorerdb.UpsertDecisionsRow, len(decisions))
+	var batchErr error
+	q.UpsertDecisions(ctx, decisions).QueryRow(func(i int, row explorerdb.UpsertDecisionsRow, err error) {
+		if err != nil && batchErr == nil {
+			batchErr = fmt.Errorf("failed to upsert decision %d: %w", i, &models.DecisionError{Index: i, Err: err})
+		}
+		rows[i] = row
+	})
+	if batchErr != nil {
+		logger.Error("Failed to ingest decisions", zap.Error(batchErr))
+		return models.IngestSummary{}, batchErr
 	}
 
-	var nextPaginationToken string
-	if len(likers) > cursor.Limit {
-		nextCursor := &utils.Cursor{
-			LastCreatedAt: likers[cursor.Limit-1].Timestamp,
-			Limit:         cursor.Limit,
+	for i, row := range rows {
+		if row.Inserted {
+			summary.Created++
+		} else {
+			summary.Updated++
 		}
 
-		nextPaginationToken, err = nextCursor.Encode()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		matchParams := explorerdb.CreateMatchParams{
+			UserID:        decisions[i].ActorUserID,
+			MatchedUserID: decisions[i].RecipientUserID,
 		}
 
-		likers = likers[:cursor.Limit]
+		switch {
+		case row.MutualLike:
+			summary.MutualLikes++
+			if err := q.CreateMatch(ctx, matchParams); err != nil {
+				return models.IngestSummary{}, fmt.Errorf("failed to create match %d: %w", i, &models.DecisionError{Index: i, Err: err})
+			}
+		case !decisions[i].LikedRecipient && !row.Inserted:
+			// Only a pass replacing an earlier like can end a match
+			if _, err := q.DeleteMatch(ctx, explorerdb.DeleteMatchParams(matchParams)); err != nil {
+				return models.IngestSummary{}, fmt.Errorf("failed to delete match %d: %w", i, &models.DecisionError{Index: i, Err: err})
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// decisionImportColumns are the columns copied into the decision_imports staging table
+var decisionImportColumns = []string{"actor_user_id", "recipient_user_id", "liked_recipient", "created_at"}
+
+const (
+	createDecisionImportsSQL = `CREATE TEMP TABLE decision_imports (
+    actor_user_id VARCHAR(255) NOT NULL,
+    recipient_user_id VARCHAR(255) NOT NULL,
+    liked_recipient BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+) ON COMMIT DROP`
+
+	// The latest decision of each pair wins, and only replaces a stored one made before it, so
+	// importing the same file twice changes nothing
+	upsertDecisionImportsSQL = `WITH upserted AS (
+    INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+    SELECT DISTINCT ON (actor_user_id, recipient_user_id) actor_user_id, recipient_user_id, liked_recipient, created_at, NOW()
+    FROM decision_imports
+    ORDER BY actor_user_id, recipient_user_id, created_at DESC
+    ON CONFLICT (actor_user_id, recipient_user_id)
+        DO UPDATE SET
+                      liked_recipient = EXCLUDED.liked_recipient,
+                      created_at = EXCLUDED.created_at,
+                      updated_at = NOW()
+        WHERE decisions.created_at < EXCLUDED.created_at
+    RETURNING (xmax = 0) AS inserted
+)
+SELECT COUNT(*) FILTER (WHERE inserted), COUNT(*) FILTER (WHERE NOT inserted) FROM upserted`
+
+	// A pass that won over a stored like ends the match it was part of
+	deleteImportedPassMatchesSQL = `DELETE FROM matches m
+USING (SELECT DISTINCT actor_user_id, recipient_user_id FROM decision_imports WHERE NOT liked_recipient) i
+WHERE ((m.user_id = i.actor_user_id AND m.matched_user_id = i.recipient_user_id)
+    OR (m.user_id = i.recipient_user_id AND m.matched_user_id = i.actor_user_id))
+  AND NOT EXISTS(
+    SELECT 1 FROM decisions d
+    WHERE d.actor_user_id = i.actor_user_id AND d.recipient_user_id = i.recipient_user_id AND d.liked_recipient = true
+)`
+
+	// Matches are stored once per participant and dated by the like completing them
+	createImportedMatchesSQL = `INSERT INTO matches (user_id, matched_user_id, matched_at)
+SELECT p.user_id, p.matched_user_id, GREATEST(a.created_at, b.created_at)
+FROM (SELECT DISTINCT actor_user_id, recipient_user_id FROM decision_imports) i
+JOIN decisions a ON a.actor_user_id = i.actor_user_id AND a.recipient_user_id = i.recipient_user_id AND a.liked_recipient = true
+JOIN decisions b ON b.actor_user_id = i.recipient_user_id AND b.recipient_user_id = i.actor_user_id AND b.liked_recipient = true
+CROSS JOIN LATERAL (VALUES (a.actor_user_id, a.recipient_user_id), (a.recipient_user_id, a.actor_user_id)) AS p(user_id, matched_user_id)
+ON CONFLICT (user_id, matched_user_id) DO NOTHING`
+)
+
+// ImportDecisions bulk-loads decisions carried over from another system, keeping the time they
+// were made. The rows are copied into a staging table with COPY and merged into decisions with a
+// few set-based statements inside one transaction, so the like count and new likes triggers stay
+// in step while no outbox events are written: historical decisions raise no webhooks or pushes.
+func (r *explorerStore) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error) {
+	summary := models.ImportSummary{Rows: len(decisions)}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ImportSummary{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, createDecisionImportsSQL); err != nil {
+		return models.ImportSummary{}, fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{"decision_imports"}, decisionImportColumns,
+		pgx.CopyFromSlice(len(decisions), func(i int) ([]any, error) {
+			d := decisions[i]
+			return []any{d.ActorUserID, d.RecipientUserID, d.LikedRecipient, d.CreatedAt}, nil
+		}))
+	if err != nil {
+		r.logger.Error("Failed to copy decisions", zap.Int("rows", len(decisions)), zap.Error(err))
+		return models.ImportSummary{}, fmt.Errorf("failed to copy decisions: %w", err)
+	}
+
+	var created, updated int64
+	if err := tx.QueryRow(ctx, upsertDecisionImportsSQL).Scan(&created, &updated); err != nil {
+		return models.ImportSummary{}, fmt.Errorf("failed to merge decisions: %w", err)
+	}
+	summary.Created = int(created)
+	summary.Updated = int(updated)
+	summary.Skipped = int(copied - created - updated)
+
+	if _, err := tx.Exec(ctx, deleteImportedPassMatchesSQL); err != nil {
+		return models.ImportSummary{}, fmt.Errorf("failed to delete matches: %w", err)
+	}
+	tag, err := tx.Exec(ctx, createImportedMatchesSQL)
+	if err != nil {
+		return models.ImportSummary{}, fmt.Errorf("failed to create matches: %w", err)
+	}
+	summary.Matches = int(tag.RowsAffected() / 2)
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ImportSummary{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return summary, nil
+}
+
+// DrainOutbox claims the outbox events due for delivery and hands each to deliver.
+// Delivered events are deleted and failed ones rescheduled with backoff, or moved to the
+// dead letters once their final attempt fails, in the same transaction that holds the claim,
+// so concurrent drains never deliver the same event.
+func (r *explorerStore) DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.DrainSummary{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	summary, err := drainOutbox(ctx, r.Queries.WithTx(tx), claim, deliver, r.logger)
+	if err != nil {
+		return models.DrainSummary{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.DrainSummary{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return summary, nil
+}
+
+// drainOutbox delivers the outbox events claimed with q and settles them as DrainOutbox does,
+// in the transaction of q
+func drainOutbox(ctx context.Context, q explorerdb.Querier, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error, logger *zap.Logger) (models.DrainSummary, error) {
+	var summary models.DrainSummary
+
+	events, err := q.ClaimOutboxEvents(ctx, claim)
+	if err != nil {
+		return models.DrainSummary{}, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	for _, event := range events {
+		if deliverErr := deliver(ctx, event); deliverErr != nil {
+			logger.Warn("Failed to deliver outbox event",
+				zap.Int64("id", event.ID),
+				zap.String("topic", event.Topic),
+				zap.Int32("attempts", event.Attempts+1),
+				zap.Error(deliverErr))
+			if event.Attempts+1 >= claim.MaxAttempts {
+				if err := q.DeadLetterOutboxEvent(ctx, explorerdb.DeadLetterOutboxEventParams{
+					ID:        event.ID,
+					LastError: deliverErr.Error(),
+				}); err != nil {
+					return models.DrainSummary{}, fmt.Errorf("failed to dead-letter outbox event %d: %w", event.ID, err)
+				}
+				summary.DeadLettered++
+				continue
+			}
+
+			if err := q.RetryOutboxEvent(ctx, explorerdb.RetryOutboxEventParams{
+				LastError: deliverErr.Error(),
+				ID:        event.ID,
+			}); err != nil {
+				return models.DrainSummary{}, fmt.Errorf("failed to reschedule outbox event %d: %w", event.ID, err)
+			}
+			summary.Retried++
+			continue
+		}
+
+		if err := q.DeleteOutboxEvent(ctx, event.ID); err != nil {
+			return models.DrainSummary{}, fmt.Errorf("failed to delete outbox event %d: %w", event.ID, err)
+		}
+		summary.Delivered++
 	}
 
-	return likers, nextPaginationToken, nil
+	return summary, nil
 }
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pashagolub/pgxmock/v3"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap/zaptest"
 
 	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
 	"github.com/backend-interview-task/internal/repository"
 	"github.com/backend-interview-task/utils"
 )
@@ -33,34 +37,58 @@ func (s *ExplorerRepositoryTestSuite) SetupTest() {
 	s.Require().NoError(err)
 
 	logger := zaptest.NewLogger(s.T())
-	s.repo = repository.NewExplorerRepository(s.mock, logger)
+	s.repo = repository.NewExplorerRepository(s.mock, 0, logger)
 }
 
 func (s *ExplorerRepositoryTestSuite) TearDownTest() {
 	s.mock.Close()
 }
 
+// bound returns v as an optional query argument
+func bound(v int64) *int64 {
+	return &v
+}
+
+// unbounded is an optional query argument left unset
+var unbounded *int64
+
+// listedAt returns the creation time of a row listed at the given second
+func listedAt(second int64) time.Time {
+	return time.Unix(second, 0)
+}
+
+// timestamptz returns t as a timestamptz argument or column
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+// pastSecond returns the time a token without the exact time seeks from, past all of its second
+func pastSecond(second int64, ascending bool) pgtype.Timestamptz {
+	if ascending {
+		return timestamptz(listedAt(second).Add(time.Second / 2))
+	}
+	return timestamptz(listedAt(second).Add(-time.Second / 2))
+}
+
 func (s *ExplorerRepositoryTestSuite) TestGetLikers_Success_NoPagination() {
 	recipientUserID := "user123"
 	paginationToken := ""
 
-	// Empty token means default cursor with limit 10
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
+	// Empty token means default cursor with limit 20
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(1234), false, int64(1), listedAt(1234))
 
-	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp"}).
-		AddRow("actor1", int64(1234))
-
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true).
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
 		WillReturnRows(rows)
 
-	likers, nextToken, err := s.repo.GetLikers(s.ctx, recipientUserID, paginationToken)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
 	s.Len(likers, 1)
 	s.Equal("actor1", likers[0].ActorID)
 	s.Equal(int64(1234), likers[0].Timestamp)
-	s.Empty(nextToken)
+	s.Empty(tokens.Next)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
@@ -72,18 +100,17 @@ func (s *ExplorerRepositoryTestSuite) TestGetLikers_Success_WithPagination() {
 	}
 	paginationToken, _ := cursor.Encode()
 
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
-
-	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp"}).
-		AddRow("actor1", int64(12345)).
-		AddRow("actor2", int64(123456)).
-		AddRow("actor3", int64(1234567))
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(12345), false, int64(2), listedAt(12345)).
+		AddRow("actor2", int64(123456), false, int64(3), listedAt(123456)).
+		AddRow("actor3", int64(1234567), false, int64(4), listedAt(1234567))
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true, int64(123)).
+	// A token without an id seeks past the whole second it ended on
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pastSecond(123, false), int64(0), (*bool)(nil), int32(3), int32(0)).
 		WillReturnRows(rows)
 
-	likers, nextToken, err := s.repo.GetLikers(s.ctx, recipientUserID, paginationToken)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
 	s.Len(likers, 2)
@@ -91,382 +118,1834 @@ func (s *ExplorerRepositoryTestSuite) TestGetLikers_Success_WithPagination() {
 	s.Equal(int64(12345), likers[0].Timestamp)
 	s.Equal("actor2", likers[1].ActorID)
 	s.Equal(int64(123456), likers[1].Timestamp)
-	s.NotEmpty(nextToken)
+	s.NotEmpty(tokens.Next)
 
 	// Verify next token contains correct timestamp
-	decodedCursor, decodeErr := utils.DecodeCursor(nextToken)
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
 	s.NoError(decodeErr)
 	s.Equal(int64(123456), decodedCursor.LastCreatedAt)
 	s.Equal(2, decodedCursor.Limit)
 
+	// A page continued from a token has a previous page, ending before its first liker
+	decodedCursor, decodeErr = utils.DecodeCursor(tokens.Prev)
+	s.NoError(decodeErr)
+	s.Equal(&utils.Cursor{LastCreatedAt: 12345, LastID: 2, LastCreatedAtMicros: 12345_000_000, Limit: 2, Backward: true}, decodedCursor)
+
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestGetLikers_EmptyResult() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_PageSizeOverridesCursor() {
 	recipientUserID := "user123"
-	paginationToken := ""
-
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
+	cursor := &utils.Cursor{
+		LastCreatedAt: 123,
+		Limit:         10,
+	}
+	paginationToken, _ := cursor.Encode()
 
-	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp"})
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(120), false, int64(5), listedAt(120)).
+		AddRow("actor2", int64(110), false, int64(6), listedAt(110))
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true).
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pastSecond(123, false), int64(0), (*bool)(nil), int32(2), int32(0)).
 		WillReturnRows(rows)
 
-	likers, nextToken, err := s.repo.GetLikers(s.ctx, recipientUserID, paginationToken)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken, Size: 1})
 
 	s.NoError(err)
-	s.Empty(likers)
-	s.Empty(nextToken)
+	s.Len(likers, 1)
+	s.Equal("actor1", likers[0].ActorID)
+
+	// The requested page size is carried into the next token
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
+	s.NoError(decodeErr)
+	s.Equal(int64(120), decodedCursor.LastCreatedAt)
+	s.Equal(1, decodedCursor.Limit)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestGetLikers_InvalidPaginationToken() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_TimeRange() {
 	recipientUserID := "user123"
-	invalidToken := "invalid_token"
 
-	likers, nextToken, err := s.repo.GetLikers(s.ctx, recipientUserID, invalidToken)
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(1500), false, int64(7), listedAt(1500))
 
-	s.Error(err)
-	s.Contains(err.Error(), "invalid paginationToken")
-	s.Nil(likers)
-	s.Empty(nextToken)
-}
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, bound(1000), bound(2000), pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnRows(rows)
 
-func (s *ExplorerRepositoryTestSuite) TestGetLikers_QueryError() {
-	recipientUserID := "user123"
-	paginationToken := ""
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Since: 1000, Until: 2000})
 
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
+	s.NoError(err)
+	s.Len(likers, 1)
+	s.Equal("actor1", likers[0].ActorID)
+	s.Empty(tokens.Next)
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true).
-		WillReturnError(errors.New("database connection failed"))
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_ClampsTokenPageSize() {
+	recipientUserID := "user123"
+	cursor := &utils.Cursor{
+		LastCreatedAt: 123,
+		Limit:         1000000,
+	}
+	paginationToken, _ := cursor.Encode()
 
-	likers, nextToken, err := s.repo.GetLikers(s.ctx, recipientUserID, paginationToken)
+	// A crafted token cannot read pages larger than the maximum page size
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pastSecond(123, false), int64(0), (*bool)(nil), int32(101), int32(0)).
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}))
 
-	s.Error(err)
-	s.Contains(err.Error(), "failed to get likers")
-	s.Nil(likers)
-	s.Empty(nextToken)
+	_, _, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
+	s.NoError(err)
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_Success_NoPagination() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_OldestFirst() {
 	recipientUserID := "user123"
-	paginationToken := ""
 
-	expectedSQL := `SELECT .* FROM decisions d1 LEFT JOIN decisions d2 ON d1.actor_user_id = d2.recipient_user_id WHERE .*`
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(100), false, int64(8), listedAt(100)).
+		AddRow("actor2", int64(200), false, int64(9), listedAt(200))
 
-	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp"}).
-		AddRow("newactor1", int64(1234)).
-		AddRow("newactor2", int64(12345))
-
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true).
+	s.mock.ExpectQuery(`-- name: ListDecidersAsc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(2), int32(0)).
 		WillReturnRows(rows)
 
-	likers, nextToken, err := s.repo.GetNewLikers(s.ctx, recipientUserID, paginationToken)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Size: 1, Ascending: true})
 
 	s.NoError(err)
-	s.Len(likers, 2)
-	s.Equal("newactor1", likers[0].ActorID)
-	s.Equal(int64(1234), likers[0].Timestamp)
-	s.Equal("newactor2", likers[1].ActorID)
-	s.Equal(int64(12345), likers[1].Timestamp)
-	s.Empty(nextToken) // No next token since results <= limit
+	s.Len(likers, 1)
+	s.Equal("actor1", likers[0].ActorID)
+
+	// The sort order is carried into the next token
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
+	s.NoError(decodeErr)
+	s.Equal(int64(100), decodedCursor.LastCreatedAt)
+	s.True(decodedCursor.Ascending)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_Success_WithPagination() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_OldestFirst_WithPagination() {
 	recipientUserID := "user123"
 	cursor := &utils.Cursor{
-		LastCreatedAt: 123,
+		LastCreatedAt: 100,
 		Limit:         2,
+		Ascending:     true,
 	}
 	paginationToken, _ := cursor.Encode()
 
-	expectedSQL := `SELECT .* FROM decisions d1 LEFT JOIN decisions d2 ON d1.actor_user_id = d2.recipient_user_id WHERE .*`
-
-	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp"}).
-		AddRow("newactor1", int64(1234)).
-		AddRow("newactor2", int64(12345)).
-		AddRow("newactor3", int64(123456))
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor2", int64(200), false, int64(10), listedAt(200))
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true, int64(123)).
+	// Ascending, a token without an id seeks past every id of its second
+	s.mock.ExpectQuery(`-- name: ListDecidersAsc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pastSecond(100, true), int64(0), (*bool)(nil), int32(3), int32(0)).
 		WillReturnRows(rows)
 
-	likers, nextToken, err := s.repo.GetNewLikers(s.ctx, recipientUserID, paginationToken)
+	// The token's order wins over the requested one
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
-	s.Len(likers, 2)
-	s.Equal("newactor1", likers[0].ActorID)
-	s.Equal("newactor2", likers[1].ActorID)
-	s.NotEmpty(nextToken)
+	s.Len(likers, 1)
+	s.Empty(tokens.Next)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_EmptyResult() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_SeeksWithinSecond() {
 	recipientUserID := "user123"
-	paginationToken := ""
+	cursor := &utils.Cursor{
+		LastCreatedAt:       123,
+		LastID:              7,
+		LastCreatedAtMicros: 123_000_250,
+		Limit:               1,
+	}
+	paginationToken, _ := cursor.Encode()
 
-	expectedSQL := `SELECT .* FROM decisions d1 LEFT JOIN decisions d2 ON d1.actor_user_id = d2.recipient_user_id WHERE .*`
+	// Likes made at the same time as the last one are told apart by decision id
+	expectedSQL := `-- name: ListDecidersDesc :many .* \(d.created_at, d.id\) < \(\$6::timestamptz, \$7::bigint\)`
 
-	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp"})
+	createdAt := time.UnixMicro(123_000_250)
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor2", int64(123), false, int64(5), createdAt).
+		AddRow("actor3", int64(123), false, int64(4), createdAt)
 
 	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, timestamptz(createdAt), int64(7), (*bool)(nil), int32(2), int32(0)).
 		WillReturnRows(rows)
 
-	likers, nextToken, err := s.repo.GetNewLikers(s.ctx, recipientUserID, paginationToken)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
-	s.Empty(likers)
-	s.Empty(nextToken)
+	s.Require().Len(likers, 1)
+	s.Equal("actor2", likers[0].ActorID)
+
+	// The next token carries the id of the last liker on the page
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
+	s.NoError(decodeErr)
+	s.Equal(int64(123), decodedCursor.LastCreatedAt)
+	s.Equal(int64(5), decodedCursor.LastID)
+	s.Equal(int64(123_000_250), decodedCursor.LastCreatedAtMicros)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_InvalidPaginationToken() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_PrevPage() {
 	recipientUserID := "user123"
-	invalidToken := "invalid_token"
-
-	likers, nextToken, err := s.repo.GetNewLikers(s.ctx, recipientUserID, invalidToken)
+	cursor := &utils.Cursor{
+		LastCreatedAt:       123,
+		LastID:              5,
+		LastCreatedAtMicros: 123_000_000,
+		Limit:               2,
+		Backward:            true,
+	}
+	paginationToken, _ := cursor.Encode()
 
-	s.Error(err)
-	s.Contains(err.Error(), "invalid paginationToken")
-	s.Nil(likers)
-	s.Empty(nextToken)
-}
+	// Newest first pages back by reading the likes after the first one, oldest first
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor3", int64(124), false, int64(6), listedAt(124)).
+		AddRow("actor2", int64(125), false, int64(8), listedAt(125)).
+		AddRow("actor1", int64(126), false, int64(9), listedAt(126))
 
-func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_QueryError() {
-	recipientUserID := "user123"
-	paginationToken := ""
+	s.mock.ExpectQuery(`-- name: ListDecidersAsc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, timestamptz(listedAt(123)), int64(5), (*bool)(nil), int32(3), int32(0)).
+		WillReturnRows(rows)
 
-	expectedSQL := `SELECT .* FROM decisions d1 LEFT JOIN decisions d2 ON d1.actor_user_id = d2.recipient_user_id WHERE .*`
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID, true).
-		WillReturnError(errors.New("database connection failed"))
+	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.Equal("actor2", likers[0].ActorID)
+	s.Equal("actor3", likers[1].ActorID)
 
-	likers, nextToken, err := s.repo.GetNewLikers(s.ctx, recipientUserID, paginationToken)
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
+	s.NoError(decodeErr)
+	s.Equal(&utils.Cursor{LastCreatedAt: 124, LastID: 6, LastCreatedAtMicros: 124_000_000, Limit: 2}, decodedCursor)
 
-	s.Error(err)
-	s.Contains(err.Error(), "failed to get new likers")
-	s.Nil(likers)
-	s.Empty(nextToken)
+	decodedCursor, decodeErr = utils.DecodeCursor(tokens.Prev)
+	s.NoError(decodeErr)
+	s.Equal(&utils.Cursor{LastCreatedAt: 125, LastID: 8, LastCreatedAtMicros: 125_000_000, Limit: 2, Backward: true}, decodedCursor)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestCountLikes_Success() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_PrevPage_First() {
 	recipientUserID := "user123"
-	expectedCount := int64(42)
-
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
+	cursor := &utils.Cursor{
+		LastCreatedAt:       123,
+		LastID:              5,
+		LastCreatedAtMicros: 123_000_000,
+		Limit:               2,
+		Backward:            true,
+	}
+	paginationToken, _ := cursor.Encode()
 
-	rows := pgxmock.NewRows([]string{"count"}).AddRow(expectedCount)
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(124), false, int64(6), listedAt(124))
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID).
+	s.mock.ExpectQuery(`-- name: ListDecidersAsc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, timestamptz(listedAt(123)), int64(5), (*bool)(nil), int32(3), int32(0)).
 		WillReturnRows(rows)
 
-	count, err := s.repo.CountLikes(s.ctx, recipientUserID)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
-	s.Equal(expectedCount, count)
+	s.Len(likers, 1)
+	s.NotEmpty(tokens.Next)
+	s.Empty(tokens.Prev)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestCountLikes_ZeroCount() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_OffsetPage() {
 	recipientUserID := "user123"
 
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
-
-	rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(0))
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor21", int64(300), false, int64(21), listedAt(300)).
+		AddRow("actor22", int64(200), false, int64(22), listedAt(200)).
+		AddRow("actor23", int64(100), false, int64(23), listedAt(100))
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID).
+	// The third page of two skips the four likers before it
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many .* LIMIT \$9::int OFFSET \$10::int`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(3), int32(4)).
 		WillReturnRows(rows)
 
-	count, err := s.repo.CountLikes(s.ctx, recipientUserID)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Size: 2, Page: 3})
 
 	s.NoError(err)
-	s.Equal(int64(0), count)
+	s.Require().Len(likers, 2)
+	s.Equal("actor21", likers[0].ActorID)
+	s.Equal("actor22", likers[1].ActorID)
+	s.Empty(tokens.Next)
+	s.Empty(tokens.Prev)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestCountLikes_QueryError() {
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_ByPopularity() {
 	recipientUserID := "user123"
 
-	expectedSQL := `SELECT .* FROM decisions WHERE .*`
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "score"}).
+		AddRow("actor1", int64(100), false, int64(1), 9.5).
+		AddRow("actor3", int64(300), true, int64(3), 4.0).
+		AddRow("actor2", int64(200), false, int64(2), 4.0)
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(recipientUserID).
-		WillReturnError(errors.New("database connection failed"))
+	s.mock.ExpectQuery(`-- name: ListLikersByPopularity :many`).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, (*float64)(nil), int64(0), int32(3), int32(0)).
+		WillReturnRows(rows)
 
-	count, err := s.repo.CountLikes(s.ctx, recipientUserID)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Size: 2, ByPopularity: true})
 
-	s.Error(err)
-	s.Equal(int64(0), count)
+	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.Equal("actor1", likers[0].ActorID)
+	s.Equal("actor3", likers[1].ActorID)
+	s.True(likers[1].LikedBack)
+	s.Empty(tokens.Prev)
+
+	// The next page seeks past the last liker's score and decision id
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
+	s.NoError(decodeErr)
+	s.Equal(&utils.Cursor{LastScore: 4.0, LastID: 3, Limit: 2, ByPopularity: true}, decodedCursor)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestCreateDecision_Success() {
-	params := explorerdb.CreateDecisionParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-		LikedRecipient:  true,
-	}
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_ByPopularity_WithPagination() {
+	recipientUserID := "user123"
+	cursor := &utils.Cursor{LastScore: 4.0, LastID: 3, Limit: 2, ByPopularity: true}
+	paginationToken, _ := cursor.Encode()
 
-	expectedSQL := `INSERT INTO decisions .* VALUES .* ON CONFLICT .* DO UPDATE .*`
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "score"}).
+		AddRow("actor2", int64(200), false, int64(2), 4.0)
 
-	s.mock.ExpectExec(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID, params.LikedRecipient).
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	// The token keeps the ranking of the first page
+	lastScore := 4.0
+	s.mock.ExpectQuery(`-- name: ListLikersByPopularity :many`).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, &lastScore, int64(3), int32(3), int32(0)).
+		WillReturnRows(rows)
 
-	err := s.repo.CreateDecision(s.ctx, params)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
+	s.Require().Len(likers, 1)
+	s.Equal("actor2", likers[0].ActorID)
+	s.Empty(tokens.Next)
+	s.Empty(tokens.Prev)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestCreateDecision_DislikeDecision() {
-	params := explorerdb.CreateDecisionParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-		LikedRecipient:  false, // Dislike
-	}
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_LikedBack() {
+	recipientUserID := "user123"
 
-	expectedSQL := `INSERT INTO decisions .* VALUES .* ON CONFLICT .* DO UPDATE .*`
+	expectedSQL := `SELECT d.actor_user_id, .*, EXISTS\( SELECT 1 FROM decisions back WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id .*\)::boolean AS liked_back, d.id, d.created_at FROM \( .* \) d WHERE .*`
 
-	s.mock.ExpectExec(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID, params.LikedRecipient).
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(200), true, int64(11), listedAt(200)).
+		AddRow("actor2", int64(100), false, int64(12), listedAt(100))
 
-	err := s.repo.CreateDecision(s.ctx, params)
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnRows(rows)
+
+	likers, _, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{})
 
 	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.True(likers[0].LikedBack)
+	s.False(likers[1].LikedBack)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestCreateDecision_Error() {
-	params := explorerdb.CreateDecisionParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-		LikedRecipient:  true,
-	}
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_DistinctActors() {
+	recipientUserID := "user123"
 
-	expectedSQL := `INSERT INTO decisions .* VALUES .* ON CONFLICT .* DO UPDATE .*`
+	// Each actor is listed by their latest decision on the recipient only
+	expectedSQL := `FROM \( SELECT DISTINCT ON \(latest.actor_user_id\) .* FROM decisions latest WHERE latest.recipient_user_id = \$1 ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC \) d WHERE d.liked_recipient = \$2`
 
-	s.mock.ExpectExec(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID, params.LikedRecipient).
-		WillReturnError(errors.New("constraint violation"))
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(300), false, int64(13), listedAt(300)).
+		AddRow("actor2", int64(200), false, int64(12), listedAt(200))
 
-	err := s.repo.CreateDecision(s.ctx, params)
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnRows(rows)
 
-	s.Error(err)
+	likers, _, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{})
+
+	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.Equal("actor1", likers[0].ActorID)
+	s.Equal(int64(300), likers[0].Timestamp)
+	s.Equal("actor2", likers[1].ActorID)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_True() {
-	params := explorerdb.HasMutualLikeParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-	}
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_ByPopularity_DistinctActors() {
+	recipientUserID := "user123"
 
-	expectedSQL := `SELECT .*`
+	expectedSQL := `-- name: ListLikersByPopularity :many .* FROM \( SELECT DISTINCT ON \(latest.actor_user_id\) .* FROM decisions latest WHERE latest.recipient_user_id = \$1 ORDER BY latest.actor_user_id, latest.created_at DESC, latest.id DESC \) d`
 
-	mutualLike := true
-	rows := pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike)
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "score"}).
+		AddRow("actor1", int64(300), false, int64(13), 9.5)
 
 	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, (*float64)(nil), int64(0), int32(21), int32(0)).
 		WillReturnRows(rows)
 
-	result, err := s.repo.HasMutualLike(s.ctx, params)
+	likers, _, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{ByPopularity: true})
 
 	s.NoError(err)
-	s.NotNil(result)
-	s.True(*result)
+	s.Require().Len(likers, 1)
+	s.Equal("actor1", likers[0].ActorID)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_False() {
-	params := explorerdb.HasMutualLikeParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-	}
-
-	expectedSQL := `SELECT .*`
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_EmptyResult() {
+	recipientUserID := "user123"
+	paginationToken := ""
 
-	mutualLike := false
-	rows := pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike)
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"})
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID).
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
 		WillReturnRows(rows)
 
-	result, err := s.repo.HasMutualLike(s.ctx, params)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
 
 	s.NoError(err)
-	s.NotNil(result)
-	s.False(*result)
+	s.Empty(likers)
+	s.Empty(tokens.Next)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_Null() {
-	params := explorerdb.HasMutualLikeParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-	}
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_InvalidPaginationToken() {
+	recipientUserID := "user123"
+	invalidToken := "invalid_token"
 
-	expectedSQL := `SELECT .*`
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: invalidToken})
 
-	rows := pgxmock.NewRows([]string{"exists"}).AddRow(nil)
+	s.Error(err)
+	s.Contains(err.Error(), "invalid paginationToken")
+	s.Nil(likers)
+	s.Empty(tokens.Next)
+}
 
-	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID).
-		WillReturnRows(rows)
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_QueryError() {
+	recipientUserID := "user123"
+	paginationToken := ""
 
-	result, err := s.repo.HasMutualLike(s.ctx, params)
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnError(errors.New("database connection failed"))
 
-	s.NoError(err)
-	s.Nil(result)
+	likers, tokens, err := s.repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to get likers")
+	s.Nil(likers)
+	s.Empty(tokens.Next)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_Error() {
-	params := explorerdb.HasMutualLikeParams{
-		ActorUserID:     "actor123",
-		RecipientUserID: "recipient456",
-	}
+func (s *ExplorerRepositoryTestSuite) TestGetPassers_Success() {
+	recipientUserID := "user123"
 
-	expectedSQL := `SELECT .*`
+	expectedSQL := `SELECT d.actor_user_id, .* FROM \( .* FROM decisions latest WHERE latest.recipient_user_id = \$1 .* \) d WHERE d.liked_recipient = \$2 AND NOT EXISTS\( SELECT 1 FROM blocks b .*\)`
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("actor1", int64(300), false, int64(13), listedAt(300)).
+		AddRow("actor2", int64(200), false, int64(14), listedAt(200))
 
 	s.mock.ExpectQuery(expectedSQL).
-		WithArgs(params.ActorUserID, params.RecipientUserID).
-		WillReturnError(errors.New("database connection failed"))
+		WithArgs(recipientUserID, false, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(2), int32(0)).
+		WillReturnRows(rows)
 
-	result, err := s.repo.HasMutualLike(s.ctx, params)
+	passers, tokens, err := s.repo.GetPassers(s.ctx, recipientUserID, models.PageRequest{Size: 1})
+
+	s.NoError(err)
+	s.Len(passers, 1)
+	s.Equal("actor1", passers[0].ActorID)
+	s.NotEmpty(tokens.Next)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetPassers_QueryError() {
+	recipientUserID := "user123"
+
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, false, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnError(errors.New("database connection failed"))
+
+	passers, tokens, err := s.repo.GetPassers(s.ctx, recipientUserID, models.PageRequest{})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to get passers")
+	s.Nil(passers)
+	s.Empty(tokens.Next)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_Success_NoPagination() {
+	recipientUserID := "user123"
+	paginationToken := ""
+
+	expectedSQL := `SELECT .* FROM \( .* FROM new_likes latest WHERE latest.recipient_user_id = \$1 .* \) nl WHERE .*`
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"}).
+		AddRow("newactor1", int64(1234), int64(15), listedAt(1234)).
+		AddRow("newactor2", int64(12345), int64(16), listedAt(12345))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), int32(21), int32(0)).
+		WillReturnRows(rows)
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Len(likers, 2)
+	s.Equal("newactor1", likers[0].ActorID)
+	s.Equal(int64(1234), likers[0].Timestamp)
+	s.Equal("newactor2", likers[1].ActorID)
+	s.Equal(int64(12345), likers[1].Timestamp)
+	s.Empty(tokens.Next) // No next token since results <= limit
+	s.Empty(tokens.Prev) // No previous token on the first page
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_DistinctActors() {
+	recipientUserID := "user123"
+
+	expectedSQL := `FROM \( SELECT DISTINCT ON \(latest.actor_user_id\) .* FROM new_likes latest WHERE latest.recipient_user_id = \$1 ORDER BY latest.actor_user_id, latest.created_at DESC, latest.decision_id DESC \) nl`
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"}).
+		AddRow("newactor1", int64(300), int64(17), listedAt(300)).
+		AddRow("newactor2", int64(100), int64(15), listedAt(100))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), int32(21), int32(0)).
+		WillReturnRows(rows)
+
+	likers, _, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{})
+
+	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.Equal("newactor1", likers[0].ActorID)
+	s.Equal(int64(300), likers[0].Timestamp)
+	s.Equal("newactor2", likers[1].ActorID)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_IncludePassed() {
+	recipientUserID := "user123"
+
+	// Likers the recipient passed on are read from decisions, leaving out those liked back
+	expectedSQL := `-- name: ListDecidersDesc :many .* AND \(\$8::boolean IS NULL OR EXISTS\( SELECT 1 FROM decisions back .* \) = \$8::boolean\)`
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+		AddRow("passedactor", int64(300), false, int64(17), listedAt(300)).
+		AddRow("newactor", int64(200), false, int64(16), listedAt(200))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, true, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), utils.ToPointer(false), int32(21), int32(0)).
+		WillReturnRows(rows)
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{IncludePassed: true})
+
+	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.Equal("passedactor", likers[0].ActorID)
+	s.Equal("newactor", likers[1].ActorID)
+	s.Empty(tokens.Next)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_Success_WithPagination() {
+	recipientUserID := "user123"
+	cursor := &utils.Cursor{
+		LastCreatedAt: 123,
+		Limit:         2,
+	}
+	paginationToken, _ := cursor.Encode()
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"}).
+		AddRow("newactor1", int64(1234), int64(17), listedAt(1234)).
+		AddRow("newactor2", int64(12345), int64(18), listedAt(12345)).
+		AddRow("newactor3", int64(123456), int64(19), listedAt(123456))
+
+	s.mock.ExpectQuery(`-- name: ListNewLikersDesc :many`).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pastSecond(123, false), int64(0), int32(3), int32(0)).
+		WillReturnRows(rows)
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Len(likers, 2)
+	s.Equal("newactor1", likers[0].ActorID)
+	s.Equal("newactor2", likers[1].ActorID)
+	s.NotEmpty(tokens.Next)
+	s.NotEmpty(tokens.Prev)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_PrevPage_OldestFirst() {
+	recipientUserID := "user123"
+	cursor := &utils.Cursor{
+		LastCreatedAt: 400,
+		Limit:         2,
+		Ascending:     true,
+		Backward:      true,
+	}
+	paginationToken, _ := cursor.Encode()
+
+	// Oldest first pages back newest first, past every like of the token's second
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"}).
+		AddRow("newactor2", int64(300), int64(18), listedAt(300)).
+		AddRow("newactor1", int64(200), int64(17), listedAt(200))
+
+	s.mock.ExpectQuery(`-- name: ListNewLikersDesc :many`).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pastSecond(400, false), int64(0), int32(3), int32(0)).
+		WillReturnRows(rows)
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Require().Len(likers, 2)
+	s.Equal("newactor1", likers[0].ActorID)
+	s.Equal("newactor2", likers[1].ActorID)
+	s.Empty(tokens.Prev)
+
+	decodedCursor, decodeErr := utils.DecodeCursor(tokens.Next)
+	s.NoError(decodeErr)
+	s.Equal(&utils.Cursor{LastCreatedAt: 300, LastID: 18, LastCreatedAtMicros: 300_000_000, Limit: 2, Ascending: true}, decodedCursor)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_SinceOnly() {
+	recipientUserID := "user123"
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"})
+
+	s.mock.ExpectQuery(`-- name: ListNewLikersDesc :many`).
+		WithArgs(recipientUserID, unbounded, bound(1000), unbounded, pgtype.Timestamptz{}, int64(0), int32(21), int32(0)).
+		WillReturnRows(rows)
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Since: 1000})
+
+	s.NoError(err)
+	s.Empty(likers)
+	s.Empty(tokens.Next)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_EmptyResult() {
+	recipientUserID := "user123"
+	paginationToken := ""
+
+	rows := pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"})
+
+	s.mock.ExpectQuery(`-- name: ListNewLikersDesc :many`).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), int32(21), int32(0)).
+		WillReturnRows(rows)
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Empty(likers)
+	s.Empty(tokens.Next)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_InvalidPaginationToken() {
+	recipientUserID := "user123"
+	invalidToken := "invalid_token"
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Token: invalidToken})
+
+	s.Error(err)
+	s.Contains(err.Error(), "invalid paginationToken")
+	s.Nil(likers)
+	s.Empty(tokens.Next)
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_QueryError() {
+	recipientUserID := "user123"
+	paginationToken := ""
+
+	s.mock.ExpectQuery(`-- name: ListNewLikersDesc :many`).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), int32(21), int32(0)).
+		WillReturnError(errors.New("database connection failed"))
+
+	likers, tokens, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{Token: paginationToken})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to get new likers")
+	s.Nil(likers)
+	s.Empty(tokens.Next)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetLikedRecipients_Success_NoPagination() {
+	actorUserID := "actor123"
+	paginationToken := ""
+
+	expectedSQL := `SELECT recipient_user_id, .* FROM decisions WHERE actor_user_id = .*`
+
+	rows := pgxmock.NewRows([]string{"recipient_user_id", "timestamp"}).
+		AddRow("recipient1", int64(1234))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(actorUserID, true).
+		WillReturnRows(rows)
+
+	recipients, nextToken, err := s.repo.GetLikedRecipients(s.ctx, actorUserID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Len(recipients, 1)
+	s.Equal("recipient1", recipients[0].RecipientID)
+	s.Equal(int64(1234), recipients[0].Timestamp)
+	s.Empty(nextToken)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetLikedRecipients_Success_WithPagination() {
+	actorUserID := "actor123"
+	cursor := &utils.Cursor{
+		LastCreatedAt: 123,
+		Limit:         2,
+	}
+	paginationToken, _ := cursor.Encode()
+
+	expectedSQL := `SELECT recipient_user_id, .* FROM decisions WHERE actor_user_id = .*`
+
+	rows := pgxmock.NewRows([]string{"recipient_user_id", "timestamp"}).
+		AddRow("recipient1", int64(120)).
+		AddRow("recipient2", int64(110)).
+		AddRow("recipient3", int64(100))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(actorUserID, true, int64(123)).
+		WillReturnRows(rows)
+
+	recipients, nextToken, err := s.repo.GetLikedRecipients(s.ctx, actorUserID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Len(recipients, 2)
+	s.Equal("recipient1", recipients[0].RecipientID)
+	s.Equal("recipient2", recipients[1].RecipientID)
+	s.NotEmpty(nextToken)
+
+	decodedCursor, decodeErr := utils.DecodeCursor(nextToken)
+	s.NoError(decodeErr)
+	s.Equal(int64(110), decodedCursor.LastCreatedAt)
+	s.Equal(2, decodedCursor.Limit)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetLikedRecipients_InvalidPaginationToken() {
+	recipients, nextToken, err := s.repo.GetLikedRecipients(s.ctx, "actor123", models.PageRequest{Token: "invalid_token"})
+
+	s.Error(err)
+	s.Contains(err.Error(), "invalid paginationToken")
+	s.Nil(recipients)
+	s.Empty(nextToken)
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetLikedRecipients_QueryError() {
+	actorUserID := "actor123"
+
+	expectedSQL := `SELECT recipient_user_id, .* FROM decisions WHERE actor_user_id = .*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(actorUserID, true).
+		WillReturnError(errors.New("database connection failed"))
+
+	recipients, nextToken, err := s.repo.GetLikedRecipients(s.ctx, actorUserID, models.PageRequest{})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to get liked recipients")
+	s.Nil(recipients)
+	s.Empty(nextToken)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCountLikers_Success() {
+	recipientUserID := "user123"
+	expectedCount := int64(42)
+
+	expectedSQL := `SELECT COALESCE\(\( SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = \$1 \), 0\)`
+
+	rows := pgxmock.NewRows([]string{"count"}).AddRow(expectedCount)
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID).
+		WillReturnRows(rows)
+
+	count, err := s.repo.CountLikers(s.ctx, recipientUserID)
+
+	s.NoError(err)
+	s.Equal(expectedCount, count)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCountLikers_ZeroCount() {
+	recipientUserID := "user123"
+
+	expectedSQL := `SELECT COALESCE\(\( SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = \$1 \), 0\)`
+
+	rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(0))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID).
+		WillReturnRows(rows)
+
+	count, err := s.repo.CountLikers(s.ctx, recipientUserID)
+
+	s.NoError(err)
+	s.Equal(int64(0), count)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCountLikers_QueryError() {
+	recipientUserID := "user123"
+
+	expectedSQL := `SELECT COALESCE\(\( SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = \$1 \), 0\)`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID).
+		WillReturnError(errors.New("database connection failed"))
+
+	count, err := s.repo.CountLikers(s.ctx, recipientUserID)
+
+	s.Error(err)
+	s.Equal(int64(0), count)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestReconcileLikeCounts_Success() {
+	expectedSQL := `WITH actual AS \( SELECT d.recipient_user_id, COUNT\(\*\) AS count FROM decisions d .* INSERT INTO like_counts .* ON CONFLICT \(recipient_user_id\) DO UPDATE SET count = EXCLUDED.count`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WillReturnRows(pgxmock.NewRows([]string{"corrected"}).AddRow(int64(3)))
+
+	corrected, err := s.repo.ReconcileLikeCounts(s.ctx)
+
+	s.NoError(err)
+	s.Equal(int64(3), corrected)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecision_Success() {
+	params := explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+		LikedRecipient:  true,
+	}
+
+	expectedSQL := `WITH previous AS \(.* FOR UPDATE \) INSERT INTO decisions .* VALUES .* ON CONFLICT .* DO UPDATE .* RETURNING .*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID, params.LikedRecipient).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+
+	created, err := s.repo.CreateDecision(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(explorerdb.CreateDecisionRow{Inserted: true, CreatedAt: timestamptz(listedAt(1700000000))}, created)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecision_DislikeDecision() {
+	params := explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+		LikedRecipient:  false, // Dislike
+	}
+
+	// The pass replaces an earlier like
+	expectedSQL := `WITH previous AS \(.* FOR UPDATE \) INSERT INTO decisions .* VALUES .* ON CONFLICT .* DO UPDATE .* RETURNING .*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID, params.LikedRecipient).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(false, true, listedAt(1700000000)))
+
+	created, err := s.repo.CreateDecision(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(explorerdb.CreateDecisionRow{PreviousLikedRecipient: true, CreatedAt: timestamptz(listedAt(1700000000))}, created)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecision_Error() {
+	params := explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+		LikedRecipient:  true,
+	}
+
+	expectedSQL := `WITH previous AS \(.* FOR UPDATE \) INSERT INTO decisions .* VALUES .* ON CONFLICT .* DO UPDATE .* RETURNING .*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID, params.LikedRecipient).
+		WillReturnError(errors.New("constraint violation"))
+
+	_, err := s.repo.CreateDecision(s.ctx, params)
+
+	s.Error(err)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_True() {
+	params := explorerdb.HasMutualLikeParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `SELECT .*`
+
+	mutualLike := true
+	rows := pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike)
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnRows(rows)
+
+	result, err := s.repo.HasMutualLike(s.ctx, params)
+
+	s.NoError(err)
+	s.NotNil(result)
+	s.True(*result)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_False() {
+	params := explorerdb.HasMutualLikeParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `SELECT .*`
+
+	mutualLike := false
+	rows := pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike)
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnRows(rows)
+
+	result, err := s.repo.HasMutualLike(s.ctx, params)
+
+	s.NoError(err)
+	s.NotNil(result)
+	s.False(*result)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_Null() {
+	params := explorerdb.HasMutualLikeParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `SELECT .*`
+
+	rows := pgxmock.NewRows([]string{"exists"}).AddRow(nil)
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnRows(rows)
+
+	result, err := s.repo.HasMutualLike(s.ctx, params)
+
+	s.NoError(err)
+	s.Nil(result)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestHasMutualLike_Error() {
+	params := explorerdb.HasMutualLikeParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `SELECT .*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnError(errors.New("database connection failed"))
+
+	result, err := s.repo.HasMutualLike(s.ctx, params)
+
+	s.Error(err)
+	s.Nil(result)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetDecision_Success() {
+	params := explorerdb.GetDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `SELECT .* FROM decisions WHERE actor_user_id = \$1 AND recipient_user_id = \$2`
+
+	createdAt := pgtype.Timestamptz{Time: time.Unix(1640995200, 0), Valid: true}
+	updatedAt := pgtype.Timestamptz{Time: time.Unix(1640995300, 0), Valid: true}
+	rows := pgxmock.NewRows([]string{"id", "actor_user_id", "recipient_user_id", "liked_recipient", "created_at", "updated_at"}).
+		AddRow(int64(1), params.ActorUserID, params.RecipientUserID, false, createdAt, updatedAt)
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnRows(rows)
+
+	decision, err := s.repo.GetDecision(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(1), decision.ID)
+	s.False(decision.LikedRecipient)
+	s.Equal(createdAt, decision.CreatedAt)
+	s.Equal(updatedAt, decision.UpdatedAt)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetDecision_NotFound() {
+	params := explorerdb.GetDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `SELECT .* FROM decisions WHERE actor_user_id = \$1 AND recipient_user_id = \$2`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "actor_user_id", "recipient_user_id", "liked_recipient", "created_at", "updated_at"}))
+
+	_, err := s.repo.GetDecision(s.ctx, params)
+
+	s.ErrorIs(err, pgx.ErrNoRows)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestDeleteDecision_Success() {
+	params := explorerdb.DeleteDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `DELETE FROM decisions WHERE .*`
+
+	s.mock.ExpectExec(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	deleted, err := s.repo.DeleteDecision(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(1), deleted)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestDeleteDecision_NoRows() {
+	params := explorerdb.DeleteDecisionParams{
+		ActorUserID:     "actor123",
+		RecipientUserID: "recipient456",
+	}
+
+	expectedSQL := `DELETE FROM decisions WHERE .*`
+
+	s.mock.ExpectExec(expectedSQL).
+		WithArgs(params.ActorUserID, params.RecipientUserID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	deleted, err := s.repo.DeleteDecision(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(0), deleted)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecisions_Success() {
+	decisions := []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", LikedRecipient: false},
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient2").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	mutualLike := true
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectExec(`INSERT INTO matches .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient2", false).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectExec(`DELETE FROM matches .*`).
+		WithArgs("actor1", "recipient2").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectCommit()
+
+	results, err := s.repo.CreateDecisions(s.ctx, decisions, make([]models.DecisionEvents, len(decisions)))
+
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal("recipient1", results[0].RecipientUserID)
+	s.True(results[0].MutualLikes)
+	s.Equal("recipient2", results[1].RecipientUserID)
+	s.False(results[1].MutualLikes)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecisions_RollbackOnError() {
+	decisions := []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: false},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", LikedRecipient: false},
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient2").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", false).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectExec(`DELETE FROM matches .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient2", false).
+		WillReturnError(errors.New("constraint violation"))
+	s.mock.ExpectRollback()
+
+	results, err := s.repo.CreateDecisions(s.ctx, decisions, make([]models.DecisionEvents, len(decisions)))
 
 	s.Error(err)
-	s.Nil(result)
+	s.Contains(err.Error(), "failed to create decision 1")
+	s.Nil(results)
+
+	var decisionErr *models.DecisionError
+	s.Require().ErrorAs(err, &decisionErr)
+	s.Equal(1, decisionErr.Index)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecisions_LocksPairsInOrder() {
+	decisions := []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", LikedRecipient: false},
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: false},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2", LikedRecipient: false},
+	}
+
+	// Every pair is locked once, in the same order whatever the order of the batch
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient2").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	for _, decision := range decisions {
+		s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+			WithArgs(decision.ActorUserID, decision.RecipientUserID, false).
+			WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+		s.mock.ExpectExec(`DELETE FROM matches .*`).
+			WithArgs(decision.ActorUserID, decision.RecipientUserID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	}
+	s.mock.ExpectCommit()
+
+	results, err := s.repo.CreateDecisions(s.ctx, decisions, make([]models.DecisionEvents, len(decisions)))
+
+	s.NoError(err)
+	s.Len(results, 3)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecisions_BeginError() {
+	s.mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+	results, err := s.repo.CreateDecisions(s.ctx, []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+	}, make([]models.DecisionEvents, 1))
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to begin transaction")
+	s.Nil(results)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetMatches_Success_WithPagination() {
+	userID := "user123"
+	cursor := &utils.Cursor{
+		LastCreatedAt: 500,
+		Limit:         1,
+	}
+	paginationToken, _ := cursor.Encode()
+
+	expectedSQL := `SELECT matched_user_id, .* FROM matches WHERE user_id = .* ORDER BY matched_at DESC`
+
+	rows := pgxmock.NewRows([]string{"matched_user_id", "timestamp"}).
+		AddRow("match1", int64(400)).
+		AddRow("match2", int64(300))
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(userID, int64(500)).
+		WillReturnRows(rows)
+
+	matches, nextToken, err := s.repo.GetMatches(s.ctx, userID, models.PageRequest{Token: paginationToken})
+
+	s.NoError(err)
+	s.Len(matches, 1)
+	s.Equal("match1", matches[0].UserID)
+	s.Equal(int64(400), matches[0].MatchedAt)
+
+	decodedCursor, decodeErr := utils.DecodeCursor(nextToken)
+	s.NoError(decodeErr)
+	s.Equal(int64(400), decodedCursor.LastCreatedAt)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetMatches_QueryError() {
+	userID := "user123"
+
+	s.mock.ExpectQuery(`SELECT .* FROM matches .*`).
+		WithArgs(userID).
+		WillReturnError(errors.New("database connection failed"))
+
+	matches, nextToken, err := s.repo.GetMatches(s.ctx, userID, models.PageRequest{})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to get matches")
+	s.Nil(matches)
+	s.Empty(nextToken)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestIngestDecisions_BeginError() {
+	s.mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+	summary, err := s.repo.IngestDecisions(s.ctx, []explorerdb.UpsertDecisionsParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+	})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to begin transaction")
+	s.Zero(summary)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestImportDecisions_Success() {
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	decisions := []models.ImportedDecision{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true, CreatedAt: createdAt},
+		{ActorUserID: "recipient1", RecipientUserID: "actor1", LikedRecipient: true, CreatedAt: createdAt},
+		{ActorUserID: "actor2", RecipientUserID: "recipient1", LikedRecipient: false, CreatedAt: createdAt},
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`CREATE TEMP TABLE decision_imports .* ON COMMIT DROP`).
+		WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+	s.mock.ExpectCopyFrom(pgx.Identifier{"decision_imports"}, []string{"actor_user_id", "recipient_user_id", "liked_recipient", "created_at"}).
+		WillReturnResult(3)
+	s.mock.ExpectQuery(`INSERT INTO decisions .* FROM decision_imports .* WHERE decisions.created_at < EXCLUDED.created_at`).
+		WillReturnRows(pgxmock.NewRows([]string{"created", "updated"}).AddRow(int64(1), int64(1)))
+	s.mock.ExpectExec(`DELETE FROM matches m USING .* decision_imports`).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectExec(`INSERT INTO matches .* FROM .* decision_imports.* ON CONFLICT .* DO NOTHING`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	s.mock.ExpectCommit()
+	s.mock.ExpectRollback()
+
+	summary, err := s.repo.ImportDecisions(s.ctx, decisions)
+
+	s.NoError(err)
+	s.Equal(models.ImportSummary{Rows: 3, Created: 1, Updated: 1, Skipped: 1, Matches: 1}, summary)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestImportDecisions_CopyError() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`CREATE TEMP TABLE decision_imports`).
+		WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+	s.mock.ExpectCopyFrom(pgx.Identifier{"decision_imports"}, []string{"actor_user_id", "recipient_user_id", "liked_recipient", "created_at"}).
+		WillReturnError(errors.New("connection reset"))
+	s.mock.ExpectRollback()
+
+	summary, err := s.repo.ImportDecisions(s.ctx, []models.ImportedDecision{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true, CreatedAt: time.Now()},
+	})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to copy decisions")
+	s.Zero(summary)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateBlock_Success() {
+	params := explorerdb.CreateBlockParams{
+		BlockerUserID: "user123",
+		BlockedUserID: "user456",
+	}
+
+	expectedSQL := `INSERT INTO blocks .* ON CONFLICT .* DO NOTHING`
+
+	s.mock.ExpectExec(expectedSQL).
+		WithArgs(params.BlockerUserID, params.BlockedUserID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := s.repo.CreateBlock(s.ctx, params)
+
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestDeleteBlock_NoRows() {
+	params := explorerdb.DeleteBlockParams{
+		BlockerUserID: "user123",
+		BlockedUserID: "user456",
+	}
+
+	expectedSQL := `DELETE FROM blocks WHERE .*`
+
+	s.mock.ExpectExec(expectedSQL).
+		WithArgs(params.BlockerUserID, params.BlockedUserID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	deleted, err := s.repo.DeleteBlock(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(0), deleted)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestIsBlocked_EitherDirection() {
+	params := explorerdb.IsBlockedParams{
+		BlockerUserID: "user123",
+		BlockedUserID: "user456",
+	}
+
+	expectedSQL := `SELECT EXISTS\( SELECT 1 FROM blocks WHERE \(blocker_user_id = \$1 AND blocked_user_id = \$2\) OR \(blocker_user_id = \$2 AND blocked_user_id = \$1\) \)`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.BlockerUserID, params.BlockedUserID).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	blocked, err := s.repo.IsBlocked(s.ctx, params)
+
+	s.NoError(err)
+	s.True(blocked)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetNewLikers_ExcludesBlockedUsers() {
+	recipientUserID := "user123"
+
+	expectedSQL := `SELECT .* FROM new_likes latest .* \) nl WHERE NOT EXISTS\( SELECT 1 FROM blocks b WHERE \(b.blocker_user_id = nl.recipient_user_id AND b.blocked_user_id = nl.actor_user_id\) OR \(b.blocker_user_id = nl.actor_user_id AND b.blocked_user_id = nl.recipient_user_id\) \).*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), int32(21), int32(0)).
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "timestamp", "id", "created_at"}))
+
+	likers, _, err := s.repo.GetNewLikers(s.ctx, recipientUserID, models.PageRequest{})
+
+	s.NoError(err)
+	s.Empty(likers)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetLikers_LikeTTL() {
+	recipientUserID := "user123"
+	repo := repository.NewExplorerRepository(s.mock, 90*24*time.Hour, zaptest.NewLogger(s.T()))
+
+	expectedSQL := `SELECT .* FROM decisions latest .* \) d .* AND \(\$3::bigint IS NULL OR d.created_at >= NOW\(\) - make_interval\(secs => \$3::bigint\)\) .*`
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(recipientUserID, true, bound(7776000), unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}))
+
+	likers, _, err := repo.GetLikers(s.ctx, recipientUserID, models.PageRequest{})
+
+	s.NoError(err)
+	s.Empty(likers)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestGetPassers_IgnoresLikeTTL() {
+	recipientUserID := "user123"
+	repo := repository.NewExplorerRepository(s.mock, 90*24*time.Hour, zaptest.NewLogger(s.T()))
+
+	s.mock.ExpectQuery(`-- name: ListDecidersDesc :many`).
+		WithArgs(recipientUserID, false, unbounded, unbounded, unbounded, pgtype.Timestamptz{}, int64(0), (*bool)(nil), int32(21), int32(0)).
+		WillReturnRows(pgxmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}))
+
+	_, _, err := repo.GetPassers(s.ctx, recipientUserID, models.PageRequest{})
+
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCountLikers_LikeTTL() {
+	recipientUserID := "user123"
+	repo := repository.NewExplorerRepository(s.mock, 90*24*time.Hour, zaptest.NewLogger(s.T()))
+
+	// The counter holds expired likes until they are purged, so unexpired likes are counted instead
+	s.mock.ExpectQuery(`SELECT COUNT\(\*\) FROM decisions d .* make_interval\(secs => \$2::bigint\)`).
+		WithArgs(recipientUserID, int64(7776000)).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	count, err := repo.CountLikers(s.ctx, recipientUserID)
+
+	s.NoError(err)
+	s.Equal(int64(3), count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestPurgeExpiredLikes_Success() {
+	params := explorerdb.PurgeExpiredLikesParams{
+		MaxAgeSeconds: 7776000,
+		BatchSize:     1000,
+	}
+
+	expectedSQL := `DELETE FROM decisions WHERE id IN \( SELECT id FROM decisions WHERE liked_recipient = true AND created_at < .* LIMIT \$2::int \)`
+
+	s.mock.ExpectExec(expectedSQL).
+		WithArgs(params.MaxAgeSeconds, params.BatchSize).
+		WillReturnResult(pgxmock.NewResult("DELETE", 12))
+
+	deleted, err := s.repo.PurgeExpiredLikes(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(12), deleted)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestCreateDecisions_WritesNewLikeToOutbox() {
+	decisions := []explorerdb.CreateDecisionParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+	}
+	events := []models.DecisionEvents{
+		{NewLike: &models.OutboxEvent{Topic: "newlikes:recipient1", Payload: []byte("event")}},
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	mutualLike := false
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectExec(`INSERT INTO outbox .*`).
+		WithArgs("newlikes:recipient1", []byte("event")).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	s.mock.ExpectCommit()
+
+	results, err := s.repo.CreateDecisions(s.ctx, decisions, events)
+
+	s.NoError(err)
+	s.False(results[0].MutualLikes)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_NewLike() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true}
+	newLike := models.OutboxEvent{Topic: "newlikes:recipient1", Payload: []byte("event")}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectQuery(`SELECT EXISTS\(\s+SELECT 1 FROM blocks`).
+		WithArgs("recipient1", "actor1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	mutualLike := false
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectExec(`INSERT INTO outbox .*`).
+		WithArgs(newLike.Topic, newLike.Payload).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	s.mock.ExpectCommit()
+
+	recorded, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{NewLike: &newLike})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{LikesDelta: 1, Created: true, Timestamp: 1700000000}, recorded)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_MutualLikeWritesMatchedEvents() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectQuery(`SELECT EXISTS\(\s+SELECT 1 FROM blocks`).
+		WithArgs("recipient1", "actor1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	mutualLike := true
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	// The match is stored in the transaction of the decision that made it
+	s.mock.ExpectExec(`INSERT INTO matches .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	s.mock.ExpectExec(`INSERT INTO outbox .*`).
+		WithArgs("webhook:https://partner.example.com/match", []byte("match")).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	s.mock.ExpectCommit()
+
+	recorded, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{
+		NewLike: &models.OutboxEvent{Topic: "newlikes:recipient1", Payload: []byte("event")},
+		Matched: []models.OutboxEvent{{Topic: "webhook:https://partner.example.com/match", Payload: []byte("match")}},
+	})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{MutualLikes: true, LikesDelta: 1, Created: true, Timestamp: 1700000000}, recorded)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_OutboxErrorRollsBack() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectQuery(`SELECT EXISTS\(\s+SELECT 1 FROM blocks`).
+		WithArgs("recipient1", "actor1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	mutualLike := false
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectExec(`INSERT INTO outbox .*`).
+		WithArgs("newlikes:recipient1", []byte("event")).
+		WillReturnError(errors.New("disk full"))
+	s.mock.ExpectRollback()
+
+	_, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{
+		NewLike: &models.OutboxEvent{Topic: "newlikes:recipient1", Payload: []byte("event")},
+	})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to create outbox event")
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_LockErrorRollsBack() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "user2", RecipientUserID: "user1", LikedRecipient: true}
+
+	// The pair is locked the same way from either side
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("user1", "user2").
+		WillReturnError(errors.New("lock timeout"))
+	s.mock.ExpectRollback()
+
+	_, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{})
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to lock decision pair")
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRemoveDecision_MutualLikeEndsMatch() {
+	decision := explorerdb.DeleteDecisionParams{ActorUserID: "user2", RecipientUserID: "user1"}
+
+	// The withdrawal is serialized with likes on the same pair, so the match cannot outlive it
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("user1", "user2").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mutualLike := true
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("user2", "user1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectExec(`DELETE FROM decisions`).
+		WithArgs("user2", "user1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	s.mock.ExpectExec(`DELETE FROM matches`).
+		WithArgs("user2", "user1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	s.mock.ExpectCommit()
+
+	removed, err := s.repo.RemoveDecision(s.ctx, decision)
+
+	s.NoError(err)
+	s.Equal(models.RemovedDecision{Deleted: true, WasMutualLike: true, MatchEnded: true}, removed)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRemoveDecision_NotFound() {
+	decision := explorerdb.DeleteDecisionParams{ActorUserID: "user1", RecipientUserID: "user2"}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("user1", "user2").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("user1", "user2").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(nil))
+	s.mock.ExpectExec(`DELETE FROM decisions`).
+		WithArgs("user1", "user2").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	s.mock.ExpectCommit()
+
+	removed, err := s.repo.RemoveDecision(s.ctx, decision)
+
+	s.NoError(err)
+	s.Equal(models.RemovedDecision{}, removed)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRemoveDecision_MatchErrorRollsBack() {
+	decision := explorerdb.DeleteDecisionParams{ActorUserID: "user1", RecipientUserID: "user2"}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("user1", "user2").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mutualLike := true
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("user1", "user2").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectExec(`DELETE FROM decisions`).
+		WithArgs("user1", "user2").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	s.mock.ExpectExec(`DELETE FROM matches`).
+		WithArgs("user1", "user2").
+		WillReturnError(errors.New("lock timeout"))
+	s.mock.ExpectRollback()
+
+	_, err := s.repo.RemoveDecision(s.ctx, decision)
+
+	s.Error(err)
+	s.Contains(err.Error(), "failed to delete match")
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_PassWithdrawsLike() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: false}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", false).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(false, true, listedAt(1700000000)))
+	s.mock.ExpectQuery(`SELECT EXISTS\(\s+SELECT 1 FROM blocks`).
+		WithArgs("recipient1", "actor1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	s.mock.ExpectExec(`DELETE FROM matches .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	s.mock.ExpectExec(`INSERT INTO outbox .*`).
+		WithArgs("webhook:https://partner.example.com/withdrawn", []byte("withdrawn")).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	s.mock.ExpectCommit()
+
+	recorded, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{
+		Withdrawn: []models.OutboxEvent{{Topic: "webhook:https://partner.example.com/withdrawn", Payload: []byte("withdrawn")}},
+	})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{LikesDelta: -1, LikedBefore: true, MatchEnded: true, Timestamp: 1700000000}, recorded)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_BlockedLikeNotCounted() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectQuery(`SELECT EXISTS\(\s+SELECT 1 FROM blocks`).
+		WithArgs("recipient1", "actor1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	mutualLike := false
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectCommit()
+
+	recorded, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{Created: true, Timestamp: 1700000000}, recorded)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestRecordDecision_RepeatedLikeSkipsBlockCheck() {
+	decision := explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(false, true, listedAt(1700000000)))
+	mutualLike := false
+	s.mock.ExpectQuery(`SELECT .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(&mutualLike))
+	s.mock.ExpectCommit()
+
+	// The like was already announced, so nothing is written to the outbox
+	recorded, err := s.repo.RecordDecision(s.ctx, decision, models.DecisionEvents{
+		NewLike: &models.OutboxEvent{Topic: "newlikes:recipient1", Payload: []byte("event")},
+	})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{LikedBefore: true, Timestamp: 1700000000}, recorded)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestDrainOutbox_DeliversAndRetries() {
+	claim := explorerdb.ClaimOutboxEventsParams{MaxAttempts: 10, BatchSize: 100}
+	columns := []string{"id", "topic", "payload", "attempts", "last_error", "next_attempt_at", "created_at"}
+	now := pgtype.Timestamptz{Time: time.Unix(1640995200, 0), Valid: true}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`SELECT .* FROM outbox WHERE .* FOR UPDATE SKIP LOCKED`).
+		WithArgs(claim.MaxAttempts, claim.BatchSize).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(int64(1), "newlikes:user1", []byte("a"), int32(0), (*string)(nil), now, now).
+			AddRow(int64(2), "newlikes:user2", []byte("b"), int32(3), (*string)(nil), now, now))
+	s.mock.ExpectExec(`DELETE FROM outbox WHERE id = \$1`).
+		WithArgs(int64(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	s.mock.ExpectExec(`UPDATE outbox SET attempts = attempts \+ 1, .*`).
+		WithArgs("bus unavailable", int64(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	s.mock.ExpectCommit()
+
+	var topics []string
+	summary, err := s.repo.DrainOutbox(s.ctx, claim, func(ctx context.Context, event explorerdb.Outbox) error {
+		topics = append(topics, event.Topic)
+		if event.ID == 2 {
+			return errors.New("bus unavailable")
+		}
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal(models.DrainSummary{Delivered: 1, Retried: 1}, summary)
+	s.Equal([]string{"newlikes:user1", "newlikes:user2"}, topics)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ExplorerRepositoryTestSuite) TestDrainOutbox_DeadLettersFinalAttempt() {
+	claim := explorerdb.ClaimOutboxEventsParams{MaxAttempts: 10, BatchSize: 100}
+	columns := []string{"id", "topic", "payload", "attempts", "last_error", "next_attempt_at", "created_at"}
+	now := pgtype.Timestamptz{Time: time.Unix(1640995200, 0), Valid: true}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`SELECT .* FROM outbox WHERE .* FOR UPDATE SKIP LOCKED`).
+		WithArgs(claim.MaxAttempts, claim.BatchSize).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(int64(7), "webhook:https://partner.example.com/match", []byte("a"), int32(9), (*string)(nil), now, now))
+	s.mock.ExpectExec(`WITH moved AS \( DELETE FROM outbox .* INSERT INTO outbox_dead_letters .*`).
+		WithArgs(int64(7), "webhook endpoint answered 503").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	s.mock.ExpectCommit()
+
+	summary, err := s.repo.DrainOutbox(s.ctx, claim, func(ctx context.Context, event explorerdb.Outbox) error {
+		return errors.New("webhook endpoint answered 503")
+	})
+
+	s.NoError(err)
+	s.Equal(models.DrainSummary{DeadLettered: 1}, summary)
 
 	s.NoError(s.mock.ExpectationsWereMet())
 }
@@ -0,0 +1,808 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+)
+
+// errAuditEventsAppendOnly is returned for changes to audit events not allowed by
+// AllowAuditEventErasure, as the audit_events trigger rejects them
+var errAuditEventsAppendOnly = errors.New("audit_events is append-only")
+
+// maxOutboxRetryDelay caps the backoff of a retried outbox event, as RetryOutboxEvent does
+const maxOutboxRetryDelay = 5 * time.Minute
+
+// userPair keys the rows between two users: a decision by its actor and recipient, a match by
+// its user and matched user, a block by its blocker and blocked user
+type userPair struct {
+	user, other string
+}
+
+// memoryTables holds the tables of the explore schema in maps and slices, and runs the queries
+// of explorerdb.Querier on them with the semantics of their SQL. Rows the triggers maintain in
+// Postgres, like counts and new likes, are derived from decisions as they are read. It is not
+// safe for concurrent use: memoryStore serializes the calls.
+type memoryTables struct {
+	now func() time.Time
+
+	decisions map[userPair]*explorerdb.Decision
+	// received and made index decisions by recipient and by actor, then by the other user
+	received map[string]map[string]*explorerdb.Decision
+	made     map[string]map[string]*explorerdb.Decision
+
+	matches         map[userPair]explorerdb.Match
+	blocks          map[userPair]explorerdb.Block
+	reports         []explorerdb.Report
+	outbox          []explorerdb.Outbox // Ordered by id
+	deadLetters     []explorerdb.OutboxDeadLetter
+	auditEvents     []explorerdb.AuditEvent // Ordered by id
+	erasureReceipts []explorerdb.ErasureReceipt
+	// claimed holds the outbox events a drain is delivering, skipped by other claims as
+	// FOR UPDATE SKIP LOCKED does
+	claimed map[int64]bool
+	// auditErasure allows the next AnonymizeUserAuditEvents, as explore.erasure does for the
+	// rest of its transaction
+	auditErasure bool
+
+	lastID struct {
+		decision, match, block, report, outbox, deadLetter, auditEvent, erasureReceipt int64
+	}
+}
+
+var _ explorerdb.Querier = (*memoryTables)(nil)
+
+func newMemoryTables(now func() time.Time) *memoryTables {
+	return &memoryTables{
+		now:       now,
+		decisions: make(map[userPair]*explorerdb.Decision),
+		received:  make(map[string]map[string]*explorerdb.Decision),
+		made:      make(map[string]map[string]*explorerdb.Decision),
+		matches:   make(map[userPair]explorerdb.Match),
+		blocks:    make(map[userPair]explorerdb.Block),
+		claimed:   make(map[int64]bool),
+	}
+}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+// putDecision stores d, replacing the decision of its actor on its recipient
+func (t *memoryTables) putDecision(d *explorerdb.Decision) {
+	t.decisions[userPair{d.ActorUserID, d.RecipientUserID}] = d
+	if t.received[d.RecipientUserID] == nil {
+		t.received[d.RecipientUserID] = make(map[string]*explorerdb.Decision)
+	}
+	t.received[d.RecipientUserID][d.ActorUserID] = d
+	if t.made[d.ActorUserID] == nil {
+		t.made[d.ActorUserID] = make(map[string]*explorerdb.Decision)
+	}
+	t.made[d.ActorUserID][d.RecipientUserID] = d
+}
+
+// insertDecision stores a new decision of actor on recipient made at createdAt
+func (t *memoryTables) insertDecision(actor, recipient string, liked bool, createdAt time.Time) {
+	t.lastID.decision++
+	t.putDecision(&explorerdb.Decision{
+		ID:              t.lastID.decision,
+		ActorUserID:     actor,
+		RecipientUserID: recipient,
+		LikedRecipient:  liked,
+		CreatedAt:       timestamptz(createdAt),
+		UpdatedAt:       timestamptz(t.now()),
+	})
+}
+
+// deleteDecision removes the decision of actor on recipient, reporting whether there was one
+func (t *memoryTables) deleteDecision(actor, recipient string) bool {
+	if _, ok := t.decisions[userPair{actor, recipient}]; !ok {
+		return false
+	}
+	delete(t.decisions, userPair{actor, recipient})
+	delete(t.received[recipient], actor)
+	if len(t.received[recipient]) == 0 {
+		delete(t.received, recipient)
+	}
+	delete(t.made[actor], recipient)
+	if len(t.made[actor]) == 0 {
+		delete(t.made, actor)
+	}
+	return true
+}
+
+// likes reports whether actor likes recipient
+func (t *memoryTables) likes(actor, recipient string) bool {
+	d, ok := t.decisions[userPair{actor, recipient}]
+	return ok && d.LikedRecipient
+}
+
+// blocked reports whether either user blocked the other
+func (t *memoryTables) blocked(user, other string) bool {
+	_, blocked := t.blocks[userPair{user, other}]
+	_, blockedBack := t.blocks[userPair{other, user}]
+	return blocked || blockedBack
+}
+
+// inListingWindow reports whether a decision made at createdAt is within the like TTL at now
+// and the time range the listing queries are given
+func inListingWindow(now, createdAt time.Time, maxAgeSeconds, since, until *int64) bool {
+	if maxAgeSeconds != nil && createdAt.Before(now.Add(-time.Duration(*maxAgeSeconds)*time.Second)) {
+		return false
+	}
+	ts := listingSecond(createdAt)
+	return (since == nil || ts >= *since) && (until == nil || ts < *until)
+}
+
+// afterKeyset reports whether the row created at createdAt with id comes after the keyset
+// (after, afterID) in the listing order. Times are compared in microseconds, as Postgres keeps
+// them and tokens carry them.
+func afterKeyset(createdAt time.Time, id int64, after pgtype.Timestamptz, afterID int64, ascending bool) bool {
+	if !after.Valid {
+		return true
+	}
+	ts, afterTS := createdAt.UnixMicro(), after.Time.UnixMicro()
+	if ascending {
+		return ts > afterTS || (ts == afterTS && id > afterID)
+	}
+	return ts < afterTS || (ts == afterTS && id < afterID)
+}
+
+// sortByKeyset orders rows by their time and id, in the listing order
+func sortByKeyset[T any](rows []T, ascending bool, position func(T) (int64, int64)) {
+	slices.SortFunc(rows, func(a, b T) int {
+		aTS, aID := position(a)
+		bTS, bID := position(b)
+		c := cmp.Compare(aTS, bTS)
+		if c == 0 {
+			c = cmp.Compare(aID, bID)
+		}
+		if !ascending {
+			c = -c
+		}
+		return c
+	})
+}
+
+// pageOf returns the rows of the page at offset, at most limit of them
+func pageOf[T any](rows []T, limit, offset int32) []T {
+	if int(offset) >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if int(limit) < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func (t *memoryTables) AllowAuditEventErasure(ctx context.Context) error {
+	t.auditErasure = true
+	return nil
+}
+
+func (t *memoryTables) AnonymizeUserAuditEvents(ctx context.Context, arg explorerdb.AnonymizeUserAuditEventsParams) (int64, error) {
+	var anonymized int64
+	for i := range t.auditEvents {
+		event := &t.auditEvents[i]
+		if !slices.Contains(event.UserIds, arg.UserID) && event.CallerID != arg.UserID {
+			continue
+		}
+		if !t.auditErasure {
+			return 0, errAuditEventsAppendOnly
+		}
+		userIDs := slices.Clone(event.UserIds)
+		for j, userID := range userIDs {
+			if userID == arg.UserID {
+				userIDs[j] = arg.Placeholder
+			}
+		}
+		event.UserIds = userIDs
+		if event.CallerID == arg.UserID {
+			event.CallerID = arg.Placeholder
+		}
+		event.Details = []byte("{}")
+		anonymized++
+	}
+	t.auditErasure = false
+	return anonymized, nil
+}
+
+func (t *memoryTables) AnonymizeUserReports(ctx context.Context, arg explorerdb.AnonymizeUserReportsParams) (int64, error) {
+	var anonymized int64
+	for i := range t.reports {
+		report := &t.reports[i]
+		if report.ReporterUserID != arg.UserID && report.ReportedUserID != arg.UserID {
+			continue
+		}
+		if report.ReporterUserID == arg.UserID {
+			report.ReporterUserID = arg.Placeholder
+		}
+		if report.ReportedUserID == arg.UserID {
+			report.ReportedUserID = arg.Placeholder
+		}
+		anonymized++
+	}
+	return anonymized, nil
+}
+
+func (t *memoryTables) ClaimOutboxEvents(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error) {
+	now := t.now()
+	var events []explorerdb.Outbox
+	for _, event := range t.outbox {
+		if int32(len(events)) == arg.BatchSize {
+			break
+		}
+		if t.claimed[event.ID] || event.NextAttemptAt.Time.After(now) || event.Attempts >= arg.MaxAttempts {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (t *memoryTables) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	var count int64
+	for actor, d := range t.received[recipientUserID] {
+		if d.LikedRecipient && !t.blocked(actor, recipientUserID) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (t *memoryTables) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (int64, error) {
+	var count int64
+	for actor, d := range t.received[arg.RecipientUserID] {
+		if d.LikedRecipient && !t.blocked(actor, arg.RecipientUserID) &&
+			inListingWindow(t.now(), d.CreatedAt.Time, &arg.MaxAgeSeconds, nil, nil) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (t *memoryTables) CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) error {
+	t.lastID.auditEvent++
+	t.auditEvents = append(t.auditEvents, explorerdb.AuditEvent{
+		ID:              t.lastID.auditEvent,
+		OccurredAt:      timestamptz(t.now()),
+		Action:          arg.Action,
+		CallerID:        arg.CallerID,
+		CallerIsService: arg.CallerIsService,
+		ClientAddress:   arg.ClientAddress,
+		RequestID:       arg.RequestID,
+		UserIds:         slices.Clone(arg.UserIds),
+		Outcome:         arg.Outcome,
+		Details:         slices.Clone(arg.Details),
+	})
+	return nil
+}
+
+func (t *memoryTables) CreateBlock(ctx context.Context, arg explorerdb.CreateBlockParams) error {
+	pair := userPair{arg.BlockerUserID, arg.BlockedUserID}
+	if _, ok := t.blocks[pair]; ok {
+		return nil
+	}
+	t.lastID.block++
+	t.blocks[pair] = explorerdb.Block{
+		ID:            t.lastID.block,
+		BlockerUserID: arg.BlockerUserID,
+		BlockedUserID: arg.BlockedUserID,
+		CreatedAt:     timestamptz(t.now()),
+	}
+	return nil
+}
+
+func (t *memoryTables) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error) {
+	now := t.now()
+	previous, ok := t.decisions[userPair{arg.ActorUserID, arg.RecipientUserID}]
+	if !ok {
+		t.insertDecision(arg.ActorUserID, arg.RecipientUserID, arg.LikedRecipient, now)
+		return explorerdb.CreateDecisionRow{Inserted: true, CreatedAt: timestamptz(now)}, nil
+	}
+
+	row := explorerdb.CreateDecisionRow{PreviousLikedRecipient: previous.LikedRecipient, CreatedAt: timestamptz(now)}
+	previous.LikedRecipient = arg.LikedRecipient
+	previous.CreatedAt = row.CreatedAt
+	previous.UpdatedAt = previous.CreatedAt
+	return row, nil
+}
+
+func (t *memoryTables) CreateErasureReceipt(ctx context.Context, arg explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error) {
+	t.lastID.erasureReceipt++
+	receipt := explorerdb.ErasureReceipt{
+		ID:          t.lastID.erasureReceipt,
+		SubjectHash: arg.SubjectHash,
+		ErasedAt:    timestamptz(t.now()),
+		CallerID:    arg.CallerID,
+		RequestID:   arg.RequestID,
+		Decisions:   arg.Decisions,
+		Matches:     arg.Matches,
+		Blocks:      arg.Blocks,
+		Reports:     arg.Reports,
+		AuditEvents: arg.AuditEvents,
+	}
+	t.erasureReceipts = append(t.erasureReceipts, receipt)
+	return explorerdb.CreateErasureReceiptRow{ID: receipt.ID, ErasedAt: receipt.ErasedAt.Time.Unix()}, nil
+}
+
+// insertMatch stores the match of user with matchedUser made at matchedAt, reporting whether
+// it is new
+func (t *memoryTables) insertMatch(user, matchedUser string, matchedAt time.Time) bool {
+	pair := userPair{user, matchedUser}
+	if _, ok := t.matches[pair]; ok {
+		return false
+	}
+	t.lastID.match++
+	t.matches[pair] = explorerdb.Match{
+		ID:            t.lastID.match,
+		UserID:        user,
+		MatchedUserID: matchedUser,
+		MatchedAt:     timestamptz(matchedAt),
+	}
+	return true
+}
+
+func (t *memoryTables) CreateMatch(ctx context.Context, arg explorerdb.CreateMatchParams) error {
+	now := t.now()
+	t.insertMatch(arg.UserID, arg.MatchedUserID, now)
+	t.insertMatch(arg.MatchedUserID, arg.UserID, now)
+	return nil
+}
+
+func (t *memoryTables) CreateOutboxEvent(ctx context.Context, arg explorerdb.CreateOutboxEventParams) error {
+	now := t.now()
+	t.lastID.outbox++
+	t.outbox = append(t.outbox, explorerdb.Outbox{
+		ID:            t.lastID.outbox,
+		Topic:         arg.Topic,
+		Payload:       slices.Clone(arg.Payload),
+		NextAttemptAt: timestamptz(now),
+		CreatedAt:     timestamptz(now),
+	})
+	return nil
+}
+
+func (t *memoryTables) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (explorerdb.Report, error) {
+	t.lastID.report++
+	report := explorerdb.Report{
+		ID:             t.lastID.report,
+		ReporterUserID: arg.ReporterUserID,
+		ReportedUserID: arg.ReportedUserID,
+		Reason:         arg.Reason,
+		CreatedAt:      timestamptz(t.now()),
+	}
+	if d, ok := t.decisions[userPair{arg.ReporterUserID, arg.ReportedUserID}]; ok {
+		report.ReporterLiked = &d.LikedRecipient
+	}
+	if d, ok := t.decisions[userPair{arg.ReportedUserID, arg.ReporterUserID}]; ok {
+		report.ReportedLiked = &d.LikedRecipient
+	}
+	t.reports = append(t.reports, report)
+	return report, nil
+}
+
+// outboxIndex returns the index of the outbox event id, or -1 when there is none
+func (t *memoryTables) outboxIndex(id int64) int {
+	i, found := slices.BinarySearchFunc(t.outbox, id, func(event explorerdb.Outbox, id int64) int {
+		return cmp.Compare(event.ID, id)
+	})
+	if !found {
+		return -1
+	}
+	return i
+}
+
+func (t *memoryTables) DeadLetterOutboxEvent(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams) error {
+	i := t.outboxIndex(arg.ID)
+	if i < 0 {
+		return nil
+	}
+	event := t.outbox[i]
+	t.outbox = slices.Delete(t.outbox, i, i+1)
+
+	t.lastID.deadLetter++
+	t.deadLetters = append(t.deadLetters, explorerdb.OutboxDeadLetter{
+		ID:        t.lastID.deadLetter,
+		OutboxID:  event.ID,
+		Topic:     event.Topic,
+		Payload:   event.Payload,
+		Attempts:  event.Attempts + 1,
+		LastError: arg.LastError,
+		CreatedAt: event.CreatedAt,
+		FailedAt:  timestamptz(t.now()),
+	})
+	return nil
+}
+
+func (t *memoryTables) DeleteBlock(ctx context.Context, arg explorerdb.DeleteBlockParams) (int64, error) {
+	pair := userPair{arg.BlockerUserID, arg.BlockedUserID}
+	if _, ok := t.blocks[pair]; !ok {
+		return 0, nil
+	}
+	delete(t.blocks, pair)
+	return 1, nil
+}
+
+func (t *memoryTables) DeleteDecision(ctx context.Context, arg explorerdb.DeleteDecisionParams) (int64, error) {
+	if !t.deleteDecision(arg.ActorUserID, arg.RecipientUserID) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (t *memoryTables) DeleteMatch(ctx context.Context, arg explorerdb.DeleteMatchParams) (int64, error) {
+	var deleted int64
+	for _, pair := range []userPair{{arg.UserID, arg.MatchedUserID}, {arg.MatchedUserID, arg.UserID}} {
+		if _, ok := t.matches[pair]; ok {
+			delete(t.matches, pair)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (t *memoryTables) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	if i := t.outboxIndex(id); i >= 0 {
+		t.outbox = slices.Delete(t.outbox, i, i+1)
+	}
+	return nil
+}
+
+func (t *memoryTables) DeleteUserBlocks(ctx context.Context, userID string) (int64, error) {
+	var deleted int64
+	for pair := range t.blocks {
+		if pair.user == userID || pair.other == userID {
+			delete(t.blocks, pair)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (t *memoryTables) DeleteUserDecisions(ctx context.Context, userID string) ([]explorerdb.DeleteUserDecisionsRow, error) {
+	var decisions []*explorerdb.Decision
+	for _, d := range t.made[userID] {
+		decisions = append(decisions, d)
+	}
+	for actor, d := range t.received[userID] {
+		if actor != userID {
+			decisions = append(decisions, d)
+		}
+	}
+	slices.SortFunc(decisions, func(a, b *explorerdb.Decision) int { return cmp.Compare(a.ID, b.ID) })
+
+	rows := make([]explorerdb.DeleteUserDecisionsRow, 0, len(decisions))
+	for _, d := range decisions {
+		t.deleteDecision(d.ActorUserID, d.RecipientUserID)
+		rows = append(rows, explorerdb.DeleteUserDecisionsRow{ActorUserID: d.ActorUserID, RecipientUserID: d.RecipientUserID})
+	}
+	return rows, nil
+}
+
+// DeleteUserLikeCount leaves nothing to delete, as like counts are derived from decisions
+func (t *memoryTables) DeleteUserLikeCount(ctx context.Context, recipientUserID string) error {
+	return nil
+}
+
+func (t *memoryTables) DeleteUserMatches(ctx context.Context, userID string) (int64, error) {
+	var deleted int64
+	for pair := range t.matches {
+		if pair.user == userID || pair.other == userID {
+			delete(t.matches, pair)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteUserPopularityScore leaves nothing to delete, as popularity scores are written by the
+// ranking pipeline, which only feeds Postgres: every user scores 0
+func (t *memoryTables) DeleteUserPopularityScore(ctx context.Context, actorUserID string) error {
+	return nil
+}
+
+func (t *memoryTables) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (explorerdb.Decision, error) {
+	d, ok := t.decisions[userPair{arg.ActorUserID, arg.RecipientUserID}]
+	if !ok {
+		return explorerdb.Decision{}, pgx.ErrNoRows
+	}
+	return *d, nil
+}
+
+func (t *memoryTables) GetServiceStats(ctx context.Context) (explorerdb.GetServiceStatsRow, error) {
+	stats := explorerdb.GetServiceStatsRow{
+		Decisions:                int64(len(t.decisions)),
+		Matches:                  int64(len(t.matches)),
+		Blocks:                   int64(len(t.blocks)),
+		PendingOutboxEvents:      int64(len(t.outbox)),
+		DeadLetteredOutboxEvents: int64(len(t.deadLetters)),
+	}
+	for recipient := range t.received {
+		likes, _ := t.CountLikes(ctx, recipient)
+		stats.Likes += likes
+	}
+	return stats, nil
+}
+
+func (t *memoryTables) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+	mutual := t.likes(arg.ActorUserID, arg.RecipientUserID) && t.likes(arg.RecipientUserID, arg.ActorUserID)
+	return &mutual, nil
+}
+
+func (t *memoryTables) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (bool, error) {
+	return t.blocked(arg.BlockerUserID, arg.BlockedUserID), nil
+}
+
+func (t *memoryTables) ListAuditEvents(ctx context.Context, arg explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error) {
+	var events []explorerdb.AuditEvent
+	for i := len(t.auditEvents) - 1; i >= 0 && int32(len(events)) < arg.PageLimit; i-- {
+		event := t.auditEvents[i]
+		occurredAt := event.OccurredAt.Time.Unix()
+		switch {
+		case arg.UserID != nil && !slices.Contains(event.UserIds, *arg.UserID) && event.CallerID != *arg.UserID,
+			arg.Action != nil && event.Action != *arg.Action,
+			arg.Since != nil && occurredAt < *arg.Since,
+			arg.Until != nil && occurredAt >= *arg.Until,
+			arg.BeforeID != nil && event.ID >= *arg.BeforeID:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (t *memoryTables) ListDecidersAsc(ctx context.Context, arg explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error) {
+	rows := t.listDeciders(explorerdb.ListDecidersDescParams(arg), true)
+	return convertDeciders[explorerdb.ListDecidersAscRow](rows), nil
+}
+
+func (t *memoryTables) ListDecidersDesc(ctx context.Context, arg explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error) {
+	return t.listDeciders(arg, false), nil
+}
+
+// listDeciders reads the deciders listing in either order
+func (t *memoryTables) listDeciders(arg explorerdb.ListDecidersDescParams, ascending bool) []explorerdb.ListDecidersDescRow {
+	var rows []explorerdb.ListDecidersDescRow
+	for actor, d := range t.received[arg.RecipientUserID] {
+		if d.LikedRecipient != arg.LikedRecipient || t.blocked(actor, arg.RecipientUserID) ||
+			!inListingWindow(t.now(), d.CreatedAt.Time, arg.MaxAgeSeconds, arg.Since, arg.Until) {
+			continue
+		}
+		row := explorerdb.ListDecidersDescRow{
+			ActorUserID: actor,
+			Timestamp:   listingSecond(d.CreatedAt.Time),
+			LikedBack:   t.likes(arg.RecipientUserID, actor),
+			ID:          d.ID,
+			CreatedAt:   d.CreatedAt,
+		}
+		if !afterKeyset(row.CreatedAt.Time, row.ID, arg.AfterCreatedAt, arg.AfterID, ascending) ||
+			(arg.LikedBack != nil && row.LikedBack != *arg.LikedBack) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	sortByKeyset(rows, ascending, func(row explorerdb.ListDecidersDescRow) (int64, int64) {
+		return row.CreatedAt.Time.UnixMicro(), row.ID
+	})
+	return pageOf(rows, arg.PageLimit, arg.PageOffset)
+}
+
+func (t *memoryTables) ListLikersByPopularity(ctx context.Context, arg explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error) {
+	var rows []explorerdb.ListLikersByPopularityRow
+	for actor, d := range t.received[arg.RecipientUserID] {
+		if !d.LikedRecipient || t.blocked(actor, arg.RecipientUserID) ||
+			!inListingWindow(t.now(), d.CreatedAt.Time, arg.MaxAgeSeconds, arg.Since, arg.Until) {
+			continue
+		}
+		// Every user scores 0, see DeleteUserPopularityScore
+		row := explorerdb.ListLikersByPopularityRow{
+			ActorUserID: actor,
+			Timestamp:   listingSecond(d.CreatedAt.Time),
+			LikedBack:   t.likes(arg.RecipientUserID, actor),
+			ID:          d.ID,
+		}
+		if arg.AfterScore != nil && (row.Score > *arg.AfterScore || (row.Score == *arg.AfterScore && row.ID >= arg.AfterID)) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	slices.SortFunc(rows, func(a, b explorerdb.ListLikersByPopularityRow) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return pageOf(rows, arg.PageLimit, arg.PageOffset), nil
+}
+
+func (t *memoryTables) ListNewLikersAsc(ctx context.Context, arg explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error) {
+	rows := t.listNewLikers(explorerdb.ListNewLikersDescParams(arg), true)
+	return convertNewLikers[explorerdb.ListNewLikersAscRow](rows), nil
+}
+
+func (t *memoryTables) ListNewLikersDesc(ctx context.Context, arg explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error) {
+	return t.listNewLikers(arg, false), nil
+}
+
+// listNewLikers lists the likes whose recipient has not decided on the liker, which the
+// new_likes table holds in Postgres, in either order
+func (t *memoryTables) listNewLikers(arg explorerdb.ListNewLikersDescParams, ascending bool) []explorerdb.ListNewLikersDescRow {
+	var rows []explorerdb.ListNewLikersDescRow
+	for actor, d := range t.received[arg.RecipientUserID] {
+		if _, decidedBack := t.decisions[userPair{arg.RecipientUserID, actor}]; decidedBack || !d.LikedRecipient ||
+			t.blocked(actor, arg.RecipientUserID) || !inListingWindow(t.now(), d.CreatedAt.Time, arg.MaxAgeSeconds, arg.Since, arg.Until) {
+			continue
+		}
+		row := explorerdb.ListNewLikersDescRow{
+			ActorUserID: actor,
+			Timestamp:   listingSecond(d.CreatedAt.Time),
+			ID:          d.ID,
+			CreatedAt:   d.CreatedAt,
+		}
+		if !afterKeyset(row.CreatedAt.Time, row.ID, arg.AfterCreatedAt, arg.AfterID, ascending) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	sortByKeyset(rows, ascending, func(row explorerdb.ListNewLikersDescRow) (int64, int64) {
+		return row.CreatedAt.Time.UnixMicro(), row.ID
+	})
+	return pageOf(rows, arg.PageLimit, arg.PageOffset)
+}
+
+func (t *memoryTables) ListUserDecisions(ctx context.Context, arg explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error) {
+	var rows []explorerdb.ListUserDecisionsRow
+	add := func(d *explorerdb.Decision) {
+		if arg.BeforeID != nil && d.ID >= *arg.BeforeID {
+			return
+		}
+		rows = append(rows, explorerdb.ListUserDecisionsRow{
+			ID:              d.ID,
+			ActorUserID:     d.ActorUserID,
+			RecipientUserID: d.RecipientUserID,
+			LikedRecipient:  d.LikedRecipient,
+			Timestamp:       d.CreatedAt.Time.Unix(),
+		})
+	}
+	for _, d := range t.made[arg.UserID] {
+		add(d)
+	}
+	for actor, d := range t.received[arg.UserID] {
+		if actor != arg.UserID {
+			add(d)
+		}
+	}
+	slices.SortFunc(rows, func(a, b explorerdb.ListUserDecisionsRow) int { return cmp.Compare(b.ID, a.ID) })
+	return pageOf(rows, arg.PageLimit, 0), nil
+}
+
+// LockDecisionPair has nothing to lock, as memoryStore serializes every call
+func (t *memoryTables) LockDecisionPair(ctx context.Context, arg explorerdb.LockDecisionPairParams) error {
+	return nil
+}
+
+func (t *memoryTables) PurgeExpiredLikes(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams) (int64, error) {
+	cutoff := t.now().Add(-time.Duration(arg.MaxAgeSeconds) * time.Second)
+	var expired []*explorerdb.Decision
+	for _, d := range t.decisions {
+		if d.LikedRecipient && d.CreatedAt.Time.Before(cutoff) {
+			expired = append(expired, d)
+		}
+	}
+	slices.SortFunc(expired, func(a, b *explorerdb.Decision) int { return cmp.Compare(a.ID, b.ID) })
+	expired = pageOf(expired, arg.BatchSize, 0)
+
+	for _, d := range expired {
+		t.deleteDecision(d.ActorUserID, d.RecipientUserID)
+	}
+	return int64(len(expired)), nil
+}
+
+// ReconcileLikeCounts has no counts to correct, as like counts are derived from decisions
+func (t *memoryTables) ReconcileLikeCounts(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (t *memoryTables) RetryOutboxEvent(ctx context.Context, arg explorerdb.RetryOutboxEventParams) error {
+	i := t.outboxIndex(arg.ID)
+	if i < 0 {
+		return nil
+	}
+	event := &t.outbox[i]
+	delay := min(time.Duration(math.Pow(2, float64(event.Attempts)))*time.Second, maxOutboxRetryDelay)
+	event.Attempts++
+	event.LastError = &arg.LastError
+	event.NextAttemptAt = timestamptz(t.now().Add(delay))
+	return nil
+}
+
+// UpsertDecisions upserts the decisions at once, and returns their rows through the batch
+// results sqlc reads them from
+func (t *memoryTables) UpsertDecisions(ctx context.Context, arg []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults {
+	rows := make([]explorerdb.UpsertDecisionsRow, len(arg))
+	for i, decision := range arg {
+		created, _ := t.CreateDecision(ctx, explorerdb.CreateDecisionParams(decision))
+		rows[i] = explorerdb.UpsertDecisionsRow{
+			Inserted:   created.Inserted,
+			MutualLike: decision.LikedRecipient && t.likes(decision.RecipientUserID, decision.ActorUserID),
+		}
+	}
+	return explorerdb.New(upsertedDecisions{rows: rows}).UpsertDecisions(ctx, arg)
+}
+
+// upsertedDecisions replays the rows of upserted decisions as the results of the batch sqlc
+// sends for them. A non-nil err is the error of the decision after the rows, failing the batch.
+type upsertedDecisions struct {
+	rows []explorerdb.UpsertDecisionsRow
+	err  error
+}
+
+func (u upsertedDecisions) SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults {
+	return &upsertedDecisionResults{rows: u.rows, err: u.err}
+}
+
+func (u upsertedDecisions) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.ErrUnsupported
+}
+
+func (u upsertedDecisions) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (u upsertedDecisions) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return upsertedDecisionRow{err: errors.ErrUnsupported}
+}
+
+type upsertedDecisionResults struct {
+	rows []explorerdb.UpsertDecisionsRow
+	err  error
+}
+
+func (r *upsertedDecisionResults) Exec() (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.ErrUnsupported
+}
+
+func (r *upsertedDecisionResults) Query() (pgx.Rows, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (r *upsertedDecisionResults) QueryRow() pgx.Row {
+	if len(r.rows) == 0 {
+		if r.err != nil {
+			return upsertedDecisionRow{err: r.err}
+		}
+		return upsertedDecisionRow{err: pgx.ErrNoRows}
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return upsertedDecisionRow{row: row}
+}
+
+func (r *upsertedDecisionResults) Close() error {
+	return nil
+}
+
+type upsertedDecisionRow struct {
+	row explorerdb.UpsertDecisionsRow
+	err error
+}
+
+func (r upsertedDecisionRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*bool) = r.row.Inserted
+	*dest[1].(*bool) = r.row.MutualLike
+	return nil
+}
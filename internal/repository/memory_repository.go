@@ -0,0 +1,515 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// memoryStore is an ExplorerRepository keeping its tables in memory, for unit tests, examples
+// and running the service without Postgres. Every call holds the store's lock while it reads or
+// writes the tables, which serializes calls as the transactions of explorerStore are, so the
+// decisions are recorded, listed and paged exactly as Postgres would. Nothing outlives the process.
+type memoryStore struct {
+	mu     sync.Mutex
+	tables *memoryTables
+	likerListings
+	logger *zap.Logger
+}
+
+// NewMemoryExplorerRepository returns an empty in-memory ExplorerRepository. Like NewExplorerRepository,
+// likeTTL hides likes older than it from likers listings, zero keeping likes forever.
+func NewMemoryExplorerRepository(likeTTL time.Duration, logger *zap.Logger) ExplorerRepository {
+	return newMemoryStore(likeTTL, time.Now, logger)
+}
+
+// NewMemoryAuditRepository returns an AuditRepository keeping the audit trail in the tables of
+// repo, an in-memory ExplorerRepository
+func NewMemoryAuditRepository(repo ExplorerRepository, logger *zap.Logger) AuditRepository {
+	return &auditStore{Querier: repo, logger: logger}
+}
+
+// newMemoryStore returns an empty memoryStore whose clock is now
+func newMemoryStore(likeTTL time.Duration, now func() time.Time, logger *zap.Logger) *memoryStore {
+	s := &memoryStore{tables: newMemoryTables(now), logger: logger}
+	s.likerListings = likerListings{q: s, likeTTL: likeTTL, logger: logger}
+	return s
+}
+
+// CountLikers returns the number of likes the recipient received, left out likes between
+// blocked users and, as in Postgres, likes past the like TTL
+func (s *memoryStore) CountLikers(ctx context.Context, recipientUserID string) (int64, error) {
+	return countLikers(ctx, s, s.maxLikeAge(), recipientUserID)
+}
+
+// GetLikedRecipients returns users the actor has liked with pagination
+func (s *memoryStore) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.Lock()
+	var liked []*explorerdb.Decision
+	for _, d := range s.tables.made[actorUserID] {
+		if d.LikedRecipient && (page.Token == "" || d.CreatedAt.Time.Unix() < cursor.LastCreatedAt) {
+			liked = append(liked, d)
+		}
+	}
+	s.mu.Unlock()
+	sortByKeyset(liked, false, func(d *explorerdb.Decision) (int64, int64) {
+		return d.CreatedAt.Time.Unix(), d.ID
+	})
+
+	var recipients []models.Recipient
+	for _, d := range pageOf(liked, int32(cursor.Limit+1), 0) {
+		recipients = append(recipients, models.Recipient{
+			RecipientID: d.RecipientUserID,
+			Timestamp:   d.CreatedAt.Time.Unix(),
+		})
+	}
+
+	var nextPaginationToken string
+	if len(recipients) > cursor.Limit {
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: recipients[cursor.Limit-1].Timestamp,
+			Limit:         cursor.Limit,
+		}
+		nextPaginationToken, err = nextCursor.Encode()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		recipients = recipients[:cursor.Limit]
+	}
+
+	return recipients, nextPaginationToken, nil
+}
+
+// RecordDecision stores the decision as explorerStore.RecordDecision does
+func (s *memoryStore) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return recordDecision(ctx, s.tables, decision, events)
+}
+
+// RemoveDecision withdraws the decision as explorerStore.RemoveDecision does
+func (s *memoryStore) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return removeDecision(ctx, s.tables, decision)
+}
+
+// CreateDecisions stores all decisions as explorerStore.CreateDecisions does. The tables are
+// only written once every decision is known to succeed, which they always do in memory.
+func (s *memoryStore) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return createDecisions(ctx, s.tables, decisions, events, s.logger)
+}
+
+// GetMatches returns users the user has a mutual like with, most recent match first
+func (s *memoryStore) GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.Lock()
+	var matched []explorerdb.Match
+	for pair, match := range s.tables.matches {
+		if pair.user == userID && (page.Token == "" || match.MatchedAt.Time.Unix() < cursor.LastCreatedAt) {
+			matched = append(matched, match)
+		}
+	}
+	s.mu.Unlock()
+	sortByKeyset(matched, false, func(match explorerdb.Match) (int64, int64) {
+		return match.MatchedAt.Time.Unix(), match.ID
+	})
+
+	var matches []models.Match
+	for _, match := range pageOf(matched, int32(cursor.Limit+1), 0) {
+		matches = append(matches, models.Match{
+			UserID:    match.MatchedUserID,
+			MatchedAt: match.MatchedAt.Time.Unix(),
+		})
+	}
+
+	var nextPaginationToken string
+	if len(matches) > cursor.Limit {
+		nextCursor := &utils.Cursor{
+			LastCreatedAt: matches[cursor.Limit-1].MatchedAt,
+			Limit:         cursor.Limit,
+		}
+		nextPaginationToken, err = nextCursor.Encode()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+		matches = matches[:cursor.Limit]
+	}
+
+	return matches, nextPaginationToken, nil
+}
+
+// IngestDecisions upserts the decisions as explorerStore.IngestDecisions does
+func (s *memoryStore) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ingestDecisions(ctx, s.tables, decisions, s.logger)
+}
+
+// ImportDecisions loads decisions carried over from another system as explorerStore.ImportDecisions
+// does: the latest decision of each pair wins and only replaces a stored decision made before it,
+// matches are created for mutual likes and dated by the like completing them, and no outbox
+// events are written.
+func (s *memoryStore) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error) {
+	summary := models.ImportSummary{Rows: len(decisions)}
+
+	latest := make(map[userPair]models.ImportedDecision, len(decisions))
+	passed := make(map[userPair]bool)
+	var pairs []userPair
+	for _, d := range decisions {
+		pair := userPair{d.ActorUserID, d.RecipientUserID}
+		if !d.LikedRecipient {
+			passed[pair] = true
+		}
+		previous, seen := latest[pair]
+		if !seen {
+			pairs = append(pairs, pair)
+		}
+		if !seen || !d.CreatedAt.Before(previous.CreatedAt) {
+			latest[pair] = d
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.tables
+
+	for _, pair := range pairs {
+		d := latest[pair]
+		stored, ok := t.decisions[pair]
+		switch {
+		case !ok:
+			t.insertDecision(d.ActorUserID, d.RecipientUserID, d.LikedRecipient, d.CreatedAt)
+			summary.Created++
+		case stored.CreatedAt.Time.Before(d.CreatedAt):
+			stored.LikedRecipient = d.LikedRecipient
+			stored.CreatedAt = timestamptz(d.CreatedAt)
+			stored.UpdatedAt = timestamptz(t.now())
+			summary.Updated++
+		}
+	}
+	summary.Skipped = summary.Rows - summary.Created - summary.Updated
+
+	// A pass that won over a stored like ends the match it was part of
+	for pair := range passed {
+		if !t.likes(pair.user, pair.other) {
+			_, _ = t.DeleteMatch(ctx, explorerdb.DeleteMatchParams{UserID: pair.user, MatchedUserID: pair.other})
+		}
+	}
+
+	var created int
+	for _, pair := range pairs {
+		if !t.likes(pair.user, pair.other) || !t.likes(pair.other, pair.user) {
+			continue
+		}
+		matchedAt := t.decisions[pair].CreatedAt.Time
+		if back := t.decisions[userPair{pair.other, pair.user}].CreatedAt.Time; back.After(matchedAt) {
+			matchedAt = back
+		}
+		for _, match := range []userPair{pair, {pair.other, pair.user}} {
+			if t.insertMatch(match.user, match.other, matchedAt) {
+				created++
+			}
+		}
+	}
+	summary.Matches = created / 2
+
+	return summary, nil
+}
+
+// DrainOutbox claims the outbox events due for delivery and hands each to deliver, settling
+// them as explorerStore.DrainOutbox does. The lock is not held while deliver runs, and other
+// drains skip the events claimed until they are settled.
+func (s *memoryStore) DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+	var summary models.DrainSummary
+
+	s.mu.Lock()
+	events, _ := s.tables.ClaimOutboxEvents(ctx, claim)
+	for _, event := range events {
+		s.tables.claimed[event.ID] = true
+	}
+	s.mu.Unlock()
+
+	for _, event := range events {
+		deliverErr := deliver(ctx, event)
+
+		s.mu.Lock()
+		delete(s.tables.claimed, event.ID)
+		switch {
+		case deliverErr == nil:
+			_ = s.tables.DeleteOutboxEvent(ctx, event.ID)
+			summary.Delivered++
+		case event.Attempts+1 >= claim.MaxAttempts:
+			_ = s.tables.DeadLetterOutboxEvent(ctx, explorerdb.DeadLetterOutboxEventParams{ID: event.ID, LastError: deliverErr.Error()})
+			summary.DeadLettered++
+		default:
+			_ = s.tables.RetryOutboxEvent(ctx, explorerdb.RetryOutboxEventParams{LastError: deliverErr.Error(), ID: event.ID})
+			summary.Retried++
+		}
+		s.mu.Unlock()
+
+		if deliverErr != nil {
+			s.logger.Warn("Failed to deliver outbox event",
+				zap.Int64("id", event.ID),
+				zap.String("topic", event.Topic),
+				zap.Int32("attempts", event.Attempts+1),
+				zap.Error(deliverErr))
+		}
+	}
+
+	return summary, nil
+}
+
+// The queries of explorerdb.Querier run on the tables one at a time
+
+func (s *memoryStore) AllowAuditEventErasure(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.AllowAuditEventErasure(ctx)
+}
+
+func (s *memoryStore) AnonymizeUserAuditEvents(ctx context.Context, arg explorerdb.AnonymizeUserAuditEventsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.AnonymizeUserAuditEvents(ctx, arg)
+}
+
+func (s *memoryStore) AnonymizeUserReports(ctx context.Context, arg explorerdb.AnonymizeUserReportsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.AnonymizeUserReports(ctx, arg)
+}
+
+func (s *memoryStore) ClaimOutboxEvents(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ClaimOutboxEvents(ctx, arg)
+}
+
+func (s *memoryStore) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CountLikes(ctx, recipientUserID)
+}
+
+func (s *memoryStore) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CountRecentLikes(ctx, arg)
+}
+
+func (s *memoryStore) CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateAuditEvent(ctx, arg)
+}
+
+func (s *memoryStore) CreateBlock(ctx context.Context, arg explorerdb.CreateBlockParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateBlock(ctx, arg)
+}
+
+func (s *memoryStore) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateDecision(ctx, arg)
+}
+
+func (s *memoryStore) CreateErasureReceipt(ctx context.Context, arg explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateErasureReceipt(ctx, arg)
+}
+
+func (s *memoryStore) CreateMatch(ctx context.Context, arg explorerdb.CreateMatchParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateMatch(ctx, arg)
+}
+
+func (s *memoryStore) CreateOutboxEvent(ctx context.Context, arg explorerdb.CreateOutboxEventParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateOutboxEvent(ctx, arg)
+}
+
+func (s *memoryStore) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (explorerdb.Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.CreateReport(ctx, arg)
+}
+
+func (s *memoryStore) DeadLetterOutboxEvent(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeadLetterOutboxEvent(ctx, arg)
+}
+
+func (s *memoryStore) DeleteBlock(ctx context.Context, arg explorerdb.DeleteBlockParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteBlock(ctx, arg)
+}
+
+func (s *memoryStore) DeleteDecision(ctx context.Context, arg explorerdb.DeleteDecisionParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteDecision(ctx, arg)
+}
+
+func (s *memoryStore) DeleteMatch(ctx context.Context, arg explorerdb.DeleteMatchParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteMatch(ctx, arg)
+}
+
+func (s *memoryStore) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteOutboxEvent(ctx, id)
+}
+
+func (s *memoryStore) DeleteUserBlocks(ctx context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteUserBlocks(ctx, userID)
+}
+
+func (s *memoryStore) DeleteUserDecisions(ctx context.Context, userID string) ([]explorerdb.DeleteUserDecisionsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteUserDecisions(ctx, userID)
+}
+
+func (s *memoryStore) DeleteUserLikeCount(ctx context.Context, recipientUserID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteUserLikeCount(ctx, recipientUserID)
+}
+
+func (s *memoryStore) DeleteUserMatches(ctx context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteUserMatches(ctx, userID)
+}
+
+func (s *memoryStore) DeleteUserPopularityScore(ctx context.Context, actorUserID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.DeleteUserPopularityScore(ctx, actorUserID)
+}
+
+func (s *memoryStore) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (explorerdb.Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.GetDecision(ctx, arg)
+}
+
+func (s *memoryStore) GetServiceStats(ctx context.Context) (explorerdb.GetServiceStatsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.GetServiceStats(ctx)
+}
+
+func (s *memoryStore) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.HasMutualLike(ctx, arg)
+}
+
+func (s *memoryStore) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.IsBlocked(ctx, arg)
+}
+
+func (s *memoryStore) ListAuditEvents(ctx context.Context, arg explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListAuditEvents(ctx, arg)
+}
+
+func (s *memoryStore) ListDecidersAsc(ctx context.Context, arg explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListDecidersAsc(ctx, arg)
+}
+
+func (s *memoryStore) ListDecidersDesc(ctx context.Context, arg explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListDecidersDesc(ctx, arg)
+}
+
+func (s *memoryStore) ListLikersByPopularity(ctx context.Context, arg explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListLikersByPopularity(ctx, arg)
+}
+
+func (s *memoryStore) ListNewLikersAsc(ctx context.Context, arg explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListNewLikersAsc(ctx, arg)
+}
+
+func (s *memoryStore) ListNewLikersDesc(ctx context.Context, arg explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListNewLikersDesc(ctx, arg)
+}
+
+func (s *memoryStore) ListUserDecisions(ctx context.Context, arg explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ListUserDecisions(ctx, arg)
+}
+
+func (s *memoryStore) LockDecisionPair(ctx context.Context, arg explorerdb.LockDecisionPairParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.LockDecisionPair(ctx, arg)
+}
+
+func (s *memoryStore) PurgeExpiredLikes(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.PurgeExpiredLikes(ctx, arg)
+}
+
+func (s *memoryStore) ReconcileLikeCounts(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.ReconcileLikeCounts(ctx)
+}
+
+func (s *memoryStore) RetryOutboxEvent(ctx context.Context, arg explorerdb.RetryOutboxEventParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.RetryOutboxEvent(ctx, arg)
+}
+
+func (s *memoryStore) UpsertDecisions(ctx context.Context, arg []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables.UpsertDecisions(ctx, arg)
+}
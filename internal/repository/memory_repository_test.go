@@ -0,0 +1,311 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+)
+
+type MemoryRepositoryTestSuite struct {
+	suite.Suite
+	repo repository.ExplorerRepository
+	ctx  context.Context
+	// now is when the decisions imported by the tests are made, a minute apart
+	now time.Time
+}
+
+func TestMemoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRepositoryTestSuite))
+}
+
+func (s *MemoryRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = repository.NewMemoryExplorerRepository(0, zaptest.NewLogger(s.T()))
+	s.now = time.Now().Truncate(time.Second)
+}
+
+// importDecisions imports the decisions, the first made the earliest
+func (s *MemoryRepositoryTestSuite) importDecisions(decisions ...models.ImportedDecision) models.ImportSummary {
+	for i := range decisions {
+		if decisions[i].CreatedAt.IsZero() {
+			decisions[i].CreatedAt = s.now.Add(time.Duration(i-len(decisions)) * time.Minute)
+		}
+	}
+	summary, err := s.repo.ImportDecisions(s.ctx, decisions)
+	s.Require().NoError(err)
+	return summary
+}
+
+func like(actor, recipient string) models.ImportedDecision {
+	return models.ImportedDecision{ActorUserID: actor, RecipientUserID: recipient, LikedRecipient: true}
+}
+
+func pass(actor, recipient string) models.ImportedDecision {
+	return models.ImportedDecision{ActorUserID: actor, RecipientUserID: recipient}
+}
+
+func likerIDs(likers []models.Liker) []string {
+	ids := make([]string, len(likers))
+	for i, liker := range likers {
+		ids[i] = liker.ActorID
+	}
+	return ids
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetLikers_WithPagination() {
+	s.importDecisions(like("a", "r"), like("b", "r"), pass("c", "r"), like("d", "r"))
+
+	likers, tokens, err := s.repo.GetLikers(s.ctx, "r", models.PageRequest{Size: 2})
+	s.Require().NoError(err)
+	s.Equal([]string{"d", "b"}, likerIDs(likers))
+	s.NotEmpty(tokens.Next)
+
+	likers, tokens, err = s.repo.GetLikers(s.ctx, "r", models.PageRequest{Token: tokens.Next, Size: 2})
+	s.Require().NoError(err)
+	s.Equal([]string{"a"}, likerIDs(likers))
+	s.Empty(tokens.Next)
+
+	likers, _, err = s.repo.GetLikers(s.ctx, "r", models.PageRequest{Size: 10, Ascending: true})
+	s.Require().NoError(err)
+	s.Equal([]string{"a", "b", "d"}, likerIDs(likers))
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetLikers_LikedBack() {
+	s.importDecisions(like("a", "r"), like("b", "r"), like("r", "a"))
+
+	likers, _, err := s.repo.GetLikers(s.ctx, "r", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "b", Timestamp: s.now.Add(-2 * time.Minute).Unix()},
+		{ActorID: "a", Timestamp: s.now.Add(-3 * time.Minute).Unix(), LikedBack: true},
+	}, likers)
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetLikers_LeavesOutBlocked() {
+	s.importDecisions(like("a", "r"), like("b", "r"))
+	s.Require().NoError(s.repo.CreateBlock(s.ctx, explorerdb.CreateBlockParams{BlockerUserID: "r", BlockedUserID: "b"}))
+
+	likers, _, err := s.repo.GetLikers(s.ctx, "r", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]string{"a"}, likerIDs(likers))
+
+	count, err := s.repo.CountLikers(s.ctx, "r")
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func (s *MemoryRepositoryTestSuite) TestCountLikers_LeavesOutExpired() {
+	s.repo = repository.NewMemoryExplorerRepository(150*time.Second, zaptest.NewLogger(s.T()))
+	s.importDecisions(like("a", "r"), like("b", "r"), like("c", "r"))
+
+	likers, _, err := s.repo.GetLikers(s.ctx, "r", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]string{"c", "b"}, likerIDs(likers))
+
+	count, err := s.repo.CountLikers(s.ctx, "r")
+	s.Require().NoError(err)
+	s.Equal(int64(2), count)
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetLikers_FlippedLikeListedOnce() {
+	s.importDecisions(like("b", "r"), like("c", "r"))
+	for _, liked := range []bool{true, false, true} {
+		_, err := s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{ActorUserID: "a", RecipientUserID: "r", LikedRecipient: liked}, models.DecisionEvents{})
+		s.Require().NoError(err)
+	}
+
+	// Paging one liker at a time crosses a page boundary after each actor
+	var listed []string
+	page := models.PageRequest{Size: 1}
+	for {
+		likers, tokens, err := s.repo.GetLikers(s.ctx, "r", page)
+		s.Require().NoError(err)
+		listed = append(listed, likerIDs(likers)...)
+		if tokens.Next == "" {
+			break
+		}
+		page.Token = tokens.Next
+	}
+	s.Equal([]string{"a", "c", "b"}, listed)
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetPassers() {
+	s.importDecisions(pass("a", "r"), like("b", "r"), pass("c", "r"))
+
+	passers, _, err := s.repo.GetPassers(s.ctx, "r", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]string{"c", "a"}, likerIDs(passers))
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetNewLikers_LeavesOutDecided() {
+	s.importDecisions(like("a", "r"), like("b", "r"), like("c", "r"), pass("r", "a"), like("r", "c"))
+
+	likers, _, err := s.repo.GetNewLikers(s.ctx, "r", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]string{"b"}, likerIDs(likers))
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetLikedRecipients_WithPagination() {
+	s.importDecisions(like("a", "x"), pass("a", "y"), like("a", "z"))
+
+	recipients, token, err := s.repo.GetLikedRecipients(s.ctx, "a", models.PageRequest{Size: 1})
+	s.Require().NoError(err)
+	s.Equal([]models.Recipient{{RecipientID: "z", Timestamp: s.now.Add(-time.Minute).Unix()}}, recipients)
+	s.NotEmpty(token)
+
+	recipients, token, err = s.repo.GetLikedRecipients(s.ctx, "a", models.PageRequest{Token: token})
+	s.Require().NoError(err)
+	s.Equal([]models.Recipient{{RecipientID: "x", Timestamp: s.now.Add(-3 * time.Minute).Unix()}}, recipients)
+	s.Empty(token)
+}
+
+func (s *MemoryRepositoryTestSuite) TestRecordDecision() {
+	recorded, err := s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{ActorUserID: "a", RecipientUserID: "b", LikedRecipient: true}, models.DecisionEvents{})
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now(), time.Unix(recorded.Timestamp, 0), time.Second)
+	s.Equal(models.RecordedDecision{Created: true, LikesDelta: 1, Timestamp: recorded.Timestamp}, recorded)
+
+	recorded, err = s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{ActorUserID: "b", RecipientUserID: "a", LikedRecipient: true}, models.DecisionEvents{})
+	s.Require().NoError(err)
+	s.Equal(models.RecordedDecision{Created: true, LikesDelta: 1, MutualLikes: true, Timestamp: recorded.Timestamp}, recorded)
+	matches, _, err := s.repo.GetMatches(s.ctx, "a", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Len(matches, 1)
+
+	recorded, err = s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{ActorUserID: "a", RecipientUserID: "b"}, models.DecisionEvents{})
+	s.Require().NoError(err)
+	s.Equal(models.RecordedDecision{LikedBefore: true, LikesDelta: -1, MatchEnded: true, Timestamp: recorded.Timestamp}, recorded)
+
+	decision, err := s.repo.GetDecision(s.ctx, explorerdb.GetDecisionParams{ActorUserID: "a", RecipientUserID: "b"})
+	s.Require().NoError(err)
+	s.False(decision.LikedRecipient)
+	matches, _, err = s.repo.GetMatches(s.ctx, "a", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Empty(matches)
+}
+
+func (s *MemoryRepositoryTestSuite) TestRemoveDecision() {
+	s.importDecisions(like("a", "b"), like("b", "a"))
+
+	removed, err := s.repo.RemoveDecision(s.ctx, explorerdb.DeleteDecisionParams{ActorUserID: "b", RecipientUserID: "a"})
+	s.Require().NoError(err)
+	s.Equal(models.RemovedDecision{Deleted: true, WasMutualLike: true, MatchEnded: true}, removed)
+	matches, _, err := s.repo.GetMatches(s.ctx, "a", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Empty(matches)
+
+	removed, err = s.repo.RemoveDecision(s.ctx, explorerdb.DeleteDecisionParams{ActorUserID: "b", RecipientUserID: "a"})
+	s.Require().NoError(err)
+	s.Equal(models.RemovedDecision{}, removed)
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetDecision_NotFound() {
+	_, err := s.repo.GetDecision(s.ctx, explorerdb.GetDecisionParams{ActorUserID: "a", RecipientUserID: "b"})
+	s.True(errors.Is(err, pgx.ErrNoRows))
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetMatches_WithPagination() {
+	// Matches are dated by the like completing them
+	s.importDecisions(like("a", "b"), like("b", "a"), like("c", "a"), like("a", "c"), like("d", "a"), like("a", "d"))
+
+	matches, token, err := s.repo.GetMatches(s.ctx, "a", models.PageRequest{Size: 2})
+	s.Require().NoError(err)
+	s.Equal([]models.Match{
+		{UserID: "d", MatchedAt: s.now.Add(-time.Minute).Unix()},
+		{UserID: "c", MatchedAt: s.now.Add(-3 * time.Minute).Unix()},
+	}, matches)
+	s.NotEmpty(token)
+
+	matches, token, err = s.repo.GetMatches(s.ctx, "a", models.PageRequest{Token: token, Size: 2})
+	s.Require().NoError(err)
+	s.Equal([]models.Match{{UserID: "b", MatchedAt: s.now.Add(-5 * time.Minute).Unix()}}, matches)
+	s.Empty(token)
+
+	matches, _, err = s.repo.GetMatches(s.ctx, "b", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]models.Match{{UserID: "a", MatchedAt: s.now.Add(-5 * time.Minute).Unix()}}, matches)
+
+	s.Require().NoError(s.repo.CreateMatch(s.ctx, explorerdb.CreateMatchParams{UserID: "b", MatchedUserID: "e"}))
+	matches, _, err = s.repo.GetMatches(s.ctx, "e", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal("b", matches[0].UserID)
+}
+
+func (s *MemoryRepositoryTestSuite) TestImportDecisions() {
+	summary := s.importDecisions(like("a", "b"), pass("a", "b"), like("b", "a"), like("a", "c"), like("c", "a"))
+	s.Equal(models.ImportSummary{Rows: 5, Created: 4, Skipped: 1, Matches: 1}, summary)
+
+	matches, _, err := s.repo.GetMatches(s.ctx, "a", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Equal([]models.Match{{UserID: "c", MatchedAt: s.now.Add(-time.Minute).Unix()}}, matches)
+
+	// Decisions made before the stored ones are skipped, later ones replace them
+	summary = s.importDecisions(
+		models.ImportedDecision{ActorUserID: "a", RecipientUserID: "b", LikedRecipient: true, CreatedAt: s.now.Add(-time.Hour)},
+		models.ImportedDecision{ActorUserID: "a", RecipientUserID: "c", CreatedAt: s.now},
+	)
+	s.Equal(models.ImportSummary{Rows: 2, Updated: 1, Skipped: 1}, summary)
+
+	matches, _, err = s.repo.GetMatches(s.ctx, "a", models.PageRequest{})
+	s.Require().NoError(err)
+	s.Empty(matches)
+}
+
+func (s *MemoryRepositoryTestSuite) TestDrainOutbox() {
+	events := models.DecisionEvents{NewLike: &models.OutboxEvent{Topic: "new_like", Payload: []byte(`{}`)}}
+	_, err := s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{ActorUserID: "a", RecipientUserID: "b", LikedRecipient: true}, events)
+	s.Require().NoError(err)
+	_, err = s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{ActorUserID: "c", RecipientUserID: "b", LikedRecipient: true}, events)
+	s.Require().NoError(err)
+
+	var delivered []explorerdb.Outbox
+	summary, err := s.repo.DrainOutbox(s.ctx, explorerdb.ClaimOutboxEventsParams{MaxAttempts: 1, BatchSize: 10}, func(ctx context.Context, event explorerdb.Outbox) error {
+		delivered = append(delivered, event)
+		if len(delivered) == 2 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal(models.DrainSummary{Delivered: 1, DeadLettered: 1}, summary)
+	s.Len(delivered, 2)
+	s.Less(delivered[0].ID, delivered[1].ID)
+
+	summary, err = s.repo.DrainOutbox(s.ctx, explorerdb.ClaimOutboxEventsParams{MaxAttempts: 1, BatchSize: 10}, func(ctx context.Context, event explorerdb.Outbox) error {
+		s.Fail("no event is left to deliver")
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal(models.DrainSummary{}, summary)
+
+	stats, err := s.repo.GetServiceStats(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(0), stats.PendingOutboxEvents)
+	s.Equal(int64(1), stats.DeadLetteredOutboxEvents)
+}
+
+func (s *MemoryRepositoryTestSuite) TestPurgeExpiredLikes() {
+	s.importDecisions(
+		models.ImportedDecision{ActorUserID: "a", RecipientUserID: "r", LikedRecipient: true, CreatedAt: s.now.Add(-48 * time.Hour)},
+		models.ImportedDecision{ActorUserID: "b", RecipientUserID: "r", CreatedAt: s.now.Add(-48 * time.Hour)},
+		like("c", "r"),
+	)
+
+	purged, err := s.repo.PurgeExpiredLikes(s.ctx, explorerdb.PurgeExpiredLikesParams{MaxAgeSeconds: int64((24 * time.Hour).Seconds()), BatchSize: 10})
+	s.Require().NoError(err)
+	s.Equal(int64(1), purged)
+
+	count, err := s.repo.CountLikes(s.ctx, "r")
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+}
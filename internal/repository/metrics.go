@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+)
+
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "explore_repository_query_duration_seconds",
+		Help:    "Duration of repository calls, by query.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"query"})
+	queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "explore_repository_query_errors_total",
+		Help: "Repository calls that failed, by query. A missing row is not counted as a failure.",
+	}, []string{"query"})
+)
+
+// observeQuery records the duration of the call to query started at started, and counts it as
+// failed when *err is set. It is deferred with a pointer to the call's error result.
+func observeQuery(query string, started time.Time, err *error) {
+	queryDuration.WithLabelValues(query).Observe(time.Since(started).Seconds())
+	if *err != nil && !errors.Is(*err, pgx.ErrNoRows) {
+		queryErrors.WithLabelValues(query).Inc()
+	}
+}
+
+// instrumentedExplorerRepository records the duration and failures of every repository call.
+// Wrapping the store directly, beneath any retries, it times each attempt against the DB on its
+// own. UpsertDecisions is left out: its batch runs as the results are read, after it returns.
+type instrumentedExplorerRepository struct {
+	ExplorerRepository
+}
+
+// NewInstrumentedExplorerRepository exports the latency and errors of repo's calls to Prometheus
+func NewInstrumentedExplorerRepository(repo ExplorerRepository) ExplorerRepository {
+	return &instrumentedExplorerRepository{ExplorerRepository: repo}
+}
+
+func (r *instrumentedExplorerRepository) GetLikers(ctx context.Context, recipientUserID string, page models.PageRequest) (_ []models.Liker, _ models.PageTokens, err error) {
+	defer observeQuery("GetLikers", time.Now(), &err)
+	return r.ExplorerRepository.GetLikers(ctx, recipientUserID, page)
+}
+
+func (r *instrumentedExplorerRepository) CountLikers(ctx context.Context, recipientUserID string) (_ int64, err error) {
+	defer observeQuery("CountLikers", time.Now(), &err)
+	return r.ExplorerRepository.CountLikers(ctx, recipientUserID)
+}
+
+func (r *instrumentedExplorerRepository) GetNewLikers(ctx context.Context, recipientUserID string, page models.PageRequest) (_ []models.Liker, _ models.PageTokens, err error) {
+	defer observeQuery("GetNewLikers", time.Now(), &err)
+	return r.ExplorerRepository.GetNewLikers(ctx, recipientUserID, page)
+}
+
+func (r *instrumentedExplorerRepository) GetPassers(ctx context.Context, recipientUserID string, page models.PageRequest) (_ []models.Liker, _ models.PageTokens, err error) {
+	defer observeQuery("GetPassers", time.Now(), &err)
+	return r.ExplorerRepository.GetPassers(ctx, recipientUserID, page)
+}
+
+func (r *instrumentedExplorerRepository) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) (_ []models.Recipient, _ string, err error) {
+	defer observeQuery("GetLikedRecipients", time.Now(), &err)
+	return r.ExplorerRepository.GetLikedRecipients(ctx, actorUserID, page)
+}
+
+func (r *instrumentedExplorerRepository) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (_ models.RecordedDecision, err error) {
+	defer observeQuery("RecordDecision", time.Now(), &err)
+	return r.ExplorerRepository.RecordDecision(ctx, decision, events)
+}
+
+func (r *instrumentedExplorerRepository) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (_ models.RemovedDecision, err error) {
+	defer observeQuery("RemoveDecision", time.Now(), &err)
+	return r.ExplorerRepository.RemoveDecision(ctx, decision)
+}
+
+func (r *instrumentedExplorerRepository) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) (_ []models.DecisionResult, err error) {
+	defer observeQuery("CreateDecisions", time.Now(), &err)
+	return r.ExplorerRepository.CreateDecisions(ctx, decisions, events)
+}
+
+func (r *instrumentedExplorerRepository) GetMatches(ctx context.Context, userID string, page models.PageRequest) (_ []models.Match, _ string, err error) {
+	defer observeQuery("GetMatches", time.Now(), &err)
+	return r.ExplorerRepository.GetMatches(ctx, userID, page)
+}
+
+func (r *instrumentedExplorerRepository) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (_ models.IngestSummary, err error) {
+	defer observeQuery("IngestDecisions", time.Now(), &err)
+	return r.ExplorerRepository.IngestDecisions(ctx, decisions)
+}
+
+func (r *instrumentedExplorerRepository) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (_ models.ImportSummary, err error) {
+	defer observeQuery("ImportDecisions", time.Now(), &err)
+	return r.ExplorerRepository.ImportDecisions(ctx, decisions)
+}
+
+// DrainOutbox is timed including the deliveries it makes
+func (r *instrumentedExplorerRepository) DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (_ models.DrainSummary, err error) {
+	defer observeQuery("DrainOutbox", time.Now(), &err)
+	return r.ExplorerRepository.DrainOutbox(ctx, claim, deliver)
+}
+
+func (r *instrumentedExplorerRepository) ClaimOutboxEvents(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams) (_ []explorerdb.Outbox, err error) {
+	defer observeQuery("ClaimOutboxEvents", time.Now(), &err)
+	return r.ExplorerRepository.ClaimOutboxEvents(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) CountLikes(ctx context.Context, recipientUserID string) (_ int64, err error) {
+	defer observeQuery("CountLikes", time.Now(), &err)
+	return r.ExplorerRepository.CountLikes(ctx, recipientUserID)
+}
+
+func (r *instrumentedExplorerRepository) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (_ int64, err error) {
+	defer observeQuery("CountRecentLikes", time.Now(), &err)
+	return r.ExplorerRepository.CountRecentLikes(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) CreateBlock(ctx context.Context, arg explorerdb.CreateBlockParams) (err error) {
+	defer observeQuery("CreateBlock", time.Now(), &err)
+	return r.ExplorerRepository.CreateBlock(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) (_ explorerdb.CreateDecisionRow, err error) {
+	defer observeQuery("CreateDecision", time.Now(), &err)
+	return r.ExplorerRepository.CreateDecision(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) CreateMatch(ctx context.Context, arg explorerdb.CreateMatchParams) (err error) {
+	defer observeQuery("CreateMatch", time.Now(), &err)
+	return r.ExplorerRepository.CreateMatch(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) CreateOutboxEvent(ctx context.Context, arg explorerdb.CreateOutboxEventParams) (err error) {
+	defer observeQuery("CreateOutboxEvent", time.Now(), &err)
+	return r.ExplorerRepository.CreateOutboxEvent(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (_ explorerdb.Report, err error) {
+	defer observeQuery("CreateReport", time.Now(), &err)
+	return r.ExplorerRepository.CreateReport(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) DeadLetterOutboxEvent(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams) (err error) {
+	defer observeQuery("DeadLetterOutboxEvent", time.Now(), &err)
+	return r.ExplorerRepository.DeadLetterOutboxEvent(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) DeleteBlock(ctx context.Context, arg explorerdb.DeleteBlockParams) (_ int64, err error) {
+	defer observeQuery("DeleteBlock", time.Now(), &err)
+	return r.ExplorerRepository.DeleteBlock(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) DeleteDecision(ctx context.Context, arg explorerdb.DeleteDecisionParams) (_ int64, err error) {
+	defer observeQuery("DeleteDecision", time.Now(), &err)
+	return r.ExplorerRepository.DeleteDecision(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) DeleteMatch(ctx context.Context, arg explorerdb.DeleteMatchParams) (_ int64, err error) {
+	defer observeQuery("DeleteMatch", time.Now(), &err)
+	return r.ExplorerRepository.DeleteMatch(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) DeleteOutboxEvent(ctx context.Context, id int64) (err error) {
+	defer observeQuery("DeleteOutboxEvent", time.Now(), &err)
+	return r.ExplorerRepository.DeleteOutboxEvent(ctx, id)
+}
+
+func (r *instrumentedExplorerRepository) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (_ explorerdb.Decision, err error) {
+	defer observeQuery("GetDecision", time.Now(), &err)
+	return r.ExplorerRepository.GetDecision(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (_ *bool, err error) {
+	defer observeQuery("HasMutualLike", time.Now(), &err)
+	return r.ExplorerRepository.HasMutualLike(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (_ bool, err error) {
+	defer observeQuery("IsBlocked", time.Now(), &err)
+	return r.ExplorerRepository.IsBlocked(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) ListDecidersAsc(ctx context.Context, arg explorerdb.ListDecidersAscParams) (_ []explorerdb.ListDecidersAscRow, err error) {
+	defer observeQuery("ListDecidersAsc", time.Now(), &err)
+	return r.ExplorerRepository.ListDecidersAsc(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) ListDecidersDesc(ctx context.Context, arg explorerdb.ListDecidersDescParams) (_ []explorerdb.ListDecidersDescRow, err error) {
+	defer observeQuery("ListDecidersDesc", time.Now(), &err)
+	return r.ExplorerRepository.ListDecidersDesc(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) ListLikersByPopularity(ctx context.Context, arg explorerdb.ListLikersByPopularityParams) (_ []explorerdb.ListLikersByPopularityRow, err error) {
+	defer observeQuery("ListLikersByPopularity", time.Now(), &err)
+	return r.ExplorerRepository.ListLikersByPopularity(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) ListNewLikersAsc(ctx context.Context, arg explorerdb.ListNewLikersAscParams) (_ []explorerdb.ListNewLikersAscRow, err error) {
+	defer observeQuery("ListNewLikersAsc", time.Now(), &err)
+	return r.ExplorerRepository.ListNewLikersAsc(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) ListNewLikersDesc(ctx context.Context, arg explorerdb.ListNewLikersDescParams) (_ []explorerdb.ListNewLikersDescRow, err error) {
+	defer observeQuery("ListNewLikersDesc", time.Now(), &err)
+	return r.ExplorerRepository.ListNewLikersDesc(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) LockDecisionPair(ctx context.Context, arg explorerdb.LockDecisionPairParams) (err error) {
+	defer observeQuery("LockDecisionPair", time.Now(), &err)
+	return r.ExplorerRepository.LockDecisionPair(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) PurgeExpiredLikes(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams) (_ int64, err error) {
+	defer observeQuery("PurgeExpiredLikes", time.Now(), &err)
+	return r.ExplorerRepository.PurgeExpiredLikes(ctx, arg)
+}
+
+func (r *instrumentedExplorerRepository) ReconcileLikeCounts(ctx context.Context) (_ int64, err error) {
+	defer observeQuery("ReconcileLikeCounts", time.Now(), &err)
+	return r.ExplorerRepository.ReconcileLikeCounts(ctx)
+}
+
+func (r *instrumentedExplorerRepository) RetryOutboxEvent(ctx context.Context, arg explorerdb.RetryOutboxEventParams) (err error) {
+	defer observeQuery("RetryOutboxEvent", time.Now(), &err)
+	return r.ExplorerRepository.RetryOutboxEvent(ctx, arg)
+}
+
+// instrumentedReportRepository records the duration and failures of every report repository call
+type instrumentedReportRepository struct {
+	ReportRepository
+}
+
+// NewInstrumentedReportRepository exports the latency and errors of repo's calls to Prometheus
+func NewInstrumentedReportRepository(repo ReportRepository) ReportRepository {
+	return &instrumentedReportRepository{ReportRepository: repo}
+}
+
+func (r *instrumentedReportRepository) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (_ explorerdb.Report, err error) {
+	defer observeQuery("CreateReport", time.Now(), &err)
+	return r.ReportRepository.CreateReport(ctx, arg)
+}
+
+// instrumentedAuditRepository records the duration and failures of every audit repository call
+type instrumentedAuditRepository struct {
+	AuditRepository
+}
+
+// NewInstrumentedAuditRepository exports the latency and errors of repo's calls to Prometheus
+func NewInstrumentedAuditRepository(repo AuditRepository) AuditRepository {
+	return &instrumentedAuditRepository{AuditRepository: repo}
+}
+
+func (r *instrumentedAuditRepository) CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) (err error) {
+	defer observeQuery("CreateAuditEvent", time.Now(), &err)
+	return r.AuditRepository.CreateAuditEvent(ctx, arg)
+}
+
+func (r *instrumentedAuditRepository) ListAuditEvents(ctx context.Context, filter models.AuditFilter, page models.PageRequest) (_ []models.AuditEvent, _ string, err error) {
+	defer observeQuery("ListAuditEvents", time.Now(), &err)
+	return r.AuditRepository.ListAuditEvents(ctx, filter, page)
+}
+
+// instrumentedAdminRepository records the duration and failures of every admin repository call
+type instrumentedAdminRepository struct {
+	AdminRepository
+}
+
+// NewInstrumentedAdminRepository exports the latency and errors of repo's calls to Prometheus
+func NewInstrumentedAdminRepository(repo AdminRepository) AdminRepository {
+	return &instrumentedAdminRepository{AdminRepository: repo}
+}
+
+func (r *instrumentedAdminRepository) ListUserDecisions(ctx context.Context, userID string, page models.PageRequest) (_ []models.UserDecision, _ string, err error) {
+	defer observeQuery("ListUserDecisions", time.Now(), &err)
+	return r.AdminRepository.ListUserDecisions(ctx, userID, page)
+}
+
+func (r *instrumentedAdminRepository) StreamUserDecisions(ctx context.Context, userID string, fn func(models.UserDecision) error) (err error) {
+	defer observeQuery("StreamUserDecisions", time.Now(), &err)
+	return r.AdminRepository.StreamUserDecisions(ctx, userID, fn)
+}
+
+func (r *instrumentedAdminRepository) PurgeUserData(ctx context.Context, userID string) (_ models.PurgeSummary, err error) {
+	defer observeQuery("PurgeUserData", time.Now(), &err)
+	return r.AdminRepository.PurgeUserData(ctx, userID)
+}
+
+func (r *instrumentedAdminRepository) EraseUserData(ctx context.Context, userID, callerID, requestID string) (_ models.ErasureReceipt, err error) {
+	defer observeQuery("EraseUserData", time.Now(), &err)
+	return r.AdminRepository.EraseUserData(ctx, userID, callerID, requestID)
+}
+
+func (r *instrumentedAdminRepository) GetServiceStats(ctx context.Context) (_ models.ServiceStats, err error) {
+	defer observeQuery("GetServiceStats", time.Now(), &err)
+	return r.AdminRepository.GetServiceStats(ctx)
+}
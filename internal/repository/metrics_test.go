@@ -0,0 +1,115 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/repository"
+)
+
+const isBlockedSQL = `SELECT EXISTS\( SELECT 1 FROM blocks`
+
+type MetricsTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo repository.ExplorerRepository
+	ctx  context.Context
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	var err error
+	s.mock, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+
+	s.repo = repository.NewInstrumentedExplorerRepository(repository.NewExplorerRepository(s.mock, 0, zaptest.NewLogger(s.T())))
+}
+
+func (s *MetricsTestSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+// metrics returns the durations recorded for query and the calls to it counted as failed
+func (s *MetricsTestSuite) metrics(query string) (uint64, float64) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	s.Require().NoError(err)
+
+	var (
+		observations uint64
+		failures     float64
+	)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if len(metric.GetLabel()) != 1 || metric.GetLabel()[0].GetValue() != query {
+				continue
+			}
+			switch family.GetName() {
+			case "explore_repository_query_duration_seconds":
+				observations = metric.GetHistogram().GetSampleCount()
+			case "explore_repository_query_errors_total":
+				failures = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return observations, failures
+}
+
+func (s *MetricsTestSuite) TestRecordsDuration() {
+	observations, failures := s.metrics("IsBlocked")
+
+	s.mock.ExpectQuery(isBlockedSQL).
+		WithArgs("user1", "user2").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+
+	_, err := s.repo.IsBlocked(s.ctx, explorerdb.IsBlockedParams{BlockerUserID: "user1", BlockedUserID: "user2"})
+
+	s.NoError(err)
+	gotObservations, gotFailures := s.metrics("IsBlocked")
+	s.Equal(observations+1, gotObservations)
+	s.Equal(failures, gotFailures)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *MetricsTestSuite) TestCountsFailure() {
+	observations, failures := s.metrics("IsBlocked")
+
+	s.mock.ExpectQuery(isBlockedSQL).
+		WithArgs("user1", "user2").
+		WillReturnError(errors.New("database connection failed"))
+
+	_, err := s.repo.IsBlocked(s.ctx, explorerdb.IsBlockedParams{BlockerUserID: "user1", BlockedUserID: "user2"})
+
+	s.Error(err)
+	gotObservations, gotFailures := s.metrics("IsBlocked")
+	s.Equal(observations+1, gotObservations)
+	s.Equal(failures+1, gotFailures)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *MetricsTestSuite) TestMissingRowIsNotAFailure() {
+	_, failures := s.metrics("GetDecision")
+
+	s.mock.ExpectQuery(`SELECT .* FROM decisions WHERE actor_user_id = \$1 AND recipient_user_id = \$2`).
+		WithArgs("actor1", "recipient1").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err := s.repo.GetDecision(s.ctx, explorerdb.GetDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1"})
+
+	s.ErrorIs(err, pgx.ErrNoRows)
+	_, gotFailures := s.metrics("GetDecision")
+	s.Equal(failures, gotFailures)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,761 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+)
+
+// mysqlDB runs the statements of mysqlQueries, on the pool or in a transaction
+type mysqlDB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// mysqlQueries runs the queries of explorerdb.Querier on the MySQL schema of db/migrations/mysql,
+// with the semantics of their Postgres SQL. The rows the Postgres triggers maintain, like counts
+// and new likes, are maintained by the MySQL triggers the same way. Queries Postgres runs as one
+// statement and MySQL cannot, such as a DELETE returning the deleted rows, run in a transaction.
+type mysqlQueries struct {
+	db mysqlDB
+}
+
+var _ explorerdb.Querier = (*mysqlQueries)(nil)
+
+// inTx calls fn with queries running in a transaction, committed if fn succeeds. Queries
+// already running in a transaction keep running in it.
+func (q *mysqlQueries) inTx(ctx context.Context, fn func(*mysqlQueries) error) error {
+	db, ok := q.db.(*sql.DB)
+	if !ok {
+		return fn(q)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(&mysqlQueries{db: tx}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// execRows runs the statement and returns the number of rows it changed
+func (q *mysqlQueries) execRows(ctx context.Context, query string, args ...any) (int64, error) {
+	result, err := q.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// queryAll runs the query and reads every row into a T, whose fields dest lists in column order
+func queryAll[T any](ctx context.Context, db mysqlDB, query squirrel.Sqlizer, dest func(*T) []any) ([]T, error) {
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var items []T
+	for rows.Next() {
+		var i T
+		if err := rows.Scan(dest(&i)...); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// noRows reports a missing row as pgx does, which is what callers of explorerdb.Querier check for
+func noRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+const (
+	// mysqlNotBlocked leaves out the decisions of d between users who blocked one another
+	mysqlNotBlocked = `NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+)`
+
+	// mysqlLikedBack tells whether the recipient of d liked its actor in return
+	mysqlLikedBack = `EXISTS(
+    SELECT 1 FROM decisions back
+    WHERE back.actor_user_id = d.recipient_user_id AND back.recipient_user_id = d.actor_user_id AND back.liked_recipient = true
+)`
+
+	// mysqlActualLikes counts the likes each recipient received, left out likes between blocked users
+	mysqlActualLikes = `SELECT d.recipient_user_id, COUNT(*) AS count
+FROM decisions d
+WHERE d.liked_recipient = true AND ` + mysqlNotBlocked + `
+GROUP BY d.recipient_user_id`
+)
+
+// keysetAfter restricts a listing to the rows after the keyset of the previous page, in the
+// listing's order. The creation time column is compared as is, so that its index seeks to it.
+func keysetAfter(createdAt, id string, after pgtype.Timestamptz, afterID int64, ascending bool) squirrel.Sqlizer {
+	if ascending {
+		return squirrel.Expr("("+createdAt+", "+id+") > (?, ?)", after.Time.UTC(), afterID)
+	}
+	return squirrel.Expr("("+createdAt+", "+id+") < (?, ?)", after.Time.UTC(), afterID)
+}
+
+// keysetOrder orders a listing by creation time and then id, oldest first when ascending
+func keysetOrder(createdAt, id string, ascending bool) []string {
+	if ascending {
+		return []string{createdAt + " ASC", id + " ASC"}
+	}
+	return []string{createdAt + " DESC", id + " DESC"}
+}
+
+func (q *mysqlQueries) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	var count int64
+	err := q.db.QueryRowContext(ctx, `SELECT COALESCE((
+    SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = ?
+), 0)`, recipientUserID).Scan(&count)
+	return count, err
+}
+
+func (q *mysqlQueries) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (int64, error) {
+	var count int64
+	err := q.db.QueryRowContext(ctx, `SELECT COUNT(*)
+FROM decisions d
+WHERE d.recipient_user_id = ? AND d.liked_recipient = true
+  AND NOT EXISTS(
+    SELECT 1 FROM blocks b
+    WHERE (b.blocker_user_id = d.recipient_user_id AND b.blocked_user_id = d.actor_user_id)
+       OR (b.blocker_user_id = d.actor_user_id AND b.blocked_user_id = d.recipient_user_id)
+)
+  AND d.created_at >= NOW(6) - INTERVAL ? SECOND`, arg.RecipientUserID, arg.MaxAgeSeconds).Scan(&count)
+	return count, err
+}
+
+func (q *mysqlQueries) CreateBlock(ctx context.Context, arg explorerdb.CreateBlockParams) error {
+	_, err := q.db.ExecContext(ctx, `INSERT INTO blocks (blocker_user_id, blocked_user_id, created_at)
+VALUES (?, ?, NOW(6))
+ON DUPLICATE KEY UPDATE id = id`, arg.BlockerUserID, arg.BlockedUserID)
+	return err
+}
+
+// CreateDecision locks the previous decision of the pair, if any, before replacing it, as the
+// Postgres query does in one statement
+func (q *mysqlQueries) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error) {
+	var created explorerdb.CreateDecisionRow
+	err := q.inTx(ctx, func(q *mysqlQueries) error {
+		err := q.db.QueryRowContext(ctx, `SELECT liked_recipient FROM decisions
+WHERE actor_user_id = ? AND recipient_user_id = ?
+FOR UPDATE`, arg.ActorUserID, arg.RecipientUserID).Scan(&created.PreviousLikedRecipient)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			created.Inserted = true
+		case err != nil:
+			return err
+		}
+
+		_, err = q.db.ExecContext(ctx, `INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+VALUES (?, ?, ?, NOW(6), NOW(6))
+ON DUPLICATE KEY UPDATE
+    liked_recipient = VALUES(liked_recipient),
+    created_at = NOW(6),
+    updated_at = NOW(6)`, arg.ActorUserID, arg.RecipientUserID, arg.LikedRecipient)
+		if err != nil {
+			return err
+		}
+
+		// MySQL has no RETURNING, so the time the decision was made at is read back
+		return q.db.QueryRowContext(ctx, `SELECT created_at FROM decisions
+WHERE actor_user_id = ? AND recipient_user_id = ?`, arg.ActorUserID, arg.RecipientUserID).Scan(&created.CreatedAt)
+	})
+	if err != nil {
+		return explorerdb.CreateDecisionRow{}, err
+	}
+	return created, nil
+}
+
+func (q *mysqlQueries) CreateMatch(ctx context.Context, arg explorerdb.CreateMatchParams) error {
+	_, err := q.db.ExecContext(ctx, `INSERT INTO matches (user_id, matched_user_id, matched_at)
+VALUES (?, ?, NOW(6)), (?, ?, NOW(6))
+ON DUPLICATE KEY UPDATE id = id`, arg.UserID, arg.MatchedUserID, arg.MatchedUserID, arg.UserID)
+	return err
+}
+
+func (q *mysqlQueries) DeleteBlock(ctx context.Context, arg explorerdb.DeleteBlockParams) (int64, error) {
+	return q.execRows(ctx, `DELETE FROM blocks
+WHERE blocker_user_id = ? AND blocked_user_id = ?`, arg.BlockerUserID, arg.BlockedUserID)
+}
+
+func (q *mysqlQueries) DeleteDecision(ctx context.Context, arg explorerdb.DeleteDecisionParams) (int64, error) {
+	return q.execRows(ctx, `DELETE FROM decisions
+WHERE actor_user_id = ? AND recipient_user_id = ?`, arg.ActorUserID, arg.RecipientUserID)
+}
+
+func (q *mysqlQueries) DeleteMatch(ctx context.Context, arg explorerdb.DeleteMatchParams) (int64, error) {
+	return q.execRows(ctx, `DELETE FROM matches
+WHERE (user_id = ? AND matched_user_id = ?) OR (user_id = ? AND matched_user_id = ?)`,
+		arg.UserID, arg.MatchedUserID, arg.MatchedUserID, arg.UserID)
+}
+
+func (q *mysqlQueries) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (explorerdb.Decision, error) {
+	var i explorerdb.Decision
+	err := q.db.QueryRowContext(ctx, `SELECT id, actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at FROM decisions
+WHERE actor_user_id = ? AND recipient_user_id = ?`, arg.ActorUserID, arg.RecipientUserID).Scan(
+		&i.ID,
+		&i.ActorUserID,
+		&i.RecipientUserID,
+		&i.LikedRecipient,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, noRows(err)
+}
+
+func (q *mysqlQueries) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+	var mutual *bool
+	err := q.db.QueryRowContext(ctx, `SELECT EXISTS(
+    SELECT 1 FROM decisions
+    WHERE decisions.actor_user_id = ? AND decisions.recipient_user_id = ? AND decisions.liked_recipient = true
+) AND EXISTS(
+    SELECT 1 FROM decisions
+    WHERE decisions.actor_user_id = ? AND decisions.recipient_user_id = ? AND decisions.liked_recipient = true
+)`, arg.ActorUserID, arg.RecipientUserID, arg.RecipientUserID, arg.ActorUserID).Scan(&mutual)
+	return mutual, err
+}
+
+func (q *mysqlQueries) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (bool, error) {
+	var exists bool
+	err := q.db.QueryRowContext(ctx, `SELECT EXISTS(
+    SELECT 1 FROM blocks
+    WHERE (blocker_user_id = ? AND blocked_user_id = ?) OR (blocker_user_id = ? AND blocked_user_id = ?)
+)`, arg.BlockerUserID, arg.BlockedUserID, arg.BlockedUserID, arg.BlockerUserID).Scan(&exists)
+	return exists, err
+}
+
+func (q *mysqlQueries) ListDecidersAsc(ctx context.Context, arg explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error) {
+	rows, err := q.listDeciders(ctx, explorerdb.ListDecidersDescParams(arg), true)
+	return convertDeciders[explorerdb.ListDecidersAscRow](rows), err
+}
+
+func (q *mysqlQueries) ListDecidersDesc(ctx context.Context, arg explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error) {
+	return q.listDeciders(ctx, arg, false)
+}
+
+// listDeciders reads the decisions on the recipient directly, in either order: MySQL keeps a
+// single decision per pair, as Postgres does, so there is no latest decision of an actor to pick
+func (q *mysqlQueries) listDeciders(ctx context.Context, arg explorerdb.ListDecidersDescParams, ascending bool) ([]explorerdb.ListDecidersDescRow, error) {
+	timestamp := mysqlDialect.unixSeconds("d.created_at")
+	query := mysqlDialect.builder().
+		Select("d.actor_user_id", timestamp+" AS timestamp", mysqlLikedBack+" AS liked_back", "d.id", "d.created_at").
+		From("decisions d").
+		Where(squirrel.Eq{"d.recipient_user_id": arg.RecipientUserID}).
+		Where(squirrel.Eq{"d.liked_recipient": arg.LikedRecipient}).
+		Where(mysqlNotBlocked)
+	if arg.MaxAgeSeconds != nil {
+		query = query.Where("d.created_at >= NOW(6) - INTERVAL ? SECOND", *arg.MaxAgeSeconds)
+	}
+	if arg.Since != nil {
+		query = query.Where(squirrel.GtOrEq{timestamp: *arg.Since})
+	}
+	if arg.Until != nil {
+		query = query.Where(squirrel.Lt{timestamp: *arg.Until})
+	}
+	if arg.AfterCreatedAt.Valid {
+		query = query.Where(keysetAfter("d.created_at", "d.id", arg.AfterCreatedAt, arg.AfterID, ascending))
+	}
+	if arg.LikedBack != nil {
+		query = query.Where(mysqlLikedBack+" = ?", *arg.LikedBack)
+	}
+	query = query.
+		OrderBy(keysetOrder("d.created_at", "d.id", ascending)...).
+		Limit(uint64(arg.PageLimit)).
+		Offset(uint64(arg.PageOffset))
+
+	return queryAll(ctx, q.db, query, func(i *explorerdb.ListDecidersDescRow) []any {
+		return []any{&i.ActorUserID, &i.Timestamp, &i.LikedBack, &i.ID, &i.CreatedAt}
+	})
+}
+
+func (q *mysqlQueries) ListLikersByPopularity(ctx context.Context, arg explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error) {
+	timestamp := mysqlDialect.unixSeconds("d.created_at")
+	query := mysqlDialect.builder().
+		Select("d.actor_user_id", timestamp+" AS timestamp", mysqlLikedBack+" AS liked_back", "d.id", "COALESCE(ps.score, 0) AS score").
+		From("decisions d").
+		LeftJoin("popularity_scores ps ON ps.actor_user_id = d.actor_user_id").
+		Where(squirrel.Eq{"d.recipient_user_id": arg.RecipientUserID}).
+		Where("d.liked_recipient = true").
+		Where(mysqlNotBlocked)
+	if arg.MaxAgeSeconds != nil {
+		query = query.Where("d.created_at >= NOW(6) - INTERVAL ? SECOND", *arg.MaxAgeSeconds)
+	}
+	if arg.Since != nil {
+		query = query.Where(squirrel.GtOrEq{timestamp: *arg.Since})
+	}
+	if arg.Until != nil {
+		query = query.Where(squirrel.Lt{timestamp: *arg.Until})
+	}
+	if arg.AfterScore != nil {
+		query = query.Where("(COALESCE(ps.score, 0), d.id) < (?, ?)", *arg.AfterScore, arg.AfterID)
+	}
+	query = query.
+		OrderBy("COALESCE(ps.score, 0) DESC", "d.id DESC").
+		Limit(uint64(arg.PageLimit)).
+		Offset(uint64(arg.PageOffset))
+
+	return queryAll(ctx, q.db, query, func(i *explorerdb.ListLikersByPopularityRow) []any {
+		return []any{&i.ActorUserID, &i.Timestamp, &i.LikedBack, &i.ID, &i.Score}
+	})
+}
+
+func (q *mysqlQueries) ListNewLikersAsc(ctx context.Context, arg explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error) {
+	rows, err := q.listNewLikers(ctx, explorerdb.ListNewLikersDescParams(arg), true)
+	return convertNewLikers[explorerdb.ListNewLikersAscRow](rows), err
+}
+
+func (q *mysqlQueries) ListNewLikersDesc(ctx context.Context, arg explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error) {
+	return q.listNewLikers(ctx, arg, false)
+}
+
+// listNewLikers reads the new likes of the recipient in either order
+func (q *mysqlQueries) listNewLikers(ctx context.Context, arg explorerdb.ListNewLikersDescParams, ascending bool) ([]explorerdb.ListNewLikersDescRow, error) {
+	timestamp := mysqlDialect.unixSeconds("d.created_at")
+	query := mysqlDialect.builder().
+		Select("d.actor_user_id", timestamp+" AS timestamp", "d.decision_id AS id", "d.created_at").
+		From("new_likes d").
+		Where(squirrel.Eq{"d.recipient_user_id": arg.RecipientUserID}).
+		Where(mysqlNotBlocked)
+	if arg.MaxAgeSeconds != nil {
+		query = query.Where("d.created_at >= NOW(6) - INTERVAL ? SECOND", *arg.MaxAgeSeconds)
+	}
+	if arg.Since != nil {
+		query = query.Where(squirrel.GtOrEq{timestamp: *arg.Since})
+	}
+	if arg.Until != nil {
+		query = query.Where(squirrel.Lt{timestamp: *arg.Until})
+	}
+	if arg.AfterCreatedAt.Valid {
+		query = query.Where(keysetAfter("d.created_at", "d.decision_id", arg.AfterCreatedAt, arg.AfterID, ascending))
+	}
+	query = query.
+		OrderBy(keysetOrder("d.created_at", "d.decision_id", ascending)...).
+		Limit(uint64(arg.PageLimit)).
+		Offset(uint64(arg.PageOffset))
+
+	return queryAll(ctx, q.db, query, func(i *explorerdb.ListNewLikersDescRow) []any {
+		return []any{&i.ActorUserID, &i.Timestamp, &i.ID, &i.CreatedAt}
+	})
+}
+
+// LockDecisionPair locks the pair's row of decision_pair_locks until the transaction ends, as
+// MySQL has no transaction-scoped advisory locks
+func (q *mysqlQueries) LockDecisionPair(ctx context.Context, arg explorerdb.LockDecisionPairParams) error {
+	_, err := q.db.ExecContext(ctx, `INSERT INTO decision_pair_locks (pair)
+VALUES (SHA2(CONCAT(LEAST(?, ?), ':', GREATEST(?, ?)), 256))
+ON DUPLICATE KEY UPDATE pair = pair`, arg.UserID, arg.OtherUserID, arg.UserID, arg.OtherUserID)
+	return err
+}
+
+func (q *mysqlQueries) PurgeExpiredLikes(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams) (int64, error) {
+	return q.execRows(ctx, `DELETE FROM decisions
+WHERE liked_recipient = true AND created_at < NOW(6) - INTERVAL ? SECOND
+LIMIT ?`, arg.MaxAgeSeconds, arg.BatchSize)
+}
+
+// ReconcileLikeCounts corrects the stored counts, adds the missing ones and zeroes those left
+// without likes, in one transaction
+func (q *mysqlQueries) ReconcileLikeCounts(ctx context.Context) (int64, error) {
+	var corrected int64
+	err := q.inTx(ctx, func(q *mysqlQueries) error {
+		for _, statement := range []string{
+			`UPDATE like_counts lc
+JOIN (` + mysqlActualLikes + `) a ON a.recipient_user_id = lc.recipient_user_id
+SET lc.count = a.count, lc.updated_at = NOW(6)
+WHERE lc.count <> a.count`,
+			`INSERT INTO like_counts (recipient_user_id, count, updated_at)
+SELECT a.recipient_user_id, a.count, NOW(6)
+FROM (` + mysqlActualLikes + `) a
+WHERE NOT EXISTS(SELECT 1 FROM like_counts lc WHERE lc.recipient_user_id = a.recipient_user_id)`,
+			`UPDATE like_counts lc
+SET lc.count = 0, lc.updated_at = NOW(6)
+WHERE lc.count <> 0
+  AND NOT EXISTS(
+    SELECT 1 FROM decisions d
+    WHERE d.recipient_user_id = lc.recipient_user_id AND d.liked_recipient = true AND ` + mysqlNotBlocked + `
+)`,
+		} {
+			rows, err := q.execRows(ctx, statement)
+			if err != nil {
+				return err
+			}
+			corrected += rows
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return corrected, nil
+}
+
+// UpsertDecisions upserts the decisions one at a time in a transaction, and returns their rows
+// through the batch results sqlc reads them from. The decisions after a failing one are not
+// upserted, and fail as they do in an aborted Postgres batch.
+func (q *mysqlQueries) UpsertDecisions(ctx context.Context, arg []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults {
+	var rows []explorerdb.UpsertDecisionsRow
+	err := q.inTx(ctx, func(q *mysqlQueries) error {
+		for _, decision := range arg {
+			// A new row counts one affected row, a replaced row two
+			affected, err := q.execRows(ctx, `INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+VALUES (?, ?, ?, NOW(6), NOW(6))
+ON DUPLICATE KEY UPDATE
+    liked_recipient = VALUES(liked_recipient),
+    created_at = NOW(6),
+    updated_at = NOW(6)`, decision.ActorUserID, decision.RecipientUserID, decision.LikedRecipient)
+			if err != nil {
+				return err
+			}
+
+			row := explorerdb.UpsertDecisionsRow{Inserted: affected == 1}
+			if decision.LikedRecipient {
+				mutual, err := q.HasMutualLike(ctx, explorerdb.HasMutualLikeParams{
+					ActorUserID:     decision.ActorUserID,
+					RecipientUserID: decision.RecipientUserID,
+				})
+				if err != nil {
+					return err
+				}
+				row.MutualLike = mutual != nil && *mutual
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+	if err != nil && len(rows) == len(arg) {
+		// The transaction failed to commit, failing every decision
+		rows = nil
+	}
+	return explorerdb.New(upsertedDecisions{rows: rows, err: err}).UpsertDecisions(ctx, arg)
+}
+
+func (q *mysqlQueries) ListUserDecisions(ctx context.Context, arg explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error) {
+	query := mysqlDialect.builder().
+		Select("id", "actor_user_id", "recipient_user_id", "liked_recipient", mysqlDialect.unixSeconds("created_at")+" AS timestamp").
+		From("decisions").
+		Where(squirrel.Or{squirrel.Eq{"actor_user_id": arg.UserID}, squirrel.Eq{"recipient_user_id": arg.UserID}})
+	if arg.BeforeID != nil {
+		query = query.Where(squirrel.Lt{"id": *arg.BeforeID})
+	}
+	query = query.OrderBy("id DESC").Limit(uint64(arg.PageLimit))
+
+	return queryAll(ctx, q.db, query, func(i *explorerdb.ListUserDecisionsRow) []any {
+		return []any{&i.ID, &i.ActorUserID, &i.RecipientUserID, &i.LikedRecipient, &i.Timestamp}
+	})
+}
+
+func (q *mysqlQueries) DeleteUserDecisions(ctx context.Context, userID string) ([]explorerdb.DeleteUserDecisionsRow, error) {
+	var deleted []explorerdb.DeleteUserDecisionsRow
+	err := q.inTx(ctx, func(q *mysqlQueries) error {
+		var err error
+		deleted, err = queryAll(ctx, q.db, squirrel.Expr(`SELECT actor_user_id, recipient_user_id FROM decisions
+WHERE actor_user_id = ? OR recipient_user_id = ?
+FOR UPDATE`, userID, userID), func(i *explorerdb.DeleteUserDecisionsRow) []any {
+			return []any{&i.ActorUserID, &i.RecipientUserID}
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.db.ExecContext(ctx, `DELETE FROM decisions
+WHERE actor_user_id = ? OR recipient_user_id = ?`, userID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+func (q *mysqlQueries) DeleteUserMatches(ctx context.Context, userID string) (int64, error) {
+	return q.execRows(ctx, `DELETE FROM matches
+WHERE user_id = ? OR matched_user_id = ?`, userID, userID)
+}
+
+func (q *mysqlQueries) DeleteUserBlocks(ctx context.Context, userID string) (int64, error) {
+	return q.execRows(ctx, `DELETE FROM blocks
+WHERE blocker_user_id = ? OR blocked_user_id = ?`, userID, userID)
+}
+
+func (q *mysqlQueries) DeleteUserLikeCount(ctx context.Context, recipientUserID string) error {
+	_, err := q.db.ExecContext(ctx, `DELETE FROM like_counts
+WHERE recipient_user_id = ?`, recipientUserID)
+	return err
+}
+
+func (q *mysqlQueries) DeleteUserPopularityScore(ctx context.Context, actorUserID string) error {
+	_, err := q.db.ExecContext(ctx, `DELETE FROM popularity_scores
+WHERE actor_user_id = ?`, actorUserID)
+	return err
+}
+
+// GetServiceStats estimates the rows of the large tables from the table statistics, as the
+// Postgres query does from pg_class
+func (q *mysqlQueries) GetServiceStats(ctx context.Context) (explorerdb.GetServiceStatsRow, error) {
+	var i explorerdb.GetServiceStatsRow
+	err := q.db.QueryRowContext(ctx, `SELECT
+    (SELECT COALESCE(MAX(t.TABLE_ROWS), 0) FROM information_schema.TABLES t WHERE t.TABLE_SCHEMA = DATABASE() AND t.TABLE_NAME = 'decisions') AS decisions,
+    (SELECT CAST(COALESCE(SUM(lc.count), 0) AS SIGNED) FROM like_counts lc) AS likes,
+    (SELECT COALESCE(MAX(t.TABLE_ROWS), 0) FROM information_schema.TABLES t WHERE t.TABLE_SCHEMA = DATABASE() AND t.TABLE_NAME = 'matches') AS matches,
+    (SELECT COALESCE(MAX(t.TABLE_ROWS), 0) FROM information_schema.TABLES t WHERE t.TABLE_SCHEMA = DATABASE() AND t.TABLE_NAME = 'blocks') AS blocks,
+    (SELECT COUNT(*) FROM outbox) AS pending_outbox_events,
+    (SELECT COUNT(*) FROM outbox_dead_letters) AS dead_lettered_outbox_events`).Scan(
+		&i.Decisions,
+		&i.Likes,
+		&i.Matches,
+		&i.Blocks,
+		&i.PendingOutboxEvents,
+		&i.DeadLetteredOutboxEvents,
+	)
+	return i, err
+}
+
+// AllowAuditEventErasure fails: MySQL has no transaction-local setting for the audit_events
+// triggers to let an erasure through, so audit events are never rewritten
+func (q *mysqlQueries) AllowAuditEventErasure(ctx context.Context) error {
+	return fmt.Errorf("audit event erasure needs postgres: %w", errors.ErrUnsupported)
+}
+
+// AnonymizeUserAuditEvents fails as AllowAuditEventErasure does
+func (q *mysqlQueries) AnonymizeUserAuditEvents(ctx context.Context, arg explorerdb.AnonymizeUserAuditEventsParams) (int64, error) {
+	return 0, fmt.Errorf("audit event erasure needs postgres: %w", errors.ErrUnsupported)
+}
+
+func (q *mysqlQueries) AnonymizeUserReports(ctx context.Context, arg explorerdb.AnonymizeUserReportsParams) (int64, error) {
+	return q.execRows(ctx, `UPDATE reports
+SET reporter_user_id = CASE WHEN reporter_user_id = ? THEN ? ELSE reporter_user_id END,
+    reported_user_id = CASE WHEN reported_user_id = ? THEN ? ELSE reported_user_id END
+WHERE reporter_user_id = ? OR reported_user_id = ?`,
+		arg.UserID, arg.Placeholder, arg.UserID, arg.Placeholder, arg.UserID, arg.UserID)
+}
+
+func (q *mysqlQueries) CreateErasureReceipt(ctx context.Context, arg explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error) {
+	var i explorerdb.CreateErasureReceiptRow
+	err := q.inTx(ctx, func(q *mysqlQueries) error {
+		result, err := q.db.ExecContext(ctx, `INSERT INTO erasure_receipts (subject_hash, caller_id, request_id, decisions, matches, blocks, reports, audit_events)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			arg.SubjectHash,
+			arg.CallerID,
+			arg.RequestID,
+			arg.Decisions,
+			arg.Matches,
+			arg.Blocks,
+			arg.Reports,
+			arg.AuditEvents,
+		)
+		if err != nil {
+			return err
+		}
+		if i.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+
+		return q.db.QueryRowContext(ctx, `SELECT `+mysqlDialect.unixSeconds("erased_at")+` FROM erasure_receipts
+WHERE id = ?`, i.ID).Scan(&i.ErasedAt)
+	})
+	if err != nil {
+		return explorerdb.CreateErasureReceiptRow{}, err
+	}
+	return i, nil
+}
+
+// CreateAuditEvent stores the user ids as a JSON array, as audit_events.user_ids holds them
+func (q *mysqlQueries) CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) error {
+	userIDs := arg.UserIds
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+	userIDsJSON, err := json.Marshal(userIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.db.ExecContext(ctx, `INSERT INTO audit_events (action, caller_id, caller_is_service, client_address, request_id, user_ids, outcome, details)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		arg.Action,
+		arg.CallerID,
+		arg.CallerIsService,
+		arg.ClientAddress,
+		arg.RequestID,
+		string(userIDsJSON),
+		arg.Outcome,
+		string(arg.Details),
+	)
+	return err
+}
+
+func (q *mysqlQueries) ListAuditEvents(ctx context.Context, arg explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error) {
+	query := mysqlDialect.builder().
+		Select("id", "occurred_at", "action", "caller_id", "caller_is_service", "client_address", "request_id", "user_ids", "outcome", "details").
+		From("audit_events")
+	if arg.UserID != nil {
+		query = query.Where("(JSON_CONTAINS(user_ids, JSON_QUOTE(?)) OR caller_id = ?)", *arg.UserID, *arg.UserID)
+	}
+	if arg.Action != nil {
+		query = query.Where(squirrel.Eq{"action": *arg.Action})
+	}
+	if arg.Since != nil {
+		query = query.Where("occurred_at >= FROM_UNIXTIME(?)", *arg.Since)
+	}
+	if arg.Until != nil {
+		query = query.Where("occurred_at < FROM_UNIXTIME(?)", *arg.Until)
+	}
+	if arg.BeforeID != nil {
+		query = query.Where(squirrel.Lt{"id": *arg.BeforeID})
+	}
+	query = query.OrderBy("id DESC").Limit(uint64(arg.PageLimit))
+
+	type auditEventRow struct {
+		explorerdb.AuditEvent
+		userIDs []byte
+	}
+	rows, err := queryAll(ctx, q.db, query, func(i *auditEventRow) []any {
+		return []any{
+			&i.ID,
+			&i.OccurredAt,
+			&i.Action,
+			&i.CallerID,
+			&i.CallerIsService,
+			&i.ClientAddress,
+			&i.RequestID,
+			&i.userIDs,
+			&i.Outcome,
+			&i.Details,
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]explorerdb.AuditEvent, len(rows))
+	for n, row := range rows {
+		items[n] = row.AuditEvent
+		if err := json.Unmarshal(row.userIDs, &items[n].UserIds); err != nil {
+			return nil, fmt.Errorf("failed to decode user ids of audit event %d: %w", row.ID, err)
+		}
+	}
+	return items, nil
+}
+
+func (q *mysqlQueries) ClaimOutboxEvents(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error) {
+	return queryAll(ctx, q.db, squirrel.Expr(`SELECT id, topic, payload, attempts, last_error, next_attempt_at, created_at FROM outbox
+WHERE next_attempt_at <= NOW(6) AND attempts < ?
+ORDER BY id
+LIMIT ?
+FOR UPDATE SKIP LOCKED`, arg.MaxAttempts, arg.BatchSize), func(i *explorerdb.Outbox) []any {
+		return []any{&i.ID, &i.Topic, &i.Payload, &i.Attempts, &i.LastError, &i.NextAttemptAt, &i.CreatedAt}
+	})
+}
+
+func (q *mysqlQueries) CreateOutboxEvent(ctx context.Context, arg explorerdb.CreateOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, `INSERT INTO outbox (topic, payload, created_at, next_attempt_at)
+VALUES (?, ?, NOW(6), NOW(6))`, arg.Topic, arg.Payload)
+	return err
+}
+
+// DeadLetterOutboxEvent copies the event to outbox_dead_letters and deletes it, in one transaction
+func (q *mysqlQueries) DeadLetterOutboxEvent(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams) error {
+	return q.inTx(ctx, func(q *mysqlQueries) error {
+		_, err := q.db.ExecContext(ctx, `INSERT INTO outbox_dead_letters (outbox_id, topic, payload, attempts, last_error, created_at, failed_at)
+SELECT id, topic, payload, attempts + 1, ?, created_at, NOW(6)
+FROM outbox
+WHERE id = ?`, arg.LastError, arg.ID)
+		if err != nil {
+			return err
+		}
+		return q.DeleteOutboxEvent(ctx, arg.ID)
+	})
+}
+
+func (q *mysqlQueries) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, `DELETE FROM outbox
+WHERE id = ?`, id)
+	return err
+}
+
+// RetryOutboxEvent sets next_attempt_at first: MySQL assigns from left to right, so the backoff
+// is computed from the attempts made before this one, as in Postgres
+func (q *mysqlQueries) RetryOutboxEvent(ctx context.Context, arg explorerdb.RetryOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, `UPDATE outbox
+SET next_attempt_at = NOW(6) + INTERVAL LEAST(POWER(2, attempts), 300) SECOND,
+    last_error = ?,
+    attempts = attempts + 1
+WHERE id = ?`, arg.LastError, arg.ID)
+	return err
+}
+
+// CreateReport reads the stored report back, as MySQL cannot return the inserted row
+func (q *mysqlQueries) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (explorerdb.Report, error) {
+	var i explorerdb.Report
+	err := q.inTx(ctx, func(q *mysqlQueries) error {
+		result, err := q.db.ExecContext(ctx, `INSERT INTO reports (reporter_user_id, reported_user_id, reason, reporter_liked, reported_liked, created_at)
+VALUES (
+    ?, ?, ?,
+    (SELECT liked_recipient FROM decisions WHERE actor_user_id = ? AND recipient_user_id = ?),
+    (SELECT liked_recipient FROM decisions WHERE actor_user_id = ? AND recipient_user_id = ?),
+    NOW(6)
+)`,
+			arg.ReporterUserID, arg.ReportedUserID, arg.Reason,
+			arg.ReporterUserID, arg.ReportedUserID,
+			arg.ReportedUserID, arg.ReporterUserID,
+		)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return q.db.QueryRowContext(ctx, `SELECT id, reporter_user_id, reported_user_id, reason, reporter_liked, reported_liked, created_at FROM reports
+WHERE id = ?`, id).Scan(
+			&i.ID,
+			&i.ReporterUserID,
+			&i.ReportedUserID,
+			&i.Reason,
+			&i.ReporterLiked,
+			&i.ReportedLiked,
+			&i.CreatedAt,
+		)
+	})
+	if err != nil {
+		return explorerdb.Report{}, err
+	}
+	return i, nil
+}
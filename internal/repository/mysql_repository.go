@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+)
+
+// mysqlImportChunk caps the decisions inserted into the staging table per statement, well
+// below the 65535 placeholders MySQL allows in one
+const mysqlImportChunk = 1000
+
+// mysqlStore is an ExplorerRepository keeping the decisions in MySQL. It runs the same
+// transactions as explorerStore with the queries of mysqlQueries, so decisions are recorded,
+// listed and paged as on Postgres.
+type mysqlStore struct {
+	db *sql.DB
+	*mysqlQueries
+	likerListings
+	logger *zap.Logger
+}
+
+// NewMySQLExplorerRepository returns an ExplorerRepository on the MySQL database of db, migrated
+// with db/migrations/mysql. Like NewExplorerRepository, likeTTL hides likes older than it from
+// likers listings, zero keeping likes forever.
+func NewMySQLExplorerRepository(db *sql.DB, likeTTL time.Duration, logger *zap.Logger) ExplorerRepository {
+	queries := &mysqlQueries{db: db}
+	return &mysqlStore{
+		db:            db,
+		logger:        logger,
+		mysqlQueries:  queries,
+		likerListings: likerListings{q: queries, likeTTL: likeTTL, logger: logger},
+	}
+}
+
+// NewMySQLAuditRepository returns an AuditRepository keeping the audit trail in the MySQL database of db
+func NewMySQLAuditRepository(db *sql.DB, logger *zap.Logger) AuditRepository {
+	return &auditStore{Querier: &mysqlQueries{db: db}, logger: logger}
+}
+
+// CountLikers returns the number of likes the recipient received, read from the like_counts table
+// or, with a like TTL, counted from the unexpired likes as in Postgres
+func (s *mysqlStore) CountLikers(ctx context.Context, recipientUserID string) (int64, error) {
+	return countLikers(ctx, s, s.maxLikeAge(), recipientUserID)
+}
+
+// GetLikedRecipients returns users the actor has liked with pagination
+func (s *mysqlStore) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query, args, err := mysqlDialect.likedRecipientsQuery(actorUserID, page, cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		s.logger.Error("Failed to get liked recipients",
+			zap.String("actor_user_id", actorUserID),
+			zap.Error(err))
+		return nil, "", fmt.Errorf("failed to get liked recipients: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanRecipients(rows, cursor)
+}
+
+// GetMatches returns users the user has a mutual like with, most recent match first
+func (s *mysqlStore) GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query, args, err := mysqlDialect.matchesQuery(userID, page, cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		s.logger.Error("Failed to get matches",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, "", fmt.Errorf("failed to get matches: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanMatches(rows, cursor)
+}
+
+// RecordDecision stores the decision as explorerStore.RecordDecision does, serializing the
+// decisions on the pair of users with a lock on their row of decision_pair_locks
+func (s *mysqlStore) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	var recorded models.RecordedDecision
+	err := s.inTx(ctx, func(q *mysqlQueries) error {
+		if err := lockDecisionPairs(ctx, q, []explorerdb.CreateDecisionParams{decision}); err != nil {
+			return err
+		}
+
+		var err error
+		recorded, err = recordDecision(ctx, q, decision, events)
+		return err
+	})
+	if err != nil {
+		return models.RecordedDecision{}, err
+	}
+	return recorded, nil
+}
+
+// RemoveDecision withdraws the decision as explorerStore.RemoveDecision does, serializing the
+// decisions on the pair of users with a lock on their row of decision_pair_locks
+func (s *mysqlStore) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	var removed models.RemovedDecision
+	err := s.inTx(ctx, func(q *mysqlQueries) error {
+		if err := lockDecisionPairs(ctx, q, []explorerdb.CreateDecisionParams{pairOf(decision)}); err != nil {
+			return err
+		}
+
+		var err error
+		removed, err = removeDecision(ctx, q, decision)
+		return err
+	})
+	if err != nil {
+		return models.RemovedDecision{}, err
+	}
+	return removed, nil
+}
+
+// CreateDecisions stores all decisions in a single transaction as explorerStore.CreateDecisions does
+func (s *mysqlStore) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error) {
+	var results []models.DecisionResult
+	err := s.inTx(ctx, func(q *mysqlQueries) error {
+		if err := lockDecisionPairs(ctx, q, decisions); err != nil {
+			return err
+		}
+
+		var err error
+		results, err = createDecisions(ctx, q, decisions, events, s.logger)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// IngestDecisions upserts the decisions in a single transaction as explorerStore.IngestDecisions does
+func (s *mysqlStore) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error) {
+	var summary models.IngestSummary
+	err := s.inTx(ctx, func(q *mysqlQueries) error {
+		var err error
+		summary, err = ingestDecisions(ctx, q, decisions, s.logger)
+		return err
+	})
+	if err != nil {
+		return models.IngestSummary{}, err
+	}
+	return summary, nil
+}
+
+const (
+	// The staging table is kept by the session, so one left by a failed import is dropped first
+	dropMySQLDecisionImportsSQL   = `DROP TEMPORARY TABLE IF EXISTS decision_imports`
+	createMySQLDecisionImportsSQL = `CREATE TEMPORARY TABLE decision_imports (
+    actor_user_id VARCHAR(255) NOT NULL,
+    recipient_user_id VARCHAR(255) NOT NULL,
+    liked_recipient BOOLEAN NOT NULL,
+    passed BOOLEAN NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (actor_user_id, recipient_user_id)
+)`
+
+	// Only a decision made after the stored one replaces it, so importing the same file twice
+	// changes nothing
+	updateMySQLDecisionImportsSQL = `UPDATE decisions d
+JOIN decision_imports i ON d.actor_user_id = i.actor_user_id AND d.recipient_user_id = i.recipient_user_id
+SET d.liked_recipient = i.liked_recipient,
+    d.created_at = i.created_at,
+    d.updated_at = NOW(6)
+WHERE d.created_at < i.created_at`
+
+	insertMySQLDecisionImportsSQL = `INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, created_at, updated_at)
+SELECT i.actor_user_id, i.recipient_user_id, i.liked_recipient, i.created_at, NOW(6)
+FROM decision_imports i
+WHERE NOT EXISTS(
+    SELECT 1 FROM decisions d
+    WHERE d.actor_user_id = i.actor_user_id AND d.recipient_user_id = i.recipient_user_id
+)`
+
+	// A pass that won over a stored like ends the match it was part of
+	deleteMySQLImportedPassMatchesSQL = `DELETE m FROM matches m
+JOIN decision_imports i
+  ON (m.user_id = i.actor_user_id AND m.matched_user_id = i.recipient_user_id)
+  OR (m.user_id = i.recipient_user_id AND m.matched_user_id = i.actor_user_id)
+WHERE i.passed
+  AND NOT EXISTS(
+    SELECT 1 FROM decisions d
+    WHERE d.actor_user_id = i.actor_user_id AND d.recipient_user_id = i.recipient_user_id AND d.liked_recipient = true
+)`
+
+	// Matches are stored once per participant and dated by the like completing them. MySQL cannot
+	// open a temporary table twice in one statement, so each participant's row is its own insert.
+	createMySQLImportedMatchesSQL = `INSERT INTO matches (user_id, matched_user_id, matched_at)
+SELECT %s, GREATEST(a.created_at, b.created_at)
+FROM decision_imports i
+JOIN decisions a ON a.actor_user_id = i.actor_user_id AND a.recipient_user_id = i.recipient_user_id AND a.liked_recipient = true
+JOIN decisions b ON b.actor_user_id = i.recipient_user_id AND b.recipient_user_id = i.actor_user_id AND b.liked_recipient = true
+ON DUPLICATE KEY UPDATE matches.id = matches.id`
+)
+
+// ImportDecisions bulk-loads decisions carried over from another system as
+// explorerStore.ImportDecisions does. The latest decision of each pair is picked before the rows
+// are inserted into the staging table in chunks, as MySQL has no COPY, and merged into decisions
+// inside one transaction.
+func (s *mysqlStore) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error) {
+	summary := models.ImportSummary{Rows: len(decisions)}
+
+	latest := make(map[userPair]models.ImportedDecision, len(decisions))
+	passed := make(map[userPair]bool)
+	var pairs []userPair
+	for _, d := range decisions {
+		pair := userPair{d.ActorUserID, d.RecipientUserID}
+		if !d.LikedRecipient {
+			passed[pair] = true
+		}
+		previous, seen := latest[pair]
+		if !seen {
+			pairs = append(pairs, pair)
+		}
+		if !seen || !d.CreatedAt.Before(previous.CreatedAt) {
+			latest[pair] = d
+		}
+	}
+
+	err := s.inTx(ctx, func(q *mysqlQueries) error {
+		if _, err := q.db.ExecContext(ctx, dropMySQLDecisionImportsSQL); err != nil {
+			return fmt.Errorf("failed to drop staging table: %w", err)
+		}
+		if _, err := q.db.ExecContext(ctx, createMySQLDecisionImportsSQL); err != nil {
+			return fmt.Errorf("failed to create staging table: %w", err)
+		}
+
+		for start := 0; start < len(pairs); start += mysqlImportChunk {
+			insert := mysqlDialect.builder().Insert("decision_imports").
+				Columns("actor_user_id", "recipient_user_id", "liked_recipient", "passed", "created_at")
+			for _, pair := range pairs[start:min(start+mysqlImportChunk, len(pairs))] {
+				d := latest[pair]
+				insert = insert.Values(d.ActorUserID, d.RecipientUserID, d.LikedRecipient, passed[pair], d.CreatedAt.UTC())
+			}
+			query, args, err := insert.ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build query: %w", err)
+			}
+			if _, err := q.db.ExecContext(ctx, query, args...); err != nil {
+				s.logger.Error("Failed to copy decisions", zap.Int("rows", len(decisions)), zap.Error(err))
+				return fmt.Errorf("failed to copy decisions: %w", err)
+			}
+		}
+
+		updated, err := q.execRows(ctx, updateMySQLDecisionImportsSQL)
+		if err != nil {
+			return fmt.Errorf("failed to merge decisions: %w", err)
+		}
+		created, err := q.execRows(ctx, insertMySQLDecisionImportsSQL)
+		if err != nil {
+			return fmt.Errorf("failed to merge decisions: %w", err)
+		}
+		summary.Created = int(created)
+		summary.Updated = int(updated)
+		summary.Skipped = summary.Rows - summary.Created - summary.Updated
+
+		if _, err := q.db.ExecContext(ctx, deleteMySQLImportedPassMatchesSQL); err != nil {
+			return fmt.Errorf("failed to delete matches: %w", err)
+		}
+		var matched int64
+		for _, participants := range []string{"a.actor_user_id, a.recipient_user_id", "a.recipient_user_id, a.actor_user_id"} {
+			rows, err := q.execRows(ctx, fmt.Sprintf(createMySQLImportedMatchesSQL, participants))
+			if err != nil {
+				return fmt.Errorf("failed to create matches: %w", err)
+			}
+			matched += rows
+		}
+		summary.Matches = int(matched / 2)
+
+		if _, err := q.db.ExecContext(ctx, dropMySQLDecisionImportsSQL); err != nil {
+			return fmt.Errorf("failed to drop staging table: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ImportSummary{}, err
+	}
+
+	return summary, nil
+}
+
+// DrainOutbox claims the outbox events due for delivery and settles them in one transaction,
+// as explorerStore.DrainOutbox does
+func (s *mysqlStore) DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+	var summary models.DrainSummary
+	err := s.inTx(ctx, func(q *mysqlQueries) error {
+		var err error
+		summary, err = drainOutbox(ctx, q, claim, deliver, s.logger)
+		return err
+	})
+	if err != nil {
+		return models.DrainSummary{}, err
+	}
+	return summary, nil
+}
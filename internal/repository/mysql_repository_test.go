@@ -0,0 +1,228 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+	"github.com/backend-interview-task/utils"
+)
+
+type MySQLRepositoryTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	repo repository.ExplorerRepository
+	ctx  context.Context
+}
+
+func TestMySQLRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MySQLRepositoryTestSuite))
+}
+
+func (s *MySQLRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = db.Close() })
+	s.mock = mock
+
+	s.repo = repository.NewMySQLExplorerRepository(db, 0, zaptest.NewLogger(s.T()))
+}
+
+func (s *MySQLRepositoryTestSuite) TearDownTest() {
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *MySQLRepositoryTestSuite) TestGetLikers_FiltersAndSeeks() {
+	cursor := &utils.Cursor{LastCreatedAt: 1700000000, LastID: 42, LastCreatedAtMicros: 1700000000_000_123, Limit: 2}
+	token, err := cursor.Encode()
+	s.Require().NoError(err)
+
+	// The creation time is compared as stored, to the microsecond, so that its index seeks to it
+	s.mock.ExpectQuery(`SELECT d.actor_user_id, CAST\(UNIX_TIMESTAMP\(d.created_at\) AS SIGNED\) AS timestamp, .*, d.id, d.created_at FROM decisions d `+
+		`WHERE d.recipient_user_id = \? AND d.liked_recipient = \? AND NOT EXISTS\(.*\) `+
+		`AND CAST\(UNIX_TIMESTAMP\(d.created_at\) AS SIGNED\) >= \? `+
+		`AND \(d.created_at, d.id\) < \(\?, \?\) `+
+		`ORDER BY d.created_at DESC, d.id DESC LIMIT 3 OFFSET 0`).
+		WithArgs("user123", true, int64(1600000000), time.UnixMicro(1700000000_000_123).UTC(), int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"actor_user_id", "timestamp", "liked_back", "id", "created_at"}).
+			AddRow("actor1", int64(1690000000), true, int64(41), time.Unix(1690000000, 0)).
+			AddRow("actor2", int64(1680000000), false, int64(40), time.Unix(1680000000, 0)))
+
+	likers, tokens, err := s.repo.GetLikers(s.ctx, "user123", models.PageRequest{Token: token, Since: 1600000000})
+
+	s.NoError(err)
+	s.Equal([]models.Liker{
+		{ActorID: "actor1", Timestamp: 1690000000, LikedBack: true},
+		{ActorID: "actor2", Timestamp: 1680000000},
+	}, likers)
+	s.Empty(tokens.Next)
+}
+
+func (s *MySQLRepositoryTestSuite) TestCountLikers_LikeTTL() {
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer func() { _ = db.Close() }()
+	repo := repository.NewMySQLExplorerRepository(db, time.Hour, zaptest.NewLogger(s.T()))
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM decisions d .* AND d.created_at >= NOW\(6\) - INTERVAL \? SECOND`).
+		WithArgs("user123", int64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	count, err := repo.CountLikers(s.ctx, "user123")
+
+	s.NoError(err)
+	s.Equal(int64(3), count)
+	s.NoError(mock.ExpectationsWereMet())
+}
+
+func (s *MySQLRepositoryTestSuite) TestGetLikedRecipients_WithPagination() {
+	cursor := &utils.Cursor{LastCreatedAt: 1700000000, Limit: 1}
+	token, err := cursor.Encode()
+	s.Require().NoError(err)
+
+	s.mock.ExpectQuery(`SELECT recipient_user_id, CAST\(UNIX_TIMESTAMP\(created_at\) AS SIGNED\) as timestamp FROM decisions `+
+		`WHERE actor_user_id = \? AND liked_recipient = \? AND CAST\(UNIX_TIMESTAMP\(created_at\) AS SIGNED\) < \? `+
+		`ORDER BY created_at DESC LIMIT 2`).
+		WithArgs("actor1", true, int64(1700000000)).
+		WillReturnRows(sqlmock.NewRows([]string{"recipient_user_id", "timestamp"}).
+			AddRow("recipient1", int64(1690000000)).
+			AddRow("recipient2", int64(1680000000)))
+
+	recipients, next, err := s.repo.GetLikedRecipients(s.ctx, "actor1", models.PageRequest{Token: token})
+
+	s.NoError(err)
+	s.Equal([]models.Recipient{{RecipientID: "recipient1", Timestamp: 1690000000}}, recipients)
+	nextCursor, err := utils.DecodeCursor(next)
+	s.Require().NoError(err)
+	s.Equal(int64(1690000000), nextCursor.LastCreatedAt)
+}
+
+func (s *MySQLRepositoryTestSuite) TestRecordDecision_NewLike() {
+	newLike := models.OutboxEvent{Topic: "likes.new", Payload: []byte(`{}`)}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`INSERT INTO decision_pair_locks .* ON DUPLICATE KEY UPDATE pair = pair`).
+		WithArgs("actor1", "recipient1", "actor1", "recipient1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery(`SELECT liked_recipient FROM decisions .* FOR UPDATE`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(sqlmock.NewRows([]string{"liked_recipient"}))
+	s.mock.ExpectExec(`INSERT INTO decisions .* ON DUPLICATE KEY UPDATE`).
+		WithArgs("actor1", "recipient1", true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectQuery(`SELECT created_at FROM decisions`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Unix(1700000000, 0)))
+	s.mock.ExpectQuery(`SELECT EXISTS\( SELECT 1 FROM blocks`).
+		WithArgs("recipient1", "actor1", "actor1", "recipient1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	s.mock.ExpectQuery(`SELECT EXISTS\( SELECT 1 FROM decisions`).
+		WithArgs("actor1", "recipient1", "recipient1", "actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	s.mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("likes.new", []byte(`{}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	recorded, err := s.repo.RecordDecision(s.ctx,
+		explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true},
+		models.DecisionEvents{NewLike: &newLike})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{Created: true, LikesDelta: 1, Timestamp: 1700000000}, recorded)
+}
+
+func (s *MySQLRepositoryTestSuite) TestRecordDecision_RollsBackOnError() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`INSERT INTO decision_pair_locks`).
+		WillReturnError(errors.New("lock wait timeout"))
+	s.mock.ExpectRollback()
+
+	_, err := s.repo.RecordDecision(s.ctx,
+		explorerdb.CreateDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1"},
+		models.DecisionEvents{})
+
+	s.ErrorContains(err, "failed to lock decision pair")
+}
+
+func (s *MySQLRepositoryTestSuite) TestGetDecision_NotFound() {
+	s.mock.ExpectQuery(`SELECT id, actor_user_id, .* FROM decisions`).
+		WithArgs("actor1", "recipient1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_user_id", "recipient_user_id", "liked_recipient", "created_at", "updated_at"}))
+
+	_, err := s.repo.GetDecision(s.ctx, explorerdb.GetDecisionParams{ActorUserID: "actor1", RecipientUserID: "recipient1"})
+
+	s.ErrorIs(err, pgx.ErrNoRows)
+}
+
+func (s *MySQLRepositoryTestSuite) TestIngestDecisions_FailingDecision() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`INSERT INTO decisions .* ON DUPLICATE KEY UPDATE`).
+		WithArgs("actor1", "recipient1", false).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectExec(`INSERT INTO decisions .* ON DUPLICATE KEY UPDATE`).
+		WithArgs("actor1", "recipient2", false).
+		WillReturnError(errors.New("data too long"))
+	s.mock.ExpectRollback()
+
+	_, err := s.repo.IngestDecisions(s.ctx, []explorerdb.UpsertDecisionsParams{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1"},
+		{ActorUserID: "actor1", RecipientUserID: "recipient2"},
+		{ActorUserID: "actor1", RecipientUserID: "recipient3"},
+	})
+
+	var decisionErr *models.DecisionError
+	s.Require().ErrorAs(err, &decisionErr)
+	s.Equal(1, decisionErr.Index)
+}
+
+func (s *MySQLRepositoryTestSuite) TestImportDecisions_Success() {
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	decisions := []models.ImportedDecision{
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: true, CreatedAt: createdAt},
+		{ActorUserID: "recipient1", RecipientUserID: "actor1", LikedRecipient: true, CreatedAt: createdAt},
+		{ActorUserID: "actor1", RecipientUserID: "recipient1", LikedRecipient: false, CreatedAt: createdAt.Add(-time.Hour)},
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`DROP TEMPORARY TABLE IF EXISTS decision_imports`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectExec(`CREATE TEMPORARY TABLE decision_imports`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectExec(`INSERT INTO decision_imports \(actor_user_id,recipient_user_id,liked_recipient,passed,created_at\) VALUES \(\?,\?,\?,\?,\?\),\(\?,\?,\?,\?,\?\)`).
+		WithArgs("actor1", "recipient1", true, true, createdAt, "recipient1", "actor1", true, false, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectExec(`UPDATE decisions d JOIN decision_imports i .* WHERE d.created_at < i.created_at`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(`INSERT INTO decisions .* FROM decision_imports i WHERE NOT EXISTS`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(`DELETE m FROM matches m JOIN decision_imports i`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectExec(`INSERT INTO matches .* SELECT a.actor_user_id, a.recipient_user_id, GREATEST`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(`INSERT INTO matches .* SELECT a.recipient_user_id, a.actor_user_id, GREATEST`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(`DROP TEMPORARY TABLE IF EXISTS decision_imports`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectCommit()
+
+	summary, err := s.repo.ImportDecisions(s.ctx, decisions)
+
+	s.NoError(err)
+	s.Equal(models.ImportSummary{Rows: 3, Created: 1, Updated: 1, Skipped: 1, Matches: 1}, summary)
+}
+
+func (s *MySQLRepositoryTestSuite) TestAuditEventErasure_Unsupported() {
+	s.ErrorIs(s.repo.AllowAuditEventErasure(s.ctx), errors.ErrUnsupported)
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// resolveCursor decodes the page token into a cursor and applies the requested page size
+func resolveCursor(page models.PageRequest) (*utils.Cursor, error) {
+	cursor, err := utils.ResolveCursor(page.Token, page.Size, page.Ascending)
+	if err != nil {
+		return nil, fmt.Errorf("invalid paginationToken: %w", err)
+	}
+	return cursor, nil
+}
+
+// keyset is a page of a listing ordered by creation time and decision id, resolved into the
+// bounds of the query reading it. Queries read one row more than the page holds, to tell whether
+// the listing goes on past it, and read a page before a Backward cursor in reverse order, seeking
+// from its first row, so paginate flips the rows back into the listing order.
+type keyset struct {
+	cursor *utils.Cursor
+	// continued is set for pages following a token, which have a page before them
+	continued bool
+	// page is the 1-based number of an offset page, zero for keyset pagination
+	page int
+}
+
+// newKeyset resolves the page requested into its keyset
+func newKeyset(page models.PageRequest) (*keyset, error) {
+	cursor, err := resolveCursor(page)
+	if err != nil {
+		return nil, err
+	}
+	if page.Token == "" {
+		cursor.ByPopularity = page.ByPopularity
+	}
+	return &keyset{cursor: cursor, continued: page.Token != "", page: page.Page}, nil
+}
+
+// ascending reports whether the rows are read in ascending order, which is the reverse of the
+// listing order for a backward cursor
+func (k *keyset) ascending() bool {
+	return k.cursor.Ascending != k.cursor.Backward
+}
+
+// after returns the creation time and decision id the rows are read after, with a null time for
+// a first page. The id tells apart rows created at the same time, so that a page ending on them
+// neither skips nor repeats the rest. Tokens without the exact time, issued by the likers index,
+// end on a second they listed in full, so they seek past all of it in their direction: rows are
+// listed at their creation second rounded, which spans half a second either side of it.
+func (k *keyset) after() (pgtype.Timestamptz, int64) {
+	if !k.continued {
+		return pgtype.Timestamptz{}, 0
+	}
+	if k.cursor.LastCreatedAtMicros != 0 {
+		return timestamptz(time.UnixMicro(k.cursor.LastCreatedAtMicros)), k.cursor.LastID
+	}
+	second := time.Unix(k.cursor.LastCreatedAt, 0)
+	if k.ascending() {
+		return timestamptz(second.Add(time.Second / 2)), 0
+	}
+	return timestamptz(second.Add(-time.Second / 2)), 0
+}
+
+// listingSecond returns the unix second a row created at t is listed at, rounded as the SQL
+// listings round it
+func listingSecond(t time.Time) int64 {
+	return t.Round(time.Second).Unix()
+}
+
+// limit returns the number of rows to read, one more than the page holds
+func (k *keyset) limit() int32 {
+	return int32(k.cursor.Limit + 1)
+}
+
+// offset returns the number of rows an offset page skips
+func (k *keyset) offset() int32 {
+	return int32(models.PageRequest{Page: k.page}.Offset(k.cursor.Limit))
+}
+
+// paginate trims the rows read for k to its page, in listing order, and returns the tokens of the
+// pages around it. position returns the creation time and decision id of a row. Offset pages
+// are addressed by number, so they get no tokens.
+func paginate[T any](k *keyset, rows []T, position func(T) (time.Time, int64)) ([]T, models.PageTokens, error) {
+	var tokens models.PageTokens
+	more := len(rows) > k.cursor.Limit
+	if more {
+		rows = rows[:k.cursor.Limit] // Remove the extra item
+	}
+	if len(rows) == 0 || k.page > 0 {
+		return rows, tokens, nil
+	}
+	// A page read backward came from the page after it, and has one before it when there are more
+	hasNext, hasPrev := more, k.continued
+	if k.cursor.Backward {
+		slices.Reverse(rows)
+		hasNext, hasPrev = true, more
+	}
+
+	var err error
+	if hasNext {
+		lastCreatedAt, lastID := position(rows[len(rows)-1])
+		if tokens.Next, err = keysetCursor(k.cursor, lastCreatedAt, lastID, false); err != nil {
+			return nil, tokens, fmt.Errorf("failed to encode next paginationToken: %w", err)
+		}
+	}
+	if hasPrev {
+		firstCreatedAt, firstID := position(rows[0])
+		if tokens.Prev, err = keysetCursor(k.cursor, firstCreatedAt, firstID, true); err != nil {
+			return nil, tokens, fmt.Errorf("failed to encode prev paginationToken: %w", err)
+		}
+	}
+	return rows, tokens, nil
+}
+
+// nextCursor returns the token of the page after the one ending on the row at the given
+// creation second and decision id
+func nextCursor(cursor *utils.Cursor, lastCreatedAt, lastID int64) (string, error) {
+	next := &utils.Cursor{
+		LastCreatedAt: lastCreatedAt,
+		LastID:        lastID,
+		Limit:         cursor.Limit,
+		Ascending:     cursor.Ascending,
+	}
+	return next.Encode()
+}
+
+// keysetCursor returns the token of the page after the one ending on the row at the given
+// creation time and decision id, or with backward of the page before the one starting on it
+func keysetCursor(cursor *utils.Cursor, createdAt time.Time, id int64, backward bool) (string, error) {
+	page := &utils.Cursor{
+		LastCreatedAt:       listingSecond(createdAt),
+		LastID:              id,
+		LastCreatedAtMicros: createdAt.UnixMicro(),
+		Limit:               cursor.Limit,
+		Ascending:           cursor.Ascending,
+		Backward:            backward,
+	}
+	return page.Encode()
+}
+
+// decidersRow and newLikersRow are the rows of the listing queries read in ascending and in
+// descending order, which only differ in their order and so convert into each other
+type (
+	decidersRow interface {
+		explorerdb.ListDecidersAscRow | explorerdb.ListDecidersDescRow
+	}
+	newLikersRow interface {
+		explorerdb.ListNewLikersAscRow | explorerdb.ListNewLikersDescRow
+	}
+)
+
+// convertDeciders converts the rows of a deciders listing to the rows of the other order
+func convertDeciders[To, From decidersRow](rows []From) []To {
+	if rows == nil {
+		return nil
+	}
+	converted := make([]To, len(rows))
+	for i, row := range rows {
+		converted[i] = To(row)
+	}
+	return converted
+}
+
+// convertNewLikers converts the rows of a new likers listing to the rows of the other order
+func convertNewLikers[To, From newLikersRow](rows []From) []To {
+	if rows == nil {
+		return nil
+	}
+	converted := make([]To, len(rows))
+	for i, row := range rows {
+		converted[i] = To(row)
+	}
+	return converted
+}
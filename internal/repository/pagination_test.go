@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/utils"
+)
+
+// listingRow is a row of a listing, created at a unix second with a decision id
+type listingRow struct {
+	createdAt int64
+	id        int64
+}
+
+func rowPosition(r listingRow) (time.Time, int64) {
+	return time.Unix(r.createdAt, 0), r.id
+}
+
+type PaginationTestSuite struct {
+	suite.Suite
+	// rows is a listing with several rows created in the same seconds
+	rows []listingRow
+}
+
+func TestPaginationTestSuite(t *testing.T) {
+	suite.Run(t, new(PaginationTestSuite))
+}
+
+func (s *PaginationTestSuite) SetupTest() {
+	s.rows = []listingRow{{100, 1}, {100, 2}, {100, 3}, {200, 4}, {300, 5}, {300, 6}, {400, 7}, {500, 8}, {500, 9}, {600, 10}}
+}
+
+func (s *PaginationTestSuite) keyset(page models.PageRequest) *keyset {
+	keyset, err := newKeyset(page)
+	s.Require().NoError(err)
+	return keyset
+}
+
+func (s *PaginationTestSuite) token(cursor utils.Cursor) string {
+	token, err := cursor.Encode()
+	s.Require().NoError(err)
+	return token
+}
+
+// read reads the rows of k from the listing as the SQL queries do
+func (s *PaginationTestSuite) read(k *keyset) []listingRow {
+	ascending := k.ascending()
+	afterCreatedAt, afterID := k.after()
+
+	var rows []listingRow
+	for _, r := range s.rows {
+		if afterCreatedAt.Valid {
+			order := cmp.Or(time.Unix(r.createdAt, 0).Compare(afterCreatedAt.Time), cmp.Compare(r.id, afterID))
+			if (ascending && order <= 0) || (!ascending && order >= 0) {
+				continue
+			}
+		}
+		rows = append(rows, r)
+	}
+	slices.SortFunc(rows, func(a, b listingRow) int {
+		order := cmp.Or(cmp.Compare(a.createdAt, b.createdAt), cmp.Compare(a.id, b.id))
+		if !ascending {
+			return -order
+		}
+		return order
+	})
+	rows = rows[min(int(k.offset()), len(rows)):]
+	return rows[:min(int(k.limit()), len(rows))]
+}
+
+// page reads the page requested from the listing
+func (s *PaginationTestSuite) page(page models.PageRequest) ([]listingRow, models.PageTokens) {
+	keyset := s.keyset(page)
+	rows, tokens, err := paginate(keyset, s.read(keyset), rowPosition)
+	s.Require().NoError(err)
+	return rows, tokens
+}
+
+func (s *PaginationTestSuite) TestResolvesQueryBounds() {
+	keyset := s.keyset(models.PageRequest{Size: 5})
+	createdAt, id := keyset.after()
+	s.False(createdAt.Valid)
+	s.Zero(id)
+	s.False(keyset.ascending())
+	s.Equal(int32(6), keyset.limit())
+	s.Zero(keyset.offset())
+
+	keyset = s.keyset(models.PageRequest{Token: s.token(utils.Cursor{LastCreatedAt: 300, LastID: 6, LastCreatedAtMicros: 300_000_123, Limit: 2})})
+	createdAt, id = keyset.after()
+	s.Equal(time.UnixMicro(300_000_123), createdAt.Time)
+	s.Equal(int64(6), id)
+	s.Equal(int32(3), keyset.limit())
+}
+
+func (s *PaginationTestSuite) TestSeeksPastWholeSeconds() {
+	// Tokens of the likers index only hold the second, listed at the creation second rounded
+	testCases := []struct {
+		cursor    utils.Cursor
+		ascending bool
+		after     time.Time
+		listed    []listingRow
+	}{
+		{utils.Cursor{LastCreatedAt: 300, Limit: 2}, false, time.Unix(299, 5e8), []listingRow{{200, 4}, {100, 3}}},
+		{utils.Cursor{LastCreatedAt: 300, Limit: 2, Ascending: true}, true, time.Unix(300, 5e8), []listingRow{{400, 7}, {500, 8}}},
+		{utils.Cursor{LastCreatedAt: 300, Limit: 2, Backward: true}, true, time.Unix(300, 5e8), []listingRow{{500, 8}, {400, 7}}},
+		{utils.Cursor{LastCreatedAt: 300, Limit: 2, Ascending: true, Backward: true}, false, time.Unix(299, 5e8), []listingRow{{100, 3}, {200, 4}}},
+	}
+
+	for _, tc := range testCases {
+		keyset := s.keyset(models.PageRequest{Token: s.token(tc.cursor)})
+		afterCreatedAt, afterID := keyset.after()
+
+		s.Equal(tc.ascending, keyset.ascending())
+		s.Equal(tc.after, afterCreatedAt.Time)
+		s.Zero(afterID)
+		rows, _ := s.page(models.PageRequest{Token: s.token(tc.cursor)})
+		s.Equal(tc.listed, rows)
+	}
+}
+
+func (s *PaginationTestSuite) TestFirstPage() {
+	rows, tokens := s.page(models.PageRequest{Size: 4})
+
+	s.Equal([]listingRow{{600, 10}, {500, 9}, {500, 8}, {400, 7}}, rows)
+	s.Empty(tokens.Prev)
+
+	cursor, err := utils.DecodeCursor(tokens.Next)
+	s.Require().NoError(err)
+	s.Equal(&utils.Cursor{LastCreatedAt: 400, LastID: 7, LastCreatedAtMicros: 400_000_000, Limit: 4}, cursor)
+}
+
+func (s *PaginationTestSuite) TestSinglePage() {
+	rows, tokens := s.page(models.PageRequest{Size: len(s.rows)})
+
+	s.Len(rows, len(s.rows))
+	s.Empty(tokens.Next)
+	s.Empty(tokens.Prev)
+}
+
+func (s *PaginationTestSuite) TestEmptyPage() {
+	s.rows = nil
+
+	rows, tokens := s.page(models.PageRequest{Token: s.token(utils.Cursor{LastCreatedAt: 300, LastID: 5, Limit: 2})})
+
+	s.Empty(rows)
+	s.Empty(tokens.Next)
+	s.Empty(tokens.Prev)
+}
+
+func (s *PaginationTestSuite) TestOffsetPage() {
+	rows, tokens := s.page(models.PageRequest{Size: 3, Page: 2, Ascending: true})
+
+	s.Equal([]listingRow{{200, 4}, {300, 5}, {300, 6}}, rows)
+	s.Empty(tokens.Next)
+	s.Empty(tokens.Prev)
+}
+
+func (s *PaginationTestSuite) TestWalksListingBothWays() {
+	for _, ascending := range []bool{false, true} {
+		for size := 1; size <= len(s.rows); size++ {
+			// Forward from the first page to the last, every row once, in order
+			var pages [][]listingRow
+			var prevTokens []string
+			var listed []listingRow
+			page := models.PageRequest{Size: size, Ascending: ascending}
+			for {
+				rows, tokens := s.page(page)
+				pages = append(pages, rows)
+				prevTokens = append(prevTokens, tokens.Prev)
+				listed = append(listed, rows...)
+				if tokens.Next == "" {
+					break
+				}
+				page = models.PageRequest{Token: tokens.Next}
+			}
+
+			expected := slices.Clone(s.rows)
+			if !ascending {
+				slices.Reverse(expected)
+			}
+			s.Equal(expected, listed, "ascending %t, size %d", ascending, size)
+			s.Empty(prevTokens[0])
+
+			// Back from the last page to the first, through the same pages
+			for i := len(pages) - 1; i > 0; i-- {
+				rows, tokens := s.page(models.PageRequest{Token: prevTokens[i]})
+
+				s.Equal(pages[i-1], rows, "ascending %t, size %d, page %d", ascending, size, i-1)
+				s.Equal(prevTokens[i-1], tokens.Prev)
+				s.NotEmpty(tokens.Next)
+			}
+		}
+	}
+}
+
+func (s *PaginationTestSuite) TestBackwardThenForward() {
+	// Going back a page and then forward again returns to the same page
+	_, tokens := s.page(models.PageRequest{Size: 3})
+	second, tokens := s.page(models.PageRequest{Token: tokens.Next})
+	_, tokens = s.page(models.PageRequest{Token: tokens.Prev})
+
+	rows, _ := s.page(models.PageRequest{Token: tokens.Next})
+
+	s.Equal(second, rows)
+}
+
+func (s *PaginationTestSuite) TestRejectsInvalidToken() {
+	_, err := newKeyset(models.PageRequest{Token: "not a token"})
+
+	s.ErrorContains(err, "invalid paginationToken")
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/providers/database"
+)
+
+type ReportRepository interface {
+	CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (explorerdb.Report, error)
+}
+
+type reportStore struct {
+	*explorerdb.Queries
+	logger *zap.Logger
+}
+
+func NewReportRepository(db database.DBProvider, logger *zap.Logger) ReportRepository {
+	return &reportStore{
+		logger:  logger,
+		Queries: explorerdb.New(db),
+	}
+}
@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/repository"
+)
+
+type ReportRepositoryTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo repository.ReportRepository
+	ctx  context.Context
+}
+
+func TestReportRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportRepositoryTestSuite))
+}
+
+func (s *ReportRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	var err error
+	s.mock, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+
+	s.repo = repository.NewReportRepository(s.mock, zaptest.NewLogger(s.T()))
+}
+
+func (s *ReportRepositoryTestSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *ReportRepositoryTestSuite) TestCreateReport_Success() {
+	params := explorerdb.CreateReportParams{
+		ReporterUserID: "user123",
+		ReportedUserID: "user456",
+		Reason:         "spam",
+	}
+	createdAt := pgtype.Timestamptz{Time: time.Unix(1640995200, 0), Valid: true}
+	liked := true
+
+	expectedSQL := `INSERT INTO reports .* \(SELECT liked_recipient FROM decisions WHERE actor_user_id = \$1 AND recipient_user_id = \$2\), \(SELECT liked_recipient FROM decisions WHERE actor_user_id = \$2 AND recipient_user_id = \$1\).* RETURNING .*`
+
+	rows := pgxmock.NewRows([]string{"id", "reporter_user_id", "reported_user_id", "reason", "reporter_liked", "reported_liked", "created_at"}).
+		AddRow(int64(42), params.ReporterUserID, params.ReportedUserID, params.Reason, &liked, (*bool)(nil), createdAt)
+
+	s.mock.ExpectQuery(expectedSQL).
+		WithArgs(params.ReporterUserID, params.ReportedUserID, params.Reason).
+		WillReturnRows(rows)
+
+	report, err := s.repo.CreateReport(s.ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(42), report.ID)
+	s.Require().NotNil(report.ReporterLiked)
+	s.True(*report.ReporterLiked)
+	s.Nil(report.ReportedLiked)
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ReportRepositoryTestSuite) TestCreateReport_DatabaseError() {
+	params := explorerdb.CreateReportParams{
+		ReporterUserID: "user123",
+		ReportedUserID: "user456",
+		Reason:         "spam",
+	}
+
+	s.mock.ExpectQuery(`INSERT INTO reports .*`).
+		WithArgs(params.ReporterUserID, params.ReportedUserID, params.Reason).
+		WillReturnError(errors.New("database timeout"))
+
+	_, err := s.repo.CreateReport(s.ctx, params)
+
+	s.Error(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
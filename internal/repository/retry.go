@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+)
+
+var dbRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "explore_db_retries_total",
+	Help: "Repository calls retried after a transient DB error, by operation.",
+}, []string{"operation"})
+
+// retryingExplorerRepository retries repository calls that fail with a transient DB error.
+// Reads are retried on any transient error, as running them twice is harmless. Decisions are
+// only retried when the error proves nothing was written: the server rolled the transaction
+// back, or the request never reached it. A connection lost mid-commit leaves the outcome
+// unknown, so such a write fails rather than risk being applied twice.
+type retryingExplorerRepository struct {
+	ExplorerRepository
+	cfg    config.RetryConfig
+	logger *zap.Logger
+}
+
+// NewRetryingExplorerRepository retries transient DB errors of repo as configured by cfg
+func NewRetryingExplorerRepository(repo ExplorerRepository, cfg config.RetryConfig, logger *zap.Logger) ExplorerRepository {
+	return &retryingExplorerRepository{
+		ExplorerRepository: repo,
+		cfg:                cfg,
+		logger:             logger,
+	}
+}
+
+// retryableWrite reports whether err guarantees the failed call wrote nothing
+func retryableWrite(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", "40P01": // serialization_failure, deadlock_detected
+			return true
+		}
+		return false
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		// ER_LOCK_DEADLOCK, ER_LOCK_WAIT_TIMEOUT: the transaction is rolled back either way
+		return mysqlErr.Number == 1213 || mysqlErr.Number == 1205
+	}
+	var canceledErr *types.TransactionCanceledException
+	if errors.As(err, &canceledErr) {
+		// A DynamoDB transaction canceled because it raced another one wrote none of its items
+		for _, reason := range canceledErr.CancellationReasons {
+			switch aws.ToString(reason.Code) {
+			case "None", "ConditionalCheckFailed", "TransactionConflict":
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	var conflictErr *types.TransactionConflictException
+	if errors.As(err, &conflictErr) {
+		return true
+	}
+	// database/sql is only handed driver.ErrBadConn for a request that was never sent
+	return pgconn.SafeToRetry(err) || errors.Is(err, driver.ErrBadConn)
+}
+
+// retryableRead reports whether err is transient, such as a dropped connection or a failover
+func retryableRead(err error) bool {
+	if retryableWrite(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection exceptions; 57P01-57P03 are raised while a server shuts down or starts up
+		return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, mysql.ErrInvalidConn)
+}
+
+// do calls fn until it succeeds, fails with an error retryable rejects, runs out of attempts or ctx is done
+func (r *retryingExplorerRepository) do(ctx context.Context, operation string, retryable func(error) bool, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.cfg.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		dbRetries.WithLabelValues(operation).Inc()
+		r.logger.Warn("Retrying transient DB error",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Error(err))
+
+		timer := time.NewTimer(r.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the retry following attempt, with full jitter
+func (r *retryingExplorerRepository) backoff(attempt int) time.Duration {
+	ceiling := r.cfg.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > r.cfg.MaxDelay {
+		ceiling = r.cfg.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+func (r *retryingExplorerRepository) GetLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	var (
+		likers []models.Liker
+		tokens models.PageTokens
+	)
+	err := r.do(ctx, "GetLikers", retryableRead, func() error {
+		var err error
+		likers, tokens, err = r.ExplorerRepository.GetLikers(ctx, recipientUserID, page)
+		return err
+	})
+	return likers, tokens, err
+}
+
+func (r *retryingExplorerRepository) CountLikers(ctx context.Context, recipientUserID string) (int64, error) {
+	var count int64
+	err := r.do(ctx, "CountLikers", retryableRead, func() error {
+		var err error
+		count, err = r.ExplorerRepository.CountLikers(ctx, recipientUserID)
+		return err
+	})
+	return count, err
+}
+
+func (r *retryingExplorerRepository) GetNewLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	var (
+		likers []models.Liker
+		tokens models.PageTokens
+	)
+	err := r.do(ctx, "GetNewLikers", retryableRead, func() error {
+		var err error
+		likers, tokens, err = r.ExplorerRepository.GetNewLikers(ctx, recipientUserID, page)
+		return err
+	})
+	return likers, tokens, err
+}
+
+func (r *retryingExplorerRepository) GetPassers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	var (
+		passers []models.Liker
+		tokens  models.PageTokens
+	)
+	err := r.do(ctx, "GetPassers", retryableRead, func() error {
+		var err error
+		passers, tokens, err = r.ExplorerRepository.GetPassers(ctx, recipientUserID, page)
+		return err
+	})
+	return passers, tokens, err
+}
+
+func (r *retryingExplorerRepository) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error) {
+	var (
+		recipients []models.Recipient
+		token      string
+	)
+	err := r.do(ctx, "GetLikedRecipients", retryableRead, func() error {
+		var err error
+		recipients, token, err = r.ExplorerRepository.GetLikedRecipients(ctx, actorUserID, page)
+		return err
+	})
+	return recipients, token, err
+}
+
+func (r *retryingExplorerRepository) GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error) {
+	var (
+		matches []models.Match
+		token   string
+	)
+	err := r.do(ctx, "GetMatches", retryableRead, func() error {
+		var err error
+		matches, token, err = r.ExplorerRepository.GetMatches(ctx, userID, page)
+		return err
+	})
+	return matches, token, err
+}
+
+func (r *retryingExplorerRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	var count int64
+	err := r.do(ctx, "CountLikes", retryableRead, func() error {
+		var err error
+		count, err = r.ExplorerRepository.CountLikes(ctx, recipientUserID)
+		return err
+	})
+	return count, err
+}
+
+func (r *retryingExplorerRepository) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (int64, error) {
+	var count int64
+	err := r.do(ctx, "CountRecentLikes", retryableRead, func() error {
+		var err error
+		count, err = r.ExplorerRepository.CountRecentLikes(ctx, arg)
+		return err
+	})
+	return count, err
+}
+
+func (r *retryingExplorerRepository) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (explorerdb.Decision, error) {
+	var decision explorerdb.Decision
+	err := r.do(ctx, "GetDecision", retryableRead, func() error {
+		var err error
+		decision, err = r.ExplorerRepository.GetDecision(ctx, arg)
+		return err
+	})
+	return decision, err
+}
+
+func (r *retryingExplorerRepository) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+	var mutualLike *bool
+	err := r.do(ctx, "HasMutualLike", retryableRead, func() error {
+		var err error
+		mutualLike, err = r.ExplorerRepository.HasMutualLike(ctx, arg)
+		return err
+	})
+	return mutualLike, err
+}
+
+func (r *retryingExplorerRepository) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (bool, error) {
+	var blocked bool
+	err := r.do(ctx, "IsBlocked", retryableRead, func() error {
+		var err error
+		blocked, err = r.ExplorerRepository.IsBlocked(ctx, arg)
+		return err
+	})
+	return blocked, err
+}
+
+func (r *retryingExplorerRepository) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	var recorded models.RecordedDecision
+	err := r.do(ctx, "RecordDecision", retryableWrite, func() error {
+		var err error
+		recorded, err = r.ExplorerRepository.RecordDecision(ctx, decision, events)
+		return err
+	})
+	return recorded, err
+}
+
+func (r *retryingExplorerRepository) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	var removed models.RemovedDecision
+	err := r.do(ctx, "RemoveDecision", retryableWrite, func() error {
+		var err error
+		removed, err = r.ExplorerRepository.RemoveDecision(ctx, decision)
+		return err
+	})
+	return removed, err
+}
+
+func (r *retryingExplorerRepository) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error) {
+	var results []models.DecisionResult
+	err := r.do(ctx, "CreateDecisions", retryableWrite, func() error {
+		var err error
+		results, err = r.ExplorerRepository.CreateDecisions(ctx, decisions, events)
+		return err
+	})
+	return results, err
+}
+
+func (r *retryingExplorerRepository) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error) {
+	var summary models.IngestSummary
+	err := r.do(ctx, "IngestDecisions", retryableWrite, func() error {
+		var err error
+		summary, err = r.ExplorerRepository.IngestDecisions(ctx, decisions)
+		return err
+	})
+	return summary, err
+}
+
+func (r *retryingExplorerRepository) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error) {
+	var summary models.ImportSummary
+	err := r.do(ctx, "ImportDecisions", retryableWrite, func() error {
+		var err error
+		summary, err = r.ExplorerRepository.ImportDecisions(ctx, decisions)
+		return err
+	})
+	return summary, err
+}
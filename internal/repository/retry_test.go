@@ -0,0 +1,197 @@
+package repository_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/backend-interview-task/config"
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+	"github.com/backend-interview-task/internal/models"
+	"github.com/backend-interview-task/internal/repository"
+)
+
+const countLikersSQL = `SELECT COALESCE\(\( SELECT lc.count FROM like_counts lc WHERE lc.recipient_user_id = \$1 \), 0\)`
+
+type RetryTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo repository.ExplorerRepository
+	ctx  context.Context
+}
+
+func TestRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
+
+func (s *RetryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	var err error
+	s.mock, err = pgxmock.NewPool()
+	s.Require().NoError(err)
+
+	logger := zaptest.NewLogger(s.T())
+	s.repo = repository.NewRetryingExplorerRepository(repository.NewExplorerRepository(s.mock, 0, logger), config.RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}, logger)
+}
+
+func (s *RetryTestSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+// expectPass expects a first pass of actor1 on recipient1 to be recorded, its commit failing with commitErr
+func (s *RetryTestSuite) expectPass(commitErr error) {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	s.mock.ExpectQuery(`WITH previous AS .* INSERT INTO decisions .*`).
+		WithArgs("actor1", "recipient1", false).
+		WillReturnRows(pgxmock.NewRows([]string{"inserted", "previous_liked_recipient", "created_at"}).AddRow(true, false, listedAt(1700000000)))
+	s.mock.ExpectExec(`DELETE FROM matches .*`).
+		WithArgs("actor1", "recipient1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	commit := s.mock.ExpectCommit()
+	if commitErr != nil {
+		commit.WillReturnError(commitErr)
+	}
+}
+
+func (s *RetryTestSuite) TestRead_RetriesSerializationFailure() {
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(&pgconn.PgError{Code: "40001"})
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(4)))
+
+	count, err := s.repo.CountLikers(s.ctx, "user123")
+
+	s.NoError(err)
+	s.Equal(int64(4), count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRead_RetriesLostConnection() {
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(io.ErrUnexpectedEOF)
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(&pgconn.PgError{Code: "57P01"})
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(4)))
+
+	count, err := s.repo.CountLikers(s.ctx, "user123")
+
+	s.NoError(err)
+	s.Equal(int64(4), count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRead_RetriesMySQLDeadlock() {
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(mysql.ErrInvalidConn)
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(4)))
+
+	count, err := s.repo.CountLikers(s.ctx, "user123")
+
+	s.NoError(err)
+	s.Equal(int64(4), count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRead_GivesUpAfterMaxAttempts() {
+	for i := 0; i < 3; i++ {
+		s.mock.ExpectQuery(countLikersSQL).
+			WithArgs("user123").
+			WillReturnError(&pgconn.PgError{Code: "40001"})
+	}
+
+	_, err := s.repo.CountLikers(s.ctx, "user123")
+
+	var pgErr *pgconn.PgError
+	s.Require().ErrorAs(err, &pgErr)
+	s.Equal("40001", pgErr.Code)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRead_DoesNotRetryPermanentError() {
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(&pgconn.PgError{Code: "42P01"})
+
+	_, err := s.repo.CountLikers(s.ctx, "user123")
+
+	var pgErr *pgconn.PgError
+	s.Require().ErrorAs(err, &pgErr)
+	s.Equal("42P01", pgErr.Code)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRead_StopsWaitingWhenContextDone() {
+	repo := repository.NewRetryingExplorerRepository(repository.NewExplorerRepository(s.mock, 0, zaptest.NewLogger(s.T())), config.RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Hour,
+		MaxDelay:    time.Hour,
+	}, zaptest.NewLogger(s.T()))
+	ctx, cancel := context.WithTimeout(s.ctx, 20*time.Millisecond)
+	defer cancel()
+
+	s.mock.ExpectQuery(countLikersSQL).
+		WithArgs("user123").
+		WillReturnError(io.ErrUnexpectedEOF)
+
+	started := time.Now()
+	_, err := repo.CountLikers(ctx, "user123")
+
+	s.ErrorIs(err, io.ErrUnexpectedEOF)
+	s.Less(time.Since(started), time.Second)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRecordDecision_RetriesRolledBackTransaction() {
+	// The server reports the transaction rolled back, so nothing was written
+	s.expectPass(&pgconn.PgError{Code: "40001"})
+	s.expectPass(nil)
+
+	recorded, err := s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor1",
+		RecipientUserID: "recipient1",
+	}, models.DecisionEvents{})
+
+	s.NoError(err)
+	s.Equal(models.RecordedDecision{Created: true, Timestamp: 1700000000}, recorded)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RetryTestSuite) TestRecordDecision_DoesNotRetryLostCommit() {
+	// The connection dropped during commit, which may or may not have been applied
+	s.expectPass(io.ErrUnexpectedEOF)
+
+	_, err := s.repo.RecordDecision(s.ctx, explorerdb.CreateDecisionParams{
+		ActorUserID:     "actor1",
+		RecipientUserID: "recipient1",
+	}, models.DecisionEvents{})
+
+	s.ErrorIs(err, io.ErrUnexpectedEOF)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
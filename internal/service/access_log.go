@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/backend-interview-task/utils"
+)
+
+type accessLogContextKey struct{}
+
+// accessLogEntry collects what the interceptors after the access log learn about a call. The
+// access log runs ahead of authentication so that rejected calls are logged too, and so only
+// sees the caller through the entry authentication fills in.
+type accessLogEntry struct {
+	caller        utils.Caller
+	authenticated bool
+}
+
+// noteCaller records the authenticated caller in the access log entry of ctx, if any
+func noteCaller(ctx context.Context, caller utils.Caller) {
+	if entry, ok := ctx.Value(accessLogContextKey{}).(*accessLogEntry); ok {
+		entry.caller = caller
+		entry.authenticated = true
+	}
+}
+
+// recipientRequest is a request about a recipient, whose ID is logged with the call
+type recipientRequest interface {
+	GetRecipientUserId() string
+}
+
+// peerHost returns the IP address the call ctx handles came from
+func peerHost(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String(), true
+	}
+	return host, true
+}
+
+// messageSize returns the encoded size of a gRPC message
+func messageSize(msg interface{}) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
+
+// accessLogFields returns the fields common to the access logs of unary and streaming calls
+func accessLogFields(ctx context.Context, entry *accessLogEntry, fullMethod string, duration time.Duration, err error) []zap.Field {
+	fields := []zap.Field{
+		zap.String("method", fullMethod),
+		zap.Duration("duration", duration),
+		zap.String("code", status.Code(err).String()),
+	}
+	if host, ok := peerHost(ctx); ok {
+		fields = append(fields, zap.String("peer_ip", host))
+	}
+	if entry.authenticated {
+		fields = append(fields, zap.String("user_id", entry.caller.UserID), zap.Bool("service_caller", entry.caller.Service))
+	}
+	return fields
+}
+
+// logAccess writes the access log line of a call
+func logAccess(ctx context.Context, logger *zap.Logger, msg string, fields []zap.Field, err error) {
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+		utils.Logger(ctx, logger).Error(msg+" failed", fields...)
+		return
+	}
+	utils.Logger(ctx, logger).Info(msg+" completed", fields...)
+}
+
+// UnaryAccessLogInterceptor logs every unary RPC with its caller, peer IP, status code, request
+// and response sizes and the recipient it is about, so calls can be traced back to who made them
+func UnaryAccessLogInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		entry := &accessLogEntry{}
+		resp, err := handler(context.WithValue(ctx, accessLogContextKey{}, entry), req)
+
+		fields := accessLogFields(ctx, entry, info.FullMethod, time.Since(start), err)
+		fields = append(fields, zap.Int("request_bytes", messageSize(req)))
+		if err == nil {
+			fields = append(fields, zap.Int("response_bytes", messageSize(resp)))
+		}
+		if r, ok := req.(recipientRequest); ok && r.GetRecipientUserId() != "" {
+			fields = append(fields, zap.String("recipient_user_id", r.GetRecipientUserId()))
+		}
+		logAccess(ctx, logger, "gRPC call", fields, err)
+
+		return resp, err
+	}
+}
+
+// StreamAccessLogInterceptor logs every streaming RPC once it ends, like its unary counterpart,
+// with the messages and bytes received and sent over the stream
+func StreamAccessLogInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := stream.Context()
+		entry := &accessLogEntry{}
+		counted := &countingServerStream{ServerStream: stream, ctx: context.WithValue(ctx, accessLogContextKey{}, entry)}
+		err := handler(srv, counted)
+
+		fields := accessLogFields(ctx, entry, info.FullMethod, time.Since(start), err)
+		fields = append(fields,
+			zap.Int("messages_received", counted.received),
+			zap.Int("request_bytes", counted.receivedBytes),
+			zap.Int("messages_sent", counted.sent),
+			zap.Int("response_bytes", counted.sentBytes))
+		if counted.recipientUserID != "" {
+			fields = append(fields, zap.String("recipient_user_id", counted.recipientUserID))
+		}
+		logAccess(ctx, logger, "gRPC stream", fields, err)
+
+		return err
+	}
+}
+
+// countingServerStream counts the messages of a stream and keeps the recipient of the first
+// message naming one
+type countingServerStream struct {
+	grpc.ServerStream
+	ctx             context.Context
+	received        int
+	receivedBytes   int
+	sent            int
+	sentBytes       int
+	recipientUserID string
+}
+
+func (s *countingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *countingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.received++
+	s.receivedBytes += messageSize(m)
+	if r, ok := m.(recipientRequest); ok && s.recipientUserID == "" {
+		s.recipientUserID = r.GetRecipientUserId()
+	}
+	return nil
+}
+
+func (s *countingServerStream) SendMsg(m interface{}) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.sent++
+	s.sentBytes += messageSize(m)
+	return nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+// recordedStream is a server stream receiving the queued messages and recording those sent
+type recordedStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	incoming []proto.Message
+	sent     []interface{}
+}
+
+func (f *recordedStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *recordedStream) RecvMsg(m interface{}) error {
+	if len(f.incoming) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), f.incoming[0])
+	f.incoming = f.incoming[1:]
+	return nil
+}
+
+func (f *recordedStream) SendMsg(m interface{}) error {
+	f.sent = append(f.sent, m)
+	return nil
+}
+
+type AccessLogInterceptorTestSuite struct {
+	suite.Suite
+	logs   *observer.ObservedLogs
+	logger *zap.Logger
+	ctx    context.Context
+}
+
+func TestAccessLogInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessLogInterceptorTestSuite))
+}
+
+func (s *AccessLogInterceptorTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s.logs = logs
+	s.logger = zap.New(core)
+	s.ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.9"), Port: 51234}})
+}
+
+// entry returns the only access log line written
+func (s *AccessLogInterceptorTestSuite) entry() (string, map[string]interface{}) {
+	entries := s.logs.AllUntimed()
+	s.Require().Len(entries, 1)
+	return entries[0].Message, entries[0].ContextMap()
+}
+
+func (s *AccessLogInterceptorTestSuite) TestUnary_LogsCallDetails() {
+	interceptor := UnaryAccessLogInterceptor(s.logger)
+	req := &pb.ListLikedYouRequest{RecipientUserId: "user123"}
+	resp := &pb.ListLikedYouResponse{Likers: []*pb.ListLikedYouResponse_Liker{{ActorId: "user456"}}}
+
+	_, err := interceptor(s.ctx, req, &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/ListLikedYou"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			// As the auth interceptor further down the chain does
+			noteCaller(ctx, utils.Caller{UserID: "user123"})
+			return resp, nil
+		})
+	s.Require().NoError(err)
+
+	msg, fields := s.entry()
+	s.Equal("gRPC call completed", msg)
+	s.Equal("/explore.ExploreService/ListLikedYou", fields["method"])
+	s.Equal("OK", fields["code"])
+	s.Equal("203.0.113.9", fields["peer_ip"])
+	s.Equal("user123", fields["user_id"])
+	s.Equal(false, fields["service_caller"])
+	s.Equal("user123", fields["recipient_user_id"])
+	s.EqualValues(proto.Size(req), fields["request_bytes"])
+	s.EqualValues(proto.Size(resp), fields["response_bytes"])
+}
+
+func (s *AccessLogInterceptorTestSuite) TestUnary_LogsRejectedCall() {
+	interceptor := UnaryAccessLogInterceptor(s.logger)
+
+	_, err := interceptor(s.ctx, &pb.CountLikedYouRequest{}, &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/CountLikedYou"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		})
+	s.Require().Error(err)
+
+	msg, fields := s.entry()
+	s.Equal("gRPC call failed", msg)
+	s.Equal("Unauthenticated", fields["code"])
+	s.NotContains(fields, "user_id")
+	s.NotContains(fields, "recipient_user_id")
+	s.NotContains(fields, "response_bytes")
+}
+
+func (s *AccessLogInterceptorTestSuite) TestStream_CountsMessages() {
+	interceptor := StreamAccessLogInterceptor(s.logger)
+	req := &pb.WatchNewLikesRequest{RecipientUserId: "user123"}
+	event := &pb.WatchNewLikesEvent{ActorId: "user456"}
+	stream := &recordedStream{ctx: s.ctx, incoming: []proto.Message{req}}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/explore.ExploreService/WatchNewLikes"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			noteCaller(stream.Context(), utils.Caller{UserID: "indexer", Service: true})
+			received := &pb.WatchNewLikesRequest{}
+			if err := stream.RecvMsg(received); err != nil {
+				return err
+			}
+			if err := stream.SendMsg(event); err != nil {
+				return err
+			}
+			return stream.SendMsg(event)
+		})
+	s.Require().NoError(err)
+
+	msg, fields := s.entry()
+	s.Equal("gRPC stream completed", msg)
+	s.Equal("indexer", fields["user_id"])
+	s.Equal(true, fields["service_caller"])
+	s.Equal("user123", fields["recipient_user_id"])
+	s.EqualValues(1, fields["messages_received"])
+	s.EqualValues(proto.Size(req), fields["request_bytes"])
+	s.EqualValues(2, fields["messages_sent"])
+	s.EqualValues(2*proto.Size(event), fields["response_bytes"])
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/core"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+// AdminService implements the admin gRPC service. It is only registered when admin RPCs are
+// enabled, and every RPC is only served to service accounts.
+type AdminService struct {
+	pb.UnimplementedAdminServiceServer
+	requestValidator
+	core core.AdminCore
+}
+
+func NewAdminService(core core.AdminCore, pagination config.PaginationConfig, userIDs *UserIDValidator, logger *zap.Logger) *AdminService {
+	return &AdminService{
+		requestValidator: newRequestValidator(pagination, userIDs, logger),
+		core:             core,
+	}
+}
+
+// ListUserDecisions returns the decisions the user made and the decisions made on them
+func (s *AdminService) ListUserDecisions(ctx context.Context, req *pb.ListUserDecisionsRequest) (*pb.ListUserDecisionsResponse, error) {
+	if err := authorizeService(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := s.validatePageSize(req.PageSize); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.ListUserDecisions(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to list user decisions", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list user decisions")
+	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExportUserData streams the decisions the user made and the decisions made on them as a JSON or
+// CSV document, for data subject access requests
+func (s *AdminService) ExportUserData(req *pb.ExportUserDataRequest, stream pb.AdminService_ExportUserDataServer) error {
+	if err := authorizeService(stream.Context()); err != nil {
+		return err
+	}
+	if err := s.validateUserID("user_id", req.UserId); err != nil {
+		return err
+	}
+	if _, ok := pb.ExportFormat_name[int32(req.Format)]; !ok {
+		return status.Error(codes.InvalidArgument, "invalid format")
+	}
+
+	if err := s.core.ExportUserData(stream.Context(), req, stream.Send); err != nil {
+		utils.Logger(stream.Context(), s.logger).Error("Failed to export user data", zap.Error(err))
+		return status.Error(codes.Internal, "failed to export user data")
+	}
+
+	return nil
+}
+
+// PurgeUserData deletes the user's decisions, matches, blocks and like count
+func (s *AdminService) PurgeUserData(ctx context.Context, req *pb.PurgeUserDataRequest) (*pb.PurgeUserDataResponse, error) {
+	if err := authorizeService(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.PurgeUserData(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to purge user data", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to purge user data")
+	}
+
+	return resp, nil
+}
+
+// EraseUserData erases the user under the right to erasure and returns the receipt of the erasure
+func (s *AdminService) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	if err := authorizeService(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.EraseUserData(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to erase user data", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to erase user data")
+	}
+
+	return resp, nil
+}
+
+// InvalidateRecipientCache drops the recipient's cached listings, to be rebuilt on their next read
+func (s *AdminService) InvalidateRecipientCache(ctx context.Context, req *pb.InvalidateRecipientCacheRequest) (*pb.InvalidateRecipientCacheResponse, error) {
+	if err := authorizeService(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.InvalidateRecipientCache(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to invalidate recipient cache", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to invalidate recipient cache")
+	}
+
+	return resp, nil
+}
+
+// GetServiceStats reports the size of the service's tables and the outbox backlog
+func (s *AdminService) GetServiceStats(ctx context.Context, req *pb.GetServiceStatsRequest) (*pb.GetServiceStatsResponse, error) {
+	if err := authorizeService(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.GetServiceStats(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get service stats", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get service stats")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	coremock "github.com/backend-interview-task/mocks/core"
+	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
+)
+
+type AdminServiceTestSuite struct {
+	suite.Suite
+	mockCore *coremock.AdminCore
+	service  *AdminService
+	ctx      context.Context
+}
+
+func TestAdminServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminServiceTestSuite))
+}
+
+func (s *AdminServiceTestSuite) SetupTest() {
+	s.ctx = utils.WithCaller(context.Background(), utils.Caller{UserID: "support", Service: true})
+	s.mockCore = new(coremock.AdminCore)
+	s.service = s.newService(config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100})
+}
+
+func (s *AdminServiceTestSuite) TearDownTest() {
+	s.mockCore.AssertExpectations(s.T())
+}
+
+// newService returns a service reading page sizes and tokens with pagination
+func (s *AdminServiceTestSuite) newService(pagination config.PaginationConfig) *AdminService {
+	userIDs, err := NewUserIDValidator(config.UserIDsConfig{Format: UserIDFormatAny, MaxLength: 128})
+	s.Require().NoError(err)
+	return NewAdminService(s.mockCore, pagination, userIDs, zaptest.NewLogger(s.T()))
+}
+
+func (s *AdminServiceTestSuite) TestUserCallerDenied() {
+	ctx := utils.WithCaller(context.Background(), utils.Caller{UserID: "user123"})
+
+	_, err := s.service.ListUserDecisions(ctx, &pb.ListUserDecisionsRequest{UserId: "user123"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.PurgeUserData(ctx, &pb.PurgeUserDataRequest{UserId: "user123"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	err = s.service.ExportUserData(&pb.ExportUserDataRequest{UserId: "user123"}, &exportUserDataStream{ctx: ctx})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.EraseUserData(ctx, &pb.EraseUserDataRequest{UserId: "user123"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.InvalidateRecipientCache(ctx, &pb.InvalidateRecipientCacheRequest{RecipientUserId: "user123"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.GetServiceStats(ctx, &pb.GetServiceStatsRequest{})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *AdminServiceTestSuite) TestUnauthenticatedServerAllowed() {
+	req := &pb.GetServiceStatsRequest{}
+	s.mockCore.EXPECT().GetServiceStats(context.Background(), req).Return(&pb.GetServiceStatsResponse{Decisions: 5}, nil).Once()
+
+	resp, err := s.service.GetServiceStats(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(uint64(5), resp.Decisions)
+}
+
+func (s *AdminServiceTestSuite) TestListUserDecisions_SignedPaginationTokens() {
+	key := strings.Repeat("k", 32)
+	service := s.newService(config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100, CursorKeys: []string{key}})
+	signer := utils.NewPageTokenSigner([]string{key}, 0)
+	issued, err := signer.Sign("token456")
+	s.Require().NoError(err)
+
+	s.mockCore.EXPECT().ListUserDecisions(mock.Anything, mock.MatchedBy(func(req *pb.ListUserDecisionsRequest) bool {
+		return req.UserId == "user123" && req.GetPaginationToken() == "token456"
+	})).Return(&pb.ListUserDecisionsResponse{NextPaginationToken: utils.ToPointer("next_token")}, nil).Once()
+
+	resp, err := service.ListUserDecisions(s.ctx, &pb.ListUserDecisionsRequest{UserId: "user123", PaginationToken: &issued})
+
+	s.Require().NoError(err)
+	next, err := signer.Verify(resp.GetNextPaginationToken())
+	s.NoError(err)
+	s.Equal("next_token", next)
+}
+
+func (s *AdminServiceTestSuite) TestListUserDecisions_InvalidRequest() {
+	testCases := []struct {
+		name string
+		req  *pb.ListUserDecisionsRequest
+	}{
+		{name: "empty user_id", req: &pb.ListUserDecisionsRequest{}},
+		{name: "page_size too large", req: &pb.ListUserDecisionsRequest{UserId: "user123", PageSize: utils.ToPointer(uint32(101))}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.service.ListUserDecisions(s.ctx, tc.req)
+
+			s.Nil(resp)
+			s.Equal(codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func (s *AdminServiceTestSuite) TestPurgeUserData_Success() {
+	req := &pb.PurgeUserDataRequest{UserId: "user123"}
+	expectedResp := &pb.PurgeUserDataResponse{DecisionsDeleted: 4, MatchesDeleted: 1}
+	s.mockCore.EXPECT().PurgeUserData(s.ctx, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.PurgeUserData(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *AdminServiceTestSuite) TestPurgeUserData_CoreError() {
+	req := &pb.PurgeUserDataRequest{UserId: "user123"}
+	s.mockCore.EXPECT().PurgeUserData(s.ctx, req).Return(nil, errors.New("database error")).Once()
+
+	resp, err := s.service.PurgeUserData(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+// exportUserDataStream is an in-memory ExportUserData stream collecting the sent chunks
+type exportUserDataStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pb.ExportUserDataChunk
+}
+
+func (f *exportUserDataStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *exportUserDataStream) Send(chunk *pb.ExportUserDataChunk) error {
+	f.chunks = append(f.chunks, chunk)
+	return nil
+}
+
+func (s *AdminServiceTestSuite) TestExportUserData_Success() {
+	req := &pb.ExportUserDataRequest{UserId: "user123", Format: pb.ExportFormat_CSV}
+	stream := &exportUserDataStream{ctx: s.ctx}
+	s.mockCore.EXPECT().ExportUserData(s.ctx, req, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *pb.ExportUserDataRequest, send func(*pb.ExportUserDataChunk) error) error {
+			return send(&pb.ExportUserDataChunk{Data: []byte("actor_user_id\n")})
+		}).Once()
+
+	err := s.service.ExportUserData(req, stream)
+
+	s.NoError(err)
+	s.Require().Len(stream.chunks, 1)
+	s.Equal("actor_user_id\n", string(stream.chunks[0].Data))
+}
+
+func (s *AdminServiceTestSuite) TestExportUserData_InvalidFormat() {
+	err := s.service.ExportUserData(&pb.ExportUserDataRequest{UserId: "user123", Format: pb.ExportFormat(7)}, &exportUserDataStream{ctx: s.ctx})
+
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *AdminServiceTestSuite) TestExportUserData_CoreError() {
+	req := &pb.ExportUserDataRequest{UserId: "user123"}
+	s.mockCore.EXPECT().ExportUserData(s.ctx, req, mock.Anything).Return(errors.New("database error")).Once()
+
+	err := s.service.ExportUserData(req, &exportUserDataStream{ctx: s.ctx})
+
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *AdminServiceTestSuite) TestEraseUserData_Success() {
+	req := &pb.EraseUserDataRequest{UserId: "user123"}
+	expectedResp := &pb.EraseUserDataResponse{ReceiptId: 42, DecisionsDeleted: 4}
+	s.mockCore.EXPECT().EraseUserData(s.ctx, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.EraseUserData(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *AdminServiceTestSuite) TestEraseUserData_EmptyUserId() {
+	resp, err := s.service.EraseUserData(s.ctx, &pb.EraseUserDataRequest{})
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *AdminServiceTestSuite) TestInvalidateRecipientCache_EmptyRecipient() {
+	resp, err := s.service.InvalidateRecipientCache(s.ctx, &pb.InvalidateRecipientCacheRequest{})
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *AdminServiceTestSuite) TestInvalidateRecipientCache_Success() {
+	req := &pb.InvalidateRecipientCacheRequest{RecipientUserId: "user123"}
+	s.mockCore.EXPECT().InvalidateRecipientCache(s.ctx, req).Return(&pb.InvalidateRecipientCacheResponse{}, nil).Once()
+
+	resp, err := s.service.InvalidateRecipientCache(s.ctx, req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+}
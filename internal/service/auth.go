@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/internal/providers/auth"
+	"github.com/backend-interview-task/utils"
+)
+
+// unauthenticatedServices are left open, for probes and tooling that carry no token
+var unauthenticatedServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// authenticate verifies the credentials in the request metadata, a Bearer token or an API key,
+// and returns ctx made on behalf of the caller they were issued to
+func authenticate(ctx context.Context, authenticators auth.Authenticators, fullMethod string, logger *zap.Logger) (context.Context, error) {
+	for _, prefix := range unauthenticatedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+	authenticator, credentials, ok := authenticators.Lookup(values[0])
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization scheme must be one of %s", strings.Join(authenticators.Schemes(), ", "))
+	}
+
+	caller, err := authenticator.Authenticate(ctx, credentials)
+	if err != nil {
+		utils.Logger(ctx, logger).Debug("Rejected credentials", zap.String("method", fullMethod), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	noteCaller(ctx, caller)
+	return utils.WithCaller(ctx, caller), nil
+}
+
+// authorizeUser rejects calls reading or writing the data of a user other than the caller,
+// unless made by a service account. field names the request field holding userID.
+func authorizeUser(ctx context.Context, field, userID string) error {
+	if !utils.MayActFor(ctx, userID) {
+		return status.Errorf(codes.PermissionDenied, "%s must be the authenticated user", field)
+	}
+	return nil
+}
+
+// authorizeService rejects calls made on behalf of end users. Calls are let through when the
+// server does not authenticate its callers.
+func authorizeService(ctx context.Context) error {
+	if caller, ok := utils.CallerFromContext(ctx); ok && !caller.Service {
+		return status.Error(codes.PermissionDenied, "admin RPCs are only served to service accounts")
+	}
+	return nil
+}
+
+// UnaryAuthInterceptor rejects unary RPCs without valid credentials
+func UnaryAuthInterceptor(authenticators auth.Authenticators, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticators, info.FullMethod, logger)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor rejects streaming RPCs without valid credentials
+func StreamAuthInterceptor(authenticators auth.Authenticators, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), authenticators, info.FullMethod, logger)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/internal/providers/auth"
+	authmock "github.com/backend-interview-task/mocks/providers/auth"
+	"github.com/backend-interview-task/utils"
+)
+
+type AuthInterceptorTestSuite struct {
+	suite.Suite
+	mockAuth    *authmock.Authenticator
+	mockAPIKeys *authmock.Authenticator
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func TestAuthInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthInterceptorTestSuite))
+}
+
+func (s *AuthInterceptorTestSuite) SetupTest() {
+	s.mockAuth = new(authmock.Authenticator)
+	s.mockAPIKeys = new(authmock.Authenticator)
+	s.interceptor = UnaryAuthInterceptor(auth.Authenticators{
+		auth.SchemeBearer: s.mockAuth,
+		auth.SchemeAPIKey: s.mockAPIKeys,
+	}, zap.NewNop())
+}
+
+func (s *AuthInterceptorTestSuite) TearDownTest() {
+	s.mockAuth.AssertExpectations(s.T())
+	s.mockAPIKeys.AssertExpectations(s.T())
+}
+
+// call runs the interceptor for method with the given metadata and returns the caller the handler saw
+func (s *AuthInterceptorTestSuite) call(method string, md metadata.MD) (utils.Caller, error) {
+	var caller utils.Caller
+	_, err := s.interceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			caller, _ = utils.CallerFromContext(ctx)
+			return nil, nil
+		})
+	return caller, err
+}
+
+func (s *AuthInterceptorTestSuite) TestInjectsCaller() {
+	s.mockAuth.EXPECT().Authenticate(mock.Anything, "token123").Return(utils.Caller{UserID: "user123"}, nil).Once()
+
+	caller, err := s.call("/explore.ExploreService/ListLikedYou", metadata.Pairs("authorization", "Bearer token123"))
+
+	s.NoError(err)
+	s.Equal(utils.Caller{UserID: "user123"}, caller)
+}
+
+func (s *AuthInterceptorTestSuite) TestAPIKey() {
+	s.mockAPIKeys.EXPECT().Authenticate(mock.Anything, "key123").Return(utils.Caller{UserID: "nightly-digest", Service: true}, nil).Once()
+
+	caller, err := s.call("/explore.ExploreService/CountLikedYou", metadata.Pairs("authorization", "apikey key123"))
+
+	s.NoError(err)
+	s.Equal(utils.Caller{UserID: "nightly-digest", Service: true}, caller)
+}
+
+func (s *AuthInterceptorTestSuite) TestMissingToken() {
+	_, err := s.call("/explore.ExploreService/ListLikedYou", metadata.MD{})
+
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *AuthInterceptorTestSuite) TestNotBearer() {
+	_, err := s.call("/explore.ExploreService/ListLikedYou", metadata.Pairs("authorization", "Basic dXNlcjpwYXNz"))
+
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *AuthInterceptorTestSuite) TestInvalidToken() {
+	s.mockAuth.EXPECT().Authenticate(mock.Anything, "expired").Return(utils.Caller{}, errors.New("token is expired")).Once()
+
+	_, err := s.call("/explore.ExploreService/ListLikedYou", metadata.Pairs("authorization", "Bearer expired"))
+
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *AuthInterceptorTestSuite) TestHealthChecksNeedNoToken() {
+	caller, err := s.call("/grpc.health.v1.Health/Check", metadata.MD{})
+
+	s.NoError(err)
+	s.Empty(caller.UserID)
+}
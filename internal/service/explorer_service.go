@@ -2,67 +2,160 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/backend-interview-task/config"
 	"github.com/backend-interview-task/internal/core"
 	pb "github.com/backend-interview-task/proto"
+	"github.com/backend-interview-task/utils"
 )
 
+// maxBatchDecisions caps the number of decisions accepted by a single BatchPutDecisions call
+const maxBatchDecisions = 100
+
+// maxReportReasonLength caps the length in bytes of the reason attached to a report
+const maxReportReasonLength = 1000
+
+// decisionStreamFlushSize is the number of streamed decisions buffered before they are written
+const decisionStreamFlushSize = 500
+
+// likersReadMaskPaths are the read_mask paths accepted by the likers listing endpoints
+var likersReadMaskPaths = map[string]struct{}{
+	"likers":                    {},
+	"likers.actor_id":           {},
+	"likers.unix_timestamp":     {},
+	"likers.already_liked_back": {},
+	"next_pagination_token":     {},
+	"prev_pagination_token":     {},
+}
+
 // ExploreService implements the gRPC service
 type ExploreService struct {
 	pb.UnimplementedExploreServiceServer
-	core   core.ExplorerCore
-	logger *zap.Logger
+	requestValidator
+	core    core.ExplorerCore
+	reports core.ReportCore
+	audit   core.AuditCore
+	admin   config.AdminConfig
 }
 
-func NewExploreService(core core.ExplorerCore, logger *zap.Logger) *ExploreService {
+func NewExploreService(core core.ExplorerCore, reports core.ReportCore, audit core.AuditCore, pagination config.PaginationConfig, admin config.AdminConfig, userIDs *UserIDValidator, logger *zap.Logger) *ExploreService {
 	return &ExploreService{
-		core:   core,
-		logger: logger,
+		requestValidator: newRequestValidator(pagination, userIDs, logger),
+		core:             core,
+		reports:          reports,
+		audit:            audit,
+		admin:            admin,
 	}
 }
 
 // ListLikedYou returns all users who liked the recipient
 func (s *ExploreService) ListLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient_user_id is required")
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := s.validatePageSize(req.PageSize); err != nil {
+		return nil, err
+	}
+	if err := s.validatePage(req.Page, req.PageSize, req.PaginationToken); err != nil {
+		return nil, err
+	}
+	if err := validateTimeRange(req); err != nil {
+		return nil, err
+	}
+	if _, ok := pb.SortOrder_name[int32(req.SortOrder)]; !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_order")
+	}
+	if req.IncludePassed {
+		return nil, status.Error(codes.InvalidArgument, "include_passed is only supported for new likers")
+	}
+	if err := validateLikersReadMask(req); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
 	}
 	resp, err := s.core.ListLikers(ctx, req)
 	if err != nil {
-		s.logger.Error("Failed to get likers", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to get likers", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to get likers")
 	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+	if err := s.signPageToken(ctx, resp.PrevPaginationToken); err != nil {
+		return nil, err
+	}
 
 	return resp, nil
 }
 
 // ListNewLikedYou returns users who liked the recipient but haven't been liked back
 func (s *ExploreService) ListNewLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient_user_id is required")
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := s.validatePageSize(req.PageSize); err != nil {
+		return nil, err
+	}
+	if err := s.validatePage(req.Page, req.PageSize, req.PaginationToken); err != nil {
+		return nil, err
+	}
+	if err := validateTimeRange(req); err != nil {
+		return nil, err
+	}
+	if _, ok := pb.SortOrder_name[int32(req.SortOrder)]; !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_order")
+	}
+	if req.SortOrder == pb.SortOrder_MOST_POPULAR {
+		return nil, status.Error(codes.InvalidArgument, "sort_order MOST_POPULAR is not supported for new likers")
+	}
+	if err := validateLikersReadMask(req); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
 	}
 
 	// Get new likers with pagination
 	resp, err := s.core.ListNewLikers(ctx, req)
 	if err != nil {
-		s.logger.Error("Failed to get new likers", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to get new likers", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to get new likers")
 	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+	if err := s.signPageToken(ctx, resp.PrevPaginationToken); err != nil {
+		return nil, err
+	}
 
 	return resp, nil
 }
 
 // CountLikedYou returns the count of users who liked the recipient
 func (s *ExploreService) CountLikedYou(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient_user_id is required")
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
 	}
 	resp, err := s.core.CountLikers(ctx, req)
 	if err != nil {
-		s.logger.Error("Failed to count likers", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to count likers", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to count likers")
 	}
 
@@ -71,21 +164,422 @@ func (s *ExploreService) CountLikedYou(ctx context.Context, req *pb.CountLikedYo
 
 // PutDecision records a decision (like/pass) from actor to recipient
 func (s *ExploreService) PutDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
-	if req.ActorUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "actor_user_id is required")
+	if err := s.validateDecision(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient_user_id is required")
-	}
-	if req.ActorUserId == req.RecipientUserId {
-		return nil, status.Error(codes.InvalidArgument, "actor and recipient cannot be the same user")
+	if err := authorizeUser(ctx, "actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
 	}
 	// Create the decision
 	resp, err := s.core.CreateDecision(ctx, req)
 	if err != nil {
-		s.logger.Error("Failed to create decision", zap.Error(err))
+		utils.Logger(ctx, s.logger).Error("Failed to create decision", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to create decision")
 	}
 
 	return resp, nil
 }
+
+// ListLikedByYou returns users the actor has liked
+func (s *ExploreService) ListLikedByYou(ctx context.Context, req *pb.ListLikedByYouRequest) (*pb.ListLikedByYouResponse, error) {
+	if err := s.validateUserID("actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.ListLikedRecipients(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get liked recipients", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get liked recipients")
+	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetDecision returns the decision the actor made on the recipient
+func (s *ExploreService) GetDecision(ctx context.Context, req *pb.GetDecisionRequest) (*pb.GetDecisionResponse, error) {
+	if err := s.validateUserID("actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.GetDecision(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, err
+		}
+		utils.Logger(ctx, s.logger).Error("Failed to get decision", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get decision")
+	}
+
+	return resp, nil
+}
+
+// DeleteDecision withdraws a decision (like/pass) from actor to recipient
+func (s *ExploreService) DeleteDecision(ctx context.Context, req *pb.DeleteDecisionRequest) (*pb.DeleteDecisionResponse, error) {
+	if err := s.validateUserID("actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.DeleteDecision(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, err
+		}
+		utils.Logger(ctx, s.logger).Error("Failed to delete decision", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete decision")
+	}
+
+	return resp, nil
+}
+
+// BatchPutDecisions records several decisions (like/pass) in one call
+func (s *ExploreService) BatchPutDecisions(ctx context.Context, req *pb.BatchPutDecisionsRequest) (*pb.BatchPutDecisionsResponse, error) {
+	if len(req.Decisions) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "decisions are required")
+	}
+	if len(req.Decisions) > maxBatchDecisions {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d decisions are allowed per batch", maxBatchDecisions)
+	}
+	for i, decision := range req.Decisions {
+		if err := s.validateDecision(decision); err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("decisions[%d]: %s", i, err))
+		}
+		if err := authorizeUser(ctx, fmt.Sprintf("decisions[%d].actor_user_id", i), decision.ActorUserId); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.core.BatchCreateDecisions(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		utils.Logger(ctx, s.logger).Error("Failed to create decisions", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create decisions")
+	}
+
+	return resp, nil
+}
+
+// ListMatches returns users the user has a mutual like with
+func (s *ExploreService) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	if err := s.validateUserID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, "user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.ListMatches(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get matches", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get matches")
+	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// validateDecision checks the fields shared by single and batched decision writes
+func (s *ExploreService) validateDecision(req *pb.PutDecisionRequest) error {
+	if err := s.userIDs.Validate(req.ActorUserId); err != nil {
+		return fmt.Errorf("actor_user_id %w", err)
+	}
+	if err := s.userIDs.Validate(req.RecipientUserId); err != nil {
+		return fmt.Errorf("recipient_user_id %w", err)
+	}
+	if req.ActorUserId == req.RecipientUserId {
+		return errors.New("actor and recipient cannot be the same user")
+	}
+	return nil
+}
+
+// validateTimeRange checks that an explicitly requested time range is not inverted
+func validateTimeRange(req *pb.ListLikedYouRequest) error {
+	if req.SinceUnix != nil && req.UntilUnix != nil && req.GetSinceUnix() >= req.GetUntilUnix() {
+		return status.Error(codes.InvalidArgument, "since_unix must be before until_unix")
+	}
+	return nil
+}
+
+// validateLikersReadMask checks that every read_mask path names a field of the likers response
+func validateLikersReadMask(req *pb.ListLikedYouRequest) error {
+	for _, path := range req.GetReadMask().GetPaths() {
+		if _, ok := likersReadMaskPaths[path]; !ok {
+			return status.Errorf(codes.InvalidArgument, "invalid read_mask path %q", path)
+		}
+	}
+	return nil
+}
+
+// PutDecisions records a stream of decisions, flushing them in batches of decisionStreamFlushSize.
+// Batches flushed before a failure stay recorded, so backfill jobs should be safe to re-run.
+func (s *ExploreService) PutDecisions(stream pb.ExploreService_PutDecisionsServer) error {
+	ctx := stream.Context()
+	summary := &pb.PutDecisionsSummary{}
+	buffer := make([]*pb.PutDecisionRequest, 0, decisionStreamFlushSize)
+
+	flush := func() error {
+		if len(buffer) == 0 {
+			return nil
+		}
+		resp, err := s.core.IngestDecisions(ctx, buffer)
+		if err != nil {
+			utils.Logger(ctx, s.logger).Error("Failed to ingest decisions", zap.Error(err))
+			return status.Error(codes.Internal, "failed to ingest decisions")
+		}
+		summary.Created += resp.Created
+		summary.Updated += resp.Updated
+		summary.MutualLikes += resp.MutualLikes
+		buffer = make([]*pb.PutDecisionRequest, 0, decisionStreamFlushSize)
+		return nil
+	}
+
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.validateDecision(req); err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("decisions[%d]: %s", i, err))
+		}
+		if err := authorizeUser(ctx, fmt.Sprintf("decisions[%d].actor_user_id", i), req.ActorUserId); err != nil {
+			return err
+		}
+
+		buffer = append(buffer, req)
+		if len(buffer) == decisionStreamFlushSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(summary)
+}
+
+// WatchNewLikes pushes an event to the client whenever someone new likes the recipient
+func (s *ExploreService) WatchNewLikes(req *pb.WatchNewLikesRequest, stream pb.ExploreService_WatchNewLikesServer) error {
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return err
+	}
+	if err := authorizeUser(stream.Context(), "recipient_user_id", req.RecipientUserId); err != nil {
+		return err
+	}
+
+	if err := s.core.WatchNewLikers(stream.Context(), req, stream.Send); err != nil {
+		utils.Logger(stream.Context(), s.logger).Error("Failed to watch new likes", zap.Error(err))
+		return status.Error(codes.Internal, "failed to watch new likes")
+	}
+
+	return nil
+}
+
+// ListPassedYou returns users who passed on the recipient. It is only served when admin RPCs are
+// enabled, and only to service accounts when the server authenticates its callers.
+func (s *ExploreService) ListPassedYou(ctx context.Context, req *pb.ListPassedYouRequest) (*pb.ListPassedYouResponse, error) {
+	if !s.admin.Enabled {
+		return nil, status.Error(codes.PermissionDenied, "admin RPCs are disabled")
+	}
+	if err := authorizeService(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := s.validatePageSize(req.PageSize); err != nil {
+		return nil, err
+	}
+	if err := s.validatePage(req.Page, req.PageSize, req.PaginationToken); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.ListPassers(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to get passers", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get passers")
+	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CheckMutualLike reports whether two users like each other
+func (s *ExploreService) CheckMutualLike(ctx context.Context, req *pb.CheckMutualLikeRequest) (*pb.CheckMutualLikeResponse, error) {
+	if err := s.validateUserID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("other_user_id", req.OtherUserId); err != nil {
+		return nil, err
+	}
+	if req.UserId == req.OtherUserId {
+		return nil, status.Error(codes.InvalidArgument, "user_id and other_user_id cannot be the same user")
+	}
+	if err := authorizeUser(ctx, "user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.CheckMutualLike(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to check mutual like", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to check mutual like")
+	}
+
+	return resp, nil
+}
+
+// BlockUser blocks the blocked user for the blocker
+func (s *ExploreService) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	if err := s.validateBlock(req.BlockerUserId, req.BlockedUserId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := authorizeUser(ctx, "blocker_user_id", req.BlockerUserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.BlockUser(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to block user", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to block user")
+	}
+
+	return resp, nil
+}
+
+// UnblockUser lifts a block the blocker placed on the blocked user
+func (s *ExploreService) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	if err := s.validateBlock(req.BlockerUserId, req.BlockedUserId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := authorizeUser(ctx, "blocker_user_id", req.BlockerUserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.core.UnblockUser(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, err
+		}
+		utils.Logger(ctx, s.logger).Error("Failed to unblock user", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to unblock user")
+	}
+
+	return resp, nil
+}
+
+// validateBlock checks the users of a block or unblock request
+func (s *ExploreService) validateBlock(blockerUserID, blockedUserID string) error {
+	if err := s.userIDs.Validate(blockerUserID); err != nil {
+		return fmt.Errorf("blocker_user_id %w", err)
+	}
+	if err := s.userIDs.Validate(blockedUserID); err != nil {
+		return fmt.Errorf("blocked_user_id %w", err)
+	}
+	if blockerUserID == blockedUserID {
+		return errors.New("blocker and blocked cannot be the same user")
+	}
+	return nil
+}
+
+// ReportUser stores a report against a user for trust & safety review
+func (s *ExploreService) ReportUser(ctx context.Context, req *pb.ReportUserRequest) (*pb.ReportUserResponse, error) {
+	if err := s.validateUserID("reporter_user_id", req.ReporterUserId); err != nil {
+		return nil, err
+	}
+	if err := s.validateUserID("reported_user_id", req.ReportedUserId); err != nil {
+		return nil, err
+	}
+	if req.ReporterUserId == req.ReportedUserId {
+		return nil, status.Error(codes.InvalidArgument, "reporter and reported cannot be the same user")
+	}
+	if err := authorizeUser(ctx, "reporter_user_id", req.ReporterUserId); err != nil {
+		return nil, err
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if len(req.Reason) > maxReportReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be at most %d bytes", maxReportReasonLength)
+	}
+
+	resp, err := s.reports.ReportUser(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to report user", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to report user")
+	}
+
+	return resp, nil
+}
+
+// ListAuditEvents returns the audit trail, newest first. It is only served when admin RPCs are
+// enabled, and only to service accounts when the server authenticates its callers.
+func (s *ExploreService) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if !s.admin.Enabled {
+		return nil, status.Error(codes.PermissionDenied, "admin RPCs are disabled")
+	}
+	if caller, ok := utils.CallerFromContext(ctx); ok && !caller.Service {
+		return nil, status.Error(codes.PermissionDenied, "audit events are only served to service accounts")
+	}
+	if req.UserId != nil {
+		if err := s.validateUserID("user_id", req.GetUserId()); err != nil {
+			return nil, err
+		}
+	}
+	if req.SinceUnix != nil && req.UntilUnix != nil && req.GetSinceUnix() > req.GetUntilUnix() {
+		return nil, status.Error(codes.InvalidArgument, "since_unix must not be after until_unix")
+	}
+	if err := s.validatePageSize(req.PageSize); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPageToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.audit.ListAuditEvents(ctx, req)
+	if err != nil {
+		utils.Logger(ctx, s.logger).Error("Failed to list audit events", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list audit events")
+	}
+	if err := s.signPageToken(ctx, resp.NextPaginationToken); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -3,14 +3,21 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
+	"github.com/backend-interview-task/config"
 	coremock "github.com/backend-interview-task/mocks/core"
 	pb "github.com/backend-interview-task/proto"
 	"github.com/backend-interview-task/utils"
@@ -18,9 +25,11 @@ import (
 
 type ExploreServiceTestSuite struct {
 	suite.Suite
-	mockCore *coremock.ExplorerCore
-	service  *ExploreService
-	ctx      context.Context
+	mockCore    *coremock.ExplorerCore
+	mockReports *coremock.ReportCore
+	mockAudit   *coremock.AuditCore
+	service     *ExploreService
+	ctx         context.Context
 }
 
 func TestExploreServiceTestSuite(t *testing.T) {
@@ -30,12 +39,23 @@ func TestExploreServiceTestSuite(t *testing.T) {
 func (s *ExploreServiceTestSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.mockCore = new(coremock.ExplorerCore)
+	s.mockReports = new(coremock.ReportCore)
+	s.mockAudit = new(coremock.AuditCore)
 	logger := zaptest.NewLogger(s.T())
-	s.service = NewExploreService(s.mockCore, logger)
+	s.service = NewExploreService(s.mockCore, s.mockReports, s.mockAudit, config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100}, config.AdminConfig{Enabled: true}, s.userIDs(), logger)
+}
+
+// userIDs returns the validator of the default user ID format
+func (s *ExploreServiceTestSuite) userIDs() *UserIDValidator {
+	userIDs, err := NewUserIDValidator(config.UserIDsConfig{Format: UserIDFormatAny, MaxLength: 128})
+	s.Require().NoError(err)
+	return userIDs
 }
 
 func (s *ExploreServiceTestSuite) TearDownTest() {
 	s.mockCore.AssertExpectations(s.T())
+	s.mockReports.AssertExpectations(s.T())
+	s.mockAudit.AssertExpectations(s.T())
 }
 
 func (s *ExploreServiceTestSuite) TestListLikedYou_Success() {
@@ -95,6 +115,152 @@ func (s *ExploreServiceTestSuite) TestListLikedYou_EmptyRecipientUserId() {
 	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
 }
 
+func (s *ExploreServiceTestSuite) TestListLikedYou_PageSizeWithinBounds() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		PageSize:        utils.ToPointer(uint32(100)),
+	}
+
+	expectedResp := &pb.ListLikedYouResponse{}
+	s.mockCore.EXPECT().ListLikers(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListLikedYou(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_PageSizeOutOfBounds() {
+	for _, pageSize := range []uint32{0, 101} {
+		req := &pb.ListLikedYouRequest{
+			RecipientUserId: "user123",
+			PageSize:        utils.ToPointer(pageSize),
+		}
+
+		resp, err := s.service.ListLikedYou(s.ctx, req)
+
+		s.Nil(resp)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Contains(err.Error(), "page_size must be between 1 and 100")
+	}
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListNewLikedYou_PageSizeOutOfBounds() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		PageSize:        utils.ToPointer(uint32(500)),
+	}
+
+	resp, err := s.service.ListNewLikedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "ListNewLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_TimeRange() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		SinceUnix:       utils.ToPointer(uint64(1000)),
+		UntilUnix:       utils.ToPointer(uint64(2000)),
+	}
+
+	expectedResp := &pb.ListLikedYouResponse{}
+	s.mockCore.EXPECT().ListLikers(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListLikedYou(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_InvertedTimeRange() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		SinceUnix:       utils.ToPointer(uint64(2000)),
+		UntilUnix:       utils.ToPointer(uint64(1000)),
+	}
+
+	resp, err := s.service.ListLikedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "since_unix must be before until_unix")
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListNewLikedYou_InvalidSortOrder() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		SortOrder:       pb.SortOrder(42),
+	}
+
+	resp, err := s.service.ListNewLikedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "invalid sort_order")
+	s.mockCore.AssertNotCalled(s.T(), "ListNewLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_IncludePassed() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		IncludePassed:   true,
+	}
+
+	resp, err := s.service.ListLikedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "only supported for new likers")
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListNewLikedYou_MostPopular() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		SortOrder:       pb.SortOrder_MOST_POPULAR,
+	}
+
+	resp, err := s.service.ListNewLikedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "not supported for new likers")
+	s.mockCore.AssertNotCalled(s.T(), "ListNewLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_ReadMask() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		ReadMask:        &fieldmaskpb.FieldMask{Paths: []string{"likers.actor_id", "next_pagination_token"}},
+	}
+
+	expectedResp := &pb.ListLikedYouResponse{}
+	s.mockCore.EXPECT().ListLikers(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListLikedYou(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_InvalidReadMask() {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: "user123",
+		ReadMask:        &fieldmaskpb.FieldMask{Paths: []string{"likers.email"}},
+	}
+
+	resp, err := s.service.ListLikedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), `invalid read_mask path "likers.email"`)
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
 func (s *ExploreServiceTestSuite) TestListLikedYou_CoreError() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "user123",
@@ -112,6 +278,137 @@ func (s *ExploreServiceTestSuite) TestListLikedYou_CoreError() {
 	s.Contains(err.Error(), "failed to get likers")
 }
 
+// signingService returns a service signing pagination tokens with the given keys
+func (s *ExploreServiceTestSuite) signingService(ttl time.Duration, keys ...string) *ExploreService {
+	pagination := config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100, CursorKeys: keys, CursorTTL: ttl}
+	return NewExploreService(s.mockCore, s.mockReports, s.mockAudit, pagination, config.AdminConfig{Enabled: true}, s.userIDs(), zaptest.NewLogger(s.T()))
+}
+
+// signToken signs token with key as it was issued, without stamping its cursor
+func (s *ExploreServiceTestSuite) signToken(key, token string) string {
+	signed, err := utils.NewPageTokenSigner([]string{key}, 0).Sign(token)
+	s.Require().NoError(err)
+	return signed
+}
+
+// encodeCursor encodes a cursor issued at the given time
+func (s *ExploreServiceTestSuite) encodeCursor(issuedAt time.Time) string {
+	token, err := (&utils.Cursor{LastCreatedAt: 1640995200, Limit: 20, IssuedAt: issuedAt.Unix()}).Encode()
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_SignedPaginationTokens() {
+	oldKey, newKey := strings.Repeat("o", 32), strings.Repeat("n", 32)
+	service := s.signingService(0, newKey, oldKey)
+	issued := s.signToken(oldKey, "token456")
+
+	s.mockCore.EXPECT().ListLikers(mock.Anything, mock.MatchedBy(func(req *pb.ListLikedYouRequest) bool {
+		return req.GetPaginationToken() == "token456"
+	})).Return(&pb.ListLikedYouResponse{NextPaginationToken: utils.ToPointer("next_token"), PrevPaginationToken: utils.ToPointer("prev_token")}, nil).Once()
+
+	resp, err := service.ListLikedYou(s.ctx, &pb.ListLikedYouRequest{RecipientUserId: "user123", PaginationToken: &issued})
+
+	s.Require().NoError(err)
+	s.Equal(s.signToken(newKey, "next_token"), resp.GetNextPaginationToken())
+	s.Equal(s.signToken(newKey, "prev_token"), resp.GetPrevPaginationToken())
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_TamperedPaginationToken() {
+	key := strings.Repeat("k", 32)
+	service := s.signingService(0, key)
+	signed := s.signToken(key, "token456")
+	forged := signed[:strings.Index(signed, ".")] + "." + strings.Repeat("A", 43)
+
+	for _, token := range []string{"token456", forged, s.signToken(strings.Repeat("x", 32), "token456")} {
+		resp, err := service.ListLikedYou(s.ctx, &pb.ListLikedYouRequest{RecipientUserId: "user123", PaginationToken: &token})
+
+		s.Nil(resp)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_ExpiringPaginationTokens() {
+	key := strings.Repeat("k", 32)
+	service := s.signingService(time.Hour, key)
+	fresh := s.signToken(key, s.encodeCursor(time.Now().Add(-time.Minute)))
+
+	// The core gets the token without its stamp, as the repository issued it
+	s.mockCore.EXPECT().ListLikers(mock.Anything, mock.MatchedBy(func(req *pb.ListLikedYouRequest) bool {
+		return req.GetPaginationToken() == s.encodeCursor(time.Unix(0, 0))
+	})).Return(&pb.ListLikedYouResponse{NextPaginationToken: utils.ToPointer(s.encodeCursor(time.Unix(0, 0)))}, nil).Once()
+
+	resp, err := service.ListLikedYou(s.ctx, &pb.ListLikedYouRequest{RecipientUserId: "user123", PaginationToken: &fresh})
+
+	s.Require().NoError(err)
+	next, err := utils.NewPageTokenSigner([]string{key}, 0).Verify(resp.GetNextPaginationToken())
+	s.Require().NoError(err)
+	cursor, err := utils.DecodeCursor(next)
+	s.Require().NoError(err)
+	s.InDelta(time.Now().Unix(), cursor.IssuedAt, 5)
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_ExpiredPaginationToken() {
+	key := strings.Repeat("k", 32)
+	service := s.signingService(time.Hour, key)
+	stale := s.signToken(key, s.encodeCursor(time.Now().Add(-2*time.Hour)))
+
+	resp, err := service.ListLikedYou(s.ctx, &pb.ListLikedYouRequest{RecipientUserId: "user123", PaginationToken: &stale})
+
+	s.Nil(resp)
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+// offsetService returns a service accepting offset pages starting up to maxOffset rows in
+func (s *ExploreServiceTestSuite) offsetService(maxOffset uint32) *ExploreService {
+	pagination := config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100, DefaultPageSize: 20, Offset: config.OffsetPaginationConfig{Enabled: true, MaxOffset: maxOffset}}
+	return NewExploreService(s.mockCore, s.mockReports, s.mockAudit, pagination, config.AdminConfig{Enabled: true}, s.userIDs(), zaptest.NewLogger(s.T()))
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_OffsetPage() {
+	req := &pb.ListLikedYouRequest{RecipientUserId: "user123", PageSize: utils.ToPointer(uint32(50)), Page: utils.ToPointer(uint32(3))}
+	s.mockCore.EXPECT().ListLikers(mock.Anything, req).
+		Return(&pb.ListLikedYouResponse{Likers: []*pb.ListLikedYouResponse_Liker{{ActorId: "actor101"}}}, nil).Once()
+
+	resp, err := s.offsetService(100).ListLikedYou(s.ctx, req)
+
+	s.Require().NoError(err)
+	s.Len(resp.Likers, 1)
+	s.Nil(resp.NextPaginationToken)
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_InvalidOffsetPage() {
+	offsetService := s.offsetService(100)
+	testCases := []struct {
+		service *ExploreService
+		req     *pb.ListLikedYouRequest
+		message string
+	}{
+		{s.service, &pb.ListLikedYouRequest{RecipientUserId: "user123", Page: utils.ToPointer(uint32(1))}, "offset pagination is disabled"},
+		{offsetService, &pb.ListLikedYouRequest{RecipientUserId: "user123", Page: utils.ToPointer(uint32(0))}, "page must be at least 1"},
+		{offsetService, &pb.ListLikedYouRequest{RecipientUserId: "user123", Page: utils.ToPointer(uint32(2)), PaginationToken: utils.ToPointer("token456")}, "mutually exclusive"},
+		{offsetService, &pb.ListLikedYouRequest{RecipientUserId: "user123", Page: utils.ToPointer(uint32(7))}, "page must not start past offset 100"},
+	}
+
+	for _, tc := range testCases {
+		resp, err := tc.service.ListLikedYou(s.ctx, tc.req)
+
+		s.Nil(resp)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Contains(err.Error(), tc.message)
+	}
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListPassedYou_OffsetPage() {
+	req := &pb.ListPassedYouRequest{RecipientUserId: "user123", Page: utils.ToPointer(uint32(2))}
+	s.mockCore.EXPECT().ListPassers(mock.Anything, req).Return(&pb.ListPassedYouResponse{}, nil).Once()
+
+	_, err := s.offsetService(100).ListPassedYou(s.ctx, req)
+
+	s.NoError(err)
+}
+
 func (s *ExploreServiceTestSuite) TestListNewLikedYou_Success() {
 	req := &pb.ListLikedYouRequest{
 		RecipientUserId: "user123",
@@ -235,6 +532,117 @@ func (s *ExploreServiceTestSuite) TestCountLikedYou_EmptyRecipientUserId() {
 	s.mockCore.AssertNotCalled(s.T(), "CountLikers")
 }
 
+func (s *ExploreServiceTestSuite) TestPutDecision_MalformedUserIDs() {
+	malformed := map[string]string{
+		"user 123":               "must not contain whitespace",
+		"user123\n":              "must not contain whitespace",
+		strings.Repeat("x", 129): "must be at most 128 bytes",
+		"user\xff":               "must be valid UTF-8",
+	}
+	for id, message := range malformed {
+		_, err := s.service.PutDecision(s.ctx, &pb.PutDecisionRequest{ActorUserId: id, RecipientUserId: "user456"})
+
+		s.Equal(codes.InvalidArgument, status.Code(err), id)
+		s.Contains(err.Error(), "actor_user_id "+message, id)
+	}
+	s.mockCore.AssertNotCalled(s.T(), "CreateDecision")
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_MalformedUserID() {
+	_, err := s.service.BatchPutDecisions(s.ctx, &pb.BatchPutDecisionsRequest{Decisions: []*pb.PutDecisionRequest{
+		{ActorUserId: "user123", RecipientUserId: "user456"},
+		{ActorUserId: "user123", RecipientUserId: " user789"},
+	}})
+
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "decisions[1]: recipient_user_id must not contain whitespace")
+}
+
+func (s *ExploreServiceTestSuite) TestCountLikedYou_OtherRecipient() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user456"})
+
+	resp, err := s.service.CountLikedYou(ctx, &pb.CountLikedYouRequest{RecipientUserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "CountLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedYou_OtherRecipient() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user456"})
+
+	resp, err := s.service.ListLikedYou(ctx, &pb.ListLikedYouRequest{RecipientUserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "ListLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestListNewLikedYou_ServiceAccount() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "indexer", Service: true})
+	req := &pb.ListLikedYouRequest{RecipientUserId: "user123"}
+	s.mockCore.EXPECT().ListNewLikers(mock.Anything, req).Return(&pb.ListLikedYouResponse{}, nil).Once()
+
+	_, err := s.service.ListNewLikedYou(ctx, req)
+
+	s.NoError(err)
+}
+
+func (s *ExploreServiceTestSuite) TestCountLikedYou_OwnLikers() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user123"})
+	req := &pb.CountLikedYouRequest{RecipientUserId: "user123"}
+	s.mockCore.EXPECT().CountLikers(mock.Anything, req).Return(&pb.CountLikedYouResponse{Count: 3}, nil).Once()
+
+	resp, err := s.service.CountLikedYou(ctx, req)
+
+	s.NoError(err)
+	s.Equal(uint64(3), resp.Count)
+}
+
+func (s *ExploreServiceTestSuite) TestOtherUserDenied() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user456"})
+
+	_, err := s.service.PutDecision(ctx, &pb.PutDecisionRequest{ActorUserId: "user123", RecipientUserId: "user789"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.Contains(err.Error(), "actor_user_id must be the authenticated user")
+	_, err = s.service.BatchPutDecisions(ctx, &pb.BatchPutDecisionsRequest{Decisions: []*pb.PutDecisionRequest{
+		{ActorUserId: "user456", RecipientUserId: "user789"},
+		{ActorUserId: "user123", RecipientUserId: "user789"},
+	}})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.Contains(err.Error(), "decisions[1].actor_user_id must be the authenticated user")
+	err = s.service.PutDecisions(&putDecisionsStream{ctx: ctx, requests: []*pb.PutDecisionRequest{
+		{ActorUserId: "user123", RecipientUserId: "user789"},
+	}})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.GetDecision(ctx, &pb.GetDecisionRequest{ActorUserId: "user123", RecipientUserId: "user456"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.DeleteDecision(ctx, &pb.DeleteDecisionRequest{ActorUserId: "user123", RecipientUserId: "user456"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.ListLikedByYou(ctx, &pb.ListLikedByYouRequest{ActorUserId: "user123"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.ListMatches(ctx, &pb.ListMatchesRequest{UserId: "user123"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.CheckMutualLike(ctx, &pb.CheckMutualLikeRequest{UserId: "user123", OtherUserId: "user456"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.BlockUser(ctx, &pb.BlockUserRequest{BlockerUserId: "user123", BlockedUserId: "user456"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.UnblockUser(ctx, &pb.UnblockUserRequest{BlockerUserId: "user123", BlockedUserId: "user456"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = s.service.ReportUser(ctx, &pb.ReportUserRequest{ReporterUserId: "user123", ReportedUserId: "user456", Reason: "spam"})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestPutDecision_OwnDecision() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user123"})
+	req := &pb.PutDecisionRequest{ActorUserId: "user123", RecipientUserId: "user456", LikedRecipient: true}
+	s.mockCore.EXPECT().CreateDecision(mock.Anything, req).Return(&pb.PutDecisionResponse{}, nil).Once()
+
+	_, err := s.service.PutDecision(ctx, req)
+
+	s.NoError(err)
+}
+
 func (s *ExploreServiceTestSuite) TestCountLikedYou_CoreError() {
 	req := &pb.CountLikedYouRequest{
 		RecipientUserId: "user123",
@@ -376,3 +784,754 @@ func (s *ExploreServiceTestSuite) TestPutDecision_CoreError() {
 	s.Equal(codes.Internal, status.Code(err))
 	s.Contains(err.Error(), "failed to create decision")
 }
+
+func (s *ExploreServiceTestSuite) TestListLikedByYou_Success() {
+	req := &pb.ListLikedByYouRequest{
+		ActorUserId:     "actor123",
+		PaginationToken: utils.ToPointer("token456"),
+	}
+
+	expectedResp := &pb.ListLikedByYouResponse{
+		Recipients: []*pb.ListLikedByYouResponse_Recipient{
+			{RecipientId: "recipient1", UnixTimestamp: 1640995200},
+		},
+		NextPaginationToken: utils.ToPointer("next_token"),
+	}
+
+	s.mockCore.EXPECT().ListLikedRecipients(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListLikedByYou(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedByYou_EmptyActorUserId() {
+	req := &pb.ListLikedByYouRequest{
+		ActorUserId: "",
+	}
+
+	resp, err := s.service.ListLikedByYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Error(err)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "actor_user_id is required")
+	s.mockCore.AssertNotCalled(s.T(), "ListLikedRecipients")
+}
+
+func (s *ExploreServiceTestSuite) TestListLikedByYou_CoreError() {
+	req := &pb.ListLikedByYouRequest{
+		ActorUserId: "actor123",
+	}
+
+	s.mockCore.EXPECT().ListLikedRecipients(mock.Anything, req).Return(nil, errors.New("database connection failed")).Once()
+
+	resp, err := s.service.ListLikedByYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Error(err)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get liked recipients")
+}
+
+func (s *ExploreServiceTestSuite) TestGetDecision_Success() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	expectedResp := &pb.GetDecisionResponse{
+		LikedRecipient: true,
+		CreatedAtUnix:  1640995200,
+		UpdatedAtUnix:  1640995200,
+	}
+
+	s.mockCore.EXPECT().GetDecision(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.GetDecision(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestGetDecision_EmptyActorUserId() {
+	req := &pb.GetDecisionRequest{
+		RecipientUserId: "recipient456",
+	}
+
+	resp, err := s.service.GetDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "actor_user_id is required")
+	s.mockCore.AssertNotCalled(s.T(), "GetDecision")
+}
+
+func (s *ExploreServiceTestSuite) TestGetDecision_EmptyRecipientUserId() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId: "actor123",
+	}
+
+	resp, err := s.service.GetDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "recipient_user_id is required")
+	s.mockCore.AssertNotCalled(s.T(), "GetDecision")
+}
+
+func (s *ExploreServiceTestSuite) TestGetDecision_NotFound() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockCore.EXPECT().GetDecision(mock.Anything, req).
+		Return(nil, status.Error(codes.NotFound, "decision not found")).Once()
+
+	resp, err := s.service.GetDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+	s.Contains(err.Error(), "decision not found")
+}
+
+func (s *ExploreServiceTestSuite) TestGetDecision_CoreError() {
+	req := &pb.GetDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockCore.EXPECT().GetDecision(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.GetDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get decision")
+}
+
+func (s *ExploreServiceTestSuite) TestDeleteDecision_Success() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	expectedResp := &pb.DeleteDecisionResponse{WasMutualLike: true}
+
+	s.mockCore.EXPECT().DeleteDecision(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.DeleteDecision(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestDeleteDecision_EmptyActorUserId() {
+	req := &pb.DeleteDecisionRequest{
+		RecipientUserId: "recipient456",
+	}
+
+	resp, err := s.service.DeleteDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "DeleteDecision")
+}
+
+func (s *ExploreServiceTestSuite) TestDeleteDecision_NotFound() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockCore.EXPECT().DeleteDecision(mock.Anything, req).
+		Return(nil, status.Error(codes.NotFound, "decision not found")).Once()
+
+	resp, err := s.service.DeleteDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestDeleteDecision_CoreError() {
+	req := &pb.DeleteDecisionRequest{
+		ActorUserId:     "actor123",
+		RecipientUserId: "recipient456",
+	}
+
+	s.mockCore.EXPECT().DeleteDecision(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.DeleteDecision(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to delete decision")
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_Success() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+			{ActorUserId: "actor1", RecipientUserId: "recipient2", LikedRecipient: false},
+		},
+	}
+
+	expectedResp := &pb.BatchPutDecisionsResponse{
+		Results: []*pb.BatchPutDecisionsResponse_Result{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", MutualLikes: true},
+			{ActorUserId: "actor1", RecipientUserId: "recipient2"},
+		},
+	}
+
+	s.mockCore.EXPECT().BatchCreateDecisions(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.BatchPutDecisions(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_Empty() {
+	resp, err := s.service.BatchPutDecisions(s.ctx, &pb.BatchPutDecisionsRequest{})
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "decisions are required")
+	s.mockCore.AssertNotCalled(s.T(), "BatchCreateDecisions")
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_TooMany() {
+	req := &pb.BatchPutDecisionsRequest{}
+	for i := 0; i <= maxBatchDecisions; i++ {
+		req.Decisions = append(req.Decisions, &pb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "recipient1"})
+	}
+
+	resp, err := s.service.BatchPutDecisions(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "BatchCreateDecisions")
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_InvalidItem() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+			{ActorUserId: "actor1", RecipientUserId: "actor1", LikedRecipient: true},
+		},
+	}
+
+	resp, err := s.service.BatchPutDecisions(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "decisions[1]: actor and recipient cannot be the same user")
+	s.mockCore.AssertNotCalled(s.T(), "BatchCreateDecisions")
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_CoreError() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+		},
+	}
+
+	s.mockCore.EXPECT().BatchCreateDecisions(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.BatchPutDecisions(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to create decisions")
+}
+
+func (s *ExploreServiceTestSuite) TestBatchPutDecisions_RejectedByCore() {
+	req := &pb.BatchPutDecisionsRequest{
+		Decisions: []*pb.PutDecisionRequest{
+			{ActorUserId: "actor1", RecipientUserId: "recipient1", LikedRecipient: true},
+		},
+	}
+
+	s.mockCore.EXPECT().BatchCreateDecisions(mock.Anything, req).
+		Return(nil, status.Error(codes.InvalidArgument, "too many decisions to record atomically, send a smaller batch")).Once()
+
+	resp, err := s.service.BatchPutDecisions(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "send a smaller batch")
+}
+
+func (s *ExploreServiceTestSuite) TestListMatches_Success() {
+	req := &pb.ListMatchesRequest{
+		UserId: "user123",
+	}
+
+	expectedResp := &pb.ListMatchesResponse{
+		Matches: []*pb.ListMatchesResponse_Match{
+			{UserId: "match1", MatchedAtUnix: 1640995200},
+		},
+	}
+
+	s.mockCore.EXPECT().ListMatches(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListMatches(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListMatches_EmptyUserId() {
+	resp, err := s.service.ListMatches(s.ctx, &pb.ListMatchesRequest{})
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "user_id is required")
+	s.mockCore.AssertNotCalled(s.T(), "ListMatches")
+}
+
+func (s *ExploreServiceTestSuite) TestListMatches_CoreError() {
+	req := &pb.ListMatchesRequest{
+		UserId: "user123",
+	}
+
+	s.mockCore.EXPECT().ListMatches(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.ListMatches(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get matches")
+}
+
+// putDecisionsStream is an in-memory PutDecisions stream replaying the queued requests
+type putDecisionsStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*pb.PutDecisionRequest
+	summary  *pb.PutDecisionsSummary
+}
+
+func (f *putDecisionsStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *putDecisionsStream) Recv() (*pb.PutDecisionRequest, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *putDecisionsStream) SendAndClose(summary *pb.PutDecisionsSummary) error {
+	f.summary = summary
+	return nil
+}
+
+func (s *ExploreServiceTestSuite) TestPutDecisions_FlushesInBatches() {
+	requests := make([]*pb.PutDecisionRequest, decisionStreamFlushSize+1)
+	for i := range requests {
+		requests[i] = &pb.PutDecisionRequest{
+			ActorUserId:     "actor123",
+			RecipientUserId: fmt.Sprintf("recipient%d", i),
+			LikedRecipient:  true,
+		}
+	}
+	stream := &putDecisionsStream{ctx: s.ctx, requests: requests}
+
+	s.mockCore.EXPECT().IngestDecisions(mock.Anything, requests[:decisionStreamFlushSize]).
+		Return(&pb.PutDecisionsSummary{Created: 400, Updated: 100, MutualLikes: 3}, nil).Once()
+	s.mockCore.EXPECT().IngestDecisions(mock.Anything, requests[decisionStreamFlushSize:]).
+		Return(&pb.PutDecisionsSummary{Created: 1}, nil).Once()
+
+	err := s.service.PutDecisions(stream)
+
+	s.NoError(err)
+	s.Equal(uint64(401), stream.summary.Created)
+	s.Equal(uint64(100), stream.summary.Updated)
+	s.Equal(uint64(3), stream.summary.MutualLikes)
+}
+
+func (s *ExploreServiceTestSuite) TestPutDecisions_EmptyStream() {
+	stream := &putDecisionsStream{ctx: s.ctx}
+
+	err := s.service.PutDecisions(stream)
+
+	s.NoError(err)
+	s.Equal(&pb.PutDecisionsSummary{}, stream.summary)
+	s.mockCore.AssertNotCalled(s.T(), "IngestDecisions")
+}
+
+func (s *ExploreServiceTestSuite) TestPutDecisions_InvalidDecision() {
+	stream := &putDecisionsStream{ctx: s.ctx, requests: []*pb.PutDecisionRequest{
+		{ActorUserId: "actor123", RecipientUserId: "recipient456"},
+		{ActorUserId: "actor123", RecipientUserId: ""},
+	}}
+
+	err := s.service.PutDecisions(stream)
+
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "decisions[1]: recipient_user_id is required")
+	s.Nil(stream.summary)
+	s.mockCore.AssertNotCalled(s.T(), "IngestDecisions")
+}
+
+func (s *ExploreServiceTestSuite) TestPutDecisions_CoreError() {
+	stream := &putDecisionsStream{ctx: s.ctx, requests: []*pb.PutDecisionRequest{
+		{ActorUserId: "actor123", RecipientUserId: "recipient456"},
+	}}
+
+	s.mockCore.EXPECT().IngestDecisions(mock.Anything, mock.Anything).
+		Return(nil, errors.New("database timeout")).Once()
+
+	err := s.service.PutDecisions(stream)
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to ingest decisions")
+	s.Nil(stream.summary)
+}
+
+// watchNewLikesStream is an in-memory WatchNewLikes stream collecting the sent events
+type watchNewLikesStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*pb.WatchNewLikesEvent
+}
+
+func (f *watchNewLikesStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *watchNewLikesStream) Send(event *pb.WatchNewLikesEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (s *ExploreServiceTestSuite) TestWatchNewLikes_Success() {
+	req := &pb.WatchNewLikesRequest{RecipientUserId: "user123"}
+	stream := &watchNewLikesStream{ctx: s.ctx}
+
+	s.mockCore.EXPECT().WatchNewLikers(mock.Anything, req, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *pb.WatchNewLikesRequest, send func(*pb.WatchNewLikesEvent) error) error {
+			return send(&pb.WatchNewLikesEvent{ActorId: "actor1"})
+		}).Once()
+
+	err := s.service.WatchNewLikes(req, stream)
+
+	s.NoError(err)
+	s.Require().Len(stream.events, 1)
+	s.Equal("actor1", stream.events[0].ActorId)
+}
+
+func (s *ExploreServiceTestSuite) TestWatchNewLikes_EmptyRecipientUserId() {
+	err := s.service.WatchNewLikes(&pb.WatchNewLikesRequest{}, &watchNewLikesStream{ctx: s.ctx})
+
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "recipient_user_id is required")
+	s.mockCore.AssertNotCalled(s.T(), "WatchNewLikers")
+}
+
+func (s *ExploreServiceTestSuite) TestWatchNewLikes_CoreError() {
+	req := &pb.WatchNewLikesRequest{RecipientUserId: "user123"}
+
+	s.mockCore.EXPECT().WatchNewLikers(mock.Anything, req, mock.Anything).
+		Return(errors.New("broker unavailable")).Once()
+
+	err := s.service.WatchNewLikes(req, &watchNewLikesStream{ctx: s.ctx})
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to watch new likes")
+}
+
+func (s *ExploreServiceTestSuite) TestListPassedYou_Success() {
+	req := &pb.ListPassedYouRequest{
+		RecipientUserId: "user123",
+		PageSize:        utils.ToPointer(uint32(10)),
+	}
+
+	expectedResp := &pb.ListPassedYouResponse{
+		Passers: []*pb.ListPassedYouResponse_Passer{
+			{ActorId: "actor1", UnixTimestamp: 1640995200},
+		},
+	}
+
+	s.mockCore.EXPECT().ListPassers(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListPassedYou(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListPassedYou_AdminDisabled() {
+	service := NewExploreService(s.mockCore, s.mockReports, s.mockAudit, config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100}, config.AdminConfig{}, s.userIDs(), zaptest.NewLogger(s.T()))
+
+	resp, err := service.ListPassedYou(s.ctx, &pb.ListPassedYouRequest{RecipientUserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "ListPassers")
+}
+
+func (s *ExploreServiceTestSuite) TestListPassedYou_UserCaller() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user123"})
+
+	resp, err := s.service.ListPassedYou(ctx, &pb.ListPassedYouRequest{RecipientUserId: "user123"})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.mockCore.AssertNotCalled(s.T(), "ListPassers")
+}
+
+func (s *ExploreServiceTestSuite) TestListPassedYou_EmptyRecipientUserId() {
+	resp, err := s.service.ListPassedYou(s.ctx, &pb.ListPassedYouRequest{})
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "recipient_user_id is required")
+	s.mockCore.AssertNotCalled(s.T(), "ListPassers")
+}
+
+func (s *ExploreServiceTestSuite) TestListPassedYou_CoreError() {
+	req := &pb.ListPassedYouRequest{RecipientUserId: "user123"}
+
+	s.mockCore.EXPECT().ListPassers(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.ListPassedYou(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to get passers")
+}
+
+func (s *ExploreServiceTestSuite) TestCheckMutualLike_Success() {
+	req := &pb.CheckMutualLikeRequest{
+		UserId:      "user123",
+		OtherUserId: "user456",
+	}
+
+	expectedResp := &pb.CheckMutualLikeResponse{MutualLike: true}
+	s.mockCore.EXPECT().CheckMutualLike(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.CheckMutualLike(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestCheckMutualLike_InvalidArguments() {
+	testCases := []struct {
+		req     *pb.CheckMutualLikeRequest
+		message string
+	}{
+		{&pb.CheckMutualLikeRequest{OtherUserId: "user456"}, "user_id is required"},
+		{&pb.CheckMutualLikeRequest{UserId: "user123"}, "other_user_id is required"},
+		{&pb.CheckMutualLikeRequest{UserId: "user123", OtherUserId: "user123"}, "cannot be the same user"},
+	}
+
+	for _, tc := range testCases {
+		resp, err := s.service.CheckMutualLike(s.ctx, tc.req)
+
+		s.Nil(resp)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Contains(err.Error(), tc.message)
+	}
+	s.mockCore.AssertNotCalled(s.T(), "CheckMutualLike")
+}
+
+func (s *ExploreServiceTestSuite) TestCheckMutualLike_CoreError() {
+	req := &pb.CheckMutualLikeRequest{
+		UserId:      "user123",
+		OtherUserId: "user456",
+	}
+
+	s.mockCore.EXPECT().CheckMutualLike(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.CheckMutualLike(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to check mutual like")
+}
+
+func (s *ExploreServiceTestSuite) TestBlockUser_Success() {
+	req := &pb.BlockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
+
+	s.mockCore.EXPECT().BlockUser(mock.Anything, req).Return(&pb.BlockUserResponse{}, nil).Once()
+
+	resp, err := s.service.BlockUser(s.ctx, req)
+
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *ExploreServiceTestSuite) TestBlockUser_InvalidArguments() {
+	testCases := []struct {
+		req     *pb.BlockUserRequest
+		message string
+	}{
+		{&pb.BlockUserRequest{BlockedUserId: "user456"}, "blocker_user_id is required"},
+		{&pb.BlockUserRequest{BlockerUserId: "user123"}, "blocked_user_id is required"},
+		{&pb.BlockUserRequest{BlockerUserId: "user123", BlockedUserId: "user123"}, "cannot be the same user"},
+	}
+
+	for _, tc := range testCases {
+		resp, err := s.service.BlockUser(s.ctx, tc.req)
+
+		s.Nil(resp)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Contains(err.Error(), tc.message)
+	}
+	s.mockCore.AssertNotCalled(s.T(), "BlockUser")
+}
+
+func (s *ExploreServiceTestSuite) TestUnblockUser_NotFound() {
+	req := &pb.UnblockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
+
+	s.mockCore.EXPECT().UnblockUser(mock.Anything, req).
+		Return(nil, status.Error(codes.NotFound, "block not found")).Once()
+
+	resp, err := s.service.UnblockUser(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestUnblockUser_CoreError() {
+	req := &pb.UnblockUserRequest{
+		BlockerUserId: "user123",
+		BlockedUserId: "user456",
+	}
+
+	s.mockCore.EXPECT().UnblockUser(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.UnblockUser(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to unblock user")
+}
+
+func (s *ExploreServiceTestSuite) TestReportUser_Success() {
+	req := &pb.ReportUserRequest{
+		ReporterUserId: "user123",
+		ReportedUserId: "user456",
+		Reason:         "spam",
+	}
+
+	expectedResp := &pb.ReportUserResponse{ReportId: 42}
+	s.mockReports.EXPECT().ReportUser(mock.Anything, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ReportUser(s.ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestReportUser_InvalidArguments() {
+	testCases := []struct {
+		req     *pb.ReportUserRequest
+		message string
+	}{
+		{&pb.ReportUserRequest{ReportedUserId: "user456", Reason: "spam"}, "reporter_user_id is required"},
+		{&pb.ReportUserRequest{ReporterUserId: "user123", Reason: "spam"}, "reported_user_id is required"},
+		{&pb.ReportUserRequest{ReporterUserId: "user123", ReportedUserId: "user123", Reason: "spam"}, "cannot be the same user"},
+		{&pb.ReportUserRequest{ReporterUserId: "user123", ReportedUserId: "user456"}, "reason is required"},
+		{&pb.ReportUserRequest{ReporterUserId: "user123", ReportedUserId: "user456", Reason: strings.Repeat("a", maxReportReasonLength+1)}, "reason must be at most"},
+	}
+
+	for _, tc := range testCases {
+		resp, err := s.service.ReportUser(s.ctx, tc.req)
+
+		s.Nil(resp)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Contains(err.Error(), tc.message)
+	}
+	s.mockReports.AssertNotCalled(s.T(), "ReportUser")
+}
+
+func (s *ExploreServiceTestSuite) TestReportUser_CoreError() {
+	req := &pb.ReportUserRequest{
+		ReporterUserId: "user123",
+		ReportedUserId: "user456",
+		Reason:         "spam",
+	}
+
+	s.mockReports.EXPECT().ReportUser(mock.Anything, req).Return(nil, errors.New("database timeout")).Once()
+
+	resp, err := s.service.ReportUser(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Contains(err.Error(), "failed to report user")
+}
+
+func (s *ExploreServiceTestSuite) TestListAuditEvents_Success() {
+	req := &pb.ListAuditEventsRequest{UserId: utils.ToPointer("user123"), PageSize: utils.ToPointer(uint32(10))}
+	expectedResp := &pb.ListAuditEventsResponse{
+		Events: []*pb.ListAuditEventsResponse_AuditEvent{
+			{Id: 7, UnixTimestamp: 1640995200, Action: "PutDecision", UserIds: []string{"user123", "user456"}, Outcome: "OK"},
+		},
+	}
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "moderation", Service: true})
+	s.mockAudit.EXPECT().ListAuditEvents(ctx, req).Return(expectedResp, nil).Once()
+
+	resp, err := s.service.ListAuditEvents(ctx, req)
+
+	s.NoError(err)
+	s.Equal(expectedResp, resp)
+}
+
+func (s *ExploreServiceTestSuite) TestListAuditEvents_AdminDisabled() {
+	service := NewExploreService(s.mockCore, s.mockReports, s.mockAudit, config.PaginationConfig{MinPageSize: 1, MaxPageSize: 100}, config.AdminConfig{}, s.userIDs(), zaptest.NewLogger(s.T()))
+
+	resp, err := service.ListAuditEvents(s.ctx, &pb.ListAuditEventsRequest{})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestListAuditEvents_UserCaller() {
+	ctx := utils.WithCaller(s.ctx, utils.Caller{UserID: "user123"})
+
+	resp, err := s.service.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{UserId: utils.ToPointer("user123")})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestListAuditEvents_InvalidTimeRange() {
+	req := &pb.ListAuditEventsRequest{SinceUnix: utils.ToPointer(uint64(200)), UntilUnix: utils.ToPointer(uint64(100))}
+
+	resp, err := s.service.ListAuditEvents(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestListAuditEvents_EmptyUserId() {
+	resp, err := s.service.ListAuditEvents(s.ctx, &pb.ListAuditEventsRequest{UserId: utils.ToPointer("")})
+
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ExploreServiceTestSuite) TestListAuditEvents_CoreError() {
+	req := &pb.ListAuditEventsRequest{}
+	s.mockAudit.EXPECT().ListAuditEvents(mock.Anything, req).Return(nil, errors.New("database error")).Once()
+
+	resp, err := s.service.ListAuditEvents(s.ctx, req)
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
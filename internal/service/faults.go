@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/faults"
+)
+
+// injectFault delays or fails the call as the injector draws. Health probes and reflection are
+// left alone, so that the orchestrator keeps the instance running through the experiment.
+func injectFault(ctx context.Context, injector *faults.Injector, fullMethod string) error {
+	for _, prefix := range unauthenticatedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return nil
+		}
+	}
+
+	err := injector.Call(ctx)
+	if errors.Is(err, faults.ErrInjected) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// UnaryFaultInterceptor injects the faults of cfg into unary RPCs, which fail with Unavailable
+func UnaryFaultInterceptor(cfg config.FaultConfig) grpc.UnaryServerInterceptor {
+	injector := faults.NewInjector(faults.TargetGRPC, cfg)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := injectFault(ctx, injector, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamFaultInterceptor injects the faults of cfg into streaming RPCs when they are opened
+func StreamFaultInterceptor(cfg config.FaultConfig) grpc.StreamServerInterceptor {
+	injector := faults.NewInjector(faults.TargetGRPC, cfg)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := injectFault(stream.Context(), injector, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+)
+
+type FaultInterceptorTestSuite struct {
+	suite.Suite
+}
+
+func TestFaultInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(FaultInterceptorTestSuite))
+}
+
+// call runs interceptor for method and reports whether the handler was reached
+func (s *FaultInterceptorTestSuite) call(ctx context.Context, interceptor grpc.UnaryServerInterceptor, method string) (bool, error) {
+	var handled bool
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return nil, nil
+		})
+	return handled, err
+}
+
+func (s *FaultInterceptorTestSuite) TestPassesCallsWithoutFaults() {
+	handled, err := s.call(context.Background(), UnaryFaultInterceptor(config.FaultConfig{}), "/explore.ExploreService/CountLikedYou")
+
+	s.NoError(err)
+	s.True(handled)
+}
+
+func (s *FaultInterceptorTestSuite) TestFailsWithUnavailable() {
+	handled, err := s.call(context.Background(), UnaryFaultInterceptor(config.FaultConfig{ErrorRate: 1}), "/explore.ExploreService/CountLikedYou")
+
+	s.Equal(codes.Unavailable, status.Code(err))
+	s.False(handled)
+}
+
+func (s *FaultInterceptorTestSuite) TestDelayCutShortByDeadline() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	handled, err := s.call(ctx, UnaryFaultInterceptor(config.FaultConfig{Latency: time.Hour, LatencyRate: 1}), "/explore.ExploreService/CountLikedYou")
+
+	s.Equal(codes.DeadlineExceeded, status.Code(err))
+	s.False(handled)
+}
+
+func (s *FaultInterceptorTestSuite) TestLeavesHealthChecksAlone() {
+	handled, err := s.call(context.Background(), UnaryFaultInterceptor(config.FaultConfig{ErrorRate: 1}), "/grpc.health.v1.Health/Check")
+
+	s.NoError(err)
+	s.True(handled)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/backend-interview-task/config"
+)
+
+// NewKeepaliveServerOptions builds the options applying the keepalive and connection age policy
+// of cfg to the gRPC server: idle and aged connections are closed with a GOAWAY, so clients
+// reconnect and spread over the instances of a new deploy, clients are pinged to drop the dead
+// ones, and clients pinging more often than allowed are disconnected.
+func NewKeepaliveServerOptions(cfg config.ServerKeepaliveConfig) ([]grpc.ServerOption, error) {
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"max_connection_idle", cfg.MaxConnectionIdle},
+		{"max_connection_age", cfg.MaxConnectionAge},
+		{"max_connection_age_grace", cfg.MaxConnectionAgeGrace},
+		{"time", cfg.Time},
+		{"timeout", cfg.Timeout},
+		{"min_time", cfg.MinTime},
+	}
+	for _, duration := range durations {
+		if duration.value < 0 {
+			return nil, fmt.Errorf("server.keepalive.%s must not be negative", duration.name)
+		}
+	}
+
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     cfg.MaxConnectionIdle,
+			MaxConnectionAge:      cfg.MaxConnectionAge,
+			MaxConnectionAgeGrace: cfg.MaxConnectionAgeGrace,
+			Time:                  cfg.Time,
+			Timeout:               cfg.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.MinTime,
+			PermitWithoutStream: cfg.PermitWithoutStream,
+		}),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/config"
+)
+
+type KeepaliveTestSuite struct {
+	suite.Suite
+	cfg config.ServerKeepaliveConfig
+}
+
+func TestKeepaliveTestSuite(t *testing.T) {
+	suite.Run(t, new(KeepaliveTestSuite))
+}
+
+func (s *KeepaliveTestSuite) SetupTest() {
+	s.cfg = config.ServerKeepaliveConfig{
+		MaxConnectionIdle:     15 * time.Minute,
+		MaxConnectionAge:      30 * time.Minute,
+		MaxConnectionAgeGrace: time.Minute,
+		Time:                  time.Minute,
+		Timeout:               20 * time.Second,
+		MinTime:               30 * time.Second,
+	}
+}
+
+func (s *KeepaliveTestSuite) TestBuildsOptions() {
+	options, err := NewKeepaliveServerOptions(s.cfg)
+
+	s.NoError(err)
+	s.Len(options, 2)
+}
+
+func (s *KeepaliveTestSuite) TestZeroMeansGRPCDefaults() {
+	options, err := NewKeepaliveServerOptions(config.ServerKeepaliveConfig{})
+
+	s.NoError(err)
+	s.Len(options, 2)
+}
+
+func (s *KeepaliveTestSuite) TestRejectsNegativeDuration() {
+	s.cfg.MaxConnectionAgeGrace = -time.Second
+
+	options, err := NewKeepaliveServerOptions(s.cfg)
+
+	s.Nil(options)
+	s.EqualError(err, "server.keepalive.max_connection_age_grace must not be negative")
+}
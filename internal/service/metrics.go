@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "explore_grpc_requests_total",
+		Help: "gRPC calls handled, by method and status code.",
+	}, []string{"method", "code"})
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "explore_grpc_request_duration_seconds",
+		Help:    "Duration of unary gRPC calls, by method.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"method"})
+	rpcActiveStreams = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "explore_grpc_active_streams",
+		Help: "Streaming gRPC calls currently open, by method.",
+	}, []string{"method"})
+)
+
+// UnaryMetricsInterceptor counts unary RPCs by status code and records their duration. Placed
+// ahead of authentication and rate limiting, it also counts the calls they reject.
+func UnaryMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+		resp, err := handler(ctx, req)
+		rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(started).Seconds())
+		rpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor counts streaming RPCs by status code once they end, and the streams
+// open meanwhile. Their duration is left out, as it is however long the client stays connected.
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		active := rpcActiveStreams.WithLabelValues(info.FullMethod)
+		active.Inc()
+		defer active.Dec()
+		err := handler(srv, stream)
+		rpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		return err
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MetricsInterceptorTestSuite struct {
+	suite.Suite
+}
+
+func TestMetricsInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsInterceptorTestSuite))
+}
+
+func (s *MetricsInterceptorTestSuite) TestCountsUnaryCallsByCode() {
+	const method = "/explore.ExploreService/MetricsUnary"
+	interceptor := UnaryMetricsInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	s.Require().NoError(err)
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad")
+	})
+	s.Require().Error(err)
+
+	s.Equal(1.0, testutil.ToFloat64(rpcRequests.WithLabelValues(method, "OK")))
+	s.Equal(1.0, testutil.ToFloat64(rpcRequests.WithLabelValues(method, "InvalidArgument")))
+	s.Equal(1, testutil.CollectAndCount(rpcDuration, "explore_grpc_request_duration_seconds"))
+}
+
+func (s *MetricsInterceptorTestSuite) TestTracksActiveStreams() {
+	const method = "/explore.ExploreService/MetricsStream"
+	interceptor := StreamMetricsInterceptor()
+
+	err := interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, stream grpc.ServerStream) error {
+		s.Equal(1.0, testutil.ToFloat64(rpcActiveStreams.WithLabelValues(method)))
+		return status.Error(codes.Canceled, "gone")
+	})
+
+	s.Error(err)
+	s.Equal(0.0, testutil.ToFloat64(rpcActiveStreams.WithLabelValues(method)))
+	s.Equal(1.0, testutil.ToFloat64(rpcRequests.WithLabelValues(method, "Canceled")))
+}
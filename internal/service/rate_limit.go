@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/internal/providers/cache"
+	"github.com/backend-interview-task/utils"
+)
+
+// rateLimitSubject names who a call is counted against: the authenticated caller, or else the
+// address the call came from, over gRPC or HTTP
+func rateLimitSubject(ctx context.Context) string {
+	if caller, ok := utils.CallerFromContext(ctx); ok {
+		return "user:" + caller.UserID
+	}
+	if host, ok := peerHost(ctx); ok {
+		return "addr:" + host
+	}
+	if addr, ok := utils.ClientAddressFromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return "addr:" + host
+		}
+		return "addr:" + addr
+	}
+	return "addr:unknown"
+}
+
+// RateLimiter admits the requests of callers within their rate limit. It counts the GraphQL
+// requests against the same limit as the calls the rate limit interceptors admit.
+type RateLimiter struct {
+	limiter cache.CacheProvider
+	cfg     config.RateLimitConfig
+	logger  *zap.Logger
+}
+
+// NewRateLimiter creates a RateLimiter counting requests in limiter
+func NewRateLimiter(limiter cache.CacheProvider, cfg config.RateLimitConfig, logger *zap.Logger) *RateLimiter {
+	return &RateLimiter{limiter: limiter, cfg: cfg, logger: logger}
+}
+
+// Allow admits the request against its caller's rate limit, or reports how long until the
+// caller may retry. Requests are let through when the limit cannot be checked, as an
+// unavailable cache should not take the API down with it.
+func (l *RateLimiter) Allow(ctx context.Context) (bool, time.Duration) {
+	subject := rateLimitSubject(ctx)
+	allowed, retryAfter, err := l.limiter.Allow(ctx, utils.RateLimitKey(ctx, subject), l.cfg.Limit, l.cfg.Period, l.cfg.Burst)
+	if err != nil {
+		utils.Logger(ctx, l.logger).Warn("Failed to check rate limit", zap.String("subject", subject), zap.Error(err))
+		return true, 0
+	}
+	return allowed, retryAfter
+}
+
+// RetryAfterSeconds rounds the time until a rejected caller may retry up to whole seconds, as
+// the retry-after header carries it
+func RetryAfterSeconds(retryAfter time.Duration) int64 {
+	return int64((retryAfter + time.Second - 1) / time.Second)
+}
+
+// limit admits the call against its caller's rate limit
+func limit(ctx context.Context, limiter cache.CacheProvider, cfg config.RateLimitConfig, fullMethod string, logger *zap.Logger) error {
+	for _, prefix := range unauthenticatedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return nil
+		}
+	}
+
+	allowed, retryAfter := NewRateLimiter(limiter, cfg, logger).Allow(ctx)
+	if allowed {
+		return nil
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(RetryAfterSeconds(retryAfter), 10)))
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", retryAfter.Round(time.Millisecond))
+}
+
+// UnaryRateLimitInterceptor rejects unary RPCs of callers over their rate limit
+func UnaryRateLimitInterceptor(limiter cache.CacheProvider, cfg config.RateLimitConfig, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := limit(ctx, limiter, cfg, info.FullMethod, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor rejects streaming RPCs of callers over their rate limit. A stream
+// counts once when it is opened, however many messages it carries.
+func StreamRateLimitInterceptor(limiter cache.CacheProvider, cfg config.RateLimitConfig, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limit(stream.Context(), limiter, cfg, info.FullMethod, logger); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	cachemock "github.com/backend-interview-task/mocks/providers/cache"
+	"github.com/backend-interview-task/utils"
+)
+
+type RateLimitInterceptorTestSuite struct {
+	suite.Suite
+	mockCache   *cachemock.CacheProvider
+	cfg         config.RateLimitConfig
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func TestRateLimitInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitInterceptorTestSuite))
+}
+
+func (s *RateLimitInterceptorTestSuite) SetupTest() {
+	s.mockCache = new(cachemock.CacheProvider)
+	s.cfg = config.RateLimitConfig{Enabled: true, Limit: 600, Period: time.Minute, Burst: 50}
+	s.interceptor = UnaryRateLimitInterceptor(s.mockCache, s.cfg, zap.NewNop())
+}
+
+func (s *RateLimitInterceptorTestSuite) TearDownTest() {
+	s.mockCache.AssertExpectations(s.T())
+}
+
+// call runs the interceptor for method and reports whether the handler was reached
+func (s *RateLimitInterceptorTestSuite) call(ctx context.Context, method string) (bool, error) {
+	var handled bool
+	_, err := s.interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return nil, nil
+		})
+	return handled, err
+}
+
+func (s *RateLimitInterceptorTestSuite) TestLimitsAuthenticatedCaller() {
+	ctx := utils.WithCaller(context.Background(), utils.Caller{UserID: "user123"})
+	s.mockCache.EXPECT().Allow(mock.Anything, utils.RateLimitKey(ctx, "user:user123"), 600, time.Minute, 50).
+		Return(true, time.Duration(0), nil).Once()
+
+	handled, err := s.call(ctx, "/explore.ExploreService/CountLikedYou")
+
+	s.NoError(err)
+	s.True(handled)
+}
+
+func (s *RateLimitInterceptorTestSuite) TestLimitsAddressWithoutCaller() {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 51234}})
+	s.mockCache.EXPECT().Allow(mock.Anything, utils.RateLimitKey(ctx, "addr:10.0.0.7"), 600, time.Minute, 50).
+		Return(true, time.Duration(0), nil).Once()
+
+	handled, err := s.call(ctx, "/explore.ExploreService/CountLikedYou")
+
+	s.NoError(err)
+	s.True(handled)
+}
+
+func (s *RateLimitInterceptorTestSuite) TestRejectsOverLimit() {
+	ctx := utils.WithCaller(context.Background(), utils.Caller{UserID: "user123"})
+	s.mockCache.EXPECT().Allow(mock.Anything, mock.Anything, 600, time.Minute, 50).
+		Return(false, 1500*time.Millisecond, nil).Once()
+
+	handled, err := s.call(ctx, "/explore.ExploreService/CountLikedYou")
+
+	s.False(handled)
+	s.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (s *RateLimitInterceptorTestSuite) TestLetsCallsThroughWhenCacheFails() {
+	ctx := utils.WithCaller(context.Background(), utils.Caller{UserID: "user123"})
+	s.mockCache.EXPECT().Allow(mock.Anything, mock.Anything, 600, time.Minute, 50).
+		Return(false, time.Duration(0), errors.New("connection refused")).Once()
+
+	handled, err := s.call(ctx, "/explore.ExploreService/CountLikedYou")
+
+	s.NoError(err)
+	s.True(handled)
+}
+
+func (s *RateLimitInterceptorTestSuite) TestHealthChecksAreNotLimited() {
+	handled, err := s.call(context.Background(), "/grpc.health.v1.Health/Check")
+
+	s.NoError(err)
+	s.True(handled)
+}
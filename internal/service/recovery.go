@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// recoverCall turns a panic of the call into an Internal error, logging it with its stack,
+// rather than letting it take the whole server down
+func recoverCall(ctx context.Context, fullMethod string, logger *zap.Logger, err *error) {
+	if r := recover(); r != nil {
+		utils.Logger(ctx, logger).Error("Recovered from panic in gRPC handler",
+			zap.String("method", fullMethod),
+			zap.Any("panic", r),
+			zap.Stack("stack"))
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+// UnaryRecoveryInterceptor recovers from panics in unary RPCs
+func UnaryRecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		defer recoverCall(ctx, info.FullMethod, logger, &err)
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor recovers from panics in streaming RPCs
+func StreamRecoveryInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverCall(stream.Context(), info.FullMethod, logger, &err)
+		return handler(srv, stream)
+	}
+}
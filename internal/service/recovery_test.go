@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RecoveryInterceptorTestSuite struct {
+	suite.Suite
+	logs   *observer.ObservedLogs
+	logger *zap.Logger
+}
+
+func TestRecoveryInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(RecoveryInterceptorTestSuite))
+}
+
+func (s *RecoveryInterceptorTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	s.logs = logs
+	s.logger = zap.New(core)
+}
+
+func (s *RecoveryInterceptorTestSuite) TestUnary_RecoversPanic() {
+	interceptor := UnaryRecoveryInterceptor(s.logger)
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/CountLikedYou"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("nil map")
+		})
+
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+	s.Require().Equal(1, s.logs.Len())
+	fields := s.logs.All()[0].ContextMap()
+	s.Equal("nil map", fields["panic"])
+	s.Equal("/explore.ExploreService/CountLikedYou", fields["method"])
+	s.Contains(fields, "stack")
+}
+
+func (s *RecoveryInterceptorTestSuite) TestUnary_PassesThrough() {
+	interceptor := UnaryRecoveryInterceptor(s.logger)
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", status.Error(codes.NotFound, "not found")
+		})
+
+	s.Equal("ok", resp)
+	s.Equal(codes.NotFound, status.Code(err))
+	s.Zero(s.logs.Len())
+}
+
+func (s *RecoveryInterceptorTestSuite) TestStream_RecoversPanic() {
+	interceptor := StreamRecoveryInterceptor(s.logger)
+	stream := &recordedStream{ctx: context.Background()}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/explore.ExploreService/WatchNewLikes"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			panic("closed channel")
+		})
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.Equal(1, s.logs.Len())
+}
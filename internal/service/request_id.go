@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// requestID returns ctx carrying the request ID of the call, taken from its metadata or
+// generated when it has none, and the trailer echoing it back to the caller
+func requestID(ctx context.Context) (context.Context, metadata.MD) {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(utils.RequestIDHeader); len(values) > 0 {
+			incoming = values[0]
+		}
+	}
+	id := utils.RequestID(incoming)
+	return utils.WithRequestID(ctx, id), metadata.Pairs(utils.RequestIDHeader, id)
+}
+
+// UnaryRequestIDInterceptor tags unary RPCs with a request ID, which is echoed in the trailers
+// and added to the log lines of the call
+func UnaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, trailer := requestID(ctx)
+		_ = grpc.SetTrailer(ctx, trailer)
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestIDInterceptor tags streaming RPCs with a request ID, which is echoed in the
+// trailers and added to the log lines of the call
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, trailer := requestID(stream.Context())
+		stream.SetTrailer(trailer)
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/backend-interview-task/utils"
+)
+
+// trailerStream records the trailer set on a unary call
+type trailerStream struct {
+	trailer metadata.MD
+}
+
+func (t *trailerStream) Method() string                  { return "" }
+func (t *trailerStream) SetHeader(md metadata.MD) error  { return nil }
+func (t *trailerStream) SendHeader(md metadata.MD) error { return nil }
+func (t *trailerStream) SetTrailer(md metadata.MD) error {
+	t.trailer = metadata.Join(t.trailer, md)
+	return nil
+}
+
+type RequestIDInterceptorTestSuite struct {
+	suite.Suite
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func TestRequestIDInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDInterceptorTestSuite))
+}
+
+func (s *RequestIDInterceptorTestSuite) SetupTest() {
+	s.interceptor = UnaryRequestIDInterceptor()
+}
+
+// call runs the interceptor with the given metadata and returns the request ID the handler saw
+// and the one echoed in the trailer
+func (s *RequestIDInterceptorTestSuite) call(md metadata.MD) (string, string) {
+	stream := &trailerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), md), stream)
+	var seen string
+	_, err := s.interceptor(ctx, nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			seen = utils.RequestIDFromContext(ctx)
+			return nil, nil
+		})
+	s.Require().NoError(err)
+
+	echoed := stream.trailer.Get(utils.RequestIDHeader)
+	s.Require().Len(echoed, 1)
+	return seen, echoed[0]
+}
+
+func (s *RequestIDInterceptorTestSuite) TestAdoptsIncomingID() {
+	seen, echoed := s.call(metadata.Pairs("x-request-id", "gateway-7f3a"))
+
+	s.Equal("gateway-7f3a", seen)
+	s.Equal("gateway-7f3a", echoed)
+}
+
+func (s *RequestIDInterceptorTestSuite) TestGeneratesMissingID() {
+	seen, echoed := s.call(metadata.MD{})
+
+	s.Len(seen, 32)
+	s.Equal(seen, echoed)
+}
+
+func (s *RequestIDInterceptorTestSuite) TestReplacesMalformedID() {
+	seen, _ := s.call(metadata.Pairs("x-request-id", "two words\n"))
+
+	s.NotEqual("two words\n", seen)
+	s.Len(seen, 32)
+}
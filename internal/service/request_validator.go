@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+// requestValidator holds the checks shared by the gRPC services on user IDs, page sizes and
+// pagination tokens, and signs the pagination tokens they issue
+type requestValidator struct {
+	pagination config.PaginationConfig
+	pageTokens *utils.PageTokenSigner
+	userIDs    *UserIDValidator
+	logger     *zap.Logger
+}
+
+func newRequestValidator(pagination config.PaginationConfig, userIDs *UserIDValidator, logger *zap.Logger) requestValidator {
+	return requestValidator{
+		pagination: pagination,
+		pageTokens: utils.NewPageTokenSigner(pagination.CursorKeys, pagination.CursorTTL),
+		userIDs:    userIDs,
+		logger:     logger,
+	}
+}
+
+// validateUserID returns an InvalidArgument error naming field unless id is a well formed user ID
+func (v *requestValidator) validateUserID(field, id string) error {
+	if err := v.userIDs.Validate(id); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s %s", field, err)
+	}
+	return nil
+}
+
+// verifyPageToken checks the signature of a request's pagination token and replaces it with the
+// token it signs, which is what the core reads
+func (v *requestValidator) verifyPageToken(token *string) error {
+	if token == nil {
+		return nil
+	}
+	unsigned, err := v.pageTokens.Verify(*token)
+	if errors.Is(err, utils.ErrExpiredPageToken) {
+		return status.Error(codes.FailedPrecondition, "pagination_token expired, restart the listing without it")
+	}
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid pagination_token")
+	}
+	*token = unsigned
+	return nil
+}
+
+// signPageToken signs a pagination token of a response
+func (v *requestValidator) signPageToken(ctx context.Context, token *string) error {
+	if token == nil {
+		return nil
+	}
+	signed, err := v.pageTokens.Sign(*token)
+	if err != nil {
+		utils.Logger(ctx, v.logger).Error("Failed to sign pagination token", zap.Error(err))
+		return status.Error(codes.Internal, "failed to issue pagination token")
+	}
+	*token = signed
+	return nil
+}
+
+// validatePageSize checks an explicitly requested page size against the configured bounds
+func (v *requestValidator) validatePageSize(pageSize *uint32) error {
+	if pageSize == nil {
+		return nil
+	}
+	if *pageSize < v.pagination.MinPageSize || *pageSize > v.pagination.MaxPageSize {
+		return status.Errorf(codes.InvalidArgument, "page_size must be between %d and %d",
+			v.pagination.MinPageSize, v.pagination.MaxPageSize)
+	}
+	return nil
+}
+
+// validatePage checks a page number requested for offset pagination against the configured mode,
+// rejecting pages that also carry a pagination token or start past the configured max offset
+func (v *requestValidator) validatePage(page, pageSize *uint32, paginationToken *string) error {
+	if page == nil {
+		return nil
+	}
+	if !v.pagination.Offset.Enabled {
+		return status.Error(codes.InvalidArgument, "offset pagination is disabled")
+	}
+	if *page == 0 {
+		return status.Error(codes.InvalidArgument, "page must be at least 1")
+	}
+	if paginationToken != nil {
+		return status.Error(codes.InvalidArgument, "page and pagination_token are mutually exclusive")
+	}
+	size := uint64(v.pagination.DefaultPageSize)
+	if pageSize != nil {
+		size = uint64(*pageSize)
+	}
+	if offset := (uint64(*page) - 1) * size; offset > uint64(v.pagination.Offset.MaxOffset) {
+		return status.Errorf(codes.InvalidArgument, "page must not start past offset %d", v.pagination.Offset.MaxOffset)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+// resolveTenant scopes ctx to the tenant named in the request metadata, rejecting requests
+// naming no configured tenant. It leaves ctx alone when the service runs single-tenant.
+func resolveTenant(ctx context.Context, cfg config.TenancyConfig) (context.Context, error) {
+	if len(cfg.Tenants) == 0 {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(strings.ToLower(cfg.Header))
+	if len(values) != 1 || values[0] == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s metadata is required", cfg.Header)
+	}
+	if !slices.Contains(cfg.Tenants, values[0]) {
+		return nil, status.Error(codes.PermissionDenied, "unknown tenant")
+	}
+	return utils.WithTenant(ctx, values[0]), nil
+}
+
+// UnaryTenantInterceptor scopes unary RPCs to the tenant named in their metadata
+func UnaryTenantInterceptor(cfg config.TenancyConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := resolveTenant(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamTenantInterceptor scopes streaming RPCs to the tenant named in their metadata
+func StreamTenantInterceptor(cfg config.TenancyConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := resolveTenant(stream.Context(), cfg)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// contextServerStream is a server stream whose context was scoped by an interceptor
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
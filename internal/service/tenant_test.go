@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/backend-interview-task/config"
+	"github.com/backend-interview-task/utils"
+)
+
+type TenantInterceptorTestSuite struct {
+	suite.Suite
+	cfg         config.TenancyConfig
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func TestTenantInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantInterceptorTestSuite))
+}
+
+func (s *TenantInterceptorTestSuite) SetupTest() {
+	s.cfg = config.TenancyConfig{Tenants: []string{"acme", "globex"}, Header: "X-Tenant-Id"}
+	s.interceptor = UnaryTenantInterceptor(s.cfg)
+}
+
+// call runs the interceptor with the given metadata and returns the tenant the handler saw
+func (s *TenantInterceptorTestSuite) call(interceptor grpc.UnaryServerInterceptor, md metadata.MD) (string, error) {
+	var tenant string
+	_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			tenant = utils.TenantFromContext(ctx)
+			return nil, nil
+		})
+	return tenant, err
+}
+
+func (s *TenantInterceptorTestSuite) TestScopesToTenant() {
+	tenant, err := s.call(s.interceptor, metadata.Pairs("x-tenant-id", "globex"))
+
+	s.NoError(err)
+	s.Equal("globex", tenant)
+}
+
+func (s *TenantInterceptorTestSuite) TestMissingTenant() {
+	_, err := s.call(s.interceptor, metadata.MD{})
+
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *TenantInterceptorTestSuite) TestUnknownTenant() {
+	_, err := s.call(s.interceptor, metadata.Pairs("x-tenant-id", "initech"))
+
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *TenantInterceptorTestSuite) TestSingleTenantIgnoresHeader() {
+	tenant, err := s.call(UnaryTenantInterceptor(config.TenancyConfig{Header: "x-tenant-id"}), metadata.Pairs("x-tenant-id", "acme"))
+
+	s.NoError(err)
+	s.Empty(tenant)
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+// NewServerTLSConfig builds the TLS configuration of the gRPC and GraphQL servers. With a client
+// CA it requires and verifies client certificates, and with allowed SPIFFE IDs it only accepts
+// clients carrying one of them.
+func NewServerTLSConfig(cfg config.ServerTLSConfig, logger *zap.Logger) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("server.tls.cert_file and server.tls.key_file are required when TLS is enabled")
+	}
+	if len(cfg.AllowedSPIFFEIDs) > 0 && cfg.ClientCAFile == "" {
+		return nil, errors.New("server.tls.client_ca_file is required to allow SPIFFE IDs")
+	}
+	for _, id := range cfg.AllowedSPIFFEIDs {
+		if !strings.HasPrefix(id, "spiffe://") {
+			return nil, fmt.Errorf("invalid SPIFFE ID %q", id)
+		}
+	}
+
+	files := &tlsFiles{cfg: cfg, logger: logger}
+	if err := files.load(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: files.certificate,
+	}
+	if cfg.ClientCAFile != "" {
+		// The chain is verified against the current client CAs below rather than a fixed
+		// ClientCAs pool, so that a rotated bundle applies without a restart
+		tlsConfig.ClientAuth = tls.RequireAnyClientCert
+		tlsConfig.VerifyPeerCertificate = files.verifyClient
+	}
+	return tlsConfig, nil
+}
+
+// tlsFiles holds the server certificate and client CAs read from disk, rereading them when
+// their files change as short-lived certificates are rotated, if reloading is on
+type tlsFiles struct {
+	cfg    config.ServerTLSConfig
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	modTimes  []time.Time
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// load reads the files if any of them changed since they were last read
+func (f *tlsFiles) load() error {
+	paths := []string{f.cfg.CertFile, f.cfg.KeyFile, f.cfg.ClientCAFile}
+	modTimes := make([]time.Time, len(paths))
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cert != nil && slices.EqualFunc(modTimes, f.modTimes, time.Time.Equal) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(f.cfg.CertFile, f.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if f.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(f.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", f.cfg.ClientCAFile)
+		}
+	}
+
+	if f.cert != nil {
+		f.logger.Info("Reloaded server TLS certificates")
+	}
+	f.cert, f.clientCAs, f.modTimes = &cert, clientCAs, modTimes
+	return nil
+}
+
+// current returns the files last read, rereading them first if they changed and reloading is
+// on. A failed reread, as when a rotation is caught halfway, keeps serving the previous ones.
+func (f *tlsFiles) current() (*tls.Certificate, *x509.CertPool) {
+	if f.cfg.Reload {
+		if err := f.load(); err != nil {
+			f.logger.Warn("Failed to reload server TLS certificates", zap.Error(err))
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cert, f.clientCAs
+}
+
+func (f *tlsFiles) certificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, _ := f.current()
+	return cert, nil
+}
+
+// verifyClient verifies the client certificate chain against the client CAs and its SPIFFE ID
+// against the allowlist
+func (f *tlsFiles) verifyClient(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errors.New("client certificate required")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+
+	_, clientCAs := f.current()
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("untrusted client certificate: %w", err)
+	}
+
+	if len(f.cfg.AllowedSPIFFEIDs) == 0 {
+		return nil
+	}
+	for _, uri := range certs[0].URIs {
+		if uri.Scheme == "spiffe" && spiffeIDAllowed(uri.String(), f.cfg.AllowedSPIFFEIDs) {
+			return nil
+		}
+	}
+	return errors.New("client SPIFFE ID is not allowed")
+}
+
+// spiffeIDAllowed reports whether id is one of allowed, where an entry ending in /* allows
+// every ID under its path
+func spiffeIDAllowed(id string, allowed []string) bool {
+	for _, entry := range allowed {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok && strings.HasSuffix(prefix, "/") {
+			if strings.HasPrefix(id, prefix) && len(id) > len(prefix) {
+				return true
+			}
+		} else if id == entry {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/backend-interview-task/config"
+)
+
+type ServerTLSTestSuite struct {
+	suite.Suite
+	dir    string
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	cfg    config.ServerTLSConfig
+	serial int64
+}
+
+func TestServerTLSTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTLSTestSuite))
+}
+
+func (s *ServerTLSTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.ca, s.caKey = s.newCA()
+
+	serverCert, serverKey := s.issue(s.ca, s.caKey, "server", "", x509.ExtKeyUsageServerAuth)
+	s.cfg = config.ServerTLSConfig{
+		Enabled:      true,
+		CertFile:     s.writePEM("server.crt", "CERTIFICATE", serverCert.Raw),
+		KeyFile:      s.writeKey("server.key", serverKey),
+		ClientCAFile: s.writePEM("ca.crt", "CERTIFICATE", s.ca.Raw),
+		Reload:       true,
+	}
+}
+
+func (s *ServerTLSTestSuite) newCA() (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	s.serial++
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(s.serial),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	ca, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return ca, key
+}
+
+// issue returns a certificate signed by ca, carrying spiffeID as a URI SAN when set
+func (s *ServerTLSTestSuite) issue(ca *x509.Certificate, caKey *ecdsa.PrivateKey, name, spiffeID string, usage x509.ExtKeyUsage) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	s.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(s.serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	if spiffeID != "" {
+		uri, err := url.Parse(spiffeID)
+		s.Require().NoError(err)
+		template.URIs = []*url.URL{uri}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return cert, key
+}
+
+func (s *ServerTLSTestSuite) writePEM(name, blockType string, der []byte) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func (s *ServerTLSTestSuite) writeKey(name string, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+	return s.writePEM(name, "EC PRIVATE KEY", der)
+}
+
+// client returns a client certificate issued by the test CA for spiffeID
+func (s *ServerTLSTestSuite) client(spiffeID string) []tls.Certificate {
+	cert, key := s.issue(s.ca, s.caKey, "client", spiffeID, x509.ExtKeyUsageClientAuth)
+	return []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}
+}
+
+// handshake connects a client presenting certs to a server using cfg and returns the server
+// side handshake error
+func (s *ServerTLSTestSuite) handshake(cfg config.ServerTLSConfig, certs []tls.Certificate) error {
+	serverConfig, err := NewServerTLSConfig(cfg, zap.NewNop())
+	s.Require().NoError(err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- conn.(*tls.Conn).Handshake()
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(s.ca)
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{RootCAs: roots, ServerName: "server", Certificates: certs})
+	if err == nil {
+		defer conn.Close()
+	}
+	return <-serverErr
+}
+
+func (s *ServerTLSTestSuite) TestMutualTLS() {
+	err := s.handshake(s.cfg, s.client(""))
+
+	s.NoError(err)
+}
+
+func (s *ServerTLSTestSuite) TestRequiresClientCertificate() {
+	err := s.handshake(s.cfg, nil)
+
+	s.Error(err)
+}
+
+func (s *ServerTLSTestSuite) TestRejectsOtherCA() {
+	otherCA, otherKey := s.newCA()
+	cert, key := s.issue(otherCA, otherKey, "client", "", x509.ExtKeyUsageClientAuth)
+
+	err := s.handshake(s.cfg, []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}})
+
+	s.Error(err)
+	s.Contains(err.Error(), "untrusted client certificate")
+}
+
+func (s *ServerTLSTestSuite) TestSPIFFEAllowlist() {
+	s.cfg.AllowedSPIFFEIDs = []string{"spiffe://example.org/ns/prod/sa/gateway", "spiffe://example.org/ns/jobs/*"}
+
+	for id, allowed := range map[string]bool{
+		"spiffe://example.org/ns/prod/sa/gateway":  true,
+		"spiffe://example.org/ns/jobs/sa/indexer":  true,
+		"spiffe://example.org/ns/prod/sa/billing":  false,
+		"spiffe://example.org/ns/jobsx/sa/indexer": false,
+		"": false,
+	} {
+		err := s.handshake(s.cfg, s.client(id))
+		if allowed {
+			s.NoError(err, id)
+		} else {
+			s.Error(err, id)
+		}
+	}
+}
+
+// rotate writes a new server certificate over the configured one and returns it
+func (s *ServerTLSTestSuite) rotate() *x509.Certificate {
+	rotated, rotatedKey := s.issue(s.ca, s.caKey, "server", "", x509.ExtKeyUsageServerAuth)
+	s.writePEM("server.crt", "CERTIFICATE", rotated.Raw)
+	s.writeKey("server.key", rotatedKey)
+	later := time.Now().Add(time.Minute)
+	s.Require().NoError(os.Chtimes(s.cfg.CertFile, later, later))
+	s.Require().NoError(os.Chtimes(s.cfg.KeyFile, later, later))
+	return rotated
+}
+
+func (s *ServerTLSTestSuite) TestReloadsRotatedCertificate() {
+	serverConfig, err := NewServerTLSConfig(s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+	before, err := serverConfig.GetCertificate(nil)
+	s.Require().NoError(err)
+
+	rotated := s.rotate()
+
+	after, err := serverConfig.GetCertificate(nil)
+	s.Require().NoError(err)
+	s.NotEqual(before.Certificate[0], after.Certificate[0])
+	s.Equal(rotated.Raw, after.Certificate[0])
+}
+
+func (s *ServerTLSTestSuite) TestKeepsCertificateWithoutReload() {
+	s.cfg.Reload = false
+	serverConfig, err := NewServerTLSConfig(s.cfg, zap.NewNop())
+	s.Require().NoError(err)
+	before, err := serverConfig.GetCertificate(nil)
+	s.Require().NoError(err)
+
+	s.rotate()
+
+	after, err := serverConfig.GetCertificate(nil)
+	s.Require().NoError(err)
+	s.Equal(before.Certificate[0], after.Certificate[0])
+}
+
+func (s *ServerTLSTestSuite) TestInvalidConfig() {
+	_, err := NewServerTLSConfig(config.ServerTLSConfig{Enabled: true}, zap.NewNop())
+	s.Error(err)
+
+	_, err = NewServerTLSConfig(config.ServerTLSConfig{
+		Enabled:          true,
+		CertFile:         s.cfg.CertFile,
+		KeyFile:          s.cfg.KeyFile,
+		AllowedSPIFFEIDs: []string{"spiffe://example.org/gateway"},
+	}, zap.NewNop())
+	s.Error(err)
+}
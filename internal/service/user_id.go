@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/backend-interview-task/config"
+)
+
+const (
+	// UserIDFormatAny accepts any user ID within the length bound and without whitespace or control characters
+	UserIDFormatAny = "any"
+	// UserIDFormatUUID accepts canonical UUIDs
+	UserIDFormatUUID = "uuid"
+	// UserIDFormatPattern accepts user IDs matching the configured pattern, such as prefixed IDs
+	UserIDFormatPattern = "pattern"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UserIDValidator checks that user IDs have the configured format before they reach the store
+type UserIDValidator struct {
+	format    string
+	pattern   *regexp.Regexp
+	maxLength int
+}
+
+// NewUserIDValidator creates a UserIDValidator for cfg, compiling its pattern
+func NewUserIDValidator(cfg config.UserIDsConfig) (*UserIDValidator, error) {
+	if cfg.MaxLength < 1 {
+		return nil, fmt.Errorf("invalid user ID max length %d", cfg.MaxLength)
+	}
+	v := &UserIDValidator{format: cfg.Format, maxLength: cfg.MaxLength}
+	switch cfg.Format {
+	case UserIDFormatAny:
+	case UserIDFormatUUID:
+		v.pattern = uuidPattern
+	case UserIDFormatPattern:
+		if cfg.Pattern == "" {
+			return nil, errors.New("a user ID pattern is required for the pattern format")
+		}
+		pattern, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID pattern: %w", err)
+		}
+		v.pattern = pattern
+	default:
+		return nil, fmt.Errorf("unknown user ID format %q", cfg.Format)
+	}
+	return v, nil
+}
+
+// Validate returns why id is not a well formed user ID, in words following the field name
+func (v *UserIDValidator) Validate(id string) error {
+	if id == "" {
+		return errors.New("is required")
+	}
+	if len(id) > v.maxLength {
+		return fmt.Errorf("must be at most %d bytes", v.maxLength)
+	}
+	if !utf8.ValidString(id) {
+		return errors.New("must be valid UTF-8")
+	}
+	if strings.IndexFunc(id, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return errors.New("must not contain whitespace or control characters")
+	}
+	switch v.format {
+	case UserIDFormatUUID:
+		if !v.pattern.MatchString(id) {
+			return errors.New("must be a UUID")
+		}
+	case UserIDFormatPattern:
+		if !v.pattern.MatchString(id) {
+			return fmt.Errorf("must match %s", v.pattern)
+		}
+	}
+	return nil
+}
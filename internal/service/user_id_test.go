@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/backend-interview-task/config"
+)
+
+type UserIDValidatorTestSuite struct {
+	suite.Suite
+}
+
+func TestUserIDValidatorTestSuite(t *testing.T) {
+	suite.Run(t, new(UserIDValidatorTestSuite))
+}
+
+func (s *UserIDValidatorTestSuite) validator(cfg config.UserIDsConfig) *UserIDValidator {
+	if cfg.MaxLength == 0 {
+		cfg.MaxLength = 128
+	}
+	v, err := NewUserIDValidator(cfg)
+	s.Require().NoError(err)
+	return v
+}
+
+func (s *UserIDValidatorTestSuite) TestUUID() {
+	v := s.validator(config.UserIDsConfig{Format: UserIDFormatUUID})
+
+	s.NoError(v.Validate("3f2504e0-4f89-11d3-9a0c-0305e82c3301"))
+	s.NoError(v.Validate("3F2504E0-4F89-11D3-9A0C-0305E82C3301"))
+	s.ErrorContains(v.Validate("3f2504e04f8911d39a0c0305e82c3301"), "must be a UUID")
+	s.ErrorContains(v.Validate("user123"), "must be a UUID")
+	s.ErrorContains(v.Validate(""), "is required")
+}
+
+func (s *UserIDValidatorTestSuite) TestPattern() {
+	v := s.validator(config.UserIDsConfig{Format: UserIDFormatPattern, Pattern: `^usr_[0-9A-Za-z]{8}$`})
+
+	s.NoError(v.Validate("usr_a1B2c3D4"))
+	s.ErrorContains(v.Validate("usr_a1B2"), "must match ^usr_[0-9A-Za-z]{8}$")
+	s.ErrorContains(v.Validate("acct_a1B2c3D4"), "must match")
+}
+
+func (s *UserIDValidatorTestSuite) TestAny() {
+	v := s.validator(config.UserIDsConfig{Format: UserIDFormatAny, MaxLength: 8})
+
+	s.NoError(v.Validate("user-123"))
+	s.ErrorContains(v.Validate("user-1234"), "must be at most 8 bytes")
+	s.ErrorContains(v.Validate("user\t1"), "must not contain whitespace")
+	s.ErrorContains(v.Validate("user\x001"), "control characters")
+}
+
+func (s *UserIDValidatorTestSuite) TestInvalidConfig() {
+	invalid := map[string]config.UserIDsConfig{
+		"unknown format":  {Format: "email", MaxLength: 128},
+		"missing pattern": {Format: UserIDFormatPattern, MaxLength: 128},
+		"bad pattern":     {Format: UserIDFormatPattern, Pattern: "usr_(", MaxLength: 128},
+		"no max length":   {Format: UserIDFormatAny},
+	}
+	for name, cfg := range invalid {
+		_, err := NewUserIDValidator(cfg)
+		s.Error(err, name)
+	}
+}
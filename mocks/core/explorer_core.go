@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	models "github.com/backend-interview-task/internal/models"
+
 	proto "github.com/backend-interview-task/proto"
 )
 
@@ -23,6 +25,183 @@ func (_m *ExplorerCore) EXPECT() *ExplorerCore_Expecter {
 	return &ExplorerCore_Expecter{mock: &_m.Mock}
 }
 
+// BatchCreateDecisions provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) BatchCreateDecisions(ctx context.Context, req *proto.BatchPutDecisionsRequest) (*proto.BatchPutDecisionsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchCreateDecisions")
+	}
+
+	var r0 *proto.BatchPutDecisionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.BatchPutDecisionsRequest) (*proto.BatchPutDecisionsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.BatchPutDecisionsRequest) *proto.BatchPutDecisionsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.BatchPutDecisionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.BatchPutDecisionsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_BatchCreateDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchCreateDecisions'
+type ExplorerCore_BatchCreateDecisions_Call struct {
+	*mock.Call
+}
+
+// BatchCreateDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.BatchPutDecisionsRequest
+func (_e *ExplorerCore_Expecter) BatchCreateDecisions(ctx interface{}, req interface{}) *ExplorerCore_BatchCreateDecisions_Call {
+	return &ExplorerCore_BatchCreateDecisions_Call{Call: _e.mock.On("BatchCreateDecisions", ctx, req)}
+}
+
+func (_c *ExplorerCore_BatchCreateDecisions_Call) Run(run func(ctx context.Context, req *proto.BatchPutDecisionsRequest)) *ExplorerCore_BatchCreateDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.BatchPutDecisionsRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_BatchCreateDecisions_Call) Return(_a0 *proto.BatchPutDecisionsResponse, _a1 error) *ExplorerCore_BatchCreateDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_BatchCreateDecisions_Call) RunAndReturn(run func(context.Context, *proto.BatchPutDecisionsRequest) (*proto.BatchPutDecisionsResponse, error)) *ExplorerCore_BatchCreateDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockUser provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) BlockUser(ctx context.Context, req *proto.BlockUserRequest) (*proto.BlockUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 *proto.BlockUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.BlockUserRequest) (*proto.BlockUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.BlockUserRequest) *proto.BlockUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.BlockUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.BlockUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_BlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUser'
+type ExplorerCore_BlockUser_Call struct {
+	*mock.Call
+}
+
+// BlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.BlockUserRequest
+func (_e *ExplorerCore_Expecter) BlockUser(ctx interface{}, req interface{}) *ExplorerCore_BlockUser_Call {
+	return &ExplorerCore_BlockUser_Call{Call: _e.mock.On("BlockUser", ctx, req)}
+}
+
+func (_c *ExplorerCore_BlockUser_Call) Run(run func(ctx context.Context, req *proto.BlockUserRequest)) *ExplorerCore_BlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.BlockUserRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_BlockUser_Call) Return(_a0 *proto.BlockUserResponse, _a1 error) *ExplorerCore_BlockUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_BlockUser_Call) RunAndReturn(run func(context.Context, *proto.BlockUserRequest) (*proto.BlockUserResponse, error)) *ExplorerCore_BlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckMutualLike provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) CheckMutualLike(ctx context.Context, req *proto.CheckMutualLikeRequest) (*proto.CheckMutualLikeResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckMutualLike")
+	}
+
+	var r0 *proto.CheckMutualLikeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.CheckMutualLikeRequest) (*proto.CheckMutualLikeResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.CheckMutualLikeRequest) *proto.CheckMutualLikeResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.CheckMutualLikeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.CheckMutualLikeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_CheckMutualLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckMutualLike'
+type ExplorerCore_CheckMutualLike_Call struct {
+	*mock.Call
+}
+
+// CheckMutualLike is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.CheckMutualLikeRequest
+func (_e *ExplorerCore_Expecter) CheckMutualLike(ctx interface{}, req interface{}) *ExplorerCore_CheckMutualLike_Call {
+	return &ExplorerCore_CheckMutualLike_Call{Call: _e.mock.On("CheckMutualLike", ctx, req)}
+}
+
+func (_c *ExplorerCore_CheckMutualLike_Call) Run(run func(ctx context.Context, req *proto.CheckMutualLikeRequest)) *ExplorerCore_CheckMutualLike_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.CheckMutualLikeRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_CheckMutualLike_Call) Return(_a0 *proto.CheckMutualLikeResponse, _a1 error) *ExplorerCore_CheckMutualLike_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_CheckMutualLike_Call) RunAndReturn(run func(context.Context, *proto.CheckMutualLikeRequest) (*proto.CheckMutualLikeResponse, error)) *ExplorerCore_CheckMutualLike_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountLikers provides a mock function with given fields: ctx, req
 func (_m *ExplorerCore) CountLikers(ctx context.Context, req *proto.CountLikedYouRequest) (*proto.CountLikedYouResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -141,28 +320,28 @@ func (_c *ExplorerCore_CreateDecision_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
-// ListLikers provides a mock function with given fields: ctx, req
-func (_m *ExplorerCore) ListLikers(ctx context.Context, req *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error) {
+// DeleteDecision provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) DeleteDecision(ctx context.Context, req *proto.DeleteDecisionRequest) (*proto.DeleteDecisionResponse, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLikers")
+		panic("no return value specified for DeleteDecision")
 	}
 
-	var r0 *proto.ListLikedYouResponse
+	var r0 *proto.DeleteDecisionResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.DeleteDecisionRequest) (*proto.DeleteDecisionResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) *proto.ListLikedYouResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.DeleteDecisionRequest) *proto.DeleteDecisionResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*proto.ListLikedYouResponse)
+			r0 = ret.Get(0).(*proto.DeleteDecisionResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListLikedYouRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.DeleteDecisionRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -171,57 +350,103 @@ func (_m *ExplorerCore) ListLikers(ctx context.Context, req *proto.ListLikedYouR
 	return r0, r1
 }
 
-// ExplorerCore_ListLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLikers'
-type ExplorerCore_ListLikers_Call struct {
+// ExplorerCore_DeleteDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDecision'
+type ExplorerCore_DeleteDecision_Call struct {
 	*mock.Call
 }
 
-// ListLikers is a helper method to define mock.On call
+// DeleteDecision is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *proto.ListLikedYouRequest
-func (_e *ExplorerCore_Expecter) ListLikers(ctx interface{}, req interface{}) *ExplorerCore_ListLikers_Call {
-	return &ExplorerCore_ListLikers_Call{Call: _e.mock.On("ListLikers", ctx, req)}
+//   - req *proto.DeleteDecisionRequest
+func (_e *ExplorerCore_Expecter) DeleteDecision(ctx interface{}, req interface{}) *ExplorerCore_DeleteDecision_Call {
+	return &ExplorerCore_DeleteDecision_Call{Call: _e.mock.On("DeleteDecision", ctx, req)}
 }
 
-func (_c *ExplorerCore_ListLikers_Call) Run(run func(ctx context.Context, req *proto.ListLikedYouRequest)) *ExplorerCore_ListLikers_Call {
+func (_c *ExplorerCore_DeleteDecision_Call) Run(run func(ctx context.Context, req *proto.DeleteDecisionRequest)) *ExplorerCore_DeleteDecision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*proto.ListLikedYouRequest))
+		run(args[0].(context.Context), args[1].(*proto.DeleteDecisionRequest))
 	})
 	return _c
 }
 
-func (_c *ExplorerCore_ListLikers_Call) Return(_a0 *proto.ListLikedYouResponse, _a1 error) *ExplorerCore_ListLikers_Call {
+func (_c *ExplorerCore_DeleteDecision_Call) Return(_a0 *proto.DeleteDecisionResponse, _a1 error) *ExplorerCore_DeleteDecision_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerCore_ListLikers_Call) RunAndReturn(run func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)) *ExplorerCore_ListLikers_Call {
+func (_c *ExplorerCore_DeleteDecision_Call) RunAndReturn(run func(context.Context, *proto.DeleteDecisionRequest) (*proto.DeleteDecisionResponse, error)) *ExplorerCore_DeleteDecision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListNewLikers provides a mock function with given fields: ctx, req
-func (_m *ExplorerCore) ListNewLikers(ctx context.Context, req *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error) {
+// Flush provides a mock function with given fields: ctx
+func (_m *ExplorerCore) Flush(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerCore_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type ExplorerCore_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ExplorerCore_Expecter) Flush(ctx interface{}) *ExplorerCore_Flush_Call {
+	return &ExplorerCore_Flush_Call{Call: _e.mock.On("Flush", ctx)}
+}
+
+func (_c *ExplorerCore_Flush_Call) Run(run func(ctx context.Context)) *ExplorerCore_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_Flush_Call) Return(_a0 error) *ExplorerCore_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerCore_Flush_Call) RunAndReturn(run func(context.Context) error) *ExplorerCore_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDecision provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) GetDecision(ctx context.Context, req *proto.GetDecisionRequest) (*proto.GetDecisionResponse, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListNewLikers")
+		panic("no return value specified for GetDecision")
 	}
 
-	var r0 *proto.ListLikedYouResponse
+	var r0 *proto.GetDecisionResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.GetDecisionRequest) (*proto.GetDecisionResponse, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) *proto.ListLikedYouResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.GetDecisionRequest) *proto.GetDecisionResponse); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*proto.ListLikedYouResponse)
+			r0 = ret.Get(0).(*proto.GetDecisionResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListLikedYouRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.GetDecisionRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -230,31 +455,596 @@ func (_m *ExplorerCore) ListNewLikers(ctx context.Context, req *proto.ListLikedY
 	return r0, r1
 }
 
-// ExplorerCore_ListNewLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNewLikers'
-type ExplorerCore_ListNewLikers_Call struct {
+// ExplorerCore_GetDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDecision'
+type ExplorerCore_GetDecision_Call struct {
 	*mock.Call
 }
 
-// ListNewLikers is a helper method to define mock.On call
+// GetDecision is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *proto.ListLikedYouRequest
-func (_e *ExplorerCore_Expecter) ListNewLikers(ctx interface{}, req interface{}) *ExplorerCore_ListNewLikers_Call {
-	return &ExplorerCore_ListNewLikers_Call{Call: _e.mock.On("ListNewLikers", ctx, req)}
+//   - req *proto.GetDecisionRequest
+func (_e *ExplorerCore_Expecter) GetDecision(ctx interface{}, req interface{}) *ExplorerCore_GetDecision_Call {
+	return &ExplorerCore_GetDecision_Call{Call: _e.mock.On("GetDecision", ctx, req)}
 }
 
-func (_c *ExplorerCore_ListNewLikers_Call) Run(run func(ctx context.Context, req *proto.ListLikedYouRequest)) *ExplorerCore_ListNewLikers_Call {
+func (_c *ExplorerCore_GetDecision_Call) Run(run func(ctx context.Context, req *proto.GetDecisionRequest)) *ExplorerCore_GetDecision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*proto.ListLikedYouRequest))
+		run(args[0].(context.Context), args[1].(*proto.GetDecisionRequest))
 	})
 	return _c
 }
 
-func (_c *ExplorerCore_ListNewLikers_Call) Return(_a0 *proto.ListLikedYouResponse, _a1 error) *ExplorerCore_ListNewLikers_Call {
+func (_c *ExplorerCore_GetDecision_Call) Return(_a0 *proto.GetDecisionResponse, _a1 error) *ExplorerCore_GetDecision_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerCore_ListNewLikers_Call) RunAndReturn(run func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)) *ExplorerCore_ListNewLikers_Call {
+func (_c *ExplorerCore_GetDecision_Call) RunAndReturn(run func(context.Context, *proto.GetDecisionRequest) (*proto.GetDecisionResponse, error)) *ExplorerCore_GetDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleDecisionChange provides a mock function with given fields: ctx, change
+func (_m *ExplorerCore) HandleDecisionChange(ctx context.Context, change models.DecisionChange) error {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleDecisionChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.DecisionChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerCore_HandleDecisionChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleDecisionChange'
+type ExplorerCore_HandleDecisionChange_Call struct {
+	*mock.Call
+}
+
+// HandleDecisionChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - change models.DecisionChange
+func (_e *ExplorerCore_Expecter) HandleDecisionChange(ctx interface{}, change interface{}) *ExplorerCore_HandleDecisionChange_Call {
+	return &ExplorerCore_HandleDecisionChange_Call{Call: _e.mock.On("HandleDecisionChange", ctx, change)}
+}
+
+func (_c *ExplorerCore_HandleDecisionChange_Call) Run(run func(ctx context.Context, change models.DecisionChange)) *ExplorerCore_HandleDecisionChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.DecisionChange))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_HandleDecisionChange_Call) Return(_a0 error) *ExplorerCore_HandleDecisionChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerCore_HandleDecisionChange_Call) RunAndReturn(run func(context.Context, models.DecisionChange) error) *ExplorerCore_HandleDecisionChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestDecisions provides a mock function with given fields: ctx, decisions
+func (_m *ExplorerCore) IngestDecisions(ctx context.Context, decisions []*proto.PutDecisionRequest) (*proto.PutDecisionsSummary, error) {
+	ret := _m.Called(ctx, decisions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IngestDecisions")
+	}
+
+	var r0 *proto.PutDecisionsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*proto.PutDecisionRequest) (*proto.PutDecisionsSummary, error)); ok {
+		return rf(ctx, decisions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*proto.PutDecisionRequest) *proto.PutDecisionsSummary); ok {
+		r0 = rf(ctx, decisions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.PutDecisionsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*proto.PutDecisionRequest) error); ok {
+		r1 = rf(ctx, decisions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_IngestDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestDecisions'
+type ExplorerCore_IngestDecisions_Call struct {
+	*mock.Call
+}
+
+// IngestDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decisions []*proto.PutDecisionRequest
+func (_e *ExplorerCore_Expecter) IngestDecisions(ctx interface{}, decisions interface{}) *ExplorerCore_IngestDecisions_Call {
+	return &ExplorerCore_IngestDecisions_Call{Call: _e.mock.On("IngestDecisions", ctx, decisions)}
+}
+
+func (_c *ExplorerCore_IngestDecisions_Call) Run(run func(ctx context.Context, decisions []*proto.PutDecisionRequest)) *ExplorerCore_IngestDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*proto.PutDecisionRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_IngestDecisions_Call) Return(_a0 *proto.PutDecisionsSummary, _a1 error) *ExplorerCore_IngestDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_IngestDecisions_Call) RunAndReturn(run func(context.Context, []*proto.PutDecisionRequest) (*proto.PutDecisionsSummary, error)) *ExplorerCore_IngestDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLikedRecipients provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) ListLikedRecipients(ctx context.Context, req *proto.ListLikedByYouRequest) (*proto.ListLikedByYouResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLikedRecipients")
+	}
+
+	var r0 *proto.ListLikedByYouResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedByYouRequest) (*proto.ListLikedByYouResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedByYouRequest) *proto.ListLikedByYouResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.ListLikedByYouResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListLikedByYouRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_ListLikedRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLikedRecipients'
+type ExplorerCore_ListLikedRecipients_Call struct {
+	*mock.Call
+}
+
+// ListLikedRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.ListLikedByYouRequest
+func (_e *ExplorerCore_Expecter) ListLikedRecipients(ctx interface{}, req interface{}) *ExplorerCore_ListLikedRecipients_Call {
+	return &ExplorerCore_ListLikedRecipients_Call{Call: _e.mock.On("ListLikedRecipients", ctx, req)}
+}
+
+func (_c *ExplorerCore_ListLikedRecipients_Call) Run(run func(ctx context.Context, req *proto.ListLikedByYouRequest)) *ExplorerCore_ListLikedRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.ListLikedByYouRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_ListLikedRecipients_Call) Return(_a0 *proto.ListLikedByYouResponse, _a1 error) *ExplorerCore_ListLikedRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_ListLikedRecipients_Call) RunAndReturn(run func(context.Context, *proto.ListLikedByYouRequest) (*proto.ListLikedByYouResponse, error)) *ExplorerCore_ListLikedRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLikers provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) ListLikers(ctx context.Context, req *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLikers")
+	}
+
+	var r0 *proto.ListLikedYouResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) *proto.ListLikedYouResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.ListLikedYouResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListLikedYouRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_ListLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLikers'
+type ExplorerCore_ListLikers_Call struct {
+	*mock.Call
+}
+
+// ListLikers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.ListLikedYouRequest
+func (_e *ExplorerCore_Expecter) ListLikers(ctx interface{}, req interface{}) *ExplorerCore_ListLikers_Call {
+	return &ExplorerCore_ListLikers_Call{Call: _e.mock.On("ListLikers", ctx, req)}
+}
+
+func (_c *ExplorerCore_ListLikers_Call) Run(run func(ctx context.Context, req *proto.ListLikedYouRequest)) *ExplorerCore_ListLikers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.ListLikedYouRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_ListLikers_Call) Return(_a0 *proto.ListLikedYouResponse, _a1 error) *ExplorerCore_ListLikers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_ListLikers_Call) RunAndReturn(run func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)) *ExplorerCore_ListLikers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMatches provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) ListMatches(ctx context.Context, req *proto.ListMatchesRequest) (*proto.ListMatchesResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMatches")
+	}
+
+	var r0 *proto.ListMatchesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListMatchesRequest) (*proto.ListMatchesResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListMatchesRequest) *proto.ListMatchesResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.ListMatchesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListMatchesRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_ListMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatches'
+type ExplorerCore_ListMatches_Call struct {
+	*mock.Call
+}
+
+// ListMatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.ListMatchesRequest
+func (_e *ExplorerCore_Expecter) ListMatches(ctx interface{}, req interface{}) *ExplorerCore_ListMatches_Call {
+	return &ExplorerCore_ListMatches_Call{Call: _e.mock.On("ListMatches", ctx, req)}
+}
+
+func (_c *ExplorerCore_ListMatches_Call) Run(run func(ctx context.Context, req *proto.ListMatchesRequest)) *ExplorerCore_ListMatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.ListMatchesRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_ListMatches_Call) Return(_a0 *proto.ListMatchesResponse, _a1 error) *ExplorerCore_ListMatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_ListMatches_Call) RunAndReturn(run func(context.Context, *proto.ListMatchesRequest) (*proto.ListMatchesResponse, error)) *ExplorerCore_ListMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNewLikers provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) ListNewLikers(ctx context.Context, req *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNewLikers")
+	}
+
+	var r0 *proto.ListLikedYouResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListLikedYouRequest) *proto.ListLikedYouResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.ListLikedYouResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListLikedYouRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_ListNewLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNewLikers'
+type ExplorerCore_ListNewLikers_Call struct {
+	*mock.Call
+}
+
+// ListNewLikers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.ListLikedYouRequest
+func (_e *ExplorerCore_Expecter) ListNewLikers(ctx interface{}, req interface{}) *ExplorerCore_ListNewLikers_Call {
+	return &ExplorerCore_ListNewLikers_Call{Call: _e.mock.On("ListNewLikers", ctx, req)}
+}
+
+func (_c *ExplorerCore_ListNewLikers_Call) Run(run func(ctx context.Context, req *proto.ListLikedYouRequest)) *ExplorerCore_ListNewLikers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.ListLikedYouRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_ListNewLikers_Call) Return(_a0 *proto.ListLikedYouResponse, _a1 error) *ExplorerCore_ListNewLikers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_ListNewLikers_Call) RunAndReturn(run func(context.Context, *proto.ListLikedYouRequest) (*proto.ListLikedYouResponse, error)) *ExplorerCore_ListNewLikers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPassers provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) ListPassers(ctx context.Context, req *proto.ListPassedYouRequest) (*proto.ListPassedYouResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPassers")
+	}
+
+	var r0 *proto.ListPassedYouResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListPassedYouRequest) (*proto.ListPassedYouResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ListPassedYouRequest) *proto.ListPassedYouResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.ListPassedYouResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.ListPassedYouRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_ListPassers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPassers'
+type ExplorerCore_ListPassers_Call struct {
+	*mock.Call
+}
+
+// ListPassers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.ListPassedYouRequest
+func (_e *ExplorerCore_Expecter) ListPassers(ctx interface{}, req interface{}) *ExplorerCore_ListPassers_Call {
+	return &ExplorerCore_ListPassers_Call{Call: _e.mock.On("ListPassers", ctx, req)}
+}
+
+func (_c *ExplorerCore_ListPassers_Call) Run(run func(ctx context.Context, req *proto.ListPassedYouRequest)) *ExplorerCore_ListPassers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.ListPassedYouRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_ListPassers_Call) Return(_a0 *proto.ListPassedYouResponse, _a1 error) *ExplorerCore_ListPassers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_ListPassers_Call) RunAndReturn(run func(context.Context, *proto.ListPassedYouRequest) (*proto.ListPassedYouResponse, error)) *ExplorerCore_ListPassers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUser provides a mock function with given fields: ctx, req
+func (_m *ExplorerCore) UnblockUser(ctx context.Context, req *proto.UnblockUserRequest) (*proto.UnblockUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 *proto.UnblockUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.UnblockUserRequest) (*proto.UnblockUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.UnblockUserRequest) *proto.UnblockUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.UnblockUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.UnblockUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_UnblockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUser'
+type ExplorerCore_UnblockUser_Call struct {
+	*mock.Call
+}
+
+// UnblockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.UnblockUserRequest
+func (_e *ExplorerCore_Expecter) UnblockUser(ctx interface{}, req interface{}) *ExplorerCore_UnblockUser_Call {
+	return &ExplorerCore_UnblockUser_Call{Call: _e.mock.On("UnblockUser", ctx, req)}
+}
+
+func (_c *ExplorerCore_UnblockUser_Call) Run(run func(ctx context.Context, req *proto.UnblockUserRequest)) *ExplorerCore_UnblockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.UnblockUserRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_UnblockUser_Call) Return(_a0 *proto.UnblockUserResponse, _a1 error) *ExplorerCore_UnblockUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_UnblockUser_Call) RunAndReturn(run func(context.Context, *proto.UnblockUserRequest) (*proto.UnblockUserResponse, error)) *ExplorerCore_UnblockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WarmRecipients provides a mock function with given fields: ctx, recipientUserIDs
+func (_m *ExplorerCore) WarmRecipients(ctx context.Context, recipientUserIDs []string) (int, error) {
+	ret := _m.Called(ctx, recipientUserIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WarmRecipients")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (int, error)); ok {
+		return rf(ctx, recipientUserIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) int); ok {
+		r0 = rf(ctx, recipientUserIDs)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, recipientUserIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerCore_WarmRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WarmRecipients'
+type ExplorerCore_WarmRecipients_Call struct {
+	*mock.Call
+}
+
+// WarmRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientUserIDs []string
+func (_e *ExplorerCore_Expecter) WarmRecipients(ctx interface{}, recipientUserIDs interface{}) *ExplorerCore_WarmRecipients_Call {
+	return &ExplorerCore_WarmRecipients_Call{Call: _e.mock.On("WarmRecipients", ctx, recipientUserIDs)}
+}
+
+func (_c *ExplorerCore_WarmRecipients_Call) Run(run func(ctx context.Context, recipientUserIDs []string)) *ExplorerCore_WarmRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_WarmRecipients_Call) Return(_a0 int, _a1 error) *ExplorerCore_WarmRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerCore_WarmRecipients_Call) RunAndReturn(run func(context.Context, []string) (int, error)) *ExplorerCore_WarmRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchNewLikers provides a mock function with given fields: ctx, req, send
+func (_m *ExplorerCore) WatchNewLikers(ctx context.Context, req *proto.WatchNewLikesRequest, send func(*proto.WatchNewLikesEvent) error) error {
+	ret := _m.Called(ctx, req, send)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchNewLikers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.WatchNewLikesRequest, func(*proto.WatchNewLikesEvent) error) error); ok {
+		r0 = rf(ctx, req, send)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerCore_WatchNewLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchNewLikers'
+type ExplorerCore_WatchNewLikers_Call struct {
+	*mock.Call
+}
+
+// WatchNewLikers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *proto.WatchNewLikesRequest
+//   - send func(*proto.WatchNewLikesEvent) error
+func (_e *ExplorerCore_Expecter) WatchNewLikers(ctx interface{}, req interface{}, send interface{}) *ExplorerCore_WatchNewLikers_Call {
+	return &ExplorerCore_WatchNewLikers_Call{Call: _e.mock.On("WatchNewLikers", ctx, req, send)}
+}
+
+func (_c *ExplorerCore_WatchNewLikers_Call) Run(run func(ctx context.Context, req *proto.WatchNewLikesRequest, send func(*proto.WatchNewLikesEvent) error)) *ExplorerCore_WatchNewLikers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proto.WatchNewLikesRequest), args[2].(func(*proto.WatchNewLikesEvent) error))
+	})
+	return _c
+}
+
+func (_c *ExplorerCore_WatchNewLikers_Call) Return(_a0 error) *ExplorerCore_WatchNewLikers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerCore_WatchNewLikers_Call) RunAndReturn(run func(context.Context, *proto.WatchNewLikesRequest, func(*proto.WatchNewLikesEvent) error) error) *ExplorerCore_WatchNewLikers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,6 +7,8 @@ import (
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/backend-interview-task/internal/models"
 )
 
 // CacheProvider is an autogenerated mock type for the CacheProvider type
@@ -22,6 +24,122 @@ func (_m *CacheProvider) EXPECT() *CacheProvider_Expecter {
 	return &CacheProvider_Expecter{mock: &_m.Mock}
 }
 
+// AddLike provides a mock function with given fields: ctx, actor, recipient, likedAt
+func (_m *CacheProvider) AddLike(ctx context.Context, actor string, recipient string, likedAt int64) error {
+	ret := _m.Called(ctx, actor, recipient, likedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(ctx, actor, recipient, likedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_AddLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLike'
+type CacheProvider_AddLike_Call struct {
+	*mock.Call
+}
+
+// AddLike is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor string
+//   - recipient string
+//   - likedAt int64
+func (_e *CacheProvider_Expecter) AddLike(ctx interface{}, actor interface{}, recipient interface{}, likedAt interface{}) *CacheProvider_AddLike_Call {
+	return &CacheProvider_AddLike_Call{Call: _e.mock.On("AddLike", ctx, actor, recipient, likedAt)}
+}
+
+func (_c *CacheProvider_AddLike_Call) Run(run func(ctx context.Context, actor string, recipient string, likedAt int64)) *CacheProvider_AddLike_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_AddLike_Call) Return(_a0 error) *CacheProvider_AddLike_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_AddLike_Call) RunAndReturn(run func(context.Context, string, string, int64) error) *CacheProvider_AddLike_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Allow provides a mock function with given fields: ctx, key, limit, period, burst
+func (_m *CacheProvider) Allow(ctx context.Context, key string, limit int, period time.Duration, burst int) (bool, time.Duration, error) {
+	ret := _m.Called(ctx, key, limit, period, burst)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allow")
+	}
+
+	var r0 bool
+	var r1 time.Duration
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration, int) (bool, time.Duration, error)); ok {
+		return rf(ctx, key, limit, period, burst)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration, int) bool); ok {
+		r0 = rf(ctx, key, limit, period, burst)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Duration, int) time.Duration); ok {
+		r1 = rf(ctx, key, limit, period, burst)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, time.Duration, int) error); ok {
+		r2 = rf(ctx, key, limit, period, burst)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CacheProvider_Allow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allow'
+type CacheProvider_Allow_Call struct {
+	*mock.Call
+}
+
+// Allow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - limit int
+//   - period time.Duration
+//   - burst int
+func (_e *CacheProvider_Expecter) Allow(ctx interface{}, key interface{}, limit interface{}, period interface{}, burst interface{}) *CacheProvider_Allow_Call {
+	return &CacheProvider_Allow_Call{Call: _e.mock.On("Allow", ctx, key, limit, period, burst)}
+}
+
+func (_c *CacheProvider_Allow_Call) Run(run func(ctx context.Context, key string, limit int, period time.Duration, burst int)) *CacheProvider_Allow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(time.Duration), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_Allow_Call) Return(_a0 bool, _a1 time.Duration, _a2 error) *CacheProvider_Allow_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *CacheProvider_Allow_Call) RunAndReturn(run func(context.Context, string, int, time.Duration, int) (bool, time.Duration, error)) *CacheProvider_Allow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Del provides a mock function with given fields: ctx, keys
 func (_m *CacheProvider) Del(ctx context.Context, keys ...string) error {
 	_va := make([]interface{}, len(keys))
@@ -83,6 +201,67 @@ func (_c *CacheProvider_Del_Call) RunAndReturn(run func(context.Context, ...stri
 	return _c
 }
 
+// DelLikersIndex provides a mock function with given fields: ctx, users
+func (_m *CacheProvider) DelLikersIndex(ctx context.Context, users ...string) error {
+	_va := make([]interface{}, len(users))
+	for _i := range users {
+		_va[_i] = users[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DelLikersIndex")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, users...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_DelLikersIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DelLikersIndex'
+type CacheProvider_DelLikersIndex_Call struct {
+	*mock.Call
+}
+
+// DelLikersIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users ...string
+func (_e *CacheProvider_Expecter) DelLikersIndex(ctx interface{}, users ...interface{}) *CacheProvider_DelLikersIndex_Call {
+	return &CacheProvider_DelLikersIndex_Call{Call: _e.mock.On("DelLikersIndex",
+		append([]interface{}{ctx}, users...)...)}
+}
+
+func (_c *CacheProvider_DelLikersIndex_Call) Run(run func(ctx context.Context, users ...string)) *CacheProvider_DelLikersIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CacheProvider_DelLikersIndex_Call) Return(_a0 error) *CacheProvider_DelLikersIndex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_DelLikersIndex_Call) RunAndReturn(run func(context.Context, ...string) error) *CacheProvider_DelLikersIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, key
 func (_m *CacheProvider) Get(ctx context.Context, key string) (string, error) {
 	ret := _m.Called(ctx, key)
@@ -198,66 +377,217 @@ func (_c *CacheProvider_GetJSON_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
-// Set provides a mock function with given fields: ctx, key, value, expiration
-func (_m *CacheProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	ret := _m.Called(ctx, key, value, expiration)
+// GetLikersPage provides a mock function with given fields: ctx, recipient, page
+func (_m *CacheProvider) GetLikersPage(ctx context.Context, recipient string, page models.LikersRange) ([]models.Liker, bool, error) {
+	ret := _m.Called(ctx, recipient, page)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Set")
+		panic("no return value specified for GetLikersPage")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
-		r0 = rf(ctx, key, value, expiration)
+	var r0 []models.Liker
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LikersRange) ([]models.Liker, bool, error)); ok {
+		return rf(ctx, recipient, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LikersRange) []models.Liker); ok {
+		r0 = rf(ctx, recipient, page)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Liker)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.LikersRange) bool); ok {
+		r1 = rf(ctx, recipient, page)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.LikersRange) error); ok {
+		r2 = rf(ctx, recipient, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// CacheProvider_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
-type CacheProvider_Set_Call struct {
+// CacheProvider_GetLikersPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikersPage'
+type CacheProvider_GetLikersPage_Call struct {
 	*mock.Call
 }
 
-// Set is a helper method to define mock.On call
+// GetLikersPage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - value interface{}
-//   - expiration time.Duration
-func (_e *CacheProvider_Expecter) Set(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *CacheProvider_Set_Call {
-	return &CacheProvider_Set_Call{Call: _e.mock.On("Set", ctx, key, value, expiration)}
+//   - recipient string
+//   - page models.LikersRange
+func (_e *CacheProvider_Expecter) GetLikersPage(ctx interface{}, recipient interface{}, page interface{}) *CacheProvider_GetLikersPage_Call {
+	return &CacheProvider_GetLikersPage_Call{Call: _e.mock.On("GetLikersPage", ctx, recipient, page)}
 }
 
-func (_c *CacheProvider_Set_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *CacheProvider_Set_Call {
+func (_c *CacheProvider_GetLikersPage_Call) Run(run func(ctx context.Context, recipient string, page models.LikersRange)) *CacheProvider_GetLikersPage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+		run(args[0].(context.Context), args[1].(string), args[2].(models.LikersRange))
 	})
 	return _c
 }
 
-func (_c *CacheProvider_Set_Call) Return(_a0 error) *CacheProvider_Set_Call {
-	_c.Call.Return(_a0)
+func (_c *CacheProvider_GetLikersPage_Call) Return(_a0 []models.Liker, _a1 bool, _a2 error) *CacheProvider_GetLikersPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *CacheProvider_Set_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *CacheProvider_Set_Call {
+func (_c *CacheProvider_GetLikersPage_Call) RunAndReturn(run func(context.Context, string, models.LikersRange) ([]models.Liker, bool, error)) *CacheProvider_GetLikersPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetJSON provides a mock function with given fields: ctx, key, val, ttl
-func (_m *CacheProvider) SetJSON(ctx context.Context, key string, val interface{}, ttl time.Duration) error {
-	ret := _m.Called(ctx, key, val, ttl)
+// GetMany provides a mock function with given fields: ctx, keys
+func (_m *CacheProvider) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetJSON")
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) ([]string, error)); ok {
+		return rf(ctx, keys...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) []string); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, keys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CacheProvider_GetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMany'
+type CacheProvider_GetMany_Call struct {
+	*mock.Call
+}
+
+// GetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys ...string
+func (_e *CacheProvider_Expecter) GetMany(ctx interface{}, keys ...interface{}) *CacheProvider_GetMany_Call {
+	return &CacheProvider_GetMany_Call{Call: _e.mock.On("GetMany",
+		append([]interface{}{ctx}, keys...)...)}
+}
+
+func (_c *CacheProvider_GetMany_Call) Run(run func(ctx context.Context, keys ...string)) *CacheProvider_GetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CacheProvider_GetMany_Call) Return(_a0 []string, _a1 error) *CacheProvider_GetMany_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CacheProvider_GetMany_Call) RunAndReturn(run func(context.Context, ...string) ([]string, error)) *CacheProvider_GetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HotRecipients provides a mock function with given fields: ctx, window, limit
+func (_m *CacheProvider) HotRecipients(ctx context.Context, window time.Time, limit int) ([]string, error) {
+	ret := _m.Called(ctx, window, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HotRecipients")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]string, error)); ok {
+		return rf(ctx, window, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []string); ok {
+		r0 = rf(ctx, window, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, window, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CacheProvider_HotRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HotRecipients'
+type CacheProvider_HotRecipients_Call struct {
+	*mock.Call
+}
+
+// HotRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - window time.Time
+//   - limit int
+func (_e *CacheProvider_Expecter) HotRecipients(ctx interface{}, window interface{}, limit interface{}) *CacheProvider_HotRecipients_Call {
+	return &CacheProvider_HotRecipients_Call{Call: _e.mock.On("HotRecipients", ctx, window, limit)}
+}
+
+func (_c *CacheProvider_HotRecipients_Call) Run(run func(ctx context.Context, window time.Time, limit int)) *CacheProvider_HotRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_HotRecipients_Call) Return(_a0 []string, _a1 error) *CacheProvider_HotRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CacheProvider_HotRecipients_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]string, error)) *CacheProvider_HotRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrLikersCount provides a mock function with given fields: ctx, recipient, delta
+func (_m *CacheProvider) IncrLikersCount(ctx context.Context, recipient string, delta int64) error {
+	ret := _m.Called(ctx, recipient, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrLikersCount")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
-		r0 = rf(ctx, key, val, ttl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, recipient, delta)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -265,33 +595,531 @@ func (_m *CacheProvider) SetJSON(ctx context.Context, key string, val interface{
 	return r0
 }
 
-// CacheProvider_SetJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetJSON'
-type CacheProvider_SetJSON_Call struct {
+// CacheProvider_IncrLikersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrLikersCount'
+type CacheProvider_IncrLikersCount_Call struct {
 	*mock.Call
 }
 
-// SetJSON is a helper method to define mock.On call
+// IncrLikersCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - val interface{}
-//   - ttl time.Duration
-func (_e *CacheProvider_Expecter) SetJSON(ctx interface{}, key interface{}, val interface{}, ttl interface{}) *CacheProvider_SetJSON_Call {
-	return &CacheProvider_SetJSON_Call{Call: _e.mock.On("SetJSON", ctx, key, val, ttl)}
+//   - recipient string
+//   - delta int64
+func (_e *CacheProvider_Expecter) IncrLikersCount(ctx interface{}, recipient interface{}, delta interface{}) *CacheProvider_IncrLikersCount_Call {
+	return &CacheProvider_IncrLikersCount_Call{Call: _e.mock.On("IncrLikersCount", ctx, recipient, delta)}
 }
 
-func (_c *CacheProvider_SetJSON_Call) Run(run func(ctx context.Context, key string, val interface{}, ttl time.Duration)) *CacheProvider_SetJSON_Call {
+func (_c *CacheProvider_IncrLikersCount_Call) Run(run func(ctx context.Context, recipient string, delta int64)) *CacheProvider_IncrLikersCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
 	})
 	return _c
 }
 
-func (_c *CacheProvider_SetJSON_Call) Return(_a0 error) *CacheProvider_SetJSON_Call {
+func (_c *CacheProvider_IncrLikersCount_Call) Return(_a0 error) *CacheProvider_IncrLikersCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *CacheProvider_SetJSON_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *CacheProvider_SetJSON_Call {
+func (_c *CacheProvider_IncrLikersCount_Call) RunAndReturn(run func(context.Context, string, int64) error) *CacheProvider_IncrLikersCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, key, ttl
+func (_m *CacheProvider) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) bool); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CacheProvider_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type CacheProvider_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *CacheProvider_Expecter) Lock(ctx interface{}, key interface{}, ttl interface{}) *CacheProvider_Lock_Call {
+	return &CacheProvider_Lock_Call{Call: _e.mock.On("Lock", ctx, key, ttl)}
+}
+
+func (_c *CacheProvider_Lock_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *CacheProvider_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_Lock_Call) Return(_a0 string, _a1 bool, _a2 error) *CacheProvider_Lock_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *CacheProvider_Lock_Call) RunAndReturn(run func(context.Context, string, time.Duration) (string, bool, error)) *CacheProvider_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *CacheProvider) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type CacheProvider_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CacheProvider_Expecter) Ping(ctx interface{}) *CacheProvider_Ping_Call {
+	return &CacheProvider_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *CacheProvider_Ping_Call) Run(run func(ctx context.Context)) *CacheProvider_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_Ping_Call) Return(_a0 error) *CacheProvider_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_Ping_Call) RunAndReturn(run func(context.Context) error) *CacheProvider_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveLike provides a mock function with given fields: ctx, actor, recipient
+func (_m *CacheProvider) RemoveLike(ctx context.Context, actor string, recipient string) error {
+	ret := _m.Called(ctx, actor, recipient)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, actor, recipient)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_RemoveLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLike'
+type CacheProvider_RemoveLike_Call struct {
+	*mock.Call
+}
+
+// RemoveLike is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor string
+//   - recipient string
+func (_e *CacheProvider_Expecter) RemoveLike(ctx interface{}, actor interface{}, recipient interface{}) *CacheProvider_RemoveLike_Call {
+	return &CacheProvider_RemoveLike_Call{Call: _e.mock.On("RemoveLike", ctx, actor, recipient)}
+}
+
+func (_c *CacheProvider_RemoveLike_Call) Run(run func(ctx context.Context, actor string, recipient string)) *CacheProvider_RemoveLike_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_RemoveLike_Call) Return(_a0 error) *CacheProvider_RemoveLike_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_RemoveLike_Call) RunAndReturn(run func(context.Context, string, string) error) *CacheProvider_RemoveLike_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SeedLikersCount provides a mock function with given fields: ctx, recipient, count, ttl
+func (_m *CacheProvider) SeedLikersCount(ctx context.Context, recipient string, count int64, ttl time.Duration) error {
+	ret := _m.Called(ctx, recipient, count, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SeedLikersCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) error); ok {
+		r0 = rf(ctx, recipient, count, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_SeedLikersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SeedLikersCount'
+type CacheProvider_SeedLikersCount_Call struct {
+	*mock.Call
+}
+
+// SeedLikersCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipient string
+//   - count int64
+//   - ttl time.Duration
+func (_e *CacheProvider_Expecter) SeedLikersCount(ctx interface{}, recipient interface{}, count interface{}, ttl interface{}) *CacheProvider_SeedLikersCount_Call {
+	return &CacheProvider_SeedLikersCount_Call{Call: _e.mock.On("SeedLikersCount", ctx, recipient, count, ttl)}
+}
+
+func (_c *CacheProvider_SeedLikersCount_Call) Run(run func(ctx context.Context, recipient string, count int64, ttl time.Duration)) *CacheProvider_SeedLikersCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_SeedLikersCount_Call) Return(_a0 error) *CacheProvider_SeedLikersCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_SeedLikersCount_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) error) *CacheProvider_SeedLikersCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: ctx, key, value, expiration
+func (_m *CacheProvider) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type CacheProvider_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *CacheProvider_Expecter) Set(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *CacheProvider_Set_Call {
+	return &CacheProvider_Set_Call{Call: _e.mock.On("Set", ctx, key, value, expiration)}
+}
+
+func (_c *CacheProvider_Set_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *CacheProvider_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_Set_Call) Return(_a0 error) *CacheProvider_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_Set_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *CacheProvider_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetJSON provides a mock function with given fields: ctx, key, val, ttl
+func (_m *CacheProvider) SetJSON(ctx context.Context, key string, val interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, val, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJSON")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, val, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_SetJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetJSON'
+type CacheProvider_SetJSON_Call struct {
+	*mock.Call
+}
+
+// SetJSON is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - val interface{}
+//   - ttl time.Duration
+func (_e *CacheProvider_Expecter) SetJSON(ctx interface{}, key interface{}, val interface{}, ttl interface{}) *CacheProvider_SetJSON_Call {
+	return &CacheProvider_SetJSON_Call{Call: _e.mock.On("SetJSON", ctx, key, val, ttl)}
+}
+
+func (_c *CacheProvider_SetJSON_Call) Run(run func(ctx context.Context, key string, val interface{}, ttl time.Duration)) *CacheProvider_SetJSON_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_SetJSON_Call) Return(_a0 error) *CacheProvider_SetJSON_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_SetJSON_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *CacheProvider_SetJSON_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLikersIndex provides a mock function with given fields: ctx, recipient, likers, ttl
+func (_m *CacheProvider) SetLikersIndex(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration) error {
+	ret := _m.Called(ctx, recipient, likers, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLikersIndex")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.Liker, time.Duration) error); ok {
+		r0 = rf(ctx, recipient, likers, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_SetLikersIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLikersIndex'
+type CacheProvider_SetLikersIndex_Call struct {
+	*mock.Call
+}
+
+// SetLikersIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipient string
+//   - likers []models.Liker
+//   - ttl time.Duration
+func (_e *CacheProvider_Expecter) SetLikersIndex(ctx interface{}, recipient interface{}, likers interface{}, ttl interface{}) *CacheProvider_SetLikersIndex_Call {
+	return &CacheProvider_SetLikersIndex_Call{Call: _e.mock.On("SetLikersIndex", ctx, recipient, likers, ttl)}
+}
+
+func (_c *CacheProvider_SetLikersIndex_Call) Run(run func(ctx context.Context, recipient string, likers []models.Liker, ttl time.Duration)) *CacheProvider_SetLikersIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]models.Liker), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_SetLikersIndex_Call) Return(_a0 error) *CacheProvider_SetLikersIndex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_SetLikersIndex_Call) RunAndReturn(run func(context.Context, string, []models.Liker, time.Duration) error) *CacheProvider_SetLikersIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMany provides a mock function with given fields: ctx, values, expiration
+func (_m *CacheProvider) SetMany(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	ret := _m.Called(ctx, values, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, values, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_SetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMany'
+type CacheProvider_SetMany_Call struct {
+	*mock.Call
+}
+
+// SetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]interface{}
+//   - expiration time.Duration
+func (_e *CacheProvider_Expecter) SetMany(ctx interface{}, values interface{}, expiration interface{}) *CacheProvider_SetMany_Call {
+	return &CacheProvider_SetMany_Call{Call: _e.mock.On("SetMany", ctx, values, expiration)}
+}
+
+func (_c *CacheProvider_SetMany_Call) Run(run func(ctx context.Context, values map[string]interface{}, expiration time.Duration)) *CacheProvider_SetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_SetMany_Call) Return(_a0 error) *CacheProvider_SetMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_SetMany_Call) RunAndReturn(run func(context.Context, map[string]interface{}, time.Duration) error) *CacheProvider_SetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrackHotRecipient provides a mock function with given fields: ctx, recipient, at
+func (_m *CacheProvider) TrackHotRecipient(ctx context.Context, recipient string, at time.Time) error {
+	ret := _m.Called(ctx, recipient, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrackHotRecipient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, recipient, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_TrackHotRecipient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrackHotRecipient'
+type CacheProvider_TrackHotRecipient_Call struct {
+	*mock.Call
+}
+
+// TrackHotRecipient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipient string
+//   - at time.Time
+func (_e *CacheProvider_Expecter) TrackHotRecipient(ctx interface{}, recipient interface{}, at interface{}) *CacheProvider_TrackHotRecipient_Call {
+	return &CacheProvider_TrackHotRecipient_Call{Call: _e.mock.On("TrackHotRecipient", ctx, recipient, at)}
+}
+
+func (_c *CacheProvider_TrackHotRecipient_Call) Run(run func(ctx context.Context, recipient string, at time.Time)) *CacheProvider_TrackHotRecipient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_TrackHotRecipient_Call) Return(_a0 error) *CacheProvider_TrackHotRecipient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_TrackHotRecipient_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *CacheProvider_TrackHotRecipient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function with given fields: ctx, key, token
+func (_m *CacheProvider) Unlock(ctx context.Context, key string, token string) error {
+	ret := _m.Called(ctx, key, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheProvider_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type CacheProvider_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - token string
+func (_e *CacheProvider_Expecter) Unlock(ctx interface{}, key interface{}, token interface{}) *CacheProvider_Unlock_Call {
+	return &CacheProvider_Unlock_Call{Call: _e.mock.On("Unlock", ctx, key, token)}
+}
+
+func (_c *CacheProvider_Unlock_Call) Run(run func(ctx context.Context, key string, token string)) *CacheProvider_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *CacheProvider_Unlock_Call) Return(_a0 error) *CacheProvider_Unlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheProvider_Unlock_Call) RunAndReturn(run func(context.Context, string, string) error) *CacheProvider_Unlock_Call {
 	_c.Call.Return(run)
 	return _c
 }
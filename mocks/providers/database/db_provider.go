@@ -25,6 +25,64 @@ func (_m *DBProvider) EXPECT() *DBProvider_Expecter {
 	return &DBProvider_Expecter{mock: &_m.Mock}
 }
 
+// Begin provides a mock function with given fields: ctx
+func (_m *DBProvider) Begin(ctx context.Context) (pgx.Tx, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 pgx.Tx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (pgx.Tx, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) pgx.Tx); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pgx.Tx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DBProvider_Begin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Begin'
+type DBProvider_Begin_Call struct {
+	*mock.Call
+}
+
+// Begin is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DBProvider_Expecter) Begin(ctx interface{}) *DBProvider_Begin_Call {
+	return &DBProvider_Begin_Call{Call: _e.mock.On("Begin", ctx)}
+}
+
+func (_c *DBProvider_Begin_Call) Run(run func(ctx context.Context)) *DBProvider_Begin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DBProvider_Begin_Call) Return(_a0 pgx.Tx, _a1 error) *DBProvider_Begin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DBProvider_Begin_Call) RunAndReturn(run func(context.Context) (pgx.Tx, error)) *DBProvider_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *DBProvider) Close() {
 	_m.Called()
@@ -125,6 +183,52 @@ func (_c *DBProvider_Exec_Call) RunAndReturn(run func(context.Context, string, .
 	return _c
 }
 
+// Ping provides a mock function with given fields: ctx
+func (_m *DBProvider) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DBProvider_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type DBProvider_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DBProvider_Expecter) Ping(ctx interface{}) *DBProvider_Ping_Call {
+	return &DBProvider_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *DBProvider_Ping_Call) Run(run func(ctx context.Context)) *DBProvider_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DBProvider_Ping_Call) Return(_a0 error) *DBProvider_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DBProvider_Ping_Call) RunAndReturn(run func(context.Context) error) *DBProvider_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Query provides a mock function with given fields: ctx, sql, args
 func (_m *DBProvider) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
 	var _ca []interface{}
@@ -255,6 +359,55 @@ func (_c *DBProvider_QueryRow_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
+// SendBatch provides a mock function with given fields: ctx, b
+func (_m *DBProvider) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendBatch")
+	}
+
+	var r0 pgx.BatchResults
+	if rf, ok := ret.Get(0).(func(context.Context, *pgx.Batch) pgx.BatchResults); ok {
+		r0 = rf(ctx, b)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pgx.BatchResults)
+		}
+	}
+
+	return r0
+}
+
+// DBProvider_SendBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendBatch'
+type DBProvider_SendBatch_Call struct {
+	*mock.Call
+}
+
+// SendBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *pgx.Batch
+func (_e *DBProvider_Expecter) SendBatch(ctx interface{}, b interface{}) *DBProvider_SendBatch_Call {
+	return &DBProvider_SendBatch_Call{Call: _e.mock.On("SendBatch", ctx, b)}
+}
+
+func (_c *DBProvider_SendBatch_Call) Run(run func(ctx context.Context, b *pgx.Batch)) *DBProvider_SendBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pgx.Batch))
+	})
+	return _c
+}
+
+func (_c *DBProvider_SendBatch_Call) Return(_a0 pgx.BatchResults) *DBProvider_SendBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DBProvider_SendBatch_Call) RunAndReturn(run func(context.Context, *pgx.Batch) pgx.BatchResults) *DBProvider_SendBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDBProvider creates a new instance of DBProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDBProvider(t interface {
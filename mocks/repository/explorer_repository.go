@@ -5,9 +5,10 @@ package mocks
 import (
 	context "context"
 
-	explorerdb "github.com/backend-interview-task/db/gen/explorer"
 	mock "github.com/stretchr/testify/mock"
 
+	explorerdb "github.com/backend-interview-task/db/gen/explorer"
+
 	models "github.com/backend-interview-task/internal/models"
 )
 
@@ -24,27 +25,73 @@ func (_m *ExplorerRepository) EXPECT() *ExplorerRepository_Expecter {
 	return &ExplorerRepository_Expecter{mock: &_m.Mock}
 }
 
-// CountLikes provides a mock function with given fields: ctx, recipientUserID
-func (_m *ExplorerRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
-	ret := _m.Called(ctx, recipientUserID)
+// AllowAuditEventErasure provides a mock function with given fields: ctx
+func (_m *ExplorerRepository) AllowAuditEventErasure(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountLikes")
+		panic("no return value specified for AllowAuditEventErasure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_AllowAuditEventErasure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllowAuditEventErasure'
+type ExplorerRepository_AllowAuditEventErasure_Call struct {
+	*mock.Call
+}
+
+// AllowAuditEventErasure is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ExplorerRepository_Expecter) AllowAuditEventErasure(ctx interface{}) *ExplorerRepository_AllowAuditEventErasure_Call {
+	return &ExplorerRepository_AllowAuditEventErasure_Call{Call: _e.mock.On("AllowAuditEventErasure", ctx)}
+}
+
+func (_c *ExplorerRepository_AllowAuditEventErasure_Call) Run(run func(ctx context.Context)) *ExplorerRepository_AllowAuditEventErasure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_AllowAuditEventErasure_Call) Return(_a0 error) *ExplorerRepository_AllowAuditEventErasure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_AllowAuditEventErasure_Call) RunAndReturn(run func(context.Context) error) *ExplorerRepository_AllowAuditEventErasure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AnonymizeUserAuditEvents provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) AnonymizeUserAuditEvents(ctx context.Context, arg explorerdb.AnonymizeUserAuditEventsParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymizeUserAuditEvents")
 	}
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
-		return rf(ctx, recipientUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.AnonymizeUserAuditEventsParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
-		r0 = rf(ctx, recipientUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.AnonymizeUserAuditEventsParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, recipientUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.AnonymizeUserAuditEventsParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,238 +99,285 @@ func (_m *ExplorerRepository) CountLikes(ctx context.Context, recipientUserID st
 	return r0, r1
 }
 
-// ExplorerRepository_CountLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountLikes'
-type ExplorerRepository_CountLikes_Call struct {
+// ExplorerRepository_AnonymizeUserAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeUserAuditEvents'
+type ExplorerRepository_AnonymizeUserAuditEvents_Call struct {
 	*mock.Call
 }
 
-// CountLikes is a helper method to define mock.On call
+// AnonymizeUserAuditEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - recipientUserID string
-func (_e *ExplorerRepository_Expecter) CountLikes(ctx interface{}, recipientUserID interface{}) *ExplorerRepository_CountLikes_Call {
-	return &ExplorerRepository_CountLikes_Call{Call: _e.mock.On("CountLikes", ctx, recipientUserID)}
+//   - arg explorerdb.AnonymizeUserAuditEventsParams
+func (_e *ExplorerRepository_Expecter) AnonymizeUserAuditEvents(ctx interface{}, arg interface{}) *ExplorerRepository_AnonymizeUserAuditEvents_Call {
+	return &ExplorerRepository_AnonymizeUserAuditEvents_Call{Call: _e.mock.On("AnonymizeUserAuditEvents", ctx, arg)}
 }
 
-func (_c *ExplorerRepository_CountLikes_Call) Run(run func(ctx context.Context, recipientUserID string)) *ExplorerRepository_CountLikes_Call {
+func (_c *ExplorerRepository_AnonymizeUserAuditEvents_Call) Run(run func(ctx context.Context, arg explorerdb.AnonymizeUserAuditEventsParams)) *ExplorerRepository_AnonymizeUserAuditEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(explorerdb.AnonymizeUserAuditEventsParams))
 	})
 	return _c
 }
 
-func (_c *ExplorerRepository_CountLikes_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_CountLikes_Call {
+func (_c *ExplorerRepository_AnonymizeUserAuditEvents_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_AnonymizeUserAuditEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerRepository_CountLikes_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ExplorerRepository_CountLikes_Call {
+func (_c *ExplorerRepository_AnonymizeUserAuditEvents_Call) RunAndReturn(run func(context.Context, explorerdb.AnonymizeUserAuditEventsParams) (int64, error)) *ExplorerRepository_AnonymizeUserAuditEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateDecision provides a mock function with given fields: ctx, arg
-func (_m *ExplorerRepository) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) error {
+// AnonymizeUserReports provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) AnonymizeUserReports(ctx context.Context, arg explorerdb.AnonymizeUserReportsParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateDecision")
+		panic("no return value specified for AnonymizeUserReports")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateDecisionParams) error); ok {
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.AnonymizeUserReportsParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.AnonymizeUserReportsParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.AnonymizeUserReportsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// ExplorerRepository_CreateDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDecision'
-type ExplorerRepository_CreateDecision_Call struct {
+// ExplorerRepository_AnonymizeUserReports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeUserReports'
+type ExplorerRepository_AnonymizeUserReports_Call struct {
 	*mock.Call
 }
 
-// CreateDecision is a helper method to define mock.On call
+// AnonymizeUserReports is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg explorerdb.CreateDecisionParams
-func (_e *ExplorerRepository_Expecter) CreateDecision(ctx interface{}, arg interface{}) *ExplorerRepository_CreateDecision_Call {
-	return &ExplorerRepository_CreateDecision_Call{Call: _e.mock.On("CreateDecision", ctx, arg)}
+//   - arg explorerdb.AnonymizeUserReportsParams
+func (_e *ExplorerRepository_Expecter) AnonymizeUserReports(ctx interface{}, arg interface{}) *ExplorerRepository_AnonymizeUserReports_Call {
+	return &ExplorerRepository_AnonymizeUserReports_Call{Call: _e.mock.On("AnonymizeUserReports", ctx, arg)}
 }
 
-func (_c *ExplorerRepository_CreateDecision_Call) Run(run func(ctx context.Context, arg explorerdb.CreateDecisionParams)) *ExplorerRepository_CreateDecision_Call {
+func (_c *ExplorerRepository_AnonymizeUserReports_Call) Run(run func(ctx context.Context, arg explorerdb.AnonymizeUserReportsParams)) *ExplorerRepository_AnonymizeUserReports_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(explorerdb.CreateDecisionParams))
+		run(args[0].(context.Context), args[1].(explorerdb.AnonymizeUserReportsParams))
 	})
 	return _c
 }
 
-func (_c *ExplorerRepository_CreateDecision_Call) Return(_a0 error) *ExplorerRepository_CreateDecision_Call {
-	_c.Call.Return(_a0)
+func (_c *ExplorerRepository_AnonymizeUserReports_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_AnonymizeUserReports_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerRepository_CreateDecision_Call) RunAndReturn(run func(context.Context, explorerdb.CreateDecisionParams) error) *ExplorerRepository_CreateDecision_Call {
+func (_c *ExplorerRepository_AnonymizeUserReports_Call) RunAndReturn(run func(context.Context, explorerdb.AnonymizeUserReportsParams) (int64, error)) *ExplorerRepository_AnonymizeUserReports_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLikers provides a mock function with given fields: ctx, recipientUserID, cursor
-func (_m *ExplorerRepository) GetLikers(ctx context.Context, recipientUserID string, cursor string) ([]models.Liker, string, error) {
-	ret := _m.Called(ctx, recipientUserID, cursor)
+// ClaimOutboxEvents provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ClaimOutboxEvents(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLikers")
+		panic("no return value specified for ClaimOutboxEvents")
 	}
 
-	var r0 []models.Liker
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.Liker, string, error)); ok {
-		return rf(ctx, recipientUserID, cursor)
+	var r0 []explorerdb.Outbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.Liker); ok {
-		r0 = rf(ctx, recipientUserID, cursor)
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ClaimOutboxEventsParams) []explorerdb.Outbox); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Liker)
+			r0 = ret.Get(0).([]explorerdb.Outbox)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, recipientUserID, cursor)
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ClaimOutboxEventsParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ClaimOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimOutboxEvents'
+type ExplorerRepository_ClaimOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// ClaimOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ClaimOutboxEventsParams
+func (_e *ExplorerRepository_Expecter) ClaimOutboxEvents(ctx interface{}, arg interface{}) *ExplorerRepository_ClaimOutboxEvents_Call {
+	return &ExplorerRepository_ClaimOutboxEvents_Call{Call: _e.mock.On("ClaimOutboxEvents", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ClaimOutboxEvents_Call) Run(run func(ctx context.Context, arg explorerdb.ClaimOutboxEventsParams)) *ExplorerRepository_ClaimOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ClaimOutboxEventsParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ClaimOutboxEvents_Call) Return(_a0 []explorerdb.Outbox, _a1 error) *ExplorerRepository_ClaimOutboxEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ClaimOutboxEvents_Call) RunAndReturn(run func(context.Context, explorerdb.ClaimOutboxEventsParams) ([]explorerdb.Outbox, error)) *ExplorerRepository_ClaimOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountLikers provides a mock function with given fields: ctx, recipientUserID
+func (_m *ExplorerRepository) CountLikers(ctx context.Context, recipientUserID string) (int64, error) {
+	ret := _m.Called(ctx, recipientUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountLikers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, recipientUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, recipientUserID)
+	} else {
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, recipientUserID, cursor)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, recipientUserID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// ExplorerRepository_GetLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikers'
-type ExplorerRepository_GetLikers_Call struct {
+// ExplorerRepository_CountLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountLikers'
+type ExplorerRepository_CountLikers_Call struct {
 	*mock.Call
 }
 
-// GetLikers is a helper method to define mock.On call
+// CountLikers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - recipientUserID string
-//   - cursor string
-func (_e *ExplorerRepository_Expecter) GetLikers(ctx interface{}, recipientUserID interface{}, cursor interface{}) *ExplorerRepository_GetLikers_Call {
-	return &ExplorerRepository_GetLikers_Call{Call: _e.mock.On("GetLikers", ctx, recipientUserID, cursor)}
+func (_e *ExplorerRepository_Expecter) CountLikers(ctx interface{}, recipientUserID interface{}) *ExplorerRepository_CountLikers_Call {
+	return &ExplorerRepository_CountLikers_Call{Call: _e.mock.On("CountLikers", ctx, recipientUserID)}
 }
 
-func (_c *ExplorerRepository_GetLikers_Call) Run(run func(ctx context.Context, recipientUserID string, cursor string)) *ExplorerRepository_GetLikers_Call {
+func (_c *ExplorerRepository_CountLikers_Call) Run(run func(ctx context.Context, recipientUserID string)) *ExplorerRepository_CountLikers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *ExplorerRepository_GetLikers_Call) Return(_a0 []models.Liker, _a1 string, _a2 error) *ExplorerRepository_GetLikers_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *ExplorerRepository_CountLikers_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_CountLikers_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerRepository_GetLikers_Call) RunAndReturn(run func(context.Context, string, string) ([]models.Liker, string, error)) *ExplorerRepository_GetLikers_Call {
+func (_c *ExplorerRepository_CountLikers_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ExplorerRepository_CountLikers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNewLikers provides a mock function with given fields: ctx, recipientUserID, cursor
-func (_m *ExplorerRepository) GetNewLikers(ctx context.Context, recipientUserID string, cursor string) ([]models.Liker, string, error) {
-	ret := _m.Called(ctx, recipientUserID, cursor)
+// CountLikes provides a mock function with given fields: ctx, recipientUserID
+func (_m *ExplorerRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	ret := _m.Called(ctx, recipientUserID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNewLikers")
+		panic("no return value specified for CountLikes")
 	}
 
-	var r0 []models.Liker
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.Liker, string, error)); ok {
-		return rf(ctx, recipientUserID, cursor)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.Liker); ok {
-		r0 = rf(ctx, recipientUserID, cursor)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Liker)
-		}
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, recipientUserID)
 	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, recipientUserID, cursor)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, recipientUserID)
 	} else {
-		r1 = ret.Get(1).(string)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, recipientUserID, cursor)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, recipientUserID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// ExplorerRepository_GetNewLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewLikers'
-type ExplorerRepository_GetNewLikers_Call struct {
+// ExplorerRepository_CountLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountLikes'
+type ExplorerRepository_CountLikes_Call struct {
 	*mock.Call
 }
 
-// GetNewLikers is a helper method to define mock.On call
+// CountLikes is a helper method to define mock.On call
 //   - ctx context.Context
 //   - recipientUserID string
-//   - cursor string
-func (_e *ExplorerRepository_Expecter) GetNewLikers(ctx interface{}, recipientUserID interface{}, cursor interface{}) *ExplorerRepository_GetNewLikers_Call {
-	return &ExplorerRepository_GetNewLikers_Call{Call: _e.mock.On("GetNewLikers", ctx, recipientUserID, cursor)}
+func (_e *ExplorerRepository_Expecter) CountLikes(ctx interface{}, recipientUserID interface{}) *ExplorerRepository_CountLikes_Call {
+	return &ExplorerRepository_CountLikes_Call{Call: _e.mock.On("CountLikes", ctx, recipientUserID)}
 }
 
-func (_c *ExplorerRepository_GetNewLikers_Call) Run(run func(ctx context.Context, recipientUserID string, cursor string)) *ExplorerRepository_GetNewLikers_Call {
+func (_c *ExplorerRepository_CountLikes_Call) Run(run func(ctx context.Context, recipientUserID string)) *ExplorerRepository_CountLikes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *ExplorerRepository_GetNewLikers_Call) Return(_a0 []models.Liker, _a1 string, _a2 error) *ExplorerRepository_GetNewLikers_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *ExplorerRepository_CountLikes_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_CountLikes_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerRepository_GetNewLikers_Call) RunAndReturn(run func(context.Context, string, string) ([]models.Liker, string, error)) *ExplorerRepository_GetNewLikers_Call {
+func (_c *ExplorerRepository_CountLikes_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ExplorerRepository_CountLikes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HasMutualLike provides a mock function with given fields: ctx, arg
-func (_m *ExplorerRepository) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+// CountRecentLikes provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CountRecentLikes(ctx context.Context, arg explorerdb.CountRecentLikesParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasMutualLike")
+		panic("no return value specified for CountRecentLikes")
 	}
 
-	var r0 *bool
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.HasMutualLikeParams) (*bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CountRecentLikesParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.HasMutualLikeParams) *bool); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CountRecentLikesParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bool)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.HasMutualLikeParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.CountRecentLikesParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -292,31 +386,2502 @@ func (_m *ExplorerRepository) HasMutualLike(ctx context.Context, arg explorerdb.
 	return r0, r1
 }
 
-// ExplorerRepository_HasMutualLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasMutualLike'
-type ExplorerRepository_HasMutualLike_Call struct {
+// ExplorerRepository_CountRecentLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRecentLikes'
+type ExplorerRepository_CountRecentLikes_Call struct {
 	*mock.Call
 }
 
-// HasMutualLike is a helper method to define mock.On call
+// CountRecentLikes is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg explorerdb.HasMutualLikeParams
-func (_e *ExplorerRepository_Expecter) HasMutualLike(ctx interface{}, arg interface{}) *ExplorerRepository_HasMutualLike_Call {
-	return &ExplorerRepository_HasMutualLike_Call{Call: _e.mock.On("HasMutualLike", ctx, arg)}
+//   - arg explorerdb.CountRecentLikesParams
+func (_e *ExplorerRepository_Expecter) CountRecentLikes(ctx interface{}, arg interface{}) *ExplorerRepository_CountRecentLikes_Call {
+	return &ExplorerRepository_CountRecentLikes_Call{Call: _e.mock.On("CountRecentLikes", ctx, arg)}
 }
 
-func (_c *ExplorerRepository_HasMutualLike_Call) Run(run func(ctx context.Context, arg explorerdb.HasMutualLikeParams)) *ExplorerRepository_HasMutualLike_Call {
+func (_c *ExplorerRepository_CountRecentLikes_Call) Run(run func(ctx context.Context, arg explorerdb.CountRecentLikesParams)) *ExplorerRepository_CountRecentLikes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(explorerdb.HasMutualLikeParams))
+		run(args[0].(context.Context), args[1].(explorerdb.CountRecentLikesParams))
 	})
 	return _c
 }
 
-func (_c *ExplorerRepository_HasMutualLike_Call) Return(_a0 *bool, _a1 error) *ExplorerRepository_HasMutualLike_Call {
+func (_c *ExplorerRepository_CountRecentLikes_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_CountRecentLikes_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ExplorerRepository_HasMutualLike_Call) RunAndReturn(run func(context.Context, explorerdb.HasMutualLikeParams) (*bool, error)) *ExplorerRepository_HasMutualLike_Call {
+func (_c *ExplorerRepository_CountRecentLikes_Call) RunAndReturn(run func(context.Context, explorerdb.CountRecentLikesParams) (int64, error)) *ExplorerRepository_CountRecentLikes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAuditEvent provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateAuditEvent(ctx context.Context, arg explorerdb.CreateAuditEventParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuditEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateAuditEventParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_CreateAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditEvent'
+type ExplorerRepository_CreateAuditEvent_Call struct {
+	*mock.Call
+}
+
+// CreateAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateAuditEventParams
+func (_e *ExplorerRepository_Expecter) CreateAuditEvent(ctx interface{}, arg interface{}) *ExplorerRepository_CreateAuditEvent_Call {
+	return &ExplorerRepository_CreateAuditEvent_Call{Call: _e.mock.On("CreateAuditEvent", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateAuditEvent_Call) Run(run func(ctx context.Context, arg explorerdb.CreateAuditEventParams)) *ExplorerRepository_CreateAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateAuditEventParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateAuditEvent_Call) Return(_a0 error) *ExplorerRepository_CreateAuditEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateAuditEvent_Call) RunAndReturn(run func(context.Context, explorerdb.CreateAuditEventParams) error) *ExplorerRepository_CreateAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBlock provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateBlock(ctx context.Context, arg explorerdb.CreateBlockParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateBlockParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_CreateBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBlock'
+type ExplorerRepository_CreateBlock_Call struct {
+	*mock.Call
+}
+
+// CreateBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateBlockParams
+func (_e *ExplorerRepository_Expecter) CreateBlock(ctx interface{}, arg interface{}) *ExplorerRepository_CreateBlock_Call {
+	return &ExplorerRepository_CreateBlock_Call{Call: _e.mock.On("CreateBlock", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateBlock_Call) Run(run func(ctx context.Context, arg explorerdb.CreateBlockParams)) *ExplorerRepository_CreateBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateBlockParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateBlock_Call) Return(_a0 error) *ExplorerRepository_CreateBlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateBlock_Call) RunAndReturn(run func(context.Context, explorerdb.CreateBlockParams) error) *ExplorerRepository_CreateBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDecision provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateDecision(ctx context.Context, arg explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDecision")
+	}
+
+	var r0 explorerdb.CreateDecisionRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateDecisionParams) explorerdb.CreateDecisionRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(explorerdb.CreateDecisionRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.CreateDecisionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_CreateDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDecision'
+type ExplorerRepository_CreateDecision_Call struct {
+	*mock.Call
+}
+
+// CreateDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateDecisionParams
+func (_e *ExplorerRepository_Expecter) CreateDecision(ctx interface{}, arg interface{}) *ExplorerRepository_CreateDecision_Call {
+	return &ExplorerRepository_CreateDecision_Call{Call: _e.mock.On("CreateDecision", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateDecision_Call) Run(run func(ctx context.Context, arg explorerdb.CreateDecisionParams)) *ExplorerRepository_CreateDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateDecisionParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateDecision_Call) Return(_a0 explorerdb.CreateDecisionRow, _a1 error) *ExplorerRepository_CreateDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateDecision_Call) RunAndReturn(run func(context.Context, explorerdb.CreateDecisionParams) (explorerdb.CreateDecisionRow, error)) *ExplorerRepository_CreateDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDecisions provides a mock function with given fields: ctx, decisions, events
+func (_m *ExplorerRepository) CreateDecisions(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents) ([]models.DecisionResult, error) {
+	ret := _m.Called(ctx, decisions, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDecisions")
+	}
+
+	var r0 []models.DecisionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []explorerdb.CreateDecisionParams, []models.DecisionEvents) ([]models.DecisionResult, error)); ok {
+		return rf(ctx, decisions, events)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []explorerdb.CreateDecisionParams, []models.DecisionEvents) []models.DecisionResult); ok {
+		r0 = rf(ctx, decisions, events)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DecisionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []explorerdb.CreateDecisionParams, []models.DecisionEvents) error); ok {
+		r1 = rf(ctx, decisions, events)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_CreateDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDecisions'
+type ExplorerRepository_CreateDecisions_Call struct {
+	*mock.Call
+}
+
+// CreateDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decisions []explorerdb.CreateDecisionParams
+//   - events []models.DecisionEvents
+func (_e *ExplorerRepository_Expecter) CreateDecisions(ctx interface{}, decisions interface{}, events interface{}) *ExplorerRepository_CreateDecisions_Call {
+	return &ExplorerRepository_CreateDecisions_Call{Call: _e.mock.On("CreateDecisions", ctx, decisions, events)}
+}
+
+func (_c *ExplorerRepository_CreateDecisions_Call) Run(run func(ctx context.Context, decisions []explorerdb.CreateDecisionParams, events []models.DecisionEvents)) *ExplorerRepository_CreateDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]explorerdb.CreateDecisionParams), args[2].([]models.DecisionEvents))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateDecisions_Call) Return(_a0 []models.DecisionResult, _a1 error) *ExplorerRepository_CreateDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateDecisions_Call) RunAndReturn(run func(context.Context, []explorerdb.CreateDecisionParams, []models.DecisionEvents) ([]models.DecisionResult, error)) *ExplorerRepository_CreateDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateErasureReceipt provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateErasureReceipt(ctx context.Context, arg explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateErasureReceipt")
+	}
+
+	var r0 explorerdb.CreateErasureReceiptRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateErasureReceiptParams) explorerdb.CreateErasureReceiptRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(explorerdb.CreateErasureReceiptRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.CreateErasureReceiptParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_CreateErasureReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateErasureReceipt'
+type ExplorerRepository_CreateErasureReceipt_Call struct {
+	*mock.Call
+}
+
+// CreateErasureReceipt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateErasureReceiptParams
+func (_e *ExplorerRepository_Expecter) CreateErasureReceipt(ctx interface{}, arg interface{}) *ExplorerRepository_CreateErasureReceipt_Call {
+	return &ExplorerRepository_CreateErasureReceipt_Call{Call: _e.mock.On("CreateErasureReceipt", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateErasureReceipt_Call) Run(run func(ctx context.Context, arg explorerdb.CreateErasureReceiptParams)) *ExplorerRepository_CreateErasureReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateErasureReceiptParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateErasureReceipt_Call) Return(_a0 explorerdb.CreateErasureReceiptRow, _a1 error) *ExplorerRepository_CreateErasureReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateErasureReceipt_Call) RunAndReturn(run func(context.Context, explorerdb.CreateErasureReceiptParams) (explorerdb.CreateErasureReceiptRow, error)) *ExplorerRepository_CreateErasureReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMatch provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateMatch(ctx context.Context, arg explorerdb.CreateMatchParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateMatchParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_CreateMatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMatch'
+type ExplorerRepository_CreateMatch_Call struct {
+	*mock.Call
+}
+
+// CreateMatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateMatchParams
+func (_e *ExplorerRepository_Expecter) CreateMatch(ctx interface{}, arg interface{}) *ExplorerRepository_CreateMatch_Call {
+	return &ExplorerRepository_CreateMatch_Call{Call: _e.mock.On("CreateMatch", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateMatch_Call) Run(run func(ctx context.Context, arg explorerdb.CreateMatchParams)) *ExplorerRepository_CreateMatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateMatchParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateMatch_Call) Return(_a0 error) *ExplorerRepository_CreateMatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateMatch_Call) RunAndReturn(run func(context.Context, explorerdb.CreateMatchParams) error) *ExplorerRepository_CreateMatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOutboxEvent provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateOutboxEvent(ctx context.Context, arg explorerdb.CreateOutboxEventParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateOutboxEventParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_CreateOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOutboxEvent'
+type ExplorerRepository_CreateOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// CreateOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateOutboxEventParams
+func (_e *ExplorerRepository_Expecter) CreateOutboxEvent(ctx interface{}, arg interface{}) *ExplorerRepository_CreateOutboxEvent_Call {
+	return &ExplorerRepository_CreateOutboxEvent_Call{Call: _e.mock.On("CreateOutboxEvent", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateOutboxEvent_Call) Run(run func(ctx context.Context, arg explorerdb.CreateOutboxEventParams)) *ExplorerRepository_CreateOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateOutboxEventParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateOutboxEvent_Call) Return(_a0 error) *ExplorerRepository_CreateOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateOutboxEvent_Call) RunAndReturn(run func(context.Context, explorerdb.CreateOutboxEventParams) error) *ExplorerRepository_CreateOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReport provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) CreateReport(ctx context.Context, arg explorerdb.CreateReportParams) (explorerdb.Report, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 explorerdb.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateReportParams) (explorerdb.Report, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateReportParams) explorerdb.Report); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(explorerdb.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.CreateReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_CreateReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReport'
+type ExplorerRepository_CreateReport_Call struct {
+	*mock.Call
+}
+
+// CreateReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.CreateReportParams
+func (_e *ExplorerRepository_Expecter) CreateReport(ctx interface{}, arg interface{}) *ExplorerRepository_CreateReport_Call {
+	return &ExplorerRepository_CreateReport_Call{Call: _e.mock.On("CreateReport", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_CreateReport_Call) Run(run func(ctx context.Context, arg explorerdb.CreateReportParams)) *ExplorerRepository_CreateReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateReportParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateReport_Call) Return(_a0 explorerdb.Report, _a1 error) *ExplorerRepository_CreateReport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_CreateReport_Call) RunAndReturn(run func(context.Context, explorerdb.CreateReportParams) (explorerdb.Report, error)) *ExplorerRepository_CreateReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeadLetterOutboxEvent provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) DeadLetterOutboxEvent(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeadLetterOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeadLetterOutboxEventParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_DeadLetterOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeadLetterOutboxEvent'
+type ExplorerRepository_DeadLetterOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// DeadLetterOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.DeadLetterOutboxEventParams
+func (_e *ExplorerRepository_Expecter) DeadLetterOutboxEvent(ctx interface{}, arg interface{}) *ExplorerRepository_DeadLetterOutboxEvent_Call {
+	return &ExplorerRepository_DeadLetterOutboxEvent_Call{Call: _e.mock.On("DeadLetterOutboxEvent", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_DeadLetterOutboxEvent_Call) Run(run func(ctx context.Context, arg explorerdb.DeadLetterOutboxEventParams)) *ExplorerRepository_DeadLetterOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.DeadLetterOutboxEventParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeadLetterOutboxEvent_Call) Return(_a0 error) *ExplorerRepository_DeadLetterOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeadLetterOutboxEvent_Call) RunAndReturn(run func(context.Context, explorerdb.DeadLetterOutboxEventParams) error) *ExplorerRepository_DeadLetterOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBlock provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) DeleteBlock(ctx context.Context, arg explorerdb.DeleteBlockParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlock")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteBlockParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteBlockParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.DeleteBlockParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DeleteBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBlock'
+type ExplorerRepository_DeleteBlock_Call struct {
+	*mock.Call
+}
+
+// DeleteBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.DeleteBlockParams
+func (_e *ExplorerRepository_Expecter) DeleteBlock(ctx interface{}, arg interface{}) *ExplorerRepository_DeleteBlock_Call {
+	return &ExplorerRepository_DeleteBlock_Call{Call: _e.mock.On("DeleteBlock", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_DeleteBlock_Call) Run(run func(ctx context.Context, arg explorerdb.DeleteBlockParams)) *ExplorerRepository_DeleteBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.DeleteBlockParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteBlock_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_DeleteBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteBlock_Call) RunAndReturn(run func(context.Context, explorerdb.DeleteBlockParams) (int64, error)) *ExplorerRepository_DeleteBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDecision provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) DeleteDecision(ctx context.Context, arg explorerdb.DeleteDecisionParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDecision")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteDecisionParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteDecisionParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.DeleteDecisionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DeleteDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDecision'
+type ExplorerRepository_DeleteDecision_Call struct {
+	*mock.Call
+}
+
+// DeleteDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.DeleteDecisionParams
+func (_e *ExplorerRepository_Expecter) DeleteDecision(ctx interface{}, arg interface{}) *ExplorerRepository_DeleteDecision_Call {
+	return &ExplorerRepository_DeleteDecision_Call{Call: _e.mock.On("DeleteDecision", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_DeleteDecision_Call) Run(run func(ctx context.Context, arg explorerdb.DeleteDecisionParams)) *ExplorerRepository_DeleteDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.DeleteDecisionParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteDecision_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_DeleteDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteDecision_Call) RunAndReturn(run func(context.Context, explorerdb.DeleteDecisionParams) (int64, error)) *ExplorerRepository_DeleteDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMatch provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) DeleteMatch(ctx context.Context, arg explorerdb.DeleteMatchParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatch")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteMatchParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteMatchParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.DeleteMatchParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DeleteMatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatch'
+type ExplorerRepository_DeleteMatch_Call struct {
+	*mock.Call
+}
+
+// DeleteMatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.DeleteMatchParams
+func (_e *ExplorerRepository_Expecter) DeleteMatch(ctx interface{}, arg interface{}) *ExplorerRepository_DeleteMatch_Call {
+	return &ExplorerRepository_DeleteMatch_Call{Call: _e.mock.On("DeleteMatch", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_DeleteMatch_Call) Run(run func(ctx context.Context, arg explorerdb.DeleteMatchParams)) *ExplorerRepository_DeleteMatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.DeleteMatchParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteMatch_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_DeleteMatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteMatch_Call) RunAndReturn(run func(context.Context, explorerdb.DeleteMatchParams) (int64, error)) *ExplorerRepository_DeleteMatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOutboxEvent provides a mock function with given fields: ctx, id
+func (_m *ExplorerRepository) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_DeleteOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOutboxEvent'
+type ExplorerRepository_DeleteOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// DeleteOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ExplorerRepository_Expecter) DeleteOutboxEvent(ctx interface{}, id interface{}) *ExplorerRepository_DeleteOutboxEvent_Call {
+	return &ExplorerRepository_DeleteOutboxEvent_Call{Call: _e.mock.On("DeleteOutboxEvent", ctx, id)}
+}
+
+func (_c *ExplorerRepository_DeleteOutboxEvent_Call) Run(run func(ctx context.Context, id int64)) *ExplorerRepository_DeleteOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteOutboxEvent_Call) Return(_a0 error) *ExplorerRepository_DeleteOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteOutboxEvent_Call) RunAndReturn(run func(context.Context, int64) error) *ExplorerRepository_DeleteOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserBlocks provides a mock function with given fields: ctx, userID
+func (_m *ExplorerRepository) DeleteUserBlocks(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserBlocks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DeleteUserBlocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserBlocks'
+type ExplorerRepository_DeleteUserBlocks_Call struct {
+	*mock.Call
+}
+
+// DeleteUserBlocks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ExplorerRepository_Expecter) DeleteUserBlocks(ctx interface{}, userID interface{}) *ExplorerRepository_DeleteUserBlocks_Call {
+	return &ExplorerRepository_DeleteUserBlocks_Call{Call: _e.mock.On("DeleteUserBlocks", ctx, userID)}
+}
+
+func (_c *ExplorerRepository_DeleteUserBlocks_Call) Run(run func(ctx context.Context, userID string)) *ExplorerRepository_DeleteUserBlocks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserBlocks_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_DeleteUserBlocks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserBlocks_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ExplorerRepository_DeleteUserBlocks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserDecisions provides a mock function with given fields: ctx, userID
+func (_m *ExplorerRepository) DeleteUserDecisions(ctx context.Context, userID string) ([]explorerdb.DeleteUserDecisionsRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserDecisions")
+	}
+
+	var r0 []explorerdb.DeleteUserDecisionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]explorerdb.DeleteUserDecisionsRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []explorerdb.DeleteUserDecisionsRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.DeleteUserDecisionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DeleteUserDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserDecisions'
+type ExplorerRepository_DeleteUserDecisions_Call struct {
+	*mock.Call
+}
+
+// DeleteUserDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ExplorerRepository_Expecter) DeleteUserDecisions(ctx interface{}, userID interface{}) *ExplorerRepository_DeleteUserDecisions_Call {
+	return &ExplorerRepository_DeleteUserDecisions_Call{Call: _e.mock.On("DeleteUserDecisions", ctx, userID)}
+}
+
+func (_c *ExplorerRepository_DeleteUserDecisions_Call) Run(run func(ctx context.Context, userID string)) *ExplorerRepository_DeleteUserDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserDecisions_Call) Return(_a0 []explorerdb.DeleteUserDecisionsRow, _a1 error) *ExplorerRepository_DeleteUserDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserDecisions_Call) RunAndReturn(run func(context.Context, string) ([]explorerdb.DeleteUserDecisionsRow, error)) *ExplorerRepository_DeleteUserDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserLikeCount provides a mock function with given fields: ctx, recipientUserID
+func (_m *ExplorerRepository) DeleteUserLikeCount(ctx context.Context, recipientUserID string) error {
+	ret := _m.Called(ctx, recipientUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserLikeCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, recipientUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_DeleteUserLikeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserLikeCount'
+type ExplorerRepository_DeleteUserLikeCount_Call struct {
+	*mock.Call
+}
+
+// DeleteUserLikeCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientUserID string
+func (_e *ExplorerRepository_Expecter) DeleteUserLikeCount(ctx interface{}, recipientUserID interface{}) *ExplorerRepository_DeleteUserLikeCount_Call {
+	return &ExplorerRepository_DeleteUserLikeCount_Call{Call: _e.mock.On("DeleteUserLikeCount", ctx, recipientUserID)}
+}
+
+func (_c *ExplorerRepository_DeleteUserLikeCount_Call) Run(run func(ctx context.Context, recipientUserID string)) *ExplorerRepository_DeleteUserLikeCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserLikeCount_Call) Return(_a0 error) *ExplorerRepository_DeleteUserLikeCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserLikeCount_Call) RunAndReturn(run func(context.Context, string) error) *ExplorerRepository_DeleteUserLikeCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserMatches provides a mock function with given fields: ctx, userID
+func (_m *ExplorerRepository) DeleteUserMatches(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserMatches")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DeleteUserMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserMatches'
+type ExplorerRepository_DeleteUserMatches_Call struct {
+	*mock.Call
+}
+
+// DeleteUserMatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ExplorerRepository_Expecter) DeleteUserMatches(ctx interface{}, userID interface{}) *ExplorerRepository_DeleteUserMatches_Call {
+	return &ExplorerRepository_DeleteUserMatches_Call{Call: _e.mock.On("DeleteUserMatches", ctx, userID)}
+}
+
+func (_c *ExplorerRepository_DeleteUserMatches_Call) Run(run func(ctx context.Context, userID string)) *ExplorerRepository_DeleteUserMatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserMatches_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_DeleteUserMatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserMatches_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ExplorerRepository_DeleteUserMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserPopularityScore provides a mock function with given fields: ctx, actorUserID
+func (_m *ExplorerRepository) DeleteUserPopularityScore(ctx context.Context, actorUserID string) error {
+	ret := _m.Called(ctx, actorUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserPopularityScore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, actorUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_DeleteUserPopularityScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserPopularityScore'
+type ExplorerRepository_DeleteUserPopularityScore_Call struct {
+	*mock.Call
+}
+
+// DeleteUserPopularityScore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorUserID string
+func (_e *ExplorerRepository_Expecter) DeleteUserPopularityScore(ctx interface{}, actorUserID interface{}) *ExplorerRepository_DeleteUserPopularityScore_Call {
+	return &ExplorerRepository_DeleteUserPopularityScore_Call{Call: _e.mock.On("DeleteUserPopularityScore", ctx, actorUserID)}
+}
+
+func (_c *ExplorerRepository_DeleteUserPopularityScore_Call) Run(run func(ctx context.Context, actorUserID string)) *ExplorerRepository_DeleteUserPopularityScore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserPopularityScore_Call) Return(_a0 error) *ExplorerRepository_DeleteUserPopularityScore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_DeleteUserPopularityScore_Call) RunAndReturn(run func(context.Context, string) error) *ExplorerRepository_DeleteUserPopularityScore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DrainOutbox provides a mock function with given fields: ctx, claim, deliver
+func (_m *ExplorerRepository) DrainOutbox(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error) {
+	ret := _m.Called(ctx, claim, deliver)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrainOutbox")
+	}
+
+	var r0 models.DrainSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ClaimOutboxEventsParams, func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error)); ok {
+		return rf(ctx, claim, deliver)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ClaimOutboxEventsParams, func(context.Context, explorerdb.Outbox) error) models.DrainSummary); ok {
+		r0 = rf(ctx, claim, deliver)
+	} else {
+		r0 = ret.Get(0).(models.DrainSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ClaimOutboxEventsParams, func(context.Context, explorerdb.Outbox) error) error); ok {
+		r1 = rf(ctx, claim, deliver)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_DrainOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DrainOutbox'
+type ExplorerRepository_DrainOutbox_Call struct {
+	*mock.Call
+}
+
+// DrainOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claim explorerdb.ClaimOutboxEventsParams
+//   - deliver func(context.Context, explorerdb.Outbox) error
+func (_e *ExplorerRepository_Expecter) DrainOutbox(ctx interface{}, claim interface{}, deliver interface{}) *ExplorerRepository_DrainOutbox_Call {
+	return &ExplorerRepository_DrainOutbox_Call{Call: _e.mock.On("DrainOutbox", ctx, claim, deliver)}
+}
+
+func (_c *ExplorerRepository_DrainOutbox_Call) Run(run func(ctx context.Context, claim explorerdb.ClaimOutboxEventsParams, deliver func(context.Context, explorerdb.Outbox) error)) *ExplorerRepository_DrainOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ClaimOutboxEventsParams), args[2].(func(context.Context, explorerdb.Outbox) error))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_DrainOutbox_Call) Return(_a0 models.DrainSummary, _a1 error) *ExplorerRepository_DrainOutbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_DrainOutbox_Call) RunAndReturn(run func(context.Context, explorerdb.ClaimOutboxEventsParams, func(context.Context, explorerdb.Outbox) error) (models.DrainSummary, error)) *ExplorerRepository_DrainOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDecision provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) GetDecision(ctx context.Context, arg explorerdb.GetDecisionParams) (explorerdb.Decision, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDecision")
+	}
+
+	var r0 explorerdb.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.GetDecisionParams) (explorerdb.Decision, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.GetDecisionParams) explorerdb.Decision); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(explorerdb.Decision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.GetDecisionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_GetDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDecision'
+type ExplorerRepository_GetDecision_Call struct {
+	*mock.Call
+}
+
+// GetDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.GetDecisionParams
+func (_e *ExplorerRepository_Expecter) GetDecision(ctx interface{}, arg interface{}) *ExplorerRepository_GetDecision_Call {
+	return &ExplorerRepository_GetDecision_Call{Call: _e.mock.On("GetDecision", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_GetDecision_Call) Run(run func(ctx context.Context, arg explorerdb.GetDecisionParams)) *ExplorerRepository_GetDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.GetDecisionParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetDecision_Call) Return(_a0 explorerdb.Decision, _a1 error) *ExplorerRepository_GetDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetDecision_Call) RunAndReturn(run func(context.Context, explorerdb.GetDecisionParams) (explorerdb.Decision, error)) *ExplorerRepository_GetDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLikedRecipients provides a mock function with given fields: ctx, actorUserID, page
+func (_m *ExplorerRepository) GetLikedRecipients(ctx context.Context, actorUserID string, page models.PageRequest) ([]models.Recipient, string, error) {
+	ret := _m.Called(ctx, actorUserID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLikedRecipients")
+	}
+
+	var r0 []models.Recipient
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) ([]models.Recipient, string, error)); ok {
+		return rf(ctx, actorUserID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) []models.Recipient); ok {
+		r0 = rf(ctx, actorUserID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Recipient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PageRequest) string); ok {
+		r1 = rf(ctx, actorUserID, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.PageRequest) error); ok {
+		r2 = rf(ctx, actorUserID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ExplorerRepository_GetLikedRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikedRecipients'
+type ExplorerRepository_GetLikedRecipients_Call struct {
+	*mock.Call
+}
+
+// GetLikedRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorUserID string
+//   - page models.PageRequest
+func (_e *ExplorerRepository_Expecter) GetLikedRecipients(ctx interface{}, actorUserID interface{}, page interface{}) *ExplorerRepository_GetLikedRecipients_Call {
+	return &ExplorerRepository_GetLikedRecipients_Call{Call: _e.mock.On("GetLikedRecipients", ctx, actorUserID, page)}
+}
+
+func (_c *ExplorerRepository_GetLikedRecipients_Call) Run(run func(ctx context.Context, actorUserID string, page models.PageRequest)) *ExplorerRepository_GetLikedRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetLikedRecipients_Call) Return(_a0 []models.Recipient, _a1 string, _a2 error) *ExplorerRepository_GetLikedRecipients_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetLikedRecipients_Call) RunAndReturn(run func(context.Context, string, models.PageRequest) ([]models.Recipient, string, error)) *ExplorerRepository_GetLikedRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLikers provides a mock function with given fields: ctx, recipientUserID, page
+func (_m *ExplorerRepository) GetLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	ret := _m.Called(ctx, recipientUserID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLikers")
+	}
+
+	var r0 []models.Liker
+	var r1 models.PageTokens
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) ([]models.Liker, models.PageTokens, error)); ok {
+		return rf(ctx, recipientUserID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) []models.Liker); ok {
+		r0 = rf(ctx, recipientUserID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Liker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PageRequest) models.PageTokens); ok {
+		r1 = rf(ctx, recipientUserID, page)
+	} else {
+		r1 = ret.Get(1).(models.PageTokens)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.PageRequest) error); ok {
+		r2 = rf(ctx, recipientUserID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ExplorerRepository_GetLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikers'
+type ExplorerRepository_GetLikers_Call struct {
+	*mock.Call
+}
+
+// GetLikers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientUserID string
+//   - page models.PageRequest
+func (_e *ExplorerRepository_Expecter) GetLikers(ctx interface{}, recipientUserID interface{}, page interface{}) *ExplorerRepository_GetLikers_Call {
+	return &ExplorerRepository_GetLikers_Call{Call: _e.mock.On("GetLikers", ctx, recipientUserID, page)}
+}
+
+func (_c *ExplorerRepository_GetLikers_Call) Run(run func(ctx context.Context, recipientUserID string, page models.PageRequest)) *ExplorerRepository_GetLikers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetLikers_Call) Return(_a0 []models.Liker, _a1 models.PageTokens, _a2 error) *ExplorerRepository_GetLikers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetLikers_Call) RunAndReturn(run func(context.Context, string, models.PageRequest) ([]models.Liker, models.PageTokens, error)) *ExplorerRepository_GetLikers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMatches provides a mock function with given fields: ctx, userID, page
+func (_m *ExplorerRepository) GetMatches(ctx context.Context, userID string, page models.PageRequest) ([]models.Match, string, error) {
+	ret := _m.Called(ctx, userID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMatches")
+	}
+
+	var r0 []models.Match
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) ([]models.Match, string, error)); ok {
+		return rf(ctx, userID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) []models.Match); ok {
+		r0 = rf(ctx, userID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PageRequest) string); ok {
+		r1 = rf(ctx, userID, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.PageRequest) error); ok {
+		r2 = rf(ctx, userID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ExplorerRepository_GetMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMatches'
+type ExplorerRepository_GetMatches_Call struct {
+	*mock.Call
+}
+
+// GetMatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - page models.PageRequest
+func (_e *ExplorerRepository_Expecter) GetMatches(ctx interface{}, userID interface{}, page interface{}) *ExplorerRepository_GetMatches_Call {
+	return &ExplorerRepository_GetMatches_Call{Call: _e.mock.On("GetMatches", ctx, userID, page)}
+}
+
+func (_c *ExplorerRepository_GetMatches_Call) Run(run func(ctx context.Context, userID string, page models.PageRequest)) *ExplorerRepository_GetMatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetMatches_Call) Return(_a0 []models.Match, _a1 string, _a2 error) *ExplorerRepository_GetMatches_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetMatches_Call) RunAndReturn(run func(context.Context, string, models.PageRequest) ([]models.Match, string, error)) *ExplorerRepository_GetMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNewLikers provides a mock function with given fields: ctx, recipientUserID, page
+func (_m *ExplorerRepository) GetNewLikers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	ret := _m.Called(ctx, recipientUserID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewLikers")
+	}
+
+	var r0 []models.Liker
+	var r1 models.PageTokens
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) ([]models.Liker, models.PageTokens, error)); ok {
+		return rf(ctx, recipientUserID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) []models.Liker); ok {
+		r0 = rf(ctx, recipientUserID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Liker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PageRequest) models.PageTokens); ok {
+		r1 = rf(ctx, recipientUserID, page)
+	} else {
+		r1 = ret.Get(1).(models.PageTokens)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.PageRequest) error); ok {
+		r2 = rf(ctx, recipientUserID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ExplorerRepository_GetNewLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewLikers'
+type ExplorerRepository_GetNewLikers_Call struct {
+	*mock.Call
+}
+
+// GetNewLikers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientUserID string
+//   - page models.PageRequest
+func (_e *ExplorerRepository_Expecter) GetNewLikers(ctx interface{}, recipientUserID interface{}, page interface{}) *ExplorerRepository_GetNewLikers_Call {
+	return &ExplorerRepository_GetNewLikers_Call{Call: _e.mock.On("GetNewLikers", ctx, recipientUserID, page)}
+}
+
+func (_c *ExplorerRepository_GetNewLikers_Call) Run(run func(ctx context.Context, recipientUserID string, page models.PageRequest)) *ExplorerRepository_GetNewLikers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetNewLikers_Call) Return(_a0 []models.Liker, _a1 models.PageTokens, _a2 error) *ExplorerRepository_GetNewLikers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetNewLikers_Call) RunAndReturn(run func(context.Context, string, models.PageRequest) ([]models.Liker, models.PageTokens, error)) *ExplorerRepository_GetNewLikers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPassers provides a mock function with given fields: ctx, recipientUserID, page
+func (_m *ExplorerRepository) GetPassers(ctx context.Context, recipientUserID string, page models.PageRequest) ([]models.Liker, models.PageTokens, error) {
+	ret := _m.Called(ctx, recipientUserID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPassers")
+	}
+
+	var r0 []models.Liker
+	var r1 models.PageTokens
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) ([]models.Liker, models.PageTokens, error)); ok {
+		return rf(ctx, recipientUserID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PageRequest) []models.Liker); ok {
+		r0 = rf(ctx, recipientUserID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Liker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PageRequest) models.PageTokens); ok {
+		r1 = rf(ctx, recipientUserID, page)
+	} else {
+		r1 = ret.Get(1).(models.PageTokens)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.PageRequest) error); ok {
+		r2 = rf(ctx, recipientUserID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ExplorerRepository_GetPassers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPassers'
+type ExplorerRepository_GetPassers_Call struct {
+	*mock.Call
+}
+
+// GetPassers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientUserID string
+//   - page models.PageRequest
+func (_e *ExplorerRepository_Expecter) GetPassers(ctx interface{}, recipientUserID interface{}, page interface{}) *ExplorerRepository_GetPassers_Call {
+	return &ExplorerRepository_GetPassers_Call{Call: _e.mock.On("GetPassers", ctx, recipientUserID, page)}
+}
+
+func (_c *ExplorerRepository_GetPassers_Call) Run(run func(ctx context.Context, recipientUserID string, page models.PageRequest)) *ExplorerRepository_GetPassers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetPassers_Call) Return(_a0 []models.Liker, _a1 models.PageTokens, _a2 error) *ExplorerRepository_GetPassers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetPassers_Call) RunAndReturn(run func(context.Context, string, models.PageRequest) ([]models.Liker, models.PageTokens, error)) *ExplorerRepository_GetPassers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceStats provides a mock function with given fields: ctx
+func (_m *ExplorerRepository) GetServiceStats(ctx context.Context) (explorerdb.GetServiceStatsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceStats")
+	}
+
+	var r0 explorerdb.GetServiceStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (explorerdb.GetServiceStatsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) explorerdb.GetServiceStatsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(explorerdb.GetServiceStatsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_GetServiceStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceStats'
+type ExplorerRepository_GetServiceStats_Call struct {
+	*mock.Call
+}
+
+// GetServiceStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ExplorerRepository_Expecter) GetServiceStats(ctx interface{}) *ExplorerRepository_GetServiceStats_Call {
+	return &ExplorerRepository_GetServiceStats_Call{Call: _e.mock.On("GetServiceStats", ctx)}
+}
+
+func (_c *ExplorerRepository_GetServiceStats_Call) Run(run func(ctx context.Context)) *ExplorerRepository_GetServiceStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_GetServiceStats_Call) Return(_a0 explorerdb.GetServiceStatsRow, _a1 error) *ExplorerRepository_GetServiceStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_GetServiceStats_Call) RunAndReturn(run func(context.Context) (explorerdb.GetServiceStatsRow, error)) *ExplorerRepository_GetServiceStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasMutualLike provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) HasMutualLike(ctx context.Context, arg explorerdb.HasMutualLikeParams) (*bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasMutualLike")
+	}
+
+	var r0 *bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.HasMutualLikeParams) (*bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.HasMutualLikeParams) *bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.HasMutualLikeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_HasMutualLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasMutualLike'
+type ExplorerRepository_HasMutualLike_Call struct {
+	*mock.Call
+}
+
+// HasMutualLike is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.HasMutualLikeParams
+func (_e *ExplorerRepository_Expecter) HasMutualLike(ctx interface{}, arg interface{}) *ExplorerRepository_HasMutualLike_Call {
+	return &ExplorerRepository_HasMutualLike_Call{Call: _e.mock.On("HasMutualLike", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_HasMutualLike_Call) Run(run func(ctx context.Context, arg explorerdb.HasMutualLikeParams)) *ExplorerRepository_HasMutualLike_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.HasMutualLikeParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_HasMutualLike_Call) Return(_a0 *bool, _a1 error) *ExplorerRepository_HasMutualLike_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_HasMutualLike_Call) RunAndReturn(run func(context.Context, explorerdb.HasMutualLikeParams) (*bool, error)) *ExplorerRepository_HasMutualLike_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportDecisions provides a mock function with given fields: ctx, decisions
+func (_m *ExplorerRepository) ImportDecisions(ctx context.Context, decisions []models.ImportedDecision) (models.ImportSummary, error) {
+	ret := _m.Called(ctx, decisions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportDecisions")
+	}
+
+	var r0 models.ImportSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ImportedDecision) (models.ImportSummary, error)); ok {
+		return rf(ctx, decisions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ImportedDecision) models.ImportSummary); ok {
+		r0 = rf(ctx, decisions)
+	} else {
+		r0 = ret.Get(0).(models.ImportSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.ImportedDecision) error); ok {
+		r1 = rf(ctx, decisions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ImportDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportDecisions'
+type ExplorerRepository_ImportDecisions_Call struct {
+	*mock.Call
+}
+
+// ImportDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decisions []models.ImportedDecision
+func (_e *ExplorerRepository_Expecter) ImportDecisions(ctx interface{}, decisions interface{}) *ExplorerRepository_ImportDecisions_Call {
+	return &ExplorerRepository_ImportDecisions_Call{Call: _e.mock.On("ImportDecisions", ctx, decisions)}
+}
+
+func (_c *ExplorerRepository_ImportDecisions_Call) Run(run func(ctx context.Context, decisions []models.ImportedDecision)) *ExplorerRepository_ImportDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.ImportedDecision))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ImportDecisions_Call) Return(_a0 models.ImportSummary, _a1 error) *ExplorerRepository_ImportDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ImportDecisions_Call) RunAndReturn(run func(context.Context, []models.ImportedDecision) (models.ImportSummary, error)) *ExplorerRepository_ImportDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestDecisions provides a mock function with given fields: ctx, decisions
+func (_m *ExplorerRepository) IngestDecisions(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error) {
+	ret := _m.Called(ctx, decisions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IngestDecisions")
+	}
+
+	var r0 models.IngestSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error)); ok {
+		return rf(ctx, decisions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []explorerdb.UpsertDecisionsParams) models.IngestSummary); ok {
+		r0 = rf(ctx, decisions)
+	} else {
+		r0 = ret.Get(0).(models.IngestSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []explorerdb.UpsertDecisionsParams) error); ok {
+		r1 = rf(ctx, decisions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_IngestDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestDecisions'
+type ExplorerRepository_IngestDecisions_Call struct {
+	*mock.Call
+}
+
+// IngestDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decisions []explorerdb.UpsertDecisionsParams
+func (_e *ExplorerRepository_Expecter) IngestDecisions(ctx interface{}, decisions interface{}) *ExplorerRepository_IngestDecisions_Call {
+	return &ExplorerRepository_IngestDecisions_Call{Call: _e.mock.On("IngestDecisions", ctx, decisions)}
+}
+
+func (_c *ExplorerRepository_IngestDecisions_Call) Run(run func(ctx context.Context, decisions []explorerdb.UpsertDecisionsParams)) *ExplorerRepository_IngestDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]explorerdb.UpsertDecisionsParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_IngestDecisions_Call) Return(_a0 models.IngestSummary, _a1 error) *ExplorerRepository_IngestDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_IngestDecisions_Call) RunAndReturn(run func(context.Context, []explorerdb.UpsertDecisionsParams) (models.IngestSummary, error)) *ExplorerRepository_IngestDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBlocked provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) IsBlocked(ctx context.Context, arg explorerdb.IsBlockedParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlocked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.IsBlockedParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.IsBlockedParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.IsBlockedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_IsBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBlocked'
+type ExplorerRepository_IsBlocked_Call struct {
+	*mock.Call
+}
+
+// IsBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.IsBlockedParams
+func (_e *ExplorerRepository_Expecter) IsBlocked(ctx interface{}, arg interface{}) *ExplorerRepository_IsBlocked_Call {
+	return &ExplorerRepository_IsBlocked_Call{Call: _e.mock.On("IsBlocked", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_IsBlocked_Call) Run(run func(ctx context.Context, arg explorerdb.IsBlockedParams)) *ExplorerRepository_IsBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.IsBlockedParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_IsBlocked_Call) Return(_a0 bool, _a1 error) *ExplorerRepository_IsBlocked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_IsBlocked_Call) RunAndReturn(run func(context.Context, explorerdb.IsBlockedParams) (bool, error)) *ExplorerRepository_IsBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListAuditEvents(ctx context.Context, arg explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 []explorerdb.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListAuditEventsParams) []explorerdb.AuditEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListAuditEventsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type ExplorerRepository_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListAuditEventsParams
+func (_e *ExplorerRepository_Expecter) ListAuditEvents(ctx interface{}, arg interface{}) *ExplorerRepository_ListAuditEvents_Call {
+	return &ExplorerRepository_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListAuditEvents_Call) Run(run func(ctx context.Context, arg explorerdb.ListAuditEventsParams)) *ExplorerRepository_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListAuditEventsParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListAuditEvents_Call) Return(_a0 []explorerdb.AuditEvent, _a1 error) *ExplorerRepository_ListAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListAuditEvents_Call) RunAndReturn(run func(context.Context, explorerdb.ListAuditEventsParams) ([]explorerdb.AuditEvent, error)) *ExplorerRepository_ListAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDecidersAsc provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListDecidersAsc(ctx context.Context, arg explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDecidersAsc")
+	}
+
+	var r0 []explorerdb.ListDecidersAscRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListDecidersAscParams) []explorerdb.ListDecidersAscRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.ListDecidersAscRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListDecidersAscParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListDecidersAsc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDecidersAsc'
+type ExplorerRepository_ListDecidersAsc_Call struct {
+	*mock.Call
+}
+
+// ListDecidersAsc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListDecidersAscParams
+func (_e *ExplorerRepository_Expecter) ListDecidersAsc(ctx interface{}, arg interface{}) *ExplorerRepository_ListDecidersAsc_Call {
+	return &ExplorerRepository_ListDecidersAsc_Call{Call: _e.mock.On("ListDecidersAsc", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListDecidersAsc_Call) Run(run func(ctx context.Context, arg explorerdb.ListDecidersAscParams)) *ExplorerRepository_ListDecidersAsc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListDecidersAscParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListDecidersAsc_Call) Return(_a0 []explorerdb.ListDecidersAscRow, _a1 error) *ExplorerRepository_ListDecidersAsc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListDecidersAsc_Call) RunAndReturn(run func(context.Context, explorerdb.ListDecidersAscParams) ([]explorerdb.ListDecidersAscRow, error)) *ExplorerRepository_ListDecidersAsc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDecidersDesc provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListDecidersDesc(ctx context.Context, arg explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDecidersDesc")
+	}
+
+	var r0 []explorerdb.ListDecidersDescRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListDecidersDescParams) []explorerdb.ListDecidersDescRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.ListDecidersDescRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListDecidersDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListDecidersDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDecidersDesc'
+type ExplorerRepository_ListDecidersDesc_Call struct {
+	*mock.Call
+}
+
+// ListDecidersDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListDecidersDescParams
+func (_e *ExplorerRepository_Expecter) ListDecidersDesc(ctx interface{}, arg interface{}) *ExplorerRepository_ListDecidersDesc_Call {
+	return &ExplorerRepository_ListDecidersDesc_Call{Call: _e.mock.On("ListDecidersDesc", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListDecidersDesc_Call) Run(run func(ctx context.Context, arg explorerdb.ListDecidersDescParams)) *ExplorerRepository_ListDecidersDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListDecidersDescParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListDecidersDesc_Call) Return(_a0 []explorerdb.ListDecidersDescRow, _a1 error) *ExplorerRepository_ListDecidersDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListDecidersDesc_Call) RunAndReturn(run func(context.Context, explorerdb.ListDecidersDescParams) ([]explorerdb.ListDecidersDescRow, error)) *ExplorerRepository_ListDecidersDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLikersByPopularity provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListLikersByPopularity(ctx context.Context, arg explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLikersByPopularity")
+	}
+
+	var r0 []explorerdb.ListLikersByPopularityRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListLikersByPopularityParams) []explorerdb.ListLikersByPopularityRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.ListLikersByPopularityRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListLikersByPopularityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListLikersByPopularity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLikersByPopularity'
+type ExplorerRepository_ListLikersByPopularity_Call struct {
+	*mock.Call
+}
+
+// ListLikersByPopularity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListLikersByPopularityParams
+func (_e *ExplorerRepository_Expecter) ListLikersByPopularity(ctx interface{}, arg interface{}) *ExplorerRepository_ListLikersByPopularity_Call {
+	return &ExplorerRepository_ListLikersByPopularity_Call{Call: _e.mock.On("ListLikersByPopularity", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListLikersByPopularity_Call) Run(run func(ctx context.Context, arg explorerdb.ListLikersByPopularityParams)) *ExplorerRepository_ListLikersByPopularity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListLikersByPopularityParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListLikersByPopularity_Call) Return(_a0 []explorerdb.ListLikersByPopularityRow, _a1 error) *ExplorerRepository_ListLikersByPopularity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListLikersByPopularity_Call) RunAndReturn(run func(context.Context, explorerdb.ListLikersByPopularityParams) ([]explorerdb.ListLikersByPopularityRow, error)) *ExplorerRepository_ListLikersByPopularity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNewLikersAsc provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListNewLikersAsc(ctx context.Context, arg explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNewLikersAsc")
+	}
+
+	var r0 []explorerdb.ListNewLikersAscRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListNewLikersAscParams) []explorerdb.ListNewLikersAscRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.ListNewLikersAscRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListNewLikersAscParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListNewLikersAsc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNewLikersAsc'
+type ExplorerRepository_ListNewLikersAsc_Call struct {
+	*mock.Call
+}
+
+// ListNewLikersAsc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListNewLikersAscParams
+func (_e *ExplorerRepository_Expecter) ListNewLikersAsc(ctx interface{}, arg interface{}) *ExplorerRepository_ListNewLikersAsc_Call {
+	return &ExplorerRepository_ListNewLikersAsc_Call{Call: _e.mock.On("ListNewLikersAsc", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListNewLikersAsc_Call) Run(run func(ctx context.Context, arg explorerdb.ListNewLikersAscParams)) *ExplorerRepository_ListNewLikersAsc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListNewLikersAscParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListNewLikersAsc_Call) Return(_a0 []explorerdb.ListNewLikersAscRow, _a1 error) *ExplorerRepository_ListNewLikersAsc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListNewLikersAsc_Call) RunAndReturn(run func(context.Context, explorerdb.ListNewLikersAscParams) ([]explorerdb.ListNewLikersAscRow, error)) *ExplorerRepository_ListNewLikersAsc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNewLikersDesc provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListNewLikersDesc(ctx context.Context, arg explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNewLikersDesc")
+	}
+
+	var r0 []explorerdb.ListNewLikersDescRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListNewLikersDescParams) []explorerdb.ListNewLikersDescRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.ListNewLikersDescRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListNewLikersDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListNewLikersDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNewLikersDesc'
+type ExplorerRepository_ListNewLikersDesc_Call struct {
+	*mock.Call
+}
+
+// ListNewLikersDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListNewLikersDescParams
+func (_e *ExplorerRepository_Expecter) ListNewLikersDesc(ctx interface{}, arg interface{}) *ExplorerRepository_ListNewLikersDesc_Call {
+	return &ExplorerRepository_ListNewLikersDesc_Call{Call: _e.mock.On("ListNewLikersDesc", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListNewLikersDesc_Call) Run(run func(ctx context.Context, arg explorerdb.ListNewLikersDescParams)) *ExplorerRepository_ListNewLikersDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListNewLikersDescParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListNewLikersDesc_Call) Return(_a0 []explorerdb.ListNewLikersDescRow, _a1 error) *ExplorerRepository_ListNewLikersDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListNewLikersDesc_Call) RunAndReturn(run func(context.Context, explorerdb.ListNewLikersDescParams) ([]explorerdb.ListNewLikersDescRow, error)) *ExplorerRepository_ListNewLikersDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserDecisions provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) ListUserDecisions(ctx context.Context, arg explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserDecisions")
+	}
+
+	var r0 []explorerdb.ListUserDecisionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.ListUserDecisionsParams) []explorerdb.ListUserDecisionsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]explorerdb.ListUserDecisionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.ListUserDecisionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ListUserDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserDecisions'
+type ExplorerRepository_ListUserDecisions_Call struct {
+	*mock.Call
+}
+
+// ListUserDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.ListUserDecisionsParams
+func (_e *ExplorerRepository_Expecter) ListUserDecisions(ctx interface{}, arg interface{}) *ExplorerRepository_ListUserDecisions_Call {
+	return &ExplorerRepository_ListUserDecisions_Call{Call: _e.mock.On("ListUserDecisions", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_ListUserDecisions_Call) Run(run func(ctx context.Context, arg explorerdb.ListUserDecisionsParams)) *ExplorerRepository_ListUserDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.ListUserDecisionsParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ListUserDecisions_Call) Return(_a0 []explorerdb.ListUserDecisionsRow, _a1 error) *ExplorerRepository_ListUserDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ListUserDecisions_Call) RunAndReturn(run func(context.Context, explorerdb.ListUserDecisionsParams) ([]explorerdb.ListUserDecisionsRow, error)) *ExplorerRepository_ListUserDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockDecisionPair provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) LockDecisionPair(ctx context.Context, arg explorerdb.LockDecisionPairParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockDecisionPair")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.LockDecisionPairParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_LockDecisionPair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockDecisionPair'
+type ExplorerRepository_LockDecisionPair_Call struct {
+	*mock.Call
+}
+
+// LockDecisionPair is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.LockDecisionPairParams
+func (_e *ExplorerRepository_Expecter) LockDecisionPair(ctx interface{}, arg interface{}) *ExplorerRepository_LockDecisionPair_Call {
+	return &ExplorerRepository_LockDecisionPair_Call{Call: _e.mock.On("LockDecisionPair", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_LockDecisionPair_Call) Run(run func(ctx context.Context, arg explorerdb.LockDecisionPairParams)) *ExplorerRepository_LockDecisionPair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.LockDecisionPairParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_LockDecisionPair_Call) Return(_a0 error) *ExplorerRepository_LockDecisionPair_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_LockDecisionPair_Call) RunAndReturn(run func(context.Context, explorerdb.LockDecisionPairParams) error) *ExplorerRepository_LockDecisionPair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredLikes provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) PurgeExpiredLikes(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredLikes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.PurgeExpiredLikesParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.PurgeExpiredLikesParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.PurgeExpiredLikesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_PurgeExpiredLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredLikes'
+type ExplorerRepository_PurgeExpiredLikes_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredLikes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.PurgeExpiredLikesParams
+func (_e *ExplorerRepository_Expecter) PurgeExpiredLikes(ctx interface{}, arg interface{}) *ExplorerRepository_PurgeExpiredLikes_Call {
+	return &ExplorerRepository_PurgeExpiredLikes_Call{Call: _e.mock.On("PurgeExpiredLikes", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_PurgeExpiredLikes_Call) Run(run func(ctx context.Context, arg explorerdb.PurgeExpiredLikesParams)) *ExplorerRepository_PurgeExpiredLikes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.PurgeExpiredLikesParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_PurgeExpiredLikes_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_PurgeExpiredLikes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_PurgeExpiredLikes_Call) RunAndReturn(run func(context.Context, explorerdb.PurgeExpiredLikesParams) (int64, error)) *ExplorerRepository_PurgeExpiredLikes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconcileLikeCounts provides a mock function with given fields: ctx
+func (_m *ExplorerRepository) ReconcileLikeCounts(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileLikeCounts")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_ReconcileLikeCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileLikeCounts'
+type ExplorerRepository_ReconcileLikeCounts_Call struct {
+	*mock.Call
+}
+
+// ReconcileLikeCounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ExplorerRepository_Expecter) ReconcileLikeCounts(ctx interface{}) *ExplorerRepository_ReconcileLikeCounts_Call {
+	return &ExplorerRepository_ReconcileLikeCounts_Call{Call: _e.mock.On("ReconcileLikeCounts", ctx)}
+}
+
+func (_c *ExplorerRepository_ReconcileLikeCounts_Call) Run(run func(ctx context.Context)) *ExplorerRepository_ReconcileLikeCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_ReconcileLikeCounts_Call) Return(_a0 int64, _a1 error) *ExplorerRepository_ReconcileLikeCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_ReconcileLikeCounts_Call) RunAndReturn(run func(context.Context) (int64, error)) *ExplorerRepository_ReconcileLikeCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDecision provides a mock function with given fields: ctx, decision, events
+func (_m *ExplorerRepository) RecordDecision(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents) (models.RecordedDecision, error) {
+	ret := _m.Called(ctx, decision, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDecision")
+	}
+
+	var r0 models.RecordedDecision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateDecisionParams, models.DecisionEvents) (models.RecordedDecision, error)); ok {
+		return rf(ctx, decision, events)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.CreateDecisionParams, models.DecisionEvents) models.RecordedDecision); ok {
+		r0 = rf(ctx, decision, events)
+	} else {
+		r0 = ret.Get(0).(models.RecordedDecision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.CreateDecisionParams, models.DecisionEvents) error); ok {
+		r1 = rf(ctx, decision, events)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_RecordDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDecision'
+type ExplorerRepository_RecordDecision_Call struct {
+	*mock.Call
+}
+
+// RecordDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decision explorerdb.CreateDecisionParams
+//   - events models.DecisionEvents
+func (_e *ExplorerRepository_Expecter) RecordDecision(ctx interface{}, decision interface{}, events interface{}) *ExplorerRepository_RecordDecision_Call {
+	return &ExplorerRepository_RecordDecision_Call{Call: _e.mock.On("RecordDecision", ctx, decision, events)}
+}
+
+func (_c *ExplorerRepository_RecordDecision_Call) Run(run func(ctx context.Context, decision explorerdb.CreateDecisionParams, events models.DecisionEvents)) *ExplorerRepository_RecordDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.CreateDecisionParams), args[2].(models.DecisionEvents))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_RecordDecision_Call) Return(_a0 models.RecordedDecision, _a1 error) *ExplorerRepository_RecordDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_RecordDecision_Call) RunAndReturn(run func(context.Context, explorerdb.CreateDecisionParams, models.DecisionEvents) (models.RecordedDecision, error)) *ExplorerRepository_RecordDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDecision provides a mock function with given fields: ctx, decision
+func (_m *ExplorerRepository) RemoveDecision(ctx context.Context, decision explorerdb.DeleteDecisionParams) (models.RemovedDecision, error) {
+	ret := _m.Called(ctx, decision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDecision")
+	}
+
+	var r0 models.RemovedDecision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteDecisionParams) (models.RemovedDecision, error)); ok {
+		return rf(ctx, decision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.DeleteDecisionParams) models.RemovedDecision); ok {
+		r0 = rf(ctx, decision)
+	} else {
+		r0 = ret.Get(0).(models.RemovedDecision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, explorerdb.DeleteDecisionParams) error); ok {
+		r1 = rf(ctx, decision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplorerRepository_RemoveDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDecision'
+type ExplorerRepository_RemoveDecision_Call struct {
+	*mock.Call
+}
+
+// RemoveDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decision explorerdb.DeleteDecisionParams
+func (_e *ExplorerRepository_Expecter) RemoveDecision(ctx interface{}, decision interface{}) *ExplorerRepository_RemoveDecision_Call {
+	return &ExplorerRepository_RemoveDecision_Call{Call: _e.mock.On("RemoveDecision", ctx, decision)}
+}
+
+func (_c *ExplorerRepository_RemoveDecision_Call) Run(run func(ctx context.Context, decision explorerdb.DeleteDecisionParams)) *ExplorerRepository_RemoveDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.DeleteDecisionParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_RemoveDecision_Call) Return(_a0 models.RemovedDecision, _a1 error) *ExplorerRepository_RemoveDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplorerRepository_RemoveDecision_Call) RunAndReturn(run func(context.Context, explorerdb.DeleteDecisionParams) (models.RemovedDecision, error)) *ExplorerRepository_RemoveDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryOutboxEvent provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) RetryOutboxEvent(ctx context.Context, arg explorerdb.RetryOutboxEventParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, explorerdb.RetryOutboxEventParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplorerRepository_RetryOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryOutboxEvent'
+type ExplorerRepository_RetryOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// RetryOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg explorerdb.RetryOutboxEventParams
+func (_e *ExplorerRepository_Expecter) RetryOutboxEvent(ctx interface{}, arg interface{}) *ExplorerRepository_RetryOutboxEvent_Call {
+	return &ExplorerRepository_RetryOutboxEvent_Call{Call: _e.mock.On("RetryOutboxEvent", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_RetryOutboxEvent_Call) Run(run func(ctx context.Context, arg explorerdb.RetryOutboxEventParams)) *ExplorerRepository_RetryOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(explorerdb.RetryOutboxEventParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_RetryOutboxEvent_Call) Return(_a0 error) *ExplorerRepository_RetryOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_RetryOutboxEvent_Call) RunAndReturn(run func(context.Context, explorerdb.RetryOutboxEventParams) error) *ExplorerRepository_RetryOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertDecisions provides a mock function with given fields: ctx, arg
+func (_m *ExplorerRepository) UpsertDecisions(ctx context.Context, arg []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertDecisions")
+	}
+
+	var r0 *explorerdb.UpsertDecisionsBatchResults
+	if rf, ok := ret.Get(0).(func(context.Context, []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*explorerdb.UpsertDecisionsBatchResults)
+		}
+	}
+
+	return r0
+}
+
+// ExplorerRepository_UpsertDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertDecisions'
+type ExplorerRepository_UpsertDecisions_Call struct {
+	*mock.Call
+}
+
+// UpsertDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg []explorerdb.UpsertDecisionsParams
+func (_e *ExplorerRepository_Expecter) UpsertDecisions(ctx interface{}, arg interface{}) *ExplorerRepository_UpsertDecisions_Call {
+	return &ExplorerRepository_UpsertDecisions_Call{Call: _e.mock.On("UpsertDecisions", ctx, arg)}
+}
+
+func (_c *ExplorerRepository_UpsertDecisions_Call) Run(run func(ctx context.Context, arg []explorerdb.UpsertDecisionsParams)) *ExplorerRepository_UpsertDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]explorerdb.UpsertDecisionsParams))
+	})
+	return _c
+}
+
+func (_c *ExplorerRepository_UpsertDecisions_Call) Return(_a0 *explorerdb.UpsertDecisionsBatchResults) *ExplorerRepository_UpsertDecisions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExplorerRepository_UpsertDecisions_Call) RunAndReturn(run func(context.Context, []explorerdb.UpsertDecisionsParams) *explorerdb.UpsertDecisionsBatchResults) *ExplorerRepository_UpsertDecisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
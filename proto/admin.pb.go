@@ -0,0 +1,938 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v5.29.3
+// source: proto/admin.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportFormat int32
+
+const (
+	ExportFormat_JSON ExportFormat = 0 // {"user_id": ..., "decisions": [{"actor_user_id", "recipient_user_id", "liked_recipient", "unix_timestamp"}, ...]}
+	ExportFormat_CSV  ExportFormat = 1 // A header line, then one actor_user_id,recipient_user_id,liked_recipient,unix_timestamp line per decision
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "JSON",
+		1: "CSV",
+	}
+	ExportFormat_value = map[string]int32{
+		"JSON": 0,
+		"CSV":  1,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_proto_admin_proto_enumTypes[0]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type ListUserDecisionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"` // Overrides the page size carried by pagination_token
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListUserDecisionsRequest) Reset() {
+	*x = ListUserDecisionsRequest{}
+	mi := &file_proto_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserDecisionsRequest) ProtoMessage() {}
+
+func (x *ListUserDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListUserDecisionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserDecisionsRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListUserDecisionsRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type ListUserDecisionsResponse struct {
+	state               protoimpl.MessageState                `protogen:"open.v1"`
+	Decisions           []*ListUserDecisionsResponse_Decision `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"` // Most recently recorded pairs first
+	NextPaginationToken *string                               `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListUserDecisionsResponse) Reset() {
+	*x = ListUserDecisionsResponse{}
+	mi := &file_proto_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserDecisionsResponse) ProtoMessage() {}
+
+func (x *ListUserDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListUserDecisionsResponse) GetDecisions() []*ListUserDecisionsResponse_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *ListUserDecisionsResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type PurgeUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserDataRequest) Reset() {
+	*x = PurgeUserDataRequest{}
+	mi := &file_proto_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserDataRequest) ProtoMessage() {}
+
+func (x *PurgeUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserDataRequest.ProtoReflect.Descriptor instead.
+func (*PurgeUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PurgeUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PurgeUserDataResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DecisionsDeleted uint64                 `protobuf:"varint,1,opt,name=decisions_deleted,json=decisionsDeleted,proto3" json:"decisions_deleted,omitempty"` // Decisions the user made or that were made on them
+	MatchesDeleted   uint64                 `protobuf:"varint,2,opt,name=matches_deleted,json=matchesDeleted,proto3" json:"matches_deleted,omitempty"`       // Matches of the user, counted once per match
+	BlocksDeleted    uint64                 `protobuf:"varint,3,opt,name=blocks_deleted,json=blocksDeleted,proto3" json:"blocks_deleted,omitempty"`          // Blocks the user placed or that were placed on them
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PurgeUserDataResponse) Reset() {
+	*x = PurgeUserDataResponse{}
+	mi := &file_proto_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserDataResponse) ProtoMessage() {}
+
+func (x *PurgeUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserDataResponse.ProtoReflect.Descriptor instead.
+func (*PurgeUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PurgeUserDataResponse) GetDecisionsDeleted() uint64 {
+	if x != nil {
+		return x.DecisionsDeleted
+	}
+	return 0
+}
+
+func (x *PurgeUserDataResponse) GetMatchesDeleted() uint64 {
+	if x != nil {
+		return x.MatchesDeleted
+	}
+	return 0
+}
+
+func (x *PurgeUserDataResponse) GetBlocksDeleted() uint64 {
+	if x != nil {
+		return x.BlocksDeleted
+	}
+	return 0
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=explore.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportUserDataRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_JSON
+}
+
+type ExportUserDataChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // The chunks of a stream make up the export document, in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataChunk) Reset() {
+	*x = ExportUserDataChunk{}
+	mi := &file_proto_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataChunk) ProtoMessage() {}
+
+func (x *ExportUserDataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataChunk.ProtoReflect.Descriptor instead.
+func (*ExportUserDataChunk) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportUserDataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_proto_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EraseUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EraseUserDataResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId             int64                  `protobuf:"varint,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"` // Identifies the erasure receipt, which keeps the SHA-256 hash of user_id instead of the ID
+	ErasedUnix            uint64                 `protobuf:"varint,2,opt,name=erased_unix,json=erasedUnix,proto3" json:"erased_unix,omitempty"`
+	DecisionsDeleted      uint64                 `protobuf:"varint,3,opt,name=decisions_deleted,json=decisionsDeleted,proto3" json:"decisions_deleted,omitempty"`                  // Decisions the user made or that were made on them
+	MatchesDeleted        uint64                 `protobuf:"varint,4,opt,name=matches_deleted,json=matchesDeleted,proto3" json:"matches_deleted,omitempty"`                        // Matches of the user, counted once per match
+	BlocksDeleted         uint64                 `protobuf:"varint,5,opt,name=blocks_deleted,json=blocksDeleted,proto3" json:"blocks_deleted,omitempty"`                           // Blocks the user placed or that were placed on them
+	ReportsAnonymized     uint64                 `protobuf:"varint,6,opt,name=reports_anonymized,json=reportsAnonymized,proto3" json:"reports_anonymized,omitempty"`               // Reports filed by or against the user
+	AuditEventsAnonymized uint64                 `protobuf:"varint,7,opt,name=audit_events_anonymized,json=auditEventsAnonymized,proto3" json:"audit_events_anonymized,omitempty"` // Audit events made by or concerning the user
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_proto_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EraseUserDataResponse) GetReceiptId() int64 {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetErasedUnix() uint64 {
+	if x != nil {
+		return x.ErasedUnix
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetDecisionsDeleted() uint64 {
+	if x != nil {
+		return x.DecisionsDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetMatchesDeleted() uint64 {
+	if x != nil {
+		return x.MatchesDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetBlocksDeleted() uint64 {
+	if x != nil {
+		return x.BlocksDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetReportsAnonymized() uint64 {
+	if x != nil {
+		return x.ReportsAnonymized
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetAuditEventsAnonymized() uint64 {
+	if x != nil {
+		return x.AuditEventsAnonymized
+	}
+	return 0
+}
+
+type InvalidateRecipientCacheRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *InvalidateRecipientCacheRequest) Reset() {
+	*x = InvalidateRecipientCacheRequest{}
+	mi := &file_proto_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateRecipientCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateRecipientCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateRecipientCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateRecipientCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateRecipientCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InvalidateRecipientCacheRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type InvalidateRecipientCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateRecipientCacheResponse) Reset() {
+	*x = InvalidateRecipientCacheResponse{}
+	mi := &file_proto_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateRecipientCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateRecipientCacheResponse) ProtoMessage() {}
+
+func (x *InvalidateRecipientCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateRecipientCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateRecipientCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type GetServiceStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceStatsRequest) Reset() {
+	*x = GetServiceStatsRequest{}
+	mi := &file_proto_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceStatsRequest) ProtoMessage() {}
+
+func (x *GetServiceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{10}
+}
+
+type GetServiceStatsResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Decisions                uint64                 `protobuf:"varint,1,opt,name=decisions,proto3" json:"decisions,omitempty"` // Estimated from the table statistics
+	Likes                    uint64                 `protobuf:"varint,2,opt,name=likes,proto3" json:"likes,omitempty"`         // Likes counted in the like counts, leaving out likes between blocked users
+	Matches                  uint64                 `protobuf:"varint,3,opt,name=matches,proto3" json:"matches,omitempty"`     // Estimated from the table statistics
+	Blocks                   uint64                 `protobuf:"varint,4,opt,name=blocks,proto3" json:"blocks,omitempty"`       // Estimated from the table statistics
+	PendingOutboxEvents      uint64                 `protobuf:"varint,5,opt,name=pending_outbox_events,json=pendingOutboxEvents,proto3" json:"pending_outbox_events,omitempty"`
+	DeadLetteredOutboxEvents uint64                 `protobuf:"varint,6,opt,name=dead_lettered_outbox_events,json=deadLetteredOutboxEvents,proto3" json:"dead_lettered_outbox_events,omitempty"`
+	UptimeSeconds            uint64                 `protobuf:"varint,7,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"` // Of the instance serving the call
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetServiceStatsResponse) Reset() {
+	*x = GetServiceStatsResponse{}
+	mi := &file_proto_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceStatsResponse) ProtoMessage() {}
+
+func (x *GetServiceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetServiceStatsResponse) GetDecisions() uint64 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *GetServiceStatsResponse) GetLikes() uint64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *GetServiceStatsResponse) GetMatches() uint64 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *GetServiceStatsResponse) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *GetServiceStatsResponse) GetPendingOutboxEvents() uint64 {
+	if x != nil {
+		return x.PendingOutboxEvents
+	}
+	return 0
+}
+
+func (x *GetServiceStatsResponse) GetDeadLetteredOutboxEvents() uint64 {
+	if x != nil {
+		return x.DeadLetteredOutboxEvents
+	}
+	return 0
+}
+
+func (x *GetServiceStatsResponse) GetUptimeSeconds() uint64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+type ListUserDecisionsResponse_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	UnixTimestamp   uint64                 `protobuf:"varint,4,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // When the decision was last changed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListUserDecisionsResponse_Decision) Reset() {
+	*x = ListUserDecisionsResponse_Decision{}
+	mi := &file_proto_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserDecisionsResponse_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserDecisionsResponse_Decision) ProtoMessage() {}
+
+func (x *ListUserDecisionsResponse_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserDecisionsResponse_Decision.ProtoReflect.Descriptor instead.
+func (*ListUserDecisionsResponse_Decision) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ListUserDecisionsResponse_Decision) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListUserDecisionsResponse_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ListUserDecisionsResponse_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ListUserDecisionsResponse_Decision) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+var File_proto_admin_proto protoreflect.FileDescriptor
+
+const file_proto_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/admin.proto\x12\aexplore\"\xa8\x01\n" +
+	"\x18ListUserDecisionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01B\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\xe6\x02\n" +
+	"\x19ListUserDecisionsResponse\x12I\n" +
+	"\tdecisions\x18\x01 \x03(\v2+.explore.ListUserDecisionsResponse.DecisionR\tdecisions\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1a\xaa\x01\n" +
+	"\bDecision\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12%\n" +
+	"\x0eunix_timestamp\x18\x04 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"/\n" +
+	"\x14PurgeUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x94\x01\n" +
+	"\x15PurgeUserDataResponse\x12+\n" +
+	"\x11decisions_deleted\x18\x01 \x01(\x04R\x10decisionsDeleted\x12'\n" +
+	"\x0fmatches_deleted\x18\x02 \x01(\x04R\x0ematchesDeleted\x12%\n" +
+	"\x0eblocks_deleted\x18\x03 \x01(\x04R\rblocksDeleted\"_\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x15.explore.ExportFormatR\x06format\")\n" +
+	"\x13ExportUserDataChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"/\n" +
+	"\x14EraseUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xbb\x02\n" +
+	"\x15EraseUserDataResponse\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x01 \x01(\x03R\treceiptId\x12\x1f\n" +
+	"\verased_unix\x18\x02 \x01(\x04R\n" +
+	"erasedUnix\x12+\n" +
+	"\x11decisions_deleted\x18\x03 \x01(\x04R\x10decisionsDeleted\x12'\n" +
+	"\x0fmatches_deleted\x18\x04 \x01(\x04R\x0ematchesDeleted\x12%\n" +
+	"\x0eblocks_deleted\x18\x05 \x01(\x04R\rblocksDeleted\x12-\n" +
+	"\x12reports_anonymized\x18\x06 \x01(\x04R\x11reportsAnonymized\x126\n" +
+	"\x17audit_events_anonymized\x18\a \x01(\x04R\x15auditEventsAnonymized\"M\n" +
+	"\x1fInvalidateRecipientCacheRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"\"\n" +
+	" InvalidateRecipientCacheResponse\"\x18\n" +
+	"\x16GetServiceStatsRequest\"\x99\x02\n" +
+	"\x17GetServiceStatsResponse\x12\x1c\n" +
+	"\tdecisions\x18\x01 \x01(\x04R\tdecisions\x12\x14\n" +
+	"\x05likes\x18\x02 \x01(\x04R\x05likes\x12\x18\n" +
+	"\amatches\x18\x03 \x01(\x04R\amatches\x12\x16\n" +
+	"\x06blocks\x18\x04 \x01(\x04R\x06blocks\x122\n" +
+	"\x15pending_outbox_events\x18\x05 \x01(\x04R\x13pendingOutboxEvents\x12=\n" +
+	"\x1bdead_lettered_outbox_events\x18\x06 \x01(\x04R\x18deadLetteredOutboxEvents\x12%\n" +
+	"\x0euptime_seconds\x18\a \x01(\x04R\ruptimeSeconds*!\n" +
+	"\fExportFormat\x12\b\n" +
+	"\x04JSON\x10\x00\x12\a\n" +
+	"\x03CSV\x10\x012\xa3\x04\n" +
+	"\fAdminService\x12Z\n" +
+	"\x11ListUserDecisions\x12!.explore.ListUserDecisionsRequest\x1a\".explore.ListUserDecisionsResponse\x12N\n" +
+	"\rPurgeUserData\x12\x1d.explore.PurgeUserDataRequest\x1a\x1e.explore.PurgeUserDataResponse\x12P\n" +
+	"\x0eExportUserData\x12\x1e.explore.ExportUserDataRequest\x1a\x1c.explore.ExportUserDataChunk0\x01\x12N\n" +
+	"\rEraseUserData\x12\x1d.explore.EraseUserDataRequest\x1a\x1e.explore.EraseUserDataResponse\x12o\n" +
+	"\x18InvalidateRecipientCache\x12(.explore.InvalidateRecipientCacheRequest\x1a).explore.InvalidateRecipientCacheResponse\x12T\n" +
+	"\x0fGetServiceStats\x12\x1f.explore.GetServiceStatsRequest\x1a .explore.GetServiceStatsResponseB)Z'github.com/backend-interview-task/protob\x06proto3"
+
+var (
+	file_proto_admin_proto_rawDescOnce sync.Once
+	file_proto_admin_proto_rawDescData []byte
+)
+
+func file_proto_admin_proto_rawDescGZIP() []byte {
+	file_proto_admin_proto_rawDescOnce.Do(func() {
+		file_proto_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_admin_proto_rawDesc), len(file_proto_admin_proto_rawDesc)))
+	})
+	return file_proto_admin_proto_rawDescData
+}
+
+var file_proto_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_admin_proto_goTypes = []any{
+	(ExportFormat)(0),                          // 0: explore.ExportFormat
+	(*ListUserDecisionsRequest)(nil),           // 1: explore.ListUserDecisionsRequest
+	(*ListUserDecisionsResponse)(nil),          // 2: explore.ListUserDecisionsResponse
+	(*PurgeUserDataRequest)(nil),               // 3: explore.PurgeUserDataRequest
+	(*PurgeUserDataResponse)(nil),              // 4: explore.PurgeUserDataResponse
+	(*ExportUserDataRequest)(nil),              // 5: explore.ExportUserDataRequest
+	(*ExportUserDataChunk)(nil),                // 6: explore.ExportUserDataChunk
+	(*EraseUserDataRequest)(nil),               // 7: explore.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),              // 8: explore.EraseUserDataResponse
+	(*InvalidateRecipientCacheRequest)(nil),    // 9: explore.InvalidateRecipientCacheRequest
+	(*InvalidateRecipientCacheResponse)(nil),   // 10: explore.InvalidateRecipientCacheResponse
+	(*GetServiceStatsRequest)(nil),             // 11: explore.GetServiceStatsRequest
+	(*GetServiceStatsResponse)(nil),            // 12: explore.GetServiceStatsResponse
+	(*ListUserDecisionsResponse_Decision)(nil), // 13: explore.ListUserDecisionsResponse.Decision
+}
+var file_proto_admin_proto_depIdxs = []int32{
+	13, // 0: explore.ListUserDecisionsResponse.decisions:type_name -> explore.ListUserDecisionsResponse.Decision
+	0,  // 1: explore.ExportUserDataRequest.format:type_name -> explore.ExportFormat
+	1,  // 2: explore.AdminService.ListUserDecisions:input_type -> explore.ListUserDecisionsRequest
+	3,  // 3: explore.AdminService.PurgeUserData:input_type -> explore.PurgeUserDataRequest
+	5,  // 4: explore.AdminService.ExportUserData:input_type -> explore.ExportUserDataRequest
+	7,  // 5: explore.AdminService.EraseUserData:input_type -> explore.EraseUserDataRequest
+	9,  // 6: explore.AdminService.InvalidateRecipientCache:input_type -> explore.InvalidateRecipientCacheRequest
+	11, // 7: explore.AdminService.GetServiceStats:input_type -> explore.GetServiceStatsRequest
+	2,  // 8: explore.AdminService.ListUserDecisions:output_type -> explore.ListUserDecisionsResponse
+	4,  // 9: explore.AdminService.PurgeUserData:output_type -> explore.PurgeUserDataResponse
+	6,  // 10: explore.AdminService.ExportUserData:output_type -> explore.ExportUserDataChunk
+	8,  // 11: explore.AdminService.EraseUserData:output_type -> explore.EraseUserDataResponse
+	10, // 12: explore.AdminService.InvalidateRecipientCache:output_type -> explore.InvalidateRecipientCacheResponse
+	12, // 13: explore.AdminService.GetServiceStats:output_type -> explore.GetServiceStatsResponse
+	8,  // [8:14] is the sub-list for method output_type
+	2,  // [2:8] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_admin_proto_init() }
+func file_proto_admin_proto_init() {
+	if File_proto_admin_proto != nil {
+		return
+	}
+	file_proto_admin_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_admin_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_admin_proto_rawDesc), len(file_proto_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_admin_proto_goTypes,
+		DependencyIndexes: file_proto_admin_proto_depIdxs,
+		EnumInfos:         file_proto_admin_proto_enumTypes,
+		MessageInfos:      file_proto_admin_proto_msgTypes,
+	}.Build()
+	File_proto_admin_proto = out.File
+	file_proto_admin_proto_goTypes = nil
+	file_proto_admin_proto_depIdxs = nil
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,10 +22,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SortOrder int32
+
+const (
+	SortOrder_NEWEST_FIRST SortOrder = 0
+	SortOrder_OLDEST_FIRST SortOrder = 1
+	SortOrder_MOST_POPULAR SortOrder = 2 // Likers with the highest popularity score first, only supported when listing all likers
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "NEWEST_FIRST",
+		1: "OLDEST_FIRST",
+		2: "MOST_POPULAR",
+	}
+	SortOrder_value = map[string]int32{
+		"NEWEST_FIRST": 0,
+		"OLDEST_FIRST": 1,
+		"MOST_POPULAR": 2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_explore_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_proto_explore_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{0}
+}
+
 type ListLikedYouRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
 	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                     // Overrides the page size carried by pagination_token
+	SinceUnix       *uint64                `protobuf:"varint,4,opt,name=since_unix,json=sinceUnix,proto3,oneof" json:"since_unix,omitempty"`                  // Only include likes created at or after this time
+	UntilUnix       *uint64                `protobuf:"varint,5,opt,name=until_unix,json=untilUnix,proto3,oneof" json:"until_unix,omitempty"`                  // Only include likes created before this time
+	SortOrder       SortOrder              `protobuf:"varint,6,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"` // Ignored when pagination_token is set, the token keeps the order of the first page
+	ReadMask        *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`                            // Response fields to return, e.g. "likers.actor_id" or "next_pagination_token". All fields when empty. Only trims the response: the page is read in full either way
+	Page            *uint32                `protobuf:"varint,8,opt,name=page,proto3,oneof" json:"page,omitempty"`                                             // 1-based page number for offset pagination, when enabled; excludes pagination_token and the response has no tokens
+	IncludePassed   bool                   `protobuf:"varint,9,opt,name=include_passed,json=includePassed,proto3" json:"include_passed,omitempty"`            // ListNewLikedYou only: also list likers the recipient passed on, who are left out by default
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -73,10 +130,60 @@ func (x *ListLikedYouRequest) GetPaginationToken() string {
 	return ""
 }
 
+func (x *ListLikedYouRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetSinceUnix() uint64 {
+	if x != nil && x.SinceUnix != nil {
+		return *x.SinceUnix
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetUntilUnix() uint64 {
+	if x != nil && x.UntilUnix != nil {
+		return *x.UntilUnix
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_NEWEST_FIRST
+}
+
+func (x *ListLikedYouRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListLikedYouRequest) GetPage() uint32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetIncludePassed() bool {
+	if x != nil {
+		return x.IncludePassed
+	}
+	return false
+}
+
 type ListLikedYouResponse struct {
 	state               protoimpl.MessageState        `protogen:"open.v1"`
 	Likers              []*ListLikedYouResponse_Liker `protobuf:"bytes,1,rep,name=likers,proto3" json:"likers,omitempty"`
 	NextPaginationToken *string                       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	PrevPaginationToken *string                       `protobuf:"bytes,3,opt,name=prev_pagination_token,json=prevPaginationToken,proto3,oneof" json:"prev_pagination_token,omitempty"` // Unset on the first page
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -125,6 +232,117 @@ func (x *ListLikedYouResponse) GetNextPaginationToken() string {
 	return ""
 }
 
+func (x *ListLikedYouResponse) GetPrevPaginationToken() string {
+	if x != nil && x.PrevPaginationToken != nil {
+		return *x.PrevPaginationToken
+	}
+	return ""
+}
+
+type ListLikedByYouRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListLikedByYouRequest) Reset() {
+	*x = ListLikedByYouRequest{}
+	mi := &file_proto_explore_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedByYouRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedByYouRequest) ProtoMessage() {}
+
+func (x *ListLikedByYouRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedByYouRequest.ProtoReflect.Descriptor instead.
+func (*ListLikedByYouRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListLikedByYouRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListLikedByYouRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+type ListLikedByYouResponse struct {
+	state               protoimpl.MessageState              `protogen:"open.v1"`
+	Recipients          []*ListLikedByYouResponse_Recipient `protobuf:"bytes,1,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	NextPaginationToken *string                             `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListLikedByYouResponse) Reset() {
+	*x = ListLikedByYouResponse{}
+	mi := &file_proto_explore_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedByYouResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedByYouResponse) ProtoMessage() {}
+
+func (x *ListLikedByYouResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedByYouResponse.ProtoReflect.Descriptor instead.
+func (*ListLikedByYouResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListLikedByYouResponse) GetRecipients() []*ListLikedByYouResponse_Recipient {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *ListLikedByYouResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
 type CountLikedYouRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
@@ -134,7 +352,7 @@ type CountLikedYouRequest struct {
 
 func (x *CountLikedYouRequest) Reset() {
 	*x = CountLikedYouRequest{}
-	mi := &file_proto_explore_proto_msgTypes[2]
+	mi := &file_proto_explore_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +364,7 @@ func (x *CountLikedYouRequest) String() string {
 func (*CountLikedYouRequest) ProtoMessage() {}
 
 func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_proto_msgTypes[2]
+	mi := &file_proto_explore_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +377,7 @@ func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouRequest.ProtoReflect.Descriptor instead.
 func (*CountLikedYouRequest) Descriptor() ([]byte, []int) {
-	return file_proto_explore_proto_rawDescGZIP(), []int{2}
+	return file_proto_explore_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CountLikedYouRequest) GetRecipientUserId() string {
@@ -178,7 +396,7 @@ type CountLikedYouResponse struct {
 
 func (x *CountLikedYouResponse) Reset() {
 	*x = CountLikedYouResponse{}
-	mi := &file_proto_explore_proto_msgTypes[3]
+	mi := &file_proto_explore_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -190,7 +408,7 @@ func (x *CountLikedYouResponse) String() string {
 func (*CountLikedYouResponse) ProtoMessage() {}
 
 func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_proto_msgTypes[3]
+	mi := &file_proto_explore_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +421,7 @@ func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouResponse.ProtoReflect.Descriptor instead.
 func (*CountLikedYouResponse) Descriptor() ([]byte, []int) {
-	return file_proto_explore_proto_rawDescGZIP(), []int{3}
+	return file_proto_explore_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CountLikedYouResponse) GetCount() uint64 {
@@ -224,7 +442,7 @@ type PutDecisionRequest struct {
 
 func (x *PutDecisionRequest) Reset() {
 	*x = PutDecisionRequest{}
-	mi := &file_proto_explore_proto_msgTypes[4]
+	mi := &file_proto_explore_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -236,7 +454,7 @@ func (x *PutDecisionRequest) String() string {
 func (*PutDecisionRequest) ProtoMessage() {}
 
 func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_proto_msgTypes[4]
+	mi := &file_proto_explore_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +467,7 @@ func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionRequest.ProtoReflect.Descriptor instead.
 func (*PutDecisionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_explore_proto_rawDescGZIP(), []int{4}
+	return file_proto_explore_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PutDecisionRequest) GetActorUserId() string {
@@ -282,7 +500,7 @@ type PutDecisionResponse struct {
 
 func (x *PutDecisionResponse) Reset() {
 	*x = PutDecisionResponse{}
-	mi := &file_proto_explore_proto_msgTypes[5]
+	mi := &file_proto_explore_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -294,7 +512,7 @@ func (x *PutDecisionResponse) String() string {
 func (*PutDecisionResponse) ProtoMessage() {}
 
 func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_proto_msgTypes[5]
+	mi := &file_proto_explore_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,7 +525,7 @@ func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionResponse.ProtoReflect.Descriptor instead.
 func (*PutDecisionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_explore_proto_rawDescGZIP(), []int{5}
+	return file_proto_explore_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PutDecisionResponse) GetMutualLikes() bool {
@@ -317,29 +535,29 @@ func (x *PutDecisionResponse) GetMutualLikes() bool {
 	return false
 }
 
-type ListLikedYouResponse_Liker struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
-	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetDecisionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ListLikedYouResponse_Liker) Reset() {
-	*x = ListLikedYouResponse_Liker{}
-	mi := &file_proto_explore_proto_msgTypes[6]
+func (x *GetDecisionRequest) Reset() {
+	*x = GetDecisionRequest{}
+	mi := &file_proto_explore_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListLikedYouResponse_Liker) String() string {
+func (x *GetDecisionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+func (*GetDecisionRequest) ProtoMessage() {}
 
-func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_proto_msgTypes[6]
+func (x *GetDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,56 +568,1754 @@ func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
-func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
-	return file_proto_explore_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use GetDecisionRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListLikedYouResponse_Liker) GetActorId() string {
+func (x *GetDecisionRequest) GetActorUserId() string {
 	if x != nil {
-		return x.ActorId
+		return x.ActorUserId
 	}
 	return ""
 }
 
-func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+func (x *GetDecisionRequest) GetRecipientUserId() string {
 	if x != nil {
-		return x.UnixTimestamp
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type GetDecisionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LikedRecipient bool                   `protobuf:"varint,1,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	CreatedAtUnix  uint64                 `protobuf:"varint,2,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	UpdatedAtUnix  uint64                 `protobuf:"varint,3,opt,name=updated_at_unix,json=updatedAtUnix,proto3" json:"updated_at_unix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDecisionResponse) Reset() {
+	*x = GetDecisionResponse{}
+	mi := &file_proto_explore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionResponse) ProtoMessage() {}
+
+func (x *GetDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetDecisionResponse) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *GetDecisionResponse) GetCreatedAtUnix() uint64 {
+	if x != nil {
+		return x.CreatedAtUnix
 	}
 	return 0
 }
 
-var File_proto_explore_proto protoreflect.FileDescriptor
+func (x *GetDecisionResponse) GetUpdatedAtUnix() uint64 {
+	if x != nil {
+		return x.UpdatedAtUnix
+	}
+	return 0
+}
 
-const file_proto_explore_proto_rawDesc = "" +
-	"\n" +
-	"\x13proto/explore.proto\x12\aexplore\"\x86\x01\n" +
-	"\x13ListLikedYouRequest\x12*\n" +
-	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
-	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
-	"\x11_pagination_token\"\xf1\x01\n" +
-	"\x14ListLikedYouResponse\x12;\n" +
-	"\x06likers\x18\x01 \x03(\v2#.explore.ListLikedYouResponse.LikerR\x06likers\x127\n" +
-	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aI\n" +
-	"\x05Liker\x12\x19\n" +
-	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
-	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
-	"\x16_next_pagination_token\"B\n" +
-	"\x14CountLikedYouRequest\x12*\n" +
-	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"-\n" +
-	"\x15CountLikedYouResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x04R\x05count\"\x8d\x01\n" +
-	"\x12PutDecisionRequest\x12\"\n" +
-	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
-	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
-	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\"8\n" +
-	"\x13PutDecisionResponse\x12!\n" +
-	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes2\xc7\x02\n" +
-	"\x0eExploreService\x12K\n" +
-	"\fListLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
-	"\x0fListNewLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
-	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12H\n" +
-	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponseB)Z'github.com/backend-interview-task/protob\x06proto3"
+type DeleteDecisionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteDecisionRequest) Reset() {
+	*x = DeleteDecisionRequest{}
+	mi := &file_proto_explore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDecisionRequest) ProtoMessage() {}
+
+func (x *DeleteDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDecisionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *DeleteDecisionRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type DeleteDecisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WasMutualLike bool                   `protobuf:"varint,1,opt,name=was_mutual_like,json=wasMutualLike,proto3" json:"was_mutual_like,omitempty"` // True if the withdrawn decision broke a mutual like
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDecisionResponse) Reset() {
+	*x = DeleteDecisionResponse{}
+	mi := &file_proto_explore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDecisionResponse) ProtoMessage() {}
+
+func (x *DeleteDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDecisionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteDecisionResponse) GetWasMutualLike() bool {
+	if x != nil {
+		return x.WasMutualLike
+	}
+	return false
+}
+
+type BatchPutDecisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decisions     []*PutDecisionRequest  `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionsRequest) Reset() {
+	*x = BatchPutDecisionsRequest{}
+	mi := &file_proto_explore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionsRequest) ProtoMessage() {}
+
+func (x *BatchPutDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchPutDecisionsRequest) GetDecisions() []*PutDecisionRequest {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type BatchPutDecisionsResponse struct {
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	Results       []*BatchPutDecisionsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // In the same order as the request decisions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionsResponse) Reset() {
+	*x = BatchPutDecisionsResponse{}
+	mi := &file_proto_explore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionsResponse) ProtoMessage() {}
+
+func (x *BatchPutDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchPutDecisionsResponse) GetResults() []*BatchPutDecisionsResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ListMatchesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMatchesRequest) Reset() {
+	*x = ListMatchesRequest{}
+	mi := &file_proto_explore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesRequest) ProtoMessage() {}
+
+func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+type ListMatchesResponse struct {
+	state               protoimpl.MessageState       `protogen:"open.v1"`
+	Matches             []*ListMatchesResponse_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	NextPaginationToken *string                      `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse) Reset() {
+	*x = ListMatchesResponse{}
+	mi := &file_proto_explore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse) ProtoMessage() {}
+
+func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMatchesResponse) GetMatches() []*ListMatchesResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ListMatchesResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type PutDecisionsSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       uint64                 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`                            // Decisions recorded for the first time
+	Updated       uint64                 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`                            // Decisions that replaced an earlier one
+	MutualLikes   uint64                 `protobuf:"varint,3,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // Likes that resulted in a mutual like
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutDecisionsSummary) Reset() {
+	*x = PutDecisionsSummary{}
+	mi := &file_proto_explore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsSummary) ProtoMessage() {}
+
+func (x *PutDecisionsSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsSummary.ProtoReflect.Descriptor instead.
+func (*PutDecisionsSummary) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PutDecisionsSummary) GetCreated() uint64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *PutDecisionsSummary) GetUpdated() uint64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *PutDecisionsSummary) GetMutualLikes() uint64 {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return 0
+}
+
+type WatchNewLikesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchNewLikesRequest) Reset() {
+	*x = WatchNewLikesRequest{}
+	mi := &file_proto_explore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNewLikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNewLikesRequest) ProtoMessage() {}
+
+func (x *WatchNewLikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNewLikesRequest.ProtoReflect.Descriptor instead.
+func (*WatchNewLikesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchNewLikesRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type WatchNewLikesEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Events are delivered at least once; the same event_id means the same like
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchNewLikesEvent) Reset() {
+	*x = WatchNewLikesEvent{}
+	mi := &file_proto_explore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNewLikesEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNewLikesEvent) ProtoMessage() {}
+
+func (x *WatchNewLikesEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNewLikesEvent.ProtoReflect.Descriptor instead.
+func (*WatchNewLikesEvent) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchNewLikesEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *WatchNewLikesEvent) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *WatchNewLikesEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type ListPassedYouRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"` // Overrides the page size carried by pagination_token
+	Page            *uint32                `protobuf:"varint,4,opt,name=page,proto3,oneof" json:"page,omitempty"`                         // 1-based page number for offset pagination, when enabled; excludes pagination_token and the response has no tokens
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListPassedYouRequest) Reset() {
+	*x = ListPassedYouRequest{}
+	mi := &file_proto_explore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassedYouRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassedYouRequest) ProtoMessage() {}
+
+func (x *ListPassedYouRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassedYouRequest.ProtoReflect.Descriptor instead.
+func (*ListPassedYouRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPassedYouRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ListPassedYouRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListPassedYouRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPassedYouRequest) GetPage() uint32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+type ListPassedYouResponse struct {
+	state               protoimpl.MessageState          `protogen:"open.v1"`
+	Passers             []*ListPassedYouResponse_Passer `protobuf:"bytes,1,rep,name=passers,proto3" json:"passers,omitempty"`
+	NextPaginationToken *string                         `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListPassedYouResponse) Reset() {
+	*x = ListPassedYouResponse{}
+	mi := &file_proto_explore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassedYouResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassedYouResponse) ProtoMessage() {}
+
+func (x *ListPassedYouResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassedYouResponse.ProtoReflect.Descriptor instead.
+func (*ListPassedYouResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPassedYouResponse) GetPassers() []*ListPassedYouResponse_Passer {
+	if x != nil {
+		return x.Passers
+	}
+	return nil
+}
+
+func (x *ListPassedYouResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type CheckMutualLikeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OtherUserId   string                 `protobuf:"bytes,2,opt,name=other_user_id,json=otherUserId,proto3" json:"other_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckMutualLikeRequest) Reset() {
+	*x = CheckMutualLikeRequest{}
+	mi := &file_proto_explore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckMutualLikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckMutualLikeRequest) ProtoMessage() {}
+
+func (x *CheckMutualLikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckMutualLikeRequest.ProtoReflect.Descriptor instead.
+func (*CheckMutualLikeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CheckMutualLikeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckMutualLikeRequest) GetOtherUserId() string {
+	if x != nil {
+		return x.OtherUserId
+	}
+	return ""
+}
+
+type CheckMutualLikeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MutualLike    bool                   `protobuf:"varint,1,opt,name=mutual_like,json=mutualLike,proto3" json:"mutual_like,omitempty"` // True if both users like each other
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckMutualLikeResponse) Reset() {
+	*x = CheckMutualLikeResponse{}
+	mi := &file_proto_explore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckMutualLikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckMutualLikeResponse) ProtoMessage() {}
+
+func (x *CheckMutualLikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckMutualLikeResponse.ProtoReflect.Descriptor instead.
+func (*CheckMutualLikeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CheckMutualLikeResponse) GetMutualLike() bool {
+	if x != nil {
+		return x.MutualLike
+	}
+	return false
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerUserId string                 `protobuf:"bytes,1,opt,name=blocker_user_id,json=blockerUserId,proto3" json:"blocker_user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_proto_explore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BlockUserRequest) GetBlockerUserId() string {
+	if x != nil {
+		return x.BlockerUserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_proto_explore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{24}
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerUserId string                 `protobuf:"bytes,1,opt,name=blocker_user_id,json=blockerUserId,proto3" json:"blocker_user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_proto_explore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnblockUserRequest) GetBlockerUserId() string {
+	if x != nil {
+		return x.BlockerUserId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_proto_explore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{26}
+}
+
+type ReportUserRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReporterUserId string                 `protobuf:"bytes,1,opt,name=reporter_user_id,json=reporterUserId,proto3" json:"reporter_user_id,omitempty"`
+	ReportedUserId string                 `protobuf:"bytes,2,opt,name=reported_user_id,json=reportedUserId,proto3" json:"reported_user_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReportUserRequest) Reset() {
+	*x = ReportUserRequest{}
+	mi := &file_proto_explore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserRequest) ProtoMessage() {}
+
+func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserRequest.ProtoReflect.Descriptor instead.
+func (*ReportUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReportUserRequest) GetReporterUserId() string {
+	if x != nil {
+		return x.ReporterUserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReportedUserId() string {
+	if x != nil {
+		return x.ReportedUserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReportUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      int64                  `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportUserResponse) Reset() {
+	*x = ReportUserResponse{}
+	mi := &file_proto_explore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserResponse) ProtoMessage() {}
+
+func (x *ReportUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserResponse.ProtoReflect.Descriptor instead.
+func (*ReportUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReportUserResponse) GetReportId() int64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+type ListAuditEventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          *string                `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`           // Only include events made by or about this user
+	Action          *string                `protobuf:"bytes,2,opt,name=action,proto3,oneof" json:"action,omitempty"`                         // Only include events of this action, e.g. "PutDecision"
+	SinceUnix       *uint64                `protobuf:"varint,3,opt,name=since_unix,json=sinceUnix,proto3,oneof" json:"since_unix,omitempty"` // Only include events recorded at or after this time
+	UntilUnix       *uint64                `protobuf:"varint,4,opt,name=until_unix,json=untilUnix,proto3,oneof" json:"until_unix,omitempty"` // Only include events recorded before this time
+	PaginationToken *string                `protobuf:"bytes,5,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"` // Overrides the page size carried by pagination_token
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_explore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListAuditEventsRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil && x.Action != nil {
+		return *x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSinceUnix() uint64 {
+	if x != nil && x.SinceUnix != nil {
+		return *x.SinceUnix
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetUntilUnix() uint64 {
+	if x != nil && x.UntilUnix != nil {
+		return *x.UntilUnix
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state               protoimpl.MessageState                `protogen:"open.v1"`
+	Events              []*ListAuditEventsResponse_AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Newest first
+	NextPaginationToken *string                               `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_proto_explore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*ListAuditEventsResponse_AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type ListLikedYouResponse_Liker struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ActorId          string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp    uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	AlreadyLikedBack bool                   `protobuf:"varint,3,opt,name=already_liked_back,json=alreadyLikedBack,proto3" json:"already_liked_back,omitempty"` // True if the recipient has liked the actor in return
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListLikedYouResponse_Liker) Reset() {
+	*x = ListLikedYouResponse_Liker{}
+	mi := &file_proto_explore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouResponse_Liker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+
+func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ListLikedYouResponse_Liker) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListLikedYouResponse_Liker) GetAlreadyLikedBack() bool {
+	if x != nil {
+		return x.AlreadyLikedBack
+	}
+	return false
+}
+
+type ListLikedByYouResponse_Recipient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikedByYouResponse_Recipient) Reset() {
+	*x = ListLikedByYouResponse_Recipient{}
+	mi := &file_proto_explore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedByYouResponse_Recipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedByYouResponse_Recipient) ProtoMessage() {}
+
+func (x *ListLikedByYouResponse_Recipient) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedByYouResponse_Recipient.ProtoReflect.Descriptor instead.
+func (*ListLikedByYouResponse_Recipient) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *ListLikedByYouResponse_Recipient) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+func (x *ListLikedByYouResponse_Recipient) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type BatchPutDecisionsResponse_Result struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	MutualLikes     bool                   `protobuf:"varint,3,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // True if both users like each other
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionsResponse_Result) Reset() {
+	*x = BatchPutDecisionsResponse_Result{}
+	mi := &file_proto_explore_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionsResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionsResponse_Result) ProtoMessage() {}
+
+func (x *BatchPutDecisionsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionsResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *BatchPutDecisionsResponse_Result) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *BatchPutDecisionsResponse_Result) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *BatchPutDecisionsResponse_Result) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+type ListMatchesResponse_Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MatchedAtUnix uint64                 `protobuf:"varint,2,opt,name=matched_at_unix,json=matchedAtUnix,proto3" json:"matched_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse_Match) Reset() {
+	*x = ListMatchesResponse_Match{}
+	mi := &file_proto_explore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse_Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse_Match) ProtoMessage() {}
+
+func (x *ListMatchesResponse_Match) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse_Match.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse_Match) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{15, 0}
+}
+
+func (x *ListMatchesResponse_Match) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesResponse_Match) GetMatchedAtUnix() uint64 {
+	if x != nil {
+		return x.MatchedAtUnix
+	}
+	return 0
+}
+
+type ListPassedYouResponse_Passer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassedYouResponse_Passer) Reset() {
+	*x = ListPassedYouResponse_Passer{}
+	mi := &file_proto_explore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassedYouResponse_Passer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassedYouResponse_Passer) ProtoMessage() {}
+
+func (x *ListPassedYouResponse_Passer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassedYouResponse_Passer.ProtoReflect.Descriptor instead.
+func (*ListPassedYouResponse_Passer) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *ListPassedYouResponse_Passer) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListPassedYouResponse_Passer) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type ListAuditEventsResponse_AuditEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UnixTimestamp   uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	Action          string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	CallerId        string                 `protobuf:"bytes,4,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"` // The authenticated user or service, empty when authentication is disabled
+	CallerIsService bool                   `protobuf:"varint,5,opt,name=caller_is_service,json=callerIsService,proto3" json:"caller_is_service,omitempty"`
+	ClientAddress   string                 `protobuf:"bytes,6,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"`
+	RequestId       string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserIds         []string               `protobuf:"bytes,8,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`              // The users the call was about
+	Outcome         string                 `protobuf:"bytes,9,opt,name=outcome,proto3" json:"outcome,omitempty"`                             // The gRPC code the call ended with, e.g. "OK"
+	DetailsJson     string                 `protobuf:"bytes,10,opt,name=details_json,json=detailsJson,proto3" json:"details_json,omitempty"` // The call's request as JSON
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) Reset() {
+	*x = ListAuditEventsResponse_AuditEvent{}
+	mi := &file_proto_explore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse_AuditEvent) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse_AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse_AuditEvent.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse_AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_explore_proto_rawDescGZIP(), []int{30, 0}
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetCallerIsService() bool {
+	if x != nil {
+		return x.CallerIsService
+	}
+	return false
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetClientAddress() string {
+	if x != nil {
+		return x.ClientAddress
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse_AuditEvent) GetDetailsJson() string {
+	if x != nil {
+		return x.DetailsJson
+	}
+	return ""
+}
+
+var File_proto_explore_proto protoreflect.FileDescriptor
+
+const file_proto_explore_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/explore.proto\x12\aexplore\x1a google/protobuf/field_mask.proto\"\xd1\x03\n" +
+	"\x13ListLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"since_unix\x18\x04 \x01(\x04H\x02R\tsinceUnix\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"until_unix\x18\x05 \x01(\x04H\x03R\tuntilUnix\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x06 \x01(\x0e2\x12.explore.SortOrderR\tsortOrder\x127\n" +
+	"\tread_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x17\n" +
+	"\x04page\x18\b \x01(\rH\x04R\x04page\x88\x01\x01\x12%\n" +
+	"\x0einclude_passed\x18\t \x01(\bR\rincludePassedB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_since_unixB\r\n" +
+	"\v_until_unixB\a\n" +
+	"\x05_page\"\xf2\x02\n" +
+	"\x14ListLikedYouResponse\x12;\n" +
+	"\x06likers\x18\x01 \x03(\v2#.explore.ListLikedYouResponse.LikerR\x06likers\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x127\n" +
+	"\x15prev_pagination_token\x18\x03 \x01(\tH\x01R\x13prevPaginationToken\x88\x01\x01\x1aw\n" +
+	"\x05Liker\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestamp\x12,\n" +
+	"\x12already_liked_back\x18\x03 \x01(\bR\x10alreadyLikedBackB\x18\n" +
+	"\x16_next_pagination_tokenB\x18\n" +
+	"\x16_prev_pagination_token\"\x80\x01\n" +
+	"\x15ListLikedByYouRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
+	"\x11_pagination_token\"\x8d\x02\n" +
+	"\x16ListLikedByYouResponse\x12I\n" +
+	"\n" +
+	"recipients\x18\x01 \x03(\v2).explore.ListLikedByYouResponse.RecipientR\n" +
+	"recipients\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aU\n" +
+	"\tRecipient\x12!\n" +
+	"\frecipient_id\x18\x01 \x01(\tR\vrecipientId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"B\n" +
+	"\x14CountLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"-\n" +
+	"\x15CountLikedYouResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\"\x8d\x01\n" +
+	"\x12PutDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\"8\n" +
+	"\x13PutDecisionResponse\x12!\n" +
+	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes\"d\n" +
+	"\x12GetDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\"\x8e\x01\n" +
+	"\x13GetDecisionResponse\x12'\n" +
+	"\x0fliked_recipient\x18\x01 \x01(\bR\x0elikedRecipient\x12&\n" +
+	"\x0fcreated_at_unix\x18\x02 \x01(\x04R\rcreatedAtUnix\x12&\n" +
+	"\x0fupdated_at_unix\x18\x03 \x01(\x04R\rupdatedAtUnix\"g\n" +
+	"\x15DeleteDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\"@\n" +
+	"\x16DeleteDecisionResponse\x12&\n" +
+	"\x0fwas_mutual_like\x18\x01 \x01(\bR\rwasMutualLike\"U\n" +
+	"\x18BatchPutDecisionsRequest\x129\n" +
+	"\tdecisions\x18\x01 \x03(\v2\x1b.explore.PutDecisionRequestR\tdecisions\"\xdd\x01\n" +
+	"\x19BatchPutDecisionsResponse\x12C\n" +
+	"\aresults\x18\x01 \x03(\v2).explore.BatchPutDecisionsResponse.ResultR\aresults\x1a{\n" +
+	"\x06Result\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12!\n" +
+	"\fmutual_likes\x18\x03 \x01(\bR\vmutualLikes\"r\n" +
+	"\x12ListMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
+	"\x11_pagination_token\"\xf0\x01\n" +
+	"\x13ListMatchesResponse\x12<\n" +
+	"\amatches\x18\x01 \x03(\v2\".explore.ListMatchesResponse.MatchR\amatches\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aH\n" +
+	"\x05Match\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fmatched_at_unix\x18\x02 \x01(\x04R\rmatchedAtUnixB\x18\n" +
+	"\x16_next_pagination_token\"l\n" +
+	"\x13PutDecisionsSummary\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x04R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x04R\aupdated\x12!\n" +
+	"\fmutual_likes\x18\x03 \x01(\x04R\vmutualLikes\"B\n" +
+	"\x14WatchNewLikesRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"q\n" +
+	"\x12WatchNewLikesEvent\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestamp\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\"\xd9\x01\n" +
+	"\x14ListPassedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x12\x17\n" +
+	"\x04page\x18\x04 \x01(\rH\x02R\x04page\x88\x01\x01B\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_sizeB\a\n" +
+	"\x05_page\"\xf7\x01\n" +
+	"\x15ListPassedYouResponse\x12?\n" +
+	"\apassers\x18\x01 \x03(\v2%.explore.ListPassedYouResponse.PasserR\apassers\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aJ\n" +
+	"\x06Passer\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"U\n" +
+	"\x16CheckMutualLikeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\rother_user_id\x18\x02 \x01(\tR\votherUserId\":\n" +
+	"\x17CheckMutualLikeResponse\x12\x1f\n" +
+	"\vmutual_like\x18\x01 \x01(\bR\n" +
+	"mutualLike\"b\n" +
+	"\x10BlockUserRequest\x12&\n" +
+	"\x0fblocker_user_id\x18\x01 \x01(\tR\rblockerUserId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x13\n" +
+	"\x11BlockUserResponse\"d\n" +
+	"\x12UnblockUserRequest\x12&\n" +
+	"\x0fblocker_user_id\x18\x01 \x01(\tR\rblockerUserId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x15\n" +
+	"\x13UnblockUserResponse\"\x7f\n" +
+	"\x11ReportUserRequest\x12(\n" +
+	"\x10reporter_user_id\x18\x01 \x01(\tR\x0ereporterUserId\x12(\n" +
+	"\x10reported_user_id\x18\x02 \x01(\tR\x0ereportedUserId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"1\n" +
+	"\x12ReportUserResponse\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\x03R\breportId\"\xc5\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x1c\n" +
+	"\auser_id\x18\x01 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x1b\n" +
+	"\x06action\x18\x02 \x01(\tH\x01R\x06action\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"since_unix\x18\x03 \x01(\x04H\x02R\tsinceUnix\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"until_unix\x18\x04 \x01(\x04H\x03R\tuntilUnix\x88\x01\x01\x12.\n" +
+	"\x10pagination_token\x18\x05 \x01(\tH\x04R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x06 \x01(\rH\x05R\bpageSize\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\t\n" +
+	"\a_actionB\r\n" +
+	"\v_since_unixB\r\n" +
+	"\v_until_unixB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\xf6\x03\n" +
+	"\x17ListAuditEventsResponse\x12C\n" +
+	"\x06events\x18\x01 \x03(\v2+.explore.ListAuditEventsResponse.AuditEventR\x06events\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1a\xc2\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestamp\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1b\n" +
+	"\tcaller_id\x18\x04 \x01(\tR\bcallerId\x12*\n" +
+	"\x11caller_is_service\x18\x05 \x01(\bR\x0fcallerIsService\x12%\n" +
+	"\x0eclient_address\x18\x06 \x01(\tR\rclientAddress\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x19\n" +
+	"\buser_ids\x18\b \x03(\tR\auserIds\x12\x18\n" +
+	"\aoutcome\x18\t \x01(\tR\aoutcome\x12!\n" +
+	"\fdetails_json\x18\n" +
+	" \x01(\tR\vdetailsJsonB\x18\n" +
+	"\x16_next_pagination_token*A\n" +
+	"\tSortOrder\x12\x10\n" +
+	"\fNEWEST_FIRST\x10\x00\x12\x10\n" +
+	"\fOLDEST_FIRST\x10\x01\x12\x10\n" +
+	"\fMOST_POPULAR\x10\x022\xca\n" +
+	"\n" +
+	"\x0eExploreService\x12K\n" +
+	"\fListLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
+	"\x0fListNewLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
+	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12H\n" +
+	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponse\x12Q\n" +
+	"\x0eListLikedByYou\x12\x1e.explore.ListLikedByYouRequest\x1a\x1f.explore.ListLikedByYouResponse\x12H\n" +
+	"\vGetDecision\x12\x1b.explore.GetDecisionRequest\x1a\x1c.explore.GetDecisionResponse\x12Q\n" +
+	"\x0eDeleteDecision\x12\x1e.explore.DeleteDecisionRequest\x1a\x1f.explore.DeleteDecisionResponse\x12Z\n" +
+	"\x11BatchPutDecisions\x12!.explore.BatchPutDecisionsRequest\x1a\".explore.BatchPutDecisionsResponse\x12H\n" +
+	"\vListMatches\x12\x1b.explore.ListMatchesRequest\x1a\x1c.explore.ListMatchesResponse\x12K\n" +
+	"\fPutDecisions\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionsSummary(\x01\x12M\n" +
+	"\rWatchNewLikes\x12\x1d.explore.WatchNewLikesRequest\x1a\x1b.explore.WatchNewLikesEvent0\x01\x12N\n" +
+	"\rListPassedYou\x12\x1d.explore.ListPassedYouRequest\x1a\x1e.explore.ListPassedYouResponse\x12T\n" +
+	"\x0fCheckMutualLike\x12\x1f.explore.CheckMutualLikeRequest\x1a .explore.CheckMutualLikeResponse\x12B\n" +
+	"\tBlockUser\x12\x19.explore.BlockUserRequest\x1a\x1a.explore.BlockUserResponse\x12H\n" +
+	"\vUnblockUser\x12\x1b.explore.UnblockUserRequest\x1a\x1c.explore.UnblockUserResponse\x12E\n" +
+	"\n" +
+	"ReportUser\x12\x1a.explore.ReportUserRequest\x1a\x1b.explore.ReportUserResponse\x12T\n" +
+	"\x0fListAuditEvents\x12\x1f.explore.ListAuditEventsRequest\x1a .explore.ListAuditEventsResponseB)Z'github.com/backend-interview-task/protob\x06proto3"
 
 var (
 	file_proto_explore_proto_rawDescOnce sync.Once
@@ -413,31 +2329,98 @@ func file_proto_explore_proto_rawDescGZIP() []byte {
 	return file_proto_explore_proto_rawDescData
 }
 
-var file_proto_explore_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_explore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_explore_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_proto_explore_proto_goTypes = []any{
-	(*ListLikedYouRequest)(nil),        // 0: explore.ListLikedYouRequest
-	(*ListLikedYouResponse)(nil),       // 1: explore.ListLikedYouResponse
-	(*CountLikedYouRequest)(nil),       // 2: explore.CountLikedYouRequest
-	(*CountLikedYouResponse)(nil),      // 3: explore.CountLikedYouResponse
-	(*PutDecisionRequest)(nil),         // 4: explore.PutDecisionRequest
-	(*PutDecisionResponse)(nil),        // 5: explore.PutDecisionResponse
-	(*ListLikedYouResponse_Liker)(nil), // 6: explore.ListLikedYouResponse.Liker
+	(SortOrder)(0),                             // 0: explore.SortOrder
+	(*ListLikedYouRequest)(nil),                // 1: explore.ListLikedYouRequest
+	(*ListLikedYouResponse)(nil),               // 2: explore.ListLikedYouResponse
+	(*ListLikedByYouRequest)(nil),              // 3: explore.ListLikedByYouRequest
+	(*ListLikedByYouResponse)(nil),             // 4: explore.ListLikedByYouResponse
+	(*CountLikedYouRequest)(nil),               // 5: explore.CountLikedYouRequest
+	(*CountLikedYouResponse)(nil),              // 6: explore.CountLikedYouResponse
+	(*PutDecisionRequest)(nil),                 // 7: explore.PutDecisionRequest
+	(*PutDecisionResponse)(nil),                // 8: explore.PutDecisionResponse
+	(*GetDecisionRequest)(nil),                 // 9: explore.GetDecisionRequest
+	(*GetDecisionResponse)(nil),                // 10: explore.GetDecisionResponse
+	(*DeleteDecisionRequest)(nil),              // 11: explore.DeleteDecisionRequest
+	(*DeleteDecisionResponse)(nil),             // 12: explore.DeleteDecisionResponse
+	(*BatchPutDecisionsRequest)(nil),           // 13: explore.BatchPutDecisionsRequest
+	(*BatchPutDecisionsResponse)(nil),          // 14: explore.BatchPutDecisionsResponse
+	(*ListMatchesRequest)(nil),                 // 15: explore.ListMatchesRequest
+	(*ListMatchesResponse)(nil),                // 16: explore.ListMatchesResponse
+	(*PutDecisionsSummary)(nil),                // 17: explore.PutDecisionsSummary
+	(*WatchNewLikesRequest)(nil),               // 18: explore.WatchNewLikesRequest
+	(*WatchNewLikesEvent)(nil),                 // 19: explore.WatchNewLikesEvent
+	(*ListPassedYouRequest)(nil),               // 20: explore.ListPassedYouRequest
+	(*ListPassedYouResponse)(nil),              // 21: explore.ListPassedYouResponse
+	(*CheckMutualLikeRequest)(nil),             // 22: explore.CheckMutualLikeRequest
+	(*CheckMutualLikeResponse)(nil),            // 23: explore.CheckMutualLikeResponse
+	(*BlockUserRequest)(nil),                   // 24: explore.BlockUserRequest
+	(*BlockUserResponse)(nil),                  // 25: explore.BlockUserResponse
+	(*UnblockUserRequest)(nil),                 // 26: explore.UnblockUserRequest
+	(*UnblockUserResponse)(nil),                // 27: explore.UnblockUserResponse
+	(*ReportUserRequest)(nil),                  // 28: explore.ReportUserRequest
+	(*ReportUserResponse)(nil),                 // 29: explore.ReportUserResponse
+	(*ListAuditEventsRequest)(nil),             // 30: explore.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),            // 31: explore.ListAuditEventsResponse
+	(*ListLikedYouResponse_Liker)(nil),         // 32: explore.ListLikedYouResponse.Liker
+	(*ListLikedByYouResponse_Recipient)(nil),   // 33: explore.ListLikedByYouResponse.Recipient
+	(*BatchPutDecisionsResponse_Result)(nil),   // 34: explore.BatchPutDecisionsResponse.Result
+	(*ListMatchesResponse_Match)(nil),          // 35: explore.ListMatchesResponse.Match
+	(*ListPassedYouResponse_Passer)(nil),       // 36: explore.ListPassedYouResponse.Passer
+	(*ListAuditEventsResponse_AuditEvent)(nil), // 37: explore.ListAuditEventsResponse.AuditEvent
+	(*fieldmaskpb.FieldMask)(nil),              // 38: google.protobuf.FieldMask
 }
 var file_proto_explore_proto_depIdxs = []int32{
-	6, // 0: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
-	0, // 1: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
-	0, // 2: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
-	2, // 3: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
-	4, // 4: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
-	1, // 5: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
-	1, // 6: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
-	3, // 7: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
-	5, // 8: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: explore.ListLikedYouRequest.sort_order:type_name -> explore.SortOrder
+	38, // 1: explore.ListLikedYouRequest.read_mask:type_name -> google.protobuf.FieldMask
+	32, // 2: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
+	33, // 3: explore.ListLikedByYouResponse.recipients:type_name -> explore.ListLikedByYouResponse.Recipient
+	7,  // 4: explore.BatchPutDecisionsRequest.decisions:type_name -> explore.PutDecisionRequest
+	34, // 5: explore.BatchPutDecisionsResponse.results:type_name -> explore.BatchPutDecisionsResponse.Result
+	35, // 6: explore.ListMatchesResponse.matches:type_name -> explore.ListMatchesResponse.Match
+	36, // 7: explore.ListPassedYouResponse.passers:type_name -> explore.ListPassedYouResponse.Passer
+	37, // 8: explore.ListAuditEventsResponse.events:type_name -> explore.ListAuditEventsResponse.AuditEvent
+	1,  // 9: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
+	1,  // 10: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
+	5,  // 11: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
+	7,  // 12: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
+	3,  // 13: explore.ExploreService.ListLikedByYou:input_type -> explore.ListLikedByYouRequest
+	9,  // 14: explore.ExploreService.GetDecision:input_type -> explore.GetDecisionRequest
+	11, // 15: explore.ExploreService.DeleteDecision:input_type -> explore.DeleteDecisionRequest
+	13, // 16: explore.ExploreService.BatchPutDecisions:input_type -> explore.BatchPutDecisionsRequest
+	15, // 17: explore.ExploreService.ListMatches:input_type -> explore.ListMatchesRequest
+	7,  // 18: explore.ExploreService.PutDecisions:input_type -> explore.PutDecisionRequest
+	18, // 19: explore.ExploreService.WatchNewLikes:input_type -> explore.WatchNewLikesRequest
+	20, // 20: explore.ExploreService.ListPassedYou:input_type -> explore.ListPassedYouRequest
+	22, // 21: explore.ExploreService.CheckMutualLike:input_type -> explore.CheckMutualLikeRequest
+	24, // 22: explore.ExploreService.BlockUser:input_type -> explore.BlockUserRequest
+	26, // 23: explore.ExploreService.UnblockUser:input_type -> explore.UnblockUserRequest
+	28, // 24: explore.ExploreService.ReportUser:input_type -> explore.ReportUserRequest
+	30, // 25: explore.ExploreService.ListAuditEvents:input_type -> explore.ListAuditEventsRequest
+	2,  // 26: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
+	2,  // 27: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
+	6,  // 28: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
+	8,  // 29: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
+	4,  // 30: explore.ExploreService.ListLikedByYou:output_type -> explore.ListLikedByYouResponse
+	10, // 31: explore.ExploreService.GetDecision:output_type -> explore.GetDecisionResponse
+	12, // 32: explore.ExploreService.DeleteDecision:output_type -> explore.DeleteDecisionResponse
+	14, // 33: explore.ExploreService.BatchPutDecisions:output_type -> explore.BatchPutDecisionsResponse
+	16, // 34: explore.ExploreService.ListMatches:output_type -> explore.ListMatchesResponse
+	17, // 35: explore.ExploreService.PutDecisions:output_type -> explore.PutDecisionsSummary
+	19, // 36: explore.ExploreService.WatchNewLikes:output_type -> explore.WatchNewLikesEvent
+	21, // 37: explore.ExploreService.ListPassedYou:output_type -> explore.ListPassedYouResponse
+	23, // 38: explore.ExploreService.CheckMutualLike:output_type -> explore.CheckMutualLikeResponse
+	25, // 39: explore.ExploreService.BlockUser:output_type -> explore.BlockUserResponse
+	27, // 40: explore.ExploreService.UnblockUser:output_type -> explore.UnblockUserResponse
+	29, // 41: explore.ExploreService.ReportUser:output_type -> explore.ReportUserResponse
+	31, // 42: explore.ExploreService.ListAuditEvents:output_type -> explore.ListAuditEventsResponse
+	26, // [26:43] is the sub-list for method output_type
+	9,  // [9:26] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_explore_proto_init() }
@@ -447,18 +2430,27 @@ func file_proto_explore_proto_init() {
 	}
 	file_proto_explore_proto_msgTypes[0].OneofWrappers = []any{}
 	file_proto_explore_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[14].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[15].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[19].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[20].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[29].OneofWrappers = []any{}
+	file_proto_explore_proto_msgTypes[30].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_explore_proto_rawDesc), len(file_proto_explore_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_explore_proto_goTypes,
 		DependencyIndexes: file_proto_explore_proto_depIdxs,
+		EnumInfos:         file_proto_explore_proto_enumTypes,
 		MessageInfos:      file_proto_explore_proto_msgTypes,
 	}.Build()
 	File_proto_explore_proto = out.File
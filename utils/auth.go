@@ -0,0 +1,31 @@
+package utils
+
+import "context"
+
+// Caller is the authenticated identity a call is made on behalf of
+type Caller struct {
+	UserID string
+	// Service marks service accounts, which may act on behalf of any user
+	Service bool
+}
+
+type callerContextKey struct{}
+
+// WithCaller returns a copy of ctx made on behalf of caller
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller ctx is made on behalf of, if the call was authenticated
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(Caller)
+	return caller, ok
+}
+
+// MayActFor reports whether the call ctx belongs to may see the data of userID: the caller must
+// be that user or a service account. Calls that were not authenticated, as when authentication
+// is disabled or for background jobs, may act for anyone.
+func MayActFor(ctx context.Context, userID string) bool {
+	caller, ok := CallerFromContext(ctx)
+	return !ok || caller.Service || caller.UserID == userID
+}
@@ -1,22 +1,100 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 const (
-	LikersTTL      = 30 * time.Second
+	LikersIndexTTL = 10 * time.Minute
 	NewLikersTTL   = 20 * time.Second
-	LikersCountTTL = 15 * time.Second
+	LikersCountTTL = time.Hour
+	LikedByTTL     = 30 * time.Second
+	MatchesTTL     = 30 * time.Second
+
+	// CacheGenerationTTL is how long a user's cache generation is kept. It must outlive every
+	// listing TTL, so that no page cached before the generation expired can be read again.
+	CacheGenerationTTL = time.Hour
+
+	// EmptyResultTTL is how long an empty listing or a zero count is cached, kept short so
+	// that a user's first like shows up soon even where no write invalidates the entry
+	EmptyResultTTL = 10 * time.Second
+
+	// HotRecipientsWindow is how long requests for recipients are tallied together; the
+	// cache warmer reads the tally of the last complete window
+	HotRecipientsWindow = time.Minute
 )
 
-func LikersKey(recipient string, token string) string {
-	return fmt.Sprintf("likers:%s:%s", recipient, token)
+// EmptyCacheValue is cached in place of an empty listing, so that users without results
+// are served from the cache instead of querying the DB on every request
+const EmptyCacheValue = "-"
+
+// CacheSchemaVersion is part of every cache key. Bump it whenever the shape of a cached value
+// changes, so that a deploy reads from fresh keys instead of decoding values written by the
+// previous release; the old keys are left to expire.
+const CacheSchemaVersion = 1
+
+// cacheKeyPrefix namespaces every cache key by service and schema version
+var cacheKeyPrefix = cacheNamespace("explore")
+
+// SetCacheKeyPrefix namespaces the cache keys under service, so that several services or
+// environments can share a cache. It is meant to be called once at startup.
+func SetCacheKeyPrefix(service string) {
+	cacheKeyPrefix = cacheNamespace(service)
+}
+
+func cacheNamespace(service string) string {
+	if service == "" {
+		return fmt.Sprintf("v%d:", CacheSchemaVersion)
+	}
+	return fmt.Sprintf("%s:v%d:", service, CacheSchemaVersion)
+}
+
+// keyPrefix returns the namespace of the cache keys of the tenant ctx is scoped to
+func keyPrefix(ctx context.Context) string {
+	if tenant := TenantFromContext(ctx); tenant != "" {
+		return cacheKeyPrefix + "t:" + tenant + ":"
+	}
+	return cacheKeyPrefix
+}
+
+// userHashTag wraps a user id in a Redis Cluster hash tag. Every key of a user carries
+// their tag, so the keys of one user that are used together live in the same slot.
+func userHashTag(user string) string {
+	return "{" + user + "}"
+}
+
+func LikersIndexKey(ctx context.Context, recipient string) string {
+	return fmt.Sprintf("%slikersindex:%s", keyPrefix(ctx), userHashTag(recipient))
+}
+func LikedSetKey(ctx context.Context, actor string) string {
+	return fmt.Sprintf("%sliked:%s", keyPrefix(ctx), userHashTag(actor))
+}
+func NewLikersKey(ctx context.Context, recipient string, generation string, token string, pageSize uint32, sortOrder int32) string {
+	return fmt.Sprintf("%snewlikers:%s:%s:%s:%d:%d", keyPrefix(ctx), userHashTag(recipient), generation, token, pageSize, sortOrder)
+}
+func LikersCountKey(ctx context.Context, recipient string) string {
+	return fmt.Sprintf("%slikerscount:%s", keyPrefix(ctx), userHashTag(recipient))
+}
+func LikedByKey(ctx context.Context, actor string, generation string, token string) string {
+	return fmt.Sprintf("%slikedby:%s:%s:%s", keyPrefix(ctx), userHashTag(actor), generation, token)
+}
+func MatchesKey(ctx context.Context, user string, generation string, token string) string {
+	return fmt.Sprintf("%smatches:%s:%s:%s", keyPrefix(ctx), userHashTag(user), generation, token)
+}
+
+// CacheGenerationKey holds the generation of the user's cached listings. Every listing key of
+// the user carries it, so moving the user to a new generation invalidates all their pages at once.
+func CacheGenerationKey(ctx context.Context, user string) string {
+	return fmt.Sprintf("%sgeneration:%s", keyPrefix(ctx), userHashTag(user))
+}
+func LockKey(ctx context.Context, name string) string {
+	return fmt.Sprintf("%slock:%s", keyPrefix(ctx), name)
 }
-func NewLikersKey(recipient string, token string) string {
-	return fmt.Sprintf("newlikers:%s:%s", recipient, token)
+func RateLimitKey(ctx context.Context, caller string) string {
+	return fmt.Sprintf("%sratelimit:%s", keyPrefix(ctx), caller)
 }
-func LikersCountKey(recipient string) string {
-	return fmt.Sprintf("likerscount:%s", recipient)
+func HotRecipientsKey(ctx context.Context, window time.Time) string {
+	return fmt.Sprintf("%shotrecipients:%d", keyPrefix(ctx), window.Truncate(HotRecipientsWindow).Unix())
 }
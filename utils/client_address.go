@@ -0,0 +1,17 @@
+package utils
+
+import "context"
+
+type clientAddressContextKey struct{}
+
+// WithClientAddress returns a copy of ctx handling a request that came from addr. gRPC calls
+// carry theirs in their peer instead.
+func WithClientAddress(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, clientAddressContextKey{}, addr)
+}
+
+// ClientAddressFromContext returns the address the request ctx handles came from, if recorded
+func ClientAddressFromContext(ctx context.Context) (string, bool) {
+	addr, ok := ctx.Value(clientAddressContextKey{}).(string)
+	return addr, ok
+}
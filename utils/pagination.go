@@ -1,13 +1,56 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 )
 
+// DefaultPageSize is the page size of requests and tokens naming none, unless SetPageSizeLimits
+// configures another
+const DefaultPageSize = 20
+
+// PageSizeLimits bound the page sizes carried by pagination tokens, and give the page size of
+// requests and tokens naming none
+type PageSizeLimits struct {
+	Min     int
+	Max     int
+	Default int
+}
+
+// pageSizeLimits are the page size limits ResolveCursor applies
+var pageSizeLimits = PageSizeLimits{Min: 1, Max: 100, Default: DefaultPageSize}
+
+// SetPageSizeLimits sets the page size limits applied to every listing. It is meant to be
+// called once at startup.
+func SetPageSizeLimits(limits PageSizeLimits) {
+	pageSizeLimits = limits
+}
+
+// Cursor is the position of a page in a listing ordered by creation time in unix seconds.
+// LastID is the decision id of the last row, breaking ties between rows created in the same
+// second; it is zero when the page ended on a second that it listed in full.
+// LastCreatedAtMicros is the exact creation time of the last row in unix microseconds, which
+// the likers listings order by and seek from; it is zero in tokens issued by the likers index,
+// which only holds seconds. A Backward cursor
+// is the position of the first row of a page, and selects the page before it. A ByPopularity
+// cursor is instead the position of a page in a listing ranked by popularity score, highest
+// first, where LastScore is the score of the last row. IssuedAt is the unix second the token was
+// handed to the client, set when tokens expire.
 type Cursor struct {
-	LastCreatedAt int64
-	Limit         int
+	LastCreatedAt       int64
+	LastID              int64 `json:",omitempty"`
+	LastCreatedAtMicros int64 `json:",omitempty"`
+	Limit               int
+	Ascending           bool    `json:",omitempty"`
+	Backward            bool    `json:",omitempty"`
+	ByPopularity        bool    `json:",omitempty"`
+	LastScore           float64 `json:",omitempty"`
+	IssuedAt            int64   `json:",omitempty"`
 }
 
 func (c *Cursor) Encode() (string, error) {
@@ -35,3 +78,136 @@ func DecodeCursor(encodedCursor string) (*Cursor, error) {
 
 	return &c, nil
 }
+
+// ResolveCursor decodes the page token into a cursor and applies the requested page size,
+// falling back to the size carried by the token and then to the default page size. Sizes carried
+// by tokens are clamped to the page size limits, since unsigned tokens can be crafted; requested
+// sizes are validated by the APIs, so that internal callers may read larger pages.
+// ascending only orders the first page, later pages follow the order carried by the token.
+func ResolveCursor(token string, size int, ascending bool) (*Cursor, error) {
+	cursor, err := DecodeCursor(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor == nil {
+		cursor = &Cursor{Ascending: ascending}
+	}
+	switch {
+	case size > 0:
+		cursor.Limit = size
+	case cursor.Limit <= 0:
+		cursor.Limit = pageSizeLimits.Default
+	default:
+		cursor.Limit = min(max(cursor.Limit, pageSizeLimits.Min), pageSizeLimits.Max)
+	}
+
+	return cursor, nil
+}
+
+var (
+	// ErrInvalidPageToken rejects a pagination token that no configured key signed
+	ErrInvalidPageToken = errors.New("invalid pagination token")
+	// ErrExpiredPageToken rejects a pagination token issued longer ago than tokens live
+	ErrExpiredPageToken = errors.New("expired pagination token")
+)
+
+// pageTokenSignatureSeparator separates a token from its signature; base64 tokens never hold it
+const pageTokenSignatureSeparator = "."
+
+// PageTokenSigner signs the pagination tokens handed to clients with HMAC-SHA256, so that they
+// cannot forge the cursor a token carries. Tokens are signed with the first key and verified
+// against every key, so that keys can be rotated without invalidating the tokens in flight.
+// Without keys, tokens are passed through unsigned. With a TTL, the cursor of every token
+// issued is stamped with the time, and tokens older than the TTL are rejected, so that clients
+// restart a listing rather than page on from a stale position.
+type PageTokenSigner struct {
+	keys [][]byte
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewPageTokenSigner creates a PageTokenSigner signing with keys[0] and verifying with all keys,
+// accepting tokens for ttl after they are issued, or forever when it is zero
+func NewPageTokenSigner(keys []string, ttl time.Duration) *PageTokenSigner {
+	signer := &PageTokenSigner{keys: make([][]byte, len(keys)), ttl: ttl, now: time.Now}
+	for i, key := range keys {
+		signer.keys[i] = []byte(key)
+	}
+	return signer
+}
+
+// Sign stamps the cursor of token with the time it is issued, when tokens expire, and appends
+// its signature. The empty token of a last page stays empty.
+func (s *PageTokenSigner) Sign(token string) (string, error) {
+	if token == "" {
+		return token, nil
+	}
+	if s.ttl > 0 {
+		cursor, err := DecodeCursor(token)
+		if err != nil {
+			return "", err
+		}
+		cursor.IssuedAt = s.now().Unix()
+		if token, err = cursor.Encode(); err != nil {
+			return "", err
+		}
+	}
+	if len(s.keys) == 0 {
+		return token, nil
+	}
+	return token + pageTokenSignatureSeparator + base64.RawURLEncoding.EncodeToString(pageTokenMAC(s.keys[0], token)), nil
+}
+
+// Verify checks the signature and age of a token returned by Sign and returns the token it signs,
+// without the time it was issued
+func (s *PageTokenSigner) Verify(signed string) (string, error) {
+	if signed == "" {
+		return signed, nil
+	}
+	token, err := s.verifySignature(signed)
+	if err != nil {
+		return "", err
+	}
+	if s.ttl > 0 {
+		cursor, err := DecodeCursor(token)
+		if err != nil {
+			return "", ErrInvalidPageToken
+		}
+		if s.now().Sub(time.Unix(cursor.IssuedAt, 0)) > s.ttl {
+			return "", ErrExpiredPageToken
+		}
+		// The stamp is dropped so that the token names the same page whenever it was issued,
+		// as cached pages are keyed by it
+		cursor.IssuedAt = 0
+		return cursor.Encode()
+	}
+	return token, nil
+}
+
+// verifySignature returns the token signed by signed, when a configured key signed it
+func (s *PageTokenSigner) verifySignature(signed string) (string, error) {
+	if len(s.keys) == 0 {
+		return signed, nil
+	}
+	token, encodedMAC, ok := strings.Cut(signed, pageTokenSignatureSeparator)
+	if !ok {
+		return "", ErrInvalidPageToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return "", ErrInvalidPageToken
+	}
+	for _, key := range s.keys {
+		if hmac.Equal(mac, pageTokenMAC(key, token)) {
+			return token, nil
+		}
+	}
+	return "", ErrInvalidPageToken
+}
+
+func pageTokenMAC(key []byte, token string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(token))
+	return mac.Sum(nil)
+}
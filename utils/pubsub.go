@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// webhookTopicPrefix marks outbox topics delivered to a webhook endpoint rather than the event bus
+const webhookTopicPrefix = "webhook:"
+
+// DecisionChangesChannel is the Postgres notification channel the decisions trigger announces changes on
+const DecisionChangesChannel = "decision_changes"
+
+func NewLikesTopic(ctx context.Context, recipient string) string {
+	if tenant := TenantFromContext(ctx); tenant != "" {
+		return fmt.Sprintf("newlikes:%s:%s", tenant, recipient)
+	}
+	return fmt.Sprintf("newlikes:%s", recipient)
+}
+
+// NewEventID returns a random identifier consumers can use to drop redelivered events
+func NewEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WebhookTopic returns the outbox topic of events delivered to the webhook endpoint
+func WebhookTopic(endpoint string) string {
+	return webhookTopicPrefix + endpoint
+}
+
+// WebhookEndpoint returns the endpoint a webhook topic delivers to, or false for other topics
+func WebhookEndpoint(topic string) (string, bool) {
+	return strings.CutPrefix(topic, webhookTopicPrefix)
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the metadata key and HTTP header carrying the request ID across services
+const RequestIDHeader = "x-request-id"
+
+// requestIDPattern is what an incoming request ID may look like to be adopted rather than replaced
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of ctx handling the request identified by id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the ID of the request ctx handles, or "" outside of one
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// RequestID returns incoming if it is usable as a request ID, or a newly generated one
+func RequestID(incoming string) string {
+	if requestIDPattern.MatchString(incoming) {
+		return incoming
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Logger returns logger annotated with the ID of the request ctx handles, if any
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return logger.With(zap.String("request_id", id))
+	}
+	return logger
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// tenantPattern is what a tenant id may look like; it ends up in schema names and cache keys
+var tenantPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of ctx scoped to tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to, or "" when it is not scoped to one
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// ValidTenant reports whether tenant is usable as a tenant id
+func ValidTenant(tenant string) bool {
+	return tenantPattern.MatchString(tenant)
+}
+
+// TenantSchema returns the Postgres schema holding the data of tenant
+func TenantSchema(tenant string) string {
+	return "tenant_" + tenant
+}
+
+// TenantOfSchema returns the tenant whose data lives in schema, if it is a tenant schema
+func TenantOfSchema(schema string) (string, bool) {
+	tenant, ok := strings.CutPrefix(schema, "tenant_")
+	return tenant, ok && ValidTenant(tenant)
+}